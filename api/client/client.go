@@ -7,10 +7,13 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/alecthomas/jsonschema"
 	ma "github.com/multiformats/go-multiaddr"
 	pb "github.com/textileio/go-threads/api/pb"
+	core "github.com/textileio/go-threads/core/db"
 	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/db"
 	"google.golang.org/grpc"
@@ -57,6 +60,9 @@ type ListenOption struct {
 	Type       ListenActionType
 	Collection string
 	InstanceID string
+	// Query only matches updates of instances matching it, which are
+	// evaluated by the server.
+	Query *db.Query
 }
 
 // ListenEvent is used to send data or error values for Listen.
@@ -186,7 +192,8 @@ func (c *Client) NewDB(ctx context.Context, dbID thread.ID, opts ...db.NewManage
 	return err
 }
 
-// NewDBFromAddr creates a new DB with address and keys.
+// NewDBFromAddr creates a new DB with address and keys. The progress of
+// WithNewManagedProgress is streamed from the daemon until the DB is created.
 func (c *Client) NewDBFromAddr(ctx context.Context, dbAddr ma.Multiaddr, dbKey thread.Key, opts ...db.NewManagedOption) error {
 	args := &db.NewManagedOptions{}
 	for _, opt := range opts {
@@ -209,23 +216,47 @@ func (c *Client) NewDBFromAddr(ctx context.Context, dbAddr ma.Multiaddr, dbKey t
 		}
 	}
 	ctx = thread.NewTokenContext(ctx, args.Token)
-	_, err := c.c.NewDBFromAddr(ctx, &pb.NewDBFromAddrRequest{
+	req := &pb.NewDBFromAddrRequest{
 		Addr:        dbAddr.Bytes(),
 		Key:         dbKey.Bytes(),
 		LogKey:      logKey,
 		Name:        args.Name,
 		Collections: pbcollections,
 		Block:       args.Block,
-	})
-	return err
+	}
+	if args.Progress == nil {
+		_, err := c.c.NewDBFromAddr(ctx, req)
+		return err
+	}
+	stream, err := c.c.NewDBFromAddrWithProgress(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		rep, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		args.Progress(db.SyncProgress{
+			Stage:        db.SyncStage(rep.Stage),
+			Logs:         int(rep.Logs),
+			Records:      int(rep.Records),
+			TotalRecords: int(rep.TotalRecords),
+			Events:       int(rep.Events),
+		})
+	}
 }
 
 func collectionConfigToPb(c db.CollectionConfig) (*pb.CollectionConfig, error) {
 	idx := make([]*pb.Index, len(c.Indexes))
 	for i, index := range c.Indexes {
 		idx[i] = &pb.Index{
-			Path:   index.Path,
-			Unique: index.Unique,
+			Path:     index.Path,
+			Unique:   index.Unique,
+			FullText: index.FullText,
+			Fields:   index.Fields,
 		}
 	}
 	schemaBytes, err := json.Marshal(c.Schema)
@@ -238,6 +269,9 @@ func collectionConfigToPb(c db.CollectionConfig) (*pb.CollectionConfig, error) {
 		Indexes:        idx,
 		WriteValidator: c.WriteValidator,
 		ReadFilter:     c.ReadFilter,
+		SoftDelete:     c.SoftDelete,
+		ExpiryField:    c.ExpiryField,
+		ApplyDefaults:  c.ApplyDefaults,
 	}, nil
 }
 
@@ -268,6 +302,48 @@ func (c *Client) ListDBs(ctx context.Context, opts ...db.ManagedOption) (map[thr
 	return dbs, nil
 }
 
+// ListDBsPage lists a page of the metadata of the dbs, without their info.
+func (c *Client) ListDBsPage(ctx context.Context, q db.ListDBsQuery, opts ...db.ManagedOption) (*db.ListDBsResult, error) {
+	args := &db.ManagedOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	res, err := c.c.ListDBs(ctx, &pb.ListDBsRequest{
+		NamePrefix: q.NamePrefix,
+		Cursor:     q.Cursor,
+		Limit:      int64(q.Limit),
+		SkipInfo:   true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &db.ListDBsResult{
+		DBs:    make([]db.DBMetadata, len(res.Dbs)),
+		Cursor: res.Cursor,
+	}
+	for i, d := range res.Dbs {
+		id, err := thread.Cast(d.DbID)
+		if err != nil {
+			return nil, err
+		}
+		md := db.DBMetadata{
+			ID:          id,
+			Name:        d.Name,
+			Collections: d.Collections,
+		}
+		if d.Created != 0 {
+			md.Created = time.Unix(0, d.Created)
+		}
+		if d.LastModified != 0 {
+			md.LastModified = time.Unix(0, d.LastModified)
+		}
+		page.DBs[i] = md
+	}
+	return page, nil
+}
+
 // GetDBInfo retrives db addresses and keys.
 func (c *Client) GetDBInfo(ctx context.Context, dbID thread.ID, opts ...db.ManagedOption) (db.Info, error) {
 	args := &db.ManagedOptions{}
@@ -330,7 +406,9 @@ func (c *Client) NewCollection(ctx context.Context, dbID thread.ID, config db.Co
 	return err
 }
 
-// UpdateCollection updates an existing collection.
+// UpdateCollection updates an existing collection. The existing instances
+// must be valid against the new schema, unless validation is skipped with
+// db.WithManagedSkipValidation.
 func (c *Client) UpdateCollection(ctx context.Context, dbID thread.ID, config db.CollectionConfig, opts ...db.ManagedOption) error {
 	args := &db.ManagedOptions{}
 	for _, opt := range opts {
@@ -341,10 +419,40 @@ func (c *Client) UpdateCollection(ctx context.Context, dbID thread.ID, config db
 		return err
 	}
 	_, err = c.c.UpdateCollection(ctx, &pb.UpdateCollectionRequest{
+		DbID:           dbID.Bytes(),
+		Config:         cc,
+		SkipValidation: args.SkipValidation,
+	})
+	return err
+}
+
+// CheckCollectionUpdate reports the instances of a collection which aren't
+// valid against the schema of the new config, without changing the collection.
+func (c *Client) CheckCollectionUpdate(ctx context.Context, dbID thread.ID, config db.CollectionConfig, opts ...db.ManagedOption) (db.CollectionUpdateReport, error) {
+	args := &db.ManagedOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	cc, err := collectionConfigToPb(config)
+	if err != nil {
+		return db.CollectionUpdateReport{}, err
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	resp, err := c.c.CheckCollectionUpdate(ctx, &pb.CheckCollectionUpdateRequest{
 		DbID:   dbID.Bytes(),
 		Config: cc,
 	})
-	return err
+	if err != nil {
+		return db.CollectionUpdateReport{}, err
+	}
+	report := db.CollectionUpdateReport{
+		Incompatible: int(resp.Incompatible),
+		Pointers:     resp.Pointers,
+	}
+	for _, id := range resp.SampleIDs {
+		report.SampleIDs = append(report.SampleIDs, core.InstanceID(id))
+	}
+	return report, nil
 }
 
 // DeleteCollection deletes a collection.
@@ -360,6 +468,21 @@ func (c *Client) DeleteCollection(ctx context.Context, dbID thread.ID, name stri
 	return err
 }
 
+// RenameCollection renames a collection, keeping its instances.
+func (c *Client) RenameCollection(ctx context.Context, dbID thread.ID, name, newName string, opts ...db.ManagedOption) error {
+	args := &db.ManagedOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	_, err := c.c.RenameCollection(ctx, &pb.RenameCollectionRequest{
+		DbID:    dbID.Bytes(),
+		Name:    name,
+		NewName: newName,
+	})
+	return err
+}
+
 // GetCollectionInfo returns information about an existing collection.
 func (c *Client) GetCollectionInfo(ctx context.Context, dbID thread.ID, name string, opts ...db.ManagedOption) (db.CollectionConfig, error) {
 	args := &db.ManagedOptions{}
@@ -384,6 +507,9 @@ func (c *Client) GetCollectionInfo(ctx context.Context, dbID thread.ID, name str
 		Indexes:        indexesFromPb(resp.Indexes),
 		WriteValidator: resp.WriteValidator,
 		ReadFilter:     resp.ReadFilter,
+		SoftDelete:     resp.SoftDelete,
+		ExpiryField:    resp.ExpiryField,
+		ApplyDefaults:  resp.ApplyDefaults,
 	}, nil
 }
 
@@ -391,8 +517,10 @@ func indexesFromPb(pbindexes []*pb.Index) []db.Index {
 	indexes := make([]db.Index, len(pbindexes))
 	for i, index := range pbindexes {
 		indexes[i] = db.Index{
-			Path:   index.Path,
-			Unique: index.Unique,
+			Path:     index.Path,
+			Unique:   index.Unique,
+			FullText: index.FullText,
+			Fields:   index.Fields,
 		}
 	}
 	return indexes
@@ -440,11 +568,82 @@ func (c *Client) ListCollections(ctx context.Context, dbID thread.ID, opts ...db
 			Indexes:        indexesFromPb(c.Indexes),
 			WriteValidator: c.WriteValidator,
 			ReadFilter:     c.ReadFilter,
+			SoftDelete:     c.SoftDelete,
+			ExpiryField:    c.ExpiryField,
+			ApplyDefaults:  c.ApplyDefaults,
 		}
 	}
 	return list, nil
 }
 
+// GetStats returns the stats of a db and of each of its collections.
+func (c *Client) GetStats(ctx context.Context, dbID thread.ID, opts ...db.ManagedOption) (db.Stats, error) {
+	args := &db.ManagedOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	resp, err := c.c.GetStats(ctx, &pb.GetStatsRequest{
+		DbID: dbID.Bytes(),
+	})
+	if err != nil {
+		return db.Stats{}, err
+	}
+	stats := db.Stats{
+		Instances:     resp.Instances,
+		InstanceBytes: resp.InstanceBytes,
+		IndexEntries:  resp.IndexEntries,
+		IndexBytes:    resp.IndexBytes,
+		Dispatcher:    db.LogStats{Entries: resp.Dispatcher.GetEntries(), Bytes: resp.Dispatcher.GetBytes()},
+		ActionLog:     db.LogStats{Entries: resp.ActionLog.GetEntries(), Bytes: resp.ActionLog.GetBytes()},
+	}
+	for _, cs := range resp.Collections {
+		stats.Collections = append(stats.Collections, collectionStatsFromPb(cs))
+	}
+	return stats, nil
+}
+
+// GetCollectionStats returns the stats of a collection.
+func (c *Client) GetCollectionStats(ctx context.Context, dbID thread.ID, name string, opts ...db.ManagedOption) (db.CollectionStats, error) {
+	args := &db.ManagedOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	resp, err := c.c.GetCollectionStats(ctx, &pb.GetCollectionStatsRequest{
+		DbID: dbID.Bytes(),
+		Name: name,
+	})
+	if err != nil {
+		return db.CollectionStats{}, err
+	}
+	return collectionStatsFromPb(resp.Stats), nil
+}
+
+// GC collects the garbage of the stores of the dbs and of the network of the
+// daemon, returning the bytes reclaimed from each.
+func (c *Client) GC(ctx context.Context) (dbBytes, netBytes uint64, err error) {
+	resp, err := c.c.GC(ctx, &pb.GCRequest{})
+	if err != nil {
+		return 0, 0, err
+	}
+	return resp.DbBytes, resp.NetBytes, nil
+}
+
+func collectionStatsFromPb(cs *pb.CollectionStats) db.CollectionStats {
+	stats := db.CollectionStats{
+		Name:          cs.GetName(),
+		Instances:     cs.GetInstances(),
+		InstanceBytes: cs.GetInstanceBytes(),
+		IndexEntries:  cs.GetIndexEntries(),
+		IndexBytes:    cs.GetIndexBytes(),
+	}
+	if cs.GetLastWrite() != 0 {
+		stats.LastWrite = time.Unix(0, cs.GetLastWrite())
+	}
+	return stats
+}
+
 // Create creates new instances of objects.
 func (c *Client) Create(ctx context.Context, dbID thread.ID, collectionName string, instances Instances, opts ...db.TxnOption) ([]string, error) {
 	args := &db.TxnOptions{}
@@ -462,7 +661,7 @@ func (c *Client) Create(ctx context.Context, dbID thread.ID, collectionName stri
 		Instances:      values,
 	})
 	if err != nil {
-		return nil, err
+		return nil, uniqueError(err)
 	}
 	return resp.GetInstanceIDs(), nil
 }
@@ -502,7 +701,32 @@ func (c *Client) Save(ctx context.Context, dbID thread.ID, collectionName string
 		CollectionName: collectionName,
 		Instances:      values,
 	})
-	return err
+	return uniqueError(err)
+}
+
+// SaveExpecting saves an instance if the stored one has the version, which is
+// the value of its _mod field, or 0 if it shouldn't exist. Otherwise it fails
+// with a *db.StaleInstanceError holding the stored instance.
+func (c *Client) SaveExpecting(ctx context.Context, dbID thread.ID, collectionName string, instance interface{}, version int64, opts ...db.TxnOption) error {
+	args := &db.TxnOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	values, err := marshalItems([]interface{}{instance})
+	if err != nil {
+		return err
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	resp, err := c.c.SaveExpecting(ctx, &pb.SaveExpectingRequest{
+		DbID:           dbID.Bytes(),
+		CollectionName: collectionName,
+		Instance:       values[0],
+		Version:        version,
+	})
+	if err != nil {
+		return uniqueError(err)
+	}
+	return processSaveExpectingReply(resp)
 }
 
 // Delete deletes data.
@@ -560,6 +784,204 @@ func (c *Client) Find(ctx context.Context, dbID thread.ID, collectionName string
 	return processFindReply(resp, dummy)
 }
 
+// FindWithCursor queries a collection like Find, and also returns a cursor
+// to the next page of results if the query is limited and there are more.
+// The next page is found with query.After.
+func (c *Client) FindWithCursor(ctx context.Context, dbID thread.ID, collectionName string, query *db.Query, dummy interface{}, opts ...db.TxnOption) (interface{}, string, error) {
+	args := &db.TxnOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return nil, "", err
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	resp, err := c.c.Find(ctx, &pb.FindRequest{
+		DbID:           dbID.Bytes(),
+		CollectionName: collectionName,
+		QueryJSON:      queryBytes,
+	})
+	if err != nil {
+		return nil, "", cursorError(err)
+	}
+	instances, err := processFindReply(resp, dummy)
+	if err != nil {
+		return nil, "", err
+	}
+	return instances, resp.GetCursor(), nil
+}
+
+// FindResult is a page of the results of a query found with FindPage.
+type FindResult struct {
+	// Instances are the results of the page, as a slice of the dummy type.
+	Instances interface{}
+	// Cursor is the cursor to the next page, if there are more results.
+	Cursor string
+	// Total is the number of instances matching the query, if it has Total
+	// set.
+	Total int
+	// Explain describes how the results were found, if the query has
+	// Explain set.
+	Explain *db.ExplainResult
+}
+
+// FindPage queries a collection like FindWithCursor, and also returns the
+// number of instances matching the query if it has Total set, and how the
+// results were found if it has Explain set.
+func (c *Client) FindPage(ctx context.Context, dbID thread.ID, collectionName string, query *db.Query, dummy interface{}, opts ...db.TxnOption) (*FindResult, error) {
+	args := &db.TxnOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	resp, err := c.c.Find(ctx, &pb.FindRequest{
+		DbID:           dbID.Bytes(),
+		CollectionName: collectionName,
+		QueryJSON:      queryBytes,
+	})
+	if err != nil {
+		return nil, cursorError(err)
+	}
+	instances, err := processFindReply(resp, dummy)
+	if err != nil {
+		return nil, err
+	}
+	res := &FindResult{Instances: instances, Cursor: resp.GetCursor(), Total: int(resp.GetTotal())}
+	if e := resp.GetExplain(); e != nil {
+		res.Explain = &db.ExplainResult{
+			Index:          e.Index,
+			IndexKeys:      int(e.IndexKeys),
+			InstancesRead:  int(e.InstancesRead),
+			Matched:        int(e.Matched),
+			SortedInMemory: e.SortedInMemory,
+			PlanTime:       time.Duration(e.PlanNanos),
+			ReadTime:       time.Duration(e.ReadNanos),
+			SortTime:       time.Duration(e.SortNanos),
+			Indexes:        e.Indexes,
+		}
+	}
+	return res, nil
+}
+
+// FindIter queries a collection like Find, returning an iterator over the
+// instances, which are streamed as they're read. The stream is paced by the
+// iterator, and ended once it's closed.
+func (c *Client) FindIter(ctx context.Context, dbID thread.ID, collectionName string, query *db.Query, opts ...db.TxnOption) (db.Iterator, error) {
+	args := &db.TxnOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(thread.NewTokenContext(ctx, args.Token))
+	stream, err := c.c.FindStream(ctx, &pb.FindRequest{
+		DbID:           dbID.Bytes(),
+		CollectionName: collectionName,
+		QueryJSON:      queryBytes,
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &findIterator{stream: stream, cancel: cancel}, nil
+}
+
+// findIterator iterates over the instances of a find stream.
+type findIterator struct {
+	stream pb.API_FindStreamClient
+	cancel context.CancelFunc
+	value  []byte
+	err    error
+	done   bool
+}
+
+func (i *findIterator) Next() bool {
+	i.value = nil
+	if i.done {
+		return false
+	}
+	rep, err := i.stream.Recv()
+	if err != nil {
+		i.done = true
+		if err != io.EOF {
+			i.err = cursorError(err)
+		}
+		return false
+	}
+	i.value = rep.Instance
+	return true
+}
+
+func (i *findIterator) Value() []byte {
+	return i.value
+}
+
+func (i *findIterator) Err() error {
+	return i.err
+}
+
+func (i *findIterator) Close() {
+	i.done = true
+	i.cancel()
+}
+
+// Count returns the number of instances matching the query.
+func (c *Client) Count(ctx context.Context, dbID thread.ID, collectionName string, query *db.Query, opts ...db.TxnOption) (int, error) {
+	args := &db.TxnOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return 0, err
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	resp, err := c.c.Count(ctx, &pb.CountRequest{
+		DbID:           dbID.Bytes(),
+		CollectionName: collectionName,
+		QueryJSON:      queryBytes,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(resp.GetCount()), nil
+}
+
+// ValidateCollection checks every instance of a collection against its schema
+// and indexes, and returns the violations found, up to limit if it's positive.
+func (c *Client) ValidateCollection(ctx context.Context, dbID thread.ID, collectionName string, limit int, opts ...db.TxnOption) ([]db.ValidationError, error) {
+	args := &db.TxnOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	resp, err := c.c.ValidateCollection(ctx, &pb.ValidateCollectionRequest{
+		DbID:           dbID.Bytes(),
+		CollectionName: collectionName,
+		Limit:          int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	errs := make([]db.ValidationError, len(resp.Errors))
+	for i, e := range resp.Errors {
+		errs[i] = db.ValidationError{
+			InstanceID: core.InstanceID(e.InstanceID),
+			Field:      e.Field,
+			Message:    e.Message,
+			Legacy:     e.Legacy,
+		}
+	}
+	return errs, nil
+}
+
 // FindByID finds an instance by id.
 func (c *Client) FindByID(ctx context.Context, dbID thread.ID, collectionName, instanceID string, instance interface{}, opts ...db.TxnOption) error {
 	args := &db.TxnOptions{}
@@ -578,6 +1000,25 @@ func (c *Client) FindByID(ctx context.Context, dbID thread.ID, collectionName, i
 	return json.Unmarshal(resp.GetInstance(), instance)
 }
 
+// FindByIDs finds the instances of the ids in a single request, in the order
+// of the ids. The instances which don't exist are nil.
+func (c *Client) FindByIDs(ctx context.Context, dbID thread.ID, collectionName string, instanceIDs []string, opts ...db.TxnOption) ([][]byte, error) {
+	args := &db.TxnOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	resp, err := c.c.FindByIDs(ctx, &pb.FindByIDsRequest{
+		DbID:           dbID.Bytes(),
+		CollectionName: collectionName,
+		InstanceIDs:    instanceIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return processFindByIDsReply(resp)
+}
+
 // ReadTransaction returns a read transaction that can be started and used and ended.
 func (c *Client) ReadTransaction(ctx context.Context, dbID thread.ID, collectionName string, opts ...db.TxnOption) (*ReadTransaction, error) {
 	args := &db.TxnOptions{}
@@ -641,6 +1082,13 @@ func (c *Client) Listen(ctx context.Context, dbID thread.ID, listenOptions []Lis
 			InstanceID:     listenOption.InstanceID,
 			Action:         action,
 		}
+		if listenOption.Query != nil {
+			queryBytes, err := json.Marshal(listenOption.Query)
+			if err != nil {
+				return nil, err
+			}
+			filters[i].QueryJSON = queryBytes
+		}
 	}
 	ctx = thread.NewTokenContext(ctx, args.Token)
 	stream, err := c.c.Listen(ctx, &pb.ListenRequest{
@@ -707,6 +1155,40 @@ func processFindReply(reply *pb.FindReply, dummy interface{}) (interface{}, erro
 	return results.Interface(), nil
 }
 
+func processFindByIDsReply(reply *pb.FindByIDsReply) ([][]byte, error) {
+	if err := txnError(reply.TransactionError); err != nil {
+		return nil, err
+	}
+	found := reply.GetFound()
+	if len(found) != len(reply.GetInstances()) {
+		return nil, fmt.Errorf("got %d instances and %d found flags", len(reply.GetInstances()), len(found))
+	}
+	instances := make([][]byte, len(found))
+	for i, instance := range reply.GetInstances() {
+		if found[i] {
+			instances[i] = instance
+		}
+	}
+	return instances, nil
+}
+
+func processSaveExpectingReply(reply *pb.SaveExpectingReply) error {
+	if err := txnError(reply.TransactionError); err != nil {
+		return err
+	}
+	if !reply.Stale {
+		return nil
+	}
+	stale := &db.StaleInstanceError{
+		InstanceID: core.InstanceID(reply.InstanceID),
+		Version:    reply.Version,
+	}
+	if len(reply.Current) > 0 {
+		stale.Current = reply.Current
+	}
+	return stale
+}
+
 func marshalItems(items []interface{}) ([][]byte, error) {
 	values := make([][]byte, len(items))
 	for i, item := range items {
@@ -719,6 +1201,41 @@ func marshalItems(items []interface{}) ([][]byte, error) {
 	return values, nil
 }
 
+// cursorError returns the db error for a rejected query cursor.
+func cursorError(err error) error {
+	stat := status.Convert(err)
+	if stat.Code() != codes.InvalidArgument {
+		return err
+	}
+	for _, e := range []error{db.ErrInvalidCursor, db.ErrCursorMismatch} {
+		if stat.Message() == e.Error() {
+			return e
+		}
+	}
+	return err
+}
+
+// uniqueErrorSeparator separates the path and instance ID of a unique
+// constraint violation in its message.
+const uniqueErrorSeparator = " is already set by instance "
+
+// uniqueError returns the db error for a write rejected by a unique index.
+func uniqueError(err error) error {
+	stat := status.Convert(err)
+	if err == nil || stat.Code() != codes.AlreadyExists {
+		return err
+	}
+	msg := strings.TrimPrefix(stat.Message(), db.ErrUniqueExists.Error()+": ")
+	i := strings.LastIndex(msg, uniqueErrorSeparator)
+	if i < 0 || msg == stat.Message() {
+		return err
+	}
+	return &db.ErrUniqueConstraintViolation{
+		Path:       msg[:i],
+		InstanceID: core.InstanceID(msg[i+len(uniqueErrorSeparator):]),
+	}
+}
+
 func txnError(s string) (err error) {
 	if s != "" {
 		err = errors.New(s)
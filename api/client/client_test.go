@@ -25,6 +25,7 @@ import (
 	. "github.com/textileio/go-threads/api/client"
 	pb "github.com/textileio/go-threads/api/pb"
 	"github.com/textileio/go-threads/common"
+	core "github.com/textileio/go-threads/core/db"
 	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/db"
 	"github.com/textileio/go-threads/util"
@@ -106,6 +107,26 @@ func TestClient_NewDBFromAddr(t *testing.T) {
 			t.Fatalf("failed to create new db from address: %v", err)
 		}
 	})
+
+	t.Run("test new db from address with progress", func(t *testing.T) {
+		client3, done3 := setup(t)
+		defer done3()
+		var stages []db.SyncStage
+		if err = client3.NewDBFromAddr(
+			context.Background(),
+			info.Addrs[0],
+			info.Key,
+			db.WithNewManagedBackfillBlock(true),
+			db.WithNewManagedProgress(func(p db.SyncProgress) {
+				stages = append(stages, p.Stage)
+			}),
+		); err != nil {
+			t.Fatalf("failed to create new db from address: %v", err)
+		}
+		if len(stages) == 0 || stages[0] != db.SyncThreadAdded || stages[len(stages)-1] != db.SyncDone {
+			t.Fatalf("expected progress from thread added to done, got: %v", stages)
+		}
+	})
 }
 
 func TestClient_ListDBs(t *testing.T) {
@@ -139,6 +160,33 @@ func TestClient_ListDBs(t *testing.T) {
 	})
 }
 
+func TestClient_ListDBsPage(t *testing.T) {
+	t.Parallel()
+	client, done := setup(t)
+	defer done()
+
+	t.Run("test list dbs page", func(t *testing.T) {
+		for _, name := range []string{"app-1", "app-2", "other"} {
+			err := client.NewDB(context.Background(), thread.NewIDV1(thread.Raw, 32), db.WithNewManagedName(name))
+			checkErr(t, err)
+		}
+
+		page, err := client.ListDBsPage(context.Background(), db.ListDBsQuery{NamePrefix: "app-", Limit: 1})
+		checkErr(t, err)
+		if len(page.DBs) != 1 || page.Cursor == "" {
+			t.Fatalf("expected a page of 1 db and a cursor, got %d dbs", len(page.DBs))
+		}
+		page, err = client.ListDBsPage(context.Background(), db.ListDBsQuery{NamePrefix: "app-", Cursor: page.Cursor})
+		checkErr(t, err)
+		if len(page.DBs) != 1 || page.Cursor != "" {
+			t.Fatalf("expected the last page of 1 db, got %d dbs", len(page.DBs))
+		}
+		if page.DBs[0].Created.IsZero() {
+			t.Fatal("expected the creation time of the db")
+		}
+	})
+}
+
 func TestClient_GetDBInfo(t *testing.T) {
 	t.Parallel()
 	client, done := setup(t)
@@ -231,6 +279,17 @@ func TestClient_UpdateCollection(t *testing.T) {
 		_, err = client.Create(context.Background(), id, collectionName, Instances{createPerson2()})
 		checkErr(t, err)
 	})
+
+	t.Run("test check collection update", func(t *testing.T) {
+		report, err := client.CheckCollectionUpdate(context.Background(), id, db.CollectionConfig{
+			Name:   collectionName,
+			Schema: util.SchemaFromSchemaString(schema2),
+		})
+		checkErr(t, err)
+		if report.Incompatible != 0 {
+			t.Fatalf("expected no incompatible instances, got %v", report)
+		}
+	})
 }
 
 func TestClient_DeleteCollection(t *testing.T) {
@@ -359,6 +418,53 @@ func TestClient_ListCollections(t *testing.T) {
 	})
 }
 
+func TestClient_GC(t *testing.T) {
+	t.Parallel()
+	client, done := setup(t)
+	defer done()
+
+	t.Run("test gc", func(t *testing.T) {
+		id := thread.NewIDV1(thread.Raw, 32)
+		err := client.NewDB(context.Background(), id)
+		checkErr(t, err)
+		_, _, err = client.GC(context.Background())
+		checkErr(t, err)
+	})
+}
+
+func TestClient_GetStats(t *testing.T) {
+	t.Parallel()
+	client, done := setup(t)
+	defer done()
+
+	t.Run("test get stats", func(t *testing.T) {
+		id := thread.NewIDV1(thread.Raw, 32)
+		err := client.NewDB(context.Background(), id)
+		checkErr(t, err)
+		err = client.NewCollection(context.Background(), id, db.CollectionConfig{
+			Name:   collectionName,
+			Schema: util.SchemaFromSchemaString(schema),
+		})
+		checkErr(t, err)
+		_, err = client.Create(context.Background(), id, collectionName, Instances{createPerson(), createPerson()})
+		checkErr(t, err)
+
+		cs, err := client.GetCollectionStats(context.Background(), id, collectionName)
+		checkErr(t, err)
+		if cs.Name != collectionName || cs.Instances != 2 || cs.LastWrite.IsZero() {
+			t.Fatalf("expected the stats of 2 instances, but got %+v", cs)
+		}
+		stats, err := client.GetStats(context.Background(), id)
+		checkErr(t, err)
+		if len(stats.Collections) != 1 || stats.Instances != 2 || stats.Dispatcher.Entries != 2 {
+			t.Fatalf("expected the stats of the db, but got %+v", stats)
+		}
+		if _, err := client.GetCollectionStats(context.Background(), id, "missing"); err == nil {
+			t.Fatal("expected the stats of a missing collection to fail")
+		}
+	})
+}
+
 func TestClient_Create(t *testing.T) {
 	t.Parallel()
 	client, done := setup(t)
@@ -404,6 +510,29 @@ func TestClient_Create(t *testing.T) {
 			t.Fatal("expected a new id, got none")
 		}
 	})
+
+	t.Run("test collection create with unique index violation", func(t *testing.T) {
+		id := thread.NewIDV1(thread.Raw, 32)
+		err := client.NewDB(context.Background(), id)
+		checkErr(t, err)
+		err = client.NewCollection(context.Background(), id, db.CollectionConfig{
+			Name:    collectionName,
+			Schema:  util.SchemaFromSchemaString(schema),
+			Indexes: []db.Index{{Path: "lastName", Unique: true}},
+		})
+		checkErr(t, err)
+
+		ids, err := client.Create(context.Background(), id, collectionName, Instances{createPerson()})
+		checkErr(t, err)
+		_, err = client.Create(context.Background(), id, collectionName, Instances{createPerson()})
+		var violation *db.ErrUniqueConstraintViolation
+		if !errors.As(err, &violation) {
+			t.Fatalf("expected a unique constraint violation, got: %v", err)
+		}
+		if violation.Path != "lastName" || violation.InstanceID.String() != ids[0] {
+			t.Fatalf("expected a violation of lastName by %s, got: %+v", ids[0], violation)
+		}
+	})
 }
 
 func TestClient_Verify(t *testing.T) {
@@ -483,6 +612,72 @@ func TestClient_Save(t *testing.T) {
 	})
 }
 
+func TestClient_SaveExpecting(t *testing.T) {
+	t.Parallel()
+	client, done := setup(t)
+	defer done()
+
+	t.Run("test collection save expecting", func(t *testing.T) {
+		id := thread.NewIDV1(thread.Raw, 32)
+		err := client.NewDB(context.Background(), id)
+		checkErr(t, err)
+		err = client.NewCollection(
+			context.Background(),
+			id,
+			db.CollectionConfig{Name: collectionName, Schema: util.SchemaFromSchemaString(schema)},
+		)
+		checkErr(t, err)
+
+		person := createPerson()
+		ids, err := client.Create(context.Background(), id, collectionName, Instances{person})
+		checkErr(t, err)
+		person.ID = ids[0]
+		version := func() int64 {
+			found, err := client.FindByIDs(context.Background(), id, collectionName, []string{person.ID})
+			checkErr(t, err)
+			var v struct {
+				Mod int64 `json:"_mod"`
+			}
+			checkErr(t, json.Unmarshal(found[0], &v))
+			return v.Mod
+		}
+		read := version()
+
+		person.Age = 30
+		err = client.SaveExpecting(context.Background(), id, collectionName, person, read)
+		if err != nil {
+			t.Fatalf("failed to save instance: %v", err)
+		}
+		saved := version()
+
+		person.Age = 31
+		err = client.SaveExpecting(context.Background(), id, collectionName, person, read)
+		var stale *db.StaleInstanceError
+		if !errors.As(err, &stale) || !errors.Is(err, db.ErrStaleInstance) {
+			t.Fatalf("expected error %v, got %v", db.ErrStaleInstance, err)
+		}
+		current := &Person{}
+		checkErr(t, json.Unmarshal(stale.Current, current))
+		if stale.InstanceID != core.InstanceID(person.ID) || stale.Version != saved || current.Age != 30 {
+			t.Fatalf("expected the stored instance in the error, got %v", stale)
+		}
+
+		txn, err := client.WriteTransaction(context.Background(), id, collectionName)
+		checkErr(t, err)
+		end, err := txn.Start()
+		checkErr(t, err)
+		err = txn.SaveExpecting(person, read)
+		if !errors.As(err, &stale) || stale.Version != saved {
+			t.Fatalf("expected error %v in txn, got %v", db.ErrStaleInstance, err)
+		}
+		checkErr(t, txn.SaveExpecting(person, saved))
+		checkErr(t, end())
+		if v := version(); v <= saved {
+			t.Fatalf("expected a version after %d, got %d", saved, v)
+		}
+	})
+}
+
 func TestClient_Delete(t *testing.T) {
 	t.Parallel()
 	client, done := setup(t)
@@ -582,6 +777,137 @@ func TestClient_Find(t *testing.T) {
 		if !reflect.DeepEqual(results[0], person) {
 			t.Fatal("collection found by query does't equal the original")
 		}
+
+		it, err := client.FindIter(context.Background(), id, collectionName, q)
+		if err != nil {
+			t.Fatalf("failed to find: %v", err)
+		}
+		defer it.Close()
+		var streamed []*Person
+		for it.Next() {
+			p := &Person{}
+			util.InstanceFromJSON(it.Value(), p)
+			streamed = append(streamed, p)
+		}
+		checkErr(t, it.Err())
+		if len(streamed) != 1 || !reflect.DeepEqual(streamed[0], person) {
+			t.Fatalf("expected the streamed result to equal the original, got %v", streamed)
+		}
+	})
+}
+
+func TestClient_Count(t *testing.T) {
+	t.Parallel()
+	client, done := setup(t)
+	defer done()
+
+	t.Run("test collection count", func(t *testing.T) {
+		id := thread.NewIDV1(thread.Raw, 32)
+		err := client.NewDB(context.Background(), id)
+		checkErr(t, err)
+		err = client.NewCollection(
+			context.Background(),
+			id,
+			db.CollectionConfig{Name: collectionName, Schema: util.SchemaFromSchemaString(schema)},
+		)
+		checkErr(t, err)
+
+		person1 := createPerson()
+		person2 := createPerson()
+		person2.LastName = "Dowe"
+		_, err = client.Create(context.Background(), id, collectionName, Instances{person1, person2})
+		checkErr(t, err)
+
+		count, err := client.Count(context.Background(), id, collectionName, &db.Query{})
+		if err != nil {
+			t.Fatalf("failed to count: %v", err)
+		}
+		if count != 2 {
+			t.Fatalf("expected count 2, but got %v", count)
+		}
+		count, err = client.Count(context.Background(), id, collectionName, db.Where("lastName").Eq(person2.LastName))
+		if err != nil {
+			t.Fatalf("failed to count: %v", err)
+		}
+		if count != 1 {
+			t.Fatalf("expected count 1, but got %v", count)
+		}
+	})
+}
+
+func TestClient_FindWithCursor(t *testing.T) {
+	t.Parallel()
+	client, done := setup(t)
+	defer done()
+
+	t.Run("test collection find with cursor", func(t *testing.T) {
+		id := thread.NewIDV1(thread.Raw, 32)
+		err := client.NewDB(context.Background(), id)
+		checkErr(t, err)
+		err = client.NewCollection(
+			context.Background(),
+			id,
+			db.CollectionConfig{Name: collectionName, Schema: util.SchemaFromSchemaString(schema)},
+		)
+		checkErr(t, err)
+
+		people := make(Instances, 3)
+		for i := range people {
+			person := createPerson()
+			person.Age = 20 + i
+			people[i] = person
+		}
+		_, err = client.Create(context.Background(), id, collectionName, people)
+		checkErr(t, err)
+
+		q := db.OrderBy("age").LimitTo(2)
+		rawResults, cursor, err := client.FindWithCursor(context.Background(), id, collectionName, q, &Person{})
+		if err != nil {
+			t.Fatalf("failed to find: %v", err)
+		}
+		results := rawResults.([]*Person)
+		if len(results) != 2 || results[0].Age != 20 || results[1].Age != 21 {
+			t.Fatalf("unexpected first page: %v", results)
+		}
+		if cursor == "" {
+			t.Fatal("expected a cursor to the next page")
+		}
+
+		rawResults, next, err := client.FindWithCursor(context.Background(), id, collectionName, q.After(cursor), &Person{})
+		if err != nil {
+			t.Fatalf("failed to find: %v", err)
+		}
+		results = rawResults.([]*Person)
+		if len(results) != 1 || results[0].Age != 22 {
+			t.Fatalf("unexpected last page: %v", results)
+		}
+		if next != "" {
+			t.Fatal("expected no cursor after the last page")
+		}
+
+		_, _, err = client.FindWithCursor(context.Background(), id, collectionName, db.OrderByDesc("age").After(cursor), &Person{})
+		if !errors.Is(err, db.ErrCursorMismatch) {
+			t.Fatalf("expected cursor mismatch, but got %v", err)
+		}
+
+		page, err := client.FindPage(context.Background(), id, collectionName, db.OrderBy("age").LimitTo(2).WithTotal(), &Person{})
+		if err != nil {
+			t.Fatalf("failed to find: %v", err)
+		}
+		if len(page.Instances.([]*Person)) != 2 || page.Total != 3 || page.Cursor == "" {
+			t.Fatalf("unexpected page: %v", page)
+		}
+		if page.Explain != nil {
+			t.Fatal("expected no explain result")
+		}
+
+		page, err = client.FindPage(context.Background(), id, collectionName, db.OrderBy("age").LimitTo(2).WithExplain(), &Person{})
+		if err != nil {
+			t.Fatalf("failed to find: %v", err)
+		}
+		if e := page.Explain; e == nil || e.Index != "" || e.InstancesRead != 3 || e.Matched != 3 || !e.SortedInMemory {
+			t.Fatalf("unexpected explain result: %+v", page.Explain)
+		}
 	})
 }
 
@@ -660,6 +986,55 @@ func TestClient_FindByID(t *testing.T) {
 	})
 }
 
+func TestClient_FindByIDs(t *testing.T) {
+	t.Parallel()
+	client, done := setup(t)
+	defer done()
+
+	t.Run("test collection find by IDs", func(t *testing.T) {
+		id := thread.NewIDV1(thread.Raw, 32)
+		err := client.NewDB(context.Background(), id)
+		checkErr(t, err)
+		err = client.NewCollection(
+			context.Background(),
+			id,
+			db.CollectionConfig{Name: collectionName, Schema: util.SchemaFromSchemaString(schema)},
+		)
+		checkErr(t, err)
+
+		person1, person2 := createPerson(), createPerson()
+		ids, err := client.Create(context.Background(), id, collectionName, Instances{person1, person2})
+		checkErr(t, err)
+		person1.ID, person2.ID = ids[0], ids[1]
+
+		found, err := client.FindByIDs(context.Background(), id, collectionName, []string{person2.ID, "missing", person1.ID})
+		if err != nil {
+			t.Fatalf("failed to find collection by ids: %v", err)
+		}
+		if len(found) != 3 || found[1] != nil {
+			t.Fatalf("expected missing instance to be nil, got %v", found)
+		}
+		for i, person := range map[int]*Person{0: person2, 2: person1} {
+			newPerson := &Person{}
+			checkErr(t, json.Unmarshal(found[i], newPerson))
+			if !reflect.DeepEqual(newPerson, person) {
+				t.Fatalf("instance %d found by ids doesn't equal the original", i)
+			}
+		}
+
+		txn, err := client.ReadTransaction(context.Background(), id, collectionName)
+		checkErr(t, err)
+		end, err := txn.Start()
+		checkErr(t, err)
+		found, err = txn.FindByIDs([]string{"missing", person1.ID})
+		checkErr(t, err)
+		if len(found) != 2 || found[0] != nil || found[1] == nil {
+			t.Fatalf("expected txn to find the existing instance only, got %v", found)
+		}
+		checkErr(t, end())
+	})
+}
+
 func TestClient_ReadTransaction(t *testing.T) {
 	t.Parallel()
 	client, done := setup(t)
@@ -728,6 +1103,14 @@ func TestClient_ReadTransaction(t *testing.T) {
 		if !reflect.DeepEqual(results[0], person) {
 			t.Fatal("collection found by query does't equal the original")
 		}
+
+		count, err := txn.Count(q)
+		if err != nil {
+			t.Fatalf("failed to count: %v", err)
+		}
+		if count != 1 {
+			t.Fatalf("expected count 1, but got %v", count)
+		}
 	})
 }
 
@@ -872,6 +1255,55 @@ func TestClient_WriteTransaction(t *testing.T) {
 			t.Fatalf("failed to write txn save: %v", err)
 		}
 	})
+
+	t.Run("test multi-collection write transaction", func(t *testing.T) {
+		id := thread.NewIDV1(thread.Raw, 32)
+		err := client.NewDB(context.Background(), id)
+		checkErr(t, err)
+		err = client.NewCollection(
+			context.Background(),
+			id,
+			db.CollectionConfig{Name: collectionName, Schema: util.SchemaFromSchemaString(schema)},
+		)
+		checkErr(t, err)
+		err = client.NewCollection(
+			context.Background(),
+			id,
+			db.CollectionConfig{Name: "other", Schema: util.SchemaFromSchemaString(schema)},
+		)
+		checkErr(t, err)
+
+		txn, err := client.WriteTransaction(context.Background(), id, collectionName)
+		if err != nil {
+			t.Fatalf("failed to create write txn: %v", err)
+		}
+		end, err := txn.Start()
+		if err != nil {
+			t.Fatalf("failed to start write txn: %v", err)
+		}
+		ids, err := txn.Create(createPerson())
+		if err != nil {
+			t.Fatalf("failed to create in write txn: %v", err)
+		}
+		otherIDs, err := txn.Collection("other").Create(createPerson())
+		if err != nil {
+			t.Fatalf("failed to create in other collection of write txn: %v", err)
+		}
+		if err := end(); err != nil {
+			t.Fatalf("failed to end txn: %v", err)
+		}
+
+		has, err := client.Has(context.Background(), id, collectionName, ids)
+		checkErr(t, err)
+		if !has {
+			t.Fatal("expected the instance to be created")
+		}
+		has, err = client.Has(context.Background(), id, "other", otherIDs)
+		checkErr(t, err)
+		if !has {
+			t.Fatal("expected the instance of the other collection to be created")
+		}
+	})
 }
 
 func TestClient_Listen(t *testing.T) {
@@ -954,6 +1386,54 @@ func TestClient_Listen(t *testing.T) {
 			}
 		}
 	})
+	t.Run("test listen with query", func(t *testing.T) {
+		id := thread.NewIDV1(thread.Raw, 32)
+		err := client.NewDB(context.Background(), id)
+		checkErr(t, err)
+		err = client.NewCollection(
+			context.Background(),
+			id,
+			db.CollectionConfig{Name: collectionName, Schema: util.SchemaFromSchemaString(schema)},
+		)
+		checkErr(t, err)
+
+		person := createPerson()
+		ids, err := client.Create(context.Background(), id, collectionName, Instances{person})
+		checkErr(t, err)
+		person.ID = ids[0]
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		opt := ListenOption{
+			Collection: collectionName,
+			Query:      db.Where("age").Eq(40),
+		}
+		channel, err := client.Listen(ctx, id, []ListenOption{opt})
+		checkErr(t, err)
+
+		go func() {
+			time.Sleep(time.Second)
+			person.Age = 30
+			_ = client.Save(context.Background(), id, collectionName, Instances{person})
+			person.Age = 40
+			_ = client.Save(context.Background(), id, collectionName, Instances{person})
+		}()
+
+		val, ok := <-channel
+		if !ok {
+			t.Fatal("channel no longer active at first event")
+		}
+		if val.Err != nil {
+			t.Fatalf("failed to receive listen result: %v", val.Err)
+		}
+		p := &Person{}
+		if err := json.Unmarshal(val.Action.Instance, p); err != nil {
+			t.Fatalf("failed to unmarshal listen result: %v", err)
+		}
+		if p.Age != 40 {
+			t.Fatalf("expected only the matching listen result age = 40 but got: %v", p.Age)
+		}
+	})
 }
 
 func TestClient_Close(t *testing.T) {
@@ -94,6 +94,32 @@ func (t *ReadTransaction) FindByID(instanceID string, instance interface{}) erro
 	}
 }
 
+// FindByIDs gets the instances with the specified IDs, in their order. The
+// instances which don't exist are nil.
+func (t *ReadTransaction) FindByIDs(instanceIDs []string) ([][]byte, error) {
+	innerReq := &pb.FindByIDsRequest{
+		InstanceIDs: instanceIDs,
+	}
+	option := &pb.ReadTransactionRequest_FindByIDsRequest{
+		FindByIDsRequest: innerReq,
+	}
+	if err := t.client.Send(&pb.ReadTransactionRequest{
+		Option: option,
+	}); err != nil {
+		return nil, err
+	}
+	resp, err := t.client.Recv()
+	if err != nil {
+		return nil, err
+	}
+	switch x := resp.GetOption().(type) {
+	case *pb.ReadTransactionReply_FindByIDsReply:
+		return processFindByIDsReply(x.FindByIDsReply)
+	default:
+		return nil, fmt.Errorf("ReadTransactionReply.Option has unexpected type %T", x)
+	}
+}
+
 // Find finds instances by query.
 func (t *ReadTransaction) Find(query *db.Query, dummy interface{}) (interface{}, error) {
 	queryBytes, err := json.Marshal(query)
@@ -123,6 +149,35 @@ func (t *ReadTransaction) Find(query *db.Query, dummy interface{}) (interface{},
 	}
 }
 
+// Count returns the number of instances matching the query.
+func (t *ReadTransaction) Count(query *db.Query) (int, error) {
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return 0, err
+	}
+	innerReq := &pb.CountRequest{
+		QueryJSON: queryBytes,
+	}
+	option := &pb.ReadTransactionRequest_CountRequest{
+		CountRequest: innerReq,
+	}
+	if err := t.client.Send(&pb.ReadTransactionRequest{
+		Option: option,
+	}); err != nil {
+		return 0, err
+	}
+	var resp *pb.ReadTransactionReply
+	if resp, err = t.client.Recv(); err != nil {
+		return 0, err
+	}
+	switch x := resp.GetOption().(type) {
+	case *pb.ReadTransactionReply_CountReply:
+		return int(x.CountReply.GetCount()), txnError(x.CountReply.TransactionError)
+	default:
+		return 0, fmt.Errorf("ReadTransactionReply.Option has unexpected type %T", x)
+	}
+}
+
 // end ends the active transaction.
 func (t *ReadTransaction) end() error {
 	if err := t.client.CloseSend(); err != nil {
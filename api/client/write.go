@@ -34,10 +34,21 @@ func (t *WriteTransaction) Start() (EndTransactionFunc, error) {
 	return t.end, nil
 }
 
+// Collection returns the transaction of the collection named name, whose
+// writes are committed with the transaction, which must be started.
+func (t *WriteTransaction) Collection(name string) *WriteTransaction {
+	return &WriteTransaction{
+		client:         t.client,
+		dbID:           t.dbID,
+		collectionName: name,
+	}
+}
+
 // Has runs a has query in the active transaction.
 func (t *WriteTransaction) Has(instanceIDs ...string) (bool, error) {
 	innerReq := &pb.HasRequest{
-		InstanceIDs: instanceIDs,
+		CollectionName: t.collectionName,
+		InstanceIDs:    instanceIDs,
 	}
 	option := &pb.WriteTransactionRequest_HasRequest{
 		HasRequest: innerReq,
@@ -63,7 +74,8 @@ func (t *WriteTransaction) Has(instanceIDs ...string) (bool, error) {
 // FindByID gets the instance with the specified ID.
 func (t *WriteTransaction) FindByID(instanceID string, instance interface{}) error {
 	innerReq := &pb.FindByIDRequest{
-		InstanceID: instanceID,
+		CollectionName: t.collectionName,
+		InstanceID:     instanceID,
 	}
 	option := &pb.WriteTransactionRequest_FindByIDRequest{
 		FindByIDRequest: innerReq,
@@ -91,6 +103,33 @@ func (t *WriteTransaction) FindByID(instanceID string, instance interface{}) err
 	}
 }
 
+// FindByIDs gets the instances with the specified IDs, in their order. The
+// instances which don't exist are nil.
+func (t *WriteTransaction) FindByIDs(instanceIDs []string) ([][]byte, error) {
+	innerReq := &pb.FindByIDsRequest{
+		CollectionName: t.collectionName,
+		InstanceIDs:    instanceIDs,
+	}
+	option := &pb.WriteTransactionRequest_FindByIDsRequest{
+		FindByIDsRequest: innerReq,
+	}
+	if err := t.client.Send(&pb.WriteTransactionRequest{
+		Option: option,
+	}); err != nil {
+		return nil, err
+	}
+	resp, err := t.client.Recv()
+	if err != nil {
+		return nil, err
+	}
+	switch x := resp.GetOption().(type) {
+	case *pb.WriteTransactionReply_FindByIDsReply:
+		return processFindByIDsReply(x.FindByIDsReply)
+	default:
+		return nil, fmt.Errorf("WriteTransactionReply.Option has unexpected type %T", x)
+	}
+}
+
 // Find finds instances by query.
 func (t *WriteTransaction) Find(query *db.Query, dummy interface{}) (interface{}, error) {
 	queryBytes, err := json.Marshal(query)
@@ -98,7 +137,8 @@ func (t *WriteTransaction) Find(query *db.Query, dummy interface{}) (interface{}
 		return nil, err
 	}
 	innerReq := &pb.FindRequest{
-		QueryJSON: queryBytes,
+		CollectionName: t.collectionName,
+		QueryJSON:      queryBytes,
 	}
 	option := &pb.WriteTransactionRequest_FindRequest{
 		FindRequest: innerReq,
@@ -127,7 +167,8 @@ func (t *WriteTransaction) Create(items ...interface{}) ([]string, error) {
 		return nil, err
 	}
 	innerReq := &pb.CreateRequest{
-		Instances: values,
+		CollectionName: t.collectionName,
+		Instances:      values,
 	}
 	option := &pb.WriteTransactionRequest_CreateRequest{
 		CreateRequest: innerReq,
@@ -156,7 +197,8 @@ func (t *WriteTransaction) Verify(items ...interface{}) error {
 		return err
 	}
 	innerReq := &pb.VerifyRequest{
-		Instances: values,
+		CollectionName: t.collectionName,
+		Instances:      values,
 	}
 	option := &pb.WriteTransactionRequest_VerifyRequest{
 		VerifyRequest: innerReq,
@@ -185,7 +227,8 @@ func (t *WriteTransaction) Save(items ...interface{}) error {
 		return err
 	}
 	innerReq := &pb.SaveRequest{
-		Instances: values,
+		CollectionName: t.collectionName,
+		Instances:      values,
 	}
 	option := &pb.WriteTransactionRequest_SaveRequest{
 		SaveRequest: innerReq,
@@ -207,10 +250,43 @@ func (t *WriteTransaction) Save(items ...interface{}) error {
 	}
 }
 
+// SaveExpecting saves an instance if the stored one has the version, or fails
+// with a *db.StaleInstanceError holding the stored instance.
+func (t *WriteTransaction) SaveExpecting(instance interface{}, version int64) error {
+	values, err := marshalItems([]interface{}{instance})
+	if err != nil {
+		return err
+	}
+	innerReq := &pb.SaveExpectingRequest{
+		CollectionName: t.collectionName,
+		Instance:       values[0],
+		Version:        version,
+	}
+	option := &pb.WriteTransactionRequest_SaveExpectingRequest{
+		SaveExpectingRequest: innerReq,
+	}
+	if err = t.client.Send(&pb.WriteTransactionRequest{
+		Option: option,
+	}); err != nil {
+		return err
+	}
+	var resp *pb.WriteTransactionReply
+	if resp, err = t.client.Recv(); err != nil {
+		return err
+	}
+	switch x := resp.GetOption().(type) {
+	case *pb.WriteTransactionReply_SaveExpectingReply:
+		return processSaveExpectingReply(x.SaveExpectingReply)
+	default:
+		return fmt.Errorf("WriteTransactionReply.Option has unexpected type %T", x)
+	}
+}
+
 // Delete deletes data.
 func (t *WriteTransaction) Delete(instanceIDs ...string) error {
 	innerReq := &pb.DeleteRequest{
-		InstanceIDs: instanceIDs,
+		CollectionName: t.collectionName,
+		InstanceIDs:    instanceIDs,
 	}
 	option := &pb.WriteTransactionRequest_DeleteRequest{
 		DeleteRequest: innerReq,
@@ -262,7 +338,7 @@ func (t *WriteTransaction) end() error {
 		return err
 	}
 	if _, err := t.client.Recv(); err != nil && err != io.EOF {
-		return err
+		return uniqueError(err)
 	}
 	return nil
 }
@@ -20,6 +20,61 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type NewDBFromAddrProgressReply_Stage int32
+
+const (
+	NewDBFromAddrProgressReply_THREAD_ADDED    NewDBFromAddrProgressReply_Stage = 0
+	NewDBFromAddrProgressReply_LOGS_DISCOVERED NewDBFromAddrProgressReply_Stage = 1
+	NewDBFromAddrProgressReply_RECORDS_FETCHED NewDBFromAddrProgressReply_Stage = 2
+	NewDBFromAddrProgressReply_EVENTS_REDUCED  NewDBFromAddrProgressReply_Stage = 3
+	NewDBFromAddrProgressReply_DONE            NewDBFromAddrProgressReply_Stage = 4
+)
+
+// Enum value maps for NewDBFromAddrProgressReply_Stage.
+var (
+	NewDBFromAddrProgressReply_Stage_name = map[int32]string{
+		0: "THREAD_ADDED",
+		1: "LOGS_DISCOVERED",
+		2: "RECORDS_FETCHED",
+		3: "EVENTS_REDUCED",
+		4: "DONE",
+	}
+	NewDBFromAddrProgressReply_Stage_value = map[string]int32{
+		"THREAD_ADDED":    0,
+		"LOGS_DISCOVERED": 1,
+		"RECORDS_FETCHED": 2,
+		"EVENTS_REDUCED":  3,
+		"DONE":            4,
+	}
+)
+
+func (x NewDBFromAddrProgressReply_Stage) Enum() *NewDBFromAddrProgressReply_Stage {
+	p := new(NewDBFromAddrProgressReply_Stage)
+	*p = x
+	return p
+}
+
+func (x NewDBFromAddrProgressReply_Stage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NewDBFromAddrProgressReply_Stage) Descriptor() protoreflect.EnumDescriptor {
+	return file_threads_proto_enumTypes[0].Descriptor()
+}
+
+func (NewDBFromAddrProgressReply_Stage) Type() protoreflect.EnumType {
+	return &file_threads_proto_enumTypes[0]
+}
+
+func (x NewDBFromAddrProgressReply_Stage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NewDBFromAddrProgressReply_Stage.Descriptor instead.
+func (NewDBFromAddrProgressReply_Stage) EnumDescriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{7, 0}
+}
+
 type ListenRequest_Filter_Action int32
 
 const (
@@ -56,11 +111,11 @@ func (x ListenRequest_Filter_Action) String() string {
 }
 
 func (ListenRequest_Filter_Action) Descriptor() protoreflect.EnumDescriptor {
-	return file_threads_proto_enumTypes[0].Descriptor()
+	return file_threads_proto_enumTypes[1].Descriptor()
 }
 
 func (ListenRequest_Filter_Action) Type() protoreflect.EnumType {
-	return &file_threads_proto_enumTypes[0]
+	return &file_threads_proto_enumTypes[1]
 }
 
 func (x ListenRequest_Filter_Action) Number() protoreflect.EnumNumber {
@@ -69,7 +124,7 @@ func (x ListenRequest_Filter_Action) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ListenRequest_Filter_Action.Descriptor instead.
 func (ListenRequest_Filter_Action) EnumDescriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{46, 0, 0}
+	return file_threads_proto_rawDescGZIP(), []int{70, 0, 0}
 }
 
 type ListenReply_Action int32
@@ -105,11 +160,11 @@ func (x ListenReply_Action) String() string {
 }
 
 func (ListenReply_Action) Descriptor() protoreflect.EnumDescriptor {
-	return file_threads_proto_enumTypes[1].Descriptor()
+	return file_threads_proto_enumTypes[2].Descriptor()
 }
 
 func (ListenReply_Action) Type() protoreflect.EnumType {
-	return &file_threads_proto_enumTypes[1]
+	return &file_threads_proto_enumTypes[2]
 }
 
 func (x ListenReply_Action) Number() protoreflect.EnumNumber {
@@ -118,7 +173,7 @@ func (x ListenReply_Action) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ListenReply_Action.Descriptor instead.
 func (ListenReply_Action) EnumDescriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{47, 0}
+	return file_threads_proto_rawDescGZIP(), []int{71, 0}
 }
 
 type GetTokenRequest struct {
@@ -479,6 +534,9 @@ type CollectionConfig struct {
 	Indexes        []*Index `protobuf:"bytes,3,rep,name=indexes,proto3" json:"indexes,omitempty"`
 	WriteValidator string   `protobuf:"bytes,4,opt,name=writeValidator,proto3" json:"writeValidator,omitempty"`
 	ReadFilter     string   `protobuf:"bytes,5,opt,name=readFilter,proto3" json:"readFilter,omitempty"`
+	SoftDelete     bool     `protobuf:"varint,6,opt,name=softDelete,proto3" json:"softDelete,omitempty"`
+	ExpiryField    string   `protobuf:"bytes,7,opt,name=expiryField,proto3" json:"expiryField,omitempty"`
+	ApplyDefaults  bool     `protobuf:"varint,8,opt,name=applyDefaults,proto3" json:"applyDefaults,omitempty"`
 }
 
 func (x *CollectionConfig) Reset() {
@@ -548,13 +606,36 @@ func (x *CollectionConfig) GetReadFilter() string {
 	return ""
 }
 
+func (x *CollectionConfig) GetSoftDelete() bool {
+	if x != nil {
+		return x.SoftDelete
+	}
+	return false
+}
+
+func (x *CollectionConfig) GetExpiryField() string {
+	if x != nil {
+		return x.ExpiryField
+	}
+	return ""
+}
+
+func (x *CollectionConfig) GetApplyDefaults() bool {
+	if x != nil {
+		return x.ApplyDefaults
+	}
+	return false
+}
+
 type Index struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Path   string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	Unique bool   `protobuf:"varint,2,opt,name=unique,proto3" json:"unique,omitempty"`
+	Path     string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Unique   bool     `protobuf:"varint,2,opt,name=unique,proto3" json:"unique,omitempty"`
+	FullText bool     `protobuf:"varint,3,opt,name=fullText,proto3" json:"fullText,omitempty"`
+	Fields   []string `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
 }
 
 func (x *Index) Reset() {
@@ -603,6 +684,20 @@ func (x *Index) GetUnique() bool {
 	return false
 }
 
+func (x *Index) GetFullText() bool {
+	if x != nil {
+		return x.FullText
+	}
+	return false
+}
+
+func (x *Index) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
 type NewDBReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -641,16 +736,100 @@ func (*NewDBReply) Descriptor() ([]byte, []int) {
 	return file_threads_proto_rawDescGZIP(), []int{6}
 }
 
+type NewDBFromAddrProgressReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stage        NewDBFromAddrProgressReply_Stage `protobuf:"varint,1,opt,name=stage,proto3,enum=threads.pb.NewDBFromAddrProgressReply_Stage" json:"stage,omitempty"`
+	Logs         int64                            `protobuf:"varint,2,opt,name=logs,proto3" json:"logs,omitempty"`
+	Records      int64                            `protobuf:"varint,3,opt,name=records,proto3" json:"records,omitempty"`
+	TotalRecords int64                            `protobuf:"varint,4,opt,name=totalRecords,proto3" json:"totalRecords,omitempty"`
+	Events       int64                            `protobuf:"varint,5,opt,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *NewDBFromAddrProgressReply) Reset() {
+	*x = NewDBFromAddrProgressReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewDBFromAddrProgressReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewDBFromAddrProgressReply) ProtoMessage() {}
+
+func (x *NewDBFromAddrProgressReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewDBFromAddrProgressReply.ProtoReflect.Descriptor instead.
+func (*NewDBFromAddrProgressReply) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *NewDBFromAddrProgressReply) GetStage() NewDBFromAddrProgressReply_Stage {
+	if x != nil {
+		return x.Stage
+	}
+	return NewDBFromAddrProgressReply_THREAD_ADDED
+}
+
+func (x *NewDBFromAddrProgressReply) GetLogs() int64 {
+	if x != nil {
+		return x.Logs
+	}
+	return 0
+}
+
+func (x *NewDBFromAddrProgressReply) GetRecords() int64 {
+	if x != nil {
+		return x.Records
+	}
+	return 0
+}
+
+func (x *NewDBFromAddrProgressReply) GetTotalRecords() int64 {
+	if x != nil {
+		return x.TotalRecords
+	}
+	return 0
+}
+
+func (x *NewDBFromAddrProgressReply) GetEvents() int64 {
+	if x != nil {
+		return x.Events
+	}
+	return 0
+}
+
 type ListDBsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	NamePrefix string `protobuf:"bytes,1,opt,name=namePrefix,proto3" json:"namePrefix,omitempty"`
+	Cursor     string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit      int64  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	SkipInfo   bool   `protobuf:"varint,4,opt,name=skipInfo,proto3" json:"skipInfo,omitempty"`
 }
 
 func (x *ListDBsRequest) Reset() {
 	*x = ListDBsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[7]
+		mi := &file_threads_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -663,7 +842,7 @@ func (x *ListDBsRequest) String() string {
 func (*ListDBsRequest) ProtoMessage() {}
 
 func (x *ListDBsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[7]
+	mi := &file_threads_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -676,7 +855,35 @@ func (x *ListDBsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDBsRequest.ProtoReflect.Descriptor instead.
 func (*ListDBsRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{7}
+	return file_threads_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListDBsRequest) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *ListDBsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListDBsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListDBsRequest) GetSkipInfo() bool {
+	if x != nil {
+		return x.SkipInfo
+	}
+	return false
 }
 
 type ListDBsReply struct {
@@ -684,13 +891,14 @@ type ListDBsReply struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Dbs []*ListDBsReply_DB `protobuf:"bytes,1,rep,name=dbs,proto3" json:"dbs,omitempty"`
+	Dbs    []*ListDBsReply_DB `protobuf:"bytes,1,rep,name=dbs,proto3" json:"dbs,omitempty"`
+	Cursor string             `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
 }
 
 func (x *ListDBsReply) Reset() {
 	*x = ListDBsReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[8]
+		mi := &file_threads_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -703,7 +911,7 @@ func (x *ListDBsReply) String() string {
 func (*ListDBsReply) ProtoMessage() {}
 
 func (x *ListDBsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[8]
+	mi := &file_threads_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -716,7 +924,7 @@ func (x *ListDBsReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDBsReply.ProtoReflect.Descriptor instead.
 func (*ListDBsReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{8}
+	return file_threads_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListDBsReply) GetDbs() []*ListDBsReply_DB {
@@ -726,6 +934,13 @@ func (x *ListDBsReply) GetDbs() []*ListDBsReply_DB {
 	return nil
 }
 
+func (x *ListDBsReply) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 type GetDBInfoRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -737,7 +952,7 @@ type GetDBInfoRequest struct {
 func (x *GetDBInfoRequest) Reset() {
 	*x = GetDBInfoRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[9]
+		mi := &file_threads_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -750,7 +965,7 @@ func (x *GetDBInfoRequest) String() string {
 func (*GetDBInfoRequest) ProtoMessage() {}
 
 func (x *GetDBInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[9]
+	mi := &file_threads_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -763,7 +978,7 @@ func (x *GetDBInfoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDBInfoRequest.ProtoReflect.Descriptor instead.
 func (*GetDBInfoRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{9}
+	return file_threads_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetDBInfoRequest) GetDbID() []byte {
@@ -786,7 +1001,7 @@ type GetDBInfoReply struct {
 func (x *GetDBInfoReply) Reset() {
 	*x = GetDBInfoReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[10]
+		mi := &file_threads_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -799,7 +1014,7 @@ func (x *GetDBInfoReply) String() string {
 func (*GetDBInfoReply) ProtoMessage() {}
 
 func (x *GetDBInfoReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[10]
+	mi := &file_threads_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -812,7 +1027,7 @@ func (x *GetDBInfoReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDBInfoReply.ProtoReflect.Descriptor instead.
 func (*GetDBInfoReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{10}
+	return file_threads_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetDBInfoReply) GetAddrs() [][]byte {
@@ -847,7 +1062,7 @@ type DeleteDBRequest struct {
 func (x *DeleteDBRequest) Reset() {
 	*x = DeleteDBRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[11]
+		mi := &file_threads_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -860,7 +1075,7 @@ func (x *DeleteDBRequest) String() string {
 func (*DeleteDBRequest) ProtoMessage() {}
 
 func (x *DeleteDBRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[11]
+	mi := &file_threads_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -873,7 +1088,7 @@ func (x *DeleteDBRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteDBRequest.ProtoReflect.Descriptor instead.
 func (*DeleteDBRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{11}
+	return file_threads_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *DeleteDBRequest) GetDbID() []byte {
@@ -892,7 +1107,7 @@ type DeleteDBReply struct {
 func (x *DeleteDBReply) Reset() {
 	*x = DeleteDBReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[12]
+		mi := &file_threads_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -905,7 +1120,7 @@ func (x *DeleteDBReply) String() string {
 func (*DeleteDBReply) ProtoMessage() {}
 
 func (x *DeleteDBReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[12]
+	mi := &file_threads_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -918,7 +1133,7 @@ func (x *DeleteDBReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteDBReply.ProtoReflect.Descriptor instead.
 func (*DeleteDBReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{12}
+	return file_threads_proto_rawDescGZIP(), []int{13}
 }
 
 type NewCollectionRequest struct {
@@ -933,7 +1148,7 @@ type NewCollectionRequest struct {
 func (x *NewCollectionRequest) Reset() {
 	*x = NewCollectionRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[13]
+		mi := &file_threads_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -946,7 +1161,7 @@ func (x *NewCollectionRequest) String() string {
 func (*NewCollectionRequest) ProtoMessage() {}
 
 func (x *NewCollectionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[13]
+	mi := &file_threads_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -959,7 +1174,7 @@ func (x *NewCollectionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewCollectionRequest.ProtoReflect.Descriptor instead.
 func (*NewCollectionRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{13}
+	return file_threads_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *NewCollectionRequest) GetDbID() []byte {
@@ -985,7 +1200,7 @@ type NewCollectionReply struct {
 func (x *NewCollectionReply) Reset() {
 	*x = NewCollectionReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[14]
+		mi := &file_threads_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -998,7 +1213,7 @@ func (x *NewCollectionReply) String() string {
 func (*NewCollectionReply) ProtoMessage() {}
 
 func (x *NewCollectionReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[14]
+	mi := &file_threads_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1011,7 +1226,7 @@ func (x *NewCollectionReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewCollectionReply.ProtoReflect.Descriptor instead.
 func (*NewCollectionReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{14}
+	return file_threads_proto_rawDescGZIP(), []int{15}
 }
 
 type UpdateCollectionRequest struct {
@@ -1019,14 +1234,15 @@ type UpdateCollectionRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DbID   []byte            `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
-	Config *CollectionConfig `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	DbID           []byte            `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
+	Config         *CollectionConfig `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	SkipValidation bool              `protobuf:"varint,3,opt,name=skipValidation,proto3" json:"skipValidation,omitempty"`
 }
 
 func (x *UpdateCollectionRequest) Reset() {
 	*x = UpdateCollectionRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[15]
+		mi := &file_threads_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1039,7 +1255,7 @@ func (x *UpdateCollectionRequest) String() string {
 func (*UpdateCollectionRequest) ProtoMessage() {}
 
 func (x *UpdateCollectionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[15]
+	mi := &file_threads_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1052,7 +1268,7 @@ func (x *UpdateCollectionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCollectionRequest.ProtoReflect.Descriptor instead.
 func (*UpdateCollectionRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{15}
+	return file_threads_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *UpdateCollectionRequest) GetDbID() []byte {
@@ -1069,6 +1285,13 @@ func (x *UpdateCollectionRequest) GetConfig() *CollectionConfig {
 	return nil
 }
 
+func (x *UpdateCollectionRequest) GetSkipValidation() bool {
+	if x != nil {
+		return x.SkipValidation
+	}
+	return false
+}
+
 type UpdateCollectionReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1078,7 +1301,7 @@ type UpdateCollectionReply struct {
 func (x *UpdateCollectionReply) Reset() {
 	*x = UpdateCollectionReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[16]
+		mi := &file_threads_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1091,7 +1314,7 @@ func (x *UpdateCollectionReply) String() string {
 func (*UpdateCollectionReply) ProtoMessage() {}
 
 func (x *UpdateCollectionReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[16]
+	mi := &file_threads_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1104,35 +1327,35 @@ func (x *UpdateCollectionReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCollectionReply.ProtoReflect.Descriptor instead.
 func (*UpdateCollectionReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{16}
+	return file_threads_proto_rawDescGZIP(), []int{17}
 }
 
-type DeleteCollectionRequest struct {
+type CheckCollectionUpdateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DbID []byte `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
-	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	DbID   []byte            `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
+	Config *CollectionConfig `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
 }
 
-func (x *DeleteCollectionRequest) Reset() {
-	*x = DeleteCollectionRequest{}
+func (x *CheckCollectionUpdateRequest) Reset() {
+	*x = CheckCollectionUpdateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[17]
+		mi := &file_threads_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DeleteCollectionRequest) String() string {
+func (x *CheckCollectionUpdateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteCollectionRequest) ProtoMessage() {}
+func (*CheckCollectionUpdateRequest) ProtoMessage() {}
 
-func (x *DeleteCollectionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[17]
+func (x *CheckCollectionUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1143,48 +1366,52 @@ func (x *DeleteCollectionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteCollectionRequest.ProtoReflect.Descriptor instead.
-func (*DeleteCollectionRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use CheckCollectionUpdateRequest.ProtoReflect.Descriptor instead.
+func (*CheckCollectionUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *DeleteCollectionRequest) GetDbID() []byte {
+func (x *CheckCollectionUpdateRequest) GetDbID() []byte {
 	if x != nil {
 		return x.DbID
 	}
 	return nil
 }
 
-func (x *DeleteCollectionRequest) GetName() string {
+func (x *CheckCollectionUpdateRequest) GetConfig() *CollectionConfig {
 	if x != nil {
-		return x.Name
+		return x.Config
 	}
-	return ""
+	return nil
 }
 
-type DeleteCollectionReply struct {
+type CheckCollectionUpdateReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Incompatible int64    `protobuf:"varint,1,opt,name=incompatible,proto3" json:"incompatible,omitempty"`
+	SampleIDs    []string `protobuf:"bytes,2,rep,name=sampleIDs,proto3" json:"sampleIDs,omitempty"`
+	Pointers     []string `protobuf:"bytes,3,rep,name=pointers,proto3" json:"pointers,omitempty"`
 }
 
-func (x *DeleteCollectionReply) Reset() {
-	*x = DeleteCollectionReply{}
+func (x *CheckCollectionUpdateReply) Reset() {
+	*x = CheckCollectionUpdateReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[18]
+		mi := &file_threads_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DeleteCollectionReply) String() string {
+func (x *CheckCollectionUpdateReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteCollectionReply) ProtoMessage() {}
+func (*CheckCollectionUpdateReply) ProtoMessage() {}
 
-func (x *DeleteCollectionReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[18]
+func (x *CheckCollectionUpdateReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1195,12 +1422,33 @@ func (x *DeleteCollectionReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteCollectionReply.ProtoReflect.Descriptor instead.
-func (*DeleteCollectionReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use CheckCollectionUpdateReply.ProtoReflect.Descriptor instead.
+func (*CheckCollectionUpdateReply) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{19}
 }
 
-type GetCollectionInfoRequest struct {
+func (x *CheckCollectionUpdateReply) GetIncompatible() int64 {
+	if x != nil {
+		return x.Incompatible
+	}
+	return 0
+}
+
+func (x *CheckCollectionUpdateReply) GetSampleIDs() []string {
+	if x != nil {
+		return x.SampleIDs
+	}
+	return nil
+}
+
+func (x *CheckCollectionUpdateReply) GetPointers() []string {
+	if x != nil {
+		return x.Pointers
+	}
+	return nil
+}
+
+type DeleteCollectionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -1209,10 +1457,204 @@ type GetCollectionInfoRequest struct {
 	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *GetCollectionInfoRequest) Reset() {
+func (x *DeleteCollectionRequest) Reset() {
+	*x = DeleteCollectionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCollectionRequest) ProtoMessage() {}
+
+func (x *DeleteCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCollectionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteCollectionRequest) GetDbID() []byte {
+	if x != nil {
+		return x.DbID
+	}
+	return nil
+}
+
+func (x *DeleteCollectionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteCollectionReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteCollectionReply) Reset() {
+	*x = DeleteCollectionReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteCollectionReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCollectionReply) ProtoMessage() {}
+
+func (x *DeleteCollectionReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCollectionReply.ProtoReflect.Descriptor instead.
+func (*DeleteCollectionReply) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{21}
+}
+
+type RenameCollectionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DbID    []byte `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	NewName string `protobuf:"bytes,3,opt,name=newName,proto3" json:"newName,omitempty"`
+}
+
+func (x *RenameCollectionRequest) Reset() {
+	*x = RenameCollectionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RenameCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameCollectionRequest) ProtoMessage() {}
+
+func (x *RenameCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameCollectionRequest.ProtoReflect.Descriptor instead.
+func (*RenameCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RenameCollectionRequest) GetDbID() []byte {
+	if x != nil {
+		return x.DbID
+	}
+	return nil
+}
+
+func (x *RenameCollectionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RenameCollectionRequest) GetNewName() string {
+	if x != nil {
+		return x.NewName
+	}
+	return ""
+}
+
+type RenameCollectionReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RenameCollectionReply) Reset() {
+	*x = RenameCollectionReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RenameCollectionReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameCollectionReply) ProtoMessage() {}
+
+func (x *RenameCollectionReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameCollectionReply.ProtoReflect.Descriptor instead.
+func (*RenameCollectionReply) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{23}
+}
+
+type GetCollectionInfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DbID []byte `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GetCollectionInfoRequest) Reset() {
 	*x = GetCollectionInfoRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[19]
+		mi := &file_threads_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1225,7 +1667,7 @@ func (x *GetCollectionInfoRequest) String() string {
 func (*GetCollectionInfoRequest) ProtoMessage() {}
 
 func (x *GetCollectionInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[19]
+	mi := &file_threads_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1238,7 +1680,7 @@ func (x *GetCollectionInfoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCollectionInfoRequest.ProtoReflect.Descriptor instead.
 func (*GetCollectionInfoRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{19}
+	return file_threads_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GetCollectionInfoRequest) GetDbID() []byte {
@@ -1265,12 +1707,15 @@ type GetCollectionInfoReply struct {
 	Indexes        []*Index `protobuf:"bytes,3,rep,name=indexes,proto3" json:"indexes,omitempty"`
 	WriteValidator string   `protobuf:"bytes,4,opt,name=writeValidator,proto3" json:"writeValidator,omitempty"`
 	ReadFilter     string   `protobuf:"bytes,5,opt,name=readFilter,proto3" json:"readFilter,omitempty"`
+	SoftDelete     bool     `protobuf:"varint,6,opt,name=softDelete,proto3" json:"softDelete,omitempty"`
+	ExpiryField    string   `protobuf:"bytes,7,opt,name=expiryField,proto3" json:"expiryField,omitempty"`
+	ApplyDefaults  bool     `protobuf:"varint,8,opt,name=applyDefaults,proto3" json:"applyDefaults,omitempty"`
 }
 
 func (x *GetCollectionInfoReply) Reset() {
 	*x = GetCollectionInfoReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[20]
+		mi := &file_threads_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1283,7 +1728,7 @@ func (x *GetCollectionInfoReply) String() string {
 func (*GetCollectionInfoReply) ProtoMessage() {}
 
 func (x *GetCollectionInfoReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[20]
+	mi := &file_threads_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1296,7 +1741,7 @@ func (x *GetCollectionInfoReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCollectionInfoReply.ProtoReflect.Descriptor instead.
 func (*GetCollectionInfoReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{20}
+	return file_threads_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GetCollectionInfoReply) GetName() string {
@@ -1334,6 +1779,27 @@ func (x *GetCollectionInfoReply) GetReadFilter() string {
 	return ""
 }
 
+func (x *GetCollectionInfoReply) GetSoftDelete() bool {
+	if x != nil {
+		return x.SoftDelete
+	}
+	return false
+}
+
+func (x *GetCollectionInfoReply) GetExpiryField() string {
+	if x != nil {
+		return x.ExpiryField
+	}
+	return ""
+}
+
+func (x *GetCollectionInfoReply) GetApplyDefaults() bool {
+	if x != nil {
+		return x.ApplyDefaults
+	}
+	return false
+}
+
 type GetCollectionIndexesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1346,7 +1812,7 @@ type GetCollectionIndexesRequest struct {
 func (x *GetCollectionIndexesRequest) Reset() {
 	*x = GetCollectionIndexesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[21]
+		mi := &file_threads_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1359,7 +1825,7 @@ func (x *GetCollectionIndexesRequest) String() string {
 func (*GetCollectionIndexesRequest) ProtoMessage() {}
 
 func (x *GetCollectionIndexesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[21]
+	mi := &file_threads_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1372,7 +1838,7 @@ func (x *GetCollectionIndexesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCollectionIndexesRequest.ProtoReflect.Descriptor instead.
 func (*GetCollectionIndexesRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{21}
+	return file_threads_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *GetCollectionIndexesRequest) GetDbID() []byte {
@@ -1400,7 +1866,7 @@ type GetCollectionIndexesReply struct {
 func (x *GetCollectionIndexesReply) Reset() {
 	*x = GetCollectionIndexesReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[22]
+		mi := &file_threads_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1413,7 +1879,7 @@ func (x *GetCollectionIndexesReply) String() string {
 func (*GetCollectionIndexesReply) ProtoMessage() {}
 
 func (x *GetCollectionIndexesReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[22]
+	mi := &file_threads_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1426,7 +1892,7 @@ func (x *GetCollectionIndexesReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCollectionIndexesReply.ProtoReflect.Descriptor instead.
 func (*GetCollectionIndexesReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{22}
+	return file_threads_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *GetCollectionIndexesReply) GetIndexes() []*Index {
@@ -1447,7 +1913,7 @@ type ListCollectionsRequest struct {
 func (x *ListCollectionsRequest) Reset() {
 	*x = ListCollectionsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[23]
+		mi := &file_threads_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1460,7 +1926,7 @@ func (x *ListCollectionsRequest) String() string {
 func (*ListCollectionsRequest) ProtoMessage() {}
 
 func (x *ListCollectionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[23]
+	mi := &file_threads_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1473,7 +1939,7 @@ func (x *ListCollectionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCollectionsRequest.ProtoReflect.Descriptor instead.
 func (*ListCollectionsRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{23}
+	return file_threads_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *ListCollectionsRequest) GetDbID() []byte {
@@ -1494,7 +1960,7 @@ type ListCollectionsReply struct {
 func (x *ListCollectionsReply) Reset() {
 	*x = ListCollectionsReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[24]
+		mi := &file_threads_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1507,7 +1973,7 @@ func (x *ListCollectionsReply) String() string {
 func (*ListCollectionsReply) ProtoMessage() {}
 
 func (x *ListCollectionsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[24]
+	mi := &file_threads_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1520,7 +1986,7 @@ func (x *ListCollectionsReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCollectionsReply.ProtoReflect.Descriptor instead.
 func (*ListCollectionsReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{24}
+	return file_threads_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *ListCollectionsReply) GetCollections() []*GetCollectionInfoReply {
@@ -1530,33 +1996,36 @@ func (x *ListCollectionsReply) GetCollections() []*GetCollectionInfoReply {
 	return nil
 }
 
-type CreateRequest struct {
+type CollectionStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DbID           []byte   `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
-	CollectionName string   `protobuf:"bytes,2,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
-	Instances      [][]byte `protobuf:"bytes,3,rep,name=instances,proto3" json:"instances,omitempty"`
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Instances     int64  `protobuf:"varint,2,opt,name=instances,proto3" json:"instances,omitempty"`
+	InstanceBytes int64  `protobuf:"varint,3,opt,name=instanceBytes,proto3" json:"instanceBytes,omitempty"`
+	IndexEntries  int64  `protobuf:"varint,4,opt,name=indexEntries,proto3" json:"indexEntries,omitempty"`
+	IndexBytes    int64  `protobuf:"varint,5,opt,name=indexBytes,proto3" json:"indexBytes,omitempty"`
+	LastWrite     int64  `protobuf:"varint,6,opt,name=lastWrite,proto3" json:"lastWrite,omitempty"`
 }
 
-func (x *CreateRequest) Reset() {
-	*x = CreateRequest{}
+func (x *CollectionStats) Reset() {
+	*x = CollectionStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[25]
+		mi := &file_threads_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CreateRequest) String() string {
+func (x *CollectionStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateRequest) ProtoMessage() {}
+func (*CollectionStats) ProtoMessage() {}
 
-func (x *CreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[25]
+func (x *CollectionStats) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1567,58 +2036,1072 @@ func (x *CreateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateRequest.ProtoReflect.Descriptor instead.
-func (*CreateRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use CollectionStats.ProtoReflect.Descriptor instead.
+func (*CollectionStats) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *CreateRequest) GetDbID() []byte {
+func (x *CollectionStats) GetName() string {
 	if x != nil {
-		return x.DbID
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *CreateRequest) GetCollectionName() string {
+func (x *CollectionStats) GetInstances() int64 {
 	if x != nil {
-		return x.CollectionName
+		return x.Instances
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateRequest) GetInstances() [][]byte {
+func (x *CollectionStats) GetInstanceBytes() int64 {
 	if x != nil {
-		return x.Instances
+		return x.InstanceBytes
 	}
-	return nil
+	return 0
 }
 
-type CreateReply struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *CollectionStats) GetIndexEntries() int64 {
+	if x != nil {
+		return x.IndexEntries
+	}
+	return 0
+}
+
+func (x *CollectionStats) GetIndexBytes() int64 {
+	if x != nil {
+		return x.IndexBytes
+	}
+	return 0
+}
+
+func (x *CollectionStats) GetLastWrite() int64 {
+	if x != nil {
+		return x.LastWrite
+	}
+	return 0
+}
+
+type LogStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries int64 `protobuf:"varint,1,opt,name=entries,proto3" json:"entries,omitempty"`
+	Bytes   int64 `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+}
+
+func (x *LogStats) Reset() {
+	*x = LogStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogStats) ProtoMessage() {}
+
+func (x *LogStats) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogStats.ProtoReflect.Descriptor instead.
+func (*LogStats) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *LogStats) GetEntries() int64 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+func (x *LogStats) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+type GetStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DbID []byte `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetStatsRequest) GetDbID() []byte {
+	if x != nil {
+		return x.DbID
+	}
+	return nil
+}
+
+type GetStatsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Collections   []*CollectionStats `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
+	Instances     int64              `protobuf:"varint,2,opt,name=instances,proto3" json:"instances,omitempty"`
+	InstanceBytes int64              `protobuf:"varint,3,opt,name=instanceBytes,proto3" json:"instanceBytes,omitempty"`
+	IndexEntries  int64              `protobuf:"varint,4,opt,name=indexEntries,proto3" json:"indexEntries,omitempty"`
+	IndexBytes    int64              `protobuf:"varint,5,opt,name=indexBytes,proto3" json:"indexBytes,omitempty"`
+	Dispatcher    *LogStats          `protobuf:"bytes,6,opt,name=dispatcher,proto3" json:"dispatcher,omitempty"`
+	ActionLog     *LogStats          `protobuf:"bytes,7,opt,name=actionLog,proto3" json:"actionLog,omitempty"`
+}
+
+func (x *GetStatsReply) Reset() {
+	*x = GetStatsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsReply) ProtoMessage() {}
+
+func (x *GetStatsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsReply.ProtoReflect.Descriptor instead.
+func (*GetStatsReply) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetStatsReply) GetCollections() []*CollectionStats {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+func (x *GetStatsReply) GetInstances() int64 {
+	if x != nil {
+		return x.Instances
+	}
+	return 0
+}
+
+func (x *GetStatsReply) GetInstanceBytes() int64 {
+	if x != nil {
+		return x.InstanceBytes
+	}
+	return 0
+}
+
+func (x *GetStatsReply) GetIndexEntries() int64 {
+	if x != nil {
+		return x.IndexEntries
+	}
+	return 0
+}
+
+func (x *GetStatsReply) GetIndexBytes() int64 {
+	if x != nil {
+		return x.IndexBytes
+	}
+	return 0
+}
+
+func (x *GetStatsReply) GetDispatcher() *LogStats {
+	if x != nil {
+		return x.Dispatcher
+	}
+	return nil
+}
+
+func (x *GetStatsReply) GetActionLog() *LogStats {
+	if x != nil {
+		return x.ActionLog
+	}
+	return nil
+}
+
+type GetCollectionStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DbID []byte `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GetCollectionStatsRequest) Reset() {
+	*x = GetCollectionStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCollectionStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollectionStatsRequest) ProtoMessage() {}
+
+func (x *GetCollectionStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollectionStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetCollectionStatsRequest) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetCollectionStatsRequest) GetDbID() []byte {
+	if x != nil {
+		return x.DbID
+	}
+	return nil
+}
+
+func (x *GetCollectionStatsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetCollectionStatsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stats *CollectionStats `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *GetCollectionStatsReply) Reset() {
+	*x = GetCollectionStatsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCollectionStatsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollectionStatsReply) ProtoMessage() {}
+
+func (x *GetCollectionStatsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollectionStatsReply.ProtoReflect.Descriptor instead.
+func (*GetCollectionStatsReply) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetCollectionStatsReply) GetStats() *CollectionStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type GCRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GCRequest) Reset() {
+	*x = GCRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GCRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCRequest) ProtoMessage() {}
+
+func (x *GCRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCRequest.ProtoReflect.Descriptor instead.
+func (*GCRequest) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{36}
+}
+
+type GCReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DbBytes  uint64 `protobuf:"varint,1,opt,name=dbBytes,proto3" json:"dbBytes,omitempty"`
+	NetBytes uint64 `protobuf:"varint,2,opt,name=netBytes,proto3" json:"netBytes,omitempty"`
+}
+
+func (x *GCReply) Reset() {
+	*x = GCReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GCReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCReply) ProtoMessage() {}
+
+func (x *GCReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCReply.ProtoReflect.Descriptor instead.
+func (*GCReply) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GCReply) GetDbBytes() uint64 {
+	if x != nil {
+		return x.DbBytes
+	}
+	return 0
+}
+
+func (x *GCReply) GetNetBytes() uint64 {
+	if x != nil {
+		return x.NetBytes
+	}
+	return 0
+}
+
+type CreateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DbID           []byte   `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
+	CollectionName string   `protobuf:"bytes,2,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
+	Instances      [][]byte `protobuf:"bytes,3,rep,name=instances,proto3" json:"instances,omitempty"`
+}
+
+func (x *CreateRequest) Reset() {
+	*x = CreateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRequest) ProtoMessage() {}
+
+func (x *CreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRequest.ProtoReflect.Descriptor instead.
+func (*CreateRequest) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreateRequest) GetDbID() []byte {
+	if x != nil {
+		return x.DbID
+	}
+	return nil
+}
+
+func (x *CreateRequest) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *CreateRequest) GetInstances() [][]byte {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+type CreateReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InstanceIDs      []string `protobuf:"bytes,1,rep,name=instanceIDs,proto3" json:"instanceIDs,omitempty"`
+	TransactionError string   `protobuf:"bytes,2,opt,name=transactionError,proto3" json:"transactionError,omitempty"`
+}
+
+func (x *CreateReply) Reset() {
+	*x = CreateReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReply) ProtoMessage() {}
+
+func (x *CreateReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReply.ProtoReflect.Descriptor instead.
+func (*CreateReply) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CreateReply) GetInstanceIDs() []string {
+	if x != nil {
+		return x.InstanceIDs
+	}
+	return nil
+}
+
+func (x *CreateReply) GetTransactionError() string {
+	if x != nil {
+		return x.TransactionError
+	}
+	return ""
+}
+
+type VerifyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DbID           []byte   `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
+	CollectionName string   `protobuf:"bytes,2,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
+	Instances      [][]byte `protobuf:"bytes,3,rep,name=instances,proto3" json:"instances,omitempty"`
+}
+
+func (x *VerifyRequest) Reset() {
+	*x = VerifyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyRequest) ProtoMessage() {}
+
+func (x *VerifyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyRequest.ProtoReflect.Descriptor instead.
+func (*VerifyRequest) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *VerifyRequest) GetDbID() []byte {
+	if x != nil {
+		return x.DbID
+	}
+	return nil
+}
+
+func (x *VerifyRequest) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *VerifyRequest) GetInstances() [][]byte {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+type VerifyReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionError string `protobuf:"bytes,1,opt,name=transactionError,proto3" json:"transactionError,omitempty"`
+}
+
+func (x *VerifyReply) Reset() {
+	*x = VerifyReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyReply) ProtoMessage() {}
+
+func (x *VerifyReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyReply.ProtoReflect.Descriptor instead.
+func (*VerifyReply) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *VerifyReply) GetTransactionError() string {
+	if x != nil {
+		return x.TransactionError
+	}
+	return ""
+}
+
+type SaveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DbID           []byte   `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
+	CollectionName string   `protobuf:"bytes,2,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
+	Instances      [][]byte `protobuf:"bytes,3,rep,name=instances,proto3" json:"instances,omitempty"`
+}
+
+func (x *SaveRequest) Reset() {
+	*x = SaveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveRequest) ProtoMessage() {}
+
+func (x *SaveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveRequest.ProtoReflect.Descriptor instead.
+func (*SaveRequest) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SaveRequest) GetDbID() []byte {
+	if x != nil {
+		return x.DbID
+	}
+	return nil
+}
+
+func (x *SaveRequest) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *SaveRequest) GetInstances() [][]byte {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+type SaveReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionError string `protobuf:"bytes,1,opt,name=transactionError,proto3" json:"transactionError,omitempty"`
+}
+
+func (x *SaveReply) Reset() {
+	*x = SaveReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveReply) ProtoMessage() {}
+
+func (x *SaveReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveReply.ProtoReflect.Descriptor instead.
+func (*SaveReply) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SaveReply) GetTransactionError() string {
+	if x != nil {
+		return x.TransactionError
+	}
+	return ""
+}
+
+type SaveExpectingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DbID           []byte `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
+	CollectionName string `protobuf:"bytes,2,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
+	Instance       []byte `protobuf:"bytes,3,opt,name=instance,proto3" json:"instance,omitempty"`
+	Version        int64  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *SaveExpectingRequest) Reset() {
+	*x = SaveExpectingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveExpectingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveExpectingRequest) ProtoMessage() {}
+
+func (x *SaveExpectingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveExpectingRequest.ProtoReflect.Descriptor instead.
+func (*SaveExpectingRequest) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SaveExpectingRequest) GetDbID() []byte {
+	if x != nil {
+		return x.DbID
+	}
+	return nil
+}
+
+func (x *SaveExpectingRequest) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *SaveExpectingRequest) GetInstance() []byte {
+	if x != nil {
+		return x.Instance
+	}
+	return nil
+}
+
+func (x *SaveExpectingRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// A stale instance isn't saved, and the reply holds the version of the stored
+// instance and the instance, which is empty if it doesn't exist.
+type SaveExpectingReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionError string `protobuf:"bytes,1,opt,name=transactionError,proto3" json:"transactionError,omitempty"`
+	Stale            bool   `protobuf:"varint,2,opt,name=stale,proto3" json:"stale,omitempty"`
+	InstanceID       string `protobuf:"bytes,3,opt,name=instanceID,proto3" json:"instanceID,omitempty"`
+	Version          int64  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	Current          []byte `protobuf:"bytes,5,opt,name=current,proto3" json:"current,omitempty"`
+}
+
+func (x *SaveExpectingReply) Reset() {
+	*x = SaveExpectingReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveExpectingReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveExpectingReply) ProtoMessage() {}
+
+func (x *SaveExpectingReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveExpectingReply.ProtoReflect.Descriptor instead.
+func (*SaveExpectingReply) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *SaveExpectingReply) GetTransactionError() string {
+	if x != nil {
+		return x.TransactionError
+	}
+	return ""
+}
+
+func (x *SaveExpectingReply) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *SaveExpectingReply) GetInstanceID() string {
+	if x != nil {
+		return x.InstanceID
+	}
+	return ""
+}
+
+func (x *SaveExpectingReply) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SaveExpectingReply) GetCurrent() []byte {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+type DeleteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DbID           []byte   `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
+	CollectionName string   `protobuf:"bytes,2,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
+	InstanceIDs    []string `protobuf:"bytes,3,rep,name=instanceIDs,proto3" json:"instanceIDs,omitempty"`
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DeleteRequest) GetDbID() []byte {
+	if x != nil {
+		return x.DbID
+	}
+	return nil
+}
+
+func (x *DeleteRequest) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetInstanceIDs() []string {
+	if x != nil {
+		return x.InstanceIDs
+	}
+	return nil
+}
+
+type DeleteReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionError string `protobuf:"bytes,1,opt,name=transactionError,proto3" json:"transactionError,omitempty"`
+}
+
+func (x *DeleteReply) Reset() {
+	*x = DeleteReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteReply) ProtoMessage() {}
+
+func (x *DeleteReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-	InstanceIDs      []string `protobuf:"bytes,1,rep,name=instanceIDs,proto3" json:"instanceIDs,omitempty"`
-	TransactionError string   `protobuf:"bytes,2,opt,name=transactionError,proto3" json:"transactionError,omitempty"`
+// Deprecated: Use DeleteReply.ProtoReflect.Descriptor instead.
+func (*DeleteReply) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *CreateReply) Reset() {
-	*x = CreateReply{}
+func (x *DeleteReply) GetTransactionError() string {
+	if x != nil {
+		return x.TransactionError
+	}
+	return ""
+}
+
+type HasRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DbID           []byte   `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
+	CollectionName string   `protobuf:"bytes,2,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
+	InstanceIDs    []string `protobuf:"bytes,3,rep,name=instanceIDs,proto3" json:"instanceIDs,omitempty"`
+}
+
+func (x *HasRequest) Reset() {
+	*x = HasRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[26]
+		mi := &file_threads_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CreateReply) String() string {
+func (x *HasRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateReply) ProtoMessage() {}
+func (*HasRequest) ProtoMessage() {}
 
-func (x *CreateReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[26]
+func (x *HasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1629,52 +3112,114 @@ func (x *CreateReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateReply.ProtoReflect.Descriptor instead.
-func (*CreateReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use HasRequest.ProtoReflect.Descriptor instead.
+func (*HasRequest) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *CreateReply) GetInstanceIDs() []string {
+func (x *HasRequest) GetDbID() []byte {
+	if x != nil {
+		return x.DbID
+	}
+	return nil
+}
+
+func (x *HasRequest) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *HasRequest) GetInstanceIDs() []string {
 	if x != nil {
 		return x.InstanceIDs
 	}
 	return nil
 }
 
-func (x *CreateReply) GetTransactionError() string {
+type HasReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exists           bool   `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`
+	TransactionError string `protobuf:"bytes,2,opt,name=transactionError,proto3" json:"transactionError,omitempty"`
+}
+
+func (x *HasReply) Reset() {
+	*x = HasReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HasReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HasReply) ProtoMessage() {}
+
+func (x *HasReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HasReply.ProtoReflect.Descriptor instead.
+func (*HasReply) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *HasReply) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+func (x *HasReply) GetTransactionError() string {
 	if x != nil {
 		return x.TransactionError
 	}
 	return ""
 }
 
-type VerifyRequest struct {
+type FindRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DbID           []byte   `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
-	CollectionName string   `protobuf:"bytes,2,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
-	Instances      [][]byte `protobuf:"bytes,3,rep,name=instances,proto3" json:"instances,omitempty"`
+	DbID           []byte `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
+	CollectionName string `protobuf:"bytes,2,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
+	QueryJSON      []byte `protobuf:"bytes,3,opt,name=queryJSON,proto3" json:"queryJSON,omitempty"`
 }
 
-func (x *VerifyRequest) Reset() {
-	*x = VerifyRequest{}
+func (x *FindRequest) Reset() {
+	*x = FindRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[27]
+		mi := &file_threads_proto_msgTypes[50]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *VerifyRequest) String() string {
+func (x *FindRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyRequest) ProtoMessage() {}
+func (*FindRequest) ProtoMessage() {}
 
-func (x *VerifyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[27]
+func (x *FindRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[50]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1685,57 +3230,61 @@ func (x *VerifyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyRequest.ProtoReflect.Descriptor instead.
-func (*VerifyRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use FindRequest.ProtoReflect.Descriptor instead.
+func (*FindRequest) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *VerifyRequest) GetDbID() []byte {
+func (x *FindRequest) GetDbID() []byte {
 	if x != nil {
 		return x.DbID
 	}
 	return nil
 }
 
-func (x *VerifyRequest) GetCollectionName() string {
+func (x *FindRequest) GetCollectionName() string {
 	if x != nil {
 		return x.CollectionName
 	}
 	return ""
 }
 
-func (x *VerifyRequest) GetInstances() [][]byte {
+func (x *FindRequest) GetQueryJSON() []byte {
 	if x != nil {
-		return x.Instances
+		return x.QueryJSON
 	}
 	return nil
 }
 
-type VerifyReply struct {
+type FindReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TransactionError string `protobuf:"bytes,1,opt,name=transactionError,proto3" json:"transactionError,omitempty"`
+	Instances        [][]byte       `protobuf:"bytes,1,rep,name=instances,proto3" json:"instances,omitempty"`
+	TransactionError string         `protobuf:"bytes,2,opt,name=transactionError,proto3" json:"transactionError,omitempty"`
+	Cursor           string         `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Total            int64          `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Explain          *ExplainResult `protobuf:"bytes,5,opt,name=explain,proto3" json:"explain,omitempty"`
 }
 
-func (x *VerifyReply) Reset() {
-	*x = VerifyReply{}
+func (x *FindReply) Reset() {
+	*x = FindReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[28]
+		mi := &file_threads_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *VerifyReply) String() string {
+func (x *FindReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyReply) ProtoMessage() {}
+func (*FindReply) ProtoMessage() {}
 
-func (x *VerifyReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[28]
+func (x *FindReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1746,45 +3295,79 @@ func (x *VerifyReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyReply.ProtoReflect.Descriptor instead.
-func (*VerifyReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use FindReply.ProtoReflect.Descriptor instead.
+func (*FindReply) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *VerifyReply) GetTransactionError() string {
+func (x *FindReply) GetInstances() [][]byte {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+func (x *FindReply) GetTransactionError() string {
 	if x != nil {
 		return x.TransactionError
 	}
 	return ""
 }
 
-type SaveRequest struct {
+func (x *FindReply) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *FindReply) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *FindReply) GetExplain() *ExplainResult {
+	if x != nil {
+		return x.Explain
+	}
+	return nil
+}
+
+type ExplainResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DbID           []byte   `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
-	CollectionName string   `protobuf:"bytes,2,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
-	Instances      [][]byte `protobuf:"bytes,3,rep,name=instances,proto3" json:"instances,omitempty"`
+	Index          string   `protobuf:"bytes,1,opt,name=index,proto3" json:"index,omitempty"`
+	IndexKeys      int64    `protobuf:"varint,2,opt,name=indexKeys,proto3" json:"indexKeys,omitempty"`
+	InstancesRead  int64    `protobuf:"varint,3,opt,name=instancesRead,proto3" json:"instancesRead,omitempty"`
+	Matched        int64    `protobuf:"varint,4,opt,name=matched,proto3" json:"matched,omitempty"`
+	SortedInMemory bool     `protobuf:"varint,5,opt,name=sortedInMemory,proto3" json:"sortedInMemory,omitempty"`
+	PlanNanos      int64    `protobuf:"varint,6,opt,name=planNanos,proto3" json:"planNanos,omitempty"`
+	ReadNanos      int64    `protobuf:"varint,7,opt,name=readNanos,proto3" json:"readNanos,omitempty"`
+	SortNanos      int64    `protobuf:"varint,8,opt,name=sortNanos,proto3" json:"sortNanos,omitempty"`
+	Indexes        []string `protobuf:"bytes,9,rep,name=indexes,proto3" json:"indexes,omitempty"`
 }
 
-func (x *SaveRequest) Reset() {
-	*x = SaveRequest{}
+func (x *ExplainResult) Reset() {
+	*x = ExplainResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[29]
+		mi := &file_threads_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SaveRequest) String() string {
+func (x *ExplainResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SaveRequest) ProtoMessage() {}
+func (*ExplainResult) ProtoMessage() {}
 
-func (x *SaveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[29]
+func (x *ExplainResult) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1795,57 +3378,99 @@ func (x *SaveRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SaveRequest.ProtoReflect.Descriptor instead.
-func (*SaveRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use ExplainResult.ProtoReflect.Descriptor instead.
+func (*ExplainResult) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *SaveRequest) GetDbID() []byte {
+func (x *ExplainResult) GetIndex() string {
 	if x != nil {
-		return x.DbID
+		return x.Index
 	}
-	return nil
+	return ""
 }
 
-func (x *SaveRequest) GetCollectionName() string {
+func (x *ExplainResult) GetIndexKeys() int64 {
 	if x != nil {
-		return x.CollectionName
+		return x.IndexKeys
 	}
-	return ""
+	return 0
 }
 
-func (x *SaveRequest) GetInstances() [][]byte {
+func (x *ExplainResult) GetInstancesRead() int64 {
 	if x != nil {
-		return x.Instances
+		return x.InstancesRead
+	}
+	return 0
+}
+
+func (x *ExplainResult) GetMatched() int64 {
+	if x != nil {
+		return x.Matched
+	}
+	return 0
+}
+
+func (x *ExplainResult) GetSortedInMemory() bool {
+	if x != nil {
+		return x.SortedInMemory
+	}
+	return false
+}
+
+func (x *ExplainResult) GetPlanNanos() int64 {
+	if x != nil {
+		return x.PlanNanos
+	}
+	return 0
+}
+
+func (x *ExplainResult) GetReadNanos() int64 {
+	if x != nil {
+		return x.ReadNanos
+	}
+	return 0
+}
+
+func (x *ExplainResult) GetSortNanos() int64 {
+	if x != nil {
+		return x.SortNanos
+	}
+	return 0
+}
+
+func (x *ExplainResult) GetIndexes() []string {
+	if x != nil {
+		return x.Indexes
 	}
 	return nil
 }
 
-type SaveReply struct {
+type FindStreamReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TransactionError string `protobuf:"bytes,1,opt,name=transactionError,proto3" json:"transactionError,omitempty"`
+	Instance []byte `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
 }
 
-func (x *SaveReply) Reset() {
-	*x = SaveReply{}
+func (x *FindStreamReply) Reset() {
+	*x = FindStreamReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[30]
+		mi := &file_threads_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SaveReply) String() string {
+func (x *FindStreamReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SaveReply) ProtoMessage() {}
+func (*FindStreamReply) ProtoMessage() {}
 
-func (x *SaveReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[30]
+func (x *FindStreamReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1856,45 +3481,45 @@ func (x *SaveReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SaveReply.ProtoReflect.Descriptor instead.
-func (*SaveReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use FindStreamReply.ProtoReflect.Descriptor instead.
+func (*FindStreamReply) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *SaveReply) GetTransactionError() string {
+func (x *FindStreamReply) GetInstance() []byte {
 	if x != nil {
-		return x.TransactionError
+		return x.Instance
 	}
-	return ""
+	return nil
 }
 
-type DeleteRequest struct {
+type FindByIDRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DbID           []byte   `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
-	CollectionName string   `protobuf:"bytes,2,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
-	InstanceIDs    []string `protobuf:"bytes,3,rep,name=instanceIDs,proto3" json:"instanceIDs,omitempty"`
+	DbID           []byte `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
+	CollectionName string `protobuf:"bytes,2,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
+	InstanceID     string `protobuf:"bytes,3,opt,name=instanceID,proto3" json:"instanceID,omitempty"`
 }
 
-func (x *DeleteRequest) Reset() {
-	*x = DeleteRequest{}
+func (x *FindByIDRequest) Reset() {
+	*x = FindByIDRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[31]
+		mi := &file_threads_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DeleteRequest) String() string {
+func (x *FindByIDRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteRequest) ProtoMessage() {}
+func (*FindByIDRequest) ProtoMessage() {}
 
-func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[31]
+func (x *FindByIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1905,57 +3530,58 @@ func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
-func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use FindByIDRequest.ProtoReflect.Descriptor instead.
+func (*FindByIDRequest) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *DeleteRequest) GetDbID() []byte {
+func (x *FindByIDRequest) GetDbID() []byte {
 	if x != nil {
 		return x.DbID
 	}
 	return nil
 }
 
-func (x *DeleteRequest) GetCollectionName() string {
+func (x *FindByIDRequest) GetCollectionName() string {
 	if x != nil {
 		return x.CollectionName
 	}
 	return ""
 }
 
-func (x *DeleteRequest) GetInstanceIDs() []string {
+func (x *FindByIDRequest) GetInstanceID() string {
 	if x != nil {
-		return x.InstanceIDs
+		return x.InstanceID
 	}
-	return nil
+	return ""
 }
 
-type DeleteReply struct {
+type FindByIDReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TransactionError string `protobuf:"bytes,1,opt,name=transactionError,proto3" json:"transactionError,omitempty"`
+	Instance         []byte `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	TransactionError string `protobuf:"bytes,2,opt,name=transactionError,proto3" json:"transactionError,omitempty"`
 }
 
-func (x *DeleteReply) Reset() {
-	*x = DeleteReply{}
+func (x *FindByIDReply) Reset() {
+	*x = FindByIDReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[32]
+		mi := &file_threads_proto_msgTypes[55]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DeleteReply) String() string {
+func (x *FindByIDReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteReply) ProtoMessage() {}
+func (*FindByIDReply) ProtoMessage() {}
 
-func (x *DeleteReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[32]
+func (x *FindByIDReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[55]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1966,19 +3592,26 @@ func (x *DeleteReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteReply.ProtoReflect.Descriptor instead.
-func (*DeleteReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use FindByIDReply.ProtoReflect.Descriptor instead.
+func (*FindByIDReply) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *DeleteReply) GetTransactionError() string {
+func (x *FindByIDReply) GetInstance() []byte {
+	if x != nil {
+		return x.Instance
+	}
+	return nil
+}
+
+func (x *FindByIDReply) GetTransactionError() string {
 	if x != nil {
 		return x.TransactionError
 	}
 	return ""
 }
 
-type HasRequest struct {
+type FindByIDsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -1988,23 +3621,23 @@ type HasRequest struct {
 	InstanceIDs    []string `protobuf:"bytes,3,rep,name=instanceIDs,proto3" json:"instanceIDs,omitempty"`
 }
 
-func (x *HasRequest) Reset() {
-	*x = HasRequest{}
+func (x *FindByIDsRequest) Reset() {
+	*x = FindByIDsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[33]
+		mi := &file_threads_proto_msgTypes[56]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *HasRequest) String() string {
+func (x *FindByIDsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HasRequest) ProtoMessage() {}
+func (*FindByIDsRequest) ProtoMessage() {}
 
-func (x *HasRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[33]
+func (x *FindByIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[56]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2015,58 +3648,61 @@ func (x *HasRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HasRequest.ProtoReflect.Descriptor instead.
-func (*HasRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use FindByIDsRequest.ProtoReflect.Descriptor instead.
+func (*FindByIDsRequest) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *HasRequest) GetDbID() []byte {
+func (x *FindByIDsRequest) GetDbID() []byte {
 	if x != nil {
 		return x.DbID
 	}
 	return nil
 }
 
-func (x *HasRequest) GetCollectionName() string {
+func (x *FindByIDsRequest) GetCollectionName() string {
 	if x != nil {
 		return x.CollectionName
 	}
 	return ""
 }
 
-func (x *HasRequest) GetInstanceIDs() []string {
+func (x *FindByIDsRequest) GetInstanceIDs() []string {
 	if x != nil {
 		return x.InstanceIDs
 	}
 	return nil
 }
 
-type HasReply struct {
+// Instances are in the order of the requested IDs, and found is false for
+// those which don't exist.
+type FindByIDsReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Exists           bool   `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`
-	TransactionError string `protobuf:"bytes,2,opt,name=transactionError,proto3" json:"transactionError,omitempty"`
+	Instances        [][]byte `protobuf:"bytes,1,rep,name=instances,proto3" json:"instances,omitempty"`
+	Found            []bool   `protobuf:"varint,2,rep,packed,name=found,proto3" json:"found,omitempty"`
+	TransactionError string   `protobuf:"bytes,3,opt,name=transactionError,proto3" json:"transactionError,omitempty"`
 }
 
-func (x *HasReply) Reset() {
-	*x = HasReply{}
+func (x *FindByIDsReply) Reset() {
+	*x = FindByIDsReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[34]
+		mi := &file_threads_proto_msgTypes[57]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *HasReply) String() string {
+func (x *FindByIDsReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HasReply) ProtoMessage() {}
+func (*FindByIDsReply) ProtoMessage() {}
 
-func (x *HasReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[34]
+func (x *FindByIDsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[57]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2077,26 +3713,33 @@ func (x *HasReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HasReply.ProtoReflect.Descriptor instead.
-func (*HasReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use FindByIDsReply.ProtoReflect.Descriptor instead.
+func (*FindByIDsReply) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *HasReply) GetExists() bool {
+func (x *FindByIDsReply) GetInstances() [][]byte {
 	if x != nil {
-		return x.Exists
+		return x.Instances
 	}
-	return false
+	return nil
 }
 
-func (x *HasReply) GetTransactionError() string {
+func (x *FindByIDsReply) GetFound() []bool {
+	if x != nil {
+		return x.Found
+	}
+	return nil
+}
+
+func (x *FindByIDsReply) GetTransactionError() string {
 	if x != nil {
 		return x.TransactionError
 	}
 	return ""
 }
 
-type FindRequest struct {
+type CountRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -2106,23 +3749,23 @@ type FindRequest struct {
 	QueryJSON      []byte `protobuf:"bytes,3,opt,name=queryJSON,proto3" json:"queryJSON,omitempty"`
 }
 
-func (x *FindRequest) Reset() {
-	*x = FindRequest{}
+func (x *CountRequest) Reset() {
+	*x = CountRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[35]
+		mi := &file_threads_proto_msgTypes[58]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FindRequest) String() string {
+func (x *CountRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FindRequest) ProtoMessage() {}
+func (*CountRequest) ProtoMessage() {}
 
-func (x *FindRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[35]
+func (x *CountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[58]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2133,58 +3776,58 @@ func (x *FindRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FindRequest.ProtoReflect.Descriptor instead.
-func (*FindRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use CountRequest.ProtoReflect.Descriptor instead.
+func (*CountRequest) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *FindRequest) GetDbID() []byte {
+func (x *CountRequest) GetDbID() []byte {
 	if x != nil {
 		return x.DbID
 	}
 	return nil
 }
 
-func (x *FindRequest) GetCollectionName() string {
+func (x *CountRequest) GetCollectionName() string {
 	if x != nil {
 		return x.CollectionName
 	}
 	return ""
 }
 
-func (x *FindRequest) GetQueryJSON() []byte {
+func (x *CountRequest) GetQueryJSON() []byte {
 	if x != nil {
 		return x.QueryJSON
 	}
 	return nil
 }
 
-type FindReply struct {
+type CountReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Instances        [][]byte `protobuf:"bytes,1,rep,name=instances,proto3" json:"instances,omitempty"`
-	TransactionError string   `protobuf:"bytes,2,opt,name=transactionError,proto3" json:"transactionError,omitempty"`
+	Count            int64  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	TransactionError string `protobuf:"bytes,2,opt,name=transactionError,proto3" json:"transactionError,omitempty"`
 }
 
-func (x *FindReply) Reset() {
-	*x = FindReply{}
+func (x *CountReply) Reset() {
+	*x = CountReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[36]
+		mi := &file_threads_proto_msgTypes[59]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FindReply) String() string {
+func (x *CountReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FindReply) ProtoMessage() {}
+func (*CountReply) ProtoMessage() {}
 
-func (x *FindReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[36]
+func (x *CountReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[59]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2195,52 +3838,52 @@ func (x *FindReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FindReply.ProtoReflect.Descriptor instead.
-func (*FindReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use CountReply.ProtoReflect.Descriptor instead.
+func (*CountReply) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *FindReply) GetInstances() [][]byte {
+func (x *CountReply) GetCount() int64 {
 	if x != nil {
-		return x.Instances
+		return x.Count
 	}
-	return nil
+	return 0
 }
 
-func (x *FindReply) GetTransactionError() string {
+func (x *CountReply) GetTransactionError() string {
 	if x != nil {
 		return x.TransactionError
 	}
 	return ""
 }
 
-type FindByIDRequest struct {
+type ValidateCollectionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	DbID           []byte `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
 	CollectionName string `protobuf:"bytes,2,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
-	InstanceID     string `protobuf:"bytes,3,opt,name=instanceID,proto3" json:"instanceID,omitempty"`
+	Limit          int64  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 }
 
-func (x *FindByIDRequest) Reset() {
-	*x = FindByIDRequest{}
+func (x *ValidateCollectionRequest) Reset() {
+	*x = ValidateCollectionRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[37]
+		mi := &file_threads_proto_msgTypes[60]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FindByIDRequest) String() string {
+func (x *ValidateCollectionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FindByIDRequest) ProtoMessage() {}
+func (*ValidateCollectionRequest) ProtoMessage() {}
 
-func (x *FindByIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[37]
+func (x *ValidateCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[60]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2251,58 +3894,57 @@ func (x *FindByIDRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FindByIDRequest.ProtoReflect.Descriptor instead.
-func (*FindByIDRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use ValidateCollectionRequest.ProtoReflect.Descriptor instead.
+func (*ValidateCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *FindByIDRequest) GetDbID() []byte {
+func (x *ValidateCollectionRequest) GetDbID() []byte {
 	if x != nil {
 		return x.DbID
 	}
 	return nil
 }
 
-func (x *FindByIDRequest) GetCollectionName() string {
+func (x *ValidateCollectionRequest) GetCollectionName() string {
 	if x != nil {
 		return x.CollectionName
 	}
 	return ""
 }
 
-func (x *FindByIDRequest) GetInstanceID() string {
+func (x *ValidateCollectionRequest) GetLimit() int64 {
 	if x != nil {
-		return x.InstanceID
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-type FindByIDReply struct {
+type ValidateCollectionReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Instance         []byte `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
-	TransactionError string `protobuf:"bytes,2,opt,name=transactionError,proto3" json:"transactionError,omitempty"`
+	Errors []*ValidationError `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
 }
 
-func (x *FindByIDReply) Reset() {
-	*x = FindByIDReply{}
+func (x *ValidateCollectionReply) Reset() {
+	*x = ValidateCollectionReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[38]
+		mi := &file_threads_proto_msgTypes[61]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FindByIDReply) String() string {
+func (x *ValidateCollectionReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FindByIDReply) ProtoMessage() {}
+func (*ValidateCollectionReply) ProtoMessage() {}
 
-func (x *FindByIDReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[38]
+func (x *ValidateCollectionReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[61]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2313,25 +3955,89 @@ func (x *FindByIDReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FindByIDReply.ProtoReflect.Descriptor instead.
-func (*FindByIDReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{38}
+// Deprecated: Use ValidateCollectionReply.ProtoReflect.Descriptor instead.
+func (*ValidateCollectionReply) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *FindByIDReply) GetInstance() []byte {
+func (x *ValidateCollectionReply) GetErrors() []*ValidationError {
 	if x != nil {
-		return x.Instance
+		return x.Errors
 	}
 	return nil
 }
 
-func (x *FindByIDReply) GetTransactionError() string {
+type ValidationError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InstanceID string `protobuf:"bytes,1,opt,name=instanceID,proto3" json:"instanceID,omitempty"`
+	Field      string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Message    string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Legacy     bool   `protobuf:"varint,4,opt,name=legacy,proto3" json:"legacy,omitempty"`
+}
+
+func (x *ValidationError) Reset() {
+	*x = ValidationError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threads_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidationError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationError) ProtoMessage() {}
+
+func (x *ValidationError) ProtoReflect() protoreflect.Message {
+	mi := &file_threads_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationError.ProtoReflect.Descriptor instead.
+func (*ValidationError) Descriptor() ([]byte, []int) {
+	return file_threads_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ValidationError) GetInstanceID() string {
 	if x != nil {
-		return x.TransactionError
+		return x.InstanceID
+	}
+	return ""
+}
+
+func (x *ValidationError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ValidationError) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
+func (x *ValidationError) GetLegacy() bool {
+	if x != nil {
+		return x.Legacy
+	}
+	return false
+}
+
 type DiscardRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2341,7 +4047,7 @@ type DiscardRequest struct {
 func (x *DiscardRequest) Reset() {
 	*x = DiscardRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[39]
+		mi := &file_threads_proto_msgTypes[63]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2354,7 +4060,7 @@ func (x *DiscardRequest) String() string {
 func (*DiscardRequest) ProtoMessage() {}
 
 func (x *DiscardRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[39]
+	mi := &file_threads_proto_msgTypes[63]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2367,7 +4073,7 @@ func (x *DiscardRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiscardRequest.ProtoReflect.Descriptor instead.
 func (*DiscardRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{39}
+	return file_threads_proto_rawDescGZIP(), []int{63}
 }
 
 type DiscardReply struct {
@@ -2379,7 +4085,7 @@ type DiscardReply struct {
 func (x *DiscardReply) Reset() {
 	*x = DiscardReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[40]
+		mi := &file_threads_proto_msgTypes[64]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2392,7 +4098,7 @@ func (x *DiscardReply) String() string {
 func (*DiscardReply) ProtoMessage() {}
 
 func (x *DiscardReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[40]
+	mi := &file_threads_proto_msgTypes[64]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2405,7 +4111,7 @@ func (x *DiscardReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiscardReply.ProtoReflect.Descriptor instead.
 func (*DiscardReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{40}
+	return file_threads_proto_rawDescGZIP(), []int{64}
 }
 
 type StartTransactionRequest struct {
@@ -2420,7 +4126,7 @@ type StartTransactionRequest struct {
 func (x *StartTransactionRequest) Reset() {
 	*x = StartTransactionRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[41]
+		mi := &file_threads_proto_msgTypes[65]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2433,7 +4139,7 @@ func (x *StartTransactionRequest) String() string {
 func (*StartTransactionRequest) ProtoMessage() {}
 
 func (x *StartTransactionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[41]
+	mi := &file_threads_proto_msgTypes[65]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2446,7 +4152,7 @@ func (x *StartTransactionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartTransactionRequest.ProtoReflect.Descriptor instead.
 func (*StartTransactionRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{41}
+	return file_threads_proto_rawDescGZIP(), []int{65}
 }
 
 func (x *StartTransactionRequest) GetDbID() []byte {
@@ -2473,13 +4179,15 @@ type ReadTransactionRequest struct {
 	//	*ReadTransactionRequest_HasRequest
 	//	*ReadTransactionRequest_FindRequest
 	//	*ReadTransactionRequest_FindByIDRequest
+	//	*ReadTransactionRequest_CountRequest
+	//	*ReadTransactionRequest_FindByIDsRequest
 	Option isReadTransactionRequest_Option `protobuf_oneof:"option"`
 }
 
 func (x *ReadTransactionRequest) Reset() {
 	*x = ReadTransactionRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[42]
+		mi := &file_threads_proto_msgTypes[66]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2492,7 +4200,7 @@ func (x *ReadTransactionRequest) String() string {
 func (*ReadTransactionRequest) ProtoMessage() {}
 
 func (x *ReadTransactionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[42]
+	mi := &file_threads_proto_msgTypes[66]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2505,7 +4213,7 @@ func (x *ReadTransactionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReadTransactionRequest.ProtoReflect.Descriptor instead.
 func (*ReadTransactionRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{42}
+	return file_threads_proto_rawDescGZIP(), []int{66}
 }
 
 func (m *ReadTransactionRequest) GetOption() isReadTransactionRequest_Option {
@@ -2543,6 +4251,20 @@ func (x *ReadTransactionRequest) GetFindByIDRequest() *FindByIDRequest {
 	return nil
 }
 
+func (x *ReadTransactionRequest) GetCountRequest() *CountRequest {
+	if x, ok := x.GetOption().(*ReadTransactionRequest_CountRequest); ok {
+		return x.CountRequest
+	}
+	return nil
+}
+
+func (x *ReadTransactionRequest) GetFindByIDsRequest() *FindByIDsRequest {
+	if x, ok := x.GetOption().(*ReadTransactionRequest_FindByIDsRequest); ok {
+		return x.FindByIDsRequest
+	}
+	return nil
+}
+
 type isReadTransactionRequest_Option interface {
 	isReadTransactionRequest_Option()
 }
@@ -2563,6 +4285,14 @@ type ReadTransactionRequest_FindByIDRequest struct {
 	FindByIDRequest *FindByIDRequest `protobuf:"bytes,4,opt,name=findByIDRequest,proto3,oneof"`
 }
 
+type ReadTransactionRequest_CountRequest struct {
+	CountRequest *CountRequest `protobuf:"bytes,5,opt,name=countRequest,proto3,oneof"`
+}
+
+type ReadTransactionRequest_FindByIDsRequest struct {
+	FindByIDsRequest *FindByIDsRequest `protobuf:"bytes,6,opt,name=findByIDsRequest,proto3,oneof"`
+}
+
 func (*ReadTransactionRequest_StartTransactionRequest) isReadTransactionRequest_Option() {}
 
 func (*ReadTransactionRequest_HasRequest) isReadTransactionRequest_Option() {}
@@ -2571,6 +4301,10 @@ func (*ReadTransactionRequest_FindRequest) isReadTransactionRequest_Option() {}
 
 func (*ReadTransactionRequest_FindByIDRequest) isReadTransactionRequest_Option() {}
 
+func (*ReadTransactionRequest_CountRequest) isReadTransactionRequest_Option() {}
+
+func (*ReadTransactionRequest_FindByIDsRequest) isReadTransactionRequest_Option() {}
+
 type ReadTransactionReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2580,13 +4314,15 @@ type ReadTransactionReply struct {
 	//	*ReadTransactionReply_HasReply
 	//	*ReadTransactionReply_FindReply
 	//	*ReadTransactionReply_FindByIDReply
+	//	*ReadTransactionReply_CountReply
+	//	*ReadTransactionReply_FindByIDsReply
 	Option isReadTransactionReply_Option `protobuf_oneof:"option"`
 }
 
 func (x *ReadTransactionReply) Reset() {
 	*x = ReadTransactionReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[43]
+		mi := &file_threads_proto_msgTypes[67]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2599,7 +4335,7 @@ func (x *ReadTransactionReply) String() string {
 func (*ReadTransactionReply) ProtoMessage() {}
 
 func (x *ReadTransactionReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[43]
+	mi := &file_threads_proto_msgTypes[67]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2612,7 +4348,7 @@ func (x *ReadTransactionReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReadTransactionReply.ProtoReflect.Descriptor instead.
 func (*ReadTransactionReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{43}
+	return file_threads_proto_rawDescGZIP(), []int{67}
 }
 
 func (m *ReadTransactionReply) GetOption() isReadTransactionReply_Option {
@@ -2636,9 +4372,23 @@ func (x *ReadTransactionReply) GetFindReply() *FindReply {
 	return nil
 }
 
-func (x *ReadTransactionReply) GetFindByIDReply() *FindByIDReply {
-	if x, ok := x.GetOption().(*ReadTransactionReply_FindByIDReply); ok {
-		return x.FindByIDReply
+func (x *ReadTransactionReply) GetFindByIDReply() *FindByIDReply {
+	if x, ok := x.GetOption().(*ReadTransactionReply_FindByIDReply); ok {
+		return x.FindByIDReply
+	}
+	return nil
+}
+
+func (x *ReadTransactionReply) GetCountReply() *CountReply {
+	if x, ok := x.GetOption().(*ReadTransactionReply_CountReply); ok {
+		return x.CountReply
+	}
+	return nil
+}
+
+func (x *ReadTransactionReply) GetFindByIDsReply() *FindByIDsReply {
+	if x, ok := x.GetOption().(*ReadTransactionReply_FindByIDsReply); ok {
+		return x.FindByIDsReply
 	}
 	return nil
 }
@@ -2659,12 +4409,24 @@ type ReadTransactionReply_FindByIDReply struct {
 	FindByIDReply *FindByIDReply `protobuf:"bytes,3,opt,name=findByIDReply,proto3,oneof"`
 }
 
+type ReadTransactionReply_CountReply struct {
+	CountReply *CountReply `protobuf:"bytes,4,opt,name=countReply,proto3,oneof"`
+}
+
+type ReadTransactionReply_FindByIDsReply struct {
+	FindByIDsReply *FindByIDsReply `protobuf:"bytes,5,opt,name=findByIDsReply,proto3,oneof"`
+}
+
 func (*ReadTransactionReply_HasReply) isReadTransactionReply_Option() {}
 
 func (*ReadTransactionReply_FindReply) isReadTransactionReply_Option() {}
 
 func (*ReadTransactionReply_FindByIDReply) isReadTransactionReply_Option() {}
 
+func (*ReadTransactionReply_CountReply) isReadTransactionReply_Option() {}
+
+func (*ReadTransactionReply_FindByIDsReply) isReadTransactionReply_Option() {}
+
 type WriteTransactionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2680,13 +4442,15 @@ type WriteTransactionRequest struct {
 	//	*WriteTransactionRequest_FindRequest
 	//	*WriteTransactionRequest_FindByIDRequest
 	//	*WriteTransactionRequest_DiscardRequest
+	//	*WriteTransactionRequest_FindByIDsRequest
+	//	*WriteTransactionRequest_SaveExpectingRequest
 	Option isWriteTransactionRequest_Option `protobuf_oneof:"option"`
 }
 
 func (x *WriteTransactionRequest) Reset() {
 	*x = WriteTransactionRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[44]
+		mi := &file_threads_proto_msgTypes[68]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2699,7 +4463,7 @@ func (x *WriteTransactionRequest) String() string {
 func (*WriteTransactionRequest) ProtoMessage() {}
 
 func (x *WriteTransactionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[44]
+	mi := &file_threads_proto_msgTypes[68]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2712,7 +4476,7 @@ func (x *WriteTransactionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteTransactionRequest.ProtoReflect.Descriptor instead.
 func (*WriteTransactionRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{44}
+	return file_threads_proto_rawDescGZIP(), []int{68}
 }
 
 func (m *WriteTransactionRequest) GetOption() isWriteTransactionRequest_Option {
@@ -2785,6 +4549,20 @@ func (x *WriteTransactionRequest) GetDiscardRequest() *DiscardRequest {
 	return nil
 }
 
+func (x *WriteTransactionRequest) GetFindByIDsRequest() *FindByIDsRequest {
+	if x, ok := x.GetOption().(*WriteTransactionRequest_FindByIDsRequest); ok {
+		return x.FindByIDsRequest
+	}
+	return nil
+}
+
+func (x *WriteTransactionRequest) GetSaveExpectingRequest() *SaveExpectingRequest {
+	if x, ok := x.GetOption().(*WriteTransactionRequest_SaveExpectingRequest); ok {
+		return x.SaveExpectingRequest
+	}
+	return nil
+}
+
 type isWriteTransactionRequest_Option interface {
 	isWriteTransactionRequest_Option()
 }
@@ -2825,6 +4603,14 @@ type WriteTransactionRequest_DiscardRequest struct {
 	DiscardRequest *DiscardRequest `protobuf:"bytes,9,opt,name=discardRequest,proto3,oneof"`
 }
 
+type WriteTransactionRequest_FindByIDsRequest struct {
+	FindByIDsRequest *FindByIDsRequest `protobuf:"bytes,10,opt,name=findByIDsRequest,proto3,oneof"`
+}
+
+type WriteTransactionRequest_SaveExpectingRequest struct {
+	SaveExpectingRequest *SaveExpectingRequest `protobuf:"bytes,11,opt,name=saveExpectingRequest,proto3,oneof"`
+}
+
 func (*WriteTransactionRequest_StartTransactionRequest) isWriteTransactionRequest_Option() {}
 
 func (*WriteTransactionRequest_CreateRequest) isWriteTransactionRequest_Option() {}
@@ -2843,6 +4629,10 @@ func (*WriteTransactionRequest_FindByIDRequest) isWriteTransactionRequest_Option
 
 func (*WriteTransactionRequest_DiscardRequest) isWriteTransactionRequest_Option() {}
 
+func (*WriteTransactionRequest_FindByIDsRequest) isWriteTransactionRequest_Option() {}
+
+func (*WriteTransactionRequest_SaveExpectingRequest) isWriteTransactionRequest_Option() {}
+
 type WriteTransactionReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2857,13 +4647,15 @@ type WriteTransactionReply struct {
 	//	*WriteTransactionReply_FindReply
 	//	*WriteTransactionReply_FindByIDReply
 	//	*WriteTransactionReply_DiscardReply
+	//	*WriteTransactionReply_FindByIDsReply
+	//	*WriteTransactionReply_SaveExpectingReply
 	Option isWriteTransactionReply_Option `protobuf_oneof:"option"`
 }
 
 func (x *WriteTransactionReply) Reset() {
 	*x = WriteTransactionReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[45]
+		mi := &file_threads_proto_msgTypes[69]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2876,7 +4668,7 @@ func (x *WriteTransactionReply) String() string {
 func (*WriteTransactionReply) ProtoMessage() {}
 
 func (x *WriteTransactionReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[45]
+	mi := &file_threads_proto_msgTypes[69]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2889,7 +4681,7 @@ func (x *WriteTransactionReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteTransactionReply.ProtoReflect.Descriptor instead.
 func (*WriteTransactionReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{45}
+	return file_threads_proto_rawDescGZIP(), []int{69}
 }
 
 func (m *WriteTransactionReply) GetOption() isWriteTransactionReply_Option {
@@ -2955,6 +4747,20 @@ func (x *WriteTransactionReply) GetDiscardReply() *DiscardReply {
 	return nil
 }
 
+func (x *WriteTransactionReply) GetFindByIDsReply() *FindByIDsReply {
+	if x, ok := x.GetOption().(*WriteTransactionReply_FindByIDsReply); ok {
+		return x.FindByIDsReply
+	}
+	return nil
+}
+
+func (x *WriteTransactionReply) GetSaveExpectingReply() *SaveExpectingReply {
+	if x, ok := x.GetOption().(*WriteTransactionReply_SaveExpectingReply); ok {
+		return x.SaveExpectingReply
+	}
+	return nil
+}
+
 type isWriteTransactionReply_Option interface {
 	isWriteTransactionReply_Option()
 }
@@ -2991,6 +4797,14 @@ type WriteTransactionReply_DiscardReply struct {
 	DiscardReply *DiscardReply `protobuf:"bytes,8,opt,name=discardReply,proto3,oneof"`
 }
 
+type WriteTransactionReply_FindByIDsReply struct {
+	FindByIDsReply *FindByIDsReply `protobuf:"bytes,9,opt,name=findByIDsReply,proto3,oneof"`
+}
+
+type WriteTransactionReply_SaveExpectingReply struct {
+	SaveExpectingReply *SaveExpectingReply `protobuf:"bytes,10,opt,name=saveExpectingReply,proto3,oneof"`
+}
+
 func (*WriteTransactionReply_CreateReply) isWriteTransactionReply_Option() {}
 
 func (*WriteTransactionReply_VerifyReply) isWriteTransactionReply_Option() {}
@@ -3007,6 +4821,10 @@ func (*WriteTransactionReply_FindByIDReply) isWriteTransactionReply_Option() {}
 
 func (*WriteTransactionReply_DiscardReply) isWriteTransactionReply_Option() {}
 
+func (*WriteTransactionReply_FindByIDsReply) isWriteTransactionReply_Option() {}
+
+func (*WriteTransactionReply_SaveExpectingReply) isWriteTransactionReply_Option() {}
+
 type ListenRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -3019,7 +4837,7 @@ type ListenRequest struct {
 func (x *ListenRequest) Reset() {
 	*x = ListenRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[46]
+		mi := &file_threads_proto_msgTypes[70]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3032,7 +4850,7 @@ func (x *ListenRequest) String() string {
 func (*ListenRequest) ProtoMessage() {}
 
 func (x *ListenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[46]
+	mi := &file_threads_proto_msgTypes[70]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3045,7 +4863,7 @@ func (x *ListenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListenRequest.ProtoReflect.Descriptor instead.
 func (*ListenRequest) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{46}
+	return file_threads_proto_rawDescGZIP(), []int{70}
 }
 
 func (x *ListenRequest) GetDbID() []byte {
@@ -3076,7 +4894,7 @@ type ListenReply struct {
 func (x *ListenReply) Reset() {
 	*x = ListenReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[47]
+		mi := &file_threads_proto_msgTypes[71]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3089,7 +4907,7 @@ func (x *ListenReply) String() string {
 func (*ListenReply) ProtoMessage() {}
 
 func (x *ListenReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[47]
+	mi := &file_threads_proto_msgTypes[71]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3102,7 +4920,7 @@ func (x *ListenReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListenReply.ProtoReflect.Descriptor instead.
 func (*ListenReply) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{47}
+	return file_threads_proto_rawDescGZIP(), []int{71}
 }
 
 func (x *ListenReply) GetCollectionName() string {
@@ -3138,14 +4956,18 @@ type ListDBsReply_DB struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DbID []byte          `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
-	Info *GetDBInfoReply `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	DbID         []byte          `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
+	Info         *GetDBInfoReply `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	Name         string          `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Collections  []string        `protobuf:"bytes,4,rep,name=collections,proto3" json:"collections,omitempty"`
+	Created      int64           `protobuf:"varint,5,opt,name=created,proto3" json:"created,omitempty"`
+	LastModified int64           `protobuf:"varint,6,opt,name=lastModified,proto3" json:"lastModified,omitempty"`
 }
 
 func (x *ListDBsReply_DB) Reset() {
 	*x = ListDBsReply_DB{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[48]
+		mi := &file_threads_proto_msgTypes[72]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3158,7 +4980,7 @@ func (x *ListDBsReply_DB) String() string {
 func (*ListDBsReply_DB) ProtoMessage() {}
 
 func (x *ListDBsReply_DB) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[48]
+	mi := &file_threads_proto_msgTypes[72]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3171,7 +4993,7 @@ func (x *ListDBsReply_DB) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDBsReply_DB.ProtoReflect.Descriptor instead.
 func (*ListDBsReply_DB) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{8, 0}
+	return file_threads_proto_rawDescGZIP(), []int{9, 0}
 }
 
 func (x *ListDBsReply_DB) GetDbID() []byte {
@@ -3188,6 +5010,34 @@ func (x *ListDBsReply_DB) GetInfo() *GetDBInfoReply {
 	return nil
 }
 
+func (x *ListDBsReply_DB) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListDBsReply_DB) GetCollections() []string {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+func (x *ListDBsReply_DB) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ListDBsReply_DB) GetLastModified() int64 {
+	if x != nil {
+		return x.LastModified
+	}
+	return 0
+}
+
 type ListenRequest_Filter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -3196,12 +5046,13 @@ type ListenRequest_Filter struct {
 	CollectionName string                      `protobuf:"bytes,1,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
 	InstanceID     string                      `protobuf:"bytes,2,opt,name=instanceID,proto3" json:"instanceID,omitempty"`
 	Action         ListenRequest_Filter_Action `protobuf:"varint,3,opt,name=action,proto3,enum=threads.pb.ListenRequest_Filter_Action" json:"action,omitempty"`
+	QueryJSON      []byte                      `protobuf:"bytes,4,opt,name=queryJSON,proto3" json:"queryJSON,omitempty"`
 }
 
 func (x *ListenRequest_Filter) Reset() {
 	*x = ListenRequest_Filter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threads_proto_msgTypes[49]
+		mi := &file_threads_proto_msgTypes[73]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3214,7 +5065,7 @@ func (x *ListenRequest_Filter) String() string {
 func (*ListenRequest_Filter) ProtoMessage() {}
 
 func (x *ListenRequest_Filter) ProtoReflect() protoreflect.Message {
-	mi := &file_threads_proto_msgTypes[49]
+	mi := &file_threads_proto_msgTypes[73]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3227,7 +5078,7 @@ func (x *ListenRequest_Filter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListenRequest_Filter.ProtoReflect.Descriptor instead.
 func (*ListenRequest_Filter) Descriptor() ([]byte, []int) {
-	return file_threads_proto_rawDescGZIP(), []int{46, 0}
+	return file_threads_proto_rawDescGZIP(), []int{70, 0}
 }
 
 func (x *ListenRequest_Filter) GetCollectionName() string {
@@ -3251,6 +5102,13 @@ func (x *ListenRequest_Filter) GetAction() ListenRequest_Filter_Action {
 	return ListenRequest_Filter_ALL
 }
 
+func (x *ListenRequest_Filter) GetQueryJSON() []byte {
+	if x != nil {
+		return x.QueryJSON
+	}
+	return nil
+}
+
 var File_threads_proto protoreflect.FileDescriptor
 
 var file_threads_proto_rawDesc = []byte{
@@ -3292,7 +5150,7 @@ var file_threads_proto_rawDesc = []byte{
 	0x63, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12,
 	0x20, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x4b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01,
 	0x28, 0x0c, 0x42, 0x02, 0x18, 0x01, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x4b, 0x65,
-	0x79, 0x22, 0xb3, 0x01, 0x0a, 0x10, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x79, 0x22, 0x9b, 0x02, 0x0a, 0x10, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
 	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63,
 	0x68, 0x65, 0x6d, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65,
@@ -3303,69 +5161,144 @@ var file_threads_proto_rawDesc = []byte{
 	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x77, 0x72, 0x69, 0x74, 0x65, 0x56, 0x61,
 	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x61, 0x64, 0x46,
 	0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x61,
-	0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x33, 0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78,
-	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x22, 0x0c, 0x0a, 0x0a,
-	0x4e, 0x65, 0x77, 0x44, 0x42, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x10, 0x0a, 0x0e, 0x4c, 0x69,
-	0x73, 0x74, 0x44, 0x42, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x87, 0x01, 0x0a,
-	0x0c, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x42, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2d, 0x0a,
-	0x03, 0x64, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x68, 0x72,
-	0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x42, 0x73, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x2e, 0x44, 0x42, 0x52, 0x03, 0x64, 0x62, 0x73, 0x1a, 0x48, 0x0a, 0x02,
-	0x44, 0x42, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x2e, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70,
-	0x62, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x42, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0x26, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x44, 0x42, 0x49,
-	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62,
-	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x22, 0x4c,
-	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x44, 0x42, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52,
-	0x05, 0x61, 0x64, 0x64, 0x72, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x25, 0x0a, 0x0f,
-	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x42, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x6f, 0x66, 0x74, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x6f, 0x66,
+	0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x79, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x79, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x70, 0x70,
+	0x6c, 0x79, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0d, 0x61, 0x70, 0x70, 0x6c, 0x79, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x22,
+	0x67, 0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06,
+	0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x6e,
+	0x69, 0x71, 0x75, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x75, 0x6c, 0x6c, 0x54, 0x65, 0x78, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x66, 0x75, 0x6c, 0x6c, 0x54, 0x65, 0x78, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x0c, 0x0a, 0x0a, 0x4e, 0x65, 0x77, 0x44,
+	0x42, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0xad, 0x02, 0x0a, 0x1a, 0x4e, 0x65, 0x77, 0x44, 0x42,
+	0x46, 0x72, 0x6f, 0x6d, 0x41, 0x64, 0x64, 0x72, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x42, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x2c, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70,
+	0x62, 0x2e, 0x4e, 0x65, 0x77, 0x44, 0x42, 0x46, 0x72, 0x6f, 0x6d, 0x41, 0x64, 0x64, 0x72, 0x50,
+	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x53, 0x74, 0x61,
+	0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x67,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x22, 0x61, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x0c,
+	0x54, 0x48, 0x52, 0x45, 0x41, 0x44, 0x5f, 0x41, 0x44, 0x44, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13,
+	0x0a, 0x0f, 0x4c, 0x4f, 0x47, 0x53, 0x5f, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x45,
+	0x44, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x53, 0x5f, 0x46,
+	0x45, 0x54, 0x43, 0x48, 0x45, 0x44, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x45, 0x56, 0x45, 0x4e,
+	0x54, 0x53, 0x5f, 0x52, 0x45, 0x44, 0x55, 0x43, 0x45, 0x44, 0x10, 0x03, 0x12, 0x08, 0x0a, 0x04,
+	0x44, 0x4f, 0x4e, 0x45, 0x10, 0x04, 0x22, 0x7a, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x42,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x61, 0x6d, 0x65,
+	0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x61,
+	0x6d, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6b, 0x69, 0x70, 0x49, 0x6e,
+	0x66, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73, 0x6b, 0x69, 0x70, 0x49, 0x6e,
+	0x66, 0x6f, 0x22, 0x94, 0x02, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x42, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x2d, 0x0a, 0x03, 0x64, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x44, 0x42, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x44, 0x42, 0x52, 0x03, 0x64,
+	0x62, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x1a, 0xbc, 0x01, 0x0a, 0x02, 0x44,
+	0x42, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x2e, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62,
+	0x2e, 0x47, 0x65, 0x74, 0x44, 0x42, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52,
+	0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x4d, 0x6f, 0x64,
+	0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6c, 0x61, 0x73,
+	0x74, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x22, 0x26, 0x0a, 0x10, 0x47, 0x65, 0x74,
+	0x44, 0x42, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49,
+	0x44, 0x22, 0x4c, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x44, 0x42, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x05, 0x61, 0x64, 0x64, 0x72, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22,
+	0x25, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x42, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x22, 0x0f, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x44, 0x42, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x60, 0x0a, 0x14, 0x4e, 0x65, 0x77, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
 	0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64,
-	0x62, 0x49, 0x44, 0x22, 0x0f, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x42, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x22, 0x60, 0x0a, 0x14, 0x4e, 0x65, 0x77, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
-	0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44,
-	0x12, 0x34, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1c, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x14, 0x0a, 0x12, 0x4e, 0x65, 0x77, 0x43, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x63, 0x0a, 0x17,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x62, 0x49, 0x44, 0x12, 0x34, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62,
+	0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x14, 0x0a, 0x12, 0x4e, 0x65, 0x77,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x8b, 0x01, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64,
+	0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12,
+	0x34, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x26, 0x0a, 0x0e, 0x73, 0x6b, 0x69, 0x70, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73,
+	0x6b, 0x69, 0x70, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x17, 0x0a,
+	0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x68, 0x0a, 0x1c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x34, 0x0a, 0x06, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x22, 0x7a, 0x0a, 0x1a, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x22,
+	0x0a, 0x0c, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x69, 0x62, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x69, 0x62,
+	0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x49, 0x44, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x49, 0x44, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x22, 0x41, 0x0a, 0x17,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x34, 0x0a, 0x06, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x22, 0x17, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x41, 0x0a, 0x17, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x17, 0x0a,
-	0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x42, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xb9, 0x01, 0x0a, 0x16, 0x47,
-	0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68,
-	0x65, 0x6d, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d,
-	0x61, 0x12, 0x2b, 0x0a, 0x07, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e,
-	0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x07, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x12, 0x26,
-	0x0a, 0x0e, 0x77, 0x72, 0x69, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x77, 0x72, 0x69, 0x74, 0x65, 0x56, 0x61, 0x6c,
-	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x61, 0x64, 0x46, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x61, 0x64,
-	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x45, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22,
+	0x17, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x5b, 0x0a, 0x17, 0x52, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6e,
+	0x65, 0x77, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65,
+	0x77, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x17, 0x0a, 0x15, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x42,
+	0x0a, 0x18, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0xa1, 0x02, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x2b, 0x0a, 0x07, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x07, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x77, 0x72, 0x69, 0x74, 0x65, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x77, 0x72, 0x69, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x1e,
+	0x0a, 0x0a, 0x72, 0x65, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1e,
+	0x0a, 0x0a, 0x73, 0x6f, 0x66, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x73, 0x6f, 0x66, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x20,
+	0x0a, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x12, 0x24, 0x0a, 0x0d, 0x61, 0x70, 0x70, 0x6c, 0x79, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x61, 0x70, 0x70, 0x6c, 0x79, 0x44, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x45, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c,
 	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x52, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
@@ -3383,216 +5316,400 @@ var file_threads_proto_rawDesc = []byte{
 	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e,
 	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66,
 	0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x0b, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x22, 0x69, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x0c, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x5b,
-	0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x20, 0x0a,
-	0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x73, 0x12,
-	0x2a, 0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x69, 0x0a, 0x0d, 0x56,
-	0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
-	0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44,
-	0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61,
-	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74,
-	0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x69, 0x6e, 0x73,
-	0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x39, 0x0a, 0x0b, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2a, 0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f,
-	0x72, 0x22, 0x67, 0x0a, 0x0b, 0x53, 0x61, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x6f, 0x6e, 0x73, 0x22, 0xcb, 0x01, 0x0a, 0x0f, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x69, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12,
+	0x22, 0x0a, 0x0c, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x45, 0x6e, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x42, 0x79, 0x74, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x57, 0x72, 0x69, 0x74, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x22, 0x3a, 0x0a, 0x08, 0x4c, 0x6f, 0x67, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x22, 0x25, 0x0a,
+	0x0f, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
-	0x64, 0x62, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09,
-	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52,
-	0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x37, 0x0a, 0x09, 0x53, 0x61,
-	0x76, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2a, 0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x22, 0x6d, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x64, 0x62, 0x49, 0x44, 0x22, 0xc0, 0x02, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3d, 0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x68,
+	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0b, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0c, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x34, 0x0a,
+	0x0a, 0x64, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4c,
+	0x6f, 0x67, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x09, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x67,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
+	0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x6f, 0x67, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x09, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x67, 0x22, 0x43, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x73, 0x18,
-	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49,
-	0x44, 0x73, 0x22, 0x39, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x12, 0x2a, 0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x72, 0x61,
-	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x6a, 0x0a,
-	0x0a, 0x48, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64,
-	0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12,
-	0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61,
-	0x6e, 0x63, 0x65, 0x49, 0x44, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e,
-	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x73, 0x22, 0x4e, 0x0a, 0x08, 0x48, 0x61, 0x73,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x12, 0x2a, 0x0a,
-	0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f,
-	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x67, 0x0a, 0x0b, 0x46, 0x69, 0x6e,
-	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44,
+	0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x4c, 0x0a, 0x17,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x31, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
+	0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0x0b, 0x0a, 0x09, 0x47, 0x43,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3f, 0x0a, 0x07, 0x47, 0x43, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x62, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x64, 0x62, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x6e, 0x65, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
+	0x6e, 0x65, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x69, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x26, 0x0a,
+	0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x73, 0x22, 0x5b, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x49, 0x44, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x22, 0x69, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x39, 0x0a, 0x0b, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2a, 0x0a, 0x10, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x67, 0x0a, 0x0b, 0x53, 0x61, 0x76, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x22,
+	0x37, 0x0a, 0x09, 0x53, 0x61, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2a, 0x0a, 0x10,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x88, 0x01, 0x0a, 0x14, 0x53, 0x61, 0x76,
+	0x65, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0xaa, 0x01, 0x0a, 0x12, 0x53, 0x61, 0x76, 0x65, 0x45, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2a, 0x0a, 0x10, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x22, 0x6d, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x73, 0x22,
+	0x39, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2a,
+	0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x6a, 0x0a, 0x0a, 0x48, 0x61,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e,
 	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x71, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x53, 0x4f,
-	0x4e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x71, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x53,
-	0x4f, 0x4e, 0x22, 0x55, 0x0a, 0x09, 0x46, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
-	0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0c, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x2a, 0x0a,
-	0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f,
-	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x6d, 0x0a, 0x0f, 0x46, 0x69, 0x6e,
-	0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
-	0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44,
-	0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61,
-	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x73, 0x74,
-	0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e,
-	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x22, 0x57, 0x0a, 0x0d, 0x46, 0x69, 0x6e, 0x64,
-	0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73,
-	0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x69, 0x6e, 0x73,
-	0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x49, 0x44, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x49, 0x44, 0x73, 0x22, 0x4e, 0x0a, 0x08, 0x48, 0x61, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x67, 0x0a, 0x0b, 0x46, 0x69, 0x6e, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x71, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x53, 0x4f, 0x4e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x71, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x53, 0x4f, 0x4e, 0x22,
+	0xb8, 0x01, 0x0a, 0x09, 0x46, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1c, 0x0a,
+	0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x33, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
+	0x2e, 0x70, 0x62, 0x2e, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x07, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x22, 0x9f, 0x02, 0x0a, 0x0d, 0x45,
+	0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x65, 0x79, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x65, 0x79, 0x73,
+	0x12, 0x24, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x61,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x73, 0x52, 0x65, 0x61, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64,
+	0x12, 0x26, 0x0a, 0x0e, 0x73, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x49, 0x6e, 0x4d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x6f, 0x72, 0x74, 0x65, 0x64,
+	0x49, 0x6e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x6e,
+	0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x70, 0x6c, 0x61,
+	0x6e, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x64, 0x4e, 0x61,
+	0x6e, 0x6f, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x72, 0x65, 0x61, 0x64, 0x4e,
+	0x61, 0x6e, 0x6f, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x6f, 0x72, 0x74, 0x4e, 0x61, 0x6e, 0x6f,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x6f, 0x72, 0x74, 0x4e, 0x61, 0x6e,
+	0x6f, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x18, 0x09, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x07, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x22, 0x2d, 0x0a, 0x0f,
+	0x46, 0x69, 0x6e, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x6d, 0x0a, 0x0f, 0x46,
+	0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62,
+	0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x22, 0x57, 0x0a, 0x0d, 0x46, 0x69,
+	0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x22, 0x70, 0x0a, 0x10, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49,
+	0x44, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x49, 0x44, 0x73, 0x22, 0x70, 0x0a, 0x0e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49,
+	0x44, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x68, 0x0a, 0x0c, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x71, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x53, 0x4f, 0x4e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x71, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x53, 0x4f,
+	0x4e, 0x22, 0x4e, 0x0a, 0x0a, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
 	0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f,
-	0x72, 0x22, 0x10, 0x0a, 0x0e, 0x44, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x22, 0x0e, 0x0a, 0x0c, 0x44, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0x55, 0x0a, 0x17, 0x53, 0x74, 0x61, 0x72, 0x74, 0x54, 0x72, 0x61, 0x6e,
-	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x72, 0x22, 0x6d, 0x0a, 0x19, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
 	0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62,
 	0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
 	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xc3, 0x02, 0x0a, 0x16, 0x52,
-	0x65, 0x61, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x5f, 0x0a, 0x17, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x22, 0x4e, 0x0a, 0x17, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x33, 0x0a, 0x06, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x68,
+	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73,
+	0x22, 0x79, 0x0a, 0x0f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x22, 0x10, 0x0a, 0x0e, 0x44,
+	0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x0e, 0x0a,
+	0x0c, 0x44, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x55, 0x0a,
+	0x17, 0x53, 0x74, 0x61, 0x72, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x4e, 0x61, 0x6d, 0x65, 0x22, 0xcf, 0x03, 0x0a, 0x16, 0x52, 0x65, 0x61, 0x64, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x5f, 0x0a, 0x17, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x17, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x72,
 	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
-	0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x17, 0x73,
-	0x74, 0x61, 0x72, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x0a, 0x68, 0x61, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x68, 0x72,
-	0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x68, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x3b, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e,
-	0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
-	0x52, 0x0b, 0x66, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x47, 0x0a,
+	0x12, 0x38, 0x0a, 0x0a, 0x68, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70,
+	0x62, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0a,
+	0x68, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x66, 0x69,
+	0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x66, 0x69, 0x6e, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x47, 0x0a, 0x0f, 0x66, 0x69, 0x6e, 0x64, 0x42,
+	0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69,
+	0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
 	0x0f, 0x66, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
-	0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0f, 0x66, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x22, 0xce, 0x01, 0x0a, 0x14, 0x52, 0x65, 0x61, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x32, 0x0a, 0x08, 0x68, 0x61, 0x73,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x48, 0x00, 0x52, 0x08, 0x68, 0x61, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x35, 0x0a,
-	0x09, 0x66, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x15, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69,
-	0x6e, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x09, 0x66, 0x69, 0x6e, 0x64, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x12, 0x41, 0x0a, 0x0d, 0x66, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49,
-	0x44, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x0d, 0x66, 0x69, 0x6e, 0x64, 0x42, 0x79,
-	0x49, 0x44, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x42, 0x08, 0x0a, 0x06, 0x6f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x22, 0x90, 0x05, 0x0a, 0x17, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x5f, 0x0a,
-	0x17, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23,
-	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x72,
-	0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x17, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x72, 0x61, 0x6e,
-	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x41,
-	0x0a, 0x0d, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e,
-	0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x48, 0x00, 0x52, 0x0d, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x41, 0x0a, 0x0d, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
-	0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x73, 0x61, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x68, 0x72, 0x65,
-	0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x61, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x41, 0x0a, 0x0d, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
-	0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x0a, 0x68, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
-	0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x48, 0x00, 0x52, 0x0a, 0x68, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3b,
-	0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62,
-	0x2e, 0x46, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b,
-	0x66, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x47, 0x0a, 0x0f, 0x66,
-	0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70,
-	0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x48, 0x00, 0x52, 0x0f, 0x66, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x44, 0x0a, 0x0e, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74,
-	0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x61, 0x72,
-	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x64, 0x69, 0x73, 0x63,
-	0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x6f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x22, 0xfd, 0x03, 0x0a, 0x15, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x72,
-	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3b,
-	0x0a, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62,
-	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x0b,
-	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3b, 0x0a, 0x0b, 0x76,
-	0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x17, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x65,
-	0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x0b, 0x76, 0x65, 0x72,
-	0x69, 0x66, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x35, 0x0a, 0x09, 0x73, 0x61, 0x76, 0x65,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x48, 0x00, 0x52, 0x09, 0x73, 0x61, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
-	0x3b, 0x0a, 0x0b, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70,
-	0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52,
-	0x0b, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x32, 0x0a, 0x08,
-	0x68, 0x61, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x12, 0x3e, 0x0a, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
+	0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x48, 0x00, 0x52, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x4a, 0x0a, 0x10, 0x66, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x10, 0x66, 0x69, 0x6e, 0x64,
+	0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x08, 0x0a, 0x06,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xce, 0x02, 0x0a, 0x14, 0x52, 0x65, 0x61, 0x64, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x32, 0x0a, 0x08, 0x68, 0x61, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x48,
+	0x61, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x08, 0x68, 0x61, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x35, 0x0a, 0x09, 0x66, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
+	0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52,
+	0x09, 0x66, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x41, 0x0a, 0x0d, 0x66, 0x69,
+	0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46,
+	0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x0d,
+	0x66, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x38, 0x0a,
+	0x0a, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x44, 0x0a, 0x0e, 0x66, 0x69, 0x6e, 0x64, 0x42,
+	0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e,
+	0x64, 0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x0e, 0x66,
+	0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x42, 0x08, 0x0a,
+	0x06, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xb4, 0x06, 0x0a, 0x17, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x5f, 0x0a, 0x17, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70,
+	0x62, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x17, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x41, 0x0a, 0x0d, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x68,
+	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x41, 0x0a, 0x0d, 0x76, 0x65, 0x72, 0x69, 0x66,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x76, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x73, 0x61,
+	0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x61, 0x76,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x61, 0x76, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x41, 0x0a, 0x0d, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x0a, 0x68, 0x61,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
 	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x08, 0x68, 0x61, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x12, 0x35, 0x0a, 0x09, 0x66, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62,
-	0x2e, 0x46, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x09, 0x66, 0x69,
-	0x6e, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x41, 0x0a, 0x0d, 0x66, 0x69, 0x6e, 0x64, 0x42,
-	0x79, 0x49, 0x44, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
-	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64,
-	0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x0d, 0x66, 0x69, 0x6e,
-	0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3e, 0x0a, 0x0c, 0x64, 0x69,
-	0x73, 0x63, 0x61, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x18, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x69,
-	0x73, 0x63, 0x61, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x0c, 0x64, 0x69,
-	0x73, 0x63, 0x61, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x42, 0x08, 0x0a, 0x06, 0x6f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x22, 0xa8, 0x02, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x3a, 0x0a, 0x07, 0x66, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x74, 0x68,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x68, 0x61, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x66, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x47, 0x0a, 0x0f, 0x66, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0f, 0x66, 0x69, 0x6e, 0x64, 0x42,
+	0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x44, 0x0a, 0x0e, 0x64, 0x69,
+	0x73, 0x63, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e,
+	0x44, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x0e, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x4a, 0x0a, 0x10, 0x66, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x10, 0x66, 0x69, 0x6e, 0x64,
+	0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x56, 0x0a, 0x14,
+	0x73, 0x61, 0x76, 0x65, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x74, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x45, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x14,
+	0x73, 0x61, 0x76, 0x65, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x95,
+	0x05, 0x0a, 0x15, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3b, 0x0a, 0x0b, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x0b, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x35, 0x0a, 0x09, 0x73, 0x61, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e,
+	0x70, 0x62, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x09,
+	0x73, 0x61, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3b, 0x0a, 0x0b, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x0b, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x32, 0x0a, 0x08, 0x68, 0x61, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00,
+	0x52, 0x08, 0x68, 0x61, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x35, 0x0a, 0x09, 0x66, 0x69,
+	0x6e, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x09, 0x66, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x41, 0x0a, 0x0d, 0x66, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x0d, 0x66, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x3e, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x74, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x44, 0x0a, 0x0e, 0x66, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74,
+	0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79,
+	0x49, 0x44, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x0e, 0x66, 0x69, 0x6e, 0x64,
+	0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x50, 0x0a, 0x12, 0x73, 0x61,
+	0x76, 0x65, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
+	0x2e, 0x70, 0x62, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x12, 0x73, 0x61, 0x76, 0x65, 0x45, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x42, 0x08, 0x0a, 0x06,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xc6, 0x02, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x65,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x62, 0x49, 0x44, 0x12, 0x3a, 0x0a, 0x07,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52,
+	0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x1a, 0xe4, 0x01, 0x0a, 0x06, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x12, 0x3f, 0x0a, 0x06, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x74, 0x68,
 	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x07, 0x66,
-	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x1a, 0xc6, 0x01, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65,
-	0x72, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x73,
-	0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69,
-	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x12, 0x3f, 0x0a, 0x06, 0x61, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x74, 0x68, 0x72, 0x65,
-	0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x2e, 0x41, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x33, 0x0a, 0x06, 0x41, 0x63,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x2e, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x53, 0x4f, 0x4e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x71, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x53, 0x4f, 0x4e, 0x22, 0x33, 0x0a, 0x06, 0x41, 0x63,
 	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x0a, 0x0a,
 	0x06, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x41, 0x56,
 	0x45, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x03, 0x22,
@@ -3609,7 +5726,7 @@ var file_threads_proto_rawDesc = []byte{
 	0x0c, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x2a, 0x0a, 0x06, 0x41,
 	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x10,
 	0x00, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x41, 0x56, 0x45, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x44,
-	0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x32, 0x99, 0x0d, 0x0a, 0x03, 0x41, 0x50, 0x49, 0x12,
+	0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x32, 0xb4, 0x14, 0x0a, 0x03, 0x41, 0x50, 0x49, 0x12,
 	0x48, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x2e, 0x74, 0x68,
 	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65,
 	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
@@ -3623,105 +5740,162 @@ var file_threads_proto_rawDesc = []byte{
 	0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x44, 0x42, 0x46, 0x72, 0x6f, 0x6d, 0x41, 0x64,
 	0x64, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x74, 0x68, 0x72, 0x65,
 	0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x44, 0x42, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x07, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x42, 0x73, 0x12, 0x1a,
-	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74,
-	0x44, 0x42, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x74, 0x68, 0x72,
-	0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x42, 0x73, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x44, 0x42, 0x49,
-	0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62,
-	0x2e, 0x47, 0x65, 0x74, 0x44, 0x42, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1a, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x47,
-	0x65, 0x74, 0x44, 0x42, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
-	0x44, 0x0a, 0x08, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x42, 0x12, 0x1b, 0x2e, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44,
-	0x42, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
-	0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x42, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x0d, 0x4e, 0x65, 0x77, 0x43, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
-	0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
-	0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5c, 0x0a, 0x10, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23,
+	0x79, 0x22, 0x00, 0x12, 0x69, 0x0a, 0x19, 0x4e, 0x65, 0x77, 0x44, 0x42, 0x46, 0x72, 0x6f, 0x6d,
+	0x41, 0x64, 0x64, 0x72, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65,
+	0x77, 0x44, 0x42, 0x46, 0x72, 0x6f, 0x6d, 0x41, 0x64, 0x64, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x26, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e,
+	0x4e, 0x65, 0x77, 0x44, 0x42, 0x46, 0x72, 0x6f, 0x6d, 0x41, 0x64, 0x64, 0x72, 0x50, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x41,
+	0x0a, 0x07, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x42, 0x73, 0x12, 0x1a, 0x2e, 0x74, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x42, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e,
+	0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x42, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x47, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x44, 0x42, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c,
+	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x44,
+	0x42, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x74,
+	0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x42, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x08, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x44, 0x42, 0x12, 0x1b, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
+	0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x42, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x42, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x53, 0x0a, 0x0d, 0x4e, 0x65, 0x77, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4e,
+	0x65, 0x77, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62,
+	0x2e, 0x4e, 0x65, 0x77, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5c, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x2e, 0x74, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
 	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62,
-	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5c, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x2e, 0x74,
-	0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x21, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5f, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x24, 0x2e, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x22, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x47,
+	0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x6b, 0x0a, 0x15, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x43, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x28, 0x2e, 0x74,
+	0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
+	0x2e, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x5c, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70,
+	0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x74, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5c,
+	0x0a, 0x10, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x23, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e,
+	0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
+	0x73, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5f, 0x0a, 0x11,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x24, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x47,
 	0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x6b, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x43, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x12,
-	0x27, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74,
-	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
+	0x73, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x6b, 0x0a,
+	0x14, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x65, 0x73, 0x12, 0x27, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e,
+	0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25,
+	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x03, 0x88, 0x02, 0x01, 0x12, 0x59, 0x0a, 0x0f, 0x4c, 0x69,
+	0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x2e,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x1b, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x47,
+	0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x62, 0x0a, 0x12, 0x47,
+	0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x25, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x47,
+	0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
 	0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x03, 0x88, 0x02, 0x01, 0x12, 0x59, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
-	0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
-	0x3e, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x19, 0x2e, 0x74, 0x68, 0x72, 0x65,
-	0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70,
-	0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
-	0x3e, 0x0a, 0x06, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x12, 0x19, 0x2e, 0x74, 0x68, 0x72, 0x65,
-	0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70,
-	0x62, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
-	0x38, 0x0a, 0x04, 0x53, 0x61, 0x76, 0x65, 0x12, 0x17, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
-	0x73, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x15, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x61,
-	0x76, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x06, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x12, 0x19, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62,
-	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
-	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x03, 0x48, 0x61, 0x73,
-	0x12, 0x16, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x48, 0x61,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
-	0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
-	0x12, 0x38, 0x0a, 0x04, 0x46, 0x69, 0x6e, 0x64, 0x12, 0x17, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
-	0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x15, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46,
-	0x69, 0x6e, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x08, 0x46, 0x69,
-	0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x12, 0x1b, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
-	0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62,
-	0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
-	0x12, 0x5d, 0x0a, 0x0f, 0x52, 0x65, 0x61, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x22, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62,
-	0x2e, 0x52, 0x65, 0x61, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
-	0x73, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12,
-	0x60, 0x0a, 0x10, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x23, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62,
+	0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x32, 0x0a, 0x02, 0x47, 0x43, 0x12, 0x15, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e,
+	0x70, 0x62, 0x2e, 0x47, 0x43, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x74,
+	0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x43, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x19, 0x2e,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x06, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x12, 0x19, 0x2e,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x04, 0x53, 0x61, 0x76, 0x65, 0x12, 0x17, 0x2e, 0x74, 0x68,
+	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70,
+	0x62, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x53, 0x0a,
+	0x0d, 0x53, 0x61, 0x76, 0x65, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x20,
+	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x61, 0x76, 0x65,
+	0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1e, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x61,
+	0x76, 0x65, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x3e, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x19, 0x2e, 0x74,
+	0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
+	0x73, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x35, 0x0a, 0x03, 0x48, 0x61, 0x73, 0x12, 0x16, 0x2e, 0x74, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x14, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x48,
+	0x61, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x04, 0x46, 0x69, 0x6e,
+	0x64, 0x12, 0x17, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46,
+	0x69, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x74, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0a, 0x46, 0x69, 0x6e, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x12, 0x17, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46,
+	0x69, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x74, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x08, 0x46,
+	0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x12, 0x1b, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
+	0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70,
+	0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x47, 0x0a, 0x09, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x73, 0x12, 0x1c,
+	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64,
+	0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x74,
+	0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79,
+	0x49, 0x44, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x05, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x18, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62,
+	0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x62, 0x0a, 0x12, 0x56, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x2e,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70,
+	0x62, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5d, 0x0a, 0x0f, 0x52,
+	0x65, 0x61, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22,
+	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x61, 0x64,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e,
+	0x52, 0x65, 0x61, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x60, 0x0a, 0x10, 0x57, 0x72,
+	0x69, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23,
+	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62,
 	0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
-	0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x30,
-	0x01, 0x12, 0x40, 0x0a, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x12, 0x19, 0x2e, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
-	0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x00, 0x30, 0x01, 0x42, 0x57, 0x0a, 0x17, 0x69, 0x6f, 0x2e, 0x74, 0x65, 0x78, 0x74, 0x69, 0x6c,
-	0x65, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x5f, 0x67, 0x72, 0x70, 0x63, 0x42, 0x07,
-	0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x50, 0x01, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
-	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x62, 0x2f, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x5f,
-	0x70, 0x62, 0xa2, 0x02, 0x07, 0x54, 0x48, 0x52, 0x45, 0x41, 0x44, 0x53, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x40, 0x0a, 0x06,
+	0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x12, 0x19, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
+	0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x17, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x62, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x42, 0x57,
+	0x0a, 0x17, 0x69, 0x6f, 0x2e, 0x74, 0x65, 0x78, 0x74, 0x69, 0x6c, 0x65, 0x2e, 0x74, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x73, 0x5f, 0x67, 0x72, 0x70, 0x63, 0x42, 0x07, 0x54, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x73, 0x50, 0x01, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x67, 0x6f, 0x2d, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x70, 0x62, 0x2f, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x5f, 0x70, 0x62, 0xa2, 0x02, 0x07,
+	0x54, 0x48, 0x52, 0x45, 0x41, 0x44, 0x53, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -3736,149 +5910,212 @@ func file_threads_proto_rawDescGZIP() []byte {
 	return file_threads_proto_rawDescData
 }
 
-var file_threads_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_threads_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
+var file_threads_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_threads_proto_msgTypes = make([]protoimpl.MessageInfo, 74)
 var file_threads_proto_goTypes = []interface{}{
-	(ListenRequest_Filter_Action)(0),    // 0: threads.pb.ListenRequest.Filter.Action
-	(ListenReply_Action)(0),             // 1: threads.pb.ListenReply.Action
-	(*GetTokenRequest)(nil),             // 2: threads.pb.GetTokenRequest
-	(*GetTokenReply)(nil),               // 3: threads.pb.GetTokenReply
-	(*NewDBRequest)(nil),                // 4: threads.pb.NewDBRequest
-	(*NewDBFromAddrRequest)(nil),        // 5: threads.pb.NewDBFromAddrRequest
-	(*CollectionConfig)(nil),            // 6: threads.pb.CollectionConfig
-	(*Index)(nil),                       // 7: threads.pb.Index
-	(*NewDBReply)(nil),                  // 8: threads.pb.NewDBReply
-	(*ListDBsRequest)(nil),              // 9: threads.pb.ListDBsRequest
-	(*ListDBsReply)(nil),                // 10: threads.pb.ListDBsReply
-	(*GetDBInfoRequest)(nil),            // 11: threads.pb.GetDBInfoRequest
-	(*GetDBInfoReply)(nil),              // 12: threads.pb.GetDBInfoReply
-	(*DeleteDBRequest)(nil),             // 13: threads.pb.DeleteDBRequest
-	(*DeleteDBReply)(nil),               // 14: threads.pb.DeleteDBReply
-	(*NewCollectionRequest)(nil),        // 15: threads.pb.NewCollectionRequest
-	(*NewCollectionReply)(nil),          // 16: threads.pb.NewCollectionReply
-	(*UpdateCollectionRequest)(nil),     // 17: threads.pb.UpdateCollectionRequest
-	(*UpdateCollectionReply)(nil),       // 18: threads.pb.UpdateCollectionReply
-	(*DeleteCollectionRequest)(nil),     // 19: threads.pb.DeleteCollectionRequest
-	(*DeleteCollectionReply)(nil),       // 20: threads.pb.DeleteCollectionReply
-	(*GetCollectionInfoRequest)(nil),    // 21: threads.pb.GetCollectionInfoRequest
-	(*GetCollectionInfoReply)(nil),      // 22: threads.pb.GetCollectionInfoReply
-	(*GetCollectionIndexesRequest)(nil), // 23: threads.pb.GetCollectionIndexesRequest
-	(*GetCollectionIndexesReply)(nil),   // 24: threads.pb.GetCollectionIndexesReply
-	(*ListCollectionsRequest)(nil),      // 25: threads.pb.ListCollectionsRequest
-	(*ListCollectionsReply)(nil),        // 26: threads.pb.ListCollectionsReply
-	(*CreateRequest)(nil),               // 27: threads.pb.CreateRequest
-	(*CreateReply)(nil),                 // 28: threads.pb.CreateReply
-	(*VerifyRequest)(nil),               // 29: threads.pb.VerifyRequest
-	(*VerifyReply)(nil),                 // 30: threads.pb.VerifyReply
-	(*SaveRequest)(nil),                 // 31: threads.pb.SaveRequest
-	(*SaveReply)(nil),                   // 32: threads.pb.SaveReply
-	(*DeleteRequest)(nil),               // 33: threads.pb.DeleteRequest
-	(*DeleteReply)(nil),                 // 34: threads.pb.DeleteReply
-	(*HasRequest)(nil),                  // 35: threads.pb.HasRequest
-	(*HasReply)(nil),                    // 36: threads.pb.HasReply
-	(*FindRequest)(nil),                 // 37: threads.pb.FindRequest
-	(*FindReply)(nil),                   // 38: threads.pb.FindReply
-	(*FindByIDRequest)(nil),             // 39: threads.pb.FindByIDRequest
-	(*FindByIDReply)(nil),               // 40: threads.pb.FindByIDReply
-	(*DiscardRequest)(nil),              // 41: threads.pb.DiscardRequest
-	(*DiscardReply)(nil),                // 42: threads.pb.DiscardReply
-	(*StartTransactionRequest)(nil),     // 43: threads.pb.StartTransactionRequest
-	(*ReadTransactionRequest)(nil),      // 44: threads.pb.ReadTransactionRequest
-	(*ReadTransactionReply)(nil),        // 45: threads.pb.ReadTransactionReply
-	(*WriteTransactionRequest)(nil),     // 46: threads.pb.WriteTransactionRequest
-	(*WriteTransactionReply)(nil),       // 47: threads.pb.WriteTransactionReply
-	(*ListenRequest)(nil),               // 48: threads.pb.ListenRequest
-	(*ListenReply)(nil),                 // 49: threads.pb.ListenReply
-	(*ListDBsReply_DB)(nil),             // 50: threads.pb.ListDBsReply.DB
-	(*ListenRequest_Filter)(nil),        // 51: threads.pb.ListenRequest.Filter
+	(NewDBFromAddrProgressReply_Stage)(0), // 0: threads.pb.NewDBFromAddrProgressReply.Stage
+	(ListenRequest_Filter_Action)(0),      // 1: threads.pb.ListenRequest.Filter.Action
+	(ListenReply_Action)(0),               // 2: threads.pb.ListenReply.Action
+	(*GetTokenRequest)(nil),               // 3: threads.pb.GetTokenRequest
+	(*GetTokenReply)(nil),                 // 4: threads.pb.GetTokenReply
+	(*NewDBRequest)(nil),                  // 5: threads.pb.NewDBRequest
+	(*NewDBFromAddrRequest)(nil),          // 6: threads.pb.NewDBFromAddrRequest
+	(*CollectionConfig)(nil),              // 7: threads.pb.CollectionConfig
+	(*Index)(nil),                         // 8: threads.pb.Index
+	(*NewDBReply)(nil),                    // 9: threads.pb.NewDBReply
+	(*NewDBFromAddrProgressReply)(nil),    // 10: threads.pb.NewDBFromAddrProgressReply
+	(*ListDBsRequest)(nil),                // 11: threads.pb.ListDBsRequest
+	(*ListDBsReply)(nil),                  // 12: threads.pb.ListDBsReply
+	(*GetDBInfoRequest)(nil),              // 13: threads.pb.GetDBInfoRequest
+	(*GetDBInfoReply)(nil),                // 14: threads.pb.GetDBInfoReply
+	(*DeleteDBRequest)(nil),               // 15: threads.pb.DeleteDBRequest
+	(*DeleteDBReply)(nil),                 // 16: threads.pb.DeleteDBReply
+	(*NewCollectionRequest)(nil),          // 17: threads.pb.NewCollectionRequest
+	(*NewCollectionReply)(nil),            // 18: threads.pb.NewCollectionReply
+	(*UpdateCollectionRequest)(nil),       // 19: threads.pb.UpdateCollectionRequest
+	(*UpdateCollectionReply)(nil),         // 20: threads.pb.UpdateCollectionReply
+	(*CheckCollectionUpdateRequest)(nil),  // 21: threads.pb.CheckCollectionUpdateRequest
+	(*CheckCollectionUpdateReply)(nil),    // 22: threads.pb.CheckCollectionUpdateReply
+	(*DeleteCollectionRequest)(nil),       // 23: threads.pb.DeleteCollectionRequest
+	(*DeleteCollectionReply)(nil),         // 24: threads.pb.DeleteCollectionReply
+	(*RenameCollectionRequest)(nil),       // 25: threads.pb.RenameCollectionRequest
+	(*RenameCollectionReply)(nil),         // 26: threads.pb.RenameCollectionReply
+	(*GetCollectionInfoRequest)(nil),      // 27: threads.pb.GetCollectionInfoRequest
+	(*GetCollectionInfoReply)(nil),        // 28: threads.pb.GetCollectionInfoReply
+	(*GetCollectionIndexesRequest)(nil),   // 29: threads.pb.GetCollectionIndexesRequest
+	(*GetCollectionIndexesReply)(nil),     // 30: threads.pb.GetCollectionIndexesReply
+	(*ListCollectionsRequest)(nil),        // 31: threads.pb.ListCollectionsRequest
+	(*ListCollectionsReply)(nil),          // 32: threads.pb.ListCollectionsReply
+	(*CollectionStats)(nil),               // 33: threads.pb.CollectionStats
+	(*LogStats)(nil),                      // 34: threads.pb.LogStats
+	(*GetStatsRequest)(nil),               // 35: threads.pb.GetStatsRequest
+	(*GetStatsReply)(nil),                 // 36: threads.pb.GetStatsReply
+	(*GetCollectionStatsRequest)(nil),     // 37: threads.pb.GetCollectionStatsRequest
+	(*GetCollectionStatsReply)(nil),       // 38: threads.pb.GetCollectionStatsReply
+	(*GCRequest)(nil),                     // 39: threads.pb.GCRequest
+	(*GCReply)(nil),                       // 40: threads.pb.GCReply
+	(*CreateRequest)(nil),                 // 41: threads.pb.CreateRequest
+	(*CreateReply)(nil),                   // 42: threads.pb.CreateReply
+	(*VerifyRequest)(nil),                 // 43: threads.pb.VerifyRequest
+	(*VerifyReply)(nil),                   // 44: threads.pb.VerifyReply
+	(*SaveRequest)(nil),                   // 45: threads.pb.SaveRequest
+	(*SaveReply)(nil),                     // 46: threads.pb.SaveReply
+	(*SaveExpectingRequest)(nil),          // 47: threads.pb.SaveExpectingRequest
+	(*SaveExpectingReply)(nil),            // 48: threads.pb.SaveExpectingReply
+	(*DeleteRequest)(nil),                 // 49: threads.pb.DeleteRequest
+	(*DeleteReply)(nil),                   // 50: threads.pb.DeleteReply
+	(*HasRequest)(nil),                    // 51: threads.pb.HasRequest
+	(*HasReply)(nil),                      // 52: threads.pb.HasReply
+	(*FindRequest)(nil),                   // 53: threads.pb.FindRequest
+	(*FindReply)(nil),                     // 54: threads.pb.FindReply
+	(*ExplainResult)(nil),                 // 55: threads.pb.ExplainResult
+	(*FindStreamReply)(nil),               // 56: threads.pb.FindStreamReply
+	(*FindByIDRequest)(nil),               // 57: threads.pb.FindByIDRequest
+	(*FindByIDReply)(nil),                 // 58: threads.pb.FindByIDReply
+	(*FindByIDsRequest)(nil),              // 59: threads.pb.FindByIDsRequest
+	(*FindByIDsReply)(nil),                // 60: threads.pb.FindByIDsReply
+	(*CountRequest)(nil),                  // 61: threads.pb.CountRequest
+	(*CountReply)(nil),                    // 62: threads.pb.CountReply
+	(*ValidateCollectionRequest)(nil),     // 63: threads.pb.ValidateCollectionRequest
+	(*ValidateCollectionReply)(nil),       // 64: threads.pb.ValidateCollectionReply
+	(*ValidationError)(nil),               // 65: threads.pb.ValidationError
+	(*DiscardRequest)(nil),                // 66: threads.pb.DiscardRequest
+	(*DiscardReply)(nil),                  // 67: threads.pb.DiscardReply
+	(*StartTransactionRequest)(nil),       // 68: threads.pb.StartTransactionRequest
+	(*ReadTransactionRequest)(nil),        // 69: threads.pb.ReadTransactionRequest
+	(*ReadTransactionReply)(nil),          // 70: threads.pb.ReadTransactionReply
+	(*WriteTransactionRequest)(nil),       // 71: threads.pb.WriteTransactionRequest
+	(*WriteTransactionReply)(nil),         // 72: threads.pb.WriteTransactionReply
+	(*ListenRequest)(nil),                 // 73: threads.pb.ListenRequest
+	(*ListenReply)(nil),                   // 74: threads.pb.ListenReply
+	(*ListDBsReply_DB)(nil),               // 75: threads.pb.ListDBsReply.DB
+	(*ListenRequest_Filter)(nil),          // 76: threads.pb.ListenRequest.Filter
 }
 var file_threads_proto_depIdxs = []int32{
-	6,  // 0: threads.pb.NewDBRequest.collections:type_name -> threads.pb.CollectionConfig
-	6,  // 1: threads.pb.NewDBFromAddrRequest.collections:type_name -> threads.pb.CollectionConfig
-	7,  // 2: threads.pb.CollectionConfig.indexes:type_name -> threads.pb.Index
-	50, // 3: threads.pb.ListDBsReply.dbs:type_name -> threads.pb.ListDBsReply.DB
-	6,  // 4: threads.pb.NewCollectionRequest.config:type_name -> threads.pb.CollectionConfig
-	6,  // 5: threads.pb.UpdateCollectionRequest.config:type_name -> threads.pb.CollectionConfig
-	7,  // 6: threads.pb.GetCollectionInfoReply.indexes:type_name -> threads.pb.Index
-	7,  // 7: threads.pb.GetCollectionIndexesReply.indexes:type_name -> threads.pb.Index
-	22, // 8: threads.pb.ListCollectionsReply.collections:type_name -> threads.pb.GetCollectionInfoReply
-	43, // 9: threads.pb.ReadTransactionRequest.startTransactionRequest:type_name -> threads.pb.StartTransactionRequest
-	35, // 10: threads.pb.ReadTransactionRequest.hasRequest:type_name -> threads.pb.HasRequest
-	37, // 11: threads.pb.ReadTransactionRequest.findRequest:type_name -> threads.pb.FindRequest
-	39, // 12: threads.pb.ReadTransactionRequest.findByIDRequest:type_name -> threads.pb.FindByIDRequest
-	36, // 13: threads.pb.ReadTransactionReply.hasReply:type_name -> threads.pb.HasReply
-	38, // 14: threads.pb.ReadTransactionReply.findReply:type_name -> threads.pb.FindReply
-	40, // 15: threads.pb.ReadTransactionReply.findByIDReply:type_name -> threads.pb.FindByIDReply
-	43, // 16: threads.pb.WriteTransactionRequest.startTransactionRequest:type_name -> threads.pb.StartTransactionRequest
-	27, // 17: threads.pb.WriteTransactionRequest.createRequest:type_name -> threads.pb.CreateRequest
-	29, // 18: threads.pb.WriteTransactionRequest.verifyRequest:type_name -> threads.pb.VerifyRequest
-	31, // 19: threads.pb.WriteTransactionRequest.saveRequest:type_name -> threads.pb.SaveRequest
-	33, // 20: threads.pb.WriteTransactionRequest.deleteRequest:type_name -> threads.pb.DeleteRequest
-	35, // 21: threads.pb.WriteTransactionRequest.hasRequest:type_name -> threads.pb.HasRequest
-	37, // 22: threads.pb.WriteTransactionRequest.findRequest:type_name -> threads.pb.FindRequest
-	39, // 23: threads.pb.WriteTransactionRequest.findByIDRequest:type_name -> threads.pb.FindByIDRequest
-	41, // 24: threads.pb.WriteTransactionRequest.discardRequest:type_name -> threads.pb.DiscardRequest
-	28, // 25: threads.pb.WriteTransactionReply.createReply:type_name -> threads.pb.CreateReply
-	30, // 26: threads.pb.WriteTransactionReply.verifyReply:type_name -> threads.pb.VerifyReply
-	32, // 27: threads.pb.WriteTransactionReply.saveReply:type_name -> threads.pb.SaveReply
-	34, // 28: threads.pb.WriteTransactionReply.deleteReply:type_name -> threads.pb.DeleteReply
-	36, // 29: threads.pb.WriteTransactionReply.hasReply:type_name -> threads.pb.HasReply
-	38, // 30: threads.pb.WriteTransactionReply.findReply:type_name -> threads.pb.FindReply
-	40, // 31: threads.pb.WriteTransactionReply.findByIDReply:type_name -> threads.pb.FindByIDReply
-	42, // 32: threads.pb.WriteTransactionReply.discardReply:type_name -> threads.pb.DiscardReply
-	51, // 33: threads.pb.ListenRequest.filters:type_name -> threads.pb.ListenRequest.Filter
-	1,  // 34: threads.pb.ListenReply.action:type_name -> threads.pb.ListenReply.Action
-	12, // 35: threads.pb.ListDBsReply.DB.info:type_name -> threads.pb.GetDBInfoReply
-	0,  // 36: threads.pb.ListenRequest.Filter.action:type_name -> threads.pb.ListenRequest.Filter.Action
-	2,  // 37: threads.pb.API.GetToken:input_type -> threads.pb.GetTokenRequest
-	4,  // 38: threads.pb.API.NewDB:input_type -> threads.pb.NewDBRequest
-	5,  // 39: threads.pb.API.NewDBFromAddr:input_type -> threads.pb.NewDBFromAddrRequest
-	9,  // 40: threads.pb.API.ListDBs:input_type -> threads.pb.ListDBsRequest
-	11, // 41: threads.pb.API.GetDBInfo:input_type -> threads.pb.GetDBInfoRequest
-	13, // 42: threads.pb.API.DeleteDB:input_type -> threads.pb.DeleteDBRequest
-	15, // 43: threads.pb.API.NewCollection:input_type -> threads.pb.NewCollectionRequest
-	17, // 44: threads.pb.API.UpdateCollection:input_type -> threads.pb.UpdateCollectionRequest
-	19, // 45: threads.pb.API.DeleteCollection:input_type -> threads.pb.DeleteCollectionRequest
-	21, // 46: threads.pb.API.GetCollectionInfo:input_type -> threads.pb.GetCollectionInfoRequest
-	23, // 47: threads.pb.API.GetCollectionIndexes:input_type -> threads.pb.GetCollectionIndexesRequest
-	25, // 48: threads.pb.API.ListCollections:input_type -> threads.pb.ListCollectionsRequest
-	27, // 49: threads.pb.API.Create:input_type -> threads.pb.CreateRequest
-	29, // 50: threads.pb.API.Verify:input_type -> threads.pb.VerifyRequest
-	31, // 51: threads.pb.API.Save:input_type -> threads.pb.SaveRequest
-	33, // 52: threads.pb.API.Delete:input_type -> threads.pb.DeleteRequest
-	35, // 53: threads.pb.API.Has:input_type -> threads.pb.HasRequest
-	37, // 54: threads.pb.API.Find:input_type -> threads.pb.FindRequest
-	39, // 55: threads.pb.API.FindByID:input_type -> threads.pb.FindByIDRequest
-	44, // 56: threads.pb.API.ReadTransaction:input_type -> threads.pb.ReadTransactionRequest
-	46, // 57: threads.pb.API.WriteTransaction:input_type -> threads.pb.WriteTransactionRequest
-	48, // 58: threads.pb.API.Listen:input_type -> threads.pb.ListenRequest
-	3,  // 59: threads.pb.API.GetToken:output_type -> threads.pb.GetTokenReply
-	8,  // 60: threads.pb.API.NewDB:output_type -> threads.pb.NewDBReply
-	8,  // 61: threads.pb.API.NewDBFromAddr:output_type -> threads.pb.NewDBReply
-	10, // 62: threads.pb.API.ListDBs:output_type -> threads.pb.ListDBsReply
-	12, // 63: threads.pb.API.GetDBInfo:output_type -> threads.pb.GetDBInfoReply
-	14, // 64: threads.pb.API.DeleteDB:output_type -> threads.pb.DeleteDBReply
-	16, // 65: threads.pb.API.NewCollection:output_type -> threads.pb.NewCollectionReply
-	18, // 66: threads.pb.API.UpdateCollection:output_type -> threads.pb.UpdateCollectionReply
-	20, // 67: threads.pb.API.DeleteCollection:output_type -> threads.pb.DeleteCollectionReply
-	22, // 68: threads.pb.API.GetCollectionInfo:output_type -> threads.pb.GetCollectionInfoReply
-	24, // 69: threads.pb.API.GetCollectionIndexes:output_type -> threads.pb.GetCollectionIndexesReply
-	26, // 70: threads.pb.API.ListCollections:output_type -> threads.pb.ListCollectionsReply
-	28, // 71: threads.pb.API.Create:output_type -> threads.pb.CreateReply
-	30, // 72: threads.pb.API.Verify:output_type -> threads.pb.VerifyReply
-	32, // 73: threads.pb.API.Save:output_type -> threads.pb.SaveReply
-	34, // 74: threads.pb.API.Delete:output_type -> threads.pb.DeleteReply
-	36, // 75: threads.pb.API.Has:output_type -> threads.pb.HasReply
-	38, // 76: threads.pb.API.Find:output_type -> threads.pb.FindReply
-	40, // 77: threads.pb.API.FindByID:output_type -> threads.pb.FindByIDReply
-	45, // 78: threads.pb.API.ReadTransaction:output_type -> threads.pb.ReadTransactionReply
-	47, // 79: threads.pb.API.WriteTransaction:output_type -> threads.pb.WriteTransactionReply
-	49, // 80: threads.pb.API.Listen:output_type -> threads.pb.ListenReply
-	59, // [59:81] is the sub-list for method output_type
-	37, // [37:59] is the sub-list for method input_type
-	37, // [37:37] is the sub-list for extension type_name
-	37, // [37:37] is the sub-list for extension extendee
-	0,  // [0:37] is the sub-list for field type_name
+	7,  // 0: threads.pb.NewDBRequest.collections:type_name -> threads.pb.CollectionConfig
+	7,  // 1: threads.pb.NewDBFromAddrRequest.collections:type_name -> threads.pb.CollectionConfig
+	8,  // 2: threads.pb.CollectionConfig.indexes:type_name -> threads.pb.Index
+	0,  // 3: threads.pb.NewDBFromAddrProgressReply.stage:type_name -> threads.pb.NewDBFromAddrProgressReply.Stage
+	75, // 4: threads.pb.ListDBsReply.dbs:type_name -> threads.pb.ListDBsReply.DB
+	7,  // 5: threads.pb.NewCollectionRequest.config:type_name -> threads.pb.CollectionConfig
+	7,  // 6: threads.pb.UpdateCollectionRequest.config:type_name -> threads.pb.CollectionConfig
+	7,  // 7: threads.pb.CheckCollectionUpdateRequest.config:type_name -> threads.pb.CollectionConfig
+	8,  // 8: threads.pb.GetCollectionInfoReply.indexes:type_name -> threads.pb.Index
+	8,  // 9: threads.pb.GetCollectionIndexesReply.indexes:type_name -> threads.pb.Index
+	28, // 10: threads.pb.ListCollectionsReply.collections:type_name -> threads.pb.GetCollectionInfoReply
+	33, // 11: threads.pb.GetStatsReply.collections:type_name -> threads.pb.CollectionStats
+	34, // 12: threads.pb.GetStatsReply.dispatcher:type_name -> threads.pb.LogStats
+	34, // 13: threads.pb.GetStatsReply.actionLog:type_name -> threads.pb.LogStats
+	33, // 14: threads.pb.GetCollectionStatsReply.stats:type_name -> threads.pb.CollectionStats
+	55, // 15: threads.pb.FindReply.explain:type_name -> threads.pb.ExplainResult
+	65, // 16: threads.pb.ValidateCollectionReply.errors:type_name -> threads.pb.ValidationError
+	68, // 17: threads.pb.ReadTransactionRequest.startTransactionRequest:type_name -> threads.pb.StartTransactionRequest
+	51, // 18: threads.pb.ReadTransactionRequest.hasRequest:type_name -> threads.pb.HasRequest
+	53, // 19: threads.pb.ReadTransactionRequest.findRequest:type_name -> threads.pb.FindRequest
+	57, // 20: threads.pb.ReadTransactionRequest.findByIDRequest:type_name -> threads.pb.FindByIDRequest
+	61, // 21: threads.pb.ReadTransactionRequest.countRequest:type_name -> threads.pb.CountRequest
+	59, // 22: threads.pb.ReadTransactionRequest.findByIDsRequest:type_name -> threads.pb.FindByIDsRequest
+	52, // 23: threads.pb.ReadTransactionReply.hasReply:type_name -> threads.pb.HasReply
+	54, // 24: threads.pb.ReadTransactionReply.findReply:type_name -> threads.pb.FindReply
+	58, // 25: threads.pb.ReadTransactionReply.findByIDReply:type_name -> threads.pb.FindByIDReply
+	62, // 26: threads.pb.ReadTransactionReply.countReply:type_name -> threads.pb.CountReply
+	60, // 27: threads.pb.ReadTransactionReply.findByIDsReply:type_name -> threads.pb.FindByIDsReply
+	68, // 28: threads.pb.WriteTransactionRequest.startTransactionRequest:type_name -> threads.pb.StartTransactionRequest
+	41, // 29: threads.pb.WriteTransactionRequest.createRequest:type_name -> threads.pb.CreateRequest
+	43, // 30: threads.pb.WriteTransactionRequest.verifyRequest:type_name -> threads.pb.VerifyRequest
+	45, // 31: threads.pb.WriteTransactionRequest.saveRequest:type_name -> threads.pb.SaveRequest
+	49, // 32: threads.pb.WriteTransactionRequest.deleteRequest:type_name -> threads.pb.DeleteRequest
+	51, // 33: threads.pb.WriteTransactionRequest.hasRequest:type_name -> threads.pb.HasRequest
+	53, // 34: threads.pb.WriteTransactionRequest.findRequest:type_name -> threads.pb.FindRequest
+	57, // 35: threads.pb.WriteTransactionRequest.findByIDRequest:type_name -> threads.pb.FindByIDRequest
+	66, // 36: threads.pb.WriteTransactionRequest.discardRequest:type_name -> threads.pb.DiscardRequest
+	59, // 37: threads.pb.WriteTransactionRequest.findByIDsRequest:type_name -> threads.pb.FindByIDsRequest
+	47, // 38: threads.pb.WriteTransactionRequest.saveExpectingRequest:type_name -> threads.pb.SaveExpectingRequest
+	42, // 39: threads.pb.WriteTransactionReply.createReply:type_name -> threads.pb.CreateReply
+	44, // 40: threads.pb.WriteTransactionReply.verifyReply:type_name -> threads.pb.VerifyReply
+	46, // 41: threads.pb.WriteTransactionReply.saveReply:type_name -> threads.pb.SaveReply
+	50, // 42: threads.pb.WriteTransactionReply.deleteReply:type_name -> threads.pb.DeleteReply
+	52, // 43: threads.pb.WriteTransactionReply.hasReply:type_name -> threads.pb.HasReply
+	54, // 44: threads.pb.WriteTransactionReply.findReply:type_name -> threads.pb.FindReply
+	58, // 45: threads.pb.WriteTransactionReply.findByIDReply:type_name -> threads.pb.FindByIDReply
+	67, // 46: threads.pb.WriteTransactionReply.discardReply:type_name -> threads.pb.DiscardReply
+	60, // 47: threads.pb.WriteTransactionReply.findByIDsReply:type_name -> threads.pb.FindByIDsReply
+	48, // 48: threads.pb.WriteTransactionReply.saveExpectingReply:type_name -> threads.pb.SaveExpectingReply
+	76, // 49: threads.pb.ListenRequest.filters:type_name -> threads.pb.ListenRequest.Filter
+	2,  // 50: threads.pb.ListenReply.action:type_name -> threads.pb.ListenReply.Action
+	14, // 51: threads.pb.ListDBsReply.DB.info:type_name -> threads.pb.GetDBInfoReply
+	1,  // 52: threads.pb.ListenRequest.Filter.action:type_name -> threads.pb.ListenRequest.Filter.Action
+	3,  // 53: threads.pb.API.GetToken:input_type -> threads.pb.GetTokenRequest
+	5,  // 54: threads.pb.API.NewDB:input_type -> threads.pb.NewDBRequest
+	6,  // 55: threads.pb.API.NewDBFromAddr:input_type -> threads.pb.NewDBFromAddrRequest
+	6,  // 56: threads.pb.API.NewDBFromAddrWithProgress:input_type -> threads.pb.NewDBFromAddrRequest
+	11, // 57: threads.pb.API.ListDBs:input_type -> threads.pb.ListDBsRequest
+	13, // 58: threads.pb.API.GetDBInfo:input_type -> threads.pb.GetDBInfoRequest
+	15, // 59: threads.pb.API.DeleteDB:input_type -> threads.pb.DeleteDBRequest
+	17, // 60: threads.pb.API.NewCollection:input_type -> threads.pb.NewCollectionRequest
+	19, // 61: threads.pb.API.UpdateCollection:input_type -> threads.pb.UpdateCollectionRequest
+	21, // 62: threads.pb.API.CheckCollectionUpdate:input_type -> threads.pb.CheckCollectionUpdateRequest
+	23, // 63: threads.pb.API.DeleteCollection:input_type -> threads.pb.DeleteCollectionRequest
+	25, // 64: threads.pb.API.RenameCollection:input_type -> threads.pb.RenameCollectionRequest
+	27, // 65: threads.pb.API.GetCollectionInfo:input_type -> threads.pb.GetCollectionInfoRequest
+	29, // 66: threads.pb.API.GetCollectionIndexes:input_type -> threads.pb.GetCollectionIndexesRequest
+	31, // 67: threads.pb.API.ListCollections:input_type -> threads.pb.ListCollectionsRequest
+	35, // 68: threads.pb.API.GetStats:input_type -> threads.pb.GetStatsRequest
+	37, // 69: threads.pb.API.GetCollectionStats:input_type -> threads.pb.GetCollectionStatsRequest
+	39, // 70: threads.pb.API.GC:input_type -> threads.pb.GCRequest
+	41, // 71: threads.pb.API.Create:input_type -> threads.pb.CreateRequest
+	43, // 72: threads.pb.API.Verify:input_type -> threads.pb.VerifyRequest
+	45, // 73: threads.pb.API.Save:input_type -> threads.pb.SaveRequest
+	47, // 74: threads.pb.API.SaveExpecting:input_type -> threads.pb.SaveExpectingRequest
+	49, // 75: threads.pb.API.Delete:input_type -> threads.pb.DeleteRequest
+	51, // 76: threads.pb.API.Has:input_type -> threads.pb.HasRequest
+	53, // 77: threads.pb.API.Find:input_type -> threads.pb.FindRequest
+	53, // 78: threads.pb.API.FindStream:input_type -> threads.pb.FindRequest
+	57, // 79: threads.pb.API.FindByID:input_type -> threads.pb.FindByIDRequest
+	59, // 80: threads.pb.API.FindByIDs:input_type -> threads.pb.FindByIDsRequest
+	61, // 81: threads.pb.API.Count:input_type -> threads.pb.CountRequest
+	63, // 82: threads.pb.API.ValidateCollection:input_type -> threads.pb.ValidateCollectionRequest
+	69, // 83: threads.pb.API.ReadTransaction:input_type -> threads.pb.ReadTransactionRequest
+	71, // 84: threads.pb.API.WriteTransaction:input_type -> threads.pb.WriteTransactionRequest
+	73, // 85: threads.pb.API.Listen:input_type -> threads.pb.ListenRequest
+	4,  // 86: threads.pb.API.GetToken:output_type -> threads.pb.GetTokenReply
+	9,  // 87: threads.pb.API.NewDB:output_type -> threads.pb.NewDBReply
+	9,  // 88: threads.pb.API.NewDBFromAddr:output_type -> threads.pb.NewDBReply
+	10, // 89: threads.pb.API.NewDBFromAddrWithProgress:output_type -> threads.pb.NewDBFromAddrProgressReply
+	12, // 90: threads.pb.API.ListDBs:output_type -> threads.pb.ListDBsReply
+	14, // 91: threads.pb.API.GetDBInfo:output_type -> threads.pb.GetDBInfoReply
+	16, // 92: threads.pb.API.DeleteDB:output_type -> threads.pb.DeleteDBReply
+	18, // 93: threads.pb.API.NewCollection:output_type -> threads.pb.NewCollectionReply
+	20, // 94: threads.pb.API.UpdateCollection:output_type -> threads.pb.UpdateCollectionReply
+	22, // 95: threads.pb.API.CheckCollectionUpdate:output_type -> threads.pb.CheckCollectionUpdateReply
+	24, // 96: threads.pb.API.DeleteCollection:output_type -> threads.pb.DeleteCollectionReply
+	26, // 97: threads.pb.API.RenameCollection:output_type -> threads.pb.RenameCollectionReply
+	28, // 98: threads.pb.API.GetCollectionInfo:output_type -> threads.pb.GetCollectionInfoReply
+	30, // 99: threads.pb.API.GetCollectionIndexes:output_type -> threads.pb.GetCollectionIndexesReply
+	32, // 100: threads.pb.API.ListCollections:output_type -> threads.pb.ListCollectionsReply
+	36, // 101: threads.pb.API.GetStats:output_type -> threads.pb.GetStatsReply
+	38, // 102: threads.pb.API.GetCollectionStats:output_type -> threads.pb.GetCollectionStatsReply
+	40, // 103: threads.pb.API.GC:output_type -> threads.pb.GCReply
+	42, // 104: threads.pb.API.Create:output_type -> threads.pb.CreateReply
+	44, // 105: threads.pb.API.Verify:output_type -> threads.pb.VerifyReply
+	46, // 106: threads.pb.API.Save:output_type -> threads.pb.SaveReply
+	48, // 107: threads.pb.API.SaveExpecting:output_type -> threads.pb.SaveExpectingReply
+	50, // 108: threads.pb.API.Delete:output_type -> threads.pb.DeleteReply
+	52, // 109: threads.pb.API.Has:output_type -> threads.pb.HasReply
+	54, // 110: threads.pb.API.Find:output_type -> threads.pb.FindReply
+	56, // 111: threads.pb.API.FindStream:output_type -> threads.pb.FindStreamReply
+	58, // 112: threads.pb.API.FindByID:output_type -> threads.pb.FindByIDReply
+	60, // 113: threads.pb.API.FindByIDs:output_type -> threads.pb.FindByIDsReply
+	62, // 114: threads.pb.API.Count:output_type -> threads.pb.CountReply
+	64, // 115: threads.pb.API.ValidateCollection:output_type -> threads.pb.ValidateCollectionReply
+	70, // 116: threads.pb.API.ReadTransaction:output_type -> threads.pb.ReadTransactionReply
+	72, // 117: threads.pb.API.WriteTransaction:output_type -> threads.pb.WriteTransactionReply
+	74, // 118: threads.pb.API.Listen:output_type -> threads.pb.ListenReply
+	86, // [86:119] is the sub-list for method output_type
+	53, // [53:86] is the sub-list for method input_type
+	53, // [53:53] is the sub-list for extension type_name
+	53, // [53:53] is the sub-list for extension extendee
+	0,  // [0:53] is the sub-list for field type_name
 }
 
 func init() { file_threads_proto_init() }
@@ -3899,8 +6136,140 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetTokenReply); i {
+		file_threads_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTokenReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewDBRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewDBFromAddrRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CollectionConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Index); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewDBReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewDBFromAddrProgressReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDBsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDBsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDBInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDBInfoReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteDBRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3911,8 +6280,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewDBRequest); i {
+		file_threads_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteDBReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3923,8 +6292,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewDBFromAddrRequest); i {
+		file_threads_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewCollectionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3935,8 +6304,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CollectionConfig); i {
+		file_threads_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewCollectionReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3947,8 +6316,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Index); i {
+		file_threads_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateCollectionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3959,8 +6328,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewDBReply); i {
+		file_threads_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateCollectionReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3971,8 +6340,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListDBsRequest); i {
+		file_threads_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckCollectionUpdateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3983,8 +6352,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListDBsReply); i {
+		file_threads_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckCollectionUpdateReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3995,8 +6364,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetDBInfoRequest); i {
+		file_threads_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteCollectionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4007,8 +6376,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetDBInfoReply); i {
+		file_threads_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteCollectionReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4019,8 +6388,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteDBRequest); i {
+		file_threads_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenameCollectionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4031,8 +6400,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteDBReply); i {
+		file_threads_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenameCollectionReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4043,8 +6412,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewCollectionRequest); i {
+		file_threads_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCollectionInfoRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4055,8 +6424,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewCollectionReply); i {
+		file_threads_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCollectionInfoReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4067,8 +6436,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateCollectionRequest); i {
+		file_threads_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCollectionIndexesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4079,8 +6448,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateCollectionReply); i {
+		file_threads_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCollectionIndexesReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4091,8 +6460,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteCollectionRequest); i {
+		file_threads_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCollectionsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4103,8 +6472,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteCollectionReply); i {
+		file_threads_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCollectionsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4115,8 +6484,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetCollectionInfoRequest); i {
+		file_threads_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CollectionStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4127,8 +6496,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetCollectionInfoReply); i {
+		file_threads_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4139,8 +6508,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetCollectionIndexesRequest); i {
+		file_threads_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStatsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4151,8 +6520,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetCollectionIndexesReply); i {
+		file_threads_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStatsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4163,8 +6532,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListCollectionsRequest); i {
+		file_threads_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCollectionStatsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4175,8 +6544,8 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListCollectionsReply); i {
+		file_threads_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCollectionStatsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4187,7 +6556,31 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GCRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GCReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateRequest); i {
 			case 0:
 				return &v.state
@@ -4199,7 +6592,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateReply); i {
 			case 0:
 				return &v.state
@@ -4211,7 +6604,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*VerifyRequest); i {
 			case 0:
 				return &v.state
@@ -4223,7 +6616,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*VerifyReply); i {
 			case 0:
 				return &v.state
@@ -4235,7 +6628,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SaveRequest); i {
 			case 0:
 				return &v.state
@@ -4247,7 +6640,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SaveReply); i {
 			case 0:
 				return &v.state
@@ -4259,7 +6652,31 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveExpectingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveExpectingReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteRequest); i {
 			case 0:
 				return &v.state
@@ -4271,7 +6688,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteReply); i {
 			case 0:
 				return &v.state
@@ -4283,7 +6700,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*HasRequest); i {
 			case 0:
 				return &v.state
@@ -4295,7 +6712,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*HasReply); i {
 			case 0:
 				return &v.state
@@ -4307,7 +6724,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FindRequest); i {
 			case 0:
 				return &v.state
@@ -4319,7 +6736,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FindReply); i {
 			case 0:
 				return &v.state
@@ -4331,7 +6748,31 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExplainResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindStreamReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FindByIDRequest); i {
 			case 0:
 				return &v.state
@@ -4343,7 +6784,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FindByIDReply); i {
 			case 0:
 				return &v.state
@@ -4355,7 +6796,91 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindByIDsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindByIDsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CountRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CountReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidateCollectionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidateCollectionReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidationError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threads_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DiscardRequest); i {
 			case 0:
 				return &v.state
@@ -4367,7 +6892,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DiscardReply); i {
 			case 0:
 				return &v.state
@@ -4379,7 +6904,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StartTransactionRequest); i {
 			case 0:
 				return &v.state
@@ -4391,7 +6916,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReadTransactionRequest); i {
 			case 0:
 				return &v.state
@@ -4403,7 +6928,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReadTransactionReply); i {
 			case 0:
 				return &v.state
@@ -4415,7 +6940,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WriteTransactionRequest); i {
 			case 0:
 				return &v.state
@@ -4427,7 +6952,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WriteTransactionReply); i {
 			case 0:
 				return &v.state
@@ -4439,7 +6964,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListenRequest); i {
 			case 0:
 				return &v.state
@@ -4451,7 +6976,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListenReply); i {
 			case 0:
 				return &v.state
@@ -4463,7 +6988,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListDBsReply_DB); i {
 			case 0:
 				return &v.state
@@ -4475,7 +7000,7 @@ func file_threads_proto_init() {
 				return nil
 			}
 		}
-		file_threads_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+		file_threads_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListenRequest_Filter); i {
 			case 0:
 				return &v.state
@@ -4496,18 +7021,22 @@ func file_threads_proto_init() {
 		(*GetTokenReply_Challenge)(nil),
 		(*GetTokenReply_Token)(nil),
 	}
-	file_threads_proto_msgTypes[42].OneofWrappers = []interface{}{
+	file_threads_proto_msgTypes[66].OneofWrappers = []interface{}{
 		(*ReadTransactionRequest_StartTransactionRequest)(nil),
 		(*ReadTransactionRequest_HasRequest)(nil),
 		(*ReadTransactionRequest_FindRequest)(nil),
 		(*ReadTransactionRequest_FindByIDRequest)(nil),
+		(*ReadTransactionRequest_CountRequest)(nil),
+		(*ReadTransactionRequest_FindByIDsRequest)(nil),
 	}
-	file_threads_proto_msgTypes[43].OneofWrappers = []interface{}{
+	file_threads_proto_msgTypes[67].OneofWrappers = []interface{}{
 		(*ReadTransactionReply_HasReply)(nil),
 		(*ReadTransactionReply_FindReply)(nil),
 		(*ReadTransactionReply_FindByIDReply)(nil),
+		(*ReadTransactionReply_CountReply)(nil),
+		(*ReadTransactionReply_FindByIDsReply)(nil),
 	}
-	file_threads_proto_msgTypes[44].OneofWrappers = []interface{}{
+	file_threads_proto_msgTypes[68].OneofWrappers = []interface{}{
 		(*WriteTransactionRequest_StartTransactionRequest)(nil),
 		(*WriteTransactionRequest_CreateRequest)(nil),
 		(*WriteTransactionRequest_VerifyRequest)(nil),
@@ -4517,8 +7046,10 @@ func file_threads_proto_init() {
 		(*WriteTransactionRequest_FindRequest)(nil),
 		(*WriteTransactionRequest_FindByIDRequest)(nil),
 		(*WriteTransactionRequest_DiscardRequest)(nil),
+		(*WriteTransactionRequest_FindByIDsRequest)(nil),
+		(*WriteTransactionRequest_SaveExpectingRequest)(nil),
 	}
-	file_threads_proto_msgTypes[45].OneofWrappers = []interface{}{
+	file_threads_proto_msgTypes[69].OneofWrappers = []interface{}{
 		(*WriteTransactionReply_CreateReply)(nil),
 		(*WriteTransactionReply_VerifyReply)(nil),
 		(*WriteTransactionReply_SaveReply)(nil),
@@ -4527,14 +7058,16 @@ func file_threads_proto_init() {
 		(*WriteTransactionReply_FindReply)(nil),
 		(*WriteTransactionReply_FindByIDReply)(nil),
 		(*WriteTransactionReply_DiscardReply)(nil),
+		(*WriteTransactionReply_FindByIDsReply)(nil),
+		(*WriteTransactionReply_SaveExpectingReply)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_threads_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   50,
+			NumEnums:      3,
+			NumMessages:   74,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,23 +21,34 @@ type APIClient interface {
 	GetToken(ctx context.Context, opts ...grpc.CallOption) (API_GetTokenClient, error)
 	NewDB(ctx context.Context, in *NewDBRequest, opts ...grpc.CallOption) (*NewDBReply, error)
 	NewDBFromAddr(ctx context.Context, in *NewDBFromAddrRequest, opts ...grpc.CallOption) (*NewDBReply, error)
+	NewDBFromAddrWithProgress(ctx context.Context, in *NewDBFromAddrRequest, opts ...grpc.CallOption) (API_NewDBFromAddrWithProgressClient, error)
 	ListDBs(ctx context.Context, in *ListDBsRequest, opts ...grpc.CallOption) (*ListDBsReply, error)
 	GetDBInfo(ctx context.Context, in *GetDBInfoRequest, opts ...grpc.CallOption) (*GetDBInfoReply, error)
 	DeleteDB(ctx context.Context, in *DeleteDBRequest, opts ...grpc.CallOption) (*DeleteDBReply, error)
 	NewCollection(ctx context.Context, in *NewCollectionRequest, opts ...grpc.CallOption) (*NewCollectionReply, error)
 	UpdateCollection(ctx context.Context, in *UpdateCollectionRequest, opts ...grpc.CallOption) (*UpdateCollectionReply, error)
+	CheckCollectionUpdate(ctx context.Context, in *CheckCollectionUpdateRequest, opts ...grpc.CallOption) (*CheckCollectionUpdateReply, error)
 	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionReply, error)
+	RenameCollection(ctx context.Context, in *RenameCollectionRequest, opts ...grpc.CallOption) (*RenameCollectionReply, error)
 	GetCollectionInfo(ctx context.Context, in *GetCollectionInfoRequest, opts ...grpc.CallOption) (*GetCollectionInfoReply, error)
 	// Deprecated: Do not use.
 	GetCollectionIndexes(ctx context.Context, in *GetCollectionIndexesRequest, opts ...grpc.CallOption) (*GetCollectionIndexesReply, error)
 	ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsReply, error)
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsReply, error)
+	GetCollectionStats(ctx context.Context, in *GetCollectionStatsRequest, opts ...grpc.CallOption) (*GetCollectionStatsReply, error)
+	GC(ctx context.Context, in *GCRequest, opts ...grpc.CallOption) (*GCReply, error)
 	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateReply, error)
 	Verify(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*VerifyReply, error)
 	Save(ctx context.Context, in *SaveRequest, opts ...grpc.CallOption) (*SaveReply, error)
+	SaveExpecting(ctx context.Context, in *SaveExpectingRequest, opts ...grpc.CallOption) (*SaveExpectingReply, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteReply, error)
 	Has(ctx context.Context, in *HasRequest, opts ...grpc.CallOption) (*HasReply, error)
 	Find(ctx context.Context, in *FindRequest, opts ...grpc.CallOption) (*FindReply, error)
+	FindStream(ctx context.Context, in *FindRequest, opts ...grpc.CallOption) (API_FindStreamClient, error)
 	FindByID(ctx context.Context, in *FindByIDRequest, opts ...grpc.CallOption) (*FindByIDReply, error)
+	FindByIDs(ctx context.Context, in *FindByIDsRequest, opts ...grpc.CallOption) (*FindByIDsReply, error)
+	Count(ctx context.Context, in *CountRequest, opts ...grpc.CallOption) (*CountReply, error)
+	ValidateCollection(ctx context.Context, in *ValidateCollectionRequest, opts ...grpc.CallOption) (*ValidateCollectionReply, error)
 	ReadTransaction(ctx context.Context, opts ...grpc.CallOption) (API_ReadTransactionClient, error)
 	WriteTransaction(ctx context.Context, opts ...grpc.CallOption) (API_WriteTransactionClient, error)
 	Listen(ctx context.Context, in *ListenRequest, opts ...grpc.CallOption) (API_ListenClient, error)
@@ -100,6 +111,38 @@ func (c *aPIClient) NewDBFromAddr(ctx context.Context, in *NewDBFromAddrRequest,
 	return out, nil
 }
 
+func (c *aPIClient) NewDBFromAddrWithProgress(ctx context.Context, in *NewDBFromAddrRequest, opts ...grpc.CallOption) (API_NewDBFromAddrWithProgressClient, error) {
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[1], "/threads.pb.API/NewDBFromAddrWithProgress", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPINewDBFromAddrWithProgressClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_NewDBFromAddrWithProgressClient interface {
+	Recv() (*NewDBFromAddrProgressReply, error)
+	grpc.ClientStream
+}
+
+type aPINewDBFromAddrWithProgressClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPINewDBFromAddrWithProgressClient) Recv() (*NewDBFromAddrProgressReply, error) {
+	m := new(NewDBFromAddrProgressReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *aPIClient) ListDBs(ctx context.Context, in *ListDBsRequest, opts ...grpc.CallOption) (*ListDBsReply, error) {
 	out := new(ListDBsReply)
 	err := c.cc.Invoke(ctx, "/threads.pb.API/ListDBs", in, out, opts...)
@@ -145,6 +188,15 @@ func (c *aPIClient) UpdateCollection(ctx context.Context, in *UpdateCollectionRe
 	return out, nil
 }
 
+func (c *aPIClient) CheckCollectionUpdate(ctx context.Context, in *CheckCollectionUpdateRequest, opts ...grpc.CallOption) (*CheckCollectionUpdateReply, error) {
+	out := new(CheckCollectionUpdateReply)
+	err := c.cc.Invoke(ctx, "/threads.pb.API/CheckCollectionUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionReply, error) {
 	out := new(DeleteCollectionReply)
 	err := c.cc.Invoke(ctx, "/threads.pb.API/DeleteCollection", in, out, opts...)
@@ -154,6 +206,15 @@ func (c *aPIClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRe
 	return out, nil
 }
 
+func (c *aPIClient) RenameCollection(ctx context.Context, in *RenameCollectionRequest, opts ...grpc.CallOption) (*RenameCollectionReply, error) {
+	out := new(RenameCollectionReply)
+	err := c.cc.Invoke(ctx, "/threads.pb.API/RenameCollection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) GetCollectionInfo(ctx context.Context, in *GetCollectionInfoRequest, opts ...grpc.CallOption) (*GetCollectionInfoReply, error) {
 	out := new(GetCollectionInfoReply)
 	err := c.cc.Invoke(ctx, "/threads.pb.API/GetCollectionInfo", in, out, opts...)
@@ -182,6 +243,33 @@ func (c *aPIClient) ListCollections(ctx context.Context, in *ListCollectionsRequ
 	return out, nil
 }
 
+func (c *aPIClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsReply, error) {
+	out := new(GetStatsReply)
+	err := c.cc.Invoke(ctx, "/threads.pb.API/GetStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetCollectionStats(ctx context.Context, in *GetCollectionStatsRequest, opts ...grpc.CallOption) (*GetCollectionStatsReply, error) {
+	out := new(GetCollectionStatsReply)
+	err := c.cc.Invoke(ctx, "/threads.pb.API/GetCollectionStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GC(ctx context.Context, in *GCRequest, opts ...grpc.CallOption) (*GCReply, error) {
+	out := new(GCReply)
+	err := c.cc.Invoke(ctx, "/threads.pb.API/GC", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateReply, error) {
 	out := new(CreateReply)
 	err := c.cc.Invoke(ctx, "/threads.pb.API/Create", in, out, opts...)
@@ -209,6 +297,15 @@ func (c *aPIClient) Save(ctx context.Context, in *SaveRequest, opts ...grpc.Call
 	return out, nil
 }
 
+func (c *aPIClient) SaveExpecting(ctx context.Context, in *SaveExpectingRequest, opts ...grpc.CallOption) (*SaveExpectingReply, error) {
+	out := new(SaveExpectingReply)
+	err := c.cc.Invoke(ctx, "/threads.pb.API/SaveExpecting", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteReply, error) {
 	out := new(DeleteReply)
 	err := c.cc.Invoke(ctx, "/threads.pb.API/Delete", in, out, opts...)
@@ -236,6 +333,38 @@ func (c *aPIClient) Find(ctx context.Context, in *FindRequest, opts ...grpc.Call
 	return out, nil
 }
 
+func (c *aPIClient) FindStream(ctx context.Context, in *FindRequest, opts ...grpc.CallOption) (API_FindStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[2], "/threads.pb.API/FindStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIFindStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_FindStreamClient interface {
+	Recv() (*FindStreamReply, error)
+	grpc.ClientStream
+}
+
+type aPIFindStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIFindStreamClient) Recv() (*FindStreamReply, error) {
+	m := new(FindStreamReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *aPIClient) FindByID(ctx context.Context, in *FindByIDRequest, opts ...grpc.CallOption) (*FindByIDReply, error) {
 	out := new(FindByIDReply)
 	err := c.cc.Invoke(ctx, "/threads.pb.API/FindByID", in, out, opts...)
@@ -245,8 +374,35 @@ func (c *aPIClient) FindByID(ctx context.Context, in *FindByIDRequest, opts ...g
 	return out, nil
 }
 
+func (c *aPIClient) FindByIDs(ctx context.Context, in *FindByIDsRequest, opts ...grpc.CallOption) (*FindByIDsReply, error) {
+	out := new(FindByIDsReply)
+	err := c.cc.Invoke(ctx, "/threads.pb.API/FindByIDs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Count(ctx context.Context, in *CountRequest, opts ...grpc.CallOption) (*CountReply, error) {
+	out := new(CountReply)
+	err := c.cc.Invoke(ctx, "/threads.pb.API/Count", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ValidateCollection(ctx context.Context, in *ValidateCollectionRequest, opts ...grpc.CallOption) (*ValidateCollectionReply, error) {
+	out := new(ValidateCollectionReply)
+	err := c.cc.Invoke(ctx, "/threads.pb.API/ValidateCollection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) ReadTransaction(ctx context.Context, opts ...grpc.CallOption) (API_ReadTransactionClient, error) {
-	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[1], "/threads.pb.API/ReadTransaction", opts...)
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[3], "/threads.pb.API/ReadTransaction", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -277,7 +433,7 @@ func (x *aPIReadTransactionClient) Recv() (*ReadTransactionReply, error) {
 }
 
 func (c *aPIClient) WriteTransaction(ctx context.Context, opts ...grpc.CallOption) (API_WriteTransactionClient, error) {
-	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[2], "/threads.pb.API/WriteTransaction", opts...)
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[4], "/threads.pb.API/WriteTransaction", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -308,7 +464,7 @@ func (x *aPIWriteTransactionClient) Recv() (*WriteTransactionReply, error) {
 }
 
 func (c *aPIClient) Listen(ctx context.Context, in *ListenRequest, opts ...grpc.CallOption) (API_ListenClient, error) {
-	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[3], "/threads.pb.API/Listen", opts...)
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[5], "/threads.pb.API/Listen", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -346,23 +502,34 @@ type APIServer interface {
 	GetToken(API_GetTokenServer) error
 	NewDB(context.Context, *NewDBRequest) (*NewDBReply, error)
 	NewDBFromAddr(context.Context, *NewDBFromAddrRequest) (*NewDBReply, error)
+	NewDBFromAddrWithProgress(*NewDBFromAddrRequest, API_NewDBFromAddrWithProgressServer) error
 	ListDBs(context.Context, *ListDBsRequest) (*ListDBsReply, error)
 	GetDBInfo(context.Context, *GetDBInfoRequest) (*GetDBInfoReply, error)
 	DeleteDB(context.Context, *DeleteDBRequest) (*DeleteDBReply, error)
 	NewCollection(context.Context, *NewCollectionRequest) (*NewCollectionReply, error)
 	UpdateCollection(context.Context, *UpdateCollectionRequest) (*UpdateCollectionReply, error)
+	CheckCollectionUpdate(context.Context, *CheckCollectionUpdateRequest) (*CheckCollectionUpdateReply, error)
 	DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionReply, error)
+	RenameCollection(context.Context, *RenameCollectionRequest) (*RenameCollectionReply, error)
 	GetCollectionInfo(context.Context, *GetCollectionInfoRequest) (*GetCollectionInfoReply, error)
 	// Deprecated: Do not use.
 	GetCollectionIndexes(context.Context, *GetCollectionIndexesRequest) (*GetCollectionIndexesReply, error)
 	ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsReply, error)
+	GetStats(context.Context, *GetStatsRequest) (*GetStatsReply, error)
+	GetCollectionStats(context.Context, *GetCollectionStatsRequest) (*GetCollectionStatsReply, error)
+	GC(context.Context, *GCRequest) (*GCReply, error)
 	Create(context.Context, *CreateRequest) (*CreateReply, error)
 	Verify(context.Context, *VerifyRequest) (*VerifyReply, error)
 	Save(context.Context, *SaveRequest) (*SaveReply, error)
+	SaveExpecting(context.Context, *SaveExpectingRequest) (*SaveExpectingReply, error)
 	Delete(context.Context, *DeleteRequest) (*DeleteReply, error)
 	Has(context.Context, *HasRequest) (*HasReply, error)
 	Find(context.Context, *FindRequest) (*FindReply, error)
+	FindStream(*FindRequest, API_FindStreamServer) error
 	FindByID(context.Context, *FindByIDRequest) (*FindByIDReply, error)
+	FindByIDs(context.Context, *FindByIDsRequest) (*FindByIDsReply, error)
+	Count(context.Context, *CountRequest) (*CountReply, error)
+	ValidateCollection(context.Context, *ValidateCollectionRequest) (*ValidateCollectionReply, error)
 	ReadTransaction(API_ReadTransactionServer) error
 	WriteTransaction(API_WriteTransactionServer) error
 	Listen(*ListenRequest, API_ListenServer) error
@@ -382,6 +549,9 @@ func (UnimplementedAPIServer) NewDB(context.Context, *NewDBRequest) (*NewDBReply
 func (UnimplementedAPIServer) NewDBFromAddr(context.Context, *NewDBFromAddrRequest) (*NewDBReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NewDBFromAddr not implemented")
 }
+func (UnimplementedAPIServer) NewDBFromAddrWithProgress(*NewDBFromAddrRequest, API_NewDBFromAddrWithProgressServer) error {
+	return status.Errorf(codes.Unimplemented, "method NewDBFromAddrWithProgress not implemented")
+}
 func (UnimplementedAPIServer) ListDBs(context.Context, *ListDBsRequest) (*ListDBsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListDBs not implemented")
 }
@@ -397,9 +567,15 @@ func (UnimplementedAPIServer) NewCollection(context.Context, *NewCollectionReque
 func (UnimplementedAPIServer) UpdateCollection(context.Context, *UpdateCollectionRequest) (*UpdateCollectionReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateCollection not implemented")
 }
+func (UnimplementedAPIServer) CheckCollectionUpdate(context.Context, *CheckCollectionUpdateRequest) (*CheckCollectionUpdateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckCollectionUpdate not implemented")
+}
 func (UnimplementedAPIServer) DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteCollection not implemented")
 }
+func (UnimplementedAPIServer) RenameCollection(context.Context, *RenameCollectionRequest) (*RenameCollectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameCollection not implemented")
+}
 func (UnimplementedAPIServer) GetCollectionInfo(context.Context, *GetCollectionInfoRequest) (*GetCollectionInfoReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCollectionInfo not implemented")
 }
@@ -409,6 +585,15 @@ func (UnimplementedAPIServer) GetCollectionIndexes(context.Context, *GetCollecti
 func (UnimplementedAPIServer) ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListCollections not implemented")
 }
+func (UnimplementedAPIServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedAPIServer) GetCollectionStats(context.Context, *GetCollectionStatsRequest) (*GetCollectionStatsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCollectionStats not implemented")
+}
+func (UnimplementedAPIServer) GC(context.Context, *GCRequest) (*GCReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GC not implemented")
+}
 func (UnimplementedAPIServer) Create(context.Context, *CreateRequest) (*CreateReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
 }
@@ -418,6 +603,9 @@ func (UnimplementedAPIServer) Verify(context.Context, *VerifyRequest) (*VerifyRe
 func (UnimplementedAPIServer) Save(context.Context, *SaveRequest) (*SaveReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Save not implemented")
 }
+func (UnimplementedAPIServer) SaveExpecting(context.Context, *SaveExpectingRequest) (*SaveExpectingReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveExpecting not implemented")
+}
 func (UnimplementedAPIServer) Delete(context.Context, *DeleteRequest) (*DeleteReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
@@ -427,9 +615,21 @@ func (UnimplementedAPIServer) Has(context.Context, *HasRequest) (*HasReply, erro
 func (UnimplementedAPIServer) Find(context.Context, *FindRequest) (*FindReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Find not implemented")
 }
+func (UnimplementedAPIServer) FindStream(*FindRequest, API_FindStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method FindStream not implemented")
+}
 func (UnimplementedAPIServer) FindByID(context.Context, *FindByIDRequest) (*FindByIDReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindByID not implemented")
 }
+func (UnimplementedAPIServer) FindByIDs(context.Context, *FindByIDsRequest) (*FindByIDsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindByIDs not implemented")
+}
+func (UnimplementedAPIServer) Count(context.Context, *CountRequest) (*CountReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Count not implemented")
+}
+func (UnimplementedAPIServer) ValidateCollection(context.Context, *ValidateCollectionRequest) (*ValidateCollectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateCollection not implemented")
+}
 func (UnimplementedAPIServer) ReadTransaction(API_ReadTransactionServer) error {
 	return status.Errorf(codes.Unimplemented, "method ReadTransaction not implemented")
 }
@@ -514,6 +714,27 @@ func _API_NewDBFromAddr_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_NewDBFromAddrWithProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(NewDBFromAddrRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).NewDBFromAddrWithProgress(m, &aPINewDBFromAddrWithProgressServer{stream})
+}
+
+type API_NewDBFromAddrWithProgressServer interface {
+	Send(*NewDBFromAddrProgressReply) error
+	grpc.ServerStream
+}
+
+type aPINewDBFromAddrWithProgressServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPINewDBFromAddrWithProgressServer) Send(m *NewDBFromAddrProgressReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _API_ListDBs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListDBsRequest)
 	if err := dec(in); err != nil {
@@ -604,6 +825,24 @@ func _API_UpdateCollection_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_CheckCollectionUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckCollectionUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CheckCollectionUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.pb.API/CheckCollectionUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CheckCollectionUpdate(ctx, req.(*CheckCollectionUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_DeleteCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteCollectionRequest)
 	if err := dec(in); err != nil {
@@ -622,6 +861,24 @@ func _API_DeleteCollection_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_RenameCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).RenameCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.pb.API/RenameCollection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).RenameCollection(ctx, req.(*RenameCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_GetCollectionInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetCollectionInfoRequest)
 	if err := dec(in); err != nil {
@@ -676,6 +933,60 @@ func _API_ListCollections_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.pb.API/GetStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetCollectionStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCollectionStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetCollectionStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.pb.API/GetCollectionStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetCollectionStats(ctx, req.(*GetCollectionStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GC_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GCRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GC(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.pb.API/GC",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GC(ctx, req.(*GCRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateRequest)
 	if err := dec(in); err != nil {
@@ -730,6 +1041,24 @@ func _API_Save_Handler(srv interface{}, ctx context.Context, dec func(interface{
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_SaveExpecting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveExpectingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).SaveExpecting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.pb.API/SaveExpecting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SaveExpecting(ctx, req.(*SaveExpectingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteRequest)
 	if err := dec(in); err != nil {
@@ -784,6 +1113,27 @@ func _API_Find_Handler(srv interface{}, ctx context.Context, dec func(interface{
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_FindStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).FindStream(m, &aPIFindStreamServer{stream})
+}
+
+type API_FindStreamServer interface {
+	Send(*FindStreamReply) error
+	grpc.ServerStream
+}
+
+type aPIFindStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIFindStreamServer) Send(m *FindStreamReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _API_FindByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FindByIDRequest)
 	if err := dec(in); err != nil {
@@ -802,6 +1152,60 @@ func _API_FindByID_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_FindByIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindByIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).FindByIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.pb.API/FindByIDs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).FindByIDs(ctx, req.(*FindByIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_Count_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Count(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.pb.API/Count",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Count(ctx, req.(*CountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ValidateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ValidateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.pb.API/ValidateCollection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ValidateCollection(ctx, req.(*ValidateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_ReadTransaction_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(APIServer).ReadTransaction(&aPIReadTransactionServer{stream})
 }
@@ -910,10 +1314,18 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateCollection",
 			Handler:    _API_UpdateCollection_Handler,
 		},
+		{
+			MethodName: "CheckCollectionUpdate",
+			Handler:    _API_CheckCollectionUpdate_Handler,
+		},
 		{
 			MethodName: "DeleteCollection",
 			Handler:    _API_DeleteCollection_Handler,
 		},
+		{
+			MethodName: "RenameCollection",
+			Handler:    _API_RenameCollection_Handler,
+		},
 		{
 			MethodName: "GetCollectionInfo",
 			Handler:    _API_GetCollectionInfo_Handler,
@@ -926,6 +1338,18 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListCollections",
 			Handler:    _API_ListCollections_Handler,
 		},
+		{
+			MethodName: "GetStats",
+			Handler:    _API_GetStats_Handler,
+		},
+		{
+			MethodName: "GetCollectionStats",
+			Handler:    _API_GetCollectionStats_Handler,
+		},
+		{
+			MethodName: "GC",
+			Handler:    _API_GC_Handler,
+		},
 		{
 			MethodName: "Create",
 			Handler:    _API_Create_Handler,
@@ -938,6 +1362,10 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Save",
 			Handler:    _API_Save_Handler,
 		},
+		{
+			MethodName: "SaveExpecting",
+			Handler:    _API_SaveExpecting_Handler,
+		},
 		{
 			MethodName: "Delete",
 			Handler:    _API_Delete_Handler,
@@ -954,6 +1382,18 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FindByID",
 			Handler:    _API_FindByID_Handler,
 		},
+		{
+			MethodName: "FindByIDs",
+			Handler:    _API_FindByIDs_Handler,
+		},
+		{
+			MethodName: "Count",
+			Handler:    _API_Count_Handler,
+		},
+		{
+			MethodName: "ValidateCollection",
+			Handler:    _API_ValidateCollection_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -962,6 +1402,16 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "NewDBFromAddrWithProgress",
+			Handler:       _API_NewDBFromAddrWithProgress_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FindStream",
+			Handler:       _API_FindStream_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "ReadTransaction",
 			Handler:       _API_ReadTransaction_Handler,
@@ -7,11 +7,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
+	"time"
 
 	"github.com/alecthomas/jsonschema"
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/libp2p/go-libp2p-core/crypto"
 	ma "github.com/multiformats/go-multiaddr"
+	sym "github.com/textileio/crypto/symmetric"
 	pb "github.com/textileio/go-threads/api/pb"
 	"github.com/textileio/go-threads/core/app"
 	core "github.com/textileio/go-threads/core/db"
@@ -36,7 +39,13 @@ type Service struct {
 
 // Config specifies service settings.
 type Config struct {
-	Debug bool
+	// GCInterval is the interval at which the garbage of the store of the
+	// dbs is collected. Zero disables it.
+	GCInterval time.Duration
+	// EncryptionKey is the key the values of the store of the dbs are
+	// encrypted with at rest. Nil leaves them in plaintext.
+	EncryptionKey *sym.Key
+	Debug         bool
 }
 
 // NewService starts and returns a new service with the given network.
@@ -48,7 +57,13 @@ func NewService(store kt.TxnDatastoreExtended, network app.Net, conf Config) (*S
 		return nil, err
 	}
 
-	manager, err := db.NewManager(store, network, db.WithNewDebug(conf.Debug))
+	manager, err := db.NewManager(
+		store,
+		network,
+		db.WithNewGCInterval(conf.GCInterval),
+		db.WithNewEncryptionKey(conf.EncryptionKey),
+		db.WithNewDebug(conf.Debug),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -207,44 +222,82 @@ func (s *Service) NewDB(ctx context.Context, req *pb.NewDBRequest) (*pb.NewDBRep
 
 func (s *Service) NewDBFromAddr(ctx context.Context, req *pb.NewDBFromAddrRequest) (*pb.NewDBReply, error) {
 	log.Debug("received new db from address request")
+	if err := s.newDBFromAddr(ctx, req); err != nil {
+		return nil, err
+	}
+	return &pb.NewDBReply{}, nil
+}
+
+// NewDBFromAddrWithProgress is NewDBFromAddr streaming the progress of the
+// sync until the db is created, which is once the thread is pulled if the
+// request blocks.
+func (s *Service) NewDBFromAddrWithProgress(req *pb.NewDBFromAddrRequest, server pb.API_NewDBFromAddrWithProgressServer) error {
+	log.Debug("received new db from address with progress request")
+	// progress of the background pull of non-blocking requests isn't sent
+	// once the stream ends
+	var lock sync.Mutex
+	var done bool
+	progress := func(p db.SyncProgress) {
+		lock.Lock()
+		defer lock.Unlock()
+		if done {
+			return
+		}
+		if err := server.Send(&pb.NewDBFromAddrProgressReply{
+			Stage:        pb.NewDBFromAddrProgressReply_Stage(p.Stage),
+			Logs:         int64(p.Logs),
+			Records:      int64(p.Records),
+			TotalRecords: int64(p.TotalRecords),
+			Events:       int64(p.Events),
+		}); err != nil {
+			log.Errorf("error sending progress: %v", err)
+		}
+	}
+	err := s.newDBFromAddr(server.Context(), req, db.WithNewManagedProgress(progress))
+	lock.Lock()
+	done = true
+	lock.Unlock()
+	return err
+}
 
+func (s *Service) newDBFromAddr(ctx context.Context, req *pb.NewDBFromAddrRequest, opts ...db.NewManagedOption) error {
 	addr, err := ma.NewMultiaddrBytes(req.Addr)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return status.Error(codes.InvalidArgument, err.Error())
 	}
 	key, err := thread.KeyFromBytes(req.Key)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return status.Error(codes.InvalidArgument, err.Error())
 	}
 	collections := make([]db.CollectionConfig, len(req.Collections))
 	for i, c := range req.Collections {
 		cc, err := collectionConfigFromPb(c)
 		if err != nil {
-			return nil, status.Error(codes.InvalidArgument, err.Error())
+			return status.Error(codes.InvalidArgument, err.Error())
 		}
 		collections[i] = cc
 	}
 	token, err := thread.NewTokenFromMD(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	logKey, err := logKeyFromBytes(req.LogKey)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return status.Error(codes.InvalidArgument, err.Error())
 	}
-	if _, err = s.manager.NewDBFromAddr(
+	_, err = s.manager.NewDBFromAddr(
 		ctx,
 		addr,
 		key,
-		db.WithNewManagedLogKey(logKey),
-		db.WithNewManagedName(req.Name),
-		db.WithNewManagedCollections(collections...),
-		db.WithNewManagedBackfillBlock(req.Block),
-		db.WithNewManagedToken(token),
-	); err != nil {
-		return nil, err
-	}
-	return &pb.NewDBReply{}, nil
+		append([]db.NewManagedOption{
+			db.WithNewManagedLogKey(logKey),
+			db.WithNewManagedName(req.Name),
+			db.WithNewManagedCollections(collections...),
+			db.WithNewManagedBackfillBlock(req.Block),
+			db.WithNewManagedToken(token),
+		}, opts...)...,
+	)
+	return err
 }
 
 func logKeyFromBytes(logKey []byte) (lk crypto.Key, err error) {
@@ -265,8 +318,10 @@ func collectionConfigFromPb(pbc *pb.CollectionConfig) (db.CollectionConfig, erro
 	indexes := make([]db.Index, len(pbc.Indexes))
 	for i, index := range pbc.Indexes {
 		indexes[i] = db.Index{
-			Path:   index.Path,
-			Unique: index.Unique,
+			Path:     index.Path,
+			Unique:   index.Unique,
+			FullText: index.FullText,
+			Fields:   index.Fields,
 		}
 	}
 	schema := &jsonschema.Schema{}
@@ -279,34 +334,63 @@ func collectionConfigFromPb(pbc *pb.CollectionConfig) (db.CollectionConfig, erro
 		Indexes:        indexes,
 		WriteValidator: pbc.WriteValidator,
 		ReadFilter:     pbc.ReadFilter,
+		SoftDelete:     pbc.SoftDelete,
+		ExpiryField:    pbc.ExpiryField,
+		ApplyDefaults:  pbc.ApplyDefaults,
 	}, nil
 }
 
-func (s *Service) ListDBs(ctx context.Context, _ *pb.ListDBsRequest) (*pb.ListDBsReply, error) {
+// ListDBs lists a page of the metadata of the dbs, along with their info
+// unless the request skips it.
+func (s *Service) ListDBs(ctx context.Context, req *pb.ListDBsRequest) (*pb.ListDBsReply, error) {
 	log.Debug("received list dbs request")
 	token, err := thread.NewTokenFromMD(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	dbs, err := s.manager.ListDBs(ctx, db.WithManagedToken(token))
-	if err != nil {
+	res, err := s.manager.ListDBsPage(ctx, db.ListDBsQuery{
+		NamePrefix: req.NamePrefix,
+		Cursor:     req.Cursor,
+		Limit:      int(req.Limit),
+	}, db.WithManagedToken(token))
+	if errors.Is(err, db.ErrInvalidCursor) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if err != nil {
 		return nil, err
 	}
-	pbdbs := make([]*pb.ListDBsReply_DB, len(dbs))
-	var i int
-	for id, d := range dbs {
-		info, err := dBInfoToPb(d, token)
+	pbdbs := make([]*pb.ListDBsReply_DB, len(res.DBs))
+	for i, md := range res.DBs {
+		pbdbs[i] = dbMetadataToPb(md)
+		if req.SkipInfo {
+			continue
+		}
+		d, err := s.manager.GetDB(ctx, md.ID, db.WithManagedToken(token))
 		if err != nil {
 			return nil, err
 		}
-		pbdbs[i] = &pb.ListDBsReply_DB{
-			DbID: id.Bytes(),
-			Info: info,
+		if pbdbs[i].Info, err = dBInfoToPb(d, token); err != nil {
+			return nil, err
 		}
-		i++
 	}
-	return &pb.ListDBsReply{Dbs: pbdbs}, nil
+	return &pb.ListDBsReply{Dbs: pbdbs, Cursor: res.Cursor}, nil
+}
+
+func dbMetadataToPb(md db.DBMetadata) *pb.ListDBsReply_DB {
+	var created, lastModified int64
+	if !md.Created.IsZero() {
+		created = md.Created.UnixNano()
+	}
+	if !md.LastModified.IsZero() {
+		lastModified = md.LastModified.UnixNano()
+	}
+	return &pb.ListDBsReply_DB{
+		DbID:         md.ID.Bytes(),
+		Name:         md.Name,
+		Collections:  md.Collections,
+		Created:      created,
+		LastModified: lastModified,
+	}
 }
 
 func (s *Service) GetDBInfo(ctx context.Context, req *pb.GetDBInfoRequest) (*pb.GetDBInfoReply, error) {
@@ -406,12 +490,48 @@ func (s *Service) UpdateCollection(ctx context.Context, req *pb.UpdateCollection
 	if err != nil {
 		return nil, err
 	}
-	if _, err = d.UpdateCollection(cc); err != nil {
+	if _, err = d.UpdateCollection(cc, db.WithSkipValidation(req.SkipValidation)); err != nil {
+		if errors.Is(err, db.ErrIncompatibleInstances) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
 		return nil, err
 	}
 	return &pb.UpdateCollectionReply{}, nil
 }
 
+func (s *Service) CheckCollectionUpdate(ctx context.Context, req *pb.CheckCollectionUpdateRequest) (*pb.CheckCollectionUpdateReply, error) {
+	log.Debug("received check collection update request")
+	id, err := thread.Cast(req.DbID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d, err := s.getDB(ctx, id, token)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := collectionConfigFromPb(req.Config)
+	if err != nil {
+		return nil, err
+	}
+	report, err := d.CheckCollectionUpdate(cc, db.WithToken(token))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(report.SampleIDs))
+	for i, id := range report.SampleIDs {
+		ids[i] = id.String()
+	}
+	return &pb.CheckCollectionUpdateReply{
+		Incompatible: int64(report.Incompatible),
+		SampleIDs:    ids,
+		Pointers:     report.Pointers,
+	}, nil
+}
+
 func (s *Service) DeleteCollection(ctx context.Context, req *pb.DeleteCollectionRequest) (*pb.DeleteCollectionReply, error) {
 	log.Debug("received delete collection request")
 	id, err := thread.Cast(req.DbID)
@@ -432,6 +552,26 @@ func (s *Service) DeleteCollection(ctx context.Context, req *pb.DeleteCollection
 	return &pb.DeleteCollectionReply{}, nil
 }
 
+func (s *Service) RenameCollection(ctx context.Context, req *pb.RenameCollectionRequest) (*pb.RenameCollectionReply, error) {
+	log.Debug("received rename collection request")
+	id, err := thread.Cast(req.DbID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d, err := s.getDB(ctx, id, token)
+	if err != nil {
+		return nil, err
+	}
+	if err = d.RenameCollection(req.Name, req.NewName, db.WithToken(token)); err != nil {
+		return nil, err
+	}
+	return &pb.RenameCollectionReply{}, nil
+}
+
 func (s *Service) GetCollectionInfo(ctx context.Context, req *pb.GetCollectionInfoRequest) (*pb.GetCollectionInfoReply, error) {
 	log.Debug("received get collection info request")
 	id, err := thread.Cast(req.DbID)
@@ -452,6 +592,9 @@ func (s *Service) GetCollectionInfo(ctx context.Context, req *pb.GetCollectionIn
 		Indexes:        indexesToPb(collection.GetIndexes()),
 		WriteValidator: string(collection.GetWriteValidator()),
 		ReadFilter:     string(collection.GetReadFilter()),
+		SoftDelete:     collection.GetSoftDelete(),
+		ExpiryField:    collection.GetExpiryField(),
+		ApplyDefaults:  collection.GetApplyDefaults(),
 	}, nil
 }
 
@@ -459,8 +602,10 @@ func indexesToPb(indexes []db.Index) []*pb.Index {
 	pbindexes := make([]*pb.Index, len(indexes))
 	for i, index := range indexes {
 		pbindexes[i] = &pb.Index{
-			Path:   index.Path,
-			Unique: index.Unique,
+			Path:     index.Path,
+			Unique:   index.Unique,
+			FullText: index.FullText,
+			Fields:   index.Fields,
 		}
 	}
 	return pbindexes
@@ -508,11 +653,102 @@ func (s *Service) ListCollections(ctx context.Context, req *pb.ListCollectionsRe
 			Indexes:        indexesToPb(c.GetIndexes()),
 			WriteValidator: string(c.GetWriteValidator()),
 			ReadFilter:     string(c.GetReadFilter()),
+			SoftDelete:     c.GetSoftDelete(),
+			ExpiryField:    c.GetExpiryField(),
+			ApplyDefaults:  c.GetApplyDefaults(),
 		}
 	}
 	return &pb.ListCollectionsReply{Collections: pblist}, nil
 }
 
+func (s *Service) GetStats(ctx context.Context, req *pb.GetStatsRequest) (*pb.GetStatsReply, error) {
+	log.Debug("received get stats request")
+	id, err := thread.Cast(req.DbID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d, err := s.getDB(ctx, id, token)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := d.Stats(db.WithToken(token))
+	if err != nil {
+		return nil, err
+	}
+	collections := make([]*pb.CollectionStats, len(stats.Collections))
+	for i, cs := range stats.Collections {
+		collections[i] = collectionStatsToPb(cs)
+	}
+	return &pb.GetStatsReply{
+		Collections:   collections,
+		Instances:     stats.Instances,
+		InstanceBytes: stats.InstanceBytes,
+		IndexEntries:  stats.IndexEntries,
+		IndexBytes:    stats.IndexBytes,
+		Dispatcher:    &pb.LogStats{Entries: stats.Dispatcher.Entries, Bytes: stats.Dispatcher.Bytes},
+		ActionLog:     &pb.LogStats{Entries: stats.ActionLog.Entries, Bytes: stats.ActionLog.Bytes},
+	}, nil
+}
+
+func (s *Service) GetCollectionStats(ctx context.Context, req *pb.GetCollectionStatsRequest) (*pb.GetCollectionStatsReply, error) {
+	log.Debug("received get collection stats request")
+	id, err := thread.Cast(req.DbID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d, err := s.getDB(ctx, id, token)
+	if err != nil {
+		return nil, err
+	}
+	cs, err := d.CollectionStats(req.Name, db.WithToken(token))
+	if errors.Is(err, db.ErrCollectionNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, err
+	}
+	return &pb.GetCollectionStatsReply{Stats: collectionStatsToPb(cs)}, nil
+}
+
+// GC collects the garbage of the store of the dbs, and of the stores of the
+// network if it can.
+func (s *Service) GC(ctx context.Context, _ *pb.GCRequest) (*pb.GCReply, error) {
+	log.Debug("received gc request")
+	dbBytes, err := s.manager.GC(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var netBytes uint64
+	if gc, ok := s.manager.Net().(util.GarbageCollector); ok {
+		if netBytes, err = gc.GC(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return &pb.GCReply{DbBytes: dbBytes, NetBytes: netBytes}, nil
+}
+
+func collectionStatsToPb(cs db.CollectionStats) *pb.CollectionStats {
+	var lastWrite int64
+	if !cs.LastWrite.IsZero() {
+		lastWrite = cs.LastWrite.UnixNano()
+	}
+	return &pb.CollectionStats{
+		Name:          cs.Name,
+		Instances:     cs.Instances,
+		InstanceBytes: cs.InstanceBytes,
+		IndexEntries:  cs.IndexEntries,
+		IndexBytes:    cs.IndexBytes,
+		LastWrite:     lastWrite,
+	}
+}
+
 func (s *Service) Create(ctx context.Context, req *pb.CreateRequest) (*pb.CreateReply, error) {
 	log.Debug("received create request")
 	id, err := thread.Cast(req.DbID)
@@ -527,7 +763,8 @@ func (s *Service) Create(ctx context.Context, req *pb.CreateRequest) (*pb.Create
 	if err != nil {
 		return nil, err
 	}
-	return s.processCreateRequest(req, token, collection.CreateMany)
+	reply, err := s.processCreateRequest(req, token, collection.CreateMany)
+	return reply, writeError(err)
 }
 
 func (s *Service) Verify(ctx context.Context, req *pb.VerifyRequest) (*pb.VerifyReply, error) {
@@ -561,7 +798,26 @@ func (s *Service) Save(ctx context.Context, req *pb.SaveRequest) (*pb.SaveReply,
 	if err != nil {
 		return nil, err
 	}
-	return s.processSaveRequest(req, token, collection.SaveMany)
+	reply, err := s.processSaveRequest(req, token, collection.SaveMany)
+	return reply, writeError(err)
+}
+
+func (s *Service) SaveExpecting(ctx context.Context, req *pb.SaveExpectingRequest) (*pb.SaveExpectingReply, error) {
+	log.Debug("received save expecting request")
+	id, err := thread.Cast(req.DbID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	collection, err := s.getCollection(ctx, req.CollectionName, id, token)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := s.processSaveExpectingRequest(req, token, collection.SaveExpecting)
+	return reply, writeError(err)
 }
 
 func (s *Service) Delete(ctx context.Context, req *pb.DeleteRequest) (*pb.DeleteReply, error) {
@@ -612,7 +868,93 @@ func (s *Service) Find(ctx context.Context, req *pb.FindRequest) (*pb.FindReply,
 	if err != nil {
 		return nil, err
 	}
-	return s.processFindRequest(req, token, collection.Find)
+	reply, err := s.processFindRequest(req, token, collection.FindPage)
+	if errors.Is(err, db.ErrInvalidCursor) || errors.Is(err, db.ErrCursorMismatch) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return reply, err
+}
+
+// FindStream streams the results of a query as they're read, which the flow
+// control of the stream paces.
+func (s *Service) FindStream(req *pb.FindRequest, server pb.API_FindStreamServer) error {
+	log.Debug("received find stream request")
+	id, err := thread.Cast(req.DbID)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(server.Context())
+	if err != nil {
+		return err
+	}
+	collection, err := s.getCollection(server.Context(), req.CollectionName, id, token)
+	if err != nil {
+		return err
+	}
+	q := &db.Query{}
+	if err := json.Unmarshal(req.QueryJSON, q); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	it, err := collection.FindIter(server.Context(), q, db.WithTxnToken(token))
+	if errors.Is(err, db.ErrInvalidCursor) || errors.Is(err, db.ErrCursorMismatch) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return err
+	}
+	defer it.Close()
+	for it.Next() {
+		if err := server.Send(&pb.FindStreamReply{Instance: it.Value()}); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+func (s *Service) Count(ctx context.Context, req *pb.CountRequest) (*pb.CountReply, error) {
+	log.Debug("received count request")
+	id, err := thread.Cast(req.DbID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	collection, err := s.getCollection(ctx, req.CollectionName, id, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.processCountRequest(req, token, collection.Count)
+}
+
+func (s *Service) ValidateCollection(ctx context.Context, req *pb.ValidateCollectionRequest) (*pb.ValidateCollectionReply, error) {
+	log.Debug("received validate collection request")
+	id, err := thread.Cast(req.DbID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	collection, err := s.getCollection(ctx, req.CollectionName, id, token)
+	if err != nil {
+		return nil, err
+	}
+	errs, err := collection.Validate(ctx, int(req.Limit), db.WithTxnToken(token))
+	if err != nil {
+		return nil, err
+	}
+	pbErrs := make([]*pb.ValidationError, len(errs))
+	for i, e := range errs {
+		pbErrs[i] = &pb.ValidationError{
+			InstanceID: e.InstanceID.String(),
+			Field:      e.Field,
+			Message:    e.Message,
+			Legacy:     e.Legacy,
+		}
+	}
+	return &pb.ValidateCollectionReply{Errors: pbErrs}, nil
 }
 
 func (s *Service) FindByID(ctx context.Context, req *pb.FindByIDRequest) (*pb.FindByIDReply, error) {
@@ -632,6 +974,23 @@ func (s *Service) FindByID(ctx context.Context, req *pb.FindByIDRequest) (*pb.Fi
 	return s.processFindByIDRequest(req, token, collection.FindByID)
 }
 
+func (s *Service) FindByIDs(ctx context.Context, req *pb.FindByIDsRequest) (*pb.FindByIDsReply, error) {
+	log.Debug("received find by ids request")
+	id, err := thread.Cast(req.DbID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	collection, err := s.getCollection(ctx, req.CollectionName, id, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.processFindByIDsRequest(req, token, collection.FindByIDs)
+}
+
 func (s *Service) ReadTransaction(stream pb.API_ReadTransactionServer) error {
 	log.Debug("received read txn request")
 	firstReq, err := stream.Recv()
@@ -698,9 +1057,20 @@ func (s *Service) ReadTransaction(stream pb.API_ReadTransactionServer) error {
 				if err := stream.Send(&pb.ReadTransactionReply{Option: option}); err != nil {
 					return err
 				}
+			case *pb.ReadTransactionRequest_FindByIDsRequest:
+				innerReply, err := s.processFindByIDsRequest(x.FindByIDsRequest, token, func(ids []core.InstanceID, _ ...db.TxnOption) ([][]byte, error) {
+					return txn.FindByIDs(ids)
+				})
+				if err != nil {
+					innerReply.TransactionError = err.Error()
+				}
+				option := &pb.ReadTransactionReply_FindByIDsReply{FindByIDsReply: innerReply}
+				if err := stream.Send(&pb.ReadTransactionReply{Option: option}); err != nil {
+					return err
+				}
 			case *pb.ReadTransactionRequest_FindRequest:
-				innerReply, err := s.processFindRequest(x.FindRequest, token, func(q *db.Query, _ ...db.TxnOption) (ret [][]byte, err error) {
-					return txn.Find(q)
+				innerReply, err := s.processFindRequest(x.FindRequest, token, func(q *db.Query, _ ...db.TxnOption) (*db.FindResult, error) {
+					return txn.FindPage(q)
 				})
 				if err != nil {
 					innerReply.TransactionError = err.Error()
@@ -709,6 +1079,17 @@ func (s *Service) ReadTransaction(stream pb.API_ReadTransactionServer) error {
 				if err := stream.Send(&pb.ReadTransactionReply{Option: option}); err != nil {
 					return err
 				}
+			case *pb.ReadTransactionRequest_CountRequest:
+				innerReply, err := s.processCountRequest(x.CountRequest, token, func(q *db.Query, _ ...db.TxnOption) (int, error) {
+					return txn.Count(q)
+				})
+				if err != nil {
+					innerReply.TransactionError = err.Error()
+				}
+				option := &pb.ReadTransactionReply_CountReply{CountReply: innerReply}
+				if err := stream.Send(&pb.ReadTransactionReply{Option: option}); err != nil {
+					return err
+				}
 			case nil:
 				return fmt.Errorf("no ReadTransactionRequest type set")
 			default:
@@ -744,12 +1125,17 @@ func (s *Service) WriteTransaction(stream pb.API_WriteTransactionServer) error {
 	if err != nil {
 		return err
 	}
-	collection, err := s.getCollection(stream.Context(), collectionName, id, token)
+	d, err := s.getDB(stream.Context(), id, token)
 	if err != nil {
 		return err
 	}
+	if collectionName != "" && d.GetCollection(collectionName) == nil {
+		return status.Error(codes.NotFound, db.ErrCollectionNotFound.Error())
+	}
 
-	return collection.WriteTxn(func(txn *db.Txn) error {
+	// requests write to the collection they name, or to the one the
+	// transaction is started with, all of which are committed together
+	err = d.WriteTxn(stream.Context(), func(mtxn *db.MultiTxn) error {
 		for {
 			req, err := stream.Recv()
 			if err == io.EOF {
@@ -758,8 +1144,15 @@ func (s *Service) WriteTransaction(stream pb.API_WriteTransactionServer) error {
 			if err != nil {
 				return err
 			}
-			if err := txn.RefreshCollection(); err != nil {
-				return err
+			name := requestCollectionName(req)
+			if name == "" {
+				name = collectionName
+			}
+			var txn *db.Txn
+			if _, ok := req.Option.(*pb.WriteTransactionRequest_DiscardRequest); !ok {
+				if txn, err = mtxn.Collection(name); err != nil {
+					return status.Error(codes.NotFound, err.Error())
+				}
 			}
 			switch x := req.Option.(type) {
 			case *pb.WriteTransactionRequest_HasRequest:
@@ -784,9 +1177,20 @@ func (s *Service) WriteTransaction(stream pb.API_WriteTransactionServer) error {
 				if err := stream.Send(&pb.WriteTransactionReply{Option: option}); err != nil {
 					return err
 				}
+			case *pb.WriteTransactionRequest_FindByIDsRequest:
+				innerReply, err := s.processFindByIDsRequest(x.FindByIDsRequest, token, func(ids []core.InstanceID, _ ...db.TxnOption) ([][]byte, error) {
+					return txn.FindByIDs(ids)
+				})
+				if err != nil {
+					innerReply.TransactionError = err.Error()
+				}
+				option := &pb.WriteTransactionReply_FindByIDsReply{FindByIDsReply: innerReply}
+				if err := stream.Send(&pb.WriteTransactionReply{Option: option}); err != nil {
+					return err
+				}
 			case *pb.WriteTransactionRequest_FindRequest:
-				innerReply, err := s.processFindRequest(x.FindRequest, token, func(q *db.Query, _ ...db.TxnOption) (ret [][]byte, err error) {
-					return txn.Find(q)
+				innerReply, err := s.processFindRequest(x.FindRequest, token, func(q *db.Query, _ ...db.TxnOption) (*db.FindResult, error) {
+					return txn.FindPage(q)
 				})
 				if err != nil {
 					innerReply.TransactionError = err.Error()
@@ -828,6 +1232,17 @@ func (s *Service) WriteTransaction(stream pb.API_WriteTransactionServer) error {
 				if err := stream.Send(&pb.WriteTransactionReply{Option: option}); err != nil {
 					return err
 				}
+			case *pb.WriteTransactionRequest_SaveExpectingRequest:
+				innerReply, err := s.processSaveExpectingRequest(x.SaveExpectingRequest, token, func(instance []byte, version int64, _ ...db.TxnOption) error {
+					return txn.SaveExpecting(instance, version)
+				})
+				if err != nil {
+					innerReply.TransactionError = err.Error()
+				}
+				option := &pb.WriteTransactionReply_SaveExpectingReply{SaveExpectingReply: innerReply}
+				if err := stream.Send(&pb.WriteTransactionReply{Option: option}); err != nil {
+					return err
+				}
 			case *pb.WriteTransactionRequest_DeleteRequest:
 				innerReply, err := s.processDeleteRequest(x.DeleteRequest, token, func(ids []core.InstanceID, _ ...db.TxnOption) error {
 					return txn.Delete(ids...)
@@ -840,7 +1255,7 @@ func (s *Service) WriteTransaction(stream pb.API_WriteTransactionServer) error {
 					return err
 				}
 			case *pb.WriteTransactionRequest_DiscardRequest:
-				txn.Discard()
+				mtxn.Discard()
 				option := &pb.WriteTransactionReply_DiscardReply{DiscardReply: &pb.DiscardReply{}}
 				if err := stream.Send(&pb.WriteTransactionReply{Option: option}); err != nil {
 					return err
@@ -852,6 +1267,34 @@ func (s *Service) WriteTransaction(stream pb.API_WriteTransactionServer) error {
 			}
 		}
 	}, db.WithTxnToken(token))
+	return writeError(err)
+}
+
+// requestCollectionName returns the name of the collection a request of a
+// write transaction names, if any.
+func requestCollectionName(req *pb.WriteTransactionRequest) string {
+	switch x := req.Option.(type) {
+	case *pb.WriteTransactionRequest_HasRequest:
+		return x.HasRequest.CollectionName
+	case *pb.WriteTransactionRequest_FindByIDRequest:
+		return x.FindByIDRequest.CollectionName
+	case *pb.WriteTransactionRequest_FindByIDsRequest:
+		return x.FindByIDsRequest.CollectionName
+	case *pb.WriteTransactionRequest_FindRequest:
+		return x.FindRequest.CollectionName
+	case *pb.WriteTransactionRequest_CreateRequest:
+		return x.CreateRequest.CollectionName
+	case *pb.WriteTransactionRequest_VerifyRequest:
+		return x.VerifyRequest.CollectionName
+	case *pb.WriteTransactionRequest_SaveRequest:
+		return x.SaveRequest.CollectionName
+	case *pb.WriteTransactionRequest_SaveExpectingRequest:
+		return x.SaveExpectingRequest.CollectionName
+	case *pb.WriteTransactionRequest_DeleteRequest:
+		return x.DeleteRequest.CollectionName
+	default:
+		return ""
+	}
 }
 
 func (s *Service) Listen(req *pb.ListenRequest, server pb.API_ListenServer) error {
@@ -889,11 +1332,18 @@ func (s *Service) Listen(req *pb.ListenRequest, server pb.API_ListenServer) erro
 			Collection: filter.CollectionName,
 			ID:         core.InstanceID(filter.InstanceID),
 		}
+		if len(filter.QueryJSON) > 0 {
+			q := &db.Query{}
+			if err := json.Unmarshal(filter.QueryJSON, q); err != nil {
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+			options[i].Query = q
+		}
 	}
 
 	l, err := d.Listen(options...)
 	if err != nil {
-		return err
+		return status.Error(codes.InvalidArgument, err.Error())
 	}
 	defer l.Close()
 
@@ -917,6 +1367,20 @@ func (s *Service) Listen(req *pb.ListenRequest, server pb.API_ListenServer) erro
 			case db.ActionSave:
 				replyAction = pb.ListenReply_SAVE
 				instance, err = s.instanceForAction(d, action, token)
+			case db.ActionMigrate:
+				// migrated instances are also sent as saves
+				continue
+			case db.ActionRename:
+				// listeners of the collection aren't sent its actions under
+				// its new name, so they must listen again
+				for _, o := range options {
+					if o.Collection == action.Collection {
+						return status.Errorf(codes.Aborted, "collection %s was renamed to %s", action.Collection, action.NewName)
+					}
+				}
+				continue
+			case db.ActionClose:
+				return status.Error(codes.Unavailable, db.ErrDBClosed.Error())
 			default:
 				err = status.Errorf(codes.Internal, "unknown action type %v", action.Type)
 			}
@@ -951,6 +1415,16 @@ func (s *Service) instanceForAction(d *db.DB, action db.Action, token thread.Tok
 	return res, nil
 }
 
+// writeError returns the status of a write rejected by a unique index, or
+// the error otherwise.
+func writeError(err error) error {
+	var violation *db.ErrUniqueConstraintViolation
+	if errors.As(err, &violation) {
+		return status.Error(codes.AlreadyExists, violation.Error())
+	}
+	return err
+}
+
 func (s *Service) processCreateRequest(req *pb.CreateRequest, token thread.Token, createFunc func([][]byte, ...db.TxnOption) ([]core.InstanceID, error)) (*pb.CreateReply, error) {
 	log.Debug("handling create request")
 	res, err := createFunc(req.Instances, db.WithTxnToken(token))
@@ -976,6 +1450,23 @@ func (s *Service) processSaveRequest(req *pb.SaveRequest, token thread.Token, sa
 	return &pb.SaveReply{}, err
 }
 
+// processSaveExpectingRequest replies to the save of a stale instance with
+// the stored instance, rather than failing.
+func (s *Service) processSaveExpectingRequest(req *pb.SaveExpectingRequest, token thread.Token, saveFunc func([]byte, int64, ...db.TxnOption) error) (*pb.SaveExpectingReply, error) {
+	log.Debug("handling save expecting request")
+	err := saveFunc(req.Instance, req.Version, db.WithTxnToken(token))
+	var stale *db.StaleInstanceError
+	if errors.As(err, &stale) {
+		return &pb.SaveExpectingReply{
+			Stale:      true,
+			InstanceID: stale.InstanceID.String(),
+			Version:    stale.Version,
+			Current:    stale.Current,
+		}, nil
+	}
+	return &pb.SaveExpectingReply{}, err
+}
+
 func (s *Service) processDeleteRequest(req *pb.DeleteRequest, token thread.Token, deleteFunc func([]core.InstanceID, ...db.TxnOption) error) (*pb.DeleteReply, error) {
 	log.Debug("handling delete request")
 	instanceIDs := make([]core.InstanceID, len(req.InstanceIDs))
@@ -1003,14 +1494,58 @@ func (s *Service) processFindByIDRequest(req *pb.FindByIDRequest, token thread.T
 	return &pb.FindByIDReply{Instance: found}, err
 }
 
-func (s *Service) processFindRequest(req *pb.FindRequest, token thread.Token, findFunc func(q *db.Query, opts ...db.TxnOption) (ret [][]byte, err error)) (*pb.FindReply, error) {
+func (s *Service) processFindByIDsRequest(req *pb.FindByIDsRequest, token thread.Token, findFunc func(ids []core.InstanceID, opts ...db.TxnOption) ([][]byte, error)) (*pb.FindByIDsReply, error) {
+	log.Debug("handling find by ids request")
+	instanceIDs := make([]core.InstanceID, len(req.InstanceIDs))
+	for i, id := range req.InstanceIDs {
+		instanceIDs[i] = core.InstanceID(id)
+	}
+	instances, err := findFunc(instanceIDs, db.WithTxnToken(token))
+	if err != nil {
+		return &pb.FindByIDsReply{}, err
+	}
+	found := make([]bool, len(instances))
+	for i, instance := range instances {
+		found[i] = instance != nil
+	}
+	return &pb.FindByIDsReply{Instances: instances, Found: found}, nil
+}
+
+func (s *Service) processFindRequest(req *pb.FindRequest, token thread.Token, findFunc func(q *db.Query, opts ...db.TxnOption) (*db.FindResult, error)) (*pb.FindReply, error) {
 	log.Debug("handling find request")
 	q := &db.Query{}
 	if err := json.Unmarshal(req.QueryJSON, q); err != nil {
 		return &pb.FindReply{}, err
 	}
-	instances, err := findFunc(q, db.WithTxnToken(token))
-	return &pb.FindReply{Instances: instances}, err
+	res, err := findFunc(q, db.WithTxnToken(token))
+	if err != nil {
+		return &pb.FindReply{}, err
+	}
+	reply := &pb.FindReply{Instances: res.Instances, Cursor: res.Cursor, Total: int64(res.Total)}
+	if e := res.Explain; e != nil {
+		reply.Explain = &pb.ExplainResult{
+			Index:          e.Index,
+			IndexKeys:      int64(e.IndexKeys),
+			InstancesRead:  int64(e.InstancesRead),
+			Matched:        int64(e.Matched),
+			SortedInMemory: e.SortedInMemory,
+			PlanNanos:      e.PlanTime.Nanoseconds(),
+			ReadNanos:      e.ReadTime.Nanoseconds(),
+			SortNanos:      e.SortTime.Nanoseconds(),
+			Indexes:        e.Indexes,
+		}
+	}
+	return reply, nil
+}
+
+func (s *Service) processCountRequest(req *pb.CountRequest, token thread.Token, countFunc func(q *db.Query, opts ...db.TxnOption) (int, error)) (*pb.CountReply, error) {
+	log.Debug("handling count request")
+	q := &db.Query{}
+	if err := json.Unmarshal(req.QueryJSON, q); err != nil {
+		return &pb.CountReply{}, err
+	}
+	count, err := countFunc(q, db.WithTxnToken(token))
+	return &pb.CountReply{Count: int64(count)}, err
 }
 
 func (s *Service) getDB(ctx context.Context, id thread.ID, token thread.Token) (*db.DB, error) {
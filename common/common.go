@@ -12,29 +12,50 @@ import (
 
 	ipfslite "github.com/hsanjuan/ipfs-lite"
 	ds "github.com/ipfs/go-datastore"
+	logging "github.com/ipfs/go-log/v2"
 	"github.com/libp2p/go-libp2p"
 	connmgr "github.com/libp2p/go-libp2p-connmgr"
 	cconnmgr "github.com/libp2p/go-libp2p-core/connmgr"
 	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/metrics"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-peerstore/pstoreds"
 	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prometheus/client_golang/prometheus"
 	badger "github.com/textileio/go-ds-badger"
 	mongods "github.com/textileio/go-ds-mongo"
 	"github.com/textileio/go-libp2p-pubsub-rpc/finalizer"
 	"github.com/textileio/go-threads/core/app"
 	core "github.com/textileio/go-threads/core/logstore"
+	cnet "github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/logstore/lstoreds"
 	"github.com/textileio/go-threads/logstore/lstorehybrid"
 	"github.com/textileio/go-threads/logstore/lstoremem"
 	"github.com/textileio/go-threads/net"
+	"github.com/textileio/go-threads/util"
 	"google.golang.org/grpc"
 )
 
+var log = logging.Logger("common")
+
+// ErrQUICUnsupported indicates the QUIC transport isn't supported by the Go
+// version the network is built with, which is the case from Go 1.18 onwards
+// with the libp2p version in use.
+var ErrQUICUnsupported = errors.New("quic transport is not supported by this go version")
+
 type NetBoostrapper interface {
 	app.Net
+	net.RateLimiter
+	net.DialBackoffReporter
+	net.DeletionNotifier
+	net.OutboxReporter
+	net.RecordInterceptorRegistry
+	net.PeerSyncReporter
 	GetIpfsLite() *ipfslite.Peer
 	Bootstrap(addrs []peer.AddrInfo)
+	// GC collects the garbage of the persistent stores of the network,
+	// returning the bytes reclaimed.
+	GC(ctx context.Context) (uint64, error)
 }
 
 // DefaultNetwork is a boostrapable default Net with sane defaults.
@@ -76,11 +97,33 @@ func DefaultNetwork(opts ...NetOption) (NetBoostrapper, error) {
 	libp2pOptions := []libp2p.Option{
 		libp2p.Peerstore(pstore),
 		libp2p.ConnectionManager(config.ConnManager),
-		libp2p.DisableRelay(),
 	}
-	if config.AnnounceAddr != nil {
+	if config.Relay {
+		libp2pOptions = append(libp2pOptions, libp2p.EnableRelay())
+		if len(config.StaticRelays) != 0 {
+			// The static relays are used, and their relayed addresses
+			// announced, once the host finds out it isn't reachable.
+			libp2pOptions = append(libp2pOptions, libp2p.EnableAutoRelay(), libp2p.StaticRelays(config.StaticRelays))
+		}
+	} else {
+		libp2pOptions = append(libp2pOptions, libp2p.DisableRelay())
+	}
+	if config.AutoNAT {
+		libp2pOptions = append(libp2pOptions, libp2p.EnableNATService(), libp2p.NATPortMap())
+	}
+	if config.BandwidthReporter != nil {
+		libp2pOptions = append(libp2pOptions, libp2p.BandwidthReporter(config.BandwidthReporter))
+	}
+	transports, err := hostTransports(config)
+	if err != nil {
+		return nil, fin.Cleanup(err)
+	}
+	libp2pOptions = append(libp2pOptions, transports...)
+	if len(config.AnnounceAddrs) != 0 {
+		// The announced addresses are those reported by the host, so they
+		// end up in the addresses of the threads and their invites.
 		libp2pOptions = append(libp2pOptions, libp2p.AddrsFactory(func([]ma.Multiaddr) []ma.Multiaddr {
-			return []ma.Multiaddr{config.AnnounceAddr}
+			return config.AnnounceAddrs
 		}))
 	}
 
@@ -88,7 +131,7 @@ func DefaultNetwork(opts ...NetOption) (NetBoostrapper, error) {
 		ctx,
 		hostKey,
 		nil,
-		[]ma.Multiaddr{config.HostAddr},
+		config.ListenAddrs,
 		litestore,
 		libp2pOptions...,
 	)
@@ -101,7 +144,7 @@ func DefaultNetwork(opts ...NetOption) (NetBoostrapper, error) {
 		return nil, fin.Cleanup(err)
 	}
 
-	tstore, err := buildLogstore(ctx, config, fin)
+	tstore, lstore, err := buildLogstore(ctx, config, fin)
 	if err != nil {
 		return nil, fin.Cleanup(err)
 	}
@@ -112,9 +155,24 @@ func DefaultNetwork(opts ...NetOption) (NetBoostrapper, error) {
 		NetPullingStartAfter:      config.NetPullingStartAfter,
 		NetPullingInitialInterval: config.NetPullingInitialInterval,
 		NetPullingInterval:        config.NetPullingInterval,
+		NetPullingMaxInterval:     config.NetPullingMaxInterval,
 		NoNetPulling:              config.NoNetPulling,
 		NoExchangeEdgesMigration:  config.NoExchangeEdgesMigration,
 		PubSub:                    config.PubSub,
+		PubSubStrategy:            config.PubSubStrategy,
+		PubSubShards:              config.PubSubShards,
+		RateLimits:                config.RateLimits,
+		DialBackoff:               config.DialBackoff,
+		DeleteAnnouncedThreads:    config.DeleteAnnouncedThreads,
+		MaxProtectedPeers:         config.MaxProtectedPeers,
+		MaxOutboxSize:             config.MaxOutboxSize,
+		OutboxOverflow:            config.OutboxOverflow,
+		HeadGossipInterval:        config.HeadGossipInterval,
+		MaxPeerStats:              config.MaxPeerStats,
+		PersistPeerStats:          config.PersistPeerStats,
+		MaxRecordSize:             config.MaxRecordSize,
+		ConfirmedAddrTTL:          config.ConfirmedAddrTTL,
+		Metrics:                   config.Metrics,
 		Debug:                     config.Debug,
 	}, config.GRPCServerOptions, config.GRPCDialOptions)
 	if err != nil {
@@ -122,44 +180,64 @@ func DefaultNetwork(opts ...NetOption) (NetBoostrapper, error) {
 	}
 	fin.Add(h, d, api)
 
-	return &netBoostrapper{
-		Net:       api,
-		litepeer:  lite,
-		finalizer: fin,
-	}, nil
+	nb := &netBoostrapper{
+		Net:                       api,
+		RateLimiter:               api.(net.RateLimiter),
+		DialBackoffReporter:       api.(net.DialBackoffReporter),
+		DeletionNotifier:          api.(net.DeletionNotifier),
+		OutboxReporter:            api.(net.OutboxReporter),
+		RecordInterceptorRegistry: api.(net.RecordInterceptorRegistry),
+		PeerSyncReporter:          api.(net.PeerSyncReporter),
+		litepeer:                  lite,
+		finalizer:                 fin,
+		stores:                    []ds.Datastore{litestore},
+	}
+	if lstore != nil {
+		nb.stores = append(nb.stores, lstore)
+	}
+	if config.GCInterval > 0 {
+		go nb.gcLoop(ctx, config.GCInterval)
+	}
+	return nb, nil
 }
 
-func buildLogstore(ctx context.Context, config NetConfig, fin *finalizer.Finalizer) (core.Logstore, error) {
+// buildLogstore returns the logstore of the network, along with its store if
+// it's persistent.
+func buildLogstore(ctx context.Context, config NetConfig, fin *finalizer.Finalizer) (core.Logstore, ds.Datastore, error) {
 	switch config.LSType {
 	case LogstoreInMemory:
-		return lstoremem.NewLogstore(), nil
+		return lstoremem.NewLogstore(), nil, nil
 
 	case LogstoreHybrid:
-		pls, err := persistentLogstore(ctx, config, fin)
+		pls, pds, err := persistentLogstore(ctx, config, fin)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		mls := lstoremem.NewLogstore()
-		return lstorehybrid.NewLogstore(pls, mls)
+		ls, err := lstorehybrid.NewLogstore(pls, mls)
+		return ls, pds, err
 
 	case LogstorePersistent:
 		return persistentLogstore(ctx, config, fin)
 
 	default:
-		return nil, fmt.Errorf("unsupported logstore type: %s", config.LSType)
+		return nil, nil, fmt.Errorf("unsupported logstore type: %s", config.LSType)
 	}
 }
 
-func persistentLogstore(ctx context.Context, config NetConfig, fin *finalizer.Finalizer) (core.Logstore, error) {
+func persistentLogstore(ctx context.Context, config NetConfig, fin *finalizer.Finalizer) (core.Logstore, ds.Datastore, error) {
 	pds, err := persistentStore(ctx, config, "logstore", fin)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return lstoreds.NewLogstore(ctx, pds, lstoreds.DefaultOpts())
+	ls, err := lstoreds.NewLogstore(ctx, pds, lstoreds.DefaultOpts())
+	return ls, pds, err
 }
 
 func persistentStore(ctx context.Context, config NetConfig, name string, fin *finalizer.Finalizer) (ds.Batching, error) {
-	if len(config.MongoUri) != 0 {
+	if config.RepoDatastore != nil {
+		return repoStore(config.RepoDatastore, name, fin)
+	} else if len(config.MongoUri) != 0 {
 		return mongoStore(ctx, config.MongoUri, config.MongoDB, name, fin)
 	} else {
 		return badgerStore(filepath.Join(config.BadgerRepoPath, name), fin)
@@ -167,11 +245,17 @@ func persistentStore(ctx context.Context, config NetConfig, name string, fin *fi
 }
 
 func badgerStore(repoPath string, fin *finalizer.Finalizer) (ds.Batching, error) {
-	if err := os.MkdirAll(repoPath, os.ModePerm); err != nil {
+	dstore, err := util.OpenBadgerDatastore(repoPath, &badger.DefaultOptions)
+	if err != nil {
 		return nil, err
 	}
+	fin.Add(dstore)
 
-	dstore, err := badger.NewDatastore(repoPath, &badger.DefaultOptions)
+	return dstore, nil
+}
+
+func repoStore(newStore func(name string) (ds.Batching, error), name string, fin *finalizer.Finalizer) (ds.Batching, error) {
+	dstore, err := newStore(name)
 	if err != nil {
 		return nil, err
 	}
@@ -191,7 +275,7 @@ func mongoStore(ctx context.Context, uri, db, collection string, fin *finalizer.
 }
 
 func getIPFSHostKey(config NetConfig, store ds.Datastore) (crypto.PrivKey, error) {
-	if len(config.MongoUri) != 0 {
+	if config.RepoDatastore != nil || len(config.MongoUri) != 0 {
 		k := ds.NewKey("key")
 		bytes, err := store.Get(k)
 		if errors.Is(err, ds.ErrNotFound) {
@@ -236,6 +320,26 @@ func getIPFSHostKey(config NetConfig, store ds.Datastore) (crypto.PrivKey, error
 	}
 }
 
+// hostTransports returns the options enabling the transports of the host,
+// which are the default ones unless TCP is disabled or QUIC is enabled.
+func hostTransports(config NetConfig) ([]libp2p.Option, error) {
+	if !config.NoTCP && !config.QUIC {
+		return nil, nil
+	}
+	var opts []libp2p.Option
+	if !config.NoTCP {
+		opts = append(opts, libp2p.DefaultTransports)
+	}
+	if config.QUIC {
+		opt, err := quicTransport()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, opt)
+	}
+	return opts, nil
+}
+
 func newIPFSHostKey() (crypto.PrivKey, []byte, error) {
 	priv, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
 	if err != nil {
@@ -267,12 +371,28 @@ func setDefaults(config *NetConfig) error {
 	if config.NetPullingInterval <= 0 {
 		config.NetPullingInterval = time.Second * 10
 	}
-	if config.HostAddr == nil {
-		addr, err := ma.NewMultiaddr("/ip4/0.0.0.0/tcp/0")
-		if err != nil {
-			return err
+	if config.NetPullingMaxInterval <= 0 {
+		config.NetPullingMaxInterval = time.Minute * 5
+	}
+	if config.DialBackoff.Initial <= 0 {
+		config.DialBackoff = net.DefaultDialBackoff
+	}
+	if config.NoTCP && !config.QUIC {
+		return errors.New("no transport is enabled")
+	}
+	if config.HostAddr != nil {
+		config.ListenAddrs = append(config.ListenAddrs, config.HostAddr)
+	}
+	if len(config.ListenAddrs) == 0 {
+		if !config.NoTCP {
+			config.ListenAddrs = append(config.ListenAddrs, ma.StringCast("/ip4/0.0.0.0/tcp/0"))
+		}
+		if config.QUIC {
+			config.ListenAddrs = append(config.ListenAddrs, ma.StringCast("/ip4/0.0.0.0/udp/0/quic"))
 		}
-		config.HostAddr = addr
+	}
+	if config.AnnounceAddr != nil {
+		config.AnnounceAddrs = append(config.AnnounceAddrs, config.AnnounceAddr)
 	}
 	if config.ConnManager == nil {
 		config.ConnManager = connmgr.NewConnManager(100, 400, time.Second*20)
@@ -293,18 +413,43 @@ type NetConfig struct {
 	NetPullingStartAfter      time.Duration
 	NetPullingInitialInterval time.Duration
 	NetPullingInterval        time.Duration
+	NetPullingMaxInterval     time.Duration
 	NoNetPulling              bool
 	NoExchangeEdgesMigration  bool
 	PubSub                    bool
+	PubSubStrategy            cnet.PubSubStrategy
+	PubSubShards              int
+	RateLimits                net.RateLimits
+	DialBackoff               net.DialBackoff
+	DeleteAnnouncedThreads    bool
+	MaxProtectedPeers         int
+	MaxOutboxSize             int
+	OutboxOverflow            net.OutboxOverflow
+	HeadGossipInterval        time.Duration
+	MaxPeerStats              int
+	PersistPeerStats          bool
+	MaxRecordSize             int
+	ConfirmedAddrTTL          time.Duration
+	Metrics                   prometheus.Registerer
 	LSType                    LogstoreType
 	BadgerRepoPath            string
+	RepoDatastore             func(name string) (ds.Batching, error)
 	MongoUri                  string
 	MongoDB                   string
 	HostAddr                  ma.Multiaddr
+	ListenAddrs               []ma.Multiaddr
 	AnnounceAddr              ma.Multiaddr
+	AnnounceAddrs             []ma.Multiaddr
+	NoTCP                     bool
+	QUIC                      bool
+	Relay                     bool
+	StaticRelays              []peer.AddrInfo
+	AutoNAT                   bool
 	ConnManager               cconnmgr.ConnManager
+	BandwidthReporter         metrics.Reporter
 	GRPCServerOptions         []grpc.ServerOption
 	GRPCDialOptions           []grpc.DialOption
+	GCInterval                time.Duration
 	Debug                     bool
 }
 
@@ -320,6 +465,15 @@ func WithNetPulling(threadLimit uint, startAfter, initialInterval, interval time
 	}
 }
 
+// WithNetPullingMaxInterval sets the ceiling of the intervals of the threads
+// which pulls keep getting no new records.
+func WithNetPullingMaxInterval(max time.Duration) NetOption {
+	return func(c *NetConfig) error {
+		c.NetPullingMaxInterval = max
+		return nil
+	}
+}
+
 func WithNoNetPulling(disable bool) NetOption {
 	return func(c *NetConfig) error {
 		c.NoNetPulling = disable
@@ -341,6 +495,134 @@ func WithNetPubSub(enabled bool) NetOption {
 	}
 }
 
+// WithNetPubSubStrategy enables pubsub with the strategy of the threads
+// without one of their own, and the number of topics of the sharded
+// strategy, with zero for the default.
+func WithNetPubSubStrategy(strategy cnet.PubSubStrategy, shards int) NetOption {
+	return func(c *NetConfig) error {
+		if !strategy.Valid() {
+			return fmt.Errorf("unknown pubsub strategy %q", strategy)
+		}
+		if shards < 0 {
+			return errors.New("pubsub shards must not be negative")
+		}
+		c.PubSub = true
+		c.PubSubStrategy = strategy
+		c.PubSubShards = shards
+		return nil
+	}
+}
+
+// WithNetRateLimits sets the limits of the inbound requests of each peer,
+// which SetRateLimits adjusts once the network runs.
+func WithNetRateLimits(limits net.RateLimits) NetOption {
+	return func(c *NetConfig) error {
+		if err := limits.Validate(); err != nil {
+			return err
+		}
+		c.RateLimits = limits
+		return nil
+	}
+}
+
+// WithNetDialBackoff sets the backoff of the addresses of peers which failed
+// to be dialed, which defaults to net.DefaultDialBackoff.
+func WithNetDialBackoff(backoff net.DialBackoff) NetOption {
+	return func(c *NetConfig) error {
+		if err := backoff.Validate(); err != nil {
+			return err
+		}
+		c.DialBackoff = backoff
+		return nil
+	}
+}
+
+// WithNetDeleteAnnouncedThreads deletes the threads whose deletion is
+// announced by the owner of one of their logs, which are otherwise only
+// reported by ThreadDeletions.
+func WithNetDeleteAnnouncedThreads(enabled bool) NetOption {
+	return func(c *NetConfig) error {
+		c.DeleteAnnouncedThreads = enabled
+		return nil
+	}
+}
+
+// WithNetMaxProtectedPeers sets the number of the peers of the hosted threads
+// protected from the pruning of the connection manager, which defaults to
+// net.DefaultMaxProtectedPeers. A negative number protects none.
+func WithNetMaxProtectedPeers(max int) NetOption {
+	return func(c *NetConfig) error {
+		c.MaxProtectedPeers = max
+		return nil
+	}
+}
+
+// WithNetOutbox sets the number of the records queued for a peer which is
+// unreachable, which defaults to net.DefaultMaxOutboxSize, and the policy of
+// the records failing to be pushed to a peer for which max records are
+// queued. A negative number doesn't queue records.
+func WithNetOutbox(max int, overflow net.OutboxOverflow) NetOption {
+	return func(c *NetConfig) error {
+		c.MaxOutboxSize = max
+		c.OutboxOverflow = overflow
+		return nil
+	}
+}
+
+// WithNetHeadGossip exchanges the edges of the heads of the threads shared
+// with the connected peers every interval and once they connect, pulling the
+// threads which edges differ. Zero disables it.
+func WithNetHeadGossip(interval time.Duration) NetOption {
+	return func(c *NetConfig) error {
+		if interval < 0 {
+			return errors.New("head gossip interval must not be negative")
+		}
+		c.HeadGossipInterval = interval
+		return nil
+	}
+}
+
+// WithNetPeerStats keeps the sync statistics of up to max peers, the last
+// records were exchanged with, which are persisted in the peerstore if persist
+// is set. Zero keeps net.DefaultMaxPeerStats peers, and a negative max keeps
+// none.
+func WithNetPeerStats(max int, persist bool) NetOption {
+	return func(c *NetConfig) error {
+		c.MaxPeerStats = max
+		c.PersistPeerStats = persist
+		return nil
+	}
+}
+
+// WithNetMaxRecordSize sets the size in bytes of the largest record body
+// created, past which the records received from peers are rejected too.
+// Zero keeps net.DefaultMaxRecordSize.
+func WithNetMaxRecordSize(size int) NetOption {
+	return func(c *NetConfig) error {
+		c.MaxRecordSize = size
+		return nil
+	}
+}
+
+// WithNetConfirmedAddrTTL sets the ttl of the addresses peers are connected
+// at, which are written to the logs which have their addresses and decay once
+// the peers fail to be dialed. Zero keeps net.DefaultConfirmedAddrTTL, and a
+// negative ttl doesn't write them.
+func WithNetConfirmedAddrTTL(ttl time.Duration) NetOption {
+	return func(c *NetConfig) error {
+		c.ConfirmedAddrTTL = ttl
+		return nil
+	}
+}
+
+// WithNetMetrics registers the metrics of the threads protocol with r.
+func WithNetMetrics(r prometheus.Registerer) NetOption {
+	return func(c *NetConfig) error {
+		c.Metrics = r
+		return nil
+	}
+}
+
 func WithNetLogstore(lt LogstoreType) NetOption {
 	return func(c *NetConfig) error {
 		c.LSType = lt
@@ -355,6 +637,18 @@ func WithNetBadgerPersistence(repoPath string) NetOption {
 	}
 }
 
+// WithRepoDatastore persists the network with the datastores returned by
+// newStore for the names of its stores, "ipfslite" and "logstore", instead
+// of badger or mongo ones. The logstore needs a transactional datastore, like
+// one of util/badger3ds, util/badger4ds or util/memds. The stores are closed
+// with the network.
+func WithRepoDatastore(newStore func(name string) (ds.Batching, error)) NetOption {
+	return func(c *NetConfig) error {
+		c.RepoDatastore = newStore
+		return nil
+	}
+}
+
 func WithNetMongoPersistence(uri, db string) NetOption {
 	return func(c *NetConfig) error {
 		c.MongoUri = uri
@@ -363,6 +657,31 @@ func WithNetMongoPersistence(uri, db string) NetOption {
 	}
 }
 
+// WithNetRelay enables the circuit relay transport of the host, which dials
+// the relayed addresses of the peers when they can't be dialed directly. The
+// host listens for relayed connections through the static relays, if any,
+// announcing its relayed addresses once it finds out it isn't reachable.
+// Upgrading relayed connections by hole punching isn't supported by the
+// libp2p version in use.
+func WithNetRelay(staticRelays ...peer.AddrInfo) NetOption {
+	return func(c *NetConfig) error {
+		c.Relay = true
+		c.StaticRelays = staticRelays
+		return nil
+	}
+}
+
+// WithNetAutoNAT makes the host help its peers find out whether they're
+// reachable, and map its ports on the NAT device it's behind, if any.
+func WithNetAutoNAT(enabled bool) NetOption {
+	return func(c *NetConfig) error {
+		c.AutoNAT = enabled
+		return nil
+	}
+}
+
+// WithNetHostAddr sets an address the host listens on along with those set
+// with WithNetListenAddrs.
 func WithNetHostAddr(addr ma.Multiaddr) NetOption {
 	return func(c *NetConfig) error {
 		c.HostAddr = addr
@@ -370,6 +689,28 @@ func WithNetHostAddr(addr ma.Multiaddr) NetOption {
 	}
 }
 
+// WithNetListenAddrs sets the addresses the host listens on, which are on
+// all the interfaces at a random port of each enabled transport by default.
+func WithNetListenAddrs(addrs ...ma.Multiaddr) NetOption {
+	return func(c *NetConfig) error {
+		c.ListenAddrs = addrs
+		return nil
+	}
+}
+
+// WithNetTransports sets the transports of the host, which are TCP (along
+// with websockets) by default.
+func WithNetTransports(tcp, quic bool) NetOption {
+	return func(c *NetConfig) error {
+		if !tcp && !quic {
+			return errors.New("no transport is enabled")
+		}
+		c.NoTCP = !tcp
+		c.QUIC = quic
+		return nil
+	}
+}
+
 func WithConnectionManager(cm cconnmgr.ConnManager) NetOption {
 	return func(c *NetConfig) error {
 		c.ConnManager = cm
@@ -377,6 +718,13 @@ func WithConnectionManager(cm cconnmgr.ConnManager) NetOption {
 	}
 }
 
+func WithNetBandwidthReporter(reporter metrics.Reporter) NetOption {
+	return func(c *NetConfig) error {
+		c.BandwidthReporter = reporter
+		return nil
+	}
+}
+
 func WithNetGRPCServerOptions(opts ...grpc.ServerOption) NetOption {
 	return func(c *NetConfig) error {
 		c.GRPCServerOptions = opts
@@ -391,6 +739,15 @@ func WithNetGRPCDialOptions(opts ...grpc.DialOption) NetOption {
 	}
 }
 
+// WithNetGCInterval collects the garbage of the persistent stores of the
+// network at interval, as GC does. Zero disables it.
+func WithNetGCInterval(interval time.Duration) NetOption {
+	return func(c *NetConfig) error {
+		c.GCInterval = interval
+		return nil
+	}
+}
+
 func WithNetDebug(enabled bool) NetOption {
 	return func(c *NetConfig) error {
 		c.Debug = enabled
@@ -398,6 +755,8 @@ func WithNetDebug(enabled bool) NetOption {
 	}
 }
 
+// WithAnnounceAddr sets an address the host announces along with those set
+// with WithNetAnnounceAddrs.
 func WithAnnounceAddr(addr ma.Multiaddr) NetOption {
 	return func(c *NetConfig) error {
 		c.AnnounceAddr = addr
@@ -405,10 +764,27 @@ func WithAnnounceAddr(addr ma.Multiaddr) NetOption {
 	}
 }
 
+// WithNetAnnounceAddrs sets the addresses the host announces instead of
+// those it listens on, as a host behind a NAT has to. They're the addresses
+// of the threads, from which their invites are made.
+func WithNetAnnounceAddrs(addrs ...ma.Multiaddr) NetOption {
+	return func(c *NetConfig) error {
+		c.AnnounceAddrs = addrs
+		return nil
+	}
+}
+
 type netBoostrapper struct {
 	app.Net
+	net.RateLimiter
+	net.DialBackoffReporter
+	net.DeletionNotifier
+	net.OutboxReporter
+	net.RecordInterceptorRegistry
+	net.PeerSyncReporter
 	litepeer  *ipfslite.Peer
 	finalizer *finalizer.Finalizer
+	stores    []ds.Datastore
 }
 
 var _ NetBoostrapper = (*netBoostrapper)(nil)
@@ -421,6 +797,35 @@ func (tsb *netBoostrapper) GetIpfsLite() *ipfslite.Peer {
 	return tsb.litepeer
 }
 
+func (tsb *netBoostrapper) GC(ctx context.Context) (uint64, error) {
+	var total uint64
+	for _, s := range tsb.stores {
+		n, err := util.CollectGarbage(ctx, s)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// gcLoop collects the garbage of the stores at interval until ctx is done,
+// as when the network is closed.
+func (tsb *netBoostrapper) gcLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := tsb.GC(ctx); err != nil && ctx.Err() == nil {
+				log.Errorf("collecting garbage of the network stores: %v", err)
+			}
+		}
+	}
+}
+
 func (tsb *netBoostrapper) Close() error {
 	return tsb.finalizer.Cleanup(nil)
 }
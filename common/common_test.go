@@ -0,0 +1,127 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peer"
+	swarm "github.com/libp2p/go-libp2p-swarm"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestDefaultNetwork_QUIC(t *testing.T) {
+	quic := []NetOption{
+		WithNetTransports(false, true),
+		WithNetListenAddrs(ma.StringCast("/ip4/127.0.0.1/udp/0/quic")),
+	}
+	n1 := makeNetwork(t, quic...)
+	if n1 == nil {
+		t.Skip(ErrQUICUnsupported)
+	}
+	defer n1.Close()
+	n2 := makeNetwork(t, quic...)
+	defer n2.Close()
+
+	for _, addr := range n1.Host().Addrs() {
+		if _, err := addr.ValueForProtocol(ma.P_TCP); err == nil {
+			t.Fatalf("expected quic address only, got %s", addr)
+		}
+	}
+
+	ctx := context.Background()
+	info, err := n1.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n1.CreateRecord(ctx, info.ID, body); err != nil {
+		t.Fatal(err)
+	}
+
+	// the invite of the thread is dialable over quic
+	info, err = n1.GetThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n2.AddThread(ctx, info.Addrs[0], core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	summary, err := n2.GetThreadSummary(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.RecordCount != 1 {
+		t.Fatalf("expected record to be synced over quic, got %d records", summary.RecordCount)
+	}
+}
+
+func TestDefaultNetwork_AnnounceAddrs(t *testing.T) {
+	announce := ma.StringCast("/dns4/threads.example.com/tcp/4006")
+	n := makeNetwork(t,
+		WithNetListenAddrs(ma.StringCast("/ip4/127.0.0.1/tcp/0")),
+		WithNetAnnounceAddrs(announce))
+	defer n.Close()
+
+	ctx := context.Background()
+	info, err := n.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err = n.GetThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Addrs) != 1 || !strings.HasPrefix(info.Addrs[0].String(), announce.String()+"/p2p/") {
+		t.Fatalf("expected thread addresses to be announced ones, got %v", info.Addrs)
+	}
+}
+
+func TestDefaultNetwork_Relay(t *testing.T) {
+	relay := makeNetwork(t, WithNetListenAddrs(ma.StringCast("/ip4/127.0.0.1/tcp/0")))
+	defer relay.Close()
+	n := makeNetwork(t,
+		WithNetListenAddrs(ma.StringCast("/ip4/127.0.0.1/tcp/0")),
+		WithNetRelay(peer.AddrInfo{ID: relay.Host().ID(), Addrs: relay.Host().Addrs()}),
+		WithNetAutoNAT(true))
+	defer n.Close()
+
+	// relayed addresses of the peers are dialable
+	circuit := ma.StringCast("/p2p-circuit")
+	if n.Host().Network().(*swarm.Swarm).TransportForDialing(relay.Host().Addrs()[0].Encapsulate(circuit)) == nil {
+		t.Fatal("expected relayed addresses to be dialable")
+	}
+}
+
+// makeNetwork returns a network in a temporary repo, or nil if QUIC is
+// enabled and unsupported.
+func makeNetwork(t *testing.T, opts ...NetOption) NetBoostrapper {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	n, err := DefaultNetwork(append([]NetOption{
+		WithNetBadgerPersistence(dir),
+		WithNoNetPulling(true),
+	}, opts...)...)
+	if errors.Is(err, ErrQUICUnsupported) {
+		return nil
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
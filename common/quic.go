@@ -0,0 +1,14 @@
+//go:build !go1.18
+// +build !go1.18
+
+package common
+
+import (
+	"github.com/libp2p/go-libp2p"
+	quic "github.com/libp2p/go-libp2p-quic-transport"
+)
+
+// quicTransport returns the option enabling the QUIC transport of the host.
+func quicTransport() (libp2p.Option, error) {
+	return libp2p.Transport(quic.NewTransport), nil
+}
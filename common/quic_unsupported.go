@@ -0,0 +1,12 @@
+//go:build go1.18
+// +build go1.18
+
+package common
+
+import "github.com/libp2p/go-libp2p"
+
+// quicTransport fails, as the QUIC transport of the libp2p version in use
+// doesn't build from Go 1.18 onwards.
+func quicTransport() (libp2p.Option, error) {
+	return nil, ErrQUICUnsupported
+}
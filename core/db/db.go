@@ -40,6 +40,18 @@ type Event interface {
 	Marshal() ([]byte, error)
 }
 
+// RenameEvent is implemented by the events of codecs which support renaming
+// collections, which dbs apply themselves rather than reducing them.
+type RenameEvent interface {
+	Event
+	// RenamedCollection returns the name the collection of the event is
+	// renamed to, if the event renames it.
+	RenamedCollection() (string, bool)
+	// WithCollection returns the event applied to the collection of the
+	// given name instead, which its collection was renamed to.
+	WithCollection(name string) Event
+}
+
 // ActionType is the type used by actions done in a txn.
 type ActionType int
 
@@ -50,6 +62,9 @@ const (
 	Save
 	// Delete indicates the deletion of an instance by ID in a txn.
 	Delete
+	// Rename indicates the renaming of a collection to the name of Current,
+	// which codecs implementing RenameEvent support.
+	Rename
 )
 
 // Action is a operation done in the collection.
@@ -3,7 +3,9 @@ package net
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-ipld-format"
@@ -44,11 +46,22 @@ type API interface {
 	// GetThread returns thread info by id.
 	GetThread(ctx context.Context, id thread.ID, opts ...ThreadOption) (thread.Info, error)
 
+	// GetThreadSummary returns a summary of a thread by id, which is cheaper to get than its info.
+	GetThreadSummary(ctx context.Context, id thread.ID, opts ...ThreadOption) (ThreadSummary, error)
+
 	// PullThread requests new records from each known thread host.
 	// This method is called internally on an interval as part of the orchestration protocol.
 	// Calling it manually can be useful when new records are known to be available.
+	// The thread is pulled at once, ahead of the pulls scheduled in the background, even if they're paused.
 	PullThread(ctx context.Context, id thread.ID, opts ...ThreadOption) error
 
+	// SetPullInterval sets the interval a thread by id is pulled at in the background, or restores the interval
+	// of the host if it's zero. The interval of threads which pulls repeatedly get no new records backs off.
+	SetPullInterval(ctx context.Context, id thread.ID, interval time.Duration, opts ...ThreadOption) error
+
+	// SetPullPaused pauses or resumes the background pulls of a thread by id.
+	SetPullPaused(ctx context.Context, id thread.ID, paused bool, opts ...ThreadOption) error
+
 	// DeleteThread removes a thread by id and opts.
 	DeleteThread(ctx context.Context, id thread.ID, opts ...ThreadOption) error
 
@@ -56,12 +69,21 @@ type API interface {
 	// All logs and records are pushed to the new host.
 	AddReplicator(ctx context.Context, id thread.ID, paddr ma.Multiaddr, opts ...ThreadOption) (peer.ID, error)
 
+	// RemoveReplicator expires the grant of a thread by id to a replicator at once, and withdraws its addresses
+	// from the logs of the host. Records aren't pushed to it anymore and it's denied getting records, yet it
+	// keeps the service key and the records it got, since this is enforced by policy and doesn't revoke keys.
+	RemoveReplicator(ctx context.Context, id thread.ID, paddr ma.Multiaddr, opts ...ThreadOption) error
+
 	// CreateRecord creates and adds a new record with body to a thread by id.
 	CreateRecord(ctx context.Context, id thread.ID, body format.Node, opts ...ThreadOption) (ThreadRecord, error)
 
 	// AddRecord add an existing record to a thread by id and lid.
 	AddRecord(ctx context.Context, id thread.ID, lid peer.ID, rec Record, opts ...ThreadOption) error
 
+	// GetMaxRecordSize returns the size in bytes of the largest record body which CreateRecord accepts, past
+	// which it fails with ErrRecordTooLarge, like AddRecord with records of larger bodies.
+	GetMaxRecordSize(ctx context.Context) (int, error)
+
 	// GetRecord returns a record by thread id and cid.
 	GetRecord(ctx context.Context, id thread.ID, rid cid.Cid, opts ...ThreadOption) (Record, error)
 
@@ -70,6 +92,46 @@ type API interface {
 	Subscribe(ctx context.Context, opts ...SubOption) (<-chan ThreadRecord, error)
 }
 
+// ErrRecordTooLarge indicates the body of a record exceeds the max record size of the network.
+var ErrRecordTooLarge = errors.New("record too large")
+
+// ThreadSummary summarizes a thread without the keys, addresses and heads of its logs.
+type ThreadSummary struct {
+	// ID is the thread id.
+	ID thread.ID
+	// HasServiceKey and HasReadKey tell whether the keys of the thread are known.
+	HasServiceKey bool
+	HasReadKey    bool
+	// LogCount is the number of logs of the thread.
+	LogCount int
+	// RecordCount estimates the number of records of the thread by the counters of the heads of its logs.
+	RecordCount int64
+	// LastUpdated is the time a record was last added to the thread, or zero if none was.
+	LastUpdated time.Time
+	// PullInterval is the interval the thread is pulled at in the background, or zero if it's the host's.
+	PullInterval time.Duration
+	// PullPaused tells whether the background pulls of the thread are paused.
+	PullPaused bool
+}
+
+// PeerSyncStats is the state of the exchange of records with a peer.
+type PeerSyncStats struct {
+	// Peer is the peer id.
+	Peer peer.ID
+	// LastPushed is the time a record was last pushed to the peer, or zero if none was.
+	LastPushed time.Time
+	// LastReceived is the time a record was last received from the peer, or zero if none was.
+	LastReceived time.Time
+	// FailedPushes are the numbers of the records which failed to be pushed to the peer by class of error,
+	// which is the status code returned by the peer, or "dial" or "backoff" if it wasn't reached.
+	FailedPushes map[string]int64
+	// Addrs are the addresses the peer was last dialed at.
+	Addrs []ma.Multiaddr
+	// Down tells whether dialing the peer backs off, until BackoffUntil.
+	Down         bool
+	BackoffUntil time.Time
+}
+
 // Token is used to restrict network APIs to a single app.App.
 // In other words, a net token protects against writes and deletes
 // which are external to an app.
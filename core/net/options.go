@@ -1,15 +1,49 @@
 package net
 
 import (
+	"time"
+
 	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/textileio/go-threads/core/thread"
 )
 
+// PubSubStrategy is how the records of a thread are announced over pubsub,
+// on top of being pushed to the peers of its logs.
+type PubSubStrategy string
+
+const (
+	// PubSubDefault is the strategy of the network.
+	PubSubDefault PubSubStrategy = ""
+	// PubSubPerThread announces the records of each thread on a topic of
+	// the thread.
+	PubSubPerThread PubSubStrategy = "thread"
+	// PubSubSharded announces records on one of a fixed number of topics,
+	// which the IDs of threads hash into, so that the topics don't grow
+	// with the number of threads. Peers ignore the records of threads they
+	// don't have.
+	PubSubSharded PubSubStrategy = "sharded"
+	// PubSubDisabled doesn't announce records over pubsub, so they're only
+	// pushed to the peers of the logs.
+	PubSubDisabled PubSubStrategy = "disabled"
+)
+
+// Valid tells whether the strategy is known.
+func (s PubSubStrategy) Valid() bool {
+	switch s {
+	case PubSubDefault, PubSubPerThread, PubSubSharded, PubSubDisabled:
+		return true
+	default:
+		return false
+	}
+}
+
 // NewThreadOptions defines options to be used when creating / adding a thread.
 type NewThreadOptions struct {
 	ThreadKey thread.Key
 	LogKey    crypto.Key
 	Token     thread.Token
+	PubSub    PubSubStrategy
 }
 
 // NewThreadOption specifies new thread options.
@@ -39,10 +73,26 @@ func WithNewThreadToken(t thread.Token) NewThreadOption {
 	}
 }
 
+// WithNewThreadPubSub sets the pubsub strategy of the thread, which is kept
+// in the metadata of the thread, instead of the one of the network.
+func WithNewThreadPubSub(strategy PubSubStrategy) NewThreadOption {
+	return func(args *NewThreadOptions) {
+		args.PubSub = strategy
+	}
+}
+
 // ThreadOptions defines options for interacting with a thread.
 type ThreadOptions struct {
 	Token    thread.Token
 	APIToken Token
+	// Fetched is called by PullThread with the number of records fetched
+	// from peers, before they're handled, for each page of records.
+	Fetched func(records int)
+	// ReplicatorExpiry is the time the grant of AddReplicator expires at.
+	ReplicatorExpiry time.Time
+	// AnnounceDeletion makes DeleteThread announce the deletion to the peers
+	// of the thread.
+	AnnounceDeletion bool
 }
 
 // ThreadOption specifies thread options.
@@ -65,10 +115,42 @@ func WithAPIToken(t Token) ThreadOption {
 	}
 }
 
+// WithRecordsFetched is called by PullThread with the number of records
+// fetched from peers, before they're handled, to report the progress of the
+// pull. It's called for each page of records pulled, and isn't called by the
+// nets of API clients.
+func WithRecordsFetched(f func(records int)) ThreadOption {
+	return func(args *ThreadOptions) {
+		args.Fetched = f
+	}
+}
+
+// WithReplicatorExpiry makes the grant of AddReplicator expire at t, after
+// which records aren't pushed to the replicator and it's denied getting
+// records of the thread. The grant is enforced by the policy of the node
+// only, since the replicator keeps the service key and the records it got.
+func WithReplicatorExpiry(t time.Time) ThreadOption {
+	return func(args *ThreadOptions) {
+		args.ReplicatorExpiry = t
+	}
+}
+
+// WithDeletionAnnouncement makes DeleteThread announce the deletion of the
+// thread, signed by the logs of the host, to the peers of the thread, which
+// stop replicating it or report it as deleted by the owner.
+func WithDeletionAnnouncement(enabled bool) ThreadOption {
+	return func(args *ThreadOptions) {
+		args.AnnounceDeletion = enabled
+	}
+}
+
 // SubOptions defines options for a thread subscription.
 type SubOptions struct {
-	ThreadIDs thread.IDSlice
-	Token     thread.Token
+	ThreadIDs  thread.IDSlice
+	LogIDs     []peer.ID
+	Token      thread.Token
+	BufferSize int
+	Dropped    func(dropped int)
 }
 
 // SubOption is a thread subscription option.
@@ -82,6 +164,36 @@ func WithSubFilter(id thread.ID) SubOption {
 	}
 }
 
+// WithSubFilterLogs restricts the subscription to the records of the given
+// logs. Records of other logs are still handled, only not delivered to the
+// subscription. Use this option multiple times to add more logs.
+func WithSubFilterLogs(ids ...peer.ID) SubOption {
+	return func(args *SubOptions) {
+		args.LogIDs = append(args.LogIDs, ids...)
+	}
+}
+
+// WithSubBuffer sets the number of records buffered for the subscription
+// while it isn't read. Once the buffer is full, the oldest record buffered is
+// dropped for each new one, so that a slow subscriber doesn't hold up the
+// handling of records. By default, records aren't buffered nor dropped, and
+// the handling of records waits for the subscriber.
+func WithSubBuffer(size int) SubOption {
+	return func(args *SubOptions) {
+		args.BufferSize = size
+	}
+}
+
+// WithSubDropped is called with the number of records the subscription has
+// dropped so far each time its buffer is full and it drops one. It mustn't
+// block. It's only called for subscriptions with a buffer set by
+// WithSubBuffer.
+func WithSubDropped(f func(dropped int)) SubOption {
+	return func(args *SubOptions) {
+		args.Dropped = f
+	}
+}
+
 // WithSubToken provides authorization for a subscription.
 func WithSubToken(t thread.Token) SubOption {
 	return func(args *SubOptions) {
@@ -0,0 +1,167 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	dse "github.com/textileio/go-datastore-extensions"
+)
+
+// Actions are logged with increasing sequence numbers in the transactions
+// applying them, so listeners resuming from the last one they received are
+// replayed the ones after it. Only the latest actions are kept, and actions
+// of migrations, which report progress, aren't logged.
+
+// DefaultActionLogSize is the default number of the latest actions kept to
+// be replayed to listeners.
+const DefaultActionLogSize = 10000
+
+var dsActions = dsPrefix.ChildString("action")
+
+// ErrActionsPruned indicates the actions to replay to a listener were pruned,
+// so the listener must resync instead.
+var ErrActionsPruned = errors.New("actions to replay were pruned")
+
+// ActionsPrunedError is returned by Listen if the actions after the sequence
+// number to resume from aren't all kept. Listeners can resync by reading the
+// instances again, and then listening since the sequence number of ActionSeq
+// from before reading them.
+type ActionsPrunedError struct {
+	// Since is the sequence number to resume from.
+	Since uint64
+	// Oldest is the sequence number of the oldest action kept, which is 0
+	// if none are.
+	Oldest uint64
+	// Last is the sequence number of the last action.
+	Last uint64
+}
+
+func (e *ActionsPrunedError) Error() string {
+	return fmt.Sprintf("%s: can't replay actions since %d, the oldest one kept is %d of %d", ErrActionsPruned, e.Since, e.Oldest, e.Last)
+}
+
+func (e *ActionsPrunedError) Unwrap() error {
+	return ErrActionsPruned
+}
+
+// ActionSeq returns the sequence number of the last action of the db.
+func (d *DB) ActionSeq() uint64 {
+	d.seqLock.Lock()
+	defer d.seqLock.Unlock()
+	return d.seq
+}
+
+// actionKey returns the key of the logged action with the sequence number,
+// which is padded to sort keys in sequence.
+func actionKey(seq uint64) ds.Key {
+	return dsActions.ChildString(fmt.Sprintf("%020d", seq))
+}
+
+// logAction gives the action the next sequence number and logs it with txn,
+// pruning the oldest action kept. The seq lock must be held, and seq reset
+// if txn isn't committed.
+func (d *DB) logAction(txn ds.Txn, a *Action) error {
+	d.seq++
+	a.Seq = d.seq
+	v, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	if err := txn.Put(actionKey(a.Seq), v); err != nil {
+		return err
+	}
+	if size := uint64(d.actionLogSize); a.Seq > size {
+		return txn.Delete(actionKey(a.Seq - size))
+	}
+	return nil
+}
+
+// loadActionSeq loads the sequence number of the last logged action. The
+// keys of the logged actions are read in order, since they're at most the
+// size of the log.
+func (d *DB) loadActionSeq() error {
+	seqs, err := d.actionSeqs(0)
+	if err != nil {
+		return err
+	}
+	if len(seqs) > 0 {
+		d.seq = seqs[len(seqs)-1]
+	}
+	return nil
+}
+
+// actionSeqs returns the sequence numbers of the logged actions in order, up
+// to limit if it's positive.
+func (d *DB) actionSeqs(limit int) ([]uint64, error) {
+	res, err := d.datastore.Query(query.Query{
+		Prefix:   dsActions.String(),
+		Orders:   []query.Order{query.OrderByKey{}},
+		Limit:    limit,
+		KeysOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return nil, err
+	}
+	seqs := make([]uint64, len(entries))
+	for i, e := range entries {
+		if seqs[i], err = strconv.ParseUint(ds.RawKey(e.Key).Name(), 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	return seqs, nil
+}
+
+// loggedActions returns the logged actions after the one with the sequence
+// number since. The seq lock must be held, so no action is logged after the
+// ones returned until it's released.
+func (d *DB) loggedActions(since uint64) ([]Action, error) {
+	if since == d.seq {
+		return nil, nil
+	}
+	seqs, err := d.actionSeqs(1)
+	if err != nil {
+		return nil, err
+	}
+	var oldest uint64
+	if len(seqs) > 0 {
+		oldest = seqs[0]
+	}
+	if since > d.seq || oldest == 0 || since+1 < oldest {
+		return nil, &ActionsPrunedError{Since: since, Oldest: oldest, Last: d.seq}
+	}
+	txn, err := d.datastore.NewTransactionExtended(true)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard()
+	res, err := txn.QueryExtended(dse.QueryExt{
+		Query:      query.Query{Prefix: dsActions.String()},
+		SeekPrefix: actionKey(since + 1).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	actions := make([]Action, 0, d.seq-since)
+	for r := range res.Next() {
+		if r.Error != nil {
+			return nil, r.Error
+		}
+		var a Action
+		if err := json.Unmarshal(r.Value, &a); err != nil {
+			return nil, err
+		}
+		if a.Seq > since {
+			actions = append(actions, a)
+		}
+	}
+	return actions, nil
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	core "github.com/textileio/go-threads/core/db"
+)
+
+// BatchError lists the invalid items of a batch write, like CreateMany or
+// SaveMany, none of which are written.
+type BatchError struct {
+	Items []ItemError
+}
+
+// ItemError is the error of an item of a batch write.
+type ItemError struct {
+	// Index is the index of the item in the batch.
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	msgs := make([]string, len(e.Items))
+	for i, item := range e.Items {
+		msgs[i] = fmt.Sprintf("item %d: %v", item.Index, item.Err)
+	}
+	return fmt.Sprintf("%d invalid batch items: %s", len(e.Items), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the error of the first invalid item.
+func (e *BatchError) Unwrap() error {
+	return e.Items[0].Err
+}
+
+// batchError returns the error of a write of n items with the errors of the
+// invalid ones, which is the error of the item if there's only one.
+func batchError(n int, errs []ItemError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	if n == 1 {
+		return errs[0].Err
+	}
+	return &BatchError{Items: errs}
+}
+
+// PartialCommitError indicates a write split among several records to fit the
+// max record size failed once some of them were created. The writes of those
+// are committed, and published to the thread, and the others aren't.
+type PartialCommitError struct {
+	// Applied are the IDs of the instances written by the records created.
+	Applied []core.InstanceID
+	Err     error
+}
+
+func (e *PartialCommitError) Error() string {
+	return fmt.Sprintf("committed a split write partially, with %d instances written: %v", len(e.Applied), e.Err)
+}
+
+func (e *PartialCommitError) Unwrap() error {
+	return e.Err
+}
+
+// partialCommitError returns the error of a split write which failed once the
+// events applied were, which is err if there are none.
+func partialCommitError(applied []core.Event, err error) error {
+	if len(applied) == 0 {
+		return err
+	}
+	seen := make(map[core.InstanceID]struct{}, len(applied))
+	ids := make([]core.InstanceID, 0, len(applied))
+	for _, e := range applied {
+		if _, ok := seen[e.InstanceID()]; !ok {
+			seen[e.InstanceID()] = struct{}{}
+			ids = append(ids, e.InstanceID())
+		}
+	}
+	return &PartialCommitError{Applied: ids, Err: err}
+}
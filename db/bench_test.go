@@ -257,3 +257,53 @@ func BenchmarkIndexFind(b *testing.B) {
 		}
 	}
 }
+
+func createOrBenchCollection(b *testing.B, db *DB, indexes []Index) *Collection {
+	collection, err := db.NewCollection(CollectionConfig{
+		Name:    "Dog",
+		Schema:  util.SchemaFromSchemaString(testBenchSchema),
+		Indexes: indexes,
+	})
+	checkBenchErr(b, err)
+
+	// in batches small enough for the transactions updating the indexes
+	for k := 0; k < 10*nameSize; k += 10 {
+		items := make([][]byte, 10)
+		for i := range items {
+			n := k + i
+			items[i] = []byte(fmt.Sprintf(`{"_id": "", "Name": "Name%d", "Age": %d}`, n%100, n%nameSize))
+		}
+		_, err := collection.CreateMany(items)
+		checkBenchErr(b, err)
+	}
+	return collection
+}
+
+func benchmarkOrFind(b *testing.B, collection *Collection) {
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		result, err := collection.Find(Where("Name").Eq("Name0").Or(Where("Age").Eq(3)))
+		if err != nil {
+			b.Fatalf("Error finding data: %s", err)
+		}
+		if len(result) != 110 {
+			b.Fatalf("Unexpected length %d, should be %d", len(result), 110)
+		}
+	}
+}
+
+// BenchmarkOrScanFind and BenchmarkOrIndexFind find the same results of a
+// query with alternatives on two fields, by scanning the collection and by
+// the union of the keys of the indexes of the fields.
+func BenchmarkOrScanFind(b *testing.B) {
+	db, clean := createBenchDB(b)
+	defer clean()
+	benchmarkOrFind(b, createOrBenchCollection(b, db, nil))
+}
+
+func BenchmarkOrIndexFind(b *testing.B) {
+	db, clean := createBenchDB(b)
+	defer clean()
+	benchmarkOrFind(b, createOrBenchCollection(b, db, []Index{{Path: "Name"}, {Path: "Age"}}))
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Closing a db stops new operations, which fail with ErrDBClosed. The
+// operations in flight are given a grace period to end, after which the rest
+// can't commit and fail with ErrDBClosed too. Commits applying when the grace
+// period ends are applied, so none is partially committed. Listeners are then
+// sent ActionClose, and their channels are closed.
+
+// DefaultCloseGracePeriod is the default time Close waits for operations in
+// flight to end.
+const DefaultCloseGracePeriod = time.Second * 5
+
+// ErrDBClosed indicates the db is closed, or closed before an operation
+// could commit.
+var ErrDBClosed = errors.New("db is closed")
+
+// closeActionTimeout is the time listeners are given to receive ActionClose,
+// after which their channels are closed anyway.
+var closeActionTimeout = time.Second
+
+// closer tracks the operations in flight on a db, and their commits, which
+// closing the db waits for.
+type closer struct {
+	lock    sync.Mutex
+	closed  bool
+	aborted bool
+	ops     sync.WaitGroup
+	commits sync.WaitGroup
+}
+
+// begin begins an operation, returning the func ending it, unless the db is
+// closed.
+func (c *closer) begin() (func(), error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.closed {
+		return nil, ErrDBClosed
+	}
+	c.ops.Add(1)
+	return c.ops.Done, nil
+}
+
+// beginCommit begins applying the commit of an operation, returning the func
+// ending it, unless the operation is aborted. Commits are applied until they
+// end once they begin.
+func (c *closer) beginCommit() (func(), error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.aborted {
+		return nil, ErrDBClosed
+	}
+	c.commits.Add(1)
+	return c.commits.Done, nil
+}
+
+// isClosed tells whether the db is closed.
+func (c *closer) isClosed() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.closed
+}
+
+// isAborted tells whether the operations in flight are aborted.
+func (c *closer) isAborted() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.aborted
+}
+
+// close stops new operations and waits up to grace for those in flight to
+// end, then aborts the rest once the commits being applied end. It returns
+// false if the db is closed already.
+func (c *closer) close(grace time.Duration) bool {
+	c.lock.Lock()
+	if c.closed {
+		c.lock.Unlock()
+		return false
+	}
+	c.closed = true
+	c.lock.Unlock()
+
+	ended := make(chan struct{})
+	go func() {
+		c.ops.Wait()
+		close(ended)
+	}()
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+	select {
+	case <-ended:
+	case <-timer.C:
+	}
+
+	c.lock.Lock()
+	c.aborted = true
+	c.lock.Unlock()
+	c.commits.Wait()
+	return true
+}
@@ -1,10 +1,12 @@
 package db
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"sync"
@@ -16,9 +18,11 @@ import (
 	ds "github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
 	format "github.com/ipfs/go-ipld-format"
+	dse "github.com/textileio/go-datastore-extensions"
 	"github.com/textileio/go-threads/core/app"
 	core "github.com/textileio/go-threads/core/db"
 	"github.com/textileio/go-threads/core/thread"
+	"github.com/tidwall/gjson"
 	"github.com/xeipuuv/gojsonschema"
 )
 
@@ -37,6 +41,15 @@ var (
 	// ErrInvalidSchemaInstance indicates the current operation is from an
 	// instance that doesn't satisfy the collection schema.
 	ErrInvalidSchemaInstance = errors.New("instance doesn't correspond to schema")
+	// ErrStaleInstance indicates an instance was saved expecting a version
+	// other than the stored one.
+	ErrStaleInstance = errors.New("stale instance")
+	// ErrInstanceDeleted indicates an instance deleted from a soft-delete
+	// collection was saved, which must be restored first.
+	ErrInstanceDeleted = errors.New("instance is deleted")
+	// ErrMultiTxnHandle indicates a collection of a MultiTxn was committed,
+	// which is committed with the MultiTxn instead.
+	ErrMultiTxnHandle = errors.New("can't commit a collection of a multi-collection txn")
 
 	errMissingInstanceID           = errors.New("invalid instance: missing _id attribute")
 	errAlreadyDiscardedCommitedTxn = errors.New("can't commit discarded/committed txn")
@@ -47,6 +60,27 @@ var (
 	vmTimeout = time.Minute
 )
 
+// StaleInstanceError indicates an instance was saved expecting a version
+// other than the stored one. It matches ErrStaleInstance.
+type StaleInstanceError struct {
+	// InstanceID is the saved instance.
+	InstanceID core.InstanceID
+	// Version is the version of the stored instance, which is 0 if it
+	// doesn't exist.
+	Version int64
+	// Current is the stored instance, which is nil if it doesn't exist or
+	// can't be read.
+	Current []byte
+}
+
+func (e *StaleInstanceError) Error() string {
+	return fmt.Sprintf("%s: instance %s has version %d", ErrStaleInstance, e.InstanceID, e.Version)
+}
+
+func (e *StaleInstanceError) Unwrap() error {
+	return ErrStaleInstance
+}
+
 const (
 	writeValidatorFn = "_validate"
 	readFilterFn     = "_filter"
@@ -56,6 +90,7 @@ const (
 // Collections are like RDBMS tables. They can only exist in a single database.
 type Collection struct {
 	name              string
+	schema            *jsonschema.Schema
 	schemaLoader      gojsonschema.JSONLoader
 	db                *DB
 	indexes           map[string]Index
@@ -64,6 +99,22 @@ type Collection struct {
 	writeValidator    goja.Callable
 	rawReadFilter     []byte
 	readFilter        goja.Callable
+	schemaVersion     int
+	softDelete        bool
+	expiryField       string
+	// defaults is the schema applied to created instances, if the
+	// collection applies default values.
+	defaults *schemaTree
+	// dates is the schema whose date-time values are normalized when
+	// instances are written, if it has any.
+	dates *schemaTree
+	// legacyBefore is the time in unix nanoseconds the schema was last
+	// updated without validating the existing instances, so the ones last
+	// modified before it are legacy.
+	legacyBefore int64
+	// hooks are run when instances are written.
+	hooks     Hooks
+	hooksLock sync.RWMutex
 	sync.Mutex
 }
 
@@ -82,6 +133,11 @@ func newCollection(d *DB, config CollectionConfig) (*Collection, error) {
 	if idType.Type != "string" {
 		return nil, ErrInvalidCollectionSchema
 	}
+	if config.ExpiryField != "" {
+		if err := checkExpiryField(config.Schema, config.ExpiryField); err != nil {
+			return nil, err
+		}
+	}
 	sb, err := json.Marshal(config.Schema)
 	if err != nil {
 		return nil, err
@@ -91,14 +147,30 @@ func newCollection(d *DB, config CollectionConfig) (*Collection, error) {
 	rf := []byte(config.ReadFilter)
 	c := &Collection{
 		name:              config.Name,
+		schema:            config.Schema,
 		schemaLoader:      gojsonschema.NewBytesLoader(sb),
 		db:                d,
 		indexes:           make(map[string]Index),
 		vm:                vm,
 		rawWriteValidator: wv,
 		rawReadFilter:     rf,
+		softDelete:        config.SoftDelete,
+		expiryField:       config.ExpiryField,
+		hooks:             config.Hooks,
+	}
+	if hasDates := bytes.Contains(sb, []byte(`"date-time"`)); config.ApplyDefaults || hasDates {
+		tree, err := newSchemaTree(sb)
+		if err != nil {
+			return nil, err
+		}
+		if config.ApplyDefaults {
+			c.defaults = tree
+		}
+		if hasDates {
+			c.dates = tree
+		}
 	}
-	wvObj, err := compileJSFunc(wv, writeValidatorFn, "writer", "event", "instance")
+	wvObj, err := compileJSFunc(wv, writeValidatorFn, "writer", "event", "instance", "next")
 	if err != nil {
 		return nil, err
 	}
@@ -136,11 +208,35 @@ func (c *Collection) GetSchema() []byte {
 	return c.schemaLoader.JsonSource().([]byte)
 }
 
+// GetSchemaVersion returns the number of times the collection was migrated
+// to a new schema with MigrateCollection.
+func (c *Collection) GetSchemaVersion() int {
+	return c.schemaVersion
+}
+
 // GetWriteValidator returns the current collection write validator.
 func (c *Collection) GetWriteValidator() []byte {
 	return c.rawWriteValidator
 }
 
+// GetSoftDelete returns whether deleting instances of the collection leaves
+// tombstones of them until they're purged.
+func (c *Collection) GetSoftDelete() bool {
+	return c.softDelete
+}
+
+// GetApplyDefaults returns whether created instances of the collection get
+// the default values of the schema for their absent properties.
+func (c *Collection) GetApplyDefaults() bool {
+	return c.defaults != nil
+}
+
+// GetExpiryField returns the path of the field holding the expiry time of
+// instances of the collection, if they expire.
+func (c *Collection) GetExpiryField() string {
+	return c.expiryField
+}
+
 // GetReadFilter returns the current collection read filter.
 func (c *Collection) GetReadFilter() []byte {
 	return c.rawReadFilter
@@ -148,7 +244,9 @@ func (c *Collection) GetReadFilter() []byte {
 
 // ReadTxn creates an explicit readonly transaction. Any operation
 // that tries to mutate an instance of the collection will ErrReadonlyTx.
-// Provides serializable isolation gurantees.
+// The transaction reads a snapshot of the collection taken when it starts,
+// so writes committed while it's open don't block on it, and none of their
+// effects are seen by it.
 func (c *Collection) ReadTxn(f func(txn *Txn) error, opts ...TxnOption) error {
 	return c.db.readTxn(c, f, opts...)
 }
@@ -169,6 +267,17 @@ func (c *Collection) FindByID(id core.InstanceID, opts ...TxnOption) (instance [
 	return
 }
 
+// FindByIDs finds the instances of the IDs in a single read of the
+// collection, in the order of the IDs. The instances which don't exist are
+// nil.
+func (c *Collection) FindByIDs(ids []core.InstanceID, opts ...TxnOption) (instances [][]byte, err error) {
+	err = c.ReadTxn(func(txn *Txn) error {
+		instances, err = txn.FindByIDs(ids)
+		return err
+	}, opts...)
+	return
+}
+
 // Create creates an instance in the collection.
 func (c *Collection) Create(v []byte, opts ...TxnOption) (id core.InstanceID, err error) {
 	err = c.WriteTxn(func(txn *Txn) error {
@@ -186,6 +295,13 @@ func (c *Collection) Create(v []byte, opts ...TxnOption) (id core.InstanceID, er
 }
 
 // CreateMany creates multiple instances in the collection.
+// The instances are validated together, so they're created all together, or
+// none of them are if any is invalid, in which case the error is a
+// BatchError. They're written to the thread in a single record, unless they
+// exceed the max record size, which splits them among several. A split write
+// isn't atomic: if a record fails to be created after others were, the
+// instances of those are created, and the error is a PartialCommitError
+// listing them.
 func (c *Collection) CreateMany(vs [][]byte, opts ...TxnOption) (ids []core.InstanceID, err error) {
 	err = c.WriteTxn(func(txn *Txn) error {
 		ids, err = txn.Create(vs...)
@@ -204,6 +320,11 @@ func (c *Collection) Delete(id core.InstanceID, opts ...TxnOption) error {
 
 // DeleteMany deletes multiple instances by ID. It doesn't
 // fail if one of the IDs don't exist.
+// The deletes are written to the thread in a single record, unless they
+// exceed the max record size, which splits them among several. A split write
+// isn't atomic: if a record fails to be created after others were, the
+// instances of those are deleted, and the error is a PartialCommitError
+// listing them.
 func (c *Collection) DeleteMany(ids []core.InstanceID, opts ...TxnOption) error {
 	return c.WriteTxn(func(txn *Txn) error {
 		return txn.Delete(ids...)
@@ -218,12 +339,26 @@ func (c *Collection) Save(v []byte, opts ...TxnOption) error {
 }
 
 // SaveMany saves changes of multiple instances in the collection.
+// The changes are validated together, so they're saved all together, or none
+// of them are if any is invalid, in which case the error is a BatchError.
+// They're written to the thread in a single record, unless they exceed the
+// max record size, which splits them among several. A split write isn't
+// atomic: if a record fails to be created after others were, the changes of
+// those are saved, and the error is a PartialCommitError listing them.
 func (c *Collection) SaveMany(vs [][]byte, opts ...TxnOption) error {
 	return c.WriteTxn(func(txn *Txn) error {
 		return txn.Save(vs...)
 	}, opts...)
 }
 
+// SaveExpecting saves the changes of an instance in the collection, if the
+// stored instance has the version.
+func (c *Collection) SaveExpecting(v []byte, version int64, opts ...TxnOption) error {
+	return c.WriteTxn(func(txn *Txn) error {
+		return txn.SaveExpecting(v, version)
+	}, opts...)
+}
+
 // Verify verifies changes of an instance in the collection.
 func (c *Collection) Verify(v []byte, opts ...TxnOption) error {
 	return c.WriteTxn(func(txn *Txn) error {
@@ -241,7 +376,7 @@ func (c *Collection) VerifyMany(vs [][]byte, opts ...TxnOption) error {
 // Has returns true if ID exists in the collection, false
 // otherwise.
 func (c *Collection) Has(id core.InstanceID, opts ...TxnOption) (exists bool, err error) {
-	_ = c.ReadTxn(func(txn *Txn) error {
+	err = c.ReadTxn(func(txn *Txn) error {
 		exists, err = txn.Has(id)
 		return err
 	}, opts...)
@@ -251,7 +386,7 @@ func (c *Collection) Has(id core.InstanceID, opts ...TxnOption) (exists bool, er
 // HasMany returns true if all IDs exist in the collection, false
 // otherwise.
 func (c *Collection) HasMany(ids []core.InstanceID, opts ...TxnOption) (exists bool, err error) {
-	_ = c.ReadTxn(func(txn *Txn) error {
+	err = c.ReadTxn(func(txn *Txn) error {
 		exists, err = txn.Has(ids...)
 		return err
 	}, opts...)
@@ -260,13 +395,42 @@ func (c *Collection) HasMany(ids []core.InstanceID, opts ...TxnOption) (exists b
 
 // Find executes a Query and returns the result.
 func (c *Collection) Find(q *Query, opts ...TxnOption) (instances [][]byte, err error) {
-	_ = c.ReadTxn(func(txn *Txn) error {
+	err = c.ReadTxn(func(txn *Txn) error {
 		instances, err = txn.Find(q)
 		return err
 	}, opts...)
 	return
 }
 
+// Count returns the number of instances matching a Query.
+func (c *Collection) Count(q *Query, opts ...TxnOption) (count int, err error) {
+	err = c.ReadTxn(func(txn *Txn) error {
+		count, err = txn.Count(q)
+		return err
+	}, opts...)
+	return
+}
+
+// FindWithCursor executes a Query and returns the result, along with a cursor
+// to the next page of results if there are more.
+func (c *Collection) FindWithCursor(q *Query, opts ...TxnOption) (instances [][]byte, cursor string, err error) {
+	err = c.ReadTxn(func(txn *Txn) error {
+		instances, cursor, err = txn.FindWithCursor(q)
+		return err
+	}, opts...)
+	return
+}
+
+// FindPage executes a Query and returns a page of the result, along with the
+// total number of matching instances if the query has Total set.
+func (c *Collection) FindPage(q *Query, opts ...TxnOption) (res *FindResult, err error) {
+	err = c.ReadTxn(func(txn *Txn) error {
+		res, err = txn.FindPage(q)
+		return err
+	}, opts...)
+	return
+}
+
 type filter struct {
 	Collection string
 	Time       int
@@ -284,7 +448,7 @@ func (f filter) Filter(e query.Entry) bool {
 
 // ModifiedSince returns a list of all instances that have been modified (and/or touched) since `time`.
 func (c *Collection) ModifiedSince(time int64, opts ...TxnOption) (ids []core.InstanceID, err error) {
-	_ = c.ReadTxn(func(txn *Txn) error {
+	err = c.ReadTxn(func(txn *Txn) error {
 		ids, err = txn.ModifiedSince(time)
 		return err
 	}, opts...)
@@ -311,8 +475,9 @@ func (c *Collection) validInstance(v []byte) error {
 	return fmt.Errorf("%w: %s", ErrInvalidSchemaInstance, msg)
 }
 
-// validWrite validates new events against the identity and user-defined write validator function.
-func (c *Collection) validWrite(identity thread.PubKey, e core.Event) error {
+// validWrite validates new events against the identity and user-defined write validator function,
+// given the instance before and after the event is applied, which are nil if it doesn't exist.
+func (c *Collection) validWrite(identity thread.PubKey, e core.Event, previous, next []byte) error {
 	c.Lock()
 	defer c.Unlock()
 	if c.writeValidator == nil {
@@ -330,22 +495,24 @@ func (c *Collection) validWrite(identity thread.PubKey, e core.Event) error {
 	if err != nil {
 		return fmt.Errorf("parsing event in validate write: %v", err)
 	}
-	var inv goja.Value
-	val, err := c.db.datastore.Get(baseKey.ChildString(c.name).ChildString(e.InstanceID().String()))
-	if err != nil && !errors.Is(err, ds.ErrNotFound) {
-		return err
-	}
-	if val != nil {
-		inv, err = parseJSON(c.vm, val)
+	var inv, nextv goja.Value
+	if previous != nil {
+		inv, err = parseJSON(c.vm, previous)
 		if err != nil {
 			return fmt.Errorf("parsing instance in validate write: %v", err)
 		}
 	}
+	if next != nil {
+		nextv, err = parseJSON(c.vm, next)
+		if err != nil {
+			return fmt.Errorf("parsing next instance in validate write: %v", err)
+		}
+	}
 	c.vm.ClearInterrupt()
 	timer := time.AfterFunc(vmTimeout, func() {
 		c.vm.Interrupt("validator timed out")
 	})
-	res, err := c.writeValidator(nil, writer, event, inv)
+	res, err := c.writeValidator(nil, writer, event, inv, nextv)
 	if err != nil {
 		return fmt.Errorf("running write validator func: %v", err)
 	}
@@ -364,6 +531,26 @@ func (c *Collection) validWrite(identity thread.PubKey, e core.Event) error {
 	}
 }
 
+// filtersResults tells whether filterResult may filter out instances of the
+// collection.
+func (c *Collection) filtersResults(includeDeleted bool) bool {
+	return c.readFilter != nil || (c.softDelete && !includeDeleted) || c.expiryField != ""
+}
+
+// filterResult is like filterRead, but also filters out expired instances, and
+// the deleted instances of a soft-delete collection unless includeDeleted.
+func (c *Collection) filterResult(identity thread.PubKey, instance []byte, includeDeleted bool) ([]byte, error) {
+	if c.expired(instance, c.db.now()) {
+		return nil, nil
+	}
+	if c.softDelete && !includeDeleted {
+		if _, ok := deletedAt(instance); ok {
+			return nil, nil
+		}
+	}
+	return c.filterRead(identity, instance)
+}
+
 // filterRead filters an instance against the identity and user-defined read filter function.
 func (c *Collection) filterRead(identity thread.PubKey, instance []byte) ([]byte, error) {
 	c.Lock()
@@ -405,58 +592,133 @@ type Txn struct {
 	discarded  bool
 	committed  bool
 	readonly   bool
+	// snapshot is the view of the datastore read by a read transaction.
+	snapshot dse.TxnExt
+	// multi is the MultiTxn the transaction is a collection of, which
+	// commits its actions.
+	multi *MultiTxn
 
 	actions []core.Action
 }
 
+// reader returns the datastore the transaction reads instances from.
+func (t *Txn) reader() ds.Read {
+	if t.snapshot != nil {
+		return t.snapshot
+	}
+	return t.collection.db.datastore
+}
+
+// datastoreTxn returns the datastore transaction queries of the transaction
+// read, and a func discarding it once they're done.
+func (t *Txn) datastoreTxn() (dse.TxnExt, func(), error) {
+	if t.snapshot != nil {
+		return t.snapshot, func() {}, nil
+	}
+	txn, err := t.collection.db.datastore.NewTransactionExtended(true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return txn, txn.Discard, nil
+}
+
+// writable returns the error writing in the transaction fails with, if it
+// does, which is ErrDBClosed once the db aborts it closing.
+func (t *Txn) writable() error {
+	if t.readonly {
+		return ErrReadonlyTx
+	}
+	if t.collection.db.inflight.isAborted() {
+		return ErrDBClosed
+	}
+	return nil
+}
+
 // Create creates new instances in the collection
 // If the ID value on the instance is nil or otherwise a null value (e.g., ""),
 // and ID is generated and used to store the instance.
+// If more than one of the instances is given and any is invalid, none are
+// created and the error is a BatchError.
 func (t *Txn) Create(new ...[]byte) ([]core.InstanceID, error) {
+	if err := t.writable(); err != nil {
+		return nil, err
+	}
+	created := make(map[core.InstanceID]bool)
+	for _, a := range t.actions {
+		if a.Type == core.Create {
+			created[a.InstanceID] = true
+		}
+	}
 	results := make([]core.InstanceID, len(new))
+	actions := make([]core.Action, 0, len(new))
+	var errs []ItemError
 	for i := range new {
-		if t.readonly {
-			return nil, ErrReadonlyTx
+		a, err := t.createAction(new[i], created)
+		if err != nil {
+			errs = append(errs, ItemError{Index: i, Err: err})
+			continue
 		}
+		created[a.InstanceID] = true
+		results[i] = a.InstanceID
+		actions = append(actions, a)
+	}
+	if err := batchError(len(new), errs); err != nil {
+		return nil, err
+	}
+	t.actions = append(t.actions, actions...)
+	return results, nil
+}
 
-		updated := make([]byte, len(new[i]))
-		copy(updated, new[i])
+// createAction returns the action creating an instance, which must not be
+// one of those created by the transaction.
+func (t *Txn) createAction(new []byte, created map[core.InstanceID]bool) (core.Action, error) {
+	updated := make([]byte, len(new))
+	copy(updated, new)
 
-		id, err := getInstanceID(updated)
-		if err != nil && !errors.Is(err, errMissingInstanceID) {
-			return nil, err
+	id, err := getInstanceID(updated)
+	if err != nil && !errors.Is(err, errMissingInstanceID) {
+		return core.Action{}, err
+	}
+	if id == core.EmptyInstanceID {
+		id, updated = setNewInstanceID(updated)
+	}
+	if t.collection.defaults != nil {
+		if updated, err = t.collection.defaults.applyDefaults(updated); err != nil {
+			return core.Action{}, err
 		}
-		if id == core.EmptyInstanceID {
-			id, updated = setNewInstanceID(updated)
+	}
+	if updated, err = beforeWrite(t.collection.getHooks().BeforeCreate, id, updated); err != nil {
+		return core.Action{}, err
+	}
+	if t.collection.dates != nil {
+		if updated, err = t.collection.dates.normalizeDates(updated); err != nil {
+			return core.Action{}, err
 		}
+	}
 
-		if err := t.collection.validInstance(updated); err != nil {
-			return nil, err
-		}
+	if err := t.collection.validInstance(updated); err != nil {
+		return core.Action{}, err
+	}
 
-		results[i] = id
-		key := baseKey.ChildString(t.collection.name).ChildString(id.String())
-		exists, err := t.collection.db.datastore.Has(key)
-		if err != nil {
-			return nil, err
-		}
-		if exists {
-			return nil, errCantCreateExistingInstance
-		}
+	key := baseKey.ChildString(t.collection.name).ChildString(id.String())
+	exists, err := t.collection.db.datastore.Has(key)
+	if err != nil {
+		return core.Action{}, err
+	}
+	if exists || created[id] {
+		return core.Action{}, errCantCreateExistingInstance
+	}
 
-		// Update readonly/protected mod tag
-		_, updated = setModifiedTag(updated)
+	// Update readonly/protected mod tag
+	_, updated = setModifiedTag(nil, updated)
 
-		a := core.Action{
-			Type:           core.Create,
-			InstanceID:     id,
-			CollectionName: t.collection.name,
-			Previous:       nil,
-			Current:        updated,
-		}
-		t.actions = append(t.actions, a)
-	}
-	return results, nil
+	return core.Action{
+		Type:           core.Create,
+		InstanceID:     id,
+		CollectionName: t.collection.name,
+		Previous:       nil,
+		Current:        updated,
+	}, nil
 }
 
 // Verify verifies updated instances but does not save them.
@@ -470,15 +732,12 @@ func (t *Txn) Verify(updated ...[]byte) error {
 		return err
 	}
 
-	events, _, err := t.createEvents(actions)
+	batches, err := t.createEvents(actions)
 	if err != nil {
 		return err
 	}
-	if len(events) == 0 {
-		return nil
-	}
-	for _, e := range events {
-		if err := t.collection.validWrite(identity, e); err != nil {
+	for _, b := range batches {
+		if err := t.collection.db.validateEvents(identity, b.events); err != nil {
 			return err
 		}
 	}
@@ -486,6 +745,8 @@ func (t *Txn) Verify(updated ...[]byte) error {
 }
 
 // Save saves an instance changes to be committed when the current transaction commits.
+// If more than one of the instances is given and any is invalid, none are
+// saved and the error is a BatchError.
 func (t *Txn) Save(updated ...[]byte) error {
 	identity, err := t.token.PubKey()
 	if err != nil {
@@ -500,60 +761,149 @@ func (t *Txn) Save(updated ...[]byte) error {
 	return nil
 }
 
-func (t *Txn) createSaveActions(identity thread.PubKey, updated ...[]byte) ([]core.Action, error) {
-	var actions []core.Action
-	for i := range updated {
-		if t.readonly {
-			return nil, ErrReadonlyTx
+// SaveExpecting saves an instance like Save, if the stored instance has the
+// version, which is the value of its _mod field. A version of 0 expects the
+// instance not to exist. Otherwise it fails with a StaleInstanceError holding
+// the stored instance, which the changes can be merged into before saving
+// again. Versions are bumped by local writes and by the events of peers, and
+// always increase, even if the clock of a peer is behind the last writer's.
+func (t *Txn) SaveExpecting(updated []byte, version int64) error {
+	if err := t.writable(); err != nil {
+		return err
+	}
+	identity, err := t.token.PubKey()
+	if err != nil {
+		return err
+	}
+	id, err := getInstanceID(updated)
+	if err != nil {
+		return err
+	}
+	current, err := t.collection.db.datastore.Get(t.collection.baseKey().ChildString(id.String()))
+	if errors.Is(err, ds.ErrNotFound) {
+		current = nil
+	} else if err != nil {
+		return err
+	}
+	if stored := instanceVersion(current); stored != version {
+		if current != nil {
+			if current, err = t.collection.filterResult(identity, current, false); err != nil {
+				return err
+			}
 		}
+		return &StaleInstanceError{InstanceID: id, Version: stored, Current: current}
+	}
+	a, err := t.saveAction(identity, updated)
+	if err != nil {
+		return err
+	}
+	t.actions = append(t.actions, a)
+	return nil
+}
 
-		next := make([]byte, len(updated[i]))
-		copy(next, updated[i])
-
-		if err := t.collection.validInstance(next); err != nil {
-			return nil, err
+func (t *Txn) createSaveActions(identity thread.PubKey, updated ...[]byte) ([]core.Action, error) {
+	if err := t.writable(); err != nil {
+		return nil, err
+	}
+	actions := make([]core.Action, 0, len(updated))
+	var errs []ItemError
+	for i := range updated {
+		a, err := t.saveAction(identity, updated[i])
+		if err != nil {
+			errs = append(errs, ItemError{Index: i, Err: err})
+			continue
 		}
+		actions = append(actions, a)
+	}
+	if err := batchError(len(updated), errs); err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
 
-		// Update readonly/protected mod tag
-		_, next = setModifiedTag(next)
+// saveAction returns the action saving an instance.
+func (t *Txn) saveAction(identity thread.PubKey, updated []byte) (core.Action, error) {
+	next := make([]byte, len(updated))
+	copy(next, updated)
 
-		// Because this is a save event, even though we might still create the new instance
-		// it has to have a valid _id ahead of time.
+	if hook := t.collection.getHooks().BeforeSave; hook != nil {
 		id, err := getInstanceID(next)
 		if err != nil {
-			return nil, err
+			return core.Action{}, err
 		}
-		key := baseKey.ChildString(t.collection.name).ChildString(id.String())
-		previous, err := t.collection.db.datastore.Get(key)
-		if err == ds.ErrNotFound {
-			// Default to an empty doc, downstream reducer will take care of patching, etc
-			previous = []byte("{}")
-		} else if err != nil {
-			return nil, err
-		} else {
-			// No errors, carry on
-			previous, err = t.collection.filterRead(identity, previous)
-			if err != nil {
-				return nil, err
-			}
+		if next, err = beforeWrite(hook, id, next); err != nil {
+			return core.Action{}, err
+		}
+	}
+	if t.collection.dates != nil {
+		var err error
+		if next, err = t.collection.dates.normalizeDates(next); err != nil {
+			return core.Action{}, err
 		}
+	}
+	if err := t.collection.validInstance(next); err != nil {
+		return core.Action{}, err
+	}
 
-		actions = append(actions, core.Action{
-			Type:           core.Save,
-			InstanceID:     id,
-			CollectionName: t.collection.name,
-			Previous:       previous,
-			Current:        next,
-		})
+	// Because this is a save event, even though we might still create the new instance
+	// it has to have a valid _id ahead of time.
+	id, err := getInstanceID(next)
+	if err != nil {
+		return core.Action{}, err
 	}
-	return actions, nil
+	key := baseKey.ChildString(t.collection.name).ChildString(id.String())
+	previous, err := t.collection.db.datastore.Get(key)
+	if err == ds.ErrNotFound {
+		// Default to an empty doc, downstream reducer will take care of patching, etc
+		previous = []byte("{}")
+		_, next = setModifiedTag(nil, next)
+	} else if err != nil {
+		return core.Action{}, err
+	} else {
+		if _, ok := deletedAt(previous); ok {
+			return core.Action{}, ErrInstanceDeleted
+		}
+		// Update readonly/protected mod tag past the stored version
+		_, next = setModifiedTag(previous, next)
+		// No errors, carry on
+		previous, err = t.collection.filterRead(identity, previous)
+		if err != nil {
+			return core.Action{}, err
+		}
+	}
+
+	return core.Action{
+		Type:           core.Save,
+		InstanceID:     id,
+		CollectionName: t.collection.name,
+		Previous:       previous,
+		Current:        next,
+	}, nil
 }
 
 // Delete deletes instances by ID when the current transaction commits.
+// Instances of a soft-delete collection are deleted to tombstones, which can
+// be restored until they're purged.
 func (t *Txn) Delete(ids ...core.InstanceID) error {
+	if err := t.writable(); err != nil {
+		return err
+	}
+	beforeDelete := t.collection.getHooks().BeforeDelete
 	for i := range ids {
-		if t.readonly {
-			return ErrReadonlyTx
+		if t.collection.softDelete {
+			a, ok, err := t.tombstoneAction(ids[i])
+			if err != nil {
+				return err
+			}
+			if ok {
+				if beforeDelete != nil {
+					if err := beforeDelete(ids[i]); err != nil {
+						return fmt.Errorf("running hook on instance %s: %w", ids[i], err)
+					}
+				}
+				t.actions = append(t.actions, a)
+			}
+			continue
 		}
 		key := baseKey.ChildString(t.collection.name).ChildString(ids[i].String())
 		exists, err := t.collection.db.datastore.Has(key)
@@ -562,7 +912,12 @@ func (t *Txn) Delete(ids ...core.InstanceID) error {
 		}
 		if !exists {
 			// Nothing to be done here
-			return nil
+			continue
+		}
+		if beforeDelete != nil {
+			if err := beforeDelete(ids[i]); err != nil {
+				return fmt.Errorf("running hook on instance %s: %w", ids[i], err)
+			}
 		}
 		a := core.Action{
 			Type:           core.Delete,
@@ -577,6 +932,7 @@ func (t *Txn) Delete(ids ...core.InstanceID) error {
 }
 
 // Has returns true if all IDs exists in the collection, false otherwise.
+// In a read transaction, it reads the snapshot of the transaction.
 func (t *Txn) Has(ids ...core.InstanceID) (bool, error) {
 	if err := t.collection.db.connector.Validate(t.token, true); err != nil {
 		return false, err
@@ -587,19 +943,19 @@ func (t *Txn) Has(ids ...core.InstanceID) (bool, error) {
 	}
 	for i := range ids {
 		key := baseKey.ChildString(t.collection.name).ChildString(ids[i].String())
-		exists, err := t.collection.db.datastore.Has(key)
+		exists, err := t.reader().Has(key)
 		if err != nil {
 			return false, err
 		}
 		if exists {
-			if t.collection.readFilter == nil {
+			if !t.collection.filtersResults(false) {
 				continue
 			}
-			bytes, err := t.collection.db.datastore.Get(key)
+			bytes, err := t.reader().Get(key)
 			if err != nil {
 				return false, err
 			}
-			bytes, err = t.collection.filterRead(pk, bytes)
+			bytes, err = t.collection.filterResult(pk, bytes, false)
 			if err != nil {
 				return false, err
 			}
@@ -614,23 +970,54 @@ func (t *Txn) Has(ids ...core.InstanceID) (bool, error) {
 }
 
 // FindByID gets an instance by ID in the current txn scope.
+// In a read transaction, it reads the snapshot of the transaction.
 func (t *Txn) FindByID(id core.InstanceID) ([]byte, error) {
 	if err := t.collection.db.connector.Validate(t.token, true); err != nil {
 		return nil, err
 	}
-	key := baseKey.ChildString(t.collection.name).ChildString(id.String())
-	bytes, err := t.collection.db.datastore.Get(key)
-	if errors.Is(err, ds.ErrNotFound) {
-		return nil, ErrInstanceNotFound
-	}
+	pk, err := t.token.PubKey()
 	if err != nil {
 		return nil, err
 	}
+	return t.findByID(pk, id)
+}
+
+// FindByIDs gets the instances of the IDs in the current txn scope, in the
+// order of the IDs. The instances which don't exist are nil, rather than
+// failing the others.
+func (t *Txn) FindByIDs(ids []core.InstanceID) ([][]byte, error) {
+	if err := t.collection.db.connector.Validate(t.token, true); err != nil {
+		return nil, err
+	}
 	pk, err := t.token.PubKey()
 	if err != nil {
 		return nil, err
 	}
-	bytes, err = t.collection.filterRead(pk, bytes)
+	instances := make([][]byte, len(ids))
+	for i, id := range ids {
+		bytes, err := t.findByID(pk, id)
+		if errors.Is(err, ErrInstanceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		instances[i] = bytes
+	}
+	return instances, nil
+}
+
+// findByID gets an instance by ID, filtered for the identity pk.
+func (t *Txn) findByID(pk thread.PubKey, id core.InstanceID) ([]byte, error) {
+	key := baseKey.ChildString(t.collection.name).ChildString(id.String())
+	bytes, err := t.reader().Get(key)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, ErrInstanceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	bytes, err = t.collection.filterResult(pk, bytes, false)
 	if err != nil {
 		return nil, err
 	}
@@ -644,28 +1031,37 @@ func (t *Txn) FindByID(id core.InstanceID) ([]byte, error) {
 // to the collection. This is a syncrhonous call so changes can
 // be assumed to be applied on function return.
 func (t *Txn) Commit() error {
-	events, node, err := t.createEvents(t.actions)
+	if t.multi != nil {
+		return ErrMultiTxnHandle
+	}
+	batches, err := t.createEvents(t.actions)
 	if err != nil {
 		return err
 	}
-	if node == nil {
+	if len(batches) == 0 {
 		return nil
 	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), createNetRecordTimeout)
-	defer cancel()
-	if _, err = t.collection.db.connector.CreateNetRecord(ctx, node, t.token); err != nil {
+	// unique indexes are checked before the events are published, so
+	// conflicting writes never reach the thread
+	if err := t.checkUnique(); err != nil {
 		return err
 	}
-	if err = t.collection.db.dispatcher.Dispatch(events); err != nil {
+	end, err := t.collection.db.inflight.beginCommit()
+	if err != nil {
 		return err
 	}
-	return t.collection.db.notifyTxnEvents(node, t.token)
+	defer end()
+
+	return t.collection.db.publishEvents(context.Background(), batches, t.token)
 }
 
 // Discard discards all changes done in the current transaction.
+// Discarding a collection of a MultiTxn discards the MultiTxn.
 func (t *Txn) Discard() {
 	t.discarded = true
+	if t.multi != nil && !t.multi.discarded {
+		t.multi.Discard()
+	}
 }
 
 // RefreshCollection updates the transaction's collection reference from the master db map,
@@ -679,10 +1075,28 @@ func (t *Txn) RefreshCollection() error {
 	return nil
 }
 
+// schemaArrayPathError indicates a path goes through the items of an array,
+// which paths don't address. It matches ErrInvalidCollectionSchemaPath.
+type schemaArrayPathError struct {
+	path  string
+	array string
+}
+
+func (e *schemaArrayPathError) Error() string {
+	return fmt.Sprintf("%s: %s goes through array %s", ErrInvalidCollectionSchemaPath, e.path, e.array)
+}
+
+func (e *schemaArrayPathError) Unwrap() error {
+	return ErrInvalidCollectionSchemaPath
+}
+
 func getSchemaTypeAtPath(schema *jsonschema.Schema, pth string) (*jsonschema.Type, error) {
 	parts := strings.Split(pth, ".")
 	jt := schema.Type
-	for _, n := range parts {
+	for i, n := range parts {
+		if i > 0 && isSchemaArray(jt, schema.Definitions) {
+			return nil, &schemaArrayPathError{path: pth, array: strings.Join(parts[:i], ".")}
+		}
 		props, err := getSchemaTypeProperties(jt, schema.Definitions)
 		if err != nil {
 			return nil, err
@@ -716,6 +1130,21 @@ func getSchemaTypeProperties(jt *jsonschema.Type, defs jsonschema.Definitions) (
 	return properties, nil
 }
 
+// isSchemaArray tells whether the schema type, or the definition it
+// references, is an array.
+func isSchemaArray(jt *jsonschema.Type, defs jsonschema.Definitions) bool {
+	if jt == nil {
+		return false
+	}
+	if jt.Ref != "" {
+		parts := strings.Split(jt.Ref, "/")
+		if def := defs[parts[len(parts)-1]]; def != nil {
+			jt = def
+		}
+	}
+	return jt.Type == "array"
+}
+
 func getInstanceID(t []byte) (core.InstanceID, error) {
 	partial := &struct {
 		ID *string `json:"_id"`
@@ -738,8 +1167,20 @@ func setNewInstanceID(t []byte) (core.InstanceID, []byte) {
 	return newID, patchedValue
 }
 
-func setModifiedTag(t []byte) (newTime int64, patchedValue []byte) {
+// instanceVersion returns the version of an instance, which is 0 if it's nil.
+func instanceVersion(instance []byte) int64 {
+	return gjson.GetBytes(instance, modFieldName).Int()
+}
+
+// setModifiedTag sets the _mod field of an instance to the current time, or
+// right after the version of the previous instance if the clock is behind it,
+// so the versions of an instance always increase. Since JSON patches carry
+// numbers as floats, the version is bumped to the next one they tell apart.
+func setModifiedTag(previous, t []byte) (newTime int64, patchedValue []byte) {
 	newTime = time.Now().UnixNano()
+	if version := instanceVersion(previous); newTime <= version {
+		newTime = int64(math.Nextafter(float64(version), math.Inf(1)))
+	}
 	patchedValue, err := jsonpatch.MergePatch(t, []byte(fmt.Sprintf(`{"%s": %d}`, modFieldName, newTime)))
 	if err != nil {
 		log.Fatalf("while automatically patching autogenerated _mod: %v", err)
@@ -747,21 +1188,74 @@ func setModifiedTag(t []byte) (newTime int64, patchedValue []byte) {
 	return
 }
 
-func (t *Txn) createEvents(actions []core.Action) (events []core.Event, node format.Node, err error) {
+func (t *Txn) createEvents(actions []core.Action) ([]eventBatch, error) {
 	if t.discarded || t.committed {
-		return nil, nil, errAlreadyDiscardedCommitedTxn
+		return nil, errAlreadyDiscardedCommitedTxn
 	}
-	events, node, err = t.collection.db.eventcodec.Create(actions)
+	return t.collection.db.createEvents(actions)
+}
+
+// eventBatch is the events of actions and the node of the record they're
+// published in.
+type eventBatch struct {
+	events []core.Event
+	node   format.Node
+}
+
+// createEvents encodes the actions to events and the nodes of the records
+// they're published in, splitting the actions among as many records as
+// needed for each to fit the max record size. There are no batches if there
+// are no events.
+func (d *DB) createEvents(actions []core.Action) ([]eventBatch, error) {
+	events, node, err := d.eventcodec.Create(actions)
 	if err != nil {
-		return
+		return nil, err
 	}
 	if len(events) == 0 && node == nil {
-		return nil, nil, nil
+		return nil, nil
 	}
 	if len(events) == 0 || node == nil {
-		return nil, nil, fmt.Errorf("created events and node must both be nil or not-nil")
+		return nil, fmt.Errorf("created events and node must both be nil or not-nil")
+	}
+	size, max := len(node.RawData()), d.maxRecordSize
+	if size <= max {
+		return []eventBatch{{events: events, node: node}}, nil
 	}
-	return events, node, nil
+	if len(actions) == 1 {
+		return nil, fmt.Errorf("%w: %d bytes is more than %d", ErrRecordTooLarge, size, max)
+	}
+	first, err := d.createEvents(actions[:len(actions)/2])
+	if err != nil {
+		return nil, err
+	}
+	rest, err := d.createEvents(actions[len(actions)/2:])
+	if err != nil {
+		return nil, err
+	}
+	return append(first, rest...), nil
+}
+
+// publishEvents creates the records of the batches in order, dispatching
+// the events of each once its record is created. If it fails once the events
+// of some batches are dispatched, the error is a PartialCommitError.
+func (d *DB) publishEvents(ctx context.Context, batches []eventBatch, token thread.Token) error {
+	var applied []core.Event
+	for _, b := range batches {
+		rctx, cancel := context.WithTimeout(ctx, createNetRecordTimeout)
+		_, err := d.connector.CreateNetRecord(rctx, b.node, token)
+		cancel()
+		if err != nil {
+			return partialCommitError(applied, err)
+		}
+		if err = d.dispatcher.Dispatch(b.events); err != nil {
+			return partialCommitError(applied, err)
+		}
+		applied = append(applied, b.events...)
+		if err = d.notifyTxnEvents(b.node, token); err != nil {
+			return partialCommitError(applied, err)
+		}
+	}
+	return nil
 }
 
 func compileJSFunc(v []byte, name string, args ...string) ([]byte, error) {
@@ -2,14 +2,22 @@ package db
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/alecthomas/jsonschema"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
 	logging "github.com/ipfs/go-log/v2"
+	"github.com/textileio/go-threads/core/app"
 	core "github.com/textileio/go-threads/core/db"
 	"github.com/textileio/go-threads/util"
 	"github.com/xeipuuv/gojsonschema"
@@ -174,6 +182,64 @@ func TestNewCollection(t *testing.T) {
 		id, err = c.Create(util.JSONFromInstance(dog))
 		checkErr(t, err)
 	})
+	t.Run("WithWriteValidatorInstances", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		c, err := db.NewCollection(CollectionConfig{
+			Name:   "Person",
+			Schema: util.SchemaFromInstance(&Person{}, false),
+			WriteValidator: `
+				if (!writer) {
+				  return false
+				}
+				if (!next) {
+				  return instance.Name !== "Keep"
+				}
+				return !instance || next.Age >= instance.Age
+			`,
+		})
+		checkErr(t, err)
+		ids, err := c.CreateMany([][]byte{
+			util.JSONFromInstance(Person{Name: "Alice", Age: 42}),
+			util.JSONFromInstance(Person{Name: "Keep", Age: 24}),
+		})
+		checkErr(t, err)
+		checkErr(t, c.Save(util.JSONFromInstance(Person{ID: ids[0], Name: "Alice", Age: 43})))
+
+		l, err := db.Listen()
+		checkErr(t, err)
+		defer l.Close()
+		if err := c.Save(util.JSONFromInstance(Person{ID: ids[0], Name: "Alice", Age: 41})); err == nil {
+			t.Fatal("save should have been invalid")
+		}
+		if err := c.Delete(ids[1]); err == nil {
+			t.Fatal("delete should have been invalid")
+		}
+		// Later events are validated with the instances resulting from earlier ones
+		err = c.WriteTxn(func(txn *Txn) error {
+			res, err := txn.Create(util.JSONFromInstance(Person{Name: "Bob", Age: 30}))
+			if err != nil {
+				return err
+			}
+			return txn.Save(util.JSONFromInstance(Person{ID: res[0], Name: "Bob", Age: 29}))
+		})
+		if err == nil {
+			t.Fatal("transaction should have been invalid")
+		}
+		select {
+		case a := <-l.Channel():
+			t.Fatalf("invalid writes shouldn't be dispatched, got %v", a)
+		case <-time.After(time.Second):
+		}
+		n, err := c.Count(nil)
+		checkErr(t, err)
+		if n != 2 {
+			t.Fatalf("expected 2 instances, got %d", n)
+		}
+
+		checkErr(t, c.Delete(ids[0]))
+	})
 	t.Run("WithReadFilter", func(t *testing.T) {
 		t.Parallel()
 		db, clean := createTestDB(t)
@@ -323,7 +389,7 @@ func TestUpdateCollection(t *testing.T) {
 		c, err = db.UpdateCollection(CollectionConfig{
 			Name:   "Dog",
 			Schema: util.SchemaFromInstance(&Dog2{}, false),
-		})
+		}, WithSkipValidation(true))
 		checkErr(t, err)
 		_, err = c.Create([]byte(`{"Name": "Fido", "Comments": []}`))
 		if err == nil {
@@ -386,6 +452,97 @@ func TestUpdateCollection(t *testing.T) {
 			t.Fatalf("expected %d indexes, got %d", 1, len(indexes))
 		}
 	})
+	t.Run("CheckUpdate", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		c, err := db.NewCollection(CollectionConfig{
+			Name:   "Dog",
+			Schema: util.SchemaFromInstance(&Dog{}, false),
+		})
+		checkErr(t, err)
+		ids, err := c.CreateMany([][]byte{
+			[]byte(`{"Name": "Fido", "Comments": []}`),
+			[]byte(`{"Name": "Rex", "Comments": []}`),
+		})
+		checkErr(t, err)
+		config := CollectionConfig{
+			Name:   "Dog",
+			Schema: util.SchemaFromInstance(&Dog2{}, false),
+		}
+		report, err := db.CheckCollectionUpdate(config)
+		checkErr(t, err)
+		expected := CollectionUpdateReport{
+			Incompatible: 2,
+			SampleIDs:    ids,
+			Pointers:     []string{"", "/Breed", "/FullName", "/Toys"},
+		}
+		sort.Slice(report.SampleIDs, func(i, j int) bool { return report.SampleIDs[i] < report.SampleIDs[j] })
+		sort.Slice(expected.SampleIDs, func(i, j int) bool { return expected.SampleIDs[i] < expected.SampleIDs[j] })
+		if !reflect.DeepEqual(report, expected) {
+			t.Fatalf("expected report %v, got %v", expected, report)
+		}
+		if db.GetCollection("Dog") != c {
+			t.Fatal("expected checking the update not to change the collection")
+		}
+
+		_, err = db.UpdateCollection(config)
+		var incompatible *IncompatibleInstancesError
+		if !errors.As(err, &incompatible) || !errors.Is(err, ErrIncompatibleInstances) {
+			t.Fatalf("expected updating with incompatible instances to fail, got %v", err)
+		}
+		if incompatible.Report.Incompatible != 2 {
+			t.Fatalf("expected 2 incompatible instances, got %d", incompatible.Report.Incompatible)
+		}
+		if db.GetCollection("Dog") != c {
+			t.Fatal("expected the failed update not to change the collection")
+		}
+
+		report, err = db.CheckCollectionUpdate(CollectionConfig{
+			Name:   "Dog",
+			Schema: util.SchemaFromInstance(&Dog{}, false),
+		})
+		checkErr(t, err)
+		if report.Incompatible != 0 || len(report.SampleIDs) != 0 {
+			t.Fatalf("expected no incompatible instances, got %v", report)
+		}
+		if _, err := db.CheckCollectionUpdate(CollectionConfig{Name: "Cat", Schema: config.Schema}); !errors.Is(err, ErrCollectionNotFound) {
+			t.Fatalf("expected checking a missing collection to fail, got %v", err)
+		}
+	})
+	t.Run("SkipValidation", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		c, err := db.NewCollection(CollectionConfig{
+			Name:   "Dog",
+			Schema: util.SchemaFromInstance(&Dog{}, false),
+		})
+		checkErr(t, err)
+		id, err := c.Create([]byte(`{"Name": "Fido", "Comments": []}`))
+		checkErr(t, err)
+		c, err = db.UpdateCollection(CollectionConfig{
+			Name:   "Dog",
+			Schema: util.SchemaFromInstance(&Dog2{}, false),
+		}, WithSkipValidation(true))
+		checkErr(t, err)
+		_, err = c.Create([]byte(`{"FullName": "Lassie", "Breed": "Collie", "Toys": {"Favorite": "Ball", "Names": []}, "Comments": []}`))
+		checkErr(t, err)
+
+		// the update is loaded from the collection metadata
+		db.collections = make(map[string]*Collection)
+		checkErr(t, db.reCreateCollections())
+		errs, err := db.GetCollection("Dog").Validate(context.Background(), 0)
+		checkErr(t, err)
+		if len(errs) != 4 {
+			t.Fatalf("expected 4 validation errors, got %v", errs)
+		}
+		for _, e := range errs {
+			if e.InstanceID != id || !e.Legacy {
+				t.Fatalf("expected legacy validation errors of the instance written before the update, got %v", e)
+			}
+		}
+	})
 	t.Run("Fail/BadIndexPath", func(t *testing.T) {
 		t.Parallel()
 		db, clean := createTestDB(t)
@@ -406,6 +563,188 @@ func TestUpdateCollection(t *testing.T) {
 	})
 }
 
+func TestMigrateCollection(t *testing.T) {
+	t.Parallel()
+	toDog2 := func(old []byte) ([]byte, error) {
+		dog := &Dog{}
+		if err := json.Unmarshal(old, dog); err != nil {
+			return nil, err
+		}
+		return json.Marshal(&Dog2{
+			ID:       dog.ID,
+			FullName: dog.Name,
+			Toys:     Toys{Names: []string{}},
+			Comments: dog.Comments,
+		})
+	}
+	t.Run("Migrate", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		c, err := db.NewCollection(CollectionConfig{
+			Name:   "Dog",
+			Schema: util.SchemaFromInstance(&Dog{}, false),
+		})
+		checkErr(t, err)
+		id, err := c.Create([]byte(`{"Name": "Fido", "Comments": []}`))
+		checkErr(t, err)
+		l, err := db.Listen(ListenOption{Type: ListenMigrate})
+		checkErr(t, err)
+		defer l.Close()
+
+		c, err = db.MigrateCollection("Dog", util.SchemaFromInstance(&Dog2{}, false), toDog2)
+		checkErr(t, err)
+		if c.GetSchemaVersion() != 1 {
+			t.Fatalf("expected schema version %d, got %d", 1, c.GetSchemaVersion())
+		}
+		if db.GetCollection("Dog") != c {
+			t.Fatal("migrated collection should be registered")
+		}
+		select {
+		case a := <-l.Channel():
+			expected := Action{Collection: "Dog", Type: ActionMigrate, ID: id, Migrated: 1, Total: 1}
+			if !reflect.DeepEqual(a, expected) {
+				t.Fatalf("expected action %v, got %v", expected, a)
+			}
+		default:
+			t.Fatal("migration progress should be notified")
+		}
+
+		res, err := c.FindByID(id)
+		checkErr(t, err)
+		dog := &Dog2{}
+		checkErr(t, json.Unmarshal(res, dog))
+		if dog.FullName != "Fido" {
+			t.Fatalf("expected migrated name %s, got %s", "Fido", dog.FullName)
+		}
+		_, err = c.Create([]byte(`{"Name": "Fido", "Comments": []}`))
+		if err == nil {
+			t.Fatal("instance should not be valid")
+		}
+
+		c, err = db.MigrateCollection("Dog", util.SchemaFromInstance(&Dog2{}, false), func(old []byte) ([]byte, error) {
+			dog := &Dog2{}
+			if err := json.Unmarshal(old, dog); err != nil {
+				return nil, err
+			}
+			dog.Breed = "Collie"
+			return json.Marshal(dog)
+		})
+		checkErr(t, err)
+		if c.GetSchemaVersion() != 2 {
+			t.Fatalf("expected schema version %d, got %d", 2, c.GetSchemaVersion())
+		}
+		v, err := db.datastore.Get(dsVersions.ChildString("Dog"))
+		checkErr(t, err)
+		if string(v) != "2" {
+			t.Fatalf("expected stored schema version %s, got %s", "2", v)
+		}
+	})
+	t.Run("Fail/InvalidInstance", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		c, err := db.NewCollection(CollectionConfig{
+			Name:   "Dog",
+			Schema: util.SchemaFromInstance(&Dog{}, false),
+		})
+		checkErr(t, err)
+		_, err = c.CreateMany([][]byte{
+			[]byte(`{"Name": "Fido", "Comments": []}`),
+			[]byte(`{"Name": "Lassie", "Comments": []}`),
+		})
+		checkErr(t, err)
+		schema := c.GetSchema()
+
+		_, err = db.MigrateCollection("Dog", util.SchemaFromInstance(&Dog2{}, false), func(old []byte) ([]byte, error) {
+			if strings.Contains(string(old), "Lassie") {
+				return old, nil
+			}
+			return toDog2(old)
+		})
+		if err == nil {
+			t.Fatal("migrated instance should not be valid")
+		}
+		c = db.GetCollection("Dog")
+		if !bytes.Equal(c.GetSchema(), schema) {
+			t.Fatal("schema should not be migrated")
+		}
+		if c.GetSchemaVersion() != 0 {
+			t.Fatalf("expected schema version %d, got %d", 0, c.GetSchemaVersion())
+		}
+		all, err := c.Find(&Query{})
+		checkErr(t, err)
+		for _, res := range all {
+			dog := &Dog{}
+			checkErr(t, json.Unmarshal(res, dog))
+			if dog.Name == "" {
+				t.Fatal("instance should not be migrated")
+			}
+		}
+	})
+	t.Run("Fail/TransformError", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		c, err := db.NewCollection(CollectionConfig{
+			Name:   "Dog",
+			Schema: util.SchemaFromInstance(&Dog{}, false),
+		})
+		checkErr(t, err)
+		_, err = c.Create([]byte(`{"Name": "Fido", "Comments": []}`))
+		checkErr(t, err)
+		errTransform := errors.New("transform failed")
+		_, err = db.MigrateCollection("Dog", util.SchemaFromInstance(&Dog2{}, false), func([]byte) ([]byte, error) {
+			return nil, errTransform
+		})
+		if !errors.Is(err, errTransform) {
+			t.Fatalf("expected error %v, got %v", errTransform, err)
+		}
+	})
+	t.Run("Fail/ChangedID", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		c, err := db.NewCollection(CollectionConfig{
+			Name:   "Dog",
+			Schema: util.SchemaFromInstance(&Dog{}, false),
+		})
+		checkErr(t, err)
+		_, err = c.Create([]byte(`{"Name": "Fido", "Comments": []}`))
+		checkErr(t, err)
+		_, err = db.MigrateCollection("Dog", util.SchemaFromInstance(&Dog{}, false), func([]byte) ([]byte, error) {
+			return []byte(`{"_id": "other", "Name": "Fido", "Comments": []}`), nil
+		})
+		if !errors.Is(err, ErrMigrationChangedID) {
+			t.Fatalf("expected error %v, got %v", ErrMigrationChangedID, err)
+		}
+	})
+	t.Run("Fail/IndexNotIndexable", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		_, err := db.NewCollection(CollectionConfig{
+			Name:    "Dog",
+			Schema:  util.SchemaFromInstance(&Dog{}, false),
+			Indexes: []Index{{Path: "Name"}},
+		})
+		checkErr(t, err)
+		_, err = db.MigrateCollection("Dog", util.SchemaFromInstance(&Dog2{}, false), toDog2)
+		if !errors.Is(err, ErrInvalidCollectionSchemaPath) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidCollectionSchemaPath, err)
+		}
+	})
+	t.Run("Fail/CollectionNotFound", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		_, err := db.MigrateCollection("Dog", util.SchemaFromInstance(&Dog2{}, false), toDog2)
+		if !errors.Is(err, ErrCollectionNotFound) {
+			t.Fatalf("expected error %v, got %v", ErrCollectionNotFound, err)
+		}
+	})
+}
+
 func TestDeleteCollection(t *testing.T) {
 	t.Parallel()
 	db, clean := createTestDB(t)
@@ -416,78 +755,437 @@ func TestDeleteCollection(t *testing.T) {
 		Indexes: []Index{{Path: "FullName", Unique: true}},
 	})
 	checkErr(t, err)
+	c := db.GetCollection("Dog")
+	_, err = c.CreateMany([][]byte{
+		util.JSONFromInstance(Dog2{FullName: "Fido", Toys: Toys{Names: []string{}}, Comments: []Comment{}}),
+		util.JSONFromInstance(Dog2{FullName: "Rex", Toys: Toys{Names: []string{}}, Comments: []Comment{}}),
+	})
+	checkErr(t, err)
 	err = db.DeleteCollection("Dog")
 	checkErr(t, err)
 	if db.GetCollection("Dog") != nil {
 		t.Fatal("collection should be deleted")
 	}
-}
-
-func TestAddIndex(t *testing.T) {
-	t.Parallel()
-	db, clean := createTestDB(t)
-	defer clean()
-	schema := util.SchemaFromInstance(&Person2{}, false)
-	c, err := db.NewCollection(CollectionConfig{
-		Name:   "Person",
-		Schema: schema,
-	})
-	checkErr(t, err)
+	assertPurged := func(t *testing.T) {
+		t.Helper()
+		prefixes := []ds.Key{c.baseKey(), indexPrefix.Child(c.baseKey()), dsDeleted.ChildString("Dog")}
+		for _, prefix := range prefixes {
+			if n := countKeys(t, db, query.Query{Prefix: prefix.String()}); n != 0 {
+				t.Fatalf("expected no keys under %s, found %d", prefix, n)
+			}
+		}
+		if n := countKeys(t, db, query.Query{
+			Prefix:  dsDispatcherPrefix.String(),
+			Filters: []query.Filter{dispatchedTo{collection: "Dog"}},
+		}); n != 0 {
+			t.Fatalf("expected no dispatched events of the collection, found %d", n)
+		}
+	}
+	assertPurged(t)
 
-	t.Run("AddNameUniqueIndex", func(t *testing.T) {
-		err := c.addIndex(schema, Index{Path: "Name", Unique: false})
-		checkErr(t, err)
-	})
-	t.Run("AddAgeNonUniqueIndex", func(t *testing.T) {
-		err := c.addIndex(schema, Index{Path: "Age", Unique: false})
+	t.Run("ReCreate", func(t *testing.T) {
+		c, err := db.NewCollection(CollectionConfig{
+			Name:    "Dog",
+			Schema:  util.SchemaFromInstance(&Dog{}, false),
+			Indexes: []Index{{Path: "Name", Unique: true}},
+		})
 		checkErr(t, err)
-	})
-	t.Run("AddNestedIndex", func(t *testing.T) {
-		err := c.addIndex(schema, Index{Path: "Toys.Favorite", Unique: false})
+		res, err := c.Find(&Query{})
 		checkErr(t, err)
-	})
-	t.Run("Fail/AddIndexWithBadPath", func(t *testing.T) {
-		err := c.addIndex(schema, Index{Path: "Bad.Path", Unique: false})
-		if err == nil {
-			t.Fatal("index path should not be valid")
+		if len(res) != 0 {
+			t.Fatalf("expected no instances of the deleted collection, got %d", len(res))
 		}
-	})
-	t.Run("Fail/AddIndexOnRef", func(t *testing.T) {
-		err := c.addIndex(schema, Index{Path: "Toys", Unique: false})
-		if err == nil {
-			t.Fatal("index path should not be valid")
+		_, err = c.Create(util.JSONFromInstance(Dog{Name: "Fido", Comments: []Comment{}}))
+		checkErr(t, err)
+		res, err = c.Find(Where("Name").Eq("Fido"))
+		checkErr(t, err)
+		if len(res) != 1 {
+			t.Fatalf("expected 1 instance, got %d", len(res))
 		}
-	})
-	t.Run("Fail/AddIndexOnBadType", func(t *testing.T) {
-		err := c.addIndex(schema, Index{Path: "Comments", Unique: false})
-		if err == nil {
-			t.Fatal("index path should not be valid")
+		res, err = c.Find(Where("FullName").Eq("Fido").UseIndex("FullName"))
+		checkErr(t, err)
+		if len(res) != 0 {
+			t.Fatalf("expected the index of the deleted collection to be purged, got %d results", len(res))
 		}
+		checkErr(t, db.DeleteCollection("Dog"))
+		assertPurged(t)
 	})
-	t.Run("Fail/AddUniqueIndexOnNonUniquePath", func(t *testing.T) {
-		_, err := c.Create(util.JSONFromInstance(Person2{Name: "Foo", Age: 42, Toys: Toys{Favorite: "", Names: []string{}}, Comments: []Comment{}}))
+	t.Run("Interrupted", func(t *testing.T) {
+		// leave the data of a collection as a crash in the middle of its
+		// purge would
+		checkErr(t, db.datastore.Put(dsDeleted.ChildString("Dog"), nil))
+		checkErr(t, db.datastore.Put(c.baseKey().ChildString("stale"), []byte(`{"_id": "stale"}`)))
+		checkErr(t, db.datastore.Put(indexPrefix.Child(c.baseKey()).ChildString("FullName").ChildString("Fido"), nil))
+		// resumed as the db is reopened
+		checkErr(t, db.resumePurges())
+		assertPurged(t)
+
+		// or before a collection of the same name is created
+		checkErr(t, db.datastore.Put(dsDeleted.ChildString("Dog"), nil))
+		checkErr(t, db.datastore.Put(c.baseKey().ChildString("stale"), []byte(`{"_id": "stale"}`)))
+		c, err := db.NewCollection(CollectionConfig{
+			Name:   "Dog",
+			Schema: util.SchemaFromInstance(&Dog{}, false),
+		})
 		checkErr(t, err)
-		_, err = c.Create(util.JSONFromInstance(Person2{Name: "Foo", Age: 42, Toys: Toys{Favorite: "", Names: []string{}}, Comments: []Comment{}}))
+		res, err := c.Find(&Query{})
 		checkErr(t, err)
-		err = c.addIndex(schema, Index{Path: "Name", Unique: true})
-		if err == nil {
-			t.Fatal("index path should not be valid")
+		if len(res) != 0 {
+			t.Fatalf("expected no instances of the deleted collection, got %d", len(res))
 		}
 	})
 }
 
-func TestEmptySchema(t *testing.T) {
+func countKeys(t *testing.T, db *DB, q query.Query) int {
+	t.Helper()
+	q.KeysOnly = true
+	res, err := db.datastore.Query(q)
+	checkErr(t, err)
+	entries, err := res.Rest()
+	checkErr(t, err)
+	return len(entries)
+}
+
+func TestRenameCollection(t *testing.T) {
 	t.Parallel()
 	db, clean := createTestDB(t)
 	defer clean()
-	// Note the empty schema {}, this is a catch all schema
-	schema := util.SchemaFromSchemaString("{}")
-	_, err := db.NewCollection(CollectionConfig{
-		Name:   "Person",
-		Schema: schema,
-	})
-	if err != ErrInvalidCollectionSchema {
-		t.Fatalf("expected to throw ErrInvalidCollectionSchema error")
+	c, err := db.NewCollection(CollectionConfig{
+		Name:    "Dog",
+		Schema:  util.SchemaFromInstance(&Dog2{}, false),
+		Indexes: []Index{{Path: "FullName", Unique: true}},
+	})
+	checkErr(t, err)
+	_, err = db.NewCollection(CollectionConfig{
+		Name:   "Cat",
+		Schema: util.SchemaFromInstance(&Dog{}, false),
+	})
+	checkErr(t, err)
+	ids, err := c.CreateMany([][]byte{
+		util.JSONFromInstance(Dog2{FullName: "Fido", Toys: Toys{Names: []string{}}, Comments: []Comment{}}),
+		util.JSONFromInstance(Dog2{FullName: "Rex", Toys: Toys{Names: []string{}}, Comments: []Comment{}}),
+	})
+	checkErr(t, err)
+	l, err := db.Listen(ListenOption{Collection: "Dog"})
+	checkErr(t, err)
+	defer l.Close()
+
+	if err := db.RenameCollection("Dog", "Cat"); !errors.Is(err, ErrCollectionAlreadyRegistered) {
+		t.Fatalf("expected renaming to an existing collection to fail, got %v", err)
+	}
+	if err := db.RenameCollection("Bird", "Parrot"); !errors.Is(err, ErrCollectionNotFound) {
+		t.Fatalf("expected renaming a missing collection to fail, got %v", err)
+	}
+	checkErr(t, db.RenameCollection("Dog", "Hound"))
+	if db.GetCollection("Dog") != nil {
+		t.Fatal("collection should be renamed")
+	}
+	rc := db.GetCollection("Hound")
+	if rc == nil {
+		t.Fatal("renamed collection should exist")
+	}
+	if _, ok := rc.indexes["FullName"]; !ok {
+		t.Fatal("renamed collection should keep its indexes")
+	}
+	select {
+	case a := <-l.Channel():
+		if a.Type != ActionRename || a.Collection != "Dog" || a.NewName != "Hound" {
+			t.Fatalf("expected the rename action, got %+v", a)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("listener should be sent the rename")
+	}
+
+	t.Run("Instances", func(t *testing.T) {
+		for _, id := range ids {
+			_, err := rc.FindByID(id)
+			checkErr(t, err)
+		}
+		res, err := rc.Find(Where("FullName").Eq("Rex").UseIndex("FullName"))
+		checkErr(t, err)
+		if len(res) != 1 {
+			t.Fatalf("expected 1 result from the index, got %d", len(res))
+		}
+		_, err = rc.Create(util.JSONFromInstance(Dog2{FullName: "Rex", Toys: Toys{Names: []string{}}, Comments: []Comment{}}))
+		if !errors.Is(err, ErrUniqueExists) {
+			t.Fatalf("expected the unique index to be kept, got %v", err)
+		}
+		for _, prefix := range []ds.Key{c.baseKey(), indexPrefix.Child(c.baseKey())} {
+			if n := countKeys(t, db, query.Query{Prefix: prefix.String()}); n != 0 {
+				t.Fatalf("expected no keys under %s, found %d", prefix, n)
+			}
+		}
+		stats, err := db.CollectionStats("Hound")
+		checkErr(t, err)
+		checkErr(t, db.RecalculateStats())
+		recalculated, err := db.CollectionStats("Hound")
+		checkErr(t, err)
+		if stats.Instances != 2 || stats != recalculated {
+			t.Fatalf("expected the stats to be renamed, got %+v, want %+v", stats, recalculated)
+		}
+	})
+	t.Run("OldName", func(t *testing.T) {
+		// events of the old name are applied to the renamed collection,
+		// as those of peers which didn't apply the rename yet are
+		id, err := c.Create(util.JSONFromInstance(Dog2{FullName: "Max", Toys: Toys{Names: []string{}}, Comments: []Comment{}}))
+		checkErr(t, err)
+		_, err = rc.FindByID(id)
+		checkErr(t, err)
+		res, err := rc.Find(Where("FullName").Eq("Max").UseIndex("FullName"))
+		checkErr(t, err)
+		if len(res) != 1 {
+			t.Fatalf("expected 1 result from the index, got %d", len(res))
+		}
+
+		// including after a rename of the renamed collection
+		checkErr(t, db.RenameCollection("Hound", "Wolf"))
+		db.renamed = make(map[string]string)
+		checkErr(t, db.loadRenames())
+		if db.renamed["Dog"] != "Wolf" || db.renamed["Hound"] != "Wolf" {
+			t.Fatalf("expected the old names to map to the new one, got %v", db.renamed)
+		}
+		_, err = c.Create(util.JSONFromInstance(Dog2{FullName: "Buddy", Toys: Toys{Names: []string{}}, Comments: []Comment{}}))
+		checkErr(t, err)
+		res, err = db.GetCollection("Wolf").Find(&Query{})
+		checkErr(t, err)
+		if len(res) != 4 {
+			t.Fatalf("expected 4 instances, got %d", len(res))
+		}
+
+		// until a collection of the old name is created
+		dc, err := db.NewCollection(CollectionConfig{
+			Name:   "Dog",
+			Schema: util.SchemaFromInstance(&Dog2{}, false),
+		})
+		checkErr(t, err)
+		_, err = dc.Create(util.JSONFromInstance(Dog2{FullName: "Fido", Toys: Toys{Names: []string{}}, Comments: []Comment{}}))
+		checkErr(t, err)
+		res, err = dc.Find(&Query{})
+		checkErr(t, err)
+		if len(res) != 1 {
+			t.Fatalf("expected 1 instance, got %d", len(res))
+		}
+		if has, _ := db.datastore.Has(dsRenamed.ChildString("Dog")); has {
+			t.Fatal("expected the rename of the old name to be forgotten")
+		}
+	})
+}
+
+func TestAddIndex(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	schema := util.SchemaFromInstance(&Person2{}, false)
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: schema,
+	})
+	checkErr(t, err)
+
+	t.Run("AddNameUniqueIndex", func(t *testing.T) {
+		err := c.addIndex(schema, Index{Path: "Name", Unique: false})
+		checkErr(t, err)
+	})
+	t.Run("AddAgeNonUniqueIndex", func(t *testing.T) {
+		err := c.addIndex(schema, Index{Path: "Age", Unique: false})
+		checkErr(t, err)
+	})
+	t.Run("AddNestedIndex", func(t *testing.T) {
+		err := c.addIndex(schema, Index{Path: "Toys.Favorite", Unique: false})
+		checkErr(t, err)
+	})
+	t.Run("Fail/AddIndexWithBadPath", func(t *testing.T) {
+		err := c.addIndex(schema, Index{Path: "Bad.Path", Unique: false})
+		if err == nil {
+			t.Fatal("index path should not be valid")
+		}
+	})
+	t.Run("Fail/AddIndexOnRef", func(t *testing.T) {
+		err := c.addIndex(schema, Index{Path: "Toys", Unique: false})
+		if err == nil {
+			t.Fatal("index path should not be valid")
+		}
+	})
+	t.Run("Fail/AddIndexOnBadType", func(t *testing.T) {
+		err := c.addIndex(schema, Index{Path: "Comments", Unique: false})
+		if err == nil {
+			t.Fatal("index path should not be valid")
+		}
+	})
+	t.Run("AddFullTextIndex", func(t *testing.T) {
+		err := c.addIndex(schema, Index{Path: "Toys.Favorite", FullText: true})
+		checkErr(t, err)
+	})
+	t.Run("Fail/AddFullTextIndexOnBadType", func(t *testing.T) {
+		err := c.addIndex(schema, Index{Path: "Age", FullText: true})
+		if !errors.Is(err, ErrNotIndexable) {
+			t.Fatalf("expected ErrNotIndexable, got: %v", err)
+		}
+	})
+	t.Run("Fail/AddUniqueFullTextIndex", func(t *testing.T) {
+		err := c.addIndex(schema, Index{Path: "Name", Unique: true, FullText: true})
+		if !errors.Is(err, ErrUniqueFullTextIndex) {
+			t.Fatalf("expected ErrUniqueFullTextIndex, got: %v", err)
+		}
+	})
+	t.Run("Fail/AddUniqueIndexOnNonUniquePath", func(t *testing.T) {
+		_, err := c.Create(util.JSONFromInstance(Person2{Name: "Foo", Age: 42, Toys: Toys{Favorite: "", Names: []string{}}, Comments: []Comment{}}))
+		checkErr(t, err)
+		_, err = c.Create(util.JSONFromInstance(Person2{Name: "Foo", Age: 42, Toys: Toys{Favorite: "", Names: []string{}}, Comments: []Comment{}}))
+		checkErr(t, err)
+		err = c.addIndex(schema, Index{Path: "Name", Unique: true})
+		if err == nil {
+			t.Fatal("index path should not be valid")
+		}
+	})
+}
+
+func TestUniqueIndex(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	schema := util.SchemaFromSchemaString(`{
+		"$schema": "http://json-schema.org/draft-04/schema#",
+		"properties": {
+			"_id": {"type": "string"},
+			"Name": {"type": "string"},
+			"Email": {"type": "string"}
+		},
+		"type": "object"
+	}`)
+	c, err := db.NewCollection(CollectionConfig{
+		Name:    "User",
+		Schema:  schema,
+		Indexes: []Index{{Path: "Email", Unique: true}},
+	})
+	checkErr(t, err)
+	a, err := c.Create([]byte(`{"Name": "a", "Email": "a@example.com"}`))
+	checkErr(t, err)
+	b, err := c.Create([]byte(`{"Name": "b", "Email": "b@example.com"}`))
+	checkErr(t, err)
+	assertViolation := func(t *testing.T, err error, id core.InstanceID) {
+		var violation *ErrUniqueConstraintViolation
+		if !errors.As(err, &violation) || !errors.Is(err, ErrUniqueExists) {
+			t.Fatalf("expected a unique constraint violation, got: %v", err)
+		}
+		if violation.Path != "Email" || violation.InstanceID != id {
+			t.Fatalf("expected a violation of Email by %s, got: %+v", id, violation)
+		}
+	}
+	assertCount := func(t *testing.T, expected int) {
+		count, err := c.Count(&Query{})
+		checkErr(t, err)
+		if count != expected {
+			t.Fatalf("expected %d instances, got %d", expected, count)
+		}
+	}
+
+	t.Run("Fail/Create", func(t *testing.T) {
+		_, err := c.Create([]byte(`{"Name": "c", "Email": "a@example.com"}`))
+		assertViolation(t, err, a)
+		assertCount(t, 2)
+	})
+	t.Run("Fail/Save", func(t *testing.T) {
+		err := c.Save([]byte(`{"_id": "` + b.String() + `", "Name": "b", "Email": "a@example.com"}`))
+		assertViolation(t, err, a)
+		res, err := c.Find(Where("Email").Eq("b@example.com"))
+		checkErr(t, err)
+		if len(res) != 1 {
+			t.Fatal("expected the instance not to be saved")
+		}
+	})
+	t.Run("Fail/SameTxn", func(t *testing.T) {
+		var first core.InstanceID
+		err := c.WriteTxn(func(txn *Txn) error {
+			ids, err := txn.Create(
+				[]byte(`{"Name": "c", "Email": "c@example.com"}`),
+				[]byte(`{"Name": "d", "Email": "c@example.com"}`),
+			)
+			if err != nil {
+				return err
+			}
+			first = ids[0]
+			return nil
+		})
+		assertViolation(t, err, first)
+		assertCount(t, 2)
+	})
+	t.Run("SaveSameValue", func(t *testing.T) {
+		checkErr(t, c.Save([]byte(`{"_id": "`+a.String()+`", "Name": "A", "Email": "a@example.com"}`)))
+	})
+	t.Run("MissingValue", func(t *testing.T) {
+		_, err := c.CreateMany([][]byte{[]byte(`{"Name": "e"}`), []byte(`{"Name": "f"}`)})
+		checkErr(t, err)
+	})
+	t.Run("Fail/SwapValues", func(t *testing.T) {
+		// writes are indexed in order, so the first save conflicts
+		err := c.WriteTxn(func(txn *Txn) error {
+			return txn.Save(
+				[]byte(`{"_id": "`+a.String()+`", "Name": "a", "Email": "b@example.com"}`),
+				[]byte(`{"_id": "`+b.String()+`", "Name": "b", "Email": "a@example.com"}`),
+			)
+		})
+		assertViolation(t, err, b)
+		res, err := c.Find(Where("Email").Eq("a@example.com"))
+		checkErr(t, err)
+		if len(res) != 1 || !strings.Contains(string(res[0]), a.String()) {
+			t.Fatal("expected the values not to be swapped")
+		}
+	})
+	t.Run("ChangeAndReuse", func(t *testing.T) {
+		checkErr(t, c.WriteTxn(func(txn *Txn) error {
+			if err := txn.Save([]byte(`{"_id": "` + a.String() + `", "Name": "a", "Email": "z@example.com"}`)); err != nil {
+				return err
+			}
+			return txn.Save([]byte(`{"_id": "` + b.String() + `", "Name": "b", "Email": "a@example.com"}`))
+		}))
+	})
+	t.Run("DeleteAndReuse", func(t *testing.T) {
+		checkErr(t, c.WriteTxn(func(txn *Txn) error {
+			if err := txn.Delete(b); err != nil {
+				return err
+			}
+			_, err := txn.Create([]byte(`{"Name": "g", "Email": "a@example.com"}`))
+			return err
+		}))
+		assertCount(t, 4)
+	})
+	t.Run("Fail/UpdateCollectionWithDuplicates", func(t *testing.T) {
+		c, err := db.NewCollection(CollectionConfig{Name: "Dupes", Schema: schema})
+		checkErr(t, err)
+		ids, err := c.CreateMany([][]byte{
+			[]byte(`{"Name": "a", "Email": "x@example.com"}`),
+			[]byte(`{"Name": "b", "Email": "y@example.com"}`),
+			[]byte(`{"Name": "c", "Email": "x@example.com"}`),
+		})
+		checkErr(t, err)
+		_, err = db.UpdateCollection(CollectionConfig{
+			Name:    "Dupes",
+			Schema:  schema,
+			Indexes: []Index{{Path: "Email", Unique: true}},
+		})
+		if !errors.Is(err, ErrCantCreateUniqueIndex) {
+			t.Fatalf("expected ErrCantCreateUniqueIndex, got: %v", err)
+		}
+		if !strings.Contains(err.Error(), ids[0].String()) || !strings.Contains(err.Error(), ids[2].String()) ||
+			strings.Contains(err.Error(), ids[1].String()) {
+			t.Fatalf("expected the error to list the duplicates, got: %v", err)
+		}
+	})
+}
+
+func TestEmptySchema(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	// Note the empty schema {}, this is a catch all schema
+	schema := util.SchemaFromSchemaString("{}")
+	_, err := db.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: schema,
+	})
+	if err != ErrInvalidCollectionSchema {
+		t.Fatalf("expected to throw ErrInvalidCollectionSchema error")
 	}
 }
 
@@ -620,9 +1318,25 @@ func TestDropIndex(t *testing.T) {
 	})
 }
 
-func TestCreateInstance(t *testing.T) {
+func TestBatchWrites(t *testing.T) {
 	t.Parallel()
-	t.Run("Single", func(t *testing.T) {
+	records := func(t *testing.T, d *DB) int64 {
+		info, err := d.connector.Net.GetThread(context.Background(), d.connector.ThreadID())
+		checkErr(t, err)
+		var n int64
+		for _, l := range info.Logs {
+			n += l.Head.Counter
+		}
+		return n
+	}
+	people := func(n int) [][]byte {
+		vs := make([][]byte, n)
+		for i := range vs {
+			vs[i] = util.JSONFromInstance(Person{Name: "Foo", Age: i})
+		}
+		return vs
+	}
+	t.Run("SingleRecord", func(t *testing.T) {
 		t.Parallel()
 		db, clean := createTestDB(t)
 		defer clean()
@@ -631,27 +1345,33 @@ func TestCreateInstance(t *testing.T) {
 			Schema: util.SchemaFromInstance(&Person{}, false),
 		})
 		checkErr(t, err)
+		before := records(t, db)
 
-		t.Run("WithImplicitTx", func(t *testing.T) {
-			newPerson := util.JSONFromInstance(Person{Name: "Foo", Age: 42})
-			res, err := c.Create(newPerson)
-			checkErr(t, err)
-			newPerson = util.SetJSONID(res, newPerson)
-			assertPersonInCollection(t, c, newPerson)
-		})
-		t.Run("WithTx", func(t *testing.T) {
-			newPerson := util.JSONFromInstance(Person{Name: "Foo", Age: 42})
-			var res []core.InstanceID
-			err = c.WriteTxn(func(txn *Txn) (err error) {
-				res, err = txn.Create(newPerson)
-				return
-			})
-			checkErr(t, err)
-			newPerson = util.SetJSONID(res[0], newPerson)
-			assertPersonInCollection(t, c, newPerson)
-		})
+		ids, err := c.CreateMany(people(100))
+		checkErr(t, err)
+		if n := records(t, db) - before; n != 1 {
+			t.Fatalf("expected %d record, got %d", 1, n)
+		}
+		all, err := c.Find(&Query{})
+		checkErr(t, err)
+		if len(all) != 100 {
+			t.Fatalf("expected %d instances, got %d", 100, len(all))
+		}
+		checkErr(t, c.SaveMany(all))
+		if n := records(t, db) - before; n != 2 {
+			t.Fatalf("expected %d records, got %d", 2, n)
+		}
+		checkErr(t, c.DeleteMany(ids))
+		if n := records(t, db) - before; n != 3 {
+			t.Fatalf("expected %d records, got %d", 3, n)
+		}
+		count, err := c.Count(&Query{})
+		checkErr(t, err)
+		if count != 0 {
+			t.Fatalf("expected %d instances, got %d", 0, count)
+		}
 	})
-	t.Run("Multiple", func(t *testing.T) {
+	t.Run("Fail/InvalidItems", func(t *testing.T) {
 		t.Parallel()
 		db, clean := createTestDB(t)
 		defer clean()
@@ -660,26 +1380,42 @@ func TestCreateInstance(t *testing.T) {
 			Schema: util.SchemaFromInstance(&Person{}, false),
 		})
 		checkErr(t, err)
+		vs := people(4)
+		vs[1] = []byte(`{"Name": 1}`)
+		vs[3] = []byte(`{"Age": "old"}`)
+		_, err = c.CreateMany(vs)
+		var batchErr *BatchError
+		if !errors.As(err, &batchErr) {
+			t.Fatalf("expected batch error, got %v", err)
+		}
+		if len(batchErr.Items) != 2 || batchErr.Items[0].Index != 1 || batchErr.Items[1].Index != 3 {
+			t.Fatalf("expected invalid items 1 and 3, got %v", batchErr)
+		}
+		if !errors.Is(err, ErrInvalidSchemaInstance) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidSchemaInstance, err)
+		}
+		count, err := c.Count(&Query{})
+		checkErr(t, err)
+		if count != 0 {
+			t.Fatalf("expected %d instances, got %d", 0, count)
+		}
 
-		newPerson1 := util.JSONFromInstance(Person{Name: "Foo1", Age: 42})
-		newPerson2 := util.JSONFromInstance(Person{Name: "Foo2", Age: 43})
-		var res1 []core.InstanceID
-		var res2 []core.InstanceID
-		err = c.WriteTxn(func(txn *Txn) (err error) {
-			res1, err = txn.Create(newPerson1)
-			if err != nil {
-				return
-			}
-			res2, err = txn.Create(newPerson2)
-			return
+		ids, err := c.CreateMany(people(2))
+		checkErr(t, err)
+		err = c.SaveMany([][]byte{
+			util.SetJSONID(ids[0], util.JSONFromInstance(Person{Name: "Bar"})),
+			util.SetJSONID(ids[1], []byte(`{"Name": 1}`)),
 		})
+		if !errors.As(err, &batchErr) || len(batchErr.Items) != 1 || batchErr.Items[0].Index != 1 {
+			t.Fatalf("expected invalid item 1, got %v", err)
+		}
+		res, err := c.FindByID(ids[0])
 		checkErr(t, err)
-		newPerson1 = util.SetJSONID(res1[0], newPerson1)
-		newPerson2 = util.SetJSONID(res2[0], newPerson2)
-		assertPersonInCollection(t, c, newPerson1)
-		assertPersonInCollection(t, c, newPerson2)
+		if strings.Contains(string(res), "Bar") {
+			t.Fatal("valid item of a failed batch should not be saved")
+		}
 	})
-	t.Run("WithDefinedID", func(t *testing.T) {
+	t.Run("Fail/DuplicateID", func(t *testing.T) {
 		t.Parallel()
 		db, clean := createTestDB(t)
 		defer clean()
@@ -688,39 +1424,319 @@ func TestCreateInstance(t *testing.T) {
 			Schema: util.SchemaFromInstance(&Person{}, false),
 		})
 		checkErr(t, err)
-
-		definedID := core.NewInstanceID()
-		newPerson := util.JSONFromInstance(&Person{ID: definedID, Name: "Foo1", Age: 42})
-		_, err = c.Create(newPerson)
-		checkErr(t, err)
-
-		exists, err := c.Has(definedID)
-		checkErr(t, err)
-		if !exists {
-			t.Fatal("manually defined instance ID should exist")
+		v := util.SetJSONID("foo", util.JSONFromInstance(Person{Name: "Foo"}))
+		_, err = c.CreateMany([][]byte{v, v})
+		var batchErr *BatchError
+		if !errors.As(err, &batchErr) || len(batchErr.Items) != 1 || batchErr.Items[0].Index != 1 {
+			t.Fatalf("expected invalid item 1, got %v", err)
+		}
+		if !errors.Is(err, errCantCreateExistingInstance) {
+			t.Fatalf("expected error %v, got %v", errCantCreateExistingInstance, err)
 		}
-		assertPersonInCollection(t, c, newPerson)
 	})
-	t.Run("Re-Create", func(t *testing.T) {
+	t.Run("DeleteManyMissing", func(t *testing.T) {
 		t.Parallel()
 		db, clean := createTestDB(t)
 		defer clean()
-		m, err := db.NewCollection(CollectionConfig{
+		c, err := db.NewCollection(CollectionConfig{
 			Name:   "Person",
 			Schema: util.SchemaFromInstance(&Person{}, false),
 		})
 		checkErr(t, err)
-
-		p := util.JSONFromInstance(Person{Name: "Foo1", Age: 42})
-		res, err := m.Create(p)
+		ids, err := c.CreateMany(people(2))
 		checkErr(t, err)
-		p2 := util.JSONFromInstance(Person{ID: res, Name: "Fool2", Age: 43})
-		_, err = m.Create(p2)
-		if !errors.Is(err, errCantCreateExistingInstance) {
-			t.Fatal("shouldn't create already existing instance")
+		checkErr(t, c.DeleteMany([]core.InstanceID{ids[0], "missing", ids[1]}))
+		count, err := c.Count(&Query{})
+		checkErr(t, err)
+		if count != 0 {
+			t.Fatalf("expected %d instances, got %d", 0, count)
 		}
 	})
-}
+	t.Run("SplitRecords", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t, WithNewMaxRecordSize(2048))
+		defer clean()
+		c, err := db.NewCollection(CollectionConfig{
+			Name:   "Person",
+			Schema: util.SchemaFromInstance(&Person{}, false),
+		})
+		checkErr(t, err)
+		before := records(t, db)
+		_, err = c.CreateMany(people(100))
+		checkErr(t, err)
+		if n := records(t, db) - before; n < 2 {
+			t.Fatalf("expected events to be split among records, got %d", n)
+		}
+		count, err := c.Count(&Query{})
+		checkErr(t, err)
+		if count != 100 {
+			t.Fatalf("expected %d instances, got %d", 100, count)
+		}
+	})
+	t.Run("Fail/RecordTooLarge", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t, WithNewMaxRecordSize(2048))
+		defer clean()
+		c, err := db.NewCollection(CollectionConfig{
+			Name:   "Person",
+			Schema: util.SchemaFromInstance(&Person{}, false),
+		})
+		checkErr(t, err)
+		before := records(t, db)
+		large := util.JSONFromInstance(Person{Name: strings.Repeat("Foo", 1000)})
+		_, err = c.CreateMany([][]byte{people(1)[0], large})
+		if !errors.Is(err, ErrRecordTooLarge) {
+			t.Fatalf("expected error %v, got %v", ErrRecordTooLarge, err)
+		}
+		if n := records(t, db) - before; n != 0 {
+			t.Fatalf("expected %d records, got %d", 0, n)
+		}
+		count, err := c.Count(&Query{})
+		checkErr(t, err)
+		if count != 0 {
+			t.Fatalf("expected %d instances, got %d", 0, count)
+		}
+		_, err = c.CreateMany(people(2))
+		checkErr(t, err)
+	})
+	t.Run("Fail/PartialCommit", func(t *testing.T) {
+		t.Parallel()
+		fn := &failingNet{allowed: -1}
+		db, clean := createTestDBWithNet(t, func(n app.Net) app.Net {
+			fn.Net = n
+			return fn
+		}, WithNewMaxRecordSize(2048))
+		defer clean()
+		c, err := db.NewCollection(CollectionConfig{
+			Name:   "Person",
+			Schema: util.SchemaFromInstance(&Person{}, false),
+		})
+		checkErr(t, err)
+		before := records(t, db)
+		fn.allow(1)
+		_, err = c.CreateMany(people(100))
+		var perr *PartialCommitError
+		if !errors.As(err, &perr) {
+			t.Fatalf("expected a partial commit error, got %v", err)
+		}
+		if !errors.Is(err, errCreateRecord) {
+			t.Fatalf("expected error %v, got %v", errCreateRecord, err)
+		}
+		if n := records(t, db) - before; n != 1 {
+			t.Fatalf("expected %d records, got %d", 1, n)
+		}
+		if len(perr.Applied) == 0 || len(perr.Applied) == 100 {
+			t.Fatalf("expected some of the instances to be applied, got %d", len(perr.Applied))
+		}
+		count, err := c.Count(&Query{})
+		checkErr(t, err)
+		if count != len(perr.Applied) {
+			t.Fatalf("expected %d instances, got %d", len(perr.Applied), count)
+		}
+		for _, id := range perr.Applied {
+			ok, err := c.Has(id)
+			checkErr(t, err)
+			if !ok {
+				t.Fatalf("expected instance %s to be created", id)
+			}
+		}
+	})
+}
+
+func TestCreateInstance(t *testing.T) {
+	t.Parallel()
+	t.Run("Single", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		c, err := db.NewCollection(CollectionConfig{
+			Name:   "Person",
+			Schema: util.SchemaFromInstance(&Person{}, false),
+		})
+		checkErr(t, err)
+
+		t.Run("WithImplicitTx", func(t *testing.T) {
+			newPerson := util.JSONFromInstance(Person{Name: "Foo", Age: 42})
+			res, err := c.Create(newPerson)
+			checkErr(t, err)
+			newPerson = util.SetJSONID(res, newPerson)
+			assertPersonInCollection(t, c, newPerson)
+		})
+		t.Run("WithTx", func(t *testing.T) {
+			newPerson := util.JSONFromInstance(Person{Name: "Foo", Age: 42})
+			var res []core.InstanceID
+			err = c.WriteTxn(func(txn *Txn) (err error) {
+				res, err = txn.Create(newPerson)
+				return
+			})
+			checkErr(t, err)
+			newPerson = util.SetJSONID(res[0], newPerson)
+			assertPersonInCollection(t, c, newPerson)
+		})
+	})
+	t.Run("Multiple", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		c, err := db.NewCollection(CollectionConfig{
+			Name:   "Person",
+			Schema: util.SchemaFromInstance(&Person{}, false),
+		})
+		checkErr(t, err)
+
+		newPerson1 := util.JSONFromInstance(Person{Name: "Foo1", Age: 42})
+		newPerson2 := util.JSONFromInstance(Person{Name: "Foo2", Age: 43})
+		var res1 []core.InstanceID
+		var res2 []core.InstanceID
+		err = c.WriteTxn(func(txn *Txn) (err error) {
+			res1, err = txn.Create(newPerson1)
+			if err != nil {
+				return
+			}
+			res2, err = txn.Create(newPerson2)
+			return
+		})
+		checkErr(t, err)
+		newPerson1 = util.SetJSONID(res1[0], newPerson1)
+		newPerson2 = util.SetJSONID(res2[0], newPerson2)
+		assertPersonInCollection(t, c, newPerson1)
+		assertPersonInCollection(t, c, newPerson2)
+	})
+	t.Run("WithDefinedID", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		c, err := db.NewCollection(CollectionConfig{
+			Name:   "Person",
+			Schema: util.SchemaFromInstance(&Person{}, false),
+		})
+		checkErr(t, err)
+
+		definedID := core.NewInstanceID()
+		newPerson := util.JSONFromInstance(&Person{ID: definedID, Name: "Foo1", Age: 42})
+		_, err = c.Create(newPerson)
+		checkErr(t, err)
+
+		exists, err := c.Has(definedID)
+		checkErr(t, err)
+		if !exists {
+			t.Fatal("manually defined instance ID should exist")
+		}
+		assertPersonInCollection(t, c, newPerson)
+	})
+	t.Run("Re-Create", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		m, err := db.NewCollection(CollectionConfig{
+			Name:   "Person",
+			Schema: util.SchemaFromInstance(&Person{}, false),
+		})
+		checkErr(t, err)
+
+		p := util.JSONFromInstance(Person{Name: "Foo1", Age: 42})
+		res, err := m.Create(p)
+		checkErr(t, err)
+		p2 := util.JSONFromInstance(Person{ID: res, Name: "Fool2", Age: 43})
+		_, err = m.Create(p2)
+		if !errors.Is(err, errCantCreateExistingInstance) {
+			t.Fatal("shouldn't create already existing instance")
+		}
+	})
+	t.Run("WithDefaults", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		schema := `{
+			"$ref": "#/definitions/issue",
+			"definitions": {
+				"issue": {
+					"type": "object",
+					"properties": {
+						"_id": {"type": "string"},
+						"title": {"type": "string"},
+						"status": {"type": "string", "default": "open"},
+						"labels": {"type": "array", "items": {"type": "string"}, "default": ["new"]},
+						"meta": {"$ref": "#/definitions/meta"},
+						"comments": {"type": "array", "items": {"$ref": "#/definitions/meta"}}
+					},
+					"required": ["_id", "title", "status"]
+				},
+				"meta": {
+					"type": "object",
+					"properties": {
+						"votes": {"type": "integer", "default": 0}
+					}
+				}
+			}
+		}`
+		c, err := db.NewCollection(CollectionConfig{
+			Name:           "Issue",
+			Schema:         util.SchemaFromSchemaString(schema),
+			WriteValidator: `return next.status === "open" && next.labels.length === 1`,
+			ApplyDefaults:  true,
+		})
+		checkErr(t, err)
+		if !c.GetApplyDefaults() {
+			t.Fatal("expected collection to apply defaults")
+		}
+
+		ids, err := c.CreateMany([][]byte{
+			[]byte(`{"title": "a", "meta": {}, "comments": [{}, {"votes": 2}]}`),
+			[]byte(`{"title": "b"}`),
+		})
+		checkErr(t, err)
+		type meta struct {
+			Votes *int `json:"votes"`
+		}
+		type issue struct {
+			Status   string   `json:"status"`
+			Labels   []string `json:"labels"`
+			Meta     *meta    `json:"meta"`
+			Comments []meta   `json:"comments"`
+		}
+		var issues []issue
+		for _, id := range ids {
+			v, err := c.FindByID(id)
+			checkErr(t, err)
+			var i issue
+			checkErr(t, json.Unmarshal(v, &i))
+			issues = append(issues, i)
+		}
+		if issues[0].Status != "open" || !reflect.DeepEqual(issues[0].Labels, []string{"new"}) {
+			t.Fatalf("expected top-level defaults, got %v", issues[0])
+		}
+		if issues[0].Meta == nil || issues[0].Meta.Votes == nil || *issues[0].Meta.Votes != 0 {
+			t.Fatalf("expected nested default, got %v", issues[0].Meta)
+		}
+		if len(issues[0].Comments) != 2 || *issues[0].Comments[0].Votes != 0 || *issues[0].Comments[1].Votes != 2 {
+			t.Fatalf("expected defaults of array items, got %v", issues[0].Comments)
+		}
+		if issues[1].Status != "open" || issues[1].Meta != nil || issues[1].Comments != nil {
+			t.Fatalf("expected only properties with defaults to be set, got %v", issues[1])
+		}
+
+		// the write validator sees explicit values
+		if _, err := c.Create([]byte(`{"title": "c", "status": "closed"}`)); err == nil {
+			t.Fatalf("expected explicit values to be kept, got %v", err)
+		}
+
+		plain, err := db.NewCollection(CollectionConfig{
+			Name:   "PlainIssue",
+			Schema: util.SchemaFromSchemaString(schema),
+		})
+		checkErr(t, err)
+		if _, err := plain.Create([]byte(`{"title": "a"}`)); !errors.Is(err, ErrInvalidSchemaInstance) {
+			t.Fatalf("expected collections which don't opt in not to apply defaults, got %v", err)
+		}
+
+		db.collections = make(map[string]*Collection)
+		checkErr(t, db.reCreateCollections())
+		if !db.GetCollection("Issue").GetApplyDefaults() || db.GetCollection("PlainIssue").GetApplyDefaults() {
+			t.Fatal("expected applying defaults to be loaded from the collection metadata")
+		}
+	})
+}
 
 func TestReadTxnValidation(t *testing.T) {
 	t.Parallel()
@@ -782,6 +1798,83 @@ func TestReadTxnValidation(t *testing.T) {
 	})
 }
 
+func TestReadTxnSnapshot(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:    "Person",
+		Schema:  util.SchemaFromInstance(&Person{}, false),
+		Indexes: []Index{{Path: "Age"}},
+	})
+	checkErr(t, err)
+	alice := util.JSONFromInstance(Person{Name: "Alice", Age: 42})
+	id, err := c.Create(alice)
+	checkErr(t, err)
+	alice = util.SetJSONID(id, alice)
+
+	var bob core.InstanceID
+	err = c.ReadTxn(func(txn *Txn) error {
+		// the writes commit while the read transaction is open
+		done := make(chan error)
+		go func() {
+			var err error
+			if bob, err = c.Create(util.JSONFromInstance(Person{Name: "Bob", Age: 24})); err != nil {
+				done <- err
+				return
+			}
+			done <- c.Save(util.SetJSONProperty("Age", 43, alice))
+		}()
+		select {
+		case err := <-done:
+			checkErr(t, err)
+		case <-time.After(10 * time.Second):
+			t.Fatal("expected writes not to block on the read transaction")
+		}
+
+		instance, err := txn.FindByID(id)
+		checkErr(t, err)
+		p := &Person{}
+		util.InstanceFromJSON(instance, p)
+		if p.Age != 42 {
+			t.Fatalf("expected instance from snapshot with age 42, got %d", p.Age)
+		}
+		if exists, err := txn.Has(bob); err != nil || exists {
+			t.Fatalf("expected instance created after snapshot not to exist, got %v (%v)", exists, err)
+		}
+		res, err := txn.Find(&Query{})
+		checkErr(t, err)
+		if len(res) != 1 {
+			t.Fatalf("expected 1 instance in snapshot, got %d", len(res))
+		}
+		res, err = txn.Find(Where("Age").Eq(42).UseIndex("Age"))
+		checkErr(t, err)
+		if len(res) != 1 {
+			t.Fatalf("expected 1 instance from the index in snapshot, got %d", len(res))
+		}
+		count, err := txn.Count(&Query{})
+		checkErr(t, err)
+		if count != 1 {
+			t.Fatalf("expected count 1 in snapshot, got %d", count)
+		}
+		return nil
+	})
+	checkErr(t, err)
+
+	res, err := c.Find(&Query{})
+	checkErr(t, err)
+	if len(res) != 2 {
+		t.Fatalf("expected 2 instances after read transaction, got %d", len(res))
+	}
+	instance, err := c.FindByID(id)
+	checkErr(t, err)
+	p := &Person{}
+	util.InstanceFromJSON(instance, p)
+	if p.Age != 43 {
+		t.Fatalf("expected saved instance with age 43, got %d", p.Age)
+	}
+}
+
 func TestVariadic(t *testing.T) {
 	t.Parallel()
 
@@ -907,6 +2000,43 @@ func TestGetInstance(t *testing.T) {
 			t.Fatalf(errInvalidInstanceState)
 		}
 	})
+	t.Run("Many", func(t *testing.T) {
+		other, err := c.Create(util.JSONFromInstance(Person{Name: "Bar", Age: 21}))
+		checkErr(t, err)
+		ids := []core.InstanceID{other, core.NewInstanceID(), res[0]}
+		check := func(found [][]byte) {
+			t.Helper()
+			if len(found) != len(ids) {
+				t.Fatalf("expected %d results, got %d", len(ids), len(found))
+			}
+			if found[1] != nil {
+				t.Fatalf("expected missing instance to be nil, got %s", found[1])
+			}
+			for i, name := range map[int]string{0: "Bar", 2: "Foo"} {
+				p := &Person{}
+				util.InstanceFromJSON(found[i], p)
+				if p.ID != ids[i] || p.Name != name {
+					t.Fatalf("expected instance %s at %d, got %+v", ids[i], i, p)
+				}
+			}
+		}
+
+		found, err := c.FindByIDs(ids)
+		checkErr(t, err)
+		check(found)
+		err = c.ReadTxn(func(txn *Txn) (err error) {
+			found, err = txn.FindByIDs(ids)
+			return
+		})
+		checkErr(t, err)
+		check(found)
+		err = c.WriteTxn(func(txn *Txn) (err error) {
+			found, err = txn.FindByIDs(ids)
+			return
+		})
+		checkErr(t, err)
+		check(found)
+	})
 }
 
 func TestModifiedSince(t *testing.T) {
@@ -1256,6 +2386,81 @@ func TestModTagIncrement(t *testing.T) {
 	})
 }
 
+func TestSaveExpecting(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+	id, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 42}))
+	checkErr(t, err)
+	find := func(t *testing.T) *Person {
+		instance, err := c.FindByID(id)
+		checkErr(t, err)
+		p := &Person{}
+		util.InstanceFromJSON(instance, p)
+		return p
+	}
+
+	// two clients read the same version
+	first, second := find(t), find(t)
+	first.Age = 43
+	checkErr(t, c.SaveExpecting(util.JSONFromInstance(first), first.Mod))
+	saved := find(t)
+	if saved.Age != 43 || saved.Mod == first.Mod {
+		t.Fatalf("expected saved instance with a new version, got %v", saved)
+	}
+
+	second.Name = "Alicia"
+	err = c.SaveExpecting(util.JSONFromInstance(second), second.Mod)
+	var stale *StaleInstanceError
+	if !errors.As(err, &stale) || !errors.Is(err, ErrStaleInstance) {
+		t.Fatalf("expected error %v, got %v", ErrStaleInstance, err)
+	}
+	current := &Person{}
+	util.InstanceFromJSON(stale.Current, current)
+	if stale.InstanceID != id || stale.Version != saved.Mod || current.Age != 43 {
+		t.Fatalf("expected the stored instance in the error, got %v", stale)
+	}
+	if p := find(t); p.Name != "Alice" {
+		t.Fatalf("expected stale save to be rejected, got %v", p)
+	}
+
+	// merged into the current instance
+	current.Name = "Alicia"
+	checkErr(t, c.SaveExpecting(util.JSONFromInstance(current), stale.Version))
+	if p := find(t); p.Name != "Alicia" || p.Age != 43 {
+		t.Fatalf("expected merged instance to be saved, got %v", p)
+	}
+
+	t.Run("New", func(t *testing.T) {
+		bob := Person{ID: core.NewInstanceID(), Name: "Bob", Age: 24}
+		checkErr(t, c.SaveExpecting(util.JSONFromInstance(bob), 0))
+		err := c.SaveExpecting(util.JSONFromInstance(bob), 0)
+		if !errors.Is(err, ErrStaleInstance) {
+			t.Fatalf("expected error %v, got %v", ErrStaleInstance, err)
+		}
+		carol := Person{ID: core.NewInstanceID(), Name: "Carol"}
+		if err := c.SaveExpecting(util.JSONFromInstance(carol), 1); !errors.As(err, &stale) || stale.Current != nil {
+			t.Fatalf("expected error %v without a current instance, got %v", ErrStaleInstance, err)
+		}
+	})
+	t.Run("ClockBehind", func(t *testing.T) {
+		// the instance was last written by a peer with a clock ahead
+		p := find(t)
+		p.Mod = time.Now().Add(time.Hour).UnixNano()
+		checkErr(t, db.datastore.Put(c.baseKey().ChildString(id.String()), util.JSONFromInstance(p)))
+		p.Age = 44
+		checkErr(t, c.SaveExpecting(util.JSONFromInstance(p), p.Mod))
+		if saved := find(t); saved.Age != 44 || saved.Mod <= p.Mod {
+			t.Fatalf("expected a version after %d, got %v", p.Mod, saved)
+		}
+	})
+}
+
 func TestDeleteInstance(t *testing.T) {
 	t.Parallel()
 	db, clean := createTestDB(t)
@@ -1291,10 +2496,278 @@ func TestDeleteInstance(t *testing.T) {
 	}
 }
 
-type PersonFake struct {
-	ID   core.InstanceID `json:"_id"`
-	Name string
-}
+func TestHooks(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+
+	errRejected := errors.New("rejected")
+	// the age of persons is the length of their name, unless it's given
+	derive := func(instance []byte) ([]byte, error) {
+		var p Person
+		util.InstanceFromJSON(instance, &p)
+		if p.Name == "Mallory" {
+			return nil, errRejected
+		}
+		if p.Age == 0 {
+			p.Age = len(p.Name)
+		}
+		return util.JSONFromInstance(p), nil
+	}
+	type committed struct {
+		hook string
+		p    Person
+	}
+	after := make(chan committed, 10)
+	afterHook := func(hook string) func([]byte) {
+		return func(instance []byte) {
+			var p Person
+			util.InstanceFromJSON(instance, &p)
+			after <- committed{hook: hook, p: p}
+		}
+	}
+	hooks := Hooks{
+		BeforeCreate: derive,
+		BeforeSave:   derive,
+		BeforeDelete: func(id core.InstanceID) error {
+			if id == "protected" {
+				return errRejected
+			}
+			return nil
+		},
+		AfterCreate: afterHook("create"),
+		AfterSave:   afterHook("save"),
+		AfterDelete: afterHook("delete"),
+	}
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+		Hooks:  hooks,
+	})
+	checkErr(t, err)
+	receive := func(t *testing.T, hook string, name string, age int) {
+		select {
+		case a := <-after:
+			if a.hook != hook || a.p.Name != name || a.p.Age != age {
+				t.Fatalf("expected the %s hook of %s aged %d, got %+v", hook, name, age, a)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected the %s hook of %s to run", hook, name)
+		}
+	}
+
+	ids, err := c.CreateMany([][]byte{
+		util.JSONFromInstance(Person{Name: "Alice"}),
+		util.JSONFromInstance(Person{ID: "protected", Name: "Bob", Age: 30}),
+	})
+	checkErr(t, err)
+	receive(t, "create", "Alice", 5)
+	receive(t, "create", "Bob", 30)
+	var p Person
+	v, err := c.FindByID(ids[0])
+	checkErr(t, err)
+	util.InstanceFromJSON(v, &p)
+	if p.Age != 5 {
+		t.Fatalf("expected the created instance to be changed by the hook, got age %d", p.Age)
+	}
+
+	p.Name, p.Age = "Alicia", 0
+	checkErr(t, c.Save(util.JSONFromInstance(p)))
+	receive(t, "save", "Alicia", 6)
+	v, err = c.FindByID(ids[0])
+	checkErr(t, err)
+	util.InstanceFromJSON(v, &p)
+	if p.Age != 6 {
+		t.Fatalf("expected the saved instance to be changed by the hook, got age %d", p.Age)
+	}
+
+	if _, err := c.Create(util.JSONFromInstance(Person{Name: "Mallory"})); !errors.Is(err, errRejected) {
+		t.Fatalf("expected the hook to abort the create, got: %v", err)
+	}
+	if err := c.Delete("protected"); !errors.Is(err, errRejected) {
+		t.Fatalf("expected the hook to abort the delete, got: %v", err)
+	}
+	if ok, err := c.Has("protected"); err != nil || !ok {
+		t.Fatal("expected the instance not to be deleted")
+	}
+	checkErr(t, c.Delete(ids[0]))
+	receive(t, "delete", "Alicia", 6)
+
+	// hooks can't change IDs, and the instances they return are validated
+	c.SetHooks(Hooks{
+		BeforeCreate: func(instance []byte) ([]byte, error) {
+			return []byte(`{"_id": "other"}`), nil
+		},
+		BeforeSave: func(instance []byte) ([]byte, error) {
+			return []byte(`{"_id": "protected", "Age": "old"}`), nil
+		},
+	})
+	if _, err := c.Create(util.JSONFromInstance(Person{Name: "Carol"})); !errors.Is(err, ErrHookChangedID) {
+		t.Fatalf("expected the hook not to change the ID, got: %v", err)
+	}
+	if err := c.Save(util.JSONFromInstance(Person{ID: "protected"})); !errors.Is(err, ErrInvalidSchemaInstance) {
+		t.Fatalf("expected the instance of the hook to be validated, got: %v", err)
+	}
+
+	// hooks aren't saved with the collection
+	db.collections = make(map[string]*Collection)
+	checkErr(t, db.reCreateCollections())
+	c = db.GetCollection("Person")
+	if _, err := c.Create(util.JSONFromInstance(Person{Name: "Mallory"})); err != nil {
+		t.Fatalf("expected no hooks once the collection is loaded, got: %v", err)
+	}
+	select {
+	case a := <-after:
+		t.Fatalf("expected no hook to run, got %+v", a)
+	case <-time.After(100 * time.Millisecond):
+	}
+	c.SetHooks(hooks)
+	if _, err := c.Create(util.JSONFromInstance(Person{Name: "Mallory"})); !errors.Is(err, errRejected) {
+		t.Fatalf("expected the hook to abort the create, got: %v", err)
+	}
+}
+
+func TestSoftDelete(t *testing.T) {
+	t.Parallel()
+	setup := func(t *testing.T) (*DB, *Collection, []core.InstanceID, func()) {
+		db, clean := createTestDB(t)
+		c, err := db.NewCollection(CollectionConfig{
+			Name:       "Person",
+			Schema:     util.SchemaFromInstance(&Person{}, false),
+			SoftDelete: true,
+		})
+		checkErr(t, err)
+		ids, err := c.CreateMany([][]byte{
+			util.JSONFromInstance(Person{Name: "Alice", Age: 42}),
+			util.JSONFromInstance(Person{Name: "Bob", Age: 24}),
+		})
+		checkErr(t, err)
+		return db, c, ids, clean
+	}
+	assertVisible := func(t *testing.T, c *Collection, id core.InstanceID, visible bool) {
+		_, err := c.FindByID(id)
+		if visible {
+			checkErr(t, err)
+		} else if !errors.Is(err, ErrInstanceNotFound) {
+			t.Fatalf("expected error %v, got %v", ErrInstanceNotFound, err)
+		}
+		if exists, err := c.Has(id); err != nil || exists != visible {
+			t.Fatalf("expected instance to exist %v, got %v (%v)", visible, exists, err)
+		}
+	}
+	assertCount := func(t *testing.T, c *Collection, q *Query, n int) {
+		res, err := c.Find(q)
+		checkErr(t, err)
+		if len(res) != n {
+			t.Fatalf("expected %d instances, got %d", n, len(res))
+		}
+		count, err := c.Count(q)
+		checkErr(t, err)
+		if count != n {
+			t.Fatalf("expected count %d, got %d", n, count)
+		}
+	}
+	t.Run("DeleteAndRestore", func(t *testing.T) {
+		t.Parallel()
+		db, c, ids, clean := setup(t)
+		defer clean()
+		l, err := db.Listen(ListenOption{Type: ListenAll})
+		checkErr(t, err)
+		defer l.Close()
+
+		checkErr(t, c.Delete(ids[0]))
+		assertVisible(t, c, ids[0], false)
+		assertVisible(t, c, ids[1], true)
+		assertCount(t, c, &Query{}, 1)
+		assertCount(t, c, Where("Age").Eq(float64(42)), 0)
+		assertCount(t, c, (&Query{}).WithDeleted(), 2)
+		assertCount(t, c, Where("Age").Eq(float64(42)).WithDeleted(), 1)
+		select {
+		case a := <-l.Channel():
+			if a.Type != ActionDelete || a.ID != ids[0] {
+				t.Fatalf("expected delete action of %s, got %v", ids[0], a)
+			}
+		default:
+			t.Fatal("deleting should be notified")
+		}
+
+		// Saving fails until the instance is restored
+		saved := util.SetJSONID(ids[0], util.JSONFromInstance(Person{Name: "Alice", Age: 43}))
+		if err := c.Save(saved); !errors.Is(err, ErrInstanceDeleted) {
+			t.Fatalf("expected error %v, got %v", ErrInstanceDeleted, err)
+		}
+		assertVisible(t, c, ids[0], false)
+		select {
+		case a := <-l.Channel():
+			t.Fatalf("failed save should not be notified, got %v", a)
+		default:
+		}
+
+		assertSaved := func() {
+			select {
+			case a := <-l.Channel():
+				if a.Type != ActionSave || a.ID != ids[0] {
+					t.Fatalf("expected save action of %s, got %v", ids[0], a)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("restoring and saving should be notified")
+			}
+		}
+		checkErr(t, c.Restore(ids[0]))
+		assertSaved()
+		assertVisible(t, c, ids[0], true)
+		res, err := c.FindByID(ids[0])
+		checkErr(t, err)
+		if strings.Contains(string(res), deletedFieldName) {
+			t.Fatal("restored instance should not have a tombstone")
+		}
+		if !strings.Contains(string(res), `"Age":42`) {
+			t.Fatalf("restored instance should be as deleted, got %s", res)
+		}
+		assertCount(t, c, &Query{}, 2)
+		checkErr(t, c.Save(saved))
+		assertSaved()
+	})
+	t.Run("Purge", func(t *testing.T) {
+		t.Parallel()
+		_, c, ids, clean := setup(t)
+		defer clean()
+		checkErr(t, c.Delete(ids[0]))
+		checkErr(t, c.Purge(time.Hour))
+		assertCount(t, c, (&Query{}).WithDeleted(), 2)
+		checkErr(t, c.Purge(0))
+		assertCount(t, c, (&Query{}).WithDeleted(), 1)
+		if err := c.Restore(ids[0]); !errors.Is(err, ErrInstanceNotFound) {
+			t.Fatalf("expected error %v, got %v", ErrInstanceNotFound, err)
+		}
+		assertVisible(t, c, ids[1], true)
+	})
+	t.Run("Config", func(t *testing.T) {
+		t.Parallel()
+		db, c, ids, clean := setup(t)
+		defer clean()
+		checkErr(t, c.Delete(ids[0]))
+		checkErr(t, db.reCreateCollections())
+		c = db.GetCollection("Person")
+		if !c.GetSoftDelete() {
+			t.Fatal("collection should soft delete instances")
+		}
+		assertVisible(t, c, ids[0], false)
+
+		c, err := db.UpdateCollection(CollectionConfig{
+			Name:   "Person",
+			Schema: util.SchemaFromInstance(&Person{}, false),
+		})
+		checkErr(t, err)
+		checkErr(t, c.Delete(ids[1]))
+		assertCount(t, c, (&Query{}).WithDeleted(), 1)
+	})
+}
+
+type PersonFake struct {
+	ID   core.InstanceID `json:"_id"`
+	Name string
+}
 
 func TestInvalidActions(t *testing.T) {
 	t.Parallel()
@@ -1322,6 +2795,164 @@ func TestInvalidActions(t *testing.T) {
 	})
 }
 
+type Session struct {
+	ID      core.InstanceID `json:"_id"`
+	Mod     int64           `json:"_mod"`
+	Name    string          `json:"name"`
+	Expires float64         `json:"expires,omitempty"`
+}
+
+func TestExpiry(t *testing.T) {
+	t.Parallel()
+	setup := func(t *testing.T) (*DB, *Collection, *time.Time, []core.InstanceID, func()) {
+		now := time.Unix(1000, 0)
+		db, clean := createTestDB(t,
+			WithNewExpirySweepInterval(time.Hour),
+			WithNewExpiryGracePeriod(time.Minute),
+			func(o *NewOptions) { o.clock = func() time.Time { return now } },
+		)
+		c, err := db.NewCollection(CollectionConfig{
+			Name:        "Session",
+			Schema:      util.SchemaFromInstance(&Session{}, false),
+			ExpiryField: "expires",
+		})
+		checkErr(t, err)
+		ids, err := c.CreateMany([][]byte{
+			util.JSONFromInstance(Session{Name: "short", Expires: 1010.5}),
+			util.JSONFromInstance(Session{Name: "long", Expires: 2000}),
+			util.JSONFromInstance(Session{Name: "forever"}),
+		})
+		checkErr(t, err)
+		return db, c, &now, ids, clean
+	}
+	assertVisible := func(t *testing.T, c *Collection, id core.InstanceID, visible bool) {
+		_, err := c.FindByID(id)
+		if visible {
+			checkErr(t, err)
+		} else if !errors.Is(err, ErrInstanceNotFound) {
+			t.Fatalf("expected error %v, got %v", ErrInstanceNotFound, err)
+		}
+		if exists, err := c.Has(id); err != nil || exists != visible {
+			t.Fatalf("expected instance to exist %v, got %v (%v)", visible, exists, err)
+		}
+	}
+	assertCount := func(t *testing.T, c *Collection, n int) {
+		res, err := c.Find(&Query{})
+		checkErr(t, err)
+		if len(res) != n {
+			t.Fatalf("expected %d instances, got %d", n, len(res))
+		}
+		count, err := c.Count(&Query{})
+		checkErr(t, err)
+		if count != n {
+			t.Fatalf("expected count %d, got %d", n, count)
+		}
+	}
+	t.Run("Hidden", func(t *testing.T) {
+		t.Parallel()
+		_, c, now, ids, clean := setup(t)
+		defer clean()
+		assertCount(t, c, 3)
+
+		*now = time.Unix(1010, 0)
+		assertVisible(t, c, ids[0], true)
+		*now = time.Unix(1010, int64(time.Second/2))
+		assertVisible(t, c, ids[0], false)
+		assertVisible(t, c, ids[1], true)
+		assertVisible(t, c, ids[2], true)
+		assertCount(t, c, 2)
+
+		*now = time.Unix(3000, 0)
+		assertCount(t, c, 1)
+	})
+	t.Run("FarOff", func(t *testing.T) {
+		t.Parallel()
+		_, c, now, _, clean := setup(t)
+		defer clean()
+		ids, err := c.CreateMany([][]byte{
+			util.JSONFromInstance(Session{Name: "future", Expires: 1e10}),
+			util.JSONFromInstance(Session{Name: "far future", Expires: 1e300}),
+			util.JSONFromInstance(Session{Name: "far past", Expires: -1e300}),
+		})
+		checkErr(t, err)
+
+		*now = time.Unix(3000, 0)
+		assertVisible(t, c, ids[0], true)
+		assertVisible(t, c, ids[1], true)
+		assertVisible(t, c, ids[2], false)
+	})
+	t.Run("Sweep", func(t *testing.T) {
+		t.Parallel()
+		db, c, now, ids, clean := setup(t)
+		defer clean()
+		info, err := db.connector.Net.GetThread(context.Background(), db.connector.ThreadID())
+		checkErr(t, err)
+		l, err := db.Listen(ListenOption{Type: ListenDelete})
+		checkErr(t, err)
+		defer l.Close()
+
+		// Expired, but within the grace period
+		*now = time.Unix(1050, 0)
+		checkErr(t, db.sweepExpired())
+		exists := func(id core.InstanceID) bool {
+			ok, err := db.datastore.Has(c.baseKey().ChildString(id.String()))
+			checkErr(t, err)
+			return ok
+		}
+		if !exists(ids[0]) {
+			t.Fatal("instance shouldn't be swept within the grace period")
+		}
+
+		*now = time.Unix(1100, 0)
+		checkErr(t, db.sweepExpired())
+		if exists(ids[0]) {
+			t.Fatal("expired instance should be swept")
+		}
+		if !exists(ids[1]) || !exists(ids[2]) {
+			t.Fatal("unexpired instances shouldn't be swept")
+		}
+		select {
+		case a := <-l.Channel():
+			if a.Type != ActionDelete || a.ID != ids[0] {
+				t.Fatalf("unexpected action %v", a)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected delete action")
+		}
+
+		// Sweeping doesn't write events
+		after, err := db.connector.Net.GetThread(context.Background(), db.connector.ThreadID())
+		checkErr(t, err)
+		for i, lg := range after.Logs {
+			if lg.Head.Counter != info.Logs[i].Head.Counter {
+				t.Fatal("sweeping shouldn't write records")
+			}
+		}
+	})
+	t.Run("Persisted", func(t *testing.T) {
+		t.Parallel()
+		db, c, _, _, clean := setup(t)
+		defer clean()
+		checkErr(t, db.reCreateCollections())
+		if f := db.GetCollection(c.GetName()).GetExpiryField(); f != "expires" {
+			t.Fatalf("expected expiry field expires, got %s", f)
+		}
+	})
+	t.Run("Fail/InvalidField", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		_, err := db.NewCollection(CollectionConfig{
+			Name:        "Session",
+			Schema:      util.SchemaFromInstance(&Session{}, false),
+			ExpiryField: "name",
+		})
+		if !errors.Is(err, ErrInvalidExpiryField) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidExpiryField, err)
+		}
+	})
+}
+
 func assertPersonInCollection(t *testing.T, c *Collection, personBytes []byte) {
 	t.Helper()
 	person := &Person{}
@@ -1336,3 +2967,384 @@ func assertPersonInCollection(t *testing.T, c *Collection, personBytes []byte) {
 		t.Fatalf(errInvalidInstanceState)
 	}
 }
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	setup := func(t *testing.T) (*DB, *Collection, []core.InstanceID, func()) {
+		db, clean := createTestDB(t)
+		c, err := db.NewCollection(CollectionConfig{
+			Name:    "Person",
+			Schema:  util.SchemaFromInstance(&Person{}, false),
+			Indexes: []Index{{Path: "Name", Unique: true}},
+		})
+		checkErr(t, err)
+		ids, err := c.CreateMany([][]byte{
+			util.JSONFromInstance(Person{Name: "alice", Age: 30}),
+			util.JSONFromInstance(Person{Name: "bob", Age: 40}),
+		})
+		checkErr(t, err)
+		return db, c, ids, clean
+	}
+	t.Run("Valid", func(t *testing.T) {
+		t.Parallel()
+		_, c, _, clean := setup(t)
+		defer clean()
+		errs, err := c.Validate(context.Background(), 0)
+		checkErr(t, err)
+		if len(errs) != 0 {
+			t.Fatalf("expected no validation errors, got %v", errs)
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+		db, c, ids, clean := setup(t)
+		defer clean()
+		// written around the collection, so it's neither validated nor indexed
+		raw := []byte(`{"_id":"zzz","_mod":0,"Name":"alice","Age":"old"}`)
+		checkErr(t, db.datastore.Put(c.baseKey().ChildString("zzz"), raw))
+
+		errs, err := c.Validate(context.Background(), 0)
+		checkErr(t, err)
+		expected := []ValidationError{
+			{InstanceID: "zzz", Field: "/Age", Message: "Invalid type. Expected: integer, given: string"},
+			{InstanceID: "zzz", Field: "/Name", Message: fmt.Sprintf("value of unique index Name is also set by instance %s", ids[0])},
+			{InstanceID: "zzz", Field: "/Name", Message: "instance isn't in index Name, which must be rebuilt"},
+		}
+		if !reflect.DeepEqual(errs, expected) {
+			t.Fatalf("expected validation errors %v, got %v", expected, errs)
+		}
+
+		errs, err = c.Validate(context.Background(), 2)
+		checkErr(t, err)
+		if !reflect.DeepEqual(errs, expected[:2]) {
+			t.Fatalf("expected validation errors %v, got %v", expected[:2], errs)
+		}
+	})
+	t.Run("Unindexed", func(t *testing.T) {
+		t.Parallel()
+		db, _, ids, clean := setup(t)
+		defer clean()
+		c, err := db.UpdateCollection(CollectionConfig{
+			Name:    "Person",
+			Schema:  util.SchemaFromInstance(&Person{}, false),
+			Indexes: []Index{{Path: "Name", Unique: true}, {Path: "Age"}},
+		})
+		checkErr(t, err)
+		errs, err := c.Validate(context.Background(), 0)
+		checkErr(t, err)
+		if len(errs) != 2 {
+			t.Fatalf("expected 2 validation errors, got %v", errs)
+		}
+		for _, e := range errs {
+			if (e.InstanceID != ids[0] && e.InstanceID != ids[1]) || e.Field != "/Age" {
+				t.Fatalf("expected instance to be unindexed at /Age, got %v", e)
+			}
+		}
+		if errs[0].InstanceID == errs[1].InstanceID {
+			t.Fatalf("expected both instances to be unindexed, got %v", errs)
+		}
+	})
+	t.Run("Canceled", func(t *testing.T) {
+		t.Parallel()
+		_, c, _, clean := setup(t)
+		defer clean()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := c.Validate(ctx, 0); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected error %v, got %v", context.Canceled, err)
+		}
+	})
+}
+
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+	setup := func(t *testing.T) (*Collection, func()) {
+		db, clean := createTestDB(t)
+		c, err := db.NewCollection(CollectionConfig{
+			Name:   "Person",
+			Schema: util.SchemaFromInstance(&Person{}, false),
+		})
+		checkErr(t, err)
+		return c, clean
+	}
+	export := func(t *testing.T) ([]core.InstanceID, []byte) {
+		c, clean := setup(t)
+		defer clean()
+		ids, err := c.CreateMany([][]byte{
+			util.JSONFromInstance(Person{Name: "Alice", Age: 42}),
+			util.JSONFromInstance(Person{Name: "Bob", Age: 24}),
+			util.JSONFromInstance(Person{Name: "Carol", Age: 33}),
+		})
+		checkErr(t, err)
+		var buf bytes.Buffer
+		checkErr(t, c.Export(context.Background(), &buf))
+		return ids, buf.Bytes()
+	}
+	assertCount := func(t *testing.T, c *Collection, n int) {
+		count, err := c.Count(&Query{})
+		checkErr(t, err)
+		if count != n {
+			t.Fatalf("expected count %d, got %d", n, count)
+		}
+	}
+	t.Run("Export", func(t *testing.T) {
+		t.Parallel()
+		ids, exported := export(t)
+		lines := strings.Split(strings.TrimSuffix(string(exported), "\n"), "\n")
+		if len(lines) != len(ids) {
+			t.Fatalf("expected %d lines, got %d", len(ids), len(lines))
+		}
+		for _, l := range lines {
+			p := &Person{}
+			checkErr(t, json.Unmarshal([]byte(l), p))
+			if p.ID == core.EmptyInstanceID {
+				t.Fatalf("expected exported instance to have an id, got %s", l)
+			}
+		}
+	})
+	t.Run("Import", func(t *testing.T) {
+		t.Parallel()
+		ids, exported := export(t)
+		c, clean := setup(t)
+		defer clean()
+		var progress []ImportResult
+		res, err := c.Import(context.Background(), bytes.NewReader(exported), ImportOptions{
+			BatchSize: 2,
+			Progress:  func(r ImportResult) { progress = append(progress, r) },
+		})
+		checkErr(t, err)
+		if res != (ImportResult{Created: 3}) {
+			t.Fatalf("expected 3 instances to be created, got %v", res)
+		}
+		expected := []ImportResult{{Created: 2}, {Created: 3}}
+		if !reflect.DeepEqual(progress, expected) {
+			t.Fatalf("expected progress %v, got %v", expected, progress)
+		}
+		for _, id := range ids {
+			_, err := c.FindByID(id)
+			checkErr(t, err)
+		}
+	})
+	t.Run("Conflict", func(t *testing.T) {
+		t.Parallel()
+		ids, exported := export(t)
+		c, clean := setup(t)
+		defer clean()
+		_, err := c.Import(context.Background(), bytes.NewReader(exported), ImportOptions{})
+		checkErr(t, err)
+
+		changed := util.JSONFromInstance(Person{ID: ids[0], Name: "Alicia", Age: 43})
+		input := append(changed, []byte("\n{\"_mod\": 0, \"Name\": \"Dave\", \"Age\": 50}\n")...)
+		res, err := c.Import(context.Background(), bytes.NewReader(input), ImportOptions{})
+		checkErr(t, err)
+		if res != (ImportResult{Created: 1, Skipped: 1}) {
+			t.Fatalf("expected 1 instance to be created and 1 skipped, got %v", res)
+		}
+		p := &Person{}
+		instance, err := c.FindByID(ids[0])
+		checkErr(t, err)
+		util.InstanceFromJSON(instance, p)
+		if p.Name != "Alice" {
+			t.Fatalf("expected skipped instance to be unchanged, got %v", p)
+		}
+
+		res, err = c.Import(context.Background(), bytes.NewReader(changed), ImportOptions{Overwrite: true})
+		checkErr(t, err)
+		if res != (ImportResult{Updated: 1}) {
+			t.Fatalf("expected 1 instance to be updated, got %v", res)
+		}
+		instance, err = c.FindByID(ids[0])
+		checkErr(t, err)
+		util.InstanceFromJSON(instance, p)
+		if p.Name != "Alicia" || p.Age != 43 {
+			t.Fatalf("expected instance to be overwritten, got %v", p)
+		}
+		assertCount(t, c, 4)
+	})
+	t.Run("DryRun", func(t *testing.T) {
+		t.Parallel()
+		_, exported := export(t)
+		c, clean := setup(t)
+		defer clean()
+		res, err := c.Import(context.Background(), bytes.NewReader(exported), ImportOptions{DryRun: true})
+		checkErr(t, err)
+		if res != (ImportResult{Created: 3}) {
+			t.Fatalf("expected 3 instances to be created, got %v", res)
+		}
+		assertCount(t, c, 0)
+	})
+	t.Run("Fail", func(t *testing.T) {
+		t.Parallel()
+		t.Run("InvalidInstance", func(t *testing.T) {
+			t.Parallel()
+			_, exported := export(t)
+			c, clean := setup(t)
+			defer clean()
+			input := append(exported, []byte(`{"_mod": 0, "Name": "Dave", "Age": "old"}`+"\n")...)
+			_, err := c.Import(context.Background(), bytes.NewReader(input), ImportOptions{BatchSize: 2})
+			if !errors.Is(err, ErrInvalidSchemaInstance) || !strings.HasPrefix(err.Error(), "line 4:") {
+				t.Fatalf("expected error %v on line 4, got %v", ErrInvalidSchemaInstance, err)
+			}
+			// the first batch was written
+			assertCount(t, c, 2)
+		})
+		t.Run("Duplicate", func(t *testing.T) {
+			t.Parallel()
+			_, exported := export(t)
+			c, clean := setup(t)
+			defer clean()
+			input := append(exported, exported...)
+			if _, err := c.Import(context.Background(), bytes.NewReader(input), ImportOptions{}); err == nil {
+				t.Fatal("expected duplicate instances to fail the import")
+			}
+			assertCount(t, c, 0)
+		})
+		t.Run("Canceled", func(t *testing.T) {
+			t.Parallel()
+			_, exported := export(t)
+			c, clean := setup(t)
+			defer clean()
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			if _, err := c.Import(ctx, bytes.NewReader(exported), ImportOptions{}); !errors.Is(err, context.Canceled) {
+				t.Fatalf("expected error %v, got %v", context.Canceled, err)
+			}
+			assertCount(t, c, 0)
+		})
+	})
+}
+
+func TestSchemaRefs(t *testing.T) {
+	t.Parallel()
+	common := func(cityType string) *jsonschema.Schema {
+		return util.SchemaFromSchemaString(fmt.Sprintf(`{
+			"definitions": {
+				"Address": {
+					"type": "object",
+					"properties": {
+						"city": {"type": %q},
+						"country": {"$ref": "#/definitions/Country"}
+					},
+					"required": ["city"]
+				},
+				"Country": {"type": "string", "minLength": 2}
+			}
+		}`, cityType))
+	}
+	schema := func(ref string) *jsonschema.Schema {
+		return util.SchemaFromSchemaString(fmt.Sprintf(`{
+			"type": "object",
+			"properties": {
+				"_id": {"type": "string"},
+				"address": {"$ref": %q}
+			}
+		}`, ref))
+	}
+	setup := func(t *testing.T) (*DB, func()) {
+		db, clean := createTestDB(t)
+		checkErr(t, db.RegisterSchemaDefinitions("common", common("string")))
+		return db, clean
+	}
+
+	t.Run("Definitions", func(t *testing.T) {
+		t.Parallel()
+		db, clean := setup(t)
+		defer clean()
+		c, err := db.NewCollection(CollectionConfig{
+			Name:    "Place",
+			Schema:  schema("definitions:common#/definitions/Address"),
+			Indexes: []Index{{Path: "address.city"}},
+		})
+		checkErr(t, err)
+		_, err = c.Create([]byte(`{"address": {"city": "Lisbon", "country": "PT"}}`))
+		checkErr(t, err)
+		if _, err := c.Create([]byte(`{"address": {"city": "Lisbon", "country": "P"}}`)); !errors.Is(err, ErrInvalidSchemaInstance) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidSchemaInstance, err)
+		}
+		res, err := c.Find(Where("address.city").Eq("Lisbon").UseIndex("address.city"))
+		checkErr(t, err)
+		if len(res) != 1 {
+			t.Fatalf("expected %d instances, got %d", 1, len(res))
+		}
+	})
+	t.Run("Collection", func(t *testing.T) {
+		t.Parallel()
+		db, clean := setup(t)
+		defer clean()
+		_, err := db.NewCollection(CollectionConfig{
+			Name:   "Address",
+			Schema: schema("definitions:common#/definitions/Address"),
+		})
+		checkErr(t, err)
+		c, err := db.NewCollection(CollectionConfig{
+			Name: "Person",
+			Schema: util.SchemaFromSchemaString(`{
+				"type": "object",
+				"properties": {
+					"_id": {"type": "string"},
+					"home": {"$ref": "collection:Address#/properties/address"}
+				}
+			}`),
+		})
+		checkErr(t, err)
+		if _, err := c.Create([]byte(`{"home": {"country": "PT"}}`)); !errors.Is(err, ErrInvalidSchemaInstance) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidSchemaInstance, err)
+		}
+		_, err = c.Create([]byte(`{"home": {"city": "Lisbon"}}`))
+		checkErr(t, err)
+	})
+	t.Run("Snapshot", func(t *testing.T) {
+		t.Parallel()
+		db, clean := setup(t)
+		defer clean()
+		c, err := db.NewCollection(CollectionConfig{
+			Name:   "Place",
+			Schema: schema("definitions:common#/definitions/Address"),
+		})
+		checkErr(t, err)
+
+		// the collection keeps validating with the definitions it was created with
+		checkErr(t, db.RegisterSchemaDefinitions("common", common("integer")))
+		_, err = c.Create([]byte(`{"address": {"city": "Lisbon"}}`))
+		checkErr(t, err)
+		checkErr(t, db.reCreateCollections())
+		_, err = db.GetCollection("Place").Create([]byte(`{"address": {"city": "Porto"}}`))
+		checkErr(t, err)
+
+		// until it's updated
+		c, err = db.UpdateCollection(CollectionConfig{
+			Name:   "Place",
+			Schema: schema("definitions:common#/definitions/Address"),
+		}, WithSkipValidation(true))
+		checkErr(t, err)
+		if _, err := c.Create([]byte(`{"address": {"city": "Lisbon"}}`)); !errors.Is(err, ErrInvalidSchemaInstance) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidSchemaInstance, err)
+		}
+	})
+	t.Run("Fail/Unresolvable", func(t *testing.T) {
+		t.Parallel()
+		db, clean := setup(t)
+		defer clean()
+		for _, ref := range []string{
+			"definitions:missing",
+			"definitions:common#/definitions/Missing",
+			"collection:Missing",
+			"#/definitions/Missing",
+		} {
+			_, err := db.NewCollection(CollectionConfig{
+				Name:   "Place",
+				Schema: schema(ref),
+			})
+			var refErr *SchemaRefError
+			if !errors.As(err, &refErr) || !errors.Is(err, ErrUnresolvableSchemaRef) {
+				t.Fatalf("expected error %v for %s, got %v", ErrUnresolvableSchemaRef, ref, err)
+			}
+			if refErr.Pointer != "/properties/address" || refErr.Ref != ref {
+				t.Fatalf("expected the reference %s at /properties/address, got %s at %s", ref, refErr.Ref, refErr.Pointer)
+			}
+		}
+		if err := db.RegisterSchemaDefinitions("other", schema("definitions:missing")); !errors.Is(err, ErrUnresolvableSchemaRef) {
+			t.Fatalf("expected error %v, got %v", ErrUnresolvableSchemaRef, err)
+		}
+	})
+}
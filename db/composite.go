@@ -0,0 +1,352 @@
+package db
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"sort"
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	dse "github.com/textileio/go-datastore-extensions"
+	"github.com/tidwall/gjson"
+)
+
+// Tags of the encoded values of composite index keys, in sort order.
+const (
+	tupleAbsent byte = iota
+	tupleNull
+	tupleFalse
+	tupleTrue
+	tupleNumber
+	tupleString
+)
+
+// appendTupleValue appends the encoding of a value to a composite index key.
+// Encoded values sort like the values, and none is the prefix of another, so
+// keys sort by their first field, then the second and so on.
+func appendTupleValue(b []byte, v interface{}, exists bool) []byte {
+	if !exists {
+		return append(b, tupleAbsent)
+	}
+	switch v := v.(type) {
+	case nil:
+		return append(b, tupleNull)
+	case bool:
+		if v {
+			return append(b, tupleTrue)
+		}
+		return append(b, tupleFalse)
+	case float64:
+		if v == 0 {
+			// -0 equals 0
+			v = 0
+		}
+		bits := math.Float64bits(v)
+		if v < 0 {
+			bits = ^bits
+		} else {
+			bits |= 1 << 63
+		}
+		var n [8]byte
+		binary.BigEndian.PutUint64(n[:], bits)
+		return append(append(b, tupleNumber), n[:]...)
+	case string:
+		b = append(b, tupleString)
+		for i := 0; i < len(v); i++ {
+			b = append(b, v[i])
+			if v[i] == 0 {
+				b = append(b, 0xff)
+			}
+		}
+		return append(b, 0, 1)
+	default:
+		return append(b, tupleAbsent)
+	}
+}
+
+// appendTupleResult appends the encoding of a JSON value to an index key.
+func appendTupleResult(b []byte, res gjson.Result) []byte {
+	switch res.Type {
+	case gjson.Null:
+		return appendTupleValue(b, nil, res.Exists())
+	case gjson.False, gjson.True:
+		return appendTupleValue(b, res.Bool(), true)
+	case gjson.Number:
+		return appendTupleValue(b, res.Num, true)
+	case gjson.String:
+		return appendTupleValue(b, res.Str, true)
+	default:
+		return appendTupleValue(b, nil, false)
+	}
+}
+
+// decodeTupleValue decodes the first value of an index key, and tells
+// whether it exists.
+func decodeTupleValue(b []byte) (interface{}, bool) {
+	if len(b) == 0 {
+		return nil, false
+	}
+	switch b[0] {
+	case tupleNull:
+		return nil, true
+	case tupleFalse:
+		return false, true
+	case tupleTrue:
+		return true, true
+	case tupleNumber:
+		if len(b) < 9 {
+			return nil, false
+		}
+		bits := binary.BigEndian.Uint64(b[1:9])
+		if bits&(1<<63) != 0 {
+			bits &^= 1 << 63
+		} else {
+			bits = ^bits
+		}
+		return math.Float64frombits(bits), true
+	case tupleString:
+		var s []byte
+		for i := 1; i < len(b); i++ {
+			if b[i] != 0 {
+				s = append(s, b[i])
+				continue
+			}
+			if i+1 < len(b) && b[i+1] == 0xff {
+				s = append(s, 0)
+				i++
+				continue
+			}
+			break
+		}
+		return string(s), true
+	default:
+		return nil, false
+	}
+}
+
+// compositeIndexValue returns the key of the values of the fields of input
+// in a composite index.
+func compositeIndexValue(fields []string, input []byte) string {
+	var b []byte
+	for _, field := range fields {
+		b = appendTupleResult(b, gjson.GetBytes(input, field))
+	}
+	return hex.EncodeToString(b)
+}
+
+// indexFields returns the fields whose values are the keys of the index, in
+// order. An index of a single field is keyed like a composite index of it.
+func indexFields(index Index) []string {
+	if len(index.Fields) > 0 {
+		return index.Fields
+	}
+	return []string{index.Path}
+}
+
+// criterionValue returns the value of a criterion as a JSON value.
+func criterionValue(v Value) (interface{}, bool) {
+	switch {
+	case v.String != nil:
+		return *v.String, true
+	case v.Float != nil:
+		return *v.Float, true
+	case v.Bool != nil:
+		return *v.Bool, true
+	default:
+		return nil, false
+	}
+}
+
+// indexPlan describes the keys of an index which hold the results of a
+// query.
+type indexPlan struct {
+	index Index
+	// equal are the values of the leading fields of the index the query
+	// matches by equality
+	equal []interface{}
+	// lower and upper are range criteria on the field following them
+	lower, upper *Criterion
+	// desc is set if the keys are read in descending order
+	desc bool
+}
+
+// score ranks the plans of a query, preferring those reading fewer keys.
+func (p *indexPlan) score() int {
+	s := 2 * len(p.equal)
+	if p.lower != nil || p.upper != nil {
+		s++
+	}
+	return s
+}
+
+// planIndex returns the plan of the query on an index.
+func planIndex(index Index, q *Query) *indexPlan {
+	p := &indexPlan{index: index}
+	for _, field := range indexFields(index) {
+		var eq *Criterion
+		for _, c := range q.Ands {
+			if c.FieldPath != field {
+				continue
+			}
+			if _, ok := criterionValue(c.Value); !ok {
+				continue
+			}
+			switch c.Operation {
+			case Eq:
+				eq = c
+			case Gt, Ge:
+				p.lower = c
+			case Lt, Le:
+				p.upper = c
+			}
+		}
+		if eq == nil {
+			break
+		}
+		v, _ := criterionValue(eq.Value)
+		p.equal = append(p.equal, v)
+		p.lower, p.upper = nil, nil
+	}
+	return p
+}
+
+// planFor returns the plan of the query on the index it uses, or on the
+// index matching most of its criteria if it doesn't use an index. Queries
+// with alternatives only use a composite index if they name it, and queries
+// on an index of a single field are only planned on a range of its keys, so
+// queries with In criteria look the keys up instead.
+func (c *Collection) planFor(q *Query) *indexPlan {
+	if q.Index != "" {
+		index, ok := c.indexes[q.Index]
+		if !ok || index.FullText {
+			return nil
+		}
+		if len(index.Fields) == 0 {
+			if len(q.Ors) != 0 {
+				return nil
+			}
+			if p := planIndex(index, q); p.score() > 0 {
+				return p
+			}
+			return nil
+		}
+		if len(q.Ors) != 0 {
+			// alternatives may match any key
+			return &indexPlan{index: index}
+		}
+		return planIndex(index, q)
+	}
+	if len(q.Ors) != 0 {
+		return nil
+	}
+	var names []string
+	for name, index := range c.indexes {
+		if !index.FullText {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var best *indexPlan
+	for _, name := range names {
+		p := planIndex(c.indexes[name], q)
+		if p.score() > 0 && (best == nil || p.score() > best.score()) {
+			best = p
+		}
+	}
+	return best
+}
+
+// sortsByIndex tells whether the keys of the plan, read in order, return
+// the results sorted like the query. That's the case if the query is only
+// sorted by the field of an index of a single field, which all its results
+// have, and whose values aren't arrays.
+func (c *Collection) sortsByIndex(q *Query, p *indexPlan) bool {
+	keys := q.sortKeys()
+	if len(keys) != 1 || len(p.index.Fields) != 0 || keys[0].FieldPath != p.index.Path || p.score() == 0 {
+		return false
+	}
+	_, err := getIndexableType(c.schema, p.index.Path)
+	return err == nil
+}
+
+// newPlanIterator returns an iterator over the instances matching the query,
+// among those of the keys of the plan on an index.
+func newPlanIterator(txn dse.TxnExt, c *Collection, q *Query, p *indexPlan) (*iterator, error) {
+	prefix := indexPrefix.Child(c.baseKey()).ChildString(p.index.Path)
+	var eq []byte
+	for _, v := range p.equal {
+		eq = appendTupleValue(eq, v, true)
+	}
+	equal := hex.EncodeToString(eq)
+	var lower, upper string
+	if p.lower != nil {
+		v, _ := criterionValue(p.lower.Value)
+		lower = hex.EncodeToString(appendTupleValue(eq, v, true))
+	}
+	if p.upper != nil {
+		v, _ := criterionValue(p.upper.Value)
+		upper = hex.EncodeToString(appendTupleValue(eq, v, true))
+	}
+	dsq := dse.QueryExt{
+		Query: query.Query{
+			Prefix: prefix.String(),
+		},
+	}
+	if p.desc {
+		// reverse iteration starts at the last key before the seek key,
+		// which sorts after the keys starting with the upper bound
+		dsq.Orders = []query.Order{query.OrderByKeyDescending{}}
+		start := equal
+		if upper != "" {
+			start = upper
+		}
+		dsq.SeekPrefix = prefix.ChildString(start + "\xff").String()
+	} else if lower != "" {
+		dsq.SeekPrefix = prefix.ChildString(lower).String()
+	} else if equal != "" {
+		dsq.SeekPrefix = prefix.ChildString(equal).String()
+	}
+	iter, err := txn.QueryExtended(dsq)
+	if err != nil {
+		return nil, err
+	}
+
+	i := &iterator{
+		txn:      txn,
+		query:    q,
+		iter:     iter,
+		matchAll: true,
+	}
+	done := false
+	i.nextKeys = func() ([]ds.Key, error) {
+		var nKeys []ds.Key
+		for !done && len(nKeys) < iteratorKeyMinCacheSize {
+			result, ok := iter.NextSync()
+			if !ok {
+				done = true
+				return nKeys, result.Error
+			}
+			// the keys of the plan are a range of the index, which the
+			// matching of the query narrows down
+			name := ds.RawKey(result.Key).Name()
+			if !strings.HasPrefix(name, equal) ||
+				(!p.desc && upper != "" && name > upper && !strings.HasPrefix(name, upper)) ||
+				(p.desc && lower != "" && name < lower) {
+				done = true
+				break
+			}
+			i.indexKeys++
+			indexValue := make(keyList, 0)
+			if err := DefaultDecode(result.Value, &indexValue); err != nil {
+				return nil, err
+			}
+			for _, v := range indexValue {
+				nKeys = append(nKeys, ds.RawKey(string(v)))
+			}
+		}
+		return nKeys, nil
+	}
+	return i, nil
+}
@@ -0,0 +1,229 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	dse "github.com/textileio/go-datastore-extensions"
+	"github.com/tidwall/gjson"
+)
+
+// Count returns the number of instances Find would return for the query,
+// without decoding them. The sort order and cursor of the query don't change
+// the count.
+func (t *Txn) Count(q *Query) (int, error) {
+	if err := t.collection.db.connector.Validate(t.token, true); err != nil {
+		return 0, err
+	}
+	if q == nil {
+		q = &Query{}
+	}
+	if err := q.Validate(); err != nil {
+		return 0, fmt.Errorf("invalid query: %s", err)
+	}
+	if err := t.collection.checkIndexUse(q); err != nil {
+		return 0, err
+	}
+	q = t.collection.indexFor(q)
+	txn, discard, err := t.datastoreTxn()
+	if err != nil {
+		return 0, fmt.Errorf("error building internal query: %v", err)
+	}
+	defer discard()
+
+	count, err := t.countMatches(txn, q)
+	if err != nil {
+		return 0, err
+	}
+	count -= q.Skip
+	if count < 0 {
+		count = 0
+	}
+	if q.Limit > 0 && count > q.Limit {
+		count = q.Limit
+	}
+	return count, nil
+}
+
+// countMatches counts the instances matching the valid query, regardless of
+// its limit and skip, with the datastore transaction.
+func (t *Txn) countMatches(txn dse.TxnExt, q *Query) (int, error) {
+	if search := t.collection.searchFor(q); search != nil {
+		return t.countSearch(txn, q, search)
+	} else if plan := t.collection.planFor(q); plan != nil {
+		return t.countPlanned(txn, q, plan)
+	} else if q.Index == "" {
+		return t.countInstances(txn, q)
+	}
+	return t.countIndexed(txn, q)
+}
+
+// countInstances counts the instances matching the query. Only the keys are
+// read if every instance matches, and the collection filters none of them.
+func (t *Txn) countInstances(txn dse.TxnExt, q *Query) (int, error) {
+	filtered := t.collection.filtersResults(q.IncludeDeleted)
+	prefix := t.collection.baseKey()
+	dsq := dse.QueryExt{
+		Query: query.Query{
+			Prefix:   prefix.String(),
+			KeysOnly: len(q.Ands) == 0 && !filtered,
+		},
+	}
+	if q.Seek != "" {
+		dsq.SeekPrefix = prefix.Child(ds.NewKey(string(q.Seek))).String()
+	}
+	res, err := txn.QueryExtended(dsq)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Close()
+	pk, err := t.token.PubKey()
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	for r := range res.Next() {
+		if r.Error != nil {
+			return 0, r.Error
+		}
+		if len(q.Ands) > 0 {
+			ok, err := q.matchJSON(r.Value)
+			if err != nil {
+				return 0, fmt.Errorf("error when matching entry with query: %v", err)
+			}
+			if !ok {
+				continue
+			}
+		}
+		if filtered {
+			v, err := t.collection.filterResult(pk, r.Value, q.IncludeDeleted)
+			if err != nil {
+				return 0, err
+			}
+			if v == nil {
+				continue
+			}
+		}
+		count++
+	}
+	return count, nil
+}
+
+// countIndexed counts the instances matching the query on an index. Only the
+// index is read unless the collection filters instances.
+func (t *Txn) countIndexed(txn dse.TxnExt, q *Query) (int, error) {
+	iter, err := newIterator(txn, t.collection.baseKey(), q)
+	if err != nil {
+		return 0, err
+	}
+	defer iter.Close()
+	if !t.collection.filtersResults(q.IncludeDeleted) {
+		// instances are found once per matching element of an indexed array
+		seen := make(map[ds.Key]bool)
+		for {
+			keys, err := iter.nextKeys()
+			if errors.Is(err, ErrIndexNotFound) {
+				// Find returns no results for empty indexes
+				return 0, nil
+			}
+			if err != nil {
+				return 0, err
+			}
+			if len(keys) == 0 {
+				return len(seen), nil
+			}
+			for _, k := range keys {
+				seen[k] = true
+			}
+		}
+	}
+	return t.countResults(iter, q)
+}
+
+// countSearch counts the instances matching the query among those found by
+// the full-text index for the Search criterion.
+func (t *Txn) countSearch(txn dse.TxnExt, q *Query, search *Criterion) (int, error) {
+	iter, err := newSearchIterator(txn, t.collection, q, search)
+	if err != nil {
+		return 0, err
+	}
+	defer iter.Close()
+	return t.countResults(iter, q)
+}
+
+// countPlanned counts the instances matching the query among those of the
+// keys of the plan on an index.
+func (t *Txn) countPlanned(txn dse.TxnExt, q *Query, p *indexPlan) (int, error) {
+	iter, err := newPlanIterator(txn, t.collection, q, p)
+	if err != nil {
+		return 0, err
+	}
+	defer iter.Close()
+	return t.countResults(iter, q)
+}
+
+// countResults counts the results of the iterator which pass the read
+// filter of the collection, and aren't expired or hidden deleted instances.
+func (t *Txn) countResults(iter *iterator, q *Query) (int, error) {
+	pk, err := t.token.PubKey()
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	for {
+		res, ok := iter.NextSync()
+		if !ok {
+			if errors.Is(res.Error, ErrIndexNotFound) {
+				return 0, nil
+			}
+			return count, res.Error
+		}
+		v, err := t.collection.filterResult(pk, res.Value, q.IncludeDeleted)
+		if err != nil {
+			return 0, err
+		}
+		if v != nil {
+			count++
+		}
+	}
+}
+
+// matchJSON is like match, but reads only the fields of the criteria from
+// the JSON instance.
+func (q *Query) matchJSON(v []byte) (bool, error) {
+	andOk := true
+	for _, c := range q.Ands {
+		field := gjson.GetBytes(v, c.FieldPath)
+		if !field.Exists() {
+			if andOk = c.Operation == Missing; !andOk {
+				break
+			}
+			continue
+		}
+		ok, err := c.matchValue(field.Value())
+		if err != nil {
+			return false, err
+		}
+		andOk = andOk && ok
+		if !andOk {
+			break
+		}
+	}
+	if andOk {
+		return true, nil
+	}
+
+	for _, q := range q.Ors {
+		ok, err := q.matchJSON(v)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -33,13 +33,23 @@ import (
 type operation int
 
 const (
-	eq operation = iota
-	ne           // !=
-	gt           // >
-	lt           // <
-	ge           // >=
-	le           // <=
-	fn           // func
+	eq        operation = iota
+	ne                  // !=
+	gt                  // >
+	lt                  // <
+	ge                  // >=
+	le                  // <=
+	fn                  // func
+	in                  // in
+	nin                 // not in
+	contains            // contains
+	hasPrefix           // has prefix
+	matches             // matches regexp
+	search              // full-text search
+	elemMatch           // any element matches
+	exists              // has a non-null value
+	missing             // has no value
+	isNull              // has a null value
 )
 
 type errTypeMismatch struct {
@@ -51,7 +61,7 @@ func (e *errTypeMismatch) Error() string {
 	return fmt.Sprintf("%v (%T) cannot be compared with %v (%T)", e.Value, e.Value, e.Other, e.Other)
 }
 
-//Comparer compares a type against the encoded value in the db. The result should be 0 if current==other,
+// Comparer compares a type against the encoded value in the db. The result should be 0 if current==other,
 // -1 if current < other, and +1 if current > other.
 // If a field in a struct doesn't specify a comparer, then the default comparison is used (convert to string and compare)
 // this interface is already handled for standard Go Types as well as more complex ones such as those in time and big
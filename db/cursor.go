@@ -0,0 +1,73 @@
+package db
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	core "github.com/textileio/go-threads/core/db"
+)
+
+var (
+	// ErrInvalidCursor indicates that a query cursor couldn't be decoded.
+	ErrInvalidCursor = errors.New("invalid query cursor")
+	// ErrCursorMismatch indicates that a query resumes from a cursor of
+	// another collection or sort order.
+	ErrCursorMismatch = errors.New("query cursor doesn't match the collection or sort order")
+)
+
+// cursor is the position of the last result of a page of query results,
+// which subsequent pages resume after.
+type cursor struct {
+	Collection string
+	Sort       []Sort
+	ID         core.InstanceID
+	// Fields and Present are the values of the sort keys of the last result
+	Fields  []interface{} `json:",omitempty"`
+	Present []bool        `json:",omitempty"`
+}
+
+// encodeCursor returns the opaque representation of a cursor.
+func encodeCursor(c *cursor) (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decodeCursor decodes an opaque cursor, and checks it belongs to the given
+// collection and sort keys.
+func decodeCursor(s, collection string, keys []Sort) (*cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	c := &cursor{}
+	if err := json.Unmarshal(b, c); err != nil || c.ID == core.EmptyInstanceID {
+		return nil, ErrInvalidCursor
+	}
+	if len(c.Fields) != len(c.Present) || (len(c.Fields) != 0 && len(c.Fields) != len(keys)) {
+		return nil, ErrInvalidCursor
+	}
+	if c.Collection != collection || !reflect.DeepEqual(c.Sort, keys) {
+		return nil, ErrCursorMismatch
+	}
+	return c, nil
+}
+
+// position returns the position of the cursor among sorted results.
+func (c *cursor) position() *sortedValue {
+	return &sortedValue{id: c.ID.String(), fields: c.Fields, present: c.Present}
+}
+
+// pageKeys returns the sort keys which order the pages of the query results.
+// Queries which aren't sorted are paged by ascending ID.
+func (q *Query) pageKeys() []Sort {
+	keys := q.sortKeys()
+	if len(keys) == 0 {
+		keys = []Sort{{FieldPath: idFieldName}}
+	}
+	return keys
+}
@@ -0,0 +1,140 @@
+package db
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateTimeLayout is the canonical representation of date-time values: UTC,
+// with fixed-width nanoseconds, so they compare chronologically as strings.
+const dateTimeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// FormatDateTime returns the canonical representation of t, which fields of
+// schema format "date-time" are normalized to when instances are written.
+func FormatDateTime(t time.Time) string {
+	return t.UTC().Format(dateTimeLayout)
+}
+
+// normalizeDates returns the instance with its date-time values in their
+// canonical representation, or the instance itself if they all are. RFC 3339
+// strings with any offset are normalized, and so are numbers of seconds since
+// the Unix epoch if the schema allows a string in their place. Other values
+// are left for the schema validation to reject.
+func (s *schemaTree) normalizeDates(instance []byte) ([]byte, error) {
+	var v interface{}
+	if err := decodeJSON(instance, &v); err != nil {
+		return nil, err
+	}
+	if _, ok := s.walkDates(s.root, v, "", canonicalDateTime); !ok {
+		return instance, nil
+	}
+	return json.Marshal(v)
+}
+
+// dateViolations returns the JSON pointers of the date-time values of the
+// instance which aren't in their canonical representation, so they don't
+// compare chronologically with the others. They're written before the schema
+// had them, or by remote peers.
+func (s *schemaTree) dateViolations(instance []byte) ([]string, error) {
+	var v interface{}
+	if err := decodeJSON(instance, &v); err != nil {
+		return nil, err
+	}
+	var pointers []string
+	s.walkDates(s.root, v, "", func(node map[string]interface{}, pointer string, v interface{}) (interface{}, bool) {
+		if _, ok := canonicalDateTime(node, pointer, v); ok {
+			pointers = append(pointers, pointer)
+		}
+		return nil, false
+	})
+	return pointers, nil
+}
+
+// walkDates calls fn with the date-time values of the value of the schema
+// node and their JSON pointers, replacing the ones fn returns true for, and
+// tells whether the value itself is to be replaced.
+func (s *schemaTree) walkDates(node map[string]interface{}, v interface{}, pointer string, fn func(node map[string]interface{}, pointer string, v interface{}) (interface{}, bool)) (interface{}, bool) {
+	node = s.resolve(node)
+	if node["format"] == "date-time" {
+		return fn(node, pointer, v)
+	}
+	var replaced bool
+	switch v := v.(type) {
+	case map[string]interface{}:
+		props, _ := node["properties"].(map[string]interface{})
+		for name, p := range props {
+			prop, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			e, ok := v[name]
+			if !ok {
+				continue
+			}
+			token := strings.Replace(strings.Replace(name, "~", "~0", -1), "/", "~1", -1)
+			if n, ok := s.walkDates(prop, e, pointer+"/"+token, fn); ok {
+				v[name] = n
+				replaced = true
+			}
+		}
+	case []interface{}:
+		items, ok := node["items"].(map[string]interface{})
+		if !ok {
+			break
+		}
+		for i, e := range v {
+			if n, ok := s.walkDates(items, e, pointer+"/"+strconv.Itoa(i), fn); ok {
+				v[i] = n
+				replaced = true
+			}
+		}
+	}
+	return v, replaced
+}
+
+// canonicalDateTime returns the canonical representation of the date-time
+// value of the schema node, and whether it differs from the value.
+func canonicalDateTime(node map[string]interface{}, _ string, v interface{}) (interface{}, bool) {
+	var t time.Time
+	switch v := v.(type) {
+	case string:
+		var err error
+		if t, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			return nil, false
+		}
+	case json.Number:
+		if !allowsString(node) {
+			return nil, false
+		}
+		f, err := v.Float64()
+		if err != nil || math.IsInf(f, 0) {
+			return nil, false
+		}
+		sec := math.Floor(f)
+		t = time.Unix(int64(sec), int64((f-sec)*1e9))
+	default:
+		return nil, false
+	}
+	d := FormatDateTime(t)
+	return d, d != v
+}
+
+// allowsString tells whether the schema node allows string values.
+func allowsString(node map[string]interface{}) bool {
+	switch t := node["type"].(type) {
+	case nil:
+		return true
+	case string:
+		return t == "string"
+	case []interface{}:
+		for _, e := range t {
+			if e == "string" {
+				return true
+			}
+		}
+	}
+	return false
+}
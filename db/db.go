@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"strconv"
 	"sync"
 	"time"
 
@@ -50,15 +51,40 @@ var (
 	ErrInvalidCollectionSchema = errors.New("the collection schema _id property must be a string")
 	// ErrCannotIndexIDField indicates a custom index was specified on the ID field.
 	ErrCannotIndexIDField = errors.New("cannot create custom index on " + idFieldName)
+	// ErrRecordTooLarge indicates the event of a single action exceeds the max record size.
+	ErrRecordTooLarge = net.ErrRecordTooLarge
 
 	nameRx *regexp.Regexp
 
-	dsPrefix     = ds.NewKey("/db")
-	dsName       = dsPrefix.ChildString("name")
-	dsSchemas    = dsPrefix.ChildString("schema")
-	dsIndexes    = dsPrefix.ChildString("index")
-	dsValidators = dsPrefix.ChildString("validator")
-	dsFilters    = dsPrefix.ChildString("filter")
+	dsPrefix      = ds.NewKey("/db")
+	dsName        = dsPrefix.ChildString("name")
+	dsSchemas     = dsPrefix.ChildString("schema")
+	dsIndexes     = dsPrefix.ChildString("index")
+	dsValidators  = dsPrefix.ChildString("validator")
+	dsFilters     = dsPrefix.ChildString("filter")
+	dsVersions    = dsPrefix.ChildString("version")
+	dsSoftDelete  = dsPrefix.ChildString("softdelete")
+	dsExpiry      = dsPrefix.ChildString("expiry")
+	dsLegacy      = dsPrefix.ChildString("legacy")
+	dsDefaults    = dsPrefix.ChildString("defaults")
+	dsIndexFormat = dsPrefix.ChildString("indexformat")
+	dsDeleted     = dsPrefix.ChildString("deleted")
+	dsRenamed     = dsPrefix.ChildString("renamed")
+
+	// dsCollectionConfigs are the prefixes of the keys of the config and
+	// stats of each collection, which are named after it.
+	dsCollectionConfigs = []ds.Key{
+		dsSchemas,
+		dsIndexes,
+		dsValidators,
+		dsFilters,
+		dsVersions,
+		dsSoftDelete,
+		dsExpiry,
+		dsLegacy,
+		dsDefaults,
+		dsStats,
+	}
 )
 
 func init() {
@@ -78,17 +104,36 @@ type DB struct {
 	name      string
 	connector *app.Connector
 
-	datastore  kt.TxnDatastoreExtended
-	dispatcher *dispatcher
-	eventcodec core.EventCodec
+	datastore     kt.TxnDatastoreExtended
+	dispatcher    *dispatcher
+	eventcodec    core.EventCodec
+	maxRecordSize int
+
+	now               func() time.Time
+	expiryGracePeriod time.Duration
 
 	lock        sync.RWMutex
 	txnlock     sync.RWMutex
 	collections map[string]*Collection
-	closed      bool
+	closeCh     chan struct{}
+	// inflight tracks the operations Close waits for, for up to
+	// closeGracePeriod.
+	inflight         closer
+	closeGracePeriod time.Duration
+	// renamed maps the names of renamed collections to their current ones,
+	// which events of the old names are applied to.
+	renamed map[string]string
+
+	// seqLock serializes the actions logged with sequence numbers up to
+	// seq, from their logging to their delivery to listeners.
+	seqLock       sync.Mutex
+	seq           uint64
+	actionLogSize int
 
 	localEventsBus      *app.LocalEventsBus
 	stateChangedNotifee *stateChangedNotifee
+	afterHooks          *hookQueue
+	syncing             *syncTracker
 }
 
 // NewDB creates a new DB, which will *own* ds and dispatcher for internal use.
@@ -129,6 +174,9 @@ func NewDB(
 // NewDBFromAddr creates a new DB from a thread hosted by another peer at address,
 // which will *own* ds and dispatcher for internal use.
 // Saying it differently, ds and dispatcher shouldn't be used externally.
+// If pulling the thread with WithNewBackfillBlock fails, as when ctx is
+// canceled, the DB is closed, and the records pulled are kept. Then NewDB with
+// the thread ID on the same store and network resumes it, and pulls the rest.
 func NewDBFromAddr(
 	ctx context.Context,
 	store kt.TxnDatastoreExtended,
@@ -161,20 +209,22 @@ func NewDBFromAddr(
 	if err != nil {
 		return nil, err
 	}
+	reportThreadAdded(args.Progress, info)
 	d, err := newDB(store, network, info.ID, args)
 	if err != nil {
 		return nil, err
 	}
 
 	if args.Block {
-		if err = network.PullThread(ctx, info.ID, net.WithThreadToken(args.Token)); err != nil {
+		if err = d.pullThread(ctx, args.Token, len(info.Logs), args.Progress); err != nil {
+			_ = d.Close()
 			return nil, err
 		}
 	} else {
 		go func() {
 			ctx, cancel := context.WithTimeout(context.Background(), pullThreadBackgroundTimeout)
 			defer cancel()
-			if err := network.PullThread(ctx, info.ID, net.WithThreadToken(args.Token)); err != nil {
+			if err := d.pullThread(ctx, args.Token, len(info.Logs), args.Progress); err != nil {
 				log.Errorf("error pulling thread %s", info.ID)
 			}
 		}()
@@ -184,17 +234,52 @@ func NewDBFromAddr(
 
 // newDB is used directly by a db manager to create new dbs with the same config.
 func newDB(s kt.TxnDatastoreExtended, n app.Net, id thread.ID, opts *NewOptions) (*DB, error) {
+	s, err := encryptDatastore(datastoreFromOptions(s, opts), opts)
+	if err != nil {
+		return nil, err
+	}
 	if opts.EventCodec == nil {
 		opts.EventCodec = newDefaultEventCodec()
 	}
+	maxRecordSize, err := n.GetMaxRecordSize(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("getting max record size: %v", err)
+	}
+	if opts.MaxRecordSize == 0 || opts.MaxRecordSize > maxRecordSize {
+		opts.MaxRecordSize = maxRecordSize
+	}
+	if opts.ExpirySweepInterval == 0 {
+		opts.ExpirySweepInterval = DefaultExpirySweepInterval
+	}
+	if opts.ExpiryGracePeriod == 0 {
+		opts.ExpiryGracePeriod = DefaultExpiryGracePeriod
+	}
+	if opts.ActionLogSize == 0 {
+		opts.ActionLogSize = DefaultActionLogSize
+	}
+	if opts.CloseGracePeriod == 0 {
+		opts.CloseGracePeriod = DefaultCloseGracePeriod
+	}
+	if opts.clock == nil {
+		opts.clock = time.Now
+	}
 
 	d := &DB{
 		datastore:           s,
 		dispatcher:          newDispatcher(s),
 		eventcodec:          opts.EventCodec,
+		maxRecordSize:       opts.MaxRecordSize,
+		now:                 opts.clock,
+		expiryGracePeriod:   opts.ExpiryGracePeriod,
+		actionLogSize:       opts.ActionLogSize,
+		closeGracePeriod:    opts.CloseGracePeriod,
 		collections:         make(map[string]*Collection),
+		renamed:             make(map[string]string),
+		closeCh:             make(chan struct{}),
 		localEventsBus:      app.NewLocalEventsBus(),
 		stateChangedNotifee: &stateChangedNotifee{},
+		afterHooks:          newHookQueue(),
+		syncing:             &syncTracker{},
 	}
 	if err := d.loadName(); err != nil {
 		return nil, err
@@ -208,10 +293,29 @@ func newDB(s kt.TxnDatastoreExtended, n app.Net, id thread.ID, opts *NewOptions)
 	if err := d.saveName(prevName); err != nil {
 		return nil, err
 	}
+	if err := d.saveCreated(); err != nil {
+		return nil, err
+	}
+	if err := d.resumePurges(); err != nil {
+		return nil, err
+	}
 	if err := d.reCreateCollections(); err != nil {
 		return nil, err
 	}
+	if err := d.loadRenames(); err != nil {
+		return nil, err
+	}
+	if err := d.upgradeIndexes(); err != nil {
+		return nil, err
+	}
+	if err := d.initStats(); err != nil {
+		return nil, err
+	}
+	if err := d.loadActionSeq(); err != nil {
+		return nil, err
+	}
 	d.dispatcher.Register(d)
+	go d.runHooks()
 
 	connector, err := n.ConnectApp(d, id)
 	if err != nil {
@@ -224,6 +328,10 @@ func newDB(s kt.TxnDatastoreExtended, n app.Net, id thread.ID, opts *NewOptions)
 			return nil, err
 		}
 	}
+	go d.sweepExpiredLoop(opts.ExpirySweepInterval)
+	if opts.GCInterval > 0 {
+		go gcLoop(opts.GCInterval, d.closeCh, d.gc, d.name)
+	}
 	return d, nil
 }
 
@@ -264,43 +372,85 @@ func (d *DB) reCreateCollections() error {
 	}
 	defer results.Close()
 	for res := range results.Next() {
-		name := ds.RawKey(res.Key).Name()
-		schema := &jsonschema.Schema{}
-		if err := json.Unmarshal(res.Value, schema); err != nil {
-			return err
-		}
-		wv, err := d.datastore.Get(dsValidators.ChildString(name))
-		if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		c, err := d.loadCollection(ds.RawKey(res.Key).Name(), res.Value)
+		if err != nil {
 			return err
 		}
-		rf, err := d.datastore.Get(dsFilters.ChildString(name))
-		if err != nil && !errors.Is(err, ds.ErrNotFound) {
-			return err
+		d.collections[c.name] = c
+	}
+	return nil
+}
+
+// loadCollection loads the collection named name, of the schema, from the
+// datastore.
+func (d *DB) loadCollection(name string, rawSchema []byte) (*Collection, error) {
+	schema := &jsonschema.Schema{}
+	if err := json.Unmarshal(rawSchema, schema); err != nil {
+		return nil, err
+	}
+	wv, err := d.datastore.Get(dsValidators.ChildString(name))
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return nil, err
+	}
+	rf, err := d.datastore.Get(dsFilters.ChildString(name))
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return nil, err
+	}
+	sd, err := d.datastore.Has(dsSoftDelete.ChildString(name))
+	if err != nil {
+		return nil, err
+	}
+	ad, err := d.datastore.Has(dsDefaults.ChildString(name))
+	if err != nil {
+		return nil, err
+	}
+	ef, err := d.datastore.Get(dsExpiry.ChildString(name))
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return nil, err
+	}
+	c, err := newCollection(d, CollectionConfig{
+		Name:           name,
+		Schema:         schema,
+		WriteValidator: string(wv),
+		ReadFilter:     string(rf),
+		SoftDelete:     sd,
+		ExpiryField:    string(ef),
+		ApplyDefaults:  ad,
+	})
+	if err != nil {
+		return nil, err
+	}
+	version, err := d.datastore.Get(dsVersions.ChildString(name))
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return nil, err
+	}
+	if version != nil {
+		if c.schemaVersion, err = strconv.Atoi(string(version)); err != nil {
+			return nil, err
 		}
-		c, err := newCollection(d, CollectionConfig{
-			Name:           name,
-			Schema:         schema,
-			WriteValidator: string(wv),
-			ReadFilter:     string(rf),
-		})
-		if err != nil {
-			return err
+	}
+	legacy, err := d.datastore.Get(dsLegacy.ChildString(name))
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return nil, err
+	}
+	if legacy != nil {
+		if c.legacyBefore, err = strconv.ParseInt(string(legacy), 10, 64); err != nil {
+			return nil, err
 		}
-		var indexes map[string]Index
-		index, err := d.datastore.Get(dsIndexes.ChildString(name))
-		if err == nil && index != nil {
-			if err := json.Unmarshal(index, &indexes); err != nil {
-				return err
-			}
+	}
+	var indexes map[string]Index
+	index, err := d.datastore.Get(dsIndexes.ChildString(name))
+	if err == nil && index != nil {
+		if err := json.Unmarshal(index, &indexes); err != nil {
+			return nil, err
 		}
-		for _, index := range indexes {
-			if index.Path != "" { // Catch bad indexes from an old bug
-				c.indexes[index.Path] = index
-			}
+	}
+	for _, index := range indexes {
+		if index.Path != "" { // Catch bad indexes from an old bug
+			c.indexes[index.Path] = index
 		}
-		d.collections[c.name] = c
 	}
-	return nil
+	return c, nil
 }
 
 // Info wraps info about a db.
@@ -342,10 +492,15 @@ type CollectionConfig struct {
 	// Indexes is a list of index configurations, which define how instances are indexed.
 	Indexes []Index
 	// An optional JavaScript (ECMAScript 5.1) function that is used to validate instances on write.
-	// The function receives three arguments:
+	// The function receives four arguments:
 	//   - writer: The multibase-encoded public key identity of the writer.
 	//   - event: An object describing the update event (see core.Event).
-	//   - instance: The current instance as a JavaScript object before the update event is applied.
+	//   - instance: The current instance as a JavaScript object before the update event is applied,
+	//     which is undefined if it doesn't exist yet.
+	//   - next: The instance as a JavaScript object after the update event is applied,
+	//     which is undefined if it's deleted.
+	// Events of a transaction are validated in order, so instance includes the changes
+	// of the events before it.
 	// A "falsy" return value indicates a failed validation (https://developer.mozilla.org/en-US/docs/Glossary/Falsy).
 	// Note: Only the function body should be defined here.
 	WriteValidator string
@@ -357,10 +512,34 @@ type CollectionConfig struct {
 	// Most implementation will modify and return the current instance.
 	// Note: Only the function body should be defined here.
 	ReadFilter string
+	// SoftDelete makes deleting instances leave tombstones of them, which are
+	// hidden from queries unless they include deleted instances, until
+	// they're purged. Deleted instances can be restored until then.
+	SoftDelete bool
+	// ExpiryField is the optional path of a number field holding the time
+	// instances expire at, in (possibly fractional) seconds since the Unix
+	// epoch. Instances without it don't expire. Expired instances are hidden
+	// from queries, and deleted by every peer without writing events once
+	// expired for longer than the grace period of its db. Peers only agree
+	// on which instances are expired up to the skew of their clocks.
+	ExpiryField string
+	// ApplyDefaults makes created instances get the default values of the
+	// schema for their absent properties, including those of the objects
+	// they contain, before they're validated. Absent objects are only set if
+	// they have a default value.
+	ApplyDefaults bool
+	// Hooks are run when instances of the collection are written. They're
+	// unavailable over the gRPC API, and aren't saved with the collection.
+	Hooks Hooks
 }
 
 // NewCollection creates a new db collection with config.
 func (d *DB) NewCollection(config CollectionConfig, opts ...Option) (*Collection, error) {
+	end, err := d.inflight.begin()
+	if err != nil {
+		return nil, err
+	}
+	defer end()
 	d.lock.Lock()
 	defer d.lock.Unlock()
 	log.Debugf("creating collection %s in %s", config.Name, d.name)
@@ -374,6 +553,15 @@ func (d *DB) NewCollection(config CollectionConfig, opts ...Option) (*Collection
 	if _, ok := d.collections[config.Name]; ok {
 		return nil, ErrCollectionAlreadyRegistered
 	}
+	if err := d.resumePurge(config.Name); err != nil {
+		return nil, err
+	}
+	if err := d.forgetRename(config.Name); err != nil {
+		return nil, err
+	}
+	if config.Schema, err = d.resolveSchemaRefs(config.Schema); err != nil {
+		return nil, err
+	}
 	c, err := newCollection(d, config)
 	if err != nil {
 		return nil, err
@@ -381,6 +569,9 @@ func (d *DB) NewCollection(config CollectionConfig, opts ...Option) (*Collection
 	if err := d.addIndexes(c, config.Schema, config.Indexes, opts...); err != nil {
 		return nil, err
 	}
+	if err := d.recalculateCollectionStats(c.name); err != nil {
+		return nil, err
+	}
 	if err := d.saveCollection(c); err != nil {
 		return nil, err
 	}
@@ -390,7 +581,15 @@ func (d *DB) NewCollection(config CollectionConfig, opts ...Option) (*Collection
 // UpdateCollection updates an existing db collection with a new config.
 // Indexes to new paths will be created.
 // Indexes to removed paths will be dropped.
+// The existing instances must be valid against the new schema, otherwise an
+// *IncompatibleInstancesError reports the ones which aren't, unless
+// validation is skipped with WithSkipValidation.
 func (d *DB) UpdateCollection(config CollectionConfig, opts ...Option) (*Collection, error) {
+	end, err := d.inflight.begin()
+	if err != nil {
+		return nil, err
+	}
+	defer end()
 	d.lock.Lock()
 	defer d.lock.Unlock()
 	log.Debugf("updating collection %s in %s", config.Name, d.name)
@@ -405,10 +604,25 @@ func (d *DB) UpdateCollection(config CollectionConfig, opts ...Option) (*Collect
 	if !ok {
 		return nil, ErrCollectionNotFound
 	}
+	if config.Schema, err = d.resolveSchemaRefs(config.Schema); err != nil {
+		return nil, err
+	}
 	c, err := newCollection(d, config)
 	if err != nil {
 		return nil, err
 	}
+	c.schemaVersion = xc.schemaVersion
+	if args.SkipValidation {
+		c.legacyBefore = time.Now().UnixNano()
+	} else {
+		report, err := c.checkInstances()
+		if err != nil {
+			return nil, err
+		}
+		if report.Incompatible > 0 {
+			return nil, &IncompatibleInstancesError{Report: report}
+		}
+	}
 	if err := d.addIndexes(c, config.Schema, config.Indexes, opts...); err != nil {
 		return nil, err
 	}
@@ -456,6 +670,39 @@ func (d *DB) saveCollection(c *Collection) error {
 			return err
 		}
 	}
+	if c.softDelete {
+		if err := d.datastore.Put(dsSoftDelete.ChildString(c.name), []byte{}); err != nil {
+			return err
+		}
+	} else if err := d.datastore.Delete(dsSoftDelete.ChildString(c.name)); err != nil {
+		return err
+	}
+	if c.expiryField != "" {
+		if err := d.datastore.Put(dsExpiry.ChildString(c.name), []byte(c.expiryField)); err != nil {
+			return err
+		}
+	} else if err := d.datastore.Delete(dsExpiry.ChildString(c.name)); err != nil {
+		return err
+	}
+	if c.defaults != nil {
+		if err := d.datastore.Put(dsDefaults.ChildString(c.name), []byte{}); err != nil {
+			return err
+		}
+	} else if err := d.datastore.Delete(dsDefaults.ChildString(c.name)); err != nil {
+		return err
+	}
+	if c.schemaVersion != 0 {
+		if err := d.datastore.Put(dsVersions.ChildString(c.name), []byte(strconv.Itoa(c.schemaVersion))); err != nil {
+			return err
+		}
+	}
+	if c.legacyBefore != 0 {
+		if err := d.datastore.Put(dsLegacy.ChildString(c.name), []byte(strconv.FormatInt(c.legacyBefore, 10))); err != nil {
+			return err
+		}
+	} else if err := d.datastore.Delete(dsLegacy.ChildString(c.name)); err != nil {
+		return err
+	}
 	d.collections[c.name] = c
 	return nil
 }
@@ -496,8 +743,20 @@ func (d *DB) ListCollections(opts ...Option) []*Collection {
 	return list
 }
 
-// DeleteCollection deletes collection by name and drops all indexes.
+// DeleteCollection deletes collection by name, along with its instances,
+// the entries of its indexes and its dispatched events. The collection is
+// flagged as deleted along with the deletion of its config, then its data is
+// purged in batches, so a deletion interrupted by a crash is completed once
+// the db is reopened. A collection of the same name can be created once it's
+// deleted.
 func (d *DB) DeleteCollection(name string, opts ...Option) error {
+	end, err := d.inflight.begin()
+	if err != nil {
+		return err
+	}
+	defer end()
+	d.txnlock.Lock()
+	defer d.txnlock.Unlock()
 	d.lock.Lock()
 	defer d.lock.Unlock()
 	log.Debugf("deleting collection %s in %s", name, d.name)
@@ -516,45 +775,99 @@ func (d *DB) DeleteCollection(name string, opts ...Option) error {
 	if err != nil {
 		return err
 	}
-	if err := txn.Delete(dsIndexes.ChildString(c.name)); err != nil {
-		return err
-	}
-	if err := txn.Delete(dsSchemas.ChildString(c.name)); err != nil {
-		return err
-	}
-	if err := txn.Delete(dsValidators.ChildString(c.name)); err != nil {
+	defer txn.Discard()
+	if err := txn.Put(dsDeleted.ChildString(c.name), nil); err != nil {
 		return err
 	}
-	if err := txn.Delete(dsFilters.ChildString(c.name)); err != nil {
-		return err
+	for _, prefix := range dsCollectionConfigs {
+		if err := txn.Delete(prefix.ChildString(c.name)); err != nil {
+			return err
+		}
 	}
 	if err := txn.Commit(); err != nil {
 		return err
 	}
 	delete(d.collections, c.name)
-	return nil
+	return d.purgeCollection(c.name)
 }
 
+// Close closes the db, once the operations in flight end, or the grace
+// period set with WithNewCloseGracePeriod passes, after which the rest fail
+// with ErrDBClosed without committing. Listeners are sent ActionClose before
+// their channels are closed.
 func (d *DB) Close() error {
 	log.Debugf("closing %s", d.name)
-	d.lock.Lock()
-	defer d.lock.Unlock()
-	d.txnlock.Lock()
-	defer d.txnlock.Unlock()
-
-	if d.closed {
+	if !d.inflight.close(d.closeGracePeriod) {
 		return nil
 	}
-	d.closed = true
+	close(d.closeCh)
 	d.localEventsBus.Discard()
-	d.stateChangedNotifee.close()
+	d.stateChangedNotifee.close(Action{Type: ActionClose})
 	return nil
 }
 
 func (d *DB) Reduce(events []core.Event) error {
 	log.Debugf("reducing events in %s", d.name)
-	codecActions, err := d.eventcodec.Reduce(events, d.datastore, baseKey, defaultIndexFunc(d))
+	d.seqLock.Lock()
+	defer d.seqLock.Unlock()
+	// renames are applied by the db in between the events before and
+	// after them, which the codec reduces
+	var run []core.Event
+	for _, e := range events {
+		if re, ok := e.(core.RenameEvent); ok {
+			if name, ok := re.RenamedCollection(); ok {
+				if err := d.reduceEvents(run); err != nil {
+					return err
+				}
+				run = nil
+				if err := d.reduceRename(e.Collection(), name); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		run = append(run, d.renamedEvent(e))
+	}
+	return d.reduceEvents(run)
+}
+
+// reduceEvents reduces events with the codec, and notifies listeners of the
+// resulting actions. The seq lock must be held.
+func (d *DB) reduceEvents(events []core.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	type reduced struct {
+		instance []byte
+		seq      uint64
+	}
+	// instances resulting from the events, or deleted by them, with the
+	// sequence numbers of their logged actions, in the order events are
+	// reduced
+	instances := make(map[ds.Key][]reduced)
+	indexFunc := defaultIndexFunc(d)
+	lastSeq := d.seq
+	codecActions, err := d.eventcodec.Reduce(events, statsStore{d.datastore}, baseKey, func(collection string, key ds.Key, oldData, newData []byte, txn ds.Txn) error {
+		if err := indexFunc(collection, key, oldData, newData, txn); err != nil {
+			return err
+		}
+		a := Action{Collection: collection, Type: ActionSave, ID: core.InstanceID(key.Name())}
+		instance := newData
+		if _, deleted := deletedAt(newData); newData == nil || deleted {
+			a.Type, instance = ActionDelete, oldData
+		} else if oldData == nil {
+			a.Type = ActionCreate
+		}
+		// the action is logged with the reduced events, so it's only
+		// logged if they're applied
+		if err := d.logAction(txn, &a); err != nil {
+			return err
+		}
+		instances[key] = append(instances[key], reduced{instance: instance, seq: a.Seq})
+		return nil
+	})
 	if err != nil {
+		d.seq = lastSeq
 		return err
 	}
 	actions := make([]Action, len(codecActions))
@@ -565,17 +878,40 @@ func (d *DB) Reduce(events []core.Event) error {
 			actionType = ActionCreate
 		case core.Save:
 			actionType = ActionSave
+			if d.deletedByTombstone(ca) {
+				actionType = ActionDelete
+			}
 		case core.Delete:
 			actionType = ActionDelete
 		default:
 			panic("eventcodec action not recognized")
 		}
 		actions[i] = Action{Collection: ca.Collection, Type: actionType, ID: ca.InstanceID}
+		key := baseKey.ChildString(ca.Collection).ChildString(ca.InstanceID.String())
+		if r := instances[key]; len(r) > 0 {
+			actions[i].instance, actions[i].Seq = r[0].instance, r[0].seq
+			instances[key] = r[1:]
+		}
 	}
 	d.notifyStateChanged(actions)
 	return nil
 }
 
+// deletedByTombstone tells whether the reduced action deleted an instance of
+// a soft-delete collection to a tombstone.
+func (d *DB) deletedByTombstone(ca core.ReduceAction) bool {
+	c := d.GetCollection(ca.Collection)
+	if c == nil || !c.softDelete {
+		return false
+	}
+	v, err := d.datastore.Get(c.baseKey().ChildString(ca.InstanceID.String()))
+	if err != nil {
+		return false
+	}
+	_, ok := deletedAt(v)
+	return ok
+}
+
 func defaultIndexFunc(d *DB) func(collection string, key ds.Key, oldData, newData []byte, txn ds.Txn) error {
 	return func(collection string, key ds.Key, oldData, newData []byte, txn ds.Txn) error {
 		c := d.GetCollection(collection)
@@ -598,19 +934,7 @@ func (d *DB) ValidateNetRecordBody(_ context.Context, body format.Node, identity
 	if err != nil {
 		return err
 	}
-	if len(events) == 0 {
-		return nil
-	}
-	for _, e := range events {
-		c, ok := d.collections[e.Collection()]
-		if !ok {
-			return ErrCollectionNotFound
-		}
-		if err := c.validWrite(identity, e); err != nil {
-			return err
-		}
-	}
-	return nil
+	return d.validateEvents(identity, events)
 }
 
 func parseJSON(vm *goja.Runtime, val []byte) (goja.Value, error) {
@@ -619,6 +943,11 @@ func parseJSON(vm *goja.Runtime, val []byte) (goja.Value, error) {
 
 func (d *DB) HandleNetRecord(ctx context.Context, rec net.ThreadRecord, key thread.Key) error {
 	log.Debugf("handling net record %s", rec.Value().Cid())
+	end, err := d.inflight.begin()
+	if err != nil {
+		return err
+	}
+	defer end()
 	event, err := threadcbor.EventFromRecord(ctx, d.connector.Net, rec.Value())
 	if err != nil {
 		block, err := d.getBlockWithRetry(ctx, rec.Value())
@@ -639,7 +968,16 @@ func (d *DB) HandleNetRecord(ctx context.Context, rec net.ThreadRecord, key thre
 		return fmt.Errorf("error when unmarshaling event from bytes: %v", err)
 	}
 	log.Debugf("dispatching new record: %s/%s", rec.ThreadID(), rec.LogID())
-	return d.dispatch(events)
+	endCommit, err := d.inflight.beginCommit()
+	if err != nil {
+		return err
+	}
+	defer endCommit()
+	if err := d.dispatch(events); err != nil {
+		return err
+	}
+	d.syncing.reduced(len(events))
+	return nil
 }
 
 // getBlockWithRetry gets a record block with exponential backoff.
@@ -673,14 +1011,16 @@ func (d *DB) dispatch(events []core.Event) error {
 
 func (d *DB) readTxn(c *Collection, f func(txn *Txn) error, opts ...TxnOption) error {
 	log.Debugf("starting read txn in %s", d.name)
-	d.txnlock.RLock()
-	defer d.txnlock.RUnlock()
-
-	args := &TxnOptions{}
-	for _, opt := range opts {
-		opt(args)
+	end, err := d.inflight.begin()
+	if err != nil {
+		return err
+	}
+	defer end()
+	txn, err := d.newReadTxn(c, opts...)
+	if err != nil {
+		return err
 	}
-	txn := &Txn{collection: c, token: args.Token, readonly: true}
+	defer txn.snapshot.Discard()
 	defer txn.Discard()
 	if err := f(txn); err != nil {
 		return err
@@ -689,8 +1029,34 @@ func (d *DB) readTxn(c *Collection, f func(txn *Txn) error, opts ...TxnOption) e
 	return nil
 }
 
+// newReadTxn returns a read transaction of the collection, whose snapshot
+// must be discarded once it ends.
+func (d *DB) newReadTxn(c *Collection, opts ...TxnOption) (*Txn, error) {
+	if d.inflight.isClosed() {
+		return nil, ErrDBClosed
+	}
+	// the snapshot is taken between write transactions, which then
+	// proceed while it's read
+	d.txnlock.RLock()
+	snapshot, err := d.datastore.NewTransactionExtended(true)
+	d.txnlock.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	args := &TxnOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	return &Txn{collection: c, token: args.Token, readonly: true, snapshot: snapshot}, nil
+}
+
 func (d *DB) writeTxn(c *Collection, f func(txn *Txn) error, opts ...TxnOption) error {
 	log.Debugf("starting write txn in %s", d.name)
+	end, err := d.inflight.begin()
+	if err != nil {
+		return err
+	}
+	defer end()
 	d.txnlock.Lock()
 	defer d.txnlock.Unlock()
 
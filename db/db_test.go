@@ -1,10 +1,13 @@
 package db
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"sync"
@@ -12,13 +15,19 @@ import (
 	"time"
 
 	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
 	format "github.com/ipfs/go-ipld-format"
 	"github.com/multiformats/go-multiaddr"
+	sym "github.com/textileio/crypto/symmetric"
+	badger "github.com/textileio/go-ds-badger"
 	"github.com/textileio/go-threads/common"
 	"github.com/textileio/go-threads/core/app"
 	core "github.com/textileio/go-threads/core/db"
 	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/db/encryption"
+	kt "github.com/textileio/go-threads/db/keytransform"
 	"github.com/textileio/go-threads/util"
+	"github.com/textileio/go-threads/util/memds"
 )
 
 func TestE2EWithThreads(t *testing.T) {
@@ -267,6 +276,129 @@ func TestWithNewEventCodec(t *testing.T) {
 	checkErr(t, d.Close())
 }
 
+func TestWithNewDatastore(t *testing.T) {
+	t.Parallel()
+	n, err := common.DefaultNetwork(
+		common.WithRepoDatastore(func(string) (ds.Batching, error) {
+			return memds.New(), nil
+		}),
+		common.WithNetHostAddr(util.FreeLocalAddr()),
+		common.WithNetDebug(true),
+	)
+	checkErr(t, err)
+	defer n.Close()
+
+	store := memds.New()
+	id := thread.NewIDV1(thread.Raw, 32)
+	d, err := NewDB(context.Background(), nil, n, id, WithNewDatastore(store))
+	checkErr(t, err)
+	c, err := d.NewCollection(CollectionConfig{
+		Name:    "dummy",
+		Schema:  util.SchemaFromInstance(&dummy{}, false),
+		Indexes: []Index{{Path: "Name"}},
+	})
+	checkErr(t, err)
+	for i := 0; i < 5; i++ {
+		_, err := c.Create(util.JSONFromInstance(dummy{Name: fmt.Sprintf("Textile%d", i), Counter: i}))
+		checkErr(t, err)
+	}
+	res, err := c.Find(Where("Name").Eq("Textile3"))
+	checkErr(t, err)
+	if len(res) != 1 {
+		t.Fatalf("expected 1 instance found with the index, got %d", len(res))
+	}
+	res, err = c.Find(OrderByDesc("Name").LimitTo(2))
+	checkErr(t, err)
+	if len(res) != 2 || !strings.Contains(string(res[0]), "Textile4") {
+		t.Fatalf("expected the 2 last instances by name, got %s", res)
+	}
+	stats, err := d.CollectionStats("dummy")
+	checkErr(t, err)
+	if stats.Instances != 5 {
+		t.Fatalf("expected stats of 5 instances, got %d", stats.Instances)
+	}
+	checkErr(t, d.Close())
+
+	// the db is reloaded from the in-memory store
+	d, err = NewDB(context.Background(), nil, n, id, WithNewDatastore(store))
+	checkErr(t, err)
+	defer d.Close()
+	c = d.GetCollection("dummy")
+	if c == nil {
+		t.Fatal("expected the collection to be reloaded")
+	}
+	res, err = c.Find(Where("Counter").Ge(3))
+	checkErr(t, err)
+	if len(res) != 2 {
+		t.Fatalf("expected 2 instances found, got %d", len(res))
+	}
+}
+
+func TestWithNewEncryptionKey(t *testing.T) {
+	t.Parallel()
+	n, err := common.DefaultNetwork(
+		common.WithRepoDatastore(func(string) (ds.Batching, error) {
+			return memds.New(), nil
+		}),
+		common.WithNetHostAddr(util.FreeLocalAddr()),
+		common.WithNetDebug(true),
+	)
+	checkErr(t, err)
+	defer n.Close()
+
+	// a plaintext db is encrypted in place
+	store := memds.New()
+	id := thread.NewIDV1(thread.Raw, 32)
+	d, err := NewDB(context.Background(), nil, n, id, WithNewDatastore(store))
+	checkErr(t, err)
+	c, err := d.NewCollection(CollectionConfig{
+		Name:    "dummy",
+		Schema:  util.SchemaFromInstance(&dummy{}, false),
+		Indexes: []Index{{Path: "Name"}},
+	})
+	checkErr(t, err)
+	_, err = c.Create(util.JSONFromInstance(dummy{Name: "Textile0"}))
+	checkErr(t, err)
+	checkErr(t, d.Close())
+	key := sym.New()
+	if _, err := NewDB(context.Background(), nil, n, id, WithNewDatastore(store), WithNewEncryptionKey(key)); !errors.Is(err, encryption.ErrNotEncrypted) {
+		t.Fatalf("expected creating an encrypted db on a plaintext store to fail, got %v", err)
+	}
+	checkErr(t, encryption.Encrypt(kt.ExtendTxnDatastore(store), key))
+
+	d, err = NewDB(context.Background(), nil, n, id, WithNewDatastore(store), WithNewEncryptionKey(key))
+	checkErr(t, err)
+	c = d.GetCollection("dummy")
+	_, err = c.Create(util.JSONFromInstance(dummy{Name: "Textile1"}))
+	checkErr(t, err)
+	for _, name := range []string{"Textile0", "Textile1"} {
+		res, err := c.Find(Where("Name").Eq(name))
+		checkErr(t, err)
+		if len(res) != 1 {
+			t.Fatalf("expected 1 instance named %s found with the index, got %d", name, len(res))
+		}
+	}
+	checkErr(t, d.Close())
+
+	// values, like those of instances and index entries, aren't plaintext
+	res, err := store.Query(query.Query{})
+	checkErr(t, err)
+	entries, err := res.Rest()
+	checkErr(t, err)
+	for _, e := range entries {
+		if bytes.Contains(e.Value, []byte("Textile")) || bytes.Contains(e.Value, []byte("dummy")) {
+			t.Fatalf("expected the value at %s to be encrypted, got %s", e.Key, e.Value)
+		}
+	}
+
+	if _, err := NewDB(context.Background(), nil, n, id, WithNewDatastore(store), WithNewEncryptionKey(sym.New())); !errors.Is(err, encryption.ErrWrongKey) {
+		t.Fatalf("expected creating the db with another key to fail, got %v", err)
+	}
+	if _, err := NewDB(context.Background(), nil, n, id, WithNewDatastore(store)); !errors.Is(err, encryption.ErrKeyRequired) {
+		t.Fatalf("expected creating the db without its key to fail, got %v", err)
+	}
+}
+
 func TestListeners(t *testing.T) {
 	t.Parallel()
 
@@ -274,8 +406,15 @@ func TestListeners(t *testing.T) {
 		if len(actions) != len(expected) {
 			t.Fatalf("number of actions isn't correct, expected %d, got %d", len(expected), len(actions))
 		}
+		var seq uint64
 		for i := range actions {
-			if !reflect.DeepEqual(actions[i], expected[i]) {
+			if actions[i].Seq <= seq {
+				t.Fatalf("action sequence number %d doesn't increase from %d", actions[i].Seq, seq)
+			}
+			seq = actions[i].Seq
+			actual := actions[i]
+			actual.Seq = 0
+			if !reflect.DeepEqual(actual, expected[i]) {
 				t.Fatalf("wrong action detect, expected %v, got %v", expected[i], actions[i])
 			}
 		}
@@ -390,6 +529,163 @@ func TestListeners(t *testing.T) {
 		}
 		assertActions(actions, expected)
 	})
+	t.Run("QueryEvents", func(t *testing.T) {
+		t.Parallel()
+		actions := runListenersComplexUseCase(t, ListenOption{
+			Collection: "Collection1",
+			Query:      Where("Counter").Eq(30),
+		})
+		// the deleted instance matched before it was deleted
+		expected := []Action{
+			{Collection: "Collection1", Type: ActionSave, ID: "id-i1"},
+			{Collection: "Collection1", Type: ActionDelete, ID: "id-i1"},
+		}
+		assertActions(actions, expected)
+	})
+	t.Run("QueryAnyCollectionEvents", func(t *testing.T) {
+		t.Parallel()
+		actions := runListenersComplexUseCase(t,
+			ListenOption{Type: ListenCreate, Query: Where("Name").Eq("Textile2")},
+			ListenOption{Query: Where("Name").Eq("Textile33")},
+		)
+		expected := []Action{
+			{Collection: "Collection1", Type: ActionCreate, ID: "id-i2"},
+			{Collection: "Collection2", Type: ActionSave, ID: "id-j1"},
+			{Collection: "Collection2", Type: ActionDelete, ID: "id-j1"},
+		}
+		assertActions(actions, expected)
+	})
+	t.Run("InvalidQuery", func(t *testing.T) {
+		t.Parallel()
+		d, clean := createTestDB(t)
+		defer clean()
+		if _, err := d.Listen(ListenOption{Query: Where("Counter").Any(nil)}); err == nil {
+			t.Fatal("expected listening with an invalid query to fail")
+		}
+	})
+}
+
+func TestListenSince(t *testing.T) {
+	t.Parallel()
+
+	receive := func(t *testing.T, l Listener, n int) []Action {
+		var actions []Action
+		for len(actions) < n {
+			select {
+			case a := <-l.Channel():
+				actions = append(actions, a)
+			case <-time.After(5 * time.Second):
+				t.Fatalf("received %d actions, expected %d", len(actions), n)
+			}
+		}
+		return actions
+	}
+
+	t.Run("Replay", func(t *testing.T) {
+		t.Parallel()
+		d, clean := createTestDB(t)
+		defer clean()
+		c, err := d.NewCollection(CollectionConfig{Name: "Dog", Schema: util.SchemaFromInstance(&dummy{}, false)})
+		checkErr(t, err)
+		i1 := util.JSONFromInstance(dummy{ID: "id-i1", Name: "Textile1"})
+		_, err = c.Create(i1)
+		checkErr(t, err)
+		checkErr(t, c.Save(util.SetJSONProperty("Counter", 1, i1)))
+		_, err = c.Create(util.JSONFromInstance(dummy{ID: "id-i2", Name: "Textile2"}))
+		checkErr(t, err)
+		if seq := d.ActionSeq(); seq != 3 {
+			t.Fatalf("expected action seq 3, got %d", seq)
+		}
+
+		l, err := d.Listen(Since(1), ListenOption{Type: ListenCreate}, ListenOption{Type: ListenDelete})
+		checkErr(t, err)
+		defer l.Close()
+		checkErr(t, c.Delete("id-i1"))
+		expected := []Action{
+			{Collection: "Dog", Type: ActionCreate, ID: "id-i2", Seq: 3},
+			{Collection: "Dog", Type: ActionDelete, ID: "id-i1", Seq: 4},
+		}
+		if actions := receive(t, l, 2); !reflect.DeepEqual(actions, expected) {
+			t.Fatalf("expected actions %v, got %v", expected, actions)
+		}
+
+		current, err := d.Listen(Since(d.ActionSeq()))
+		checkErr(t, err)
+		defer current.Close()
+		checkErr(t, c.Save(util.SetJSONProperty("Counter", 2, util.JSONFromInstance(dummy{ID: "id-i2", Name: "Textile2"}))))
+		if a := receive(t, current, 1)[0]; a.Type != ActionSave || a.Seq != 5 {
+			t.Fatalf("expected live save with seq 5, got %v", a)
+		}
+
+		d.seq = 0
+		checkErr(t, d.loadActionSeq())
+		if d.seq != 5 {
+			t.Fatalf("expected loaded action seq 5, got %d", d.seq)
+		}
+	})
+	t.Run("NoGaps", func(t *testing.T) {
+		t.Parallel()
+		d, clean := createTestDB(t)
+		defer clean()
+		c, err := d.NewCollection(CollectionConfig{Name: "Dog", Schema: util.SchemaFromInstance(&dummy{}, false)})
+		checkErr(t, err)
+		i := util.JSONFromInstance(dummy{ID: "id-i1", Name: "Textile1"})
+		_, err = c.Create(i)
+		checkErr(t, err)
+
+		const saves = 20
+		done := make(chan error)
+		go func() {
+			for n := 1; n <= saves; n++ {
+				if err := c.Save(util.SetJSONProperty("Counter", n, i)); err != nil {
+					done <- err
+					return
+				}
+			}
+			done <- nil
+		}()
+		time.Sleep(50 * time.Millisecond)
+		l, err := d.Listen(Since(0))
+		checkErr(t, err)
+		defer l.Close()
+		actions := receive(t, l, saves+1)
+		checkErr(t, <-done)
+		for n, a := range actions {
+			if a.Seq != uint64(n+1) {
+				t.Fatalf("expected action %d to have seq %d, got %v", n, n+1, a)
+			}
+		}
+	})
+	t.Run("Pruned", func(t *testing.T) {
+		t.Parallel()
+		d, clean := createTestDB(t, WithNewActionLogSize(2))
+		defer clean()
+		c, err := d.NewCollection(CollectionConfig{Name: "Dog", Schema: util.SchemaFromInstance(&dummy{}, false)})
+		checkErr(t, err)
+		for n := 1; n <= 4; n++ {
+			_, err := c.Create(util.JSONFromInstance(dummy{ID: core.InstanceID(fmt.Sprintf("id-i%d", n))}))
+			checkErr(t, err)
+		}
+		for _, since := range []uint64{0, 1, 5} {
+			_, err := d.Listen(Since(since))
+			var pruned *ActionsPrunedError
+			if !errors.As(err, &pruned) || !errors.Is(err, ErrActionsPruned) {
+				t.Fatalf("expected listening since %d to be pruned, got %v", since, err)
+			}
+			if pruned.Oldest != 3 || pruned.Last != 4 {
+				t.Fatalf("expected oldest action 3 of 4, got %d of %d", pruned.Oldest, pruned.Last)
+			}
+		}
+		l, err := d.Listen(Since(2))
+		checkErr(t, err)
+		defer l.Close()
+		if actions := receive(t, l, 2); actions[0].ID != "id-i3" || actions[1].ID != "id-i4" {
+			t.Fatalf("expected the kept actions to be replayed, got %v", actions)
+		}
+		if _, err := d.Listen(Since(2), ListenOption{Query: Where("Name").Eq("")}); !errors.Is(err, ErrListenQuerySince) {
+			t.Fatalf("expected resuming with a query to fail, got %v", err)
+		}
+	})
 }
 
 // runListenersComplexUseCase runs a complex db use-case, and returns
@@ -512,3 +808,742 @@ func (dec *mockEventCodec) EventsFromBytes([]byte) ([]core.Event, error) {
 	dec.called = true
 	return nil, nil
 }
+
+func TestDumpRestore(t *testing.T) {
+	t.Parallel()
+	d1, clean := createTestDB(t, WithNewName("backup"))
+	defer clean()
+	c1, err := d1.NewCollection(CollectionConfig{
+		Name:    "Person",
+		Schema:  util.SchemaFromInstance(&Person{}, false),
+		Indexes: []Index{{Path: "Name", Unique: true}},
+	})
+	checkErr(t, err)
+	ids, err := c1.CreateMany([][]byte{
+		util.JSONFromInstance(Person{Name: "Alice", Age: 42}),
+		util.JSONFromInstance(Person{Name: "Bob", Age: 24}),
+	})
+	checkErr(t, err)
+	_, err = d1.NewCollection(CollectionConfig{
+		Name:       "Dog",
+		Schema:     util.SchemaFromInstance(&Dog{}, false),
+		SoftDelete: true,
+	})
+	checkErr(t, err)
+	var dump bytes.Buffer
+	checkErr(t, d1.Dump(context.Background(), &dump))
+	info1, err := d1.GetDBInfo()
+	checkErr(t, err)
+
+	newNetAndStore := func(t *testing.T) (app.Net, kt.TxnDatastoreExtended, func()) {
+		dir, err := ioutil.TempDir("", "")
+		checkErr(t, err)
+		n, err := common.DefaultNetwork(
+			common.WithNetBadgerPersistence(dir),
+			common.WithNetHostAddr(util.FreeLocalAddr()),
+			common.WithNetDebug(true),
+		)
+		checkErr(t, err)
+		store, err := util.NewBadgerDatastore(dir, "eventstore", false)
+		checkErr(t, err)
+		return n, store, func() {
+			_ = n.Close()
+			_ = store.Close()
+			_ = os.RemoveAll(dir)
+		}
+	}
+
+	t.Run("Restore", func(t *testing.T) {
+		t.Parallel()
+		n, store, clean := newNetAndStore(t)
+		defer clean()
+		d2, err := RestoreDB(context.Background(), store, n, bytes.NewReader(dump.Bytes()))
+		checkErr(t, err)
+		defer d2.Close()
+		if d2.name != "backup" {
+			t.Fatalf("expected name backup, got %s", d2.name)
+		}
+		info2, err := d2.GetDBInfo()
+		checkErr(t, err)
+		if d2.connector.ThreadID() != d1.connector.ThreadID() || !bytes.Equal(info2.Key.Bytes(), info1.Key.Bytes()) {
+			t.Fatal("expected restored db to have the thread of the dumped one")
+		}
+		if dog := d2.GetCollection("Dog"); dog == nil || !dog.GetSoftDelete() {
+			t.Fatal("expected restored soft-delete collection Dog")
+		}
+
+		c2 := d2.GetCollection("Person")
+		res, err := c2.Find(Where("Name").Eq("Bob").UseIndex("Name"))
+		checkErr(t, err)
+		if len(res) != 1 {
+			t.Fatalf("expected 1 instance from the restored index, got %d", len(res))
+		}
+		p := &Person{}
+		util.InstanceFromJSON(res[0], p)
+		if p.ID != ids[1] || p.Age != 24 {
+			t.Fatalf("expected restored instance %s, got %v", ids[1], p)
+		}
+		if _, err := c2.Create(util.JSONFromInstance(Person{Name: "Bob"})); !errors.Is(err, ErrUniqueExists) {
+			t.Fatalf("expected error %v, got %v", ErrUniqueExists, err)
+		}
+		_, err = c2.Create(util.JSONFromInstance(Person{Name: "Carol", Age: 33}))
+		checkErr(t, err)
+	})
+	t.Run("NotEmpty", func(t *testing.T) {
+		t.Parallel()
+		n, store, clean := newNetAndStore(t)
+		defer clean()
+		checkErr(t, store.Put(ds.NewKey("leftover"), []byte("x")))
+		if _, err := RestoreDB(context.Background(), store, n, bytes.NewReader(dump.Bytes())); !errors.Is(err, ErrDBNotEmpty) {
+			t.Fatalf("expected error %v, got %v", ErrDBNotEmpty, err)
+		}
+		d2, err := RestoreDB(context.Background(), store, n, bytes.NewReader(dump.Bytes()), WithNewForceRestore(true))
+		checkErr(t, err)
+		defer d2.Close()
+		if exists, err := store.Has(ds.NewKey("leftover")); err != nil || exists {
+			t.Fatalf("expected forced restore to clear the store, got %v (%v)", exists, err)
+		}
+		count, err := d2.GetCollection("Person").Count(&Query{})
+		checkErr(t, err)
+		if count != 2 {
+			t.Fatalf("expected 2 restored instances, got %d", count)
+		}
+	})
+	t.Run("Version", func(t *testing.T) {
+		t.Parallel()
+		n, store, clean := newNetAndStore(t)
+		defer clean()
+		newer := bytes.Replace(dump.Bytes(), []byte(`{"version":1,`), []byte(`{"version":2,`), 1)
+		if _, err := RestoreDB(context.Background(), store, n, bytes.NewReader(newer)); !errors.Is(err, ErrUnsupportedDumpVersion) {
+			t.Fatalf("expected error %v, got %v", ErrUnsupportedDumpVersion, err)
+		}
+	})
+}
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:    "Dog",
+		Schema:  util.SchemaFromInstance(&Dog2{}, false),
+		Indexes: []Index{{Path: "FullName"}},
+	})
+	checkErr(t, err)
+	_, err = d.NewCollection(CollectionConfig{
+		Name:   "Cat",
+		Schema: util.SchemaFromInstance(&Dog{}, false),
+	})
+	checkErr(t, err)
+	empty, err := d.CollectionStats("Dog")
+	checkErr(t, err)
+	if empty.Instances != 0 || empty.IndexEntries != 0 || !empty.LastWrite.IsZero() {
+		t.Fatalf("expected the stats of an empty collection, got %+v", empty)
+	}
+	if _, err := d.CollectionStats("Bird"); !errors.Is(err, ErrCollectionNotFound) {
+		t.Fatalf("expected the stats of a missing collection to fail, got %v", err)
+	}
+
+	ids, err := c.CreateMany([][]byte{
+		util.JSONFromInstance(Dog2{FullName: "Fido", Toys: Toys{Names: []string{}}, Comments: []Comment{}}),
+		util.JSONFromInstance(Dog2{FullName: "Rex", Toys: Toys{Names: []string{}}, Comments: []Comment{}}),
+		util.JSONFromInstance(Dog2{FullName: "Max", Toys: Toys{Names: []string{}}, Comments: []Comment{}}),
+	})
+	checkErr(t, err)
+	checkErr(t, c.Save(util.JSONFromInstance(Dog2{ID: ids[0], FullName: "Fido the Great", Toys: Toys{Names: []string{}}, Comments: []Comment{}})))
+	checkErr(t, c.Delete(ids[2]))
+
+	cs, err := d.CollectionStats("Dog")
+	checkErr(t, err)
+	if cs.Instances != 2 || cs.InstanceBytes == 0 {
+		t.Fatalf("expected the stats of 2 instances, got %+v", cs)
+	}
+	// entries of the _id and FullName indexes
+	if cs.IndexEntries != 4 || cs.IndexBytes == 0 {
+		t.Fatalf("expected the stats of 4 index entries, got %+v", cs)
+	}
+	if cs.LastWrite.IsZero() || time.Since(cs.LastWrite) > time.Minute {
+		t.Fatalf("expected the time of the last write, got %v", cs.LastWrite)
+	}
+	stats, err := d.Stats()
+	checkErr(t, err)
+	if len(stats.Collections) != 2 || stats.Collections[0].Name != "Cat" || stats.Collections[1] != cs {
+		t.Fatalf("expected the stats of each collection, got %+v", stats.Collections)
+	}
+	if stats.Instances != 2 || stats.IndexEntries != 4 || stats.InstanceBytes != cs.InstanceBytes {
+		t.Fatalf("expected the totals of the collections, got %+v", stats)
+	}
+	// 3 creates, a save and a delete
+	if stats.Dispatcher.Entries != 5 || stats.ActionLog.Entries != 5 || stats.ActionLog.Bytes == 0 {
+		t.Fatalf("expected the stats of the logs, got %+v and %+v", stats.Dispatcher, stats.ActionLog)
+	}
+
+	t.Run("Recalculate", func(t *testing.T) {
+		// kept stats match the ones of the data
+		checkErr(t, d.RecalculateStats())
+		recalculated, err := d.Stats()
+		checkErr(t, err)
+		if !reflect.DeepEqual(recalculated, stats) {
+			t.Fatalf("expected the recalculated stats to match, got %+v, want %+v", recalculated, stats)
+		}
+
+		// and they're calculated for dbs which didn't keep them
+		checkErr(t, d.datastore.Delete(dsStats.ChildString("Dog")))
+		checkErr(t, d.datastore.Delete(dsActionStats))
+		checkErr(t, d.initStats())
+		initialized, err := d.Stats()
+		checkErr(t, err)
+		initialized.Collections[1].LastWrite = cs.LastWrite
+		if !reflect.DeepEqual(initialized, stats) {
+			t.Fatalf("expected the initialized stats to match, got %+v, want %+v", initialized, stats)
+		}
+	})
+	t.Run("Delete", func(t *testing.T) {
+		checkErr(t, d.DeleteCollection("Dog"))
+		stats, err := d.Stats()
+		checkErr(t, err)
+		if len(stats.Collections) != 1 || stats.Instances != 0 {
+			t.Fatalf("expected the stats of the remaining collection, got %+v", stats)
+		}
+		if stats.Dispatcher.Entries != 0 || stats.Dispatcher.Bytes != 0 {
+			t.Fatalf("expected the dispatched events to be purged, got %+v", stats.Dispatcher)
+		}
+		if has, _ := d.datastore.Has(dsStats.ChildString("Dog")); has {
+			t.Fatal("expected the stats of the deleted collection to be deleted")
+		}
+	})
+}
+
+func TestGC(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "")
+	checkErr(t, err)
+	defer os.RemoveAll(dir)
+	n, err := common.DefaultNetwork(
+		common.WithNetBadgerPersistence(dir),
+		common.WithNetHostAddr(util.FreeLocalAddr()),
+		common.WithNetDebug(true),
+	)
+	checkErr(t, err)
+	defer n.Close()
+	// small value log files, which fill up with garbage quickly, and no
+	// collections but those of the test
+	opts := badger.DefaultOptions
+	opts.ValueLogFileSize = 1 << 20
+	opts.GcInterval = 0
+	store, err := util.OpenBadgerDatastore(filepath.Join(dir, "eventstore"), &opts)
+	checkErr(t, err)
+	defer store.Close()
+	d, err := NewDB(context.Background(), store, n, thread.NewIDV1(thread.Raw, 32))
+	checkErr(t, err)
+	defer d.Close()
+
+	// the instances of a deleted collection, and its events, are garbage
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "dummy",
+		Schema: util.SchemaFromInstance(&dummy{}, false),
+	})
+	checkErr(t, err)
+	name := strings.Repeat("Textile", 150)
+	for i := 0; i < 150; i++ {
+		batch := make([][]byte, 10)
+		for j := range batch {
+			batch[j] = util.JSONFromInstance(dummy{Name: name, Counter: i})
+		}
+		_, err := c.CreateMany(batch)
+		checkErr(t, err)
+	}
+	checkErr(t, d.DeleteCollection("dummy"))
+
+	// collecting garbage while writing to the db doesn't block either
+	c, err = d.NewCollection(CollectionConfig{
+		Name:   "live",
+		Schema: util.SchemaFromInstance(&dummy{}, false),
+	})
+	checkErr(t, err)
+	var reclaimed uint64
+	writers, gcs := make(chan error), make(chan error)
+	for w := 0; w < 4; w++ {
+		go func() {
+			writers <- func() error {
+				for i := 0; i < 25; i++ {
+					res, err := c.Create(util.JSONFromInstance(dummy{Name: name, Counter: i}))
+					if err != nil {
+						return err
+					}
+					updated := util.JSONFromInstance(dummy{ID: res, Name: name + "2", Counter: i})
+					if err := c.Save(updated); err != nil {
+						return err
+					}
+				}
+				return nil
+			}()
+		}()
+	}
+	stop := make(chan struct{})
+	go func() {
+		gcs <- func() error {
+			for {
+				select {
+				case <-stop:
+					return nil
+				default:
+				}
+				n, err := d.GC(context.Background())
+				if err != nil {
+					return err
+				}
+				reclaimed += n
+			}
+		}()
+	}()
+	timeout := time.After(time.Minute * 2)
+	for w := 0; w < 4; w++ {
+		select {
+		case err := <-writers:
+			checkErr(t, err)
+		case <-timeout:
+			t.Fatal("timed out writing while collecting garbage")
+		}
+	}
+	close(stop)
+	select {
+	case err := <-gcs:
+		checkErr(t, err)
+	case <-timeout:
+		t.Fatal("timed out collecting garbage while writing")
+	}
+
+	n2, err := d.GC(context.Background())
+	checkErr(t, err)
+	reclaimed += n2
+	if reclaimed == 0 {
+		t.Fatal("expected garbage to be collected")
+	}
+	instances, err := c.Find(&Query{})
+	checkErr(t, err)
+	if len(instances) != 100 {
+		t.Fatalf("expected the instances written while collecting garbage, got %d", len(instances))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := d.GC(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected collecting garbage to stop with the context, got %v", err)
+	}
+}
+
+func TestWriteTxn(t *testing.T) {
+	t.Parallel()
+	setup := func(t *testing.T, d *DB) (*Collection, *Collection) {
+		people, err := d.NewCollection(CollectionConfig{
+			Name:    "Person",
+			Schema:  util.SchemaFromInstance(&Person{}, false),
+			Indexes: []Index{{Path: "Name", Unique: true}},
+		})
+		checkErr(t, err)
+		dogs, err := d.NewCollection(CollectionConfig{
+			Name:   "Dog",
+			Schema: util.SchemaFromInstance(&Dog{}, false),
+		})
+		checkErr(t, err)
+		return people, dogs
+	}
+	records := func(t *testing.T, d *DB) int64 {
+		info, err := d.connector.Net.GetThread(context.Background(), d.connector.ThreadID())
+		checkErr(t, err)
+		var n int64
+		for _, l := range info.Logs {
+			n += l.Head.Counter
+		}
+		return n
+	}
+	write := func(d *DB, person Person, dog Dog) ([]core.InstanceID, error) {
+		var ids []core.InstanceID
+		err := d.WriteTxn(context.Background(), func(txn *MultiTxn) error {
+			people, err := txn.Collection("Person")
+			if err != nil {
+				return err
+			}
+			pids, err := people.Create(util.JSONFromInstance(person))
+			if err != nil {
+				return err
+			}
+			dogs, err := txn.Collection("Dog")
+			if err != nil {
+				return err
+			}
+			dids, err := dogs.Create(util.JSONFromInstance(dog))
+			if err != nil {
+				return err
+			}
+			ids = append(pids, dids...)
+			return nil
+		})
+		return ids, err
+	}
+	count := func(t *testing.T, c *Collection) int {
+		n, err := c.Count(&Query{})
+		checkErr(t, err)
+		return n
+	}
+
+	t.Run("SingleRecord", func(t *testing.T) {
+		t.Parallel()
+		d, clean := createTestDB(t)
+		defer clean()
+		people, dogs := setup(t, d)
+		before := records(t, d)
+
+		ids, err := write(d, Person{Name: "Alice"}, Dog{Name: "Rex", Comments: []Comment{}})
+		checkErr(t, err)
+		if n := records(t, d) - before; n != 1 {
+			t.Fatalf("expected %d record, got %d", 1, n)
+		}
+		if ok, err := people.Has(ids[0]); err != nil || !ok {
+			t.Fatalf("expected the person to be created, got %v", err)
+		}
+		if ok, err := dogs.Has(ids[1]); err != nil || !ok {
+			t.Fatalf("expected the dog to be created, got %v", err)
+		}
+	})
+	t.Run("Fail/UniqueIndex", func(t *testing.T) {
+		t.Parallel()
+		d, clean := createTestDB(t)
+		defer clean()
+		people, dogs := setup(t, d)
+		_, err := write(d, Person{Name: "Alice"}, Dog{Name: "Rex", Comments: []Comment{}})
+		checkErr(t, err)
+		before := records(t, d)
+
+		// the person conflicts, so the dog isn't created either
+		if _, err := write(d, Person{Name: "Alice"}, Dog{Name: "Fido", Comments: []Comment{}}); !errors.Is(err, ErrUniqueExists) {
+			t.Fatalf("expected error %v, got %v", ErrUniqueExists, err)
+		}
+		if n := records(t, d) - before; n != 0 {
+			t.Fatalf("expected %d records, got %d", 0, n)
+		}
+		if count(t, people) != 1 || count(t, dogs) != 1 {
+			t.Fatal("writes of a failed transaction should not be committed")
+		}
+	})
+	t.Run("Fail/Discarded", func(t *testing.T) {
+		t.Parallel()
+		d, clean := createTestDB(t)
+		defer clean()
+		people, dogs := setup(t, d)
+		err := d.WriteTxn(context.Background(), func(txn *MultiTxn) error {
+			p, err := txn.Collection("Person")
+			checkErr(t, err)
+			_, err = p.Create(util.JSONFromInstance(Person{Name: "Alice"}))
+			checkErr(t, err)
+			dg, err := txn.Collection("Dog")
+			checkErr(t, err)
+			dg.Discard()
+			return nil
+		})
+		if !errors.Is(err, errAlreadyDiscardedCommitedTxn) {
+			t.Fatalf("expected error %v, got %v", errAlreadyDiscardedCommitedTxn, err)
+		}
+		if count(t, people) != 0 || count(t, dogs) != 0 {
+			t.Fatal("writes of a discarded transaction should not be committed")
+		}
+	})
+	t.Run("Fail/CollectionCommit", func(t *testing.T) {
+		t.Parallel()
+		d, clean := createTestDB(t)
+		defer clean()
+		setup(t, d)
+		err := d.WriteTxn(context.Background(), func(txn *MultiTxn) error {
+			if _, err := txn.Collection("Cat"); !errors.Is(err, ErrCollectionNotFound) {
+				t.Fatalf("expected error %v, got %v", ErrCollectionNotFound, err)
+			}
+			p, err := txn.Collection("Person")
+			checkErr(t, err)
+			return p.Commit()
+		})
+		if !errors.Is(err, ErrMultiTxnHandle) {
+			t.Fatalf("expected error %v, got %v", ErrMultiTxnHandle, err)
+		}
+	})
+	t.Run("Remote", func(t *testing.T) {
+		t.Parallel()
+		peer := func(t *testing.T) (common.NetBoostrapper, kt.TxnDatastoreExtended, func()) {
+			dir, err := ioutil.TempDir("", "")
+			checkErr(t, err)
+			n, err := common.DefaultNetwork(
+				common.WithNetBadgerPersistence(dir),
+				common.WithNetHostAddr(util.FreeLocalAddr()),
+				common.WithNetDebug(true),
+			)
+			checkErr(t, err)
+			store, err := util.NewBadgerDatastore(dir, "eventstore", false)
+			checkErr(t, err)
+			return n, store, func() {
+				_ = n.Close()
+				_ = store.Close()
+				_ = os.RemoveAll(dir)
+			}
+		}
+		n1, store1, clean1 := peer(t)
+		defer clean1()
+		id := thread.NewIDV1(thread.Raw, 32)
+		d1, err := NewDB(context.Background(), store1, n1, id)
+		checkErr(t, err)
+		defer d1.Close()
+		setup(t, d1)
+
+		ti, err := n1.GetThread(context.Background(), id)
+		checkErr(t, err)
+		addr, err := multiaddr.NewMultiaddr(fmt.Sprintf("%s/p2p/%s/thread/%s", n1.Host().Addrs()[0], n1.Host().ID(), id))
+		checkErr(t, err)
+		n2, store2, clean2 := peer(t)
+		defer clean2()
+		d2, err := NewDBFromAddr(context.Background(), store2, n2, addr, ti.Key)
+		checkErr(t, err)
+		defer d2.Close()
+		people2, dogs2 := setup(t, d2)
+
+		ids, err := write(d1, Person{Name: "Alice"}, Dog{Name: "Rex", Comments: []Comment{}})
+		checkErr(t, err)
+		synced := func() bool {
+			person, err := people2.Has(ids[0])
+			checkErr(t, err)
+			dog, err := dogs2.Has(ids[1])
+			checkErr(t, err)
+			return person && dog
+		}
+		deadline := time.Now().Add(time.Second * 15)
+		for !synced() {
+			if time.Now().After(deadline) {
+				t.Fatal("expected the instances to be synced")
+			}
+			time.Sleep(time.Millisecond * 100)
+		}
+	})
+}
+
+func TestClose(t *testing.T) {
+	t.Parallel()
+	setup := func(t *testing.T, opts ...NewOption) (*DB, *Collection, func()) {
+		d, clean := createTestDB(t, opts...)
+		c, err := d.NewCollection(CollectionConfig{
+			Name:   "dummy",
+			Schema: util.SchemaFromInstance(&dummy{}, false),
+		})
+		checkErr(t, err)
+		return d, c, clean
+	}
+	records := func(t *testing.T, d *DB) int64 {
+		info, err := d.connector.Net.GetThread(context.Background(), d.connector.ThreadID())
+		checkErr(t, err)
+		var n int64
+		for _, l := range info.Logs {
+			n += l.Head.Counter
+		}
+		return n
+	}
+	stored := func(t *testing.T, c *Collection) int {
+		res, err := c.db.datastore.Query(query.Query{Prefix: c.baseKey().String(), KeysOnly: true})
+		checkErr(t, err)
+		entries, err := res.Rest()
+		checkErr(t, err)
+		return len(entries)
+	}
+
+	t.Run("NewOperations", func(t *testing.T) {
+		t.Parallel()
+		d, c, clean := setup(t)
+		defer clean()
+		id, err := c.Create(util.JSONFromInstance(dummy{Name: "Textile"}))
+		checkErr(t, err)
+		checkErr(t, d.Close())
+		checkErr(t, d.Close())
+
+		if _, err := c.Create(util.JSONFromInstance(dummy{Name: "Textile"})); !errors.Is(err, ErrDBClosed) {
+			t.Fatalf("expected create to fail with %v, got %v", ErrDBClosed, err)
+		}
+		if _, err := c.FindByID(id); !errors.Is(err, ErrDBClosed) {
+			t.Fatalf("expected find by id to fail with %v, got %v", ErrDBClosed, err)
+		}
+		if _, err := c.Find(&Query{}); !errors.Is(err, ErrDBClosed) {
+			t.Fatalf("expected find to fail with %v, got %v", ErrDBClosed, err)
+		}
+		if _, err := d.Listen(); !errors.Is(err, ErrDBClosed) {
+			t.Fatalf("expected listen to fail with %v, got %v", ErrDBClosed, err)
+		}
+		if err := d.WriteTxn(context.Background(), func(*MultiTxn) error { return nil }); !errors.Is(err, ErrDBClosed) {
+			t.Fatalf("expected write txn to fail with %v, got %v", ErrDBClosed, err)
+		}
+		if _, err := d.NewCollection(CollectionConfig{
+			Name:   "other",
+			Schema: util.SchemaFromInstance(&dummy{}, false),
+		}); !errors.Is(err, ErrDBClosed) {
+			t.Fatalf("expected new collection to fail with %v, got %v", ErrDBClosed, err)
+		}
+	})
+	t.Run("Listeners", func(t *testing.T) {
+		t.Parallel()
+		d, _, clean := setup(t)
+		defer clean()
+		l1, err := d.Listen()
+		checkErr(t, err)
+		l2, err := d.Listen(Since(0))
+		checkErr(t, err)
+		checkErr(t, d.Close())
+		for _, l := range []Listener{l1, l2} {
+			select {
+			case a := <-l.Channel():
+				if a.Type != ActionClose {
+					t.Fatalf("expected close action, got %v", a)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("expected close action")
+			}
+			if _, ok := <-l.Channel(); ok {
+				t.Fatal("expected the channel to be closed after the close action")
+			}
+			l.Close()
+		}
+	})
+	t.Run("GracePeriod/Drain", func(t *testing.T) {
+		t.Parallel()
+		d, c, clean := setup(t, WithNewCloseGracePeriod(time.Second*5))
+		defer clean()
+		started, committed := make(chan struct{}), make(chan error)
+		go func() {
+			committed <- c.WriteTxn(func(txn *Txn) error {
+				close(started)
+				time.Sleep(time.Millisecond * 500)
+				_, err := txn.Create(util.JSONFromInstance(dummy{Name: "Textile"}))
+				return err
+			})
+		}()
+		<-started
+		checkErr(t, d.Close())
+		checkErr(t, <-committed)
+		if n := stored(t, c); n != 1 {
+			t.Fatalf("expected the in-flight transaction to be committed, got %d instances", n)
+		}
+	})
+	t.Run("GracePeriod/Abort", func(t *testing.T) {
+		t.Parallel()
+		d, c, clean := setup(t, WithNewCloseGracePeriod(time.Millisecond*100))
+		defer clean()
+		before := records(t, d)
+		started, release, committed := make(chan struct{}), make(chan struct{}), make(chan error)
+		go func() {
+			committed <- c.WriteTxn(func(txn *Txn) error {
+				if _, err := txn.Create(util.JSONFromInstance(dummy{Name: "Textile"})); err != nil {
+					return err
+				}
+				close(started)
+				<-release
+				return nil
+			})
+		}()
+		<-started
+		checkErr(t, d.Close())
+		close(release)
+		if err := <-committed; !errors.Is(err, ErrDBClosed) {
+			t.Fatalf("expected the transaction to be aborted with %v, got %v", ErrDBClosed, err)
+		}
+		if n := stored(t, c); n != 0 {
+			t.Fatalf("expected no instances of an aborted transaction, got %d", n)
+		}
+		if n := records(t, d) - before; n != 0 {
+			t.Fatalf("expected no records of an aborted transaction, got %d", n)
+		}
+	})
+	t.Run("Concurrent", func(t *testing.T) {
+		t.Parallel()
+		d, c, clean := setup(t, WithNewCloseGracePeriod(time.Millisecond*200))
+		defer clean()
+		before := records(t, d)
+		var created int64
+		var lock sync.Mutex
+		errs := make(chan error, 12)
+		var wg sync.WaitGroup
+		for w := 0; w < 4; w++ {
+			wg.Add(3)
+			go func() {
+				defer wg.Done()
+				for {
+					_, err := c.Create(util.JSONFromInstance(dummy{Name: "Textile"}))
+					if err != nil {
+						errs <- err
+						return
+					}
+					lock.Lock()
+					created++
+					lock.Unlock()
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for {
+					if _, err := c.Find(&Query{}); err != nil {
+						errs <- err
+						return
+					}
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for {
+					l, err := d.Listen()
+					if err != nil {
+						errs <- err
+						return
+					}
+					var last Action
+					for a := range l.Channel() {
+						last = a
+					}
+					if last.Type != ActionClose {
+						errs <- fmt.Errorf("expected close action last, got %v", last)
+						return
+					}
+				}
+			}()
+		}
+		time.Sleep(time.Millisecond * 500)
+		closed := make(chan error)
+		go func() {
+			closed <- d.Close()
+		}()
+		select {
+		case err := <-closed:
+			checkErr(t, err)
+		case <-time.After(time.Second * 10):
+			t.Fatal("timed out closing")
+		}
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second * 10):
+			t.Fatal("timed out ending operations")
+		}
+		close(errs)
+		for err := range errs {
+			if !errors.Is(err, ErrDBClosed) {
+				t.Fatalf("expected operations to fail with %v, got %v", ErrDBClosed, err)
+			}
+		}
+
+		// every create either committed its record and instance, or neither
+		if created == 0 {
+			t.Fatal("expected instances to be created before closing")
+		}
+		if n := stored(t, c); int64(n) != created {
+			t.Fatalf("expected %d instances, got %d", created, n)
+		}
+		if n := records(t, d) - before; n != created {
+			t.Fatalf("expected %d records, got %d", created, n)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// maxSchemaRefs is the max number of references followed to find the
+// schema of a value, which stops recursive references.
+const maxSchemaRefs = 32
+
+// schemaTree is a JSON schema read to apply its default values and
+// normalize its date-time values.
+type schemaTree struct {
+	root map[string]interface{}
+}
+
+// newSchemaTree parses the JSON schema.
+func newSchemaTree(schema []byte) (*schemaTree, error) {
+	var root map[string]interface{}
+	if err := decodeJSON(schema, &root); err != nil {
+		return nil, err
+	}
+	return &schemaTree{root: root}, nil
+}
+
+// applyDefaults returns the instance with the default values of its absent
+// properties, and of absent properties of the objects it contains, or the
+// instance itself if none are absent. Absent objects are only created if
+// they have a default value.
+func (s *schemaTree) applyDefaults(instance []byte) ([]byte, error) {
+	var v interface{}
+	if err := decodeJSON(instance, &v); err != nil {
+		return nil, err
+	}
+	if !s.applyDefaultsTo(s.root, v) {
+		return instance, nil
+	}
+	return json.Marshal(v)
+}
+
+// applyDefaultsTo sets the default values of the absent properties of the value of
+// the schema node, and tells whether any was set.
+func (s *schemaTree) applyDefaultsTo(node map[string]interface{}, v interface{}) bool {
+	node = s.resolve(node)
+	var applied bool
+	switch v := v.(type) {
+	case map[string]interface{}:
+		props, _ := node["properties"].(map[string]interface{})
+		for name, p := range props {
+			prop, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			prop = s.resolve(prop)
+			if _, ok := v[name]; !ok {
+				d, ok := prop["default"]
+				if !ok {
+					continue
+				}
+				// each instance gets its own copy of objects and arrays
+				v[name] = copyJSONValue(d)
+				applied = true
+			}
+			applied = s.applyDefaultsTo(prop, v[name]) || applied
+		}
+	case []interface{}:
+		items, ok := node["items"].(map[string]interface{})
+		if !ok {
+			break
+		}
+		for _, item := range v {
+			applied = s.applyDefaultsTo(items, item) || applied
+		}
+	}
+	return applied
+}
+
+// resolve follows the local references of the schema node to the one they
+// point to, or to an empty node if they can't be followed.
+func (s *schemaTree) resolve(node map[string]interface{}) map[string]interface{} {
+	for i := 0; ; i++ {
+		ref, ok := node["$ref"].(string)
+		if !ok {
+			return node
+		}
+		if i == maxSchemaRefs || !strings.HasPrefix(ref, "#") {
+			return nil
+		}
+		node = s.root
+		for _, token := range strings.Split(strings.TrimPrefix(ref, "#"), "/")[1:] {
+			token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+			if node, ok = node[token].(map[string]interface{}); !ok {
+				return nil
+			}
+		}
+	}
+}
+
+// decodeJSON decodes data into v, keeping numbers as they're written.
+func decodeJSON(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// copyJSONValue returns a deep copy of the decoded JSON value.
+func copyJSONValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			c[k] = copyJSONValue(e)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, e := range v {
+			c[i] = copyJSONValue(e)
+		}
+		return c
+	default:
+		return v
+	}
+}
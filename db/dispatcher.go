@@ -64,11 +64,12 @@ func (d *dispatcher) Dispatch(events []core.Event) error {
 	d.lock.Lock()
 	defer d.lock.Unlock()
 
-	txn, err := d.store.NewTransaction(false)
+	dtxn, err := d.store.NewTransaction(false)
 	if err != nil {
 		return err
 	}
-	defer txn.Discard()
+	defer dtxn.Discard()
+	txn := trackStats(dtxn)
 	for _, event := range events {
 		key, err := getKey(event)
 		if err != nil {
@@ -105,7 +106,12 @@ func (d *dispatcher) Dispatch(events []core.Event) error {
 
 // Query searches the internal event store and returns a query result.
 // This is a synchronous version of github.com/ipfs/go-datastore's Query method.
+// Queries without a prefix search the dispatched events, rather than the
+// stats kept with them.
 func (d *dispatcher) Query(query query.Query) ([]query.Entry, error) {
+	if query.Prefix == "" {
+		query.Prefix = dsDispatcherPrefix.String()
+	}
 	result, err := d.store.Query(query)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,407 @@
+package db
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/alecthomas/jsonschema"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	logging "github.com/ipfs/go-log/v2"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/textileio/go-threads/core/app"
+	lstore "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	kt "github.com/textileio/go-threads/db/keytransform"
+	"github.com/textileio/go-threads/util"
+)
+
+// dumpVersion is the version of the dump format written by Dump. RestoreDB
+// reads dumps of this version or older.
+const dumpVersion = 1
+
+var (
+	// ErrUnsupportedDumpVersion indicates a dump was written by a newer release.
+	ErrUnsupportedDumpVersion = errors.New("unsupported dump version")
+	// ErrDBNotEmpty indicates a dump can't be restored into a datastore which isn't empty.
+	ErrDBNotEmpty = errors.New("db datastore isn't empty")
+)
+
+// dumpHeader is the first line of a dump, which describes the thread of the db.
+type dumpHeader struct {
+	Version int    `json:"version"`
+	Name    string `json:"name"`
+	Thread  string `json:"thread"`
+	Key     string `json:"key"`
+	// LogKey is the marshaled private key of the log of the host.
+	LogKey []byte `json:"logKey,omitempty"`
+}
+
+// dumpLine is a line of a dump after the header, which holds either a
+// collection or one of the instances of a collection on a line before.
+type dumpLine struct {
+	Collection *dumpCollection `json:"collection,omitempty"`
+	Instance   *dumpInstance   `json:"instance,omitempty"`
+}
+
+type dumpCollection struct {
+	Name           string          `json:"name"`
+	Schema         json.RawMessage `json:"schema"`
+	SchemaVersion  int             `json:"schemaVersion,omitempty"`
+	Indexes        []Index         `json:"indexes,omitempty"`
+	WriteValidator string          `json:"writeValidator,omitempty"`
+	ReadFilter     string          `json:"readFilter,omitempty"`
+	SoftDelete     bool            `json:"softDelete,omitempty"`
+	ExpiryField    string          `json:"expiryField,omitempty"`
+	ApplyDefaults  bool            `json:"applyDefaults,omitempty"`
+	LegacyBefore   int64           `json:"legacyBefore,omitempty"`
+}
+
+type dumpInstance struct {
+	Collection string          `json:"collection"`
+	Value      json.RawMessage `json:"value"`
+}
+
+// Dump writes a snapshot of the db to w, which RestoreDB reads to recreate
+// it without peers. The snapshot holds the collections with their instances,
+// including deleted and expired ones, and the thread key with the log key
+// of the host. It's read from a single transaction, so writes committed
+// while dumping aren't part of it.
+func (d *DB) Dump(ctx context.Context, w io.Writer, opts ...Option) error {
+	args := &Options{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if err := d.connector.Validate(args.Token, true); err != nil {
+		return err
+	}
+	info, err := d.connector.Net.GetThread(ctx, d.connector.ThreadID(), net.WithThreadToken(args.Token))
+	if err != nil {
+		return err
+	}
+	header := dumpHeader{
+		Version: dumpVersion,
+		Name:    d.name,
+		Thread:  info.ID.String(),
+		Key:     info.Key.String(),
+	}
+	for _, l := range info.Logs {
+		if l.PrivKey != nil {
+			if header.LogKey, err = crypto.MarshalPrivateKey(l.PrivKey); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	d.lock.RLock()
+	d.txnlock.RLock()
+	collections := make([]dumpCollection, 0, len(d.collections))
+	for _, c := range d.collections {
+		collections = append(collections, c.dumpConfig())
+	}
+	txn, err := d.datastore.NewTransaction(true)
+	d.txnlock.RUnlock()
+	d.lock.RUnlock()
+	if err != nil {
+		return err
+	}
+	defer txn.Discard()
+	sort.Slice(collections, func(i, j int) bool {
+		return collections[i].Name < collections[j].Name
+	})
+
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(header); err != nil {
+		return err
+	}
+	for i := range collections {
+		if err := enc.Encode(dumpLine{Collection: &collections[i]}); err != nil {
+			return err
+		}
+		if err := dumpInstances(ctx, enc, txn, collections[i].Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dumpConfig returns the config of the collection in a dump.
+func (c *Collection) dumpConfig() dumpCollection {
+	config := dumpCollection{
+		Name:           c.name,
+		Schema:         c.GetSchema(),
+		SchemaVersion:  c.schemaVersion,
+		WriteValidator: string(c.rawWriteValidator),
+		ReadFilter:     string(c.rawReadFilter),
+		SoftDelete:     c.softDelete,
+		ExpiryField:    c.expiryField,
+		ApplyDefaults:  c.defaults != nil,
+		LegacyBefore:   c.legacyBefore,
+	}
+	for path, index := range c.indexes {
+		if path != idFieldName {
+			config.Indexes = append(config.Indexes, index)
+		}
+	}
+	sort.Slice(config.Indexes, func(i, j int) bool {
+		return config.Indexes[i].Path < config.Indexes[j].Path
+	})
+	return config
+}
+
+// dumpInstances writes a line per instance of the collection read with txn.
+func dumpInstances(ctx context.Context, enc *json.Encoder, txn ds.Read, collection string) error {
+	res, err := txn.Query(query.Query{Prefix: baseKey.ChildString(collection).String()})
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+	for r := range res.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if r.Error != nil {
+			return r.Error
+		}
+		if err := enc.Encode(dumpLine{Instance: &dumpInstance{
+			Collection: collection,
+			Value:      r.Value,
+		}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreDB creates a DB from a snapshot written by Dump, which will *own* ds
+// and dispatcher for internal use, like NewDB.
+// The thread of the DB is created with the thread key and the log key of the
+// snapshot, unless the log key is given. The log records aren't part of the
+// snapshot, so it can be restored offline, but then peers with records
+// written before the dump shouldn't be synced with again.
+// The store must be empty, unless the restore is forced, in which case its
+// contents are deleted first.
+func RestoreDB(
+	ctx context.Context,
+	store kt.TxnDatastoreExtended,
+	network app.Net,
+	r io.Reader,
+	opts ...NewOption,
+) (*DB, error) {
+	log.Debug("restoring db")
+	args := &NewOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if err := util.SetLogLevels(map[string]logging.LogLevel{
+		"db": util.LevelFromDebugFlag(args.Debug),
+	}); err != nil {
+		return nil, err
+	}
+	store = datastoreFromOptions(store, args)
+
+	br := bufio.NewReader(r)
+	line, err := br.ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	var header dumpHeader
+	if err := json.Unmarshal(line, &header); err != nil {
+		return nil, fmt.Errorf("reading dump header: %w", err)
+	}
+	if header.Version < 1 || header.Version > dumpVersion {
+		return nil, fmt.Errorf("%w %d", ErrUnsupportedDumpVersion, header.Version)
+	}
+	id, err := thread.Decode(header.Thread)
+	if err != nil {
+		return nil, err
+	}
+	key, err := thread.KeyFromString(header.Key)
+	if err != nil {
+		return nil, err
+	}
+	if key.Defined() && !key.CanRead() {
+		return nil, ErrThreadReadKeyRequired
+	}
+	if args.LogKey == nil && header.LogKey != nil {
+		if args.LogKey, err = crypto.UnmarshalPrivateKey(header.LogKey); err != nil {
+			return nil, err
+		}
+	}
+	if args.Name == "" {
+		args.Name = header.Name
+	}
+	if err := clearStore(store, args.ForceRestore); err != nil {
+		return nil, err
+	}
+
+	if _, err := network.CreateThread(
+		ctx,
+		id,
+		net.WithThreadKey(key),
+		net.WithLogKey(args.LogKey),
+		net.WithNewThreadToken(args.Token),
+	); err != nil && !errors.Is(err, lstore.ErrThreadExists) && !errors.Is(err, lstore.ErrLogExists) {
+		return nil, err
+	}
+	d, err := newDB(store, network, id, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.restore(ctx, br, args.Token); err != nil {
+		_ = d.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+// clearStore returns ErrDBNotEmpty if store isn't empty, unless force is
+// set, in which case everything in store is deleted.
+func clearStore(store ds.TxnDatastore, force bool) error {
+	res, err := store.Query(query.Query{KeysOnly: true})
+	if err != nil {
+		return err
+	}
+	keys, err := res.Rest()
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	if !force {
+		return ErrDBNotEmpty
+	}
+	txn, err := store.NewTransaction(false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard()
+	for _, k := range keys {
+		if err := txn.Delete(ds.RawKey(k.Key)); err != nil {
+			return err
+		}
+	}
+	return txn.Commit()
+}
+
+// restore creates the collections and instances read from the lines of a
+// dump after its header. Instances are written and indexed in batches.
+func (d *DB) restore(ctx context.Context, br *bufio.Reader, token thread.Token) error {
+	var (
+		txn     ds.Txn
+		pending int
+	)
+	commit := func() error {
+		if txn == nil {
+			return nil
+		}
+		err := txn.Commit()
+		txn, pending = nil, 0
+		return err
+	}
+	defer func() {
+		if txn != nil {
+			txn.Discard()
+		}
+	}()
+
+	for n := 2; ; n++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		eof := err == io.EOF
+		if len(line) > 0 {
+			var l dumpLine
+			if err := json.Unmarshal(line, &l); err != nil {
+				return fmt.Errorf("dump line %d: %w", n, err)
+			}
+			switch {
+			case l.Collection != nil:
+				if err := commit(); err != nil {
+					return err
+				}
+				if err := d.restoreCollection(l.Collection, token); err != nil {
+					return fmt.Errorf("dump line %d: %w", n, err)
+				}
+			case l.Instance != nil:
+				c := d.GetCollection(l.Instance.Collection, WithToken(token))
+				if c == nil {
+					return fmt.Errorf("dump line %d: %w", n, ErrCollectionNotFound)
+				}
+				if txn == nil {
+					dtxn, err := d.datastore.NewTransaction(false)
+					if err != nil {
+						return err
+					}
+					txn = trackStats(dtxn)
+				}
+				if err := c.restoreInstance(txn, l.Instance.Value); err != nil {
+					return fmt.Errorf("dump line %d: %w", n, err)
+				}
+				if pending++; pending == DefaultImportBatchSize {
+					if err := commit(); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		if eof {
+			break
+		}
+	}
+	return commit()
+}
+
+// restoreCollection creates a collection of a dump.
+func (d *DB) restoreCollection(config *dumpCollection, token thread.Token) error {
+	schema := &jsonschema.Schema{}
+	if err := json.Unmarshal(config.Schema, schema); err != nil {
+		return err
+	}
+	c, err := d.NewCollection(CollectionConfig{
+		Name:           config.Name,
+		Schema:         schema,
+		Indexes:        config.Indexes,
+		WriteValidator: config.WriteValidator,
+		ReadFilter:     config.ReadFilter,
+		SoftDelete:     config.SoftDelete,
+		ExpiryField:    config.ExpiryField,
+		ApplyDefaults:  config.ApplyDefaults,
+	}, WithToken(token))
+	if err != nil {
+		return err
+	}
+	if config.SchemaVersion == 0 && config.LegacyBefore == 0 {
+		return nil
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	c.schemaVersion = config.SchemaVersion
+	c.legacyBefore = config.LegacyBefore
+	return d.saveCollection(c)
+}
+
+// restoreInstance writes and indexes an instance of a dump with txn.
+func (c *Collection) restoreInstance(txn ds.Txn, instance []byte) error {
+	id, err := getInstanceID(instance)
+	if err != nil {
+		return err
+	}
+	key := c.baseKey().ChildString(id.String())
+	if err := txn.Put(key, instance); err != nil {
+		return err
+	}
+	return c.indexAdd(txn, key, instance)
+}
@@ -0,0 +1,407 @@
+// Package encryption provides a datastore wrapper encrypting the values
+// written to it at rest, which can back dbs and managers.
+//
+// Values, including those of instances, indexes and collection metadata, are
+// sealed with AES-256-GCM under the key given, along with the datastore key
+// they're written at, so that values can't be moved between keys. Datastore
+// keys are kept in plaintext, as dbs rely on their order to seek and query,
+// so the names of collections can be read from them, along with instance IDs
+// and the values of indexed fields.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	sym "github.com/textileio/crypto/symmetric"
+	dse "github.com/textileio/go-datastore-extensions"
+	kt "github.com/textileio/go-threads/db/keytransform"
+)
+
+var (
+	// ErrWrongKey indicates a store is encrypted with another key.
+	ErrWrongKey = errors.New("datastore is encrypted with another key")
+	// ErrNotEncrypted indicates a store with plaintext values, which need
+	// encrypting with Encrypt first.
+	ErrNotEncrypted = errors.New("datastore isn't encrypted, encrypt it first")
+	// ErrKeyRequired indicates an encrypted store opened without its key.
+	ErrKeyRequired = errors.New("datastore is encrypted, its key is required")
+	// ErrDecrypt indicates a value which can't be decrypted, as it was
+	// tampered with or moved to another key.
+	ErrDecrypt = errors.New("decrypting value failed")
+)
+
+// CheckKey is the key of the value checking the key of an encrypted store.
+// It's hidden from the queries of the store.
+var CheckKey = ds.NewKey("/_encryption")
+
+const (
+	// checkValue is the value sealed at CheckKey.
+	checkValue = "go-threads encrypted datastore"
+	// encryptBatchSize is the number of values encrypted per transaction
+	// by Encrypt.
+	encryptBatchSize = 100
+)
+
+var (
+	_ kt.TxnDatastoreExtended = (*Datastore)(nil)
+	_ ds.Shim                 = (*Datastore)(nil)
+	_ dse.TxnExt              = (*txn)(nil)
+)
+
+// Datastore encrypts the values written to the store it wraps, and decrypts
+// those read from it.
+type Datastore struct {
+	child kt.TxnDatastoreExtended
+	aead  cipher.AEAD
+}
+
+// Wrap returns store with its values encrypted with key. An empty store is
+// marked as encrypted with key, and opening it with another key fails with
+// ErrWrongKey. Stores with plaintext values fail with ErrNotEncrypted.
+func Wrap(store kt.TxnDatastoreExtended, key *sym.Key) (*Datastore, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	d := &Datastore{child: store, aead: aead}
+	if err := d.check(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// IsEncrypted returns whether store is encrypted by Wrap.
+func IsEncrypted(store ds.Datastore) (bool, error) {
+	return store.Has(CheckKey)
+}
+
+// Encrypt encrypts the plaintext values of store in place with key, so that
+// it can be opened with Wrap once it's done. Values are encrypted in batches,
+// and as those encrypted already are skipped, Encrypt resumes when it's
+// interrupted. Stores encrypted with key are left as they are. The store
+// can't be in use meanwhile.
+func Encrypt(store kt.TxnDatastoreExtended, key *sym.Key) error {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+	d := &Datastore{child: store, aead: aead}
+	if err := d.check(); !errors.Is(err, ErrNotEncrypted) {
+		return err
+	}
+	res, err := store.Query(dsq.Query{KeysOnly: true})
+	if err != nil {
+		return err
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return err
+	}
+	for len(entries) > 0 {
+		n := encryptBatchSize
+		if n > len(entries) {
+			n = len(entries)
+		}
+		if err := d.encrypt(entries[:n]); err != nil {
+			return err
+		}
+		entries = entries[n:]
+	}
+	return d.putCheck(store)
+}
+
+// encrypt encrypts the values of entries in a transaction, skipping those
+// encrypted already.
+func (d *Datastore) encrypt(entries []dsq.Entry) error {
+	txn, err := d.child.NewTransaction(false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard()
+	for _, e := range entries {
+		k := ds.RawKey(e.Key)
+		v, err := txn.Get(k)
+		if errors.Is(err, ds.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if _, err := d.open(k, v); err == nil {
+			continue
+		}
+		if err := put(d, txn, k, v); err != nil {
+			return err
+		}
+	}
+	return txn.Commit()
+}
+
+func newAEAD(key *sym.Key) (cipher.AEAD, error) {
+	if key == nil || len(key.Bytes()) != sym.KeyBytes {
+		return nil, fmt.Errorf("encryption key must be %d bytes", sym.KeyBytes)
+	}
+	block, err := aes.NewCipher(key.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// check checks the store is encrypted with the key of d, marking it as such
+// if it's empty.
+func (d *Datastore) check() error {
+	v, err := d.child.Get(CheckKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		res, err := d.child.Query(dsq.Query{KeysOnly: true, Limit: 1})
+		if err != nil {
+			return err
+		}
+		entries, err := res.Rest()
+		if err != nil {
+			return err
+		}
+		if len(entries) > 0 {
+			return ErrNotEncrypted
+		}
+		return d.putCheck(d.child)
+	} else if err != nil {
+		return err
+	}
+	if v, err := d.open(CheckKey, v); err != nil || string(v) != checkValue {
+		return ErrWrongKey
+	}
+	return nil
+}
+
+func (d *Datastore) putCheck(w ds.Write) error {
+	v, err := d.seal(CheckKey, []byte(checkValue))
+	if err != nil {
+		return err
+	}
+	return w.Put(CheckKey, v)
+}
+
+// overhead is the size sealing adds to values.
+func (d *Datastore) overhead() int {
+	return d.aead.NonceSize() + d.aead.Overhead()
+}
+
+// seal encrypts value with the key it's written at as additional data.
+func (d *Datastore) seal(key ds.Key, value []byte) ([]byte, error) {
+	ns := d.aead.NonceSize()
+	nonce := make([]byte, ns, ns+len(value)+d.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return d.aead.Seal(nonce, nonce, value, key.Bytes()), nil
+}
+
+func (d *Datastore) open(key ds.Key, value []byte) ([]byte, error) {
+	ns := d.aead.NonceSize()
+	if len(value) < d.overhead() {
+		return nil, fmt.Errorf("%w at %s", ErrDecrypt, key)
+	}
+	v, err := d.aead.Open(nil, value[:ns], value[ns:], key.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%w at %s", ErrDecrypt, key)
+	}
+	return v, nil
+}
+
+// Children implements ds.Shim, returning the wrapped datastore.
+func (d *Datastore) Children() []ds.Datastore {
+	return []ds.Datastore{d.child}
+}
+
+func (d *Datastore) Get(key ds.Key) ([]byte, error) {
+	return get(d, d.child, key)
+}
+
+func (d *Datastore) Has(key ds.Key) (bool, error) {
+	return d.child.Has(key)
+}
+
+func (d *Datastore) GetSize(key ds.Key) (int, error) {
+	return ds.GetBackedSize(d, key)
+}
+
+func (d *Datastore) Query(q dsq.Query) (dsq.Results, error) {
+	return d.QueryExtended(dse.QueryExt{Query: q})
+}
+
+func (d *Datastore) QueryExtended(q dse.QueryExt) (dsq.Results, error) {
+	return query(d, d.child, q)
+}
+
+func (d *Datastore) Put(key ds.Key, value []byte) error {
+	return put(d, d.child, key, value)
+}
+
+func (d *Datastore) Delete(key ds.Key) error {
+	return d.child.Delete(key)
+}
+
+func (d *Datastore) Sync(prefix ds.Key) error {
+	return d.child.Sync(prefix)
+}
+
+func (d *Datastore) Close() error {
+	return d.child.Close()
+}
+
+func (d *Datastore) NewTransaction(readOnly bool) (ds.Txn, error) {
+	return d.NewTransactionExtended(readOnly)
+}
+
+func (d *Datastore) NewTransactionExtended(readOnly bool) (dse.TxnExt, error) {
+	t, err := d.child.NewTransactionExtended(readOnly)
+	if err != nil {
+		return nil, err
+	}
+	return &txn{ds: d, child: t}, nil
+}
+
+type txn struct {
+	ds    *Datastore
+	child dse.TxnExt
+}
+
+func (t *txn) Get(key ds.Key) ([]byte, error) {
+	return get(t.ds, t.child, key)
+}
+
+func (t *txn) Has(key ds.Key) (bool, error) {
+	return t.child.Has(key)
+}
+
+func (t *txn) GetSize(key ds.Key) (int, error) {
+	return ds.GetBackedSize(t, key)
+}
+
+func (t *txn) Query(q dsq.Query) (dsq.Results, error) {
+	return t.QueryExtended(dse.QueryExt{Query: q})
+}
+
+func (t *txn) QueryExtended(q dse.QueryExt) (dsq.Results, error) {
+	return query(t.ds, t.child, q)
+}
+
+func (t *txn) Put(key ds.Key, value []byte) error {
+	return put(t.ds, t.child, key, value)
+}
+
+func (t *txn) Delete(key ds.Key) error {
+	return t.child.Delete(key)
+}
+
+func (t *txn) Commit() error {
+	return t.child.Commit()
+}
+
+func (t *txn) Discard() {
+	t.child.Discard()
+}
+
+type queryExtender interface {
+	QueryExtended(q dse.QueryExt) (dsq.Results, error)
+}
+
+func get(d *Datastore, r ds.Read, key ds.Key) ([]byte, error) {
+	v, err := r.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return d.open(key, v)
+}
+
+func put(d *Datastore, w ds.Write, key ds.Key, value []byte) error {
+	v, err := d.seal(key, value)
+	if err != nil {
+		return err
+	}
+	return w.Put(key, v)
+}
+
+// query runs q against the wrapped store, decrypting the values of the
+// results. What applies to values, like their filters and orders, applies
+// once they're decrypted, and so do the offset and limit.
+func query(d *Datastore, qe queryExtender, q dse.QueryExt) (dsq.Results, error) {
+	child, naive := splitQuery(q)
+	res, err := qe.QueryExtended(child)
+	if err != nil {
+		return nil, err
+	}
+	check := CheckKey.String()
+	return dsq.NaiveQueryApply(naive, dsq.ResultsFromIterator(q.Query, dsq.Iterator{
+		Next: func() (dsq.Result, bool) {
+			for {
+				r, ok := res.NextSync()
+				if !ok || r.Error != nil {
+					return r, ok
+				}
+				if r.Key == check {
+					continue
+				}
+				if !child.KeysOnly {
+					v, err := d.open(ds.RawKey(r.Key), r.Value)
+					if err != nil {
+						return dsq.Result{Error: err}, true
+					}
+					r.Value, r.Size = v, len(v)
+					if q.KeysOnly {
+						r.Value = nil
+					}
+				} else if r.Size > d.overhead() {
+					r.Size -= d.overhead()
+				} else {
+					r.Size = -1
+				}
+				return r, true
+			}
+		},
+		Close: res.Close,
+	})), nil
+}
+
+// splitQuery returns the part of q the wrapped store runs, and the part
+// applied to its results. Filters run once values are decrypted, and so do
+// orders unless the first is by key. The offset and limit then follow, as do
+// they if the check key is in the range of q.
+func splitQuery(q dse.QueryExt) (dse.QueryExt, dsq.Query) {
+	child, naive := q, dsq.Query{}
+	keyOrdered := len(q.Orders) == 0
+	if !keyOrdered {
+		switch q.Orders[0].(type) {
+		case dsq.OrderByKey, *dsq.OrderByKey,
+			dsq.OrderByKeyDescending, *dsq.OrderByKeyDescending:
+			keyOrdered = true
+		}
+	}
+	if keyOrdered && len(q.Filters) == 0 && !inRange(q.Prefix) {
+		return child, naive
+	}
+	child.Filters, child.Offset, child.Limit = nil, 0, 0
+	naive.Filters, naive.Offset, naive.Limit = q.Filters, q.Offset, q.Limit
+	if keyOrdered {
+		// the first order by key is total, keeping seeking in its direction
+		if len(q.Orders) > 0 {
+			child.Orders = q.Orders[:1]
+		}
+	} else {
+		child.Orders, naive.Orders = nil, q.Orders
+	}
+	child.KeysOnly = q.KeysOnly && len(naive.Filters) == 0 && len(naive.Orders) == 0
+	return child, naive
+}
+
+// inRange returns whether the check key has prefix.
+func inRange(prefix string) bool {
+	p := ds.NewKey(prefix)
+	return p.String() == "/" || p.IsAncestorOf(CheckKey) || p.Equal(CheckKey)
+}
@@ -0,0 +1,180 @@
+package encryption_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	sym "github.com/textileio/crypto/symmetric"
+	"github.com/textileio/go-threads/db/encryption"
+	kt "github.com/textileio/go-threads/db/keytransform"
+	pt "github.com/textileio/go-threads/test"
+)
+
+func TestDatastoreConformance(t *testing.T) {
+	for name, factory := range pt.DatastoreFactories {
+		factory := encrypted(factory)
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			pt.DatastoreTest(t, factory)
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+	store, _ := pt.MemoryDatastore()
+	key := sym.New()
+	d, err := encryption.Wrap(store, key)
+	checkErr(t, err)
+	checkErr(t, d.Put(ds.NewKey("/a"), []byte("secret")))
+	raw, err := store.Get(ds.NewKey("/a"))
+	checkErr(t, err)
+	if bytes.Contains(raw, []byte("secret")) {
+		t.Fatal("expected the value to be encrypted in the wrapped store")
+	}
+	v, err := d.Get(ds.NewKey("/a"))
+	checkErr(t, err)
+	if string(v) != "secret" {
+		t.Fatalf("expected the value to be decrypted, got %s", v)
+	}
+	if _, err := encryption.Wrap(store, key); err != nil {
+		t.Fatalf("expected the store to be reopened with its key, got %v", err)
+	}
+	if _, err := encryption.Wrap(store, sym.New()); !errors.Is(err, encryption.ErrWrongKey) {
+		t.Fatalf("expected opening with another key to fail, got %v", err)
+	}
+	encrypted, err := encryption.IsEncrypted(store)
+	checkErr(t, err)
+	if !encrypted {
+		t.Fatal("expected the store to be encrypted")
+	}
+
+	// values are bound to the keys they're written at
+	checkErr(t, store.Put(ds.NewKey("/b"), raw))
+	if _, err := d.Get(ds.NewKey("/b")); !errors.Is(err, encryption.ErrDecrypt) {
+		t.Fatalf("expected a value moved to another key to fail decrypting, got %v", err)
+	}
+}
+
+func TestQueryValues(t *testing.T) {
+	t.Parallel()
+	store, _ := pt.MemoryDatastore()
+	d, err := encryption.Wrap(store, sym.New())
+	checkErr(t, err)
+	for k, v := range map[string]string{"/k/a": "3", "/k/b": "1", "/k/c": "4", "/k/d": "2"} {
+		checkErr(t, d.Put(ds.NewKey(k), []byte(v)))
+	}
+
+	// filters and orders of values apply once they're decrypted
+	res, err := d.Query(dsq.Query{
+		Filters: []dsq.Filter{dsq.FilterValueCompare{Op: dsq.GreaterThan, Value: []byte("1")}},
+		Orders:  []dsq.Order{dsq.OrderByValue{}},
+		Offset:  1,
+		Limit:   1,
+	})
+	checkErr(t, err)
+	entries, err := res.Rest()
+	checkErr(t, err)
+	if len(entries) != 1 || entries[0].Key != "/k/a" || string(entries[0].Value) != "3" {
+		t.Fatalf("expected /k/a with 3, got %v", entries)
+	}
+
+	// the check key is hidden
+	res, err = d.Query(dsq.Query{KeysOnly: true, Offset: 3})
+	checkErr(t, err)
+	entries, err = res.Rest()
+	checkErr(t, err)
+	if len(entries) != 1 || entries[0].Key != "/k/d" {
+		t.Fatalf("expected /k/d, got %v", entries)
+	}
+}
+
+func TestEncrypt(t *testing.T) {
+	t.Parallel()
+	store, closer := pt.BadgerDatastore()
+	defer closer()
+	values := make(map[ds.Key][]byte)
+	for i := 0; i < 250; i++ {
+		k := ds.NewKey(fmt.Sprintf("/k/%03d", i))
+		values[k] = []byte("plaintext " + k.String())
+		checkErr(t, store.Put(k, values[k]))
+	}
+	key := sym.New()
+	if _, err := encryption.Wrap(store, key); !errors.Is(err, encryption.ErrNotEncrypted) {
+		t.Fatalf("expected opening a plaintext store to fail, got %v", err)
+	}
+
+	checkErr(t, encryption.Encrypt(store, key))
+	d, err := encryption.Wrap(store, key)
+	checkErr(t, err)
+	for k, v := range values {
+		raw, err := store.Get(k)
+		checkErr(t, err)
+		if bytes.Equal(raw, v) {
+			t.Fatalf("expected the value at %s to be encrypted", k)
+		}
+		dv, err := d.Get(k)
+		checkErr(t, err)
+		if !bytes.Equal(dv, v) {
+			t.Fatalf("expected %s at %s, got %s", v, k, dv)
+		}
+	}
+
+	// encrypting again leaves the values as they are
+	checkErr(t, encryption.Encrypt(store, key))
+	for k, v := range values {
+		dv, err := d.Get(k)
+		checkErr(t, err)
+		if !bytes.Equal(dv, v) {
+			t.Fatalf("expected %s at %s, got %s", v, k, dv)
+		}
+	}
+	if err := encryption.Encrypt(store, sym.New()); !errors.Is(err, encryption.ErrWrongKey) {
+		t.Fatalf("expected encrypting with another key to fail, got %v", err)
+	}
+}
+
+func TestEncryptResumes(t *testing.T) {
+	t.Parallel()
+	store, _ := pt.MemoryDatastore()
+	key := sym.New()
+	checkErr(t, store.Put(ds.NewKey("/a"), []byte("a")))
+	checkErr(t, encryption.Encrypt(store, key))
+
+	// a value written while encrypting was interrupted, with the check key
+	// left to write
+	checkErr(t, store.Delete(encryption.CheckKey))
+	checkErr(t, store.Put(ds.NewKey("/b"), []byte("b")))
+	checkErr(t, encryption.Encrypt(store, key))
+	d, err := encryption.Wrap(store, key)
+	checkErr(t, err)
+	for _, k := range []string{"a", "b"} {
+		v, err := d.Get(ds.NewKey(k))
+		checkErr(t, err)
+		if string(v) != k {
+			t.Fatalf("expected %s, got %s", k, v)
+		}
+	}
+}
+
+func encrypted(factory pt.DatastoreFactory) pt.DatastoreFactory {
+	return func() (kt.TxnDatastoreExtended, func()) {
+		store, closer := factory()
+		d, err := encryption.Wrap(store, sym.New())
+		if err != nil {
+			panic(err)
+		}
+		return d, closer
+	}
+}
+
+func checkErr(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,173 @@
+package db
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/alecthomas/jsonschema"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/tidwall/gjson"
+)
+
+// Expired instances are hidden from queries on every peer as soon as their
+// expiry time passes on its clock, without writing delete events. Since the
+// expiry of an instance is a time in its data, peers agree on which instances
+// are expired, up to the skew of their clocks. The sweeper only deletes the
+// instances expired for longer than the grace period, which should exceed the
+// skew plus the time records take to reach peers, so changes of the expiry
+// made on a peer before the instance expired are received before sweeping it
+// elsewhere.
+
+const (
+	// DefaultExpirySweepInterval is the default interval at which expired
+	// instances are deleted.
+	DefaultExpirySweepInterval = time.Minute
+	// DefaultExpiryGracePeriod is the default time instances are expired
+	// before they're deleted.
+	DefaultExpiryGracePeriod = time.Minute
+)
+
+// maxExpirySeconds bounds the expiry times of instances, in seconds from the
+// Unix epoch either way, so far-off ones don't overflow.
+const maxExpirySeconds = 1 << 62
+
+// ErrInvalidExpiryField indicates the expiry field of a collection isn't a number.
+var ErrInvalidExpiryField = errors.New("expiry field must be a number")
+
+// checkExpiryField returns ErrInvalidExpiryField if the field at path isn't
+// a number in schema.
+func checkExpiryField(schema *jsonschema.Schema, path string) error {
+	jt, err := getSchemaTypeAtPath(schema, path)
+	if err != nil {
+		return err
+	}
+	if jt.Type != "number" && jt.Type != "integer" {
+		return ErrInvalidExpiryField
+	}
+	return nil
+}
+
+// expiresAt returns the expiry time of an instance of the collection, or
+// false if it doesn't expire.
+func (c *Collection) expiresAt(instance []byte) (time.Time, bool) {
+	if c.expiryField == "" {
+		return time.Time{}, false
+	}
+	res := gjson.GetBytes(instance, c.expiryField)
+	if res.Type != gjson.Number {
+		return time.Time{}, false
+	}
+	sec, frac := math.Modf(math.Max(-maxExpirySeconds, math.Min(res.Num, maxExpirySeconds)))
+	return time.Unix(int64(sec), int64(frac*float64(time.Second))), true
+}
+
+// expired tells whether an instance of the collection is expired at now.
+func (c *Collection) expired(instance []byte, now time.Time) bool {
+	at, ok := c.expiresAt(instance)
+	return ok && !now.Before(at)
+}
+
+// sweepExpiredLoop deletes expired instances at interval until the db is
+// closed.
+func (d *DB) sweepExpiredLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.closeCh:
+			return
+		case <-ticker.C:
+			if err := d.sweepExpired(); err != nil {
+				log.Errorf("sweeping expired instances in %s: %v", d.name, err)
+			}
+		}
+	}
+}
+
+// sweepExpired deletes the instances expired for longer than the grace
+// period. They're only deleted locally, since every peer sweeps them.
+func (d *DB) sweepExpired() error {
+	end, err := d.inflight.begin()
+	if errors.Is(err, ErrDBClosed) {
+		return nil
+	}
+	defer end()
+	d.lock.RLock()
+	var collections []*Collection
+	for _, c := range d.collections {
+		if c.expiryField != "" {
+			collections = append(collections, c)
+		}
+	}
+	d.lock.RUnlock()
+
+	d.txnlock.Lock()
+	defer d.txnlock.Unlock()
+	endCommit, err := d.inflight.beginCommit()
+	if errors.Is(err, ErrDBClosed) {
+		return nil
+	}
+	defer endCommit()
+	d.seqLock.Lock()
+	defer d.seqLock.Unlock()
+	before := d.now().Add(-d.expiryGracePeriod)
+	var actions []Action
+	for _, c := range collections {
+		swept, err := c.sweepExpired(before)
+		if err != nil {
+			return err
+		}
+		actions = append(actions, swept...)
+	}
+	d.notifyStateChanged(actions)
+	return nil
+}
+
+// sweepExpired deletes the instances of the collection expired at before,
+// logging their actions. The seq lock must be held.
+func (c *Collection) sweepExpired(before time.Time) (actions []Action, err error) {
+	dtxn, err := c.db.datastore.NewTransaction(false)
+	if err != nil {
+		return nil, err
+	}
+	defer dtxn.Discard()
+	txn := trackStats(dtxn)
+	lastSeq := c.db.seq
+	defer func() {
+		if err != nil {
+			c.db.seq = lastSeq
+		}
+	}()
+	res, err := txn.Query(query.Query{Prefix: c.baseKey().String()})
+	if err != nil {
+		return nil, err
+	}
+	instances, err := res.Rest()
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range instances {
+		if !c.expired(i.Value, before) {
+			continue
+		}
+		key := ds.RawKey(i.Key)
+		if err := txn.Delete(key); err != nil {
+			return nil, err
+		}
+		if err := c.indexDelete(txn, key, i.Value); err != nil {
+			return nil, err
+		}
+		a := Action{Collection: c.name, Type: ActionDelete, ID: core.InstanceID(key.Name()), instance: i.Value}
+		if err := c.db.logAction(txn, &a); err != nil {
+			return nil, err
+		}
+		actions = append(actions, a)
+	}
+	if err := txn.Commit(); err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
@@ -0,0 +1,208 @@
+package db
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ipfs/go-datastore/query"
+	core "github.com/textileio/go-threads/core/db"
+)
+
+// DefaultImportBatchSize is the default number of instances imported per
+// transaction.
+const DefaultImportBatchSize = 100
+
+// ImportOptions defines how instances are imported into a collection.
+type ImportOptions struct {
+	// Overwrite replaces existing instances with the imported ones, which
+	// are skipped otherwise.
+	Overwrite bool
+	// DryRun validates the instances and reports what would change, without
+	// writing them.
+	DryRun bool
+	// BatchSize is the number of instances written per transaction, which
+	// defaults to DefaultImportBatchSize.
+	BatchSize int
+	// Progress is called with the totals so far once each batch is written.
+	Progress func(ImportResult)
+}
+
+// ImportResult counts the instances of an import.
+type ImportResult struct {
+	// Created is the number of instances created.
+	Created int
+	// Updated is the number of existing instances overwritten.
+	Updated int
+	// Skipped is the number of existing instances left as they are.
+	Skipped int
+}
+
+// Export writes the instances of the collection to w as JSON Lines, one
+// instance per line including its _id. Instances hidden by the read filter,
+// deleted or expired aren't exported.
+func (c *Collection) Export(ctx context.Context, w io.Writer, opts ...TxnOption) error {
+	args := &TxnOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if err := c.db.connector.Validate(args.Token, true); err != nil {
+		return err
+	}
+	pk, err := args.Token.PubKey()
+	if err != nil {
+		return err
+	}
+	txn, err := c.db.datastore.NewTransaction(true)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard()
+	res, err := txn.Query(query.Query{Prefix: c.baseKey().String()})
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	var line bytes.Buffer
+	for r := range res.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if r.Error != nil {
+			return r.Error
+		}
+		v, err := c.filterResult(pk, r.Value, false)
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			continue
+		}
+		line.Reset()
+		if err := json.Compact(&line, v); err != nil {
+			return err
+		}
+		line.WriteByte('\n')
+		if _, err := w.Write(line.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Import creates the instances read from r as JSON Lines, such as written by
+// Export. Each instance is validated against the schema, and instances
+// without an _id get a new one. Instances are written in transactions of
+// the batch size, so if an instance is invalid, or ctx is done, the import
+// stops with an error and only the batches before it are written.
+// An instance can't be imported more than once.
+func (c *Collection) Import(ctx context.Context, r io.Reader, opts ImportOptions, txnOpts ...TxnOption) (ImportResult, error) {
+	args := &TxnOptions{}
+	for _, opt := range txnOpts {
+		opt(args)
+	}
+	if err := c.db.connector.Validate(args.Token, false); err != nil {
+		return ImportResult{}, err
+	}
+	size := opts.BatchSize
+	if size <= 0 {
+		size = DefaultImportBatchSize
+	}
+
+	var (
+		total   ImportResult
+		batch   ImportResult
+		creates [][]byte
+		saves   [][]byte
+	)
+	flush := func() error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !opts.DryRun {
+			if err := c.WriteTxn(func(txn *Txn) error {
+				if len(creates) > 0 {
+					if _, err := txn.Create(creates...); err != nil {
+						return err
+					}
+				}
+				if len(saves) > 0 {
+					return txn.Save(saves...)
+				}
+				return nil
+			}, txnOpts...); err != nil {
+				return err
+			}
+		}
+		total.Created += batch.Created
+		total.Updated += batch.Updated
+		total.Skipped += batch.Skipped
+		batch, creates, saves = ImportResult{}, nil, nil
+		if opts.Progress != nil {
+			opts.Progress(total)
+		}
+		return nil
+	}
+
+	imported := make(map[core.InstanceID]bool)
+	br := bufio.NewReader(r)
+	for n := 1; ; n++ {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		line, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return total, err
+		}
+		eof := err == io.EOF
+		if instance := bytes.TrimSpace(line); len(instance) > 0 {
+			id, err := getInstanceID(instance)
+			if err != nil && !errors.Is(err, errMissingInstanceID) {
+				return total, fmt.Errorf("line %d: %w", n, err)
+			}
+			if id == core.EmptyInstanceID {
+				id, instance = setNewInstanceID(instance)
+			}
+			if err := c.validInstance(instance); err != nil {
+				return total, fmt.Errorf("line %d: %w", n, err)
+			}
+			if imported[id] {
+				return total, fmt.Errorf("line %d: instance %s is imported more than once", n, id)
+			}
+			imported[id] = true
+			exists, err := c.db.datastore.Has(c.baseKey().ChildString(id.String()))
+			if err != nil {
+				return total, err
+			}
+			switch {
+			case !exists:
+				creates = append(creates, instance)
+				batch.Created++
+			case opts.Overwrite:
+				saves = append(saves, instance)
+				batch.Updated++
+			default:
+				batch.Skipped++
+			}
+			if batch.Created+batch.Updated+batch.Skipped == size {
+				if err := flush(); err != nil {
+					return total, err
+				}
+			}
+		}
+		if eof {
+			break
+		}
+	}
+	if batch != (ImportResult{}) {
+		if err := flush(); err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
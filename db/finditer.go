@@ -0,0 +1,194 @@
+package db
+
+import (
+	"context"
+
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// Iterator iterates over the results of a query, which it reads from a
+// snapshot of the collection, in the order Find returns them. Results are
+// read as the iterator advances, except those of queries sorted in memory,
+// which are read on the first call to Next. It isn't safe for concurrent use.
+type Iterator interface {
+	// Next advances to the next result, and returns false once there are
+	// none, or the iteration failed or its context is canceled.
+	Next() bool
+	// Value returns the instance of the current result.
+	Value() []byte
+	// Err returns the error the iteration failed with, or the error of its
+	// context once it's canceled.
+	Err() error
+	// Close releases the snapshot read.
+	Close()
+}
+
+// findIterator is the Iterator of a planned query.
+type findIterator struct {
+	ctx     context.Context
+	txn     *Txn
+	plan    *findPlan
+	pk      thread.PubKey
+	iter    *iterator
+	discard func()
+
+	// sorted are the results of the page sorted in memory, which are read
+	// once
+	sorted []sortedValue
+	read   bool
+	// count is the number of results read, and returned the number of them
+	// returned
+	count, returned int
+	value           []byte
+	err             error
+	closed          bool
+}
+
+// FindIter queries for instances by Query like Find, returning an iterator
+// over the results. The snapshot of the collection it reads is released once
+// the iterator is closed.
+func (c *Collection) FindIter(ctx context.Context, q *Query, opts ...TxnOption) (Iterator, error) {
+	txn, err := c.db.newReadTxn(c, opts...)
+	if err != nil {
+		return nil, err
+	}
+	it, err := txn.findIter(ctx, q)
+	if err != nil {
+		txn.snapshot.Discard()
+		return nil, err
+	}
+	discard := it.discard
+	it.discard = func() {
+		discard()
+		txn.snapshot.Discard()
+	}
+	return it, nil
+}
+
+// FindIter queries for instances by Query like Find, returning an iterator
+// over the results. In a read transaction, it reads the snapshot of the
+// transaction, so it must be closed before the transaction ends.
+func (t *Txn) FindIter(ctx context.Context, q *Query) (Iterator, error) {
+	return t.findIter(ctx, q)
+}
+
+func (t *Txn) findIter(ctx context.Context, q *Query) (*findIterator, error) {
+	p, err := t.planFind(q)
+	if err != nil {
+		return nil, err
+	}
+	pk, err := t.token.PubKey()
+	if err != nil {
+		return nil, err
+	}
+	txn, discard, err := t.datastoreTxn()
+	if err != nil {
+		return nil, err
+	}
+	iter, err := t.iterate(txn, p)
+	if err != nil {
+		discard()
+		return nil, err
+	}
+	return &findIterator{ctx: ctx, txn: t, plan: p, pk: pk, iter: iter, discard: discard}, nil
+}
+
+func (it *findIterator) Next() bool {
+	it.value = nil
+	if it.closed || it.err != nil {
+		return false
+	}
+	if it.plan.inMemory {
+		return it.nextSorted()
+	}
+	q := it.plan.query
+	if q.Limit > 0 && it.returned == q.Limit {
+		return false
+	}
+	for {
+		res, ok := it.next()
+		if !ok {
+			return false
+		}
+		if it.plan.skipped(res) {
+			continue
+		}
+		it.count++
+		if it.count > q.Skip {
+			it.value = res.Value
+			it.returned++
+			return true
+		}
+	}
+}
+
+// nextSorted advances to the next result of the page sorted in memory,
+// reading them all first.
+func (it *findIterator) nextSorted() bool {
+	if !it.read {
+		it.read = true
+		var values []MarshaledResult
+		for {
+			res, ok := it.next()
+			if !ok {
+				break
+			}
+			values = append(values, res)
+		}
+		if it.err != nil {
+			return false
+		}
+		if it.sorted, _, it.err = it.plan.sortPage(values); it.err != nil {
+			return false
+		}
+	}
+	if len(it.sorted) == 0 {
+		return false
+	}
+	it.value = it.sorted[0].result.Value
+	it.sorted = it.sorted[1:]
+	return true
+}
+
+// next returns the next result matching the query which the read filter
+// doesn't filter out, checking the context first.
+func (it *findIterator) next() (MarshaledResult, bool) {
+	for {
+		if it.err = it.ctx.Err(); it.err != nil {
+			return MarshaledResult{}, false
+		}
+		// like FindPage, results end where the iterator stops, as when
+		// the index used isn't found
+		res, ok := it.iter.NextSync()
+		if !ok {
+			return res, false
+		}
+		value, err := it.txn.collection.filterResult(it.pk, res.Value, it.plan.query.IncludeDeleted)
+		if err != nil {
+			it.err = err
+			return res, false
+		}
+		if value != nil {
+			res.Value = value
+			return res, true
+		}
+	}
+}
+
+func (it *findIterator) Value() []byte {
+	return it.value
+}
+
+func (it *findIterator) Err() error {
+	return it.err
+}
+
+func (it *findIterator) Close() {
+	if it.closed {
+		return
+	}
+	it.closed = true
+	it.value, it.sorted = nil, nil
+	it.iter.Close()
+	it.discard()
+}
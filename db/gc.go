@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/textileio/go-threads/util"
+)
+
+// GC collects the garbage of the store of the db, like the value log of a
+// badger store, returning the bytes reclaimed. The db remains usable while
+// it runs, and it stops early if ctx is canceled. Stores are shared by the
+// dbs of a manager, whose garbage is collected at once with Manager.GC.
+func (d *DB) GC(ctx context.Context, opts ...Option) (uint64, error) {
+	args := &Options{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if err := d.connector.Validate(args.Token, false); err != nil {
+		return 0, err
+	}
+	return d.gc(ctx)
+}
+
+func (d *DB) gc(ctx context.Context) (uint64, error) {
+	n, err := util.CollectGarbage(ctx, d.datastore)
+	if err != nil {
+		return 0, err
+	}
+	log.Debugf("collected %d bytes of garbage in %s", n, d.name)
+	return n, nil
+}
+
+// GC collects the garbage of the store of the manager's dbs, returning the
+// bytes reclaimed. The dbs remain usable while it runs.
+func (m *Manager) GC(ctx context.Context) (uint64, error) {
+	n, err := util.CollectGarbage(ctx, m.store)
+	if err != nil {
+		return 0, err
+	}
+	log.Debugf("collected %d bytes of garbage in manager", n)
+	return n, nil
+}
+
+// gcLoop collects garbage with gc at interval until done is closed, which
+// cancels a collection running then.
+func gcLoop(interval time.Duration, done chan struct{}, gc func(context.Context) (uint64, error), name string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-done
+		cancel()
+	}()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if _, err := gc(ctx); err != nil && ctx.Err() == nil {
+				log.Errorf("collecting garbage in %s: %v", name, err)
+			}
+		}
+	}
+}
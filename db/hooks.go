@@ -0,0 +1,168 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	core "github.com/textileio/go-threads/core/db"
+)
+
+// ErrHookChangedID indicates a hook changed the ID of the instance it was
+// given.
+var ErrHookChangedID = errors.New("hook changed the instance ID")
+
+// Hooks are functions run when instances of a collection are written, to
+// compute derived fields for example.
+//
+// Before hooks run when a transaction writes an instance locally, including
+// the instances saved by migrations, and an error they return aborts the
+// write. They don't run for the events of remote peers, which peers apply as
+// they were written so their instances converge.
+//
+// After hooks run with the instance once it's committed, for local writes
+// and the events of remote peers alike, and for instances deleted as they
+// expire. They run outside of the transaction, in the order instances are
+// committed, by a goroutine of the db, so they can write to the db but
+// writes don't wait for them. They don't run once the db is closed.
+//
+// Hooks are Go functions, so they can't be set over the gRPC API, and they
+// aren't saved with the collection. They're set with the config of
+// NewCollection or UpdateCollection, kept by MigrateCollection, and set again
+// with SetHooks once the db is reopened.
+type Hooks struct {
+	// BeforeCreate returns the instance to create in place of the given
+	// one, which is validated afterwards.
+	BeforeCreate func(instance []byte) ([]byte, error)
+	// BeforeSave returns the instance to save in place of the given one,
+	// which is validated afterwards.
+	BeforeSave func(instance []byte) ([]byte, error)
+	// BeforeDelete is given the ID of an instance to delete.
+	BeforeDelete func(id core.InstanceID) error
+	// AfterCreate is given a created instance.
+	AfterCreate func(instance []byte)
+	// AfterSave is given a saved instance.
+	AfterSave func(instance []byte)
+	// AfterDelete is given a deleted instance.
+	AfterDelete func(instance []byte)
+}
+
+// SetHooks sets the hooks of the collection, in place of its current ones.
+func (c *Collection) SetHooks(hooks Hooks) {
+	c.hooksLock.Lock()
+	defer c.hooksLock.Unlock()
+	c.hooks = hooks
+}
+
+// getHooks returns the hooks of the collection.
+func (c *Collection) getHooks() Hooks {
+	c.hooksLock.RLock()
+	defer c.hooksLock.RUnlock()
+	return c.hooks
+}
+
+// beforeWrite runs a hook returning the instance to write in place of the
+// given one, which must keep its ID.
+func beforeWrite(hook func([]byte) ([]byte, error), id core.InstanceID, instance []byte) ([]byte, error) {
+	if hook == nil {
+		return instance, nil
+	}
+	hooked, err := hook(instance)
+	if err != nil {
+		return nil, fmt.Errorf("running hook on instance %s: %w", id, err)
+	}
+	hookedID, err := getInstanceID(hooked)
+	if err != nil {
+		return nil, fmt.Errorf("running hook on instance %s: %w", id, err)
+	}
+	if hookedID != id {
+		return nil, fmt.Errorf("running hook on instance %s: %w", id, ErrHookChangedID)
+	}
+	return hooked, nil
+}
+
+// hookCall is an After hook along with the instance to run it with.
+type hookCall struct {
+	hook     func(instance []byte)
+	instance []byte
+}
+
+// hookQueue runs the After hooks of committed actions in order.
+type hookQueue struct {
+	lock  sync.Mutex
+	calls []hookCall
+	wake  chan struct{}
+}
+
+func newHookQueue() *hookQueue {
+	return &hookQueue{wake: make(chan struct{}, 1)}
+}
+
+// queueAfterHooks queues the After hooks of the collections of the actions.
+func (d *DB) queueAfterHooks(actions []Action) {
+	var calls []hookCall
+	for _, a := range actions {
+		switch a.Type {
+		case ActionCreate, ActionSave, ActionDelete:
+		default:
+			continue
+		}
+		d.lock.RLock()
+		c := d.collections[a.Collection]
+		d.lock.RUnlock()
+		if c == nil {
+			continue
+		}
+		hooks := c.getHooks()
+		var hook func([]byte)
+		switch a.Type {
+		case ActionCreate:
+			hook = hooks.AfterCreate
+		case ActionSave:
+			hook = hooks.AfterSave
+		case ActionDelete:
+			hook = hooks.AfterDelete
+		}
+		if hook != nil {
+			calls = append(calls, hookCall{hook: hook, instance: a.instance})
+		}
+	}
+	if len(calls) == 0 {
+		return
+	}
+	d.afterHooks.lock.Lock()
+	d.afterHooks.calls = append(d.afterHooks.calls, calls...)
+	d.afterHooks.lock.Unlock()
+	select {
+	case d.afterHooks.wake <- struct{}{}:
+	default:
+	}
+}
+
+// runHooks runs the queued After hooks until the db is closed.
+func (d *DB) runHooks() {
+	for {
+		select {
+		case <-d.closeCh:
+			return
+		case <-d.afterHooks.wake:
+		}
+		for {
+			d.afterHooks.lock.Lock()
+			calls := d.afterHooks.calls
+			d.afterHooks.calls = nil
+			d.afterHooks.lock.Unlock()
+			if len(calls) == 0 {
+				break
+			}
+			for _, call := range calls {
+				select {
+				case <-d.closeCh:
+					return
+				default:
+				}
+				call.hook(call.instance)
+			}
+		}
+	}
+}
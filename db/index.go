@@ -6,15 +6,20 @@ package db
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/alecthomas/jsonschema"
 	ds "github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
 	dse "github.com/textileio/go-datastore-extensions"
+	core "github.com/textileio/go-threads/core/db"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 )
@@ -22,6 +27,10 @@ import (
 const (
 	// iteratorKeyMinCacheSize is the size of iterator keys stored in memory before more are fetched.
 	iteratorKeyMinCacheSize = 100
+	// indexFormat is the version of the format of index keys. Before
+	// version 1, the keys of indexes of a single field were their values
+	// as strings, which don't sort like the values.
+	indexFormat = 1
 )
 
 var (
@@ -33,6 +42,9 @@ var (
 	ErrCantCreateUniqueIndex = errors.New("can't create unique index (duplicate instances exist)")
 	// ErrIndexNotFound indicates a requested index was not found.
 	ErrIndexNotFound = errors.New("index not found")
+	// ErrMissingIndexQuery indicates a query uses the index of a field to
+	// find instances missing it, which the index doesn't hold.
+	ErrMissingIndexQuery = errors.New("index doesn't hold instances missing its field")
 
 	indexPrefix = ds.NewKey("_index")
 	indexTypes  = []string{"string", "number", "integer", "boolean"}
@@ -44,6 +56,13 @@ type Index struct {
 	Path string `json:"path"`
 	// Unique indicates that only one instance should exist per field value.
 	Unique bool `json:"unique,omitempty"`
+	// FullText indicates that the words of a string field are indexed, so
+	// that instances can be found by Search queries.
+	FullText bool `json:"fullText,omitempty"`
+	// Fields are the paths of the fields of a composite index, in order.
+	// The Path of a composite index only names it, and defaults to the
+	// fields separated by commas.
+	Fields []string `json:"fields,omitempty"`
 }
 
 // GetIndexes returns the current indexes.
@@ -67,8 +86,10 @@ func (c *Collection) GetIndexes() []Index {
 // The field at path must be one of the supported JSON Schema types: string, number, integer, or boolean
 // Set unique to true if you want a unique constraint on path.
 // Adding an index will override any overlapping index values if they already exist.
+// Set fullText to true to index the words of a string field for Search queries.
+// Set fields to index the values of several fields together, in order.
 // @note: This does NOT currently build the index. If items have been added prior to adding
-// a new index, they will NOT be indexed a posteriori.
+// a new index, they will NOT be indexed until the index is rebuilt with RebuildIndex.
 func (c *Collection) addIndex(schema *jsonschema.Schema, index Index, opts ...Option) error {
 	args := &Options{}
 	for _, opt := range opts {
@@ -83,43 +104,26 @@ func (c *Collection) addIndex(schema *jsonschema.Schema, index Index, opts ...Op
 	}
 
 	// Validate path and type.
-	jt, err := getSchemaTypeAtPath(schema, index.Path)
-	if err != nil {
-		return err
-	}
-	var valid bool
-	for _, t := range indexTypes {
-		if jt.Type == t {
-			valid = true
-			break
-		}
+	if len(index.Fields) > 0 && index.Path == "" {
+		index.Path = strings.Join(index.Fields, ",")
 	}
-	if !valid {
-		return ErrNotIndexable
+	if err := checkIndexable(schema, index); err != nil {
+		return err
 	}
 
 	// Skip if nothing to do
-	if x, ok := c.indexes[index.Path]; ok && index.Unique == x.Unique {
+	if x, ok := c.indexes[index.Path]; ok && reflect.DeepEqual(index, x) {
 		return nil
 	}
 
 	// Ensure collection does not contain multiple instances with the same value at path
 	if index.Unique && index.Path != idFieldName {
-		vals := make(map[interface{}]struct{})
 		all, err := c.Find(&Query{}, WithTxnToken(args.Token))
 		if err != nil {
 			return err
 		}
-		for _, i := range all {
-			res := gjson.GetBytes(i, index.Path)
-			if !res.Exists() {
-				continue
-			}
-			if _, ok := vals[res.Value()]; ok {
-				return ErrCantCreateUniqueIndex
-			} else {
-				vals[res.Value()] = struct{}{}
-			}
+		if err := uniqueDuplicates(index, all); err != nil {
+			return err
 		}
 	}
 
@@ -127,6 +131,70 @@ func (c *Collection) addIndex(schema *jsonschema.Schema, index Index, opts ...Op
 	return c.saveIndexes()
 }
 
+// checkIndexable returns an error if the fields of the index aren't
+// indexable in schema.
+func checkIndexable(schema *jsonschema.Schema, index Index) error {
+	if len(index.Fields) > 0 {
+		if index.FullText {
+			return ErrNotIndexable
+		}
+		for _, field := range index.Fields {
+			if _, err := getIndexableType(schema, field); err != nil {
+				return err
+			}
+		}
+	} else {
+		jt, err := getIndexableType(schema, index.Path)
+		if errors.Is(err, ErrNotIndexable) && isIndexableArray(schema, index.Path) {
+			// each element is indexed, so they can't be unique or searched
+			if index.Unique || index.FullText {
+				return ErrNotIndexable
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if index.FullText && jt != "string" {
+			return ErrNotIndexable
+		}
+	}
+	if index.FullText && index.Unique {
+		return ErrUniqueFullTextIndex
+	}
+	return nil
+}
+
+// getIndexableType returns the JSON Schema type of the field at path, or
+// ErrNotIndexable if it's not one of the indexable types.
+func getIndexableType(schema *jsonschema.Schema, pth string) (string, error) {
+	jt, err := getSchemaTypeAtPath(schema, pth)
+	if err != nil {
+		return "", err
+	}
+	for _, t := range indexTypes {
+		if jt.Type == t {
+			return jt.Type, nil
+		}
+	}
+	return "", ErrNotIndexable
+}
+
+// isIndexableArray tells whether the field at path is an array of one of the
+// indexable types, whose elements are indexed separately.
+func isIndexableArray(schema *jsonschema.Schema, pth string) bool {
+	jt, err := getSchemaTypeAtPath(schema, pth)
+	if err != nil || jt.Type != "array" || jt.Items == nil {
+		return false
+	}
+	for _, t := range indexTypes {
+		if jt.Items.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
 // dropIndex drops the index at path.
 func (c *Collection) dropIndex(pth string) error {
 	// Don't allow the default index to be dropped
@@ -137,6 +205,103 @@ func (c *Collection) dropIndex(pth string) error {
 	return c.saveIndexes()
 }
 
+// RebuildIndex rebuilds the index at path from the current instances of the
+// collection. Indexes added to a collection which already has instances only
+// cover the instances written afterwards until they're rebuilt.
+func (c *Collection) RebuildIndex(path string, opts ...TxnOption) error {
+	args := &TxnOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if err := c.db.connector.Validate(args.Token, false); err != nil {
+		return err
+	}
+	c.db.txnlock.Lock()
+	defer c.db.txnlock.Unlock()
+	index, ok := c.indexes[path]
+	if !ok {
+		return ErrIndexNotFound
+	}
+
+	txn, err := c.db.datastore.NewTransaction(false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard()
+	stxn := trackStats(txn)
+	if err := c.rebuildIndex(stxn, path, index); err != nil {
+		return err
+	}
+	return stxn.Commit()
+}
+
+// rebuildIndex rebuilds the index at path from the instances of the
+// collection with txn.
+func (c *Collection) rebuildIndex(txn ds.Txn, path string, index Index) error {
+	prefix := indexPrefix.Child(c.baseKey()).ChildString(path)
+	if index.FullText {
+		prefix = textIndexPrefix.Child(c.baseKey()).ChildString(path)
+	}
+	if err := deletePrefix(txn, prefix); err != nil {
+		return err
+	}
+	res, err := txn.Query(query.Query{Prefix: c.baseKey().String()})
+	if err != nil {
+		return err
+	}
+	instances, err := res.Rest()
+	if err != nil {
+		return err
+	}
+	for _, i := range instances {
+		if err := c.indexUpdate(path, index, txn, ds.RawKey(i.Key), i.Value, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// upgradeIndexes rebuilds the indexes of single fields of the collections
+// if they were built with an older format of index keys. Indexes are
+// rebuilt one at a time, and the format is only saved once they all are, so
+// upgrades resume if they're interrupted.
+func (d *DB) upgradeIndexes() error {
+	v, err := d.datastore.Get(dsIndexFormat)
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return err
+	}
+	if v != nil {
+		format, err := strconv.Atoi(string(v))
+		if err != nil {
+			return err
+		}
+		if format >= indexFormat {
+			return nil
+		}
+	}
+	for _, c := range d.collections {
+		for path, index := range c.indexes {
+			if index.FullText || len(index.Fields) > 0 {
+				continue
+			}
+			log.Debugf("upgrading index %s of collection %s", path, c.name)
+			txn, err := d.datastore.NewTransaction(false)
+			if err != nil {
+				return err
+			}
+			stxn := trackStats(txn)
+			if err := c.rebuildIndex(stxn, path, index); err != nil {
+				txn.Discard()
+				return err
+			}
+			if err := stxn.Commit(); err != nil {
+				return err
+			}
+		}
+	}
+	return d.datastore.Put(dsIndexFormat, []byte(strconv.Itoa(indexFormat)))
+}
+
 // saveIndexes persists the current indexes.
 func (c *Collection) saveIndexes() error {
 	ib, err := json.Marshal(c.indexes)
@@ -171,24 +336,51 @@ func (c *Collection) indexDelete(tx ds.Txn, key ds.Key, originalData []byte) err
 
 // indexUpdate adds or removes a specific index on an item.
 func (c *Collection) indexUpdate(field string, index Index, tx ds.Txn, key ds.Key, input []byte, delete bool) error {
-	valueKey, err := getIndexValue(field, input)
-	if err != nil {
-		if errors.Is(err, ErrNotIndexable) {
-			return nil
+	if index.FullText {
+		return c.textIndexUpdate(field, tx, key, input, delete)
+	}
+	for _, value := range indexValues(field, index, input) {
+		if err := c.indexValueUpdate(field, index, tx, key, value, delete); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexValues returns the keys of the values of input in the index at path,
+// which are one per distinct element of arrays. Values are encoded like
+// those of composite indexes, so the keys sort like the values.
+func indexValues(path string, index Index, input []byte) []string {
+	if len(index.Fields) > 0 {
+		return []string{compositeIndexValue(index.Fields, input)}
+	}
+	result := gjson.GetBytes(input, path)
+	if !result.Exists() {
+		return nil
+	}
+	if !result.IsArray() {
+		return []string{hex.EncodeToString(appendTupleResult(nil, result))}
+	}
+	var values []string
+	seen := make(map[string]bool)
+	for _, elem := range result.Array() {
+		value := hex.EncodeToString(appendTupleResult(nil, elem))
+		if !seen[value] {
+			seen[value] = true
+			values = append(values, value)
 		}
-		return err
 	}
+	return values
+}
 
-	indexKey := indexPrefix.Child(c.baseKey()).ChildString(field).ChildString(valueKey.String()[1:])
+// indexValueUpdate adds or removes an item from the keys of a value in the
+// index.
+func (c *Collection) indexValueUpdate(field string, index Index, tx ds.Txn, key ds.Key, value string, delete bool) error {
+	indexKey := indexPrefix.Child(c.baseKey()).ChildString(field).ChildString(value)
 	data, err := tx.Get(indexKey)
 	if err != nil && err != ds.ErrNotFound {
 		return err
 	}
-	if err != ds.ErrNotFound {
-		if index.Unique && !delete {
-			return ErrUniqueExists
-		}
-	}
 
 	indexValue := make(keyList, 0)
 	if data != nil {
@@ -197,6 +389,13 @@ func (c *Collection) indexUpdate(field string, index Index, tx ds.Txn, key ds.Ke
 			return err
 		}
 	}
+	if index.Unique && !delete {
+		for _, k := range indexValue {
+			if other := ds.RawKey(string(k)); other != key {
+				return &ErrUniqueConstraintViolation{Path: field, InstanceID: core.InstanceID(other.Name())}
+			}
+		}
+	}
 	if delete {
 		indexValue.remove(key)
 	} else {
@@ -212,15 +411,6 @@ func (c *Collection) indexUpdate(field string, index Index, tx ds.Txn, key ds.Ke
 	return tx.Put(indexKey, val)
 }
 
-// getIndexValue returns the result of a field search on input.
-func getIndexValue(field string, input []byte) (ds.Key, error) {
-	result := gjson.GetBytes(input, field)
-	if !result.Exists() {
-		return ds.Key{}, ErrNotIndexable
-	}
-	return ds.NewKey(result.String()), nil
-}
-
 // keyList is a slice of unique, sorted keys([]byte) such as what an index points to
 type keyList [][]byte
 
@@ -272,6 +462,15 @@ type iterator struct {
 	query    *Query
 	keyCache []ds.Key
 	iter     query.Results
+	// matchAll is set if the instances of the keys must be matched
+	// against the whole query, like those found by a full-text index
+	matchAll bool
+	// seen are the keys already returned, since instances are found
+	// once per matching element of an indexed array
+	seen map[ds.Key]bool
+	// indexKeys and instancesRead are the number of index keys and
+	// instances read so far
+	indexKeys, instancesRead int
 }
 
 func newIterator(txn dse.TxnExt, baseKey ds.Key, q *Query) (*iterator, error) {
@@ -304,6 +503,37 @@ func newIterator(txn dse.TxnExt, baseKey ds.Key, q *Query) (*iterator, error) {
 	}
 	if q.Seek != "" {
 		dsq.SeekPrefix = prefix.Child(ds.NewKey(string(q.Seek))).String()
+	} else if q.Sort.FieldPath == idFieldName && q.Sort.Desc {
+		// Reverse iteration would otherwise start past the end of the
+		// prefix, and find nothing
+		dsq.SeekPrefix = prefix.ChildString("\xff").String()
+	}
+	if lookups := q.indexLookups(prefix); lookups != nil {
+		// get the keys of each value from the index, rather than scanning it
+		i.iter = query.ResultsWithEntries(dsq.Query, nil)
+		i.nextKeys = func() ([]ds.Key, error) {
+			var nKeys []ds.Key
+			for len(nKeys) < iteratorKeyMinCacheSize && len(lookups) > 0 {
+				data, err := txn.Get(lookups[0])
+				lookups = lookups[1:]
+				if errors.Is(err, ds.ErrNotFound) {
+					continue
+				}
+				if err != nil {
+					return nil, err
+				}
+				i.indexKeys++
+				indexValue := make(keyList, 0)
+				if err := DefaultDecode(data, &indexValue); err != nil {
+					return nil, err
+				}
+				for _, v := range indexValue {
+					nKeys = append(nKeys, ds.RawKey(string(v)))
+				}
+			}
+			return nKeys, nil
+		}
+		return i, nil
 	}
 	iter, err := txn.QueryExtended(dsq)
 	if err != nil {
@@ -332,15 +562,17 @@ func newIterator(txn dse.TxnExt, baseKey ds.Key, q *Query) (*iterator, error) {
 				return nKeys, result.Error
 			}
 			first = false
+			i.indexKeys++
 			// result.Key contains the indexed value, extract here first
-			key := ds.RawKey(result.Key)
-			base := prefix.Name()
-			name := key.Name()
-			val := gjson.Parse(name).Value()
-			if val == nil {
-				val = name
+			b, err := hex.DecodeString(ds.RawKey(result.Key).Name())
+			if err != nil {
+				return nil, err
 			}
-			doc, err := sjson.Set("", base, val)
+			val, ok := decodeTupleValue(b)
+			if !ok {
+				continue
+			}
+			doc, err := sjson.Set("", prefix.Name(), val)
 			if err != nil {
 				return nil, err
 			}
@@ -367,13 +599,73 @@ func newIterator(txn dse.TxnExt, baseKey ds.Key, q *Query) (*iterator, error) {
 	return i, nil
 }
 
+// indexLookups returns the keys of the index at prefix holding the values of
+// the query, if it's only an In criterion on the indexed field.
+func (q *Query) indexLookups(prefix ds.Key) []ds.Key {
+	if len(q.Ands) != 1 || len(q.Ors) != 0 {
+		return nil
+	}
+	c := q.Ands[0]
+	if c.Operation != In || c.FieldPath != q.Index {
+		return nil
+	}
+	lookups := []ds.Key{}
+	seen := make(map[ds.Key]bool)
+	for _, v := range c.Values {
+		value, ok := criterionValue(v)
+		if !ok {
+			continue
+		}
+		// same as the keys of indexUpdate
+		key := prefix.ChildString(hex.EncodeToString(appendTupleValue(nil, value, true)))
+		if !seen[key] {
+			seen[key] = true
+			lookups = append(lookups, key)
+		}
+	}
+	return lookups
+}
+
+// matchesMissing tells whether the query or any of its alternatives has a
+// Missing criterion on the field at path.
+func (q *Query) matchesMissing(path string) bool {
+	for _, c := range q.Ands {
+		if c.Operation == Missing && c.FieldPath == path {
+			return true
+		}
+	}
+	for _, or := range q.Ors {
+		if or.matchesMissing(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// indexFor returns the query on the index of the field of its only In
+// criterion if the query has no index and the field is indexed, or the query
+// otherwise.
+func (c *Collection) indexFor(q *Query) *Query {
+	if q.Index != "" || len(q.Ands) != 1 || len(q.Ors) != 0 || q.Ands[0].Operation != In {
+		return q
+	}
+	field := q.Ands[0].FieldPath
+	if _, ok := c.indexes[field]; !ok {
+		return q
+	}
+	indexed := *q
+	indexed.Index = field
+	return &indexed
+}
+
 // NextSync returns the next key value that matches the iterators criteria
 // If there is an error, ok is false and result.Error() will return the error
 func (i *iterator) NextSync() (MarshaledResult, bool) {
-	if i.query.Index == "" {
+	if i.query.Index == "" && !i.matchAll {
 		value := MarshaledResult{}
 		var ok bool
 		for res := range i.iter.Next() {
+			i.instancesRead++
 			val := make(map[string]interface{})
 			if value.Error = json.Unmarshal(res.Value, &val); value.Error != nil {
 				break
@@ -391,47 +683,74 @@ func (i *iterator) NextSync() (MarshaledResult, bool) {
 		}
 		return value, ok
 	}
-	if len(i.keyCache) == 0 {
-		newKeys, err := i.nextKeys()
+	for {
+		if len(i.keyCache) == 0 {
+			newKeys, err := i.nextKeys()
+			if err != nil {
+				return MarshaledResult{
+					Result: query.Result{
+						Entry: query.Entry{},
+						Error: err,
+					},
+				}, false
+			}
+
+			if len(newKeys) == 0 {
+				return MarshaledResult{
+					Result: query.Result{
+						Entry: query.Entry{},
+						Error: nil,
+					},
+				}, false
+			}
+			i.keyCache = append(i.keyCache, newKeys...)
+		}
+
+		key := i.keyCache[0]
+		i.keyCache = i.keyCache[1:]
+		if i.seen == nil {
+			i.seen = make(map[ds.Key]bool)
+		}
+		if i.seen[key] {
+			continue
+		}
+		i.seen[key] = true
+
+		value, err := i.txn.Get(key)
+		i.instancesRead++
 		if err != nil {
 			return MarshaledResult{
 				Result: query.Result{
 					Entry: query.Entry{},
 					Error: err,
-				},
-			}, false
+				}}, false
 		}
-
-		if len(newKeys) == 0 {
-			return MarshaledResult{
-				Result: query.Result{
-					Entry: query.Entry{},
-					Error: nil,
+		res := MarshaledResult{
+			Result: query.Result{
+				Entry: query.Entry{
+					Key:   key.String(),
+					Value: value,
 				},
-			}, false
+				Error: nil,
+			}}
+		if !i.matchAll {
+			return res, true
+		}
+		val := make(map[string]interface{})
+		if err := json.Unmarshal(value, &val); err != nil {
+			res.Error = err
+			return res, false
+		}
+		ok, err := i.query.match(val)
+		if err != nil {
+			res.Error = err
+			return res, false
+		}
+		if ok {
+			res.MarshaledValue = val
+			return res, true
 		}
-		i.keyCache = append(i.keyCache, newKeys...)
 	}
-
-	key := i.keyCache[0]
-	i.keyCache = i.keyCache[1:]
-
-	value, err := i.txn.Get(key)
-	if err != nil {
-		return MarshaledResult{
-			Result: query.Result{
-				Entry: query.Entry{},
-				Error: err,
-			}}, false
-	}
-	return MarshaledResult{
-		Result: query.Result{
-			Entry: query.Entry{
-				Key:   key.String(),
-				Value: value,
-			},
-			Error: nil,
-		}}, true
 }
 
 func (i *iterator) Close() {
@@ -0,0 +1,76 @@
+package keytransform
+
+import (
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	dse "github.com/textileio/go-datastore-extensions"
+)
+
+// ExtendTxnDatastore returns store with the query extensions dbs rely on, so
+// that any transactional datastore can back them. Stores implementing the
+// extensions, like the badger one, are returned as they are, and the
+// extensions are emulated for the rest.
+func ExtendTxnDatastore(store ds.TxnDatastore) TxnDatastoreExtended {
+	if s, ok := store.(TxnDatastoreExtended); ok {
+		return s
+	}
+	return &extended{TxnDatastore: store}
+}
+
+type extended struct {
+	ds.TxnDatastore
+}
+
+var (
+	_ TxnDatastoreExtended = (*extended)(nil)
+	_ ds.Shim              = (*extended)(nil)
+)
+
+// Children implements ds.Shim, returning the extended datastore.
+func (e *extended) Children() []ds.Datastore {
+	return []ds.Datastore{e.TxnDatastore}
+}
+
+func (e *extended) NewTransactionExtended(readOnly bool) (dse.TxnExt, error) {
+	t, err := e.NewTransaction(readOnly)
+	if err != nil {
+		return nil, err
+	}
+	return &extendedTxn{Txn: t}, nil
+}
+
+func (e *extended) QueryExtended(q dse.QueryExt) (dsq.Results, error) {
+	return e.Query(seekQuery(q))
+}
+
+type extendedTxn struct {
+	ds.Txn
+}
+
+var _ dse.TxnExt = (*extendedTxn)(nil)
+
+func (t *extendedTxn) QueryExtended(q dse.QueryExt) (dsq.Results, error) {
+	return t.Query(seekQuery(q))
+}
+
+// seekQuery returns the query of q with its seek prefix applied as a filter,
+// skipping the keys before it in the order of the query. Like seeking, the
+// filter applies before the offset and limit.
+func seekQuery(q dse.QueryExt) dsq.Query {
+	if q.SeekPrefix == "" {
+		return q.Query
+	}
+	op := dsq.GreaterThanOrEqual
+	if len(q.Orders) > 0 {
+		switch q.Orders[0].(type) {
+		case dsq.OrderByKeyDescending, *dsq.OrderByKeyDescending:
+			op = dsq.LessThanOrEqual
+		}
+	}
+	query := q.Query
+	query.Filters = append(append([]dsq.Filter(nil), q.Filters...), dsq.FilterKeyCompare{
+		Op:  op,
+		Key: q.SeekPrefix,
+	})
+	return query
+}
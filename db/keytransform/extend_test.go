@@ -0,0 +1,17 @@
+package keytransform_test
+
+import (
+	"testing"
+
+	pt "github.com/textileio/go-threads/test"
+)
+
+func TestDatastoreConformance(t *testing.T) {
+	for name, factory := range pt.DatastoreFactories {
+		factory := factory
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			pt.DatastoreTest(t, factory)
+		})
+	}
+}
@@ -29,7 +29,15 @@ type Datastore struct {
 	kt.KeyTransform
 }
 
-var _ dse.DatastoreExtensions = (*Datastore)(nil)
+var (
+	_ dse.DatastoreExtensions = (*Datastore)(nil)
+	_ ds.Shim                 = (*Datastore)(nil)
+)
+
+// Children implements ds.Shim, returning the wrapped datastore.
+func (d *Datastore) Children() []ds.Datastore {
+	return []ds.Datastore{d.child}
+}
 
 type txn struct {
 	Txn dse.TxnExt
@@ -55,8 +63,17 @@ func (d *Datastore) newTransaction(readOnly bool) (dse.TxnExt, error) {
 	return &txn{Txn: t, ds: d}, nil
 }
 
+// QueryExtended implements QueryExtended, transforming the keys of the query
+// and inverting the keys of the results.
 func (d *Datastore) QueryExtended(q dse.QueryExt) (dsq.Results, error) {
-	return d.child.QueryExtended(q)
+	t := &txn{ds: d}
+	nq, cq := t.prepareQuery(q)
+
+	qr, err := d.child.QueryExtended(cq)
+	if err != nil {
+		return nil, err
+	}
+	return dsq.NaiveQueryApply(nq.Query, dsq.ResultsFromIterator(q.Query, t.getIterator(qr))), nil
 }
 
 func (t *txn) Commit() error {
@@ -0,0 +1,224 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	dse "github.com/textileio/go-datastore-extensions"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+var dsCreated = dsPrefix.ChildString("created")
+
+// DBMetadata is the metadata of a managed db, read from the store without
+// loading the db.
+type DBMetadata struct {
+	ID   thread.ID
+	Name string
+	// Collections are the names of the collections, sorted.
+	Collections []string
+	// Created is the time the db was created, or first loaded for dbs
+	// created before it was kept.
+	Created time.Time
+	// LastModified is the time an instance was last written or deleted on
+	// this peer, which is zero if none was.
+	LastModified time.Time
+}
+
+// ListDBsQuery selects a page of the dbs of a manager, which are listed in
+// the order of their IDs.
+type ListDBsQuery struct {
+	// NamePrefix lists the dbs with names starting with it.
+	NamePrefix string
+	// Cursor resumes the listing after the last db of a previous page.
+	Cursor string
+	// Limit is the max number of dbs of the page. Zero lists them all.
+	Limit int
+}
+
+// ListDBsResult is a page of the dbs of a manager.
+type ListDBsResult struct {
+	DBs []DBMetadata
+	// Cursor is the cursor to the next page, if there are more dbs.
+	Cursor string
+}
+
+// ListDBsPage returns a page of the metadata of the dbs, which is read from
+// the store, so that dbs are listed without getting each from the network.
+func (m *Manager) ListDBsPage(ctx context.Context, q ListDBsQuery, opts ...ManagedOption) (*ListDBsResult, error) {
+	log.Debug("manager: listing db metadata")
+	args := &ManagedOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+
+	// the keys of each db are skipped past, seeking the next db
+	seek := dsManagerBaseKey.String() + "/"
+	if q.Cursor != "" {
+		id, err := thread.Decode(q.Cursor)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		seek = skipDB(id)
+	}
+	res := &ListDBsResult{}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		id, ok, err := m.nextDB(seek)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return res, nil
+		}
+		seek = skipDB(id)
+		if _, err := m.network.Validate(id, args.Token, true); err != nil {
+			return nil, err
+		}
+		store, _, err := wrapDB(m.store, id, m.opts, "")
+		if err != nil {
+			return nil, err
+		}
+		md, err := readDBMetadata(store, id)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(md.Name, q.NamePrefix) {
+			continue
+		}
+		if q.Limit > 0 && len(res.DBs) == q.Limit {
+			res.Cursor = res.DBs[len(res.DBs)-1].ID.String()
+			return res, nil
+		}
+		res.DBs = append(res.DBs, md)
+	}
+}
+
+// skipDB returns the key seeking past the keys of the db with id.
+func skipDB(id thread.ID) string {
+	// '0' is the character following the separator
+	return dsManagerBaseKey.ChildString(id.String()).String() + "0"
+}
+
+// nextDB returns the ID of the first db with keys after seek.
+func (m *Manager) nextDB(seek string) (thread.ID, bool, error) {
+	for {
+		res, err := m.store.QueryExtended(dse.QueryExt{
+			Query: query.Query{
+				Prefix:   dsManagerBaseKey.String(),
+				Orders:   []query.Order{query.OrderByKey{}},
+				Limit:    1,
+				KeysOnly: true,
+			},
+			SeekPrefix: seek,
+		})
+		if err != nil {
+			return thread.Undef, false, err
+		}
+		entries, err := res.Rest()
+		if err != nil {
+			return thread.Undef, false, err
+		}
+		if len(entries) == 0 {
+			return thread.Undef, false, nil
+		}
+		parts := strings.SplitN(entries[0].Key, "/", 4)
+		if len(parts) < 3 {
+			return thread.Undef, false, nil
+		}
+		id, err := thread.Decode(parts[2])
+		if err != nil {
+			// keys of no db are skipped too
+			seek = dsManagerBaseKey.ChildString(parts[2]).String() + "0"
+			continue
+		}
+		return id, true, nil
+	}
+}
+
+// readDBMetadata reads the metadata of the db with id from a snapshot of its
+// store.
+func readDBMetadata(store ds.TxnDatastore, id thread.ID) (DBMetadata, error) {
+	txn, err := store.NewTransaction(true)
+	if err != nil {
+		return DBMetadata{}, err
+	}
+	defer txn.Discard()
+	return dbMetadata(txn, id)
+}
+
+// dbMetadata reads the metadata of the db with id.
+func dbMetadata(store ds.Read, id thread.ID) (DBMetadata, error) {
+	md := DBMetadata{ID: id}
+	name, err := store.Get(dsName)
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return md, err
+	}
+	md.Name = string(name)
+	created, err := loadCreated(store)
+	if err != nil {
+		return md, err
+	}
+	md.Created = created
+
+	res, err := store.Query(query.Query{Prefix: dsSchemas.String(), KeysOnly: true})
+	if err != nil {
+		return md, err
+	}
+	schemas, err := res.Rest()
+	if err != nil {
+		return md, err
+	}
+	for _, e := range schemas {
+		md.Collections = append(md.Collections, ds.RawKey(e.Key).Name())
+	}
+	sort.Strings(md.Collections)
+
+	var last int64
+	for _, name := range md.Collections {
+		s, err := getStats(store, dsStats.ChildString(name))
+		if errors.Is(err, ds.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return md, err
+		}
+		if s.LastWrite > last {
+			last = s.LastWrite
+		}
+	}
+	if last != 0 {
+		md.LastModified = time.Unix(0, last)
+	}
+	return md, nil
+}
+
+// saveCreated saves the time the db is created, unless it's saved already.
+func (d *DB) saveCreated() error {
+	if ok, err := d.datastore.Has(dsCreated); err != nil || ok {
+		return err
+	}
+	return d.datastore.Put(dsCreated, []byte(strconv.FormatInt(d.now().UnixNano(), 10)))
+}
+
+// loadCreated loads the time the db was created, which is zero if it
+// isn't saved.
+func loadCreated(store ds.Read) (time.Time, error) {
+	v, err := store.Get(dsCreated)
+	if errors.Is(err, ds.ErrNotFound) {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, err
+	}
+	n, err := strconv.ParseInt(string(v), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, n), nil
+}
@@ -1,8 +1,10 @@
 package db
 
 import (
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	format "github.com/ipfs/go-ipld-format"
 	"github.com/textileio/go-threads/core/app"
@@ -13,23 +15,74 @@ import (
 // Listen returns a Listener which notifies about actions applying the
 // defined filters. The DB *won't* wait for slow receivers, so if the
 // channel is full, the action will be dropped.
+// With Since, the logged actions after the sequence number are replayed
+// first, and then the ones applied after them. Actions are queued until
+// they're received instead of being dropped, so a listener resuming after
+// the last action it received misses none. If the actions to replay were
+// pruned, an *ActionsPrunedError is returned.
 func (d *DB) Listen(los ...ListenOption) (Listener, error) {
+	var (
+		filters []ListenOption
+		resume  *ListenOption
+	)
+	for i, lo := range los {
+		if lo.resume {
+			resume = &los[i]
+			continue
+		}
+		if lo.Query != nil {
+			if err := lo.Query.Validate(); err != nil {
+				return nil, fmt.Errorf("invalid query: %s", err)
+			}
+		}
+		filters = append(filters, lo)
+	}
+	if resume != nil {
+		for _, lo := range filters {
+			if lo.Query != nil {
+				return nil, ErrListenQuerySince
+			}
+		}
+	}
+	end, err := d.inflight.begin()
+	if err != nil {
+		return nil, err
+	}
+	defer end()
 	d.txnlock.Lock()
 	defer d.txnlock.Unlock()
-	if d.closed {
-		return nil, fmt.Errorf("can't listen on closed DB")
-	}
 
-	sl := &listener{
-		scn:     d.stateChangedNotifee,
-		filters: los,
-		c:       make(chan Action, 1),
+	sl := newListener(d.stateChangedNotifee, filters)
+	if resume == nil {
+		if err := d.stateChangedNotifee.addListener(sl); err != nil {
+			return nil, err
+		}
+		return sl, nil
+	}
+	sl.queued = true
+	// no action is logged or delivered until the listener is added, so
+	// it's delivered the ones after those replayed
+	d.seqLock.Lock()
+	defer d.seqLock.Unlock()
+	logged, err := d.loggedActions(resume.since)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range logged {
+		if sl.evaluate(a, nil) {
+			sl.queue = append(sl.queue, a)
+		}
+	}
+	if err := d.stateChangedNotifee.addListener(sl); err != nil {
+		return nil, err
 	}
-	d.stateChangedNotifee.addListener(sl)
+	sl.pumped.Add(1)
+	go sl.pump()
 	return sl, nil
 }
 
 func (d *DB) notifyStateChanged(actions []Action) {
+	d.queueAfterHooks(actions)
 	d.stateChangedNotifee.notify(actions)
 }
 
@@ -40,6 +93,9 @@ func (d *DB) notifyTxnEvents(node format.Node, token thread.Token) error {
 	})
 }
 
+// ErrListenQuerySince indicates listeners with a query can't resume with Since.
+var ErrListenQuerySince = errors.New("listeners with a query can't resume since an action")
+
 type ActionType int
 type ListenActionType int
 
@@ -47,6 +103,11 @@ const (
 	ActionCreate ActionType = iota + 1
 	ActionSave
 	ActionDelete
+	ActionMigrate
+	ActionRename
+	// ActionClose is the last action listeners are sent, when the db is
+	// closed, before their channels are closed.
+	ActionClose
 )
 
 const (
@@ -54,18 +115,49 @@ const (
 	ListenCreate
 	ListenSave
 	ListenDelete
+	ListenMigrate
 )
 
 type Action struct {
 	Collection string
 	Type       ActionType
 	ID         core.InstanceID
+	// Migrated and Total are the number of instances migrated so far and
+	// to migrate by MigrateCollection, for ActionMigrate.
+	Migrated int
+	Total    int
+	// NewName is the name a collection was renamed to by
+	// RenameCollection, for ActionRename.
+	NewName string
+	// Seq is the sequence number of the action, which increases with every
+	// action applied by the db. It's 0 for ActionMigrate, which isn't logged.
+	Seq uint64
+
+	// instance is the instance resulting from a create or save, or the one
+	// deleted by a delete, which listeners receive the action without.
+	instance []byte
 }
 
 type ListenOption struct {
 	Type       ListenActionType
 	Collection string
 	ID         core.InstanceID
+	// Query only matches the actions of instances matching it, which are
+	// the instances resulting from creates and saves, and the instances
+	// deleted by deletes. Migrations don't match.
+	Query *Query
+
+	// since and resume are set by Since, which isn't a filter.
+	since  uint64
+	resume bool
+}
+
+// Since resumes a listener after the action with the sequence number seq,
+// replaying the logged actions after it which match the other options. The
+// other options can't have a query. Since(0) replays every action, if none
+// was pruned.
+func Since(seq uint64) ListenOption {
+	return ListenOption{since: seq, resume: true}
 }
 
 type Listener interface {
@@ -76,12 +168,32 @@ type Listener interface {
 type stateChangedNotifee struct {
 	lock      sync.RWMutex
 	listeners []*listener
+	closed    bool
 }
 
 type listener struct {
 	scn     *stateChangedNotifee
 	filters []ListenOption
 	c       chan Action
+
+	// queued listeners are sent the actions of queue in order by pump,
+	// which is woken up when actions are queued.
+	queued bool
+	lock   sync.Mutex
+	queue  []Action
+	wake   chan struct{}
+	pumped sync.WaitGroup
+	done   chan struct{}
+}
+
+func newListener(scn *stateChangedNotifee, filters []ListenOption) *listener {
+	return &listener{
+		scn:     scn,
+		filters: filters,
+		c:       make(chan Action, 1),
+		wake:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
 }
 
 var _ Listener = (*listener)(nil)
@@ -90,22 +202,24 @@ func (scn *stateChangedNotifee) notify(actions []Action) {
 	scn.lock.RLock()
 	defer scn.lock.RUnlock()
 	for _, a := range actions {
+		instance := a.instance
+		a.instance = nil
 		for _, l := range scn.listeners {
-			if l.evaluate(a) {
-				select {
-				case l.c <- a:
-				default:
-					log.Warnf("dropped action %v for reducer with filters %v", a, l.filters)
-				}
+			if l.evaluate(a, instance) {
+				l.send(a)
 			}
 		}
 	}
 }
 
-func (scn *stateChangedNotifee) addListener(sl *listener) {
+func (scn *stateChangedNotifee) addListener(sl *listener) error {
 	scn.lock.Lock()
 	defer scn.lock.Unlock()
+	if scn.closed {
+		return ErrDBClosed
+	}
 	scn.listeners = append(scn.listeners, sl)
+	return nil
 }
 
 func (scn *stateChangedNotifee) remove(sl *listener) bool {
@@ -122,14 +236,24 @@ func (scn *stateChangedNotifee) remove(sl *listener) bool {
 	return false
 }
 
-func (scn *stateChangedNotifee) close() {
+// close removes the listeners, which are sent the terminal action before
+// their channels are closed.
+func (scn *stateChangedNotifee) close(terminal Action) {
 	scn.lock.Lock()
-	defer scn.lock.Unlock()
-	for i := range scn.listeners {
-		close(scn.listeners[i].c)
-		scn.listeners[i] = nil
-	}
+	listeners := scn.listeners
 	scn.listeners = nil
+	scn.closed = true
+	scn.lock.Unlock()
+
+	var wg sync.WaitGroup
+	for _, l := range listeners {
+		wg.Add(1)
+		go func(l *listener) {
+			defer wg.Done()
+			l.terminate(terminal)
+		}(l)
+	}
+	wg.Wait()
 }
 
 // Channel returns an unbuffered channel to receive
@@ -142,15 +266,91 @@ func (sl *listener) Channel() <-chan Action {
 // and ready for being garbage collected
 func (sl *listener) Close() {
 	if ok := sl.scn.remove(sl); ok {
-		close(sl.c)
+		sl.stop()
 	}
 }
 
-func (sl *listener) evaluate(a Action) bool {
+// send delivers the action, or queues it for queued listeners.
+func (sl *listener) send(a Action) {
+	if !sl.queued {
+		select {
+		case sl.c <- a:
+		default:
+			log.Warnf("dropped action %v for reducer with filters %v", a, sl.filters)
+		}
+		return
+	}
+	sl.lock.Lock()
+	sl.queue = append(sl.queue, a)
+	sl.lock.Unlock()
+	select {
+	case sl.wake <- struct{}{}:
+	default:
+	}
+}
+
+// pump delivers the queued actions in order until the listener is stopped.
+func (sl *listener) pump() {
+	defer sl.pumped.Done()
+	for {
+		sl.lock.Lock()
+		if len(sl.queue) == 0 {
+			sl.lock.Unlock()
+			select {
+			case <-sl.wake:
+				continue
+			case <-sl.done:
+				return
+			}
+		}
+		a := sl.queue[0]
+		sl.queue = sl.queue[1:]
+		sl.lock.Unlock()
+		select {
+		case sl.c <- a:
+		case <-sl.done:
+			return
+		}
+	}
+}
+
+// stop stops delivering queued actions and closes the channel. The
+// listener must be removed from its notifee.
+func (sl *listener) stop() {
+	close(sl.done)
+	sl.pumped.Wait()
+	close(sl.c)
+}
+
+// terminate stops delivering queued actions, and closes the channel once
+// the terminal action is received, or closeActionTimeout passes. The
+// listener must be removed from its notifee.
+func (sl *listener) terminate(terminal Action) {
+	close(sl.done)
+	sl.pumped.Wait()
+	timer := time.NewTimer(closeActionTimeout)
+	defer timer.Stop()
+	select {
+	case sl.c <- terminal:
+	case <-timer.C:
+		log.Warnf("dropped action %v for reducer with filters %v", terminal, sl.filters)
+	}
+	close(sl.c)
+}
+
+func (sl *listener) evaluate(a Action, instance []byte) bool {
 	if len(sl.filters) == 0 {
 		return true
 	}
 	for _, f := range sl.filters {
+		if a.Type == ActionRename {
+			// listeners of a collection are sent its rename, after which
+			// they're sent none of its actions under its old name
+			if f.Collection == "" || f.Collection == a.Collection {
+				return true
+			}
+			continue
+		}
 		switch f.Type {
 		case ListenAll:
 		case ListenCreate:
@@ -165,6 +365,10 @@ func (sl *listener) evaluate(a Action) bool {
 			if a.Type != ActionDelete {
 				continue
 			}
+		case ListenMigrate:
+			if a.Type != ActionMigrate {
+				continue
+			}
 		default:
 			panic("unknown action type")
 		}
@@ -176,6 +380,20 @@ func (sl *listener) evaluate(a Action) bool {
 		if f.ID != core.EmptyInstanceID && f.ID != a.ID {
 			continue
 		}
+
+		if f.Query != nil {
+			if instance == nil {
+				continue
+			}
+			ok, err := f.Query.matchJSON(instance)
+			if err != nil {
+				log.Errorf("matching action %v with query of listener: %v", a, err)
+				continue
+			}
+			if !ok {
+				continue
+			}
+		}
 		return true
 	}
 	return false
@@ -16,6 +16,7 @@ import (
 	"github.com/textileio/go-threads/core/app"
 	"github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/db/encryption"
 	kt "github.com/textileio/go-threads/db/keytransform"
 	"github.com/textileio/go-threads/util"
 	"golang.org/x/sync/errgroup"
@@ -41,8 +42,10 @@ type Manager struct {
 	store   kt.TxnDatastoreExtended
 	network app.Net
 
-	lk  sync.RWMutex
-	dbs map[thread.ID]*DB
+	lk      sync.RWMutex
+	dbs     map[thread.ID]*DB
+	closed  bool
+	closeCh chan struct{}
 }
 
 // NewManager hydrates and starts dbs from prefixes.
@@ -56,12 +59,17 @@ func NewManager(store kt.TxnDatastoreExtended, network app.Net, opts ...NewOptio
 	}); err != nil {
 		return nil, err
 	}
+	store, err := encryptDatastore(datastoreFromOptions(store, args), args)
+	if err != nil {
+		return nil, err
+	}
 
 	m := &Manager{
 		store:   store,
 		network: network,
 		opts:    args,
 		dbs:     make(map[thread.ID]*DB),
+		closeCh: make(chan struct{}),
 	}
 
 	results, err := store.Query(query.Query{
@@ -135,6 +143,9 @@ func NewManager(store kt.TxnDatastoreExtended, network app.Net, opts ...NewOptio
 	}
 
 	log.Infof("manager: finished loading %d dbs", len(m.dbs))
+	if args.GCInterval > 0 {
+		go gcLoop(args.GCInterval, m.closeCh, m.GC, "manager")
+	}
 	return m, nil
 }
 
@@ -196,6 +207,9 @@ func (m *Manager) NewDB(ctx context.Context, id thread.ID, opts ...NewManagedOpt
 // NewDBFromAddr creates a new db from address and prefixes its datastore with base key.
 // Unlike NewDB, this method takes a list of collections added to the original db that
 // should also be added to this host.
+// If pulling the thread with WithNewManagedBackfillBlock fails, as when ctx is
+// canceled, the db stays managed with the records pulled. It's resumed by the
+// pulls of the network, or PullThread, and can be deleted with DeleteDB.
 func (m *Manager) NewDBFromAddr(
 	ctx context.Context,
 	addr ma.Multiaddr,
@@ -227,16 +241,18 @@ func (m *Manager) NewDBFromAddr(
 		return nil, ErrThreadReadKeyRequired
 	}
 	log.Debugf("manager: adding thread to net %s", id)
-	if _, err := m.network.AddThread(
+	info, err := m.network.AddThread(
 		ctx,
 		addr,
 		net.WithThreadKey(key),
 		net.WithLogKey(args.LogKey),
 		net.WithNewThreadToken(args.Token),
-	); err != nil {
+	)
+	if err != nil {
 		return nil, err
 	}
 	log.Debugf("manager: added thread to net %s", id)
+	reportThreadAdded(args.Progress, info)
 
 	store, dbOpts, err := wrapDB(m.store, id, m.opts, args.Name, args.Collections...)
 	if err != nil {
@@ -252,7 +268,7 @@ func (m *Manager) NewDBFromAddr(
 
 	if args.Block {
 		log.Debugf("manager: pulling thread %s", id)
-		if err = m.network.PullThread(ctx, id, net.WithThreadToken(args.Token)); err != nil {
+		if err = db.pullThread(ctx, args.Token, len(info.Logs), args.Progress); err != nil {
 			return nil, err
 		}
 		log.Debugf("manager: pulled thread %s", id)
@@ -261,7 +277,7 @@ func (m *Manager) NewDBFromAddr(
 			ctx, cancel := context.WithTimeout(context.Background(), pullThreadBackgroundTimeout)
 			defer cancel()
 			log.Debugf("manager: pulling thread %s", id)
-			if err := m.network.PullThread(ctx, id, net.WithThreadToken(args.Token)); err != nil {
+			if err := db.pullThread(ctx, args.Token, len(info.Logs), args.Progress); err != nil {
 				log.Errorf("error pulling thread %s", id)
 			}
 			log.Debugf("manager: pulled thread %s", id)
@@ -272,7 +288,8 @@ func (m *Manager) NewDBFromAddr(
 	return db, nil
 }
 
-// ListDBs returns a list of all dbs.
+// ListDBs returns a list of all dbs. ListDBsPage lists their metadata in
+// pages instead, without getting each from the network.
 func (m *Manager) ListDBs(ctx context.Context, opts ...ManagedOption) (map[thread.ID]*DB, error) {
 	log.Debug("manager: listing dbs")
 	args := &ManagedOptions{}
@@ -396,6 +413,10 @@ func (m *Manager) Net() net.Net {
 func (m *Manager) Close() error {
 	m.lk.Lock()
 	defer m.lk.Unlock()
+	if !m.closed {
+		m.closed = true
+		close(m.closeCh)
+	}
 	for _, s := range m.dbs {
 		if err := s.Close(); err != nil {
 			log.Error("error when closing manager datastore: %v", err)
@@ -404,6 +425,32 @@ func (m *Manager) Close() error {
 	return nil
 }
 
+// datastoreFromOptions returns the store set with WithNewDatastore, if it is,
+// or else store.
+func datastoreFromOptions(store kt.TxnDatastoreExtended, opts *NewOptions) kt.TxnDatastoreExtended {
+	if opts.Datastore != nil {
+		return kt.ExtendTxnDatastore(opts.Datastore)
+	}
+	return store
+}
+
+// encryptDatastore returns store with its values encrypted with the key set
+// with WithNewEncryptionKey, if it is, or else store, failing with
+// encryption.ErrKeyRequired if it's encrypted.
+func encryptDatastore(store kt.TxnDatastoreExtended, opts *NewOptions) (kt.TxnDatastoreExtended, error) {
+	if opts.EncryptionKey == nil {
+		encrypted, err := encryption.IsEncrypted(store)
+		if err != nil {
+			return nil, err
+		}
+		if encrypted {
+			return nil, encryption.ErrKeyRequired
+		}
+		return store, nil
+	}
+	return encryption.Wrap(store, opts.EncryptionKey)
+}
+
 // wrapDB copies the manager's base config,
 // wraps the datastore with an id prefix,
 // and merges specified collection configs with those from base
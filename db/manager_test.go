@@ -4,13 +4,17 @@ import (
 	"context"
 	"crypto/rand"
 	"errors"
+	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/libp2p/go-libp2p-core/crypto"
+	sym "github.com/textileio/crypto/symmetric"
 	"github.com/textileio/go-threads/common"
 	lstore "github.com/textileio/go-threads/core/logstore"
 	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/db/encryption"
 	"github.com/textileio/go-threads/util"
 )
 
@@ -146,6 +150,129 @@ func TestManager_ReloadDBs(t *testing.T) {
 	checkErr(t, err)
 }
 
+func TestManager_EncryptionKey(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	n, err := common.DefaultNetwork(
+		common.WithNetBadgerPersistence(dir),
+		common.WithNetHostAddr(util.FreeLocalAddr()),
+		common.WithNetDebug(true),
+	)
+	checkErr(t, err)
+	defer n.Close()
+	store, err := util.NewBadgerDatastore(dir, "eventstore", false)
+	checkErr(t, err)
+	defer store.Close()
+	key := sym.New()
+	man, err := NewManager(store, n, WithNewEncryptionKey(key), WithNewDebug(true))
+	checkErr(t, err)
+	id := thread.NewIDV1(thread.Raw, 32)
+	d, err := man.NewDB(ctx, id)
+	checkErr(t, err)
+	c, err := d.NewCollection(CollectionConfig{Name: "Person", Schema: util.SchemaFromSchemaString(jsonSchema)})
+	checkErr(t, err)
+	_, err = c.Create([]byte(`{"_id": "", "name": "foo", "age": 21}`))
+	checkErr(t, err)
+	checkErr(t, man.Close())
+
+	if _, err := NewManager(store, n, WithNewEncryptionKey(sym.New())); !errors.Is(err, encryption.ErrWrongKey) {
+		t.Fatalf("expected creating the manager with another key to fail, got %v", err)
+	}
+	if _, err := NewManager(store, n); !errors.Is(err, encryption.ErrKeyRequired) {
+		t.Fatalf("expected creating the manager without its key to fail, got %v", err)
+	}
+	man, err = NewManager(store, n, WithNewEncryptionKey(key), WithNewDebug(true))
+	checkErr(t, err)
+	defer man.Close()
+	d, err = man.GetDB(ctx, id)
+	checkErr(t, err)
+	res, err := d.GetCollection("Person").Find(Where("name").Eq("foo"))
+	checkErr(t, err)
+	if len(res) != 1 {
+		t.Fatalf("expected the instance of the reloaded db to be found, got %d", len(res))
+	}
+}
+
+func TestManager_ListDBsPage(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	man, clean := createTestManager(t)
+	defer clean()
+
+	names := []string{"app-0", "app-1", "app-2", "other-0", "other-1"}
+	ids := make(map[string]thread.ID)
+	for _, name := range names {
+		id := thread.NewIDV1(thread.Raw, 32)
+		_, err := man.NewDB(ctx, id, WithNewManagedName(name))
+		checkErr(t, err)
+		ids[name] = id
+	}
+	d, err := man.GetDB(ctx, ids["app-1"])
+	checkErr(t, err)
+	c, err := d.NewCollection(CollectionConfig{Name: "Person", Schema: util.SchemaFromSchemaString(jsonSchema)})
+	checkErr(t, err)
+	_, err = d.NewCollection(CollectionConfig{Name: "Dog", Schema: util.SchemaFromInstance(&dummy{}, false)})
+	checkErr(t, err)
+	_, err = c.Create([]byte(`{"_id": "", "name": "foo", "age": 21}`))
+	checkErr(t, err)
+
+	// dbs are paged in the order of their IDs
+	var listed []DBMetadata
+	q := ListDBsQuery{Limit: 2}
+	for pages := 0; ; pages++ {
+		res, err := man.ListDBsPage(ctx, q)
+		checkErr(t, err)
+		if len(res.DBs) > 2 {
+			t.Fatalf("expected pages of up to 2 dbs, got %d", len(res.DBs))
+		}
+		listed = append(listed, res.DBs...)
+		if res.Cursor == "" {
+			if pages != 2 {
+				t.Fatalf("expected 3 pages, got %d", pages+1)
+			}
+			break
+		}
+		q.Cursor = res.Cursor
+	}
+	if len(listed) != len(names) {
+		t.Fatalf("expected %d dbs listed, got %d", len(names), len(listed))
+	}
+	for i := 1; i < len(listed); i++ {
+		if listed[i-1].ID.String() >= listed[i].ID.String() {
+			t.Fatal("expected dbs listed in the order of their IDs")
+		}
+	}
+	for _, md := range listed {
+		if md.ID != ids[md.Name] {
+			t.Fatalf("expected db %s to be named after its ID", md.ID)
+		}
+		if md.Created.IsZero() {
+			t.Fatalf("expected the creation time of %s", md.Name)
+		}
+		if md.Name == "app-1" {
+			if !reflect.DeepEqual(md.Collections, []string{"Dog", "Person"}) {
+				t.Fatalf("expected collections Dog and Person, got %v", md.Collections)
+			}
+			if md.LastModified.IsZero() {
+				t.Fatal("expected the time of the last write")
+			}
+		} else if len(md.Collections) != 0 || !md.LastModified.IsZero() {
+			t.Fatalf("expected no collections or writes in %s", md.Name)
+		}
+	}
+
+	res, err := man.ListDBsPage(ctx, ListDBsQuery{NamePrefix: "app-"})
+	checkErr(t, err)
+	if len(res.DBs) != 3 || res.Cursor != "" {
+		t.Fatalf("expected the 3 dbs named with the prefix, got %d", len(res.DBs))
+	}
+	if _, err := man.ListDBsPage(ctx, ListDBsQuery{Cursor: "cursor"}); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected an invalid cursor to fail, got %v", err)
+	}
+}
+
 func TestManager_GetDB(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -284,6 +411,95 @@ func TestManager_DeleteDB(t *testing.T) {
 	}
 }
 
+func TestManager_NewDBFromAddr(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	man, clean := createTestManager(t)
+	defer clean()
+
+	id := thread.NewIDV1(thread.Raw, 32)
+	db, err := man.NewDB(ctx, id)
+	checkErr(t, err)
+	cc := CollectionConfig{Name: "Person", Schema: util.SchemaFromSchemaString(jsonSchema)}
+	collection, err := db.NewCollection(cc)
+	checkErr(t, err)
+	for i := 0; i < 10; i++ {
+		_, err = collection.Create([]byte(fmt.Sprintf(`{"_id": "", "name": "foo", "age": %d}`, i)))
+		checkErr(t, err)
+	}
+	info, err := man.network.GetThread(ctx, id)
+	checkErr(t, err)
+
+	t.Run("Progress", func(t *testing.T) {
+		man2, clean2 := createTestManager(t)
+		defer clean2()
+		var progress []SyncProgress
+		db2, err := man2.NewDBFromAddr(ctx, info.Addrs[0], info.Key,
+			WithNewManagedCollections(cc),
+			WithNewManagedBackfillBlock(true),
+			WithNewManagedProgress(func(p SyncProgress) {
+				progress = append(progress, p)
+			}))
+		checkErr(t, err)
+
+		var stages []SyncStage
+		for _, p := range progress {
+			if len(stages) == 0 || stages[len(stages)-1] != p.Stage {
+				stages = append(stages, p.Stage)
+			}
+		}
+		expected := []SyncStage{SyncThreadAdded, SyncLogsDiscovered, SyncRecordsFetched, SyncEventsReduced, SyncDone}
+		if !reflect.DeepEqual(stages, expected) {
+			t.Fatalf("expected stages %v, got: %v", expected, stages)
+		}
+		last := progress[len(progress)-1]
+		if last.Logs != 2 || last.TotalRecords != 10 || last.Records != 10 || last.Events != 10 {
+			t.Fatalf("expected 2 logs and 10 records and events, got: %+v", last)
+		}
+		n, err := db2.GetCollection("Person").Count(&Query{})
+		checkErr(t, err)
+		if n != 10 {
+			t.Fatalf("expected 10 instances, got %d", n)
+		}
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		man2, clean2 := createTestManager(t)
+		defer clean2()
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		if _, err := man2.NewDBFromAddr(ctx, info.Addrs[0], info.Key,
+			WithNewManagedCollections(cc),
+			WithNewManagedBackfillBlock(true),
+			WithNewManagedProgress(func(p SyncProgress) {
+				if p.Stage == SyncEventsReduced && p.Records == 3 {
+					cancel()
+				}
+			})); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected the sync to be canceled, got: %v", err)
+		}
+
+		// the records reduced are kept, and pulling again resumes after them
+		db2, err := man2.GetDB(context.Background(), id)
+		checkErr(t, err)
+		n, err := db2.GetCollection("Person").Count(&Query{})
+		checkErr(t, err)
+		if n != 3 {
+			t.Fatalf("expected 3 instances once canceled, got %d", n)
+		}
+		var records int
+		checkErr(t, db2.pullThread(context.Background(), "", 0, func(p SyncProgress) {
+			records = p.Records
+		}))
+		n, err = db2.GetCollection("Person").Count(&Query{})
+		checkErr(t, err)
+		if n != 10 || records != 7 {
+			t.Fatalf("expected 10 instances once resumed from 7 records, got %d from %d", n, records)
+		}
+		checkErr(t, man2.DeleteDB(context.Background(), id))
+	})
+}
+
 func createTestManager(t *testing.T) (*Manager, func()) {
 	dir := t.TempDir()
 	n, err := common.DefaultNetwork(
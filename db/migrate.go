@@ -0,0 +1,138 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alecthomas/jsonschema"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+)
+
+var (
+	// ErrMigrationChangedID indicates a migration transform changed the _id of an instance.
+	ErrMigrationChangedID = errors.New("migration can't change the instance " + idFieldName)
+	// ErrMigrationConflict indicates a collection was changed while its instances were migrated.
+	ErrMigrationConflict = errors.New("collection changed during migration")
+)
+
+// MigrateCollection migrates the instances of a collection to a new schema.
+// Each instance is transformed and validated against the new schema in a
+// single write transaction, which is only committed if all of them are valid,
+// so a failed migration leaves the instances and the schema untouched. Then
+// the new schema replaces the old one and the schema version of the
+// collection is incremented.
+// The indexes of the collection must be indexable in the new schema.
+// Listeners are notified of the progress of the migration with ActionMigrate
+// actions.
+func (d *DB) MigrateCollection(name string, schema *jsonschema.Schema, transform func(old []byte) ([]byte, error), opts ...Option) (*Collection, error) {
+	end, err := d.inflight.begin()
+	if err != nil {
+		return nil, err
+	}
+	defer end()
+	args := &Options{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	xc, c, err := d.newMigratedCollection(name, schema, args)
+	if err != nil {
+		return nil, err
+	}
+
+	// the db lock isn't held while the instances are migrated, since
+	// indexing them needs to get the collection
+	if err := d.writeTxn(c, func(txn *Txn) error {
+		return d.migrateInstances(txn, xc, transform)
+	}, WithTxnToken(args.Token)); err != nil {
+		return nil, err
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.collections[name] != xc {
+		return nil, ErrMigrationConflict
+	}
+	if err := d.saveCollection(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// newMigratedCollection returns the registered collection with name, and the
+// collection it becomes with the new schema.
+func (d *DB) newMigratedCollection(name string, schema *jsonschema.Schema, args *Options) (*Collection, *Collection, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	log.Debugf("migrating collection %s in %s", name, d.name)
+	if err := d.connector.Validate(args.Token, false); err != nil {
+		return nil, nil, err
+	}
+	xc, ok := d.collections[name]
+	if !ok {
+		return nil, nil, ErrCollectionNotFound
+	}
+	schema, err := d.resolveSchemaRefs(schema)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := newCollection(d, CollectionConfig{
+		Name:           name,
+		Schema:         schema,
+		WriteValidator: string(xc.rawWriteValidator),
+		ReadFilter:     string(xc.rawReadFilter),
+		SoftDelete:     xc.softDelete,
+		ExpiryField:    xc.expiryField,
+		ApplyDefaults:  xc.defaults != nil,
+		Hooks:          xc.getHooks(),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	for path, index := range xc.indexes {
+		if err := checkIndexable(schema, index); err != nil {
+			return nil, nil, fmt.Errorf("index %s: %w", path, err)
+		}
+		c.indexes[path] = index
+	}
+	c.schemaVersion = xc.schemaVersion + 1
+	return xc, c, nil
+}
+
+// migrateInstances saves the transformed instances of the collection with
+// the transaction.
+func (d *DB) migrateInstances(txn *Txn, xc *Collection, transform func(old []byte) ([]byte, error)) error {
+	res, err := d.datastore.Query(query.Query{Prefix: xc.baseKey().String()})
+	if err != nil {
+		return err
+	}
+	instances, err := res.Rest()
+	if err != nil {
+		return err
+	}
+	for i, instance := range instances {
+		id := ds.RawKey(instance.Key).Name()
+		migrated, err := transform(instance.Value)
+		if err != nil {
+			return fmt.Errorf("migrating instance %s: %w", id, err)
+		}
+		migratedID, err := getInstanceID(migrated)
+		if err != nil {
+			return fmt.Errorf("migrating instance %s: %w", id, err)
+		}
+		if migratedID.String() != id {
+			return fmt.Errorf("migrating instance %s: %w", id, ErrMigrationChangedID)
+		}
+		if err := txn.Save(migrated); err != nil {
+			return fmt.Errorf("migrating instance %s: %w", id, err)
+		}
+		d.notifyStateChanged([]Action{{
+			Collection: xc.name,
+			Type:       ActionMigrate,
+			ID:         migratedID,
+			Migrated:   i + 1,
+			Total:      len(instances),
+		}})
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// MultiTxn is a write transaction of several collections of the db. The
+// writes of its collections are validated together and committed in a
+// single record, which peers apply atomically, unless they exceed the max
+// record size, which splits them among several. Peers apply each of those
+// on its own, and if one fails to be created after others were, the writes
+// of those are committed, and the error is a PartialCommitError.
+type MultiTxn struct {
+	db        *DB
+	token     thread.Token
+	discarded bool
+
+	// txns are the transactions of the collections, in the order they're
+	// first used in.
+	txns  []*Txn
+	names map[string]*Txn
+}
+
+// WriteTxn creates an explicit write transaction of several collections.
+// Provides serializable isolation gurantees. The writes are committed once f
+// returns, and none are if it returns an error or any of them is invalid.
+// The context bounds the time the record takes to be created.
+func (d *DB) WriteTxn(ctx context.Context, f func(txn *MultiTxn) error, opts ...TxnOption) error {
+	log.Debugf("starting multi-collection write txn in %s", d.name)
+	end, err := d.inflight.begin()
+	if err != nil {
+		return err
+	}
+	defer end()
+	d.txnlock.Lock()
+	defer d.txnlock.Unlock()
+
+	args := &TxnOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	txn := &MultiTxn{db: d, token: args.Token, names: make(map[string]*Txn)}
+	defer txn.Discard()
+	if err := f(txn); err != nil {
+		return err
+	}
+	if err := txn.commit(ctx); err != nil {
+		return err
+	}
+	log.Debugf("ending multi-collection write txn in %s", d.name)
+	return nil
+}
+
+// Collection returns the transaction of the collection named name, whose
+// writes are committed with the MultiTxn. It's the same transaction each
+// time, with the collection reference updated from the db.
+func (m *MultiTxn) Collection(name string) (*Txn, error) {
+	m.db.lock.RLock()
+	c, ok := m.db.collections[name]
+	m.db.lock.RUnlock()
+	if !ok {
+		return nil, ErrCollectionNotFound
+	}
+	if t, ok := m.names[name]; ok {
+		t.collection = c
+		return t, nil
+	}
+	t := &Txn{collection: c, token: m.token, discarded: m.discarded, multi: m}
+	m.txns = append(m.txns, t)
+	m.names[name] = t
+	return t, nil
+}
+
+// Discard discards all changes done in the transaction.
+func (m *MultiTxn) Discard() {
+	m.discarded = true
+	for _, t := range m.txns {
+		t.discarded = true
+	}
+}
+
+// commit publishes the actions of the collections in the records they're
+// split among to fit the max record size, once unique indexes are checked.
+func (m *MultiTxn) commit(ctx context.Context) error {
+	if m.discarded {
+		return errAlreadyDiscardedCommitedTxn
+	}
+	var actions []core.Action
+	for _, t := range m.txns {
+		actions = append(actions, t.actions...)
+	}
+	batches, err := m.db.createEvents(actions)
+	if err != nil {
+		return err
+	}
+	if len(batches) == 0 {
+		return nil
+	}
+	for _, t := range m.txns {
+		if err := t.checkUnique(); err != nil {
+			return err
+		}
+	}
+	end, err := m.db.inflight.beginCommit()
+	if err != nil {
+		return err
+	}
+	defer end()
+
+	return m.db.publishEvents(ctx, batches, m.token)
+}
@@ -1,7 +1,11 @@
 package db
 
 import (
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p-core/crypto"
+	sym "github.com/textileio/crypto/symmetric"
 	core "github.com/textileio/go-threads/core/db"
 	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/jsonpatcher"
@@ -21,6 +25,39 @@ type NewOptions struct {
 	EventCodec  core.EventCodec
 	Token       thread.Token
 	Debug       bool
+	// MaxRecordSize is the max size in bytes of the records the events of
+	// transactions are written in.
+	MaxRecordSize int
+	// ExpirySweepInterval is the interval at which expired instances are
+	// deleted.
+	ExpirySweepInterval time.Duration
+	// ExpiryGracePeriod is the time instances are expired before they're
+	// deleted.
+	ExpiryGracePeriod time.Duration
+	// ForceRestore makes RestoreDB delete the contents of the store instead
+	// of failing if it isn't empty.
+	ForceRestore bool
+	// ActionLogSize is the number of the latest actions kept to be replayed
+	// to listeners.
+	ActionLogSize int
+	// Progress is called with the progress of syncing a db created with
+	// NewDBFromAddr.
+	Progress func(SyncProgress)
+	// Datastore is the store of the db, used instead of the one given to
+	// the constructor.
+	Datastore ds.TxnDatastore
+	// GCInterval is the interval at which the garbage of the store is
+	// collected. Zero disables it.
+	GCInterval time.Duration
+	// EncryptionKey is the key the values of the store are encrypted with
+	// at rest.
+	EncryptionKey *sym.Key
+	// CloseGracePeriod is the time Close waits for operations in flight to
+	// end before aborting them.
+	CloseGracePeriod time.Duration
+
+	// clock returns the current time deciding which instances are expired.
+	clock func() time.Time
 }
 
 // NewOption specifies a new db option.
@@ -68,6 +105,15 @@ func WithNewBackfillBlock(block bool) NewOption {
 	}
 }
 
+// WithNewProgress is called by NewDBFromAddr with the progress of syncing the
+// db from the peers of its thread, in order, until it's pulled. It blocks
+// the sync, so it must return quickly.
+func WithNewProgress(f func(SyncProgress)) NewOption {
+	return func(o *NewOptions) {
+		o.Progress = f
+	}
+}
+
 // WithNewEventCodec configure to use ec as the EventCodec
 // for transforming actions in events, and viceversa.
 func WithNewEventCodec(ec core.EventCodec) NewOption {
@@ -83,6 +129,98 @@ func WithNewToken(t thread.Token) NewOption {
 	}
 }
 
+// WithNewMaxRecordSize sets the max size in bytes of the records the events
+// of a transaction are written to the thread in. The events of transactions
+// exceeding it are split among several records, and transactions with the
+// event of a single action exceeding it fail to commit with
+// ErrRecordTooLarge. Defaults to the max record size of the network, which
+// larger sizes are lowered to.
+func WithNewMaxRecordSize(size int) NewOption {
+	return func(o *NewOptions) {
+		o.MaxRecordSize = size
+	}
+}
+
+// WithNewExpirySweepInterval sets the interval at which instances expired
+// for longer than the grace period are deleted.
+// Defaults to DefaultExpirySweepInterval.
+func WithNewExpirySweepInterval(interval time.Duration) NewOption {
+	return func(o *NewOptions) {
+		o.ExpirySweepInterval = interval
+	}
+}
+
+// WithNewExpiryGracePeriod sets the time instances are expired before
+// they're deleted, which should exceed the clock skew between peers plus the
+// time records take to reach them.
+// Defaults to DefaultExpiryGracePeriod.
+func WithNewExpiryGracePeriod(period time.Duration) NewOption {
+	return func(o *NewOptions) {
+		o.ExpiryGracePeriod = period
+	}
+}
+
+// WithNewActionLogSize sets the number of the latest actions kept to be
+// replayed to listeners resuming with Since. Older actions are pruned.
+// Defaults to DefaultActionLogSize.
+func WithNewActionLogSize(size int) NewOption {
+	return func(o *NewOptions) {
+		o.ActionLogSize = size
+	}
+}
+
+// WithNewForceRestore makes RestoreDB restore a snapshot into a store
+// which isn't empty, deleting its contents first.
+func WithNewForceRestore(force bool) NewOption {
+	return func(o *NewOptions) {
+		o.ForceRestore = force
+	}
+}
+
+// WithNewDatastore sets the store of the db to any transactional datastore,
+// like one of util/badger3ds, util/badger4ds or util/memds, instead of the
+// store given to the constructor, which may then be nil. The query extensions dbs rely on are
+// emulated if the store doesn't implement them.
+// With a manager, it sets the store of its dbs, which are prefixed by their
+// IDs.
+func WithNewDatastore(store ds.TxnDatastore) NewOption {
+	return func(o *NewOptions) {
+		o.Datastore = store
+	}
+}
+
+// WithNewGCInterval collects the garbage of the store at interval, as GC
+// does. With a manager, the garbage of the store of its dbs is collected
+// once per interval.
+// Defaults to zero, which disables it.
+func WithNewGCInterval(interval time.Duration) NewOption {
+	return func(o *NewOptions) {
+		o.GCInterval = interval
+	}
+}
+
+// WithNewEncryptionKey encrypts the values written to the store of the db
+// with key, as encryption.Wrap does, and fails creating the db with
+// encryption.ErrWrongKey if it's encrypted with another key. Stores with
+// plaintext values need encrypting first with encryption.Encrypt, or else it
+// fails with encryption.ErrNotEncrypted.
+// With a manager, it encrypts the store of all its dbs.
+func WithNewEncryptionKey(key *sym.Key) NewOption {
+	return func(o *NewOptions) {
+		o.EncryptionKey = key
+	}
+}
+
+// WithNewCloseGracePeriod sets the time Close waits for operations in flight
+// to end, after which those which haven't begun committing fail with
+// ErrDBClosed. With a manager, it's the grace period of each of its dbs.
+// Defaults to DefaultCloseGracePeriod.
+func WithNewCloseGracePeriod(period time.Duration) NewOption {
+	return func(o *NewOptions) {
+		o.CloseGracePeriod = period
+	}
+}
+
 // WithNewDebug indicate to output debug information.
 func WithNewDebug(enable bool) NewOption {
 	return func(o *NewOptions) {
@@ -93,6 +231,9 @@ func WithNewDebug(enable bool) NewOption {
 // Options defines options for interacting with a db.
 type Options struct {
 	Token thread.Token
+	// SkipValidation makes UpdateCollection apply the new schema to future
+	// writes only, without validating the existing instances.
+	SkipValidation bool
 }
 
 // Option specifies a db option.
@@ -105,6 +246,15 @@ func WithToken(t thread.Token) Option {
 	}
 }
 
+// WithSkipValidation makes UpdateCollection apply the new schema to future
+// writes only, without validating the existing instances against it. The
+// instances then aren't migrated, and Validate reports them as legacy.
+func WithSkipValidation(skip bool) Option {
+	return func(o *Options) {
+		o.SkipValidation = skip
+	}
+}
+
 // TxnOptions defines options for a transaction.
 type TxnOptions struct {
 	Token thread.Token
@@ -128,6 +278,9 @@ type NewManagedOptions struct {
 	Token       thread.Token
 	Collections []CollectionConfig
 	Block       bool
+	// Progress is called with the progress of syncing a db created with
+	// NewDBFromAddr.
+	Progress func(SyncProgress)
 }
 
 // NewManagedOption specifies a new managed db option.
@@ -182,9 +335,21 @@ func WithNewManagedBackfillBlock(block bool) NewManagedOption {
 	}
 }
 
+// WithNewManagedProgress is called by NewDBFromAddr with the progress of
+// syncing the managed db from the peers of its thread, in order, until it's
+// pulled. It blocks the sync, so it must return quickly.
+func WithNewManagedProgress(f func(SyncProgress)) NewManagedOption {
+	return func(o *NewManagedOptions) {
+		o.Progress = f
+	}
+}
+
 // ManagedOptions defines options for interacting with a managed db.
 type ManagedOptions struct {
 	Token thread.Token
+	// SkipValidation makes UpdateCollection apply the new schema to future
+	// writes only, without validating the existing instances.
+	SkipValidation bool
 }
 
 // ManagedOption specifies a managed db option.
@@ -196,3 +361,11 @@ func WithManagedToken(t thread.Token) ManagedOption {
 		o.Token = t
 	}
 }
+
+// WithManagedSkipValidation makes UpdateCollection apply the new schema to
+// future writes only, without validating the existing instances against it.
+func WithManagedSkipValidation(skip bool) ManagedOption {
+	return func(o *ManagedOptions) {
+		o.SkipValidation = skip
+	}
+}
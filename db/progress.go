@@ -0,0 +1,130 @@
+package db
+
+import (
+	"context"
+	"sync"
+
+	"github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// SyncStage is a stage of syncing a db created from the address of a thread
+// hosted by other peers.
+type SyncStage int
+
+const (
+	// SyncThreadAdded is reported once the thread is added to the network.
+	SyncThreadAdded SyncStage = iota
+	// SyncLogsDiscovered is reported with the number of logs of the thread
+	// discovered from its peers.
+	SyncLogsDiscovered
+	// SyncRecordsFetched is reported with the number of records fetched
+	// from the peers, which are reduced next.
+	SyncRecordsFetched
+	// SyncEventsReduced is reported as the events of each record are
+	// reduced.
+	SyncEventsReduced
+	// SyncDone is reported once the thread is pulled.
+	SyncDone
+)
+
+func (s SyncStage) String() string {
+	switch s {
+	case SyncThreadAdded:
+		return "thread added"
+	case SyncLogsDiscovered:
+		return "logs discovered"
+	case SyncRecordsFetched:
+		return "records fetched"
+	case SyncEventsReduced:
+		return "events reduced"
+	case SyncDone:
+		return "done"
+	default:
+		return "unknown"
+	}
+}
+
+// SyncProgress is the progress of syncing a db created from the address of a
+// thread, reported by NewDBFromAddr with WithNewProgress.
+type SyncProgress struct {
+	// Stage is the stage reached.
+	Stage SyncStage
+	// Logs is the number of logs of the thread.
+	Logs int
+	// Records is the number of records reduced.
+	Records int
+	// TotalRecords is the number of records fetched, which is 0 until
+	// they're fetched. Records fetched by more than one pull, or received
+	// from peers while pulling, can make Records exceed it.
+	TotalRecords int
+	// Events is the number of events reduced.
+	Events int
+}
+
+// syncTracker reports the progress of syncing a db while it's pulled.
+type syncTracker struct {
+	lock     sync.Mutex
+	report   func(SyncProgress)
+	progress SyncProgress
+}
+
+// update updates the progress with f and reports it, if it's tracked.
+// Progress is reported in order, with the lock held.
+func (t *syncTracker) update(f func(p *SyncProgress)) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.report == nil {
+		return
+	}
+	f(&t.progress)
+	t.report(t.progress)
+}
+
+// reduced reports the events of a record as reduced.
+func (t *syncTracker) reduced(events int) {
+	t.update(func(p *SyncProgress) {
+		p.Stage = SyncEventsReduced
+		p.Records++
+		p.Events += events
+	})
+}
+
+// reportThreadAdded reports the thread of info added, along with its logs.
+func reportThreadAdded(report func(SyncProgress), info thread.Info) {
+	if report == nil {
+		return
+	}
+	report(SyncProgress{Stage: SyncThreadAdded})
+	report(SyncProgress{Stage: SyncLogsDiscovered, Logs: len(info.Logs)})
+}
+
+// pullThread pulls the thread of the db from its peers, reporting progress
+// with report if it's set. The records pulled before ctx is canceled are
+// kept, so pulling again resumes after them.
+func (d *DB) pullThread(ctx context.Context, token thread.Token, logs int, report func(SyncProgress)) error {
+	if report != nil {
+		d.syncing.lock.Lock()
+		d.syncing.report = report
+		d.syncing.progress = SyncProgress{Logs: logs}
+		d.syncing.lock.Unlock()
+		defer func() {
+			d.syncing.lock.Lock()
+			d.syncing.report = nil
+			d.syncing.lock.Unlock()
+		}()
+	}
+	fetched := func(records int) {
+		d.syncing.update(func(p *SyncProgress) {
+			p.Stage = SyncRecordsFetched
+			p.TotalRecords += records
+		})
+	}
+	if err := d.connector.Net.PullThread(ctx, d.connector.ThreadID(), net.WithThreadToken(token), net.WithRecordsFetched(fetched)); err != nil {
+		return err
+	}
+	d.syncing.update(func(p *SyncProgress) {
+		p.Stage = SyncDone
+	})
+	return nil
+}
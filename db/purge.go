@@ -0,0 +1,119 @@
+package db
+
+import (
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+)
+
+// purgeBatchSize is the number of keys of a deleted collection each
+// transaction purging it deletes, so purging a large collection doesn't
+// exceed the size of a transaction.
+const purgeBatchSize = 1000
+
+// purgeCollection purges the data of the deleted collection named name: its
+// instances, the entries of its indexes, and its dispatched events. Its
+// deleted flag is removed last, so a purge interrupted by a crash is resumed
+// once the db is reopened.
+func (d *DB) purgeCollection(name string) error {
+	base := baseKey.ChildString(name)
+	for _, prefix := range []ds.Key{base, indexPrefix.Child(base), textIndexPrefix.Child(base)} {
+		if err := d.purgeKeys(query.Query{Prefix: prefix.String(), KeysOnly: true}); err != nil {
+			return err
+		}
+	}
+	if err := d.purgeKeys(query.Query{
+		Prefix:   dsDispatcherPrefix.String(),
+		KeysOnly: true,
+		Filters:  []query.Filter{dispatchedTo{collection: name}},
+	}); err != nil {
+		return err
+	}
+	return d.datastore.Delete(dsDeleted.ChildString(name))
+}
+
+// resumePurges purges the collections deleted before the db was closed
+// which weren't purged yet.
+func (d *DB) resumePurges() error {
+	res, err := d.datastore.Query(query.Query{Prefix: dsDeleted.String(), KeysOnly: true})
+	if err != nil {
+		return err
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		name := ds.RawKey(e.Key).Name()
+		log.Debugf("resuming purge of collection %s in %s", name, d.name)
+		if err := d.purgeCollection(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resumePurge purges the deleted collection named name if it wasn't purged
+// yet, because purging it failed.
+func (d *DB) resumePurge(name string) error {
+	deleted, err := d.datastore.Has(dsDeleted.ChildString(name))
+	if err != nil || !deleted {
+		return err
+	}
+	return d.purgeCollection(name)
+}
+
+// purgeKeys deletes the keys of the results of q in batches.
+func (d *DB) purgeKeys(q query.Query) error {
+	res, err := d.datastore.Query(q)
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+	keys := make([]ds.Key, 0, purgeBatchSize)
+	for r := range res.Next() {
+		if r.Error != nil {
+			return r.Error
+		}
+		keys = append(keys, ds.RawKey(r.Key))
+		if len(keys) == purgeBatchSize {
+			if err := d.deleteKeys(keys); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+	return d.deleteKeys(keys)
+}
+
+// deleteKeys deletes keys with a transaction.
+func (d *DB) deleteKeys(keys []ds.Key) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	txn, err := d.datastore.NewTransaction(false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard()
+	stxn := trackStats(txn)
+	for _, k := range keys {
+		if err := stxn.Delete(k); err != nil {
+			return err
+		}
+	}
+	return stxn.Commit()
+}
+
+// dispatchedTo filters the events dispatched to a collection, whose keys
+// have the format of getKey.
+type dispatchedTo struct {
+	collection string
+}
+
+func (f dispatchedTo) Filter(e query.Entry) bool {
+	// keys are /db/dispatcher/<time>/<collection>:<instance-id>
+	ns := ds.RawKey(e.Key).Namespaces()
+	return len(ns) > 3 && strings.HasPrefix(ns[3], f.collection+":")
+}
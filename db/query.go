@@ -1,12 +1,15 @@
 package db
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	ds "github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
@@ -23,14 +26,75 @@ type Query struct {
 	Limit int
 	Skip  int
 	Index string
+	// ThenSort are the sort keys which break ties on Sort, in order.
+	ThenSort []Sort
+	// Cursor resumes the query after the last result of a previous page.
+	Cursor string
+	// IncludeDeleted includes the instances of a soft-delete collection
+	// which are deleted but not purged.
+	IncludeDeleted bool
+	// Total makes FindPage also count the instances matching the query,
+	// regardless of its limit, skip and cursor.
+	Total bool
+	// Explain makes FindPage also describe how it found the results.
+	Explain bool
 }
 
-// Criterion represents a restriction on a field.
+// FindResult is a page of the results of a query.
+type FindResult struct {
+	// Instances are the results of the page.
+	Instances [][]byte
+	// Cursor is the cursor to the next page, if there are more results.
+	Cursor string
+	// Total is the number of instances matching the query, if it has Total
+	// set.
+	Total int
+	// Explain describes how the results were found, if the query has
+	// Explain set.
+	Explain *ExplainResult
+}
+
+// ExplainResult describes how FindPage found the results of a query.
+type ExplainResult struct {
+	// Index is the path of the index the results were read from, or empty
+	// if the instances of the collection were scanned.
+	Index string
+	// Indexes are the paths of the indexes the alternatives of a query
+	// were read from, in order, if the results were found among the union
+	// of their keys.
+	Indexes []string
+	// IndexKeys is the number of keys read from the index.
+	IndexKeys int
+	// InstancesRead is the number of instances read to find the results.
+	InstancesRead int
+	// Matched is the number of instances read which matched the query and
+	// passed the read filter. Reading stops at the end of the page if
+	// the results are read in order.
+	Matched int
+	// SortedInMemory is set if the results were sorted once they were all
+	// read, rather than read in order.
+	SortedInMemory bool
+	// PlanTime, ReadTime and SortTime are the time spent choosing how to
+	// find the results, reading them, and sorting them in memory.
+	PlanTime, ReadTime, SortTime time.Duration
+}
+
+// Criterion represents a restriction on a field. A criterion on an array
+// field matches if any of its elements matches.
 type Criterion struct {
 	FieldPath string
 	Operation Operation
 	Value     Value
-	query     *Query
+	// Values are the values of In and NotIn operations.
+	Values []Value
+	// Elem is the query of an Any operation, which an object element must
+	// match.
+	Elem *Query
+	// CaseInsensitive makes Contains, HasPrefix and Matches operations
+	// compare strings regardless of case.
+	CaseInsensitive bool
+	query           *Query
+	re              *regexp.Regexp
 }
 
 // Value models a single value in JSON.
@@ -55,6 +119,19 @@ func (q *Query) Validate() error {
 			return err
 		}
 	}
+	if q.Sort.FieldPath != "" {
+		if err := q.Sort.validate(); err != nil {
+			return err
+		}
+	}
+	for _, s := range q.ThenSort {
+		if s.FieldPath == "" {
+			return fmt.Errorf("sort field path can't be empty")
+		}
+		if err := s.validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -63,14 +140,72 @@ func (c *Criterion) Validate() error {
 	if c == nil {
 		return nil
 	}
+	if c.Operation == In || c.Operation == NotIn {
+		for _, v := range c.Values {
+			if err := v.validate(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if c.Operation == Any {
+		if c.Elem == nil {
+			return fmt.Errorf("%s operation requires an element query", c.Operation)
+		}
+		return c.Elem.Validate()
+	}
+	if c.Operation == Exists || c.Operation == Missing || c.Operation == IsNull {
+		return nil
+	}
+	if err := c.Value.validate(); err != nil {
+		return err
+	}
+	switch c.Operation {
+	case Contains, HasPrefix, Search:
+		if c.Value.String == nil {
+			return fmt.Errorf("%s operation requires a string value", c.Operation)
+		}
+	case Matches:
+		if c.Value.String == nil {
+			return fmt.Errorf("%s operation requires a string value", c.Operation)
+		}
+		if c.re == nil {
+			re, err := compileRegexp(*c.Value.String, c.CaseInsensitive)
+			if err != nil {
+				return err
+			}
+			c.re = re
+		}
+	}
+	return nil
+}
+
+// compileRegexp compiles the pattern of a Matches operation. Patterns use the
+// RE2 syntax, which matches in time linear to the input, and are limited to
+// maxRegexpLength bytes to bound the cost of compiling them.
+func compileRegexp(pattern string, ignoreCase bool) (*regexp.Regexp, error) {
+	if len(pattern) > maxRegexpLength {
+		return nil, fmt.Errorf("regular expression is longer than %d bytes", maxRegexpLength)
+	}
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %v", pattern, err)
+	}
+	return re, nil
+}
+
+func (v Value) validate() error {
 	noNil := 0
-	if c.Value.Bool != nil {
+	if v.Bool != nil {
 		noNil++
 	}
-	if c.Value.String != nil {
+	if v.String != nil {
 		noNil++
 	}
-	if c.Value.Float != nil {
+	if v.Float != nil {
 		noNil++
 	}
 	if noNil != 1 {
@@ -79,7 +214,9 @@ func (c *Criterion) Validate() error {
 	return nil
 }
 
-// Sort represents a sort order on a field.
+// Sort represents a sort order on a field. The field path may name a nested
+// field, like "Meta.TotalReads", and is the same as the path of an index on
+// the field.
 type Sort struct {
 	FieldPath string
 	Desc      bool
@@ -101,8 +238,72 @@ const (
 	Ge = Operation(ge)
 	// Le is "less than or equal to"
 	Le = Operation(le)
+	// In is "equal to any of"
+	In = Operation(in)
+	// NotIn is "equal to none of"
+	NotIn = Operation(nin)
+	// Contains is "contains the substring"
+	Contains = Operation(contains)
+	// HasPrefix is "starts with"
+	HasPrefix = Operation(hasPrefix)
+	// Matches is "matches the regular expression"
+	Matches = Operation(matches)
+	// Search is "contains any of the words"
+	Search = Operation(search)
+	// Any is "has an element matching the query"
+	Any = Operation(elemMatch)
+	// Exists is "has a value other than null"
+	Exists = Operation(exists)
+	// Missing is "has no value"
+	Missing = Operation(missing)
+	// IsNull is "has a null value"
+	IsNull = Operation(isNull)
 )
 
+// maxRegexpLength is the maximum length in bytes of the pattern of a Matches
+// operation.
+const maxRegexpLength = 1 << 10
+
+// String returns the name of the operation.
+func (o Operation) String() string {
+	switch o {
+	case Eq:
+		return "Eq"
+	case Ne:
+		return "Ne"
+	case Gt:
+		return "Gt"
+	case Lt:
+		return "Lt"
+	case Ge:
+		return "Ge"
+	case Le:
+		return "Le"
+	case In:
+		return "In"
+	case NotIn:
+		return "NotIn"
+	case Contains:
+		return "Contains"
+	case HasPrefix:
+		return "HasPrefix"
+	case Matches:
+		return "Matches"
+	case Search:
+		return "Search"
+	case Any:
+		return "Any"
+	case Exists:
+		return "Exists"
+	case Missing:
+		return "Missing"
+	case IsNull:
+		return "IsNull"
+	default:
+		return fmt.Sprintf("Operation(%d)", int(o))
+	}
+}
+
 var (
 	// ErrInvalidSortingField is returned when a query sorts a result by a
 	// non-existent field in the collection schema.
@@ -162,6 +363,26 @@ func (q *Query) UseIndex(path string) *Query {
 	return q
 }
 
+// WithDeleted includes deleted instances of a soft-delete collection in the
+// results.
+func (q *Query) WithDeleted() *Query {
+	q.IncludeDeleted = true
+	return q
+}
+
+// WithTotal makes FindPage also count the instances matching the query,
+// regardless of its limit, skip and cursor.
+func (q *Query) WithTotal() *Query {
+	q.Total = true
+	return q
+}
+
+// WithExplain makes FindPage also describe how it found the results.
+func (q *Query) WithExplain() *Query {
+	q.Explain = true
+	return q
+}
+
 // Or concatenates a new condition that is sufficient
 // for an instance to satisfy, independant of the current Query.
 // Has left-associativity as: (a And b) Or c
@@ -186,6 +407,14 @@ func (q *Query) OrderByDesc(field string) *Query {
 	return q
 }
 
+// ThenBy specifies an order for the query results which have the same
+// values of the previous sort keys. It can be called multiple times, the
+// keys applying in order.
+func (q *Query) ThenBy(field string, dir Direction) *Query {
+	q.ThenSort = append(q.ThenSort, Sort{FieldPath: field, Desc: dir == Desc})
+	return q
+}
+
 // OrderByID specifies ascending ID order for the query results.
 // On multiple calls, only the last one is considered.
 func (q *Query) OrderByID() *Query {
@@ -208,6 +437,13 @@ func (q *Query) SeekID(id core.InstanceID) *Query {
 	return q
 }
 
+// After resumes the query after the page of results which returned the
+// cursor. The query must be on the same collection and sorted the same way.
+func (q *Query) After(cursor string) *Query {
+	q.Cursor = cursor
+	return q
+}
+
 // LimitTo sets the maximum number of results.
 func (q *Query) LimitTo(limit int) *Query {
 	q.Limit = limit
@@ -252,6 +488,93 @@ func (c *Criterion) Le(value interface{}) *Query {
 	return c.createcriterion(Le, value)
 }
 
+// Before is a less operator against a date-time field, which compares values
+// chronologically.
+func (c *Criterion) Before(t time.Time) *Query {
+	return c.createcriterion(Lt, t)
+}
+
+// After is a greater operator against a date-time field, which compares
+// values chronologically.
+func (c *Criterion) After(t time.Time) *Query {
+	return c.createcriterion(Gt, t)
+}
+
+// In is an operator matching a field equal to any of the values, which may
+// be strings, numbers or bools.
+func (c *Criterion) In(values ...interface{}) *Query {
+	return c.createmulticriterion(In, values)
+}
+
+// NotIn is an operator matching a field equal to none of the values, which
+// may be strings, numbers or bools. Like Ne, it doesn't match instances
+// missing the field.
+func (c *Criterion) NotIn(values ...interface{}) *Query {
+	return c.createmulticriterion(NotIn, values)
+}
+
+// Contains is an operator matching a string field containing the substring.
+func (c *Criterion) Contains(s string) *Query {
+	return c.createcriterion(Contains, s)
+}
+
+// HasPrefix is an operator matching a string field starting with the prefix.
+func (c *Criterion) HasPrefix(s string) *Query {
+	return c.createcriterion(HasPrefix, s)
+}
+
+// Matches is an operator matching a string field against a regular
+// expression in the RE2 syntax. An invalid pattern makes the query invalid.
+func (c *Criterion) Matches(pattern string) *Query {
+	// errors are reported when the query is validated
+	c.re, _ = compileRegexp(pattern, c.CaseInsensitive)
+	return c.createcriterion(Matches, pattern)
+}
+
+// Search is an operator matching a string field containing any of the words
+// of text, regardless of case. Unless the query is sorted, its results are
+// ranked by the number of occurrences of the words. Searches on a field with
+// a full-text index find instances from the index, rather than scanning the
+// collection.
+func (c *Criterion) Search(text string) *Query {
+	return c.createcriterion(Search, text)
+}
+
+// Any is an operator matching an array field with an object element which
+// matches all the criteria of the query, like
+// Where("assignees").Any(Where("id").Eq(id).And("role").Eq("owner")).
+func (c *Criterion) Any(q *Query) *Query {
+	c.Elem = q
+	return c.createcriterion(Any, nil)
+}
+
+// Exists is an operator matching a field with a value other than null.
+func (c *Criterion) Exists() *Query {
+	return c.createcriterion(Exists, nil)
+}
+
+// Missing is an operator matching instances without the field, or with a
+// value along its path which isn't an object. Instances missing the field
+// aren't in the index of the field, so Missing is never read from an index:
+// queries scan the collection for it, unless they read another criterion
+// from an index.
+func (c *Criterion) Missing() *Query {
+	return c.createcriterion(Missing, nil)
+}
+
+// IsNull is an operator matching a field with a null value. Null values
+// match no other operator.
+func (c *Criterion) IsNull() *Query {
+	return c.createcriterion(IsNull, nil)
+}
+
+// IgnoreCase makes the Contains, HasPrefix or Matches operator that follows
+// case-insensitive.
+func (c *Criterion) IgnoreCase() *Criterion {
+	c.CaseInsensitive = true
+	return c
+}
+
 func createValue(value interface{}) Value {
 	s, ok := value.(string)
 	if ok {
@@ -277,6 +600,23 @@ func createValue(value interface{}) Value {
 	if ok {
 		return Value{Float: fp}
 	}
+	switch n := value.(type) {
+	case int:
+		f := float64(n)
+		return Value{Float: &f}
+	case int64:
+		f := float64(n)
+		return Value{Float: &f}
+	case int32:
+		f := float64(n)
+		return Value{Float: &f}
+	case float32:
+		f := float64(n)
+		return Value{Float: &f}
+	case time.Time:
+		d := FormatDateTime(n)
+		return Value{String: &d}
+	}
 	return Value{}
 }
 
@@ -290,8 +630,64 @@ func (c *Criterion) createcriterion(op Operation, value interface{}) *Query {
 	return c.query
 }
 
+func (c *Criterion) createmulticriterion(op Operation, values []interface{}) *Query {
+	c.Operation = op
+	c.Values = make([]Value, len(values))
+	for i, v := range values {
+		c.Values[i] = createValue(v)
+	}
+	if c.query == nil {
+		c.query = &Query{}
+	}
+	c.query.Ands = append(c.query.Ands, c)
+	return c.query
+}
+
 // Find queries for instances by Query.
+// In a read transaction, it reads the snapshot of the transaction.
 func (t *Txn) Find(q *Query) ([][]byte, error) {
+	it, err := t.findIter(context.Background(), q)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	res := make([][]byte, 0)
+	for it.Next() {
+		res = append(res, it.Value())
+	}
+	return res, it.Err()
+}
+
+// FindWithCursor queries for instances by Query, and also returns a cursor to
+// the next page of results if the query is limited and there are more. The
+// next page is queried with Query.After.
+func (t *Txn) FindWithCursor(q *Query) ([][]byte, string, error) {
+	res, err := t.FindPage(q)
+	if err != nil {
+		return nil, "", err
+	}
+	return res.Instances, res.Cursor, nil
+}
+
+// findPlan is how the results of a query are found.
+type findPlan struct {
+	// query is the query with the index it uses, and iterQuery the one
+	// iterated to seek past the cursor.
+	query, iterQuery *Query
+	search           *Criterion
+	plan             *indexPlan
+	keys             []Sort
+	after            *cursor
+	// ordered is set if the results are read in order from the index of
+	// the plan, and inMemory if they're sorted in memory instead.
+	ordered, inMemory bool
+	// union are the alternatives of the query read from indexes, if it
+	// has alternatives and all of them are
+	union []unionBranch
+}
+
+// planFind validates the query and plans how its results are found.
+func (t *Txn) planFind(q *Query) (*findPlan, error) {
 	if err := t.collection.db.connector.Validate(t.token, true); err != nil {
 		return nil, err
 	}
@@ -301,12 +697,132 @@ func (t *Txn) Find(q *Query) ([][]byte, error) {
 	if err := q.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid query: %s", err)
 	}
-	txn, err := t.collection.db.datastore.NewTransactionExtended(true)
+	if err := t.collection.checkIndexUse(q); err != nil {
+		return nil, err
+	}
+	if err := t.collection.checkSortPaths(q); err != nil {
+		return nil, err
+	}
+	p := &findPlan{}
+	p.query = t.collection.indexFor(q)
+	p.search = t.collection.searchFor(p.query)
+	if p.search == nil {
+		p.plan = t.collection.planFor(p.query)
+	}
+	if p.search == nil && p.plan == nil {
+		p.union = t.collection.planUnion(p.query)
+	}
+	p.keys = p.query.pageKeys()
+	if p.query.Cursor != "" {
+		var err error
+		if p.after, err = decodeCursor(p.query.Cursor, t.collection.name, p.keys); err != nil {
+			return nil, err
+		}
+	}
+	// Pages of results sorted by fields or read from an index can only be
+	// found once all the results are sorted, otherwise the scan resumes at
+	// the key of the cursor. Results found by a full-text index, planned
+	// on an index or found by the union of alternatives are sorted in
+	// memory, unless the first page is sorted by the field of the index of
+	// a plan, whose keys are then read in order.
+	p.ordered = p.plan != nil && p.after == nil && t.collection.sortsByIndex(p.query, p.plan)
+	if p.ordered {
+		sorted := *p.plan
+		sorted.desc = p.keys[0].Desc
+		p.plan = &sorted
+	}
+	p.inMemory = !p.ordered && (p.query.sortsInMemory() || p.search != nil || p.plan != nil || p.union != nil ||
+		(p.query.Index != "" && (p.query.Limit > 0 || p.after != nil)))
+	p.iterQuery = p.query
+	if p.after != nil && !p.inMemory {
+		p.iterQuery = &Query{}
+		*p.iterQuery = *p.query
+		p.iterQuery.Seek = p.after.ID
+	}
+	return p, nil
+}
+
+// iterate returns an iterator over the instances read from txn which match
+// the planned query.
+func (t *Txn) iterate(txn dse.TxnExt, p *findPlan) (*iterator, error) {
+	switch {
+	case p.search != nil:
+		return newSearchIterator(txn, t.collection, p.iterQuery, p.search)
+	case p.plan != nil:
+		return newPlanIterator(txn, t.collection, p.iterQuery, p.plan)
+	case p.union != nil:
+		return newUnionIterator(txn, t.collection, p.iterQuery, p.union)
+	default:
+		return newIterator(txn, t.collection.baseKey(), p.iterQuery)
+	}
+}
+
+// skipped tells whether the result is skipped as the last one of the previous
+// page, where seeking the iterator query starts.
+func (p *findPlan) skipped(res MarshaledResult) bool {
+	return p.after != nil && !p.inMemory && ds.RawKey(res.Key).Name() == p.after.ID.String()
+}
+
+// sortPage sorts the results found in memory, and returns the page of them
+// after the cursor, along with whether there are more.
+func (p *findPlan) sortPage(values []MarshaledResult) ([]sortedValue, bool, error) {
+	sorted, err := sortResults(values, p.keys, p.query.searchScore)
+	if err != nil {
+		return nil, false, err
+	}
+	if p.after != nil {
+		pos := p.after.position()
+		sorted = sorted[sort.Search(len(sorted), func(i int) bool {
+			return compareSorted(&sorted[i], pos, p.keys) > 0
+		}):]
+	}
+	if p.query.Skip >= len(sorted) {
+		sorted = nil
+	} else {
+		sorted = sorted[p.query.Skip:]
+	}
+	var more bool
+	if p.query.Limit > 0 && len(sorted) > p.query.Limit {
+		sorted, more = sorted[:p.query.Limit], true
+	}
+	return sorted, more, nil
+}
+
+// FindPage queries for instances by Query like FindWithCursor, and also
+// counts the instances matching the query if it has Total set. The results
+// and the total are read from the same snapshot of the collection.
+func (t *Txn) FindPage(q *Query) (*FindResult, error) {
+	start := time.Now()
+	p, err := t.planFind(q)
+	if err != nil {
+		return nil, err
+	}
+	q = p.query
+	var explain *ExplainResult
+	if q.Explain {
+		explain = &ExplainResult{Index: q.Index, SortedInMemory: p.inMemory, PlanTime: time.Since(start)}
+		if p.search != nil {
+			explain.Index = p.search.FieldPath
+		} else if p.plan != nil {
+			explain.Index = p.plan.index.Path
+		}
+		for _, b := range p.union {
+			explain.Indexes = append(explain.Indexes, b.path())
+		}
+	}
+
+	txn, discard, err := t.datastoreTxn()
 	if err != nil {
 		return nil, fmt.Errorf("error building internal query: %v", err)
 	}
-	defer txn.Discard()
-	iter, err := newIterator(txn, t.collection.baseKey(), q)
+	defer discard()
+	var total int
+	if q.Total {
+		if total, err = t.countMatches(txn, q); err != nil {
+			return nil, err
+		}
+	}
+	iter, err := t.iterate(txn, p)
 	if err != nil {
 		return nil, err
 	}
@@ -320,55 +836,73 @@ func (t *Txn) Find(q *Query) ([][]byte, error) {
 	// Use count to track real count of returned values taking into account
 	// read filter and any indexes etc in the query
 	var count = 0
+	var more bool
+	start = time.Now()
 	for {
 		res, ok := iter.NextSync()
 		if !ok {
 			break
 		}
-		res.Value, err = t.collection.filterRead(pk, res.Value)
+		if p.skipped(res) {
+			continue
+		}
+		res.Value, err = t.collection.filterResult(pk, res.Value, q.IncludeDeleted)
 		if err != nil {
 			return nil, err
 		}
 		if res.Value != nil {
 			// Only count valid values that aren't filtered by the read filter
 			count++
-			if count > q.Skip {
+			if p.inMemory || count > q.Skip {
+				if !p.inMemory && q.Limit > 0 && len(values) == q.Limit {
+					more = true
+					break
+				}
 				values = append(values, res)
 			}
 		}
-		if len(values) == q.Limit {
-			break
+	}
+
+	if explain != nil {
+		explain.ReadTime = time.Since(start)
+		explain.IndexKeys, explain.InstancesRead, explain.Matched = iter.indexKeys, iter.instancesRead, count
+	}
+
+	var last *sortedValue
+	if p.inMemory {
+		start = time.Now()
+		var sorted []sortedValue
+		if sorted, more, err = p.sortPage(values); err != nil {
+			return nil, err
 		}
+		values = make([]MarshaledResult, len(sorted))
+		for i := range sorted {
+			values[i] = sorted[i].result
+		}
+		if more {
+			last = &sorted[len(sorted)-1]
+		}
+		if explain != nil {
+			explain.SortTime = time.Since(start)
+		}
+	} else if more && p.ordered {
+		sorted, err := sortResults(values[len(values)-1:], p.keys, q.searchScore)
+		if err != nil {
+			return nil, err
+		}
+		last = &sorted[0]
+	} else if more {
+		last = &sortedValue{id: ds.RawKey(values[len(values)-1].Key).Name()}
 	}
 
-	if q.Sort.FieldPath != "" && q.Sort.FieldPath != idFieldName {
-		var wrongField, cantCompare bool
-		sort.Slice(values, func(i, j int) bool {
-			fieldI, err := traverseFieldPathMap(values[i].MarshaledValue, q.Sort.FieldPath)
-			if err != nil {
-				wrongField = true
-				return false
-			}
-			fieldJ, err := traverseFieldPathMap(values[j].MarshaledValue, q.Sort.FieldPath)
-			if err != nil {
-				wrongField = true
-				return false
-			}
-			res, err := compare(fieldI.Interface(), fieldJ.Interface())
-			if err != nil {
-				cantCompare = true
-				return false
-			}
-			if q.Sort.Desc {
-				res *= -1
-			}
-			return res < 0
-		})
-		if wrongField {
-			return nil, ErrInvalidSortingField
+	var next string
+	if last != nil {
+		c := &cursor{Collection: t.collection.name, Sort: p.keys, ID: core.InstanceID(last.id)}
+		if p.inMemory || p.ordered {
+			c.Fields, c.Present = last.fields, last.present
 		}
-		if cantCompare {
-			panic("can't compare while sorting")
+		if next, err = encodeCursor(c); err != nil {
+			return nil, err
 		}
 	}
 
@@ -377,7 +911,7 @@ func (t *Txn) Find(q *Query) ([][]byte, error) {
 		res[i] = values[i].Value
 	}
 
-	return res, nil
+	return &FindResult{Instances: res, Cursor: next, Total: total, Explain: explain}, nil
 }
 
 func (q *Query) match(v map[string]interface{}) (bool, error) {
@@ -389,7 +923,11 @@ func (q *Query) match(v map[string]interface{}) (bool, error) {
 	for _, c := range q.Ands {
 		fieldRes, err := traverseFieldPathMap(v, c.FieldPath)
 		if err != nil {
-			return false, err
+			// instances missing the field only match Missing
+			if andOk = c.Operation == Missing; !andOk {
+				break
+			}
+			continue
 		}
 		ok, err := c.match(fieldRes)
 		if err != nil {
@@ -453,8 +991,42 @@ func compareValue(value interface{}, critVal Value) (int, error) {
 }
 
 func (c *Criterion) match(value reflect.Value) (bool, error) {
-	valueInterface := value.Interface()
-	result, err := compareValue(valueInterface, c.Value)
+	if !value.IsValid() {
+		// the field is null
+		return c.matchValue(nil)
+	}
+	return c.matchValue(value.Interface())
+}
+
+func (c *Criterion) matchValue(value interface{}) (bool, error) {
+	switch c.Operation {
+	case Exists:
+		return value != nil, nil
+	case Missing:
+		return false, nil
+	case IsNull:
+		return value == nil, nil
+	}
+	if value == nil {
+		return false, nil
+	}
+	if c.Operation == Any {
+		return c.matchElem(value)
+	}
+	if elems, ok := value.([]interface{}); ok {
+		return c.matchElems(elems)
+	}
+	if c.Operation == In || c.Operation == NotIn {
+		found, err := matchAny(value, c.Values)
+		if err != nil {
+			return false, err
+		}
+		return found == (c.Operation == In), nil
+	}
+	if c.Operation == Contains || c.Operation == HasPrefix || c.Operation == Matches || c.Operation == Search {
+		return c.matchString(value), nil
+	}
+	result, err := compareValue(value, c.Value)
 	if err != nil {
 		return false, err
 	}
@@ -477,6 +1049,90 @@ func (c *Criterion) match(value reflect.Value) (bool, error) {
 
 }
 
+// matchElems tells whether any of the elements of an array matches. Elements
+// of another type than the criterion never match.
+func (c *Criterion) matchElems(elems []interface{}) (bool, error) {
+	for _, e := range elems {
+		ok, err := c.matchValue(e)
+		if err != nil {
+			var mismatch *errTypeMismatch
+			if errors.As(err, &mismatch) {
+				continue
+			}
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// matchElem tells whether any of the object elements of an array matches the
+// query of an Any operation. Values which aren't arrays never match.
+func (c *Criterion) matchElem(value interface{}) (bool, error) {
+	elems, ok := value.([]interface{})
+	if !ok {
+		return false, nil
+	}
+	for _, e := range elems {
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ok, err := c.Elem.match(m)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// matchString tells whether the value matches a string operation. Values
+// which aren't strings never match.
+func (c *Criterion) matchString(value interface{}) bool {
+	s, ok := value.(string)
+	if !ok {
+		return false
+	}
+	switch c.Operation {
+	case Matches:
+		return c.re.MatchString(s)
+	case Search:
+		return matchSearch(s, *c.Value.String)
+	}
+	crit := *c.Value.String
+	if c.CaseInsensitive {
+		s, crit = strings.ToLower(s), strings.ToLower(crit)
+	}
+	if c.Operation == Contains {
+		return strings.Contains(s, crit)
+	}
+	return strings.HasPrefix(s, crit)
+}
+
+// matchAny tells whether the value equals any of values. Values of another
+// type never equal it.
+func matchAny(value interface{}, values []Value) (bool, error) {
+	for _, v := range values {
+		res, err := compareValue(value, v)
+		if err != nil {
+			var mismatch *errTypeMismatch
+			if errors.As(err, &mismatch) {
+				continue
+			}
+			return false, err
+		}
+		if res == 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func traverseFieldPathMap(value map[string]interface{}, fieldPath string) (reflect.Value, error) {
 	fields := strings.Split(fieldPath, ".")
 
@@ -501,11 +1157,11 @@ func traverseFieldPathMap(value map[string]interface{}, fieldPath string) (refle
 // to query the dispatcher for all (unique) instances in this collection that have been modified
 // at all since `time`.
 func (t *Txn) ModifiedSince(time int64) (ids []core.InstanceID, err error) {
-	txn, err := t.collection.db.datastore.NewTransactionExtended(true)
+	txn, discard, err := t.datastoreTxn()
 	if err != nil {
 		return nil, err
 	}
-	defer txn.Discard()
+	defer discard()
 
 	timestr := strconv.FormatInt(time, 10)
 	res, err := txn.QueryExtended(dse.QueryExt{
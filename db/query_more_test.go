@@ -1,12 +1,18 @@
 package db
 
 import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/ipfs/go-datastore/query"
 	core "github.com/textileio/go-threads/core/db"
 	"github.com/textileio/go-threads/util"
 )
@@ -30,6 +36,13 @@ type queryTest struct {
 	ordered bool
 }
 
+// anyObjectSchema allows instances with any fields.
+const anyObjectSchema = `{
+	"$schema": "http://json-schema.org/draft-04/schema#",
+	"properties": {"_id": {"type": "string"}},
+	"type": "object"
+}`
+
 var (
 	sampleData = []book{
 		{Title: "Title1", Author: "Author1", Meta: bookStats{TotalReads: 10, Rating: 3.3}},
@@ -94,6 +107,10 @@ var (
 		{name: "LimitTotalReadsInside", query: Where("Meta.TotalReads").Lt(float64(100)).LimitTo(2), resIdx: []int{0, 1}},
 
 		{name: "LimitWithSkip", query: Where("Meta.TotalReads").Gt(float64(0)).SkipNum(1).LimitTo(3), resIdx: []int{1, 2, 3}},
+
+		{name: "SortThenByDesc", query: OrderBy("Author").ThenBy("Meta.TotalReads", Desc), resIdx: []int{2, 1, 0, 3, 4}, ordered: true},
+		{name: "SortDescThenByAsc", query: OrderByDesc("Author").ThenBy("Title", Asc), resIdx: []int{4, 3, 0, 1, 2}, ordered: true},
+		{name: "SortWithSkipLimit", query: OrderByDesc("Meta.TotalReads").SkipNum(1).LimitTo(2), resIdx: []int{3, 2}, ordered: true},
 	}
 )
 
@@ -139,6 +156,63 @@ func TestCollectionQuery(t *testing.T) {
 	}
 }
 
+func TestCollectionCount(t *testing.T) {
+	t.Parallel()
+	c, _, clean := createCollectionWithData(t)
+	defer clean()
+	for _, q := range queries {
+		q := q
+		t.Run(q.name, func(t *testing.T) {
+			count, err := c.Count(q.query)
+			checkErr(t, err)
+			if count != len(q.resIdx) {
+				t.Fatalf("wrong count, expected: %d, got: %d", len(q.resIdx), count)
+			}
+		})
+	}
+
+	t.Run("InReadTxn", func(t *testing.T) {
+		err := c.ReadTxn(func(txn *Txn) error {
+			count, err := txn.Count(Where("Author").Eq("Author1"))
+			if err != nil {
+				return err
+			}
+			if count != 3 {
+				t.Fatalf("wrong count, expected: 3, got: %d", count)
+			}
+			return nil
+		})
+		checkErr(t, err)
+	})
+
+	t.Run("WithReadFilter", func(t *testing.T) {
+		_, err := c.db.UpdateCollection(CollectionConfig{
+			Name:   "Book",
+			Schema: util.SchemaFromInstance(&book{}, false),
+			ReadFilter: `
+				if (instance.Author === "Author1") {
+					return instance
+				}
+			`,
+		})
+		checkErr(t, err)
+		c := c.db.GetCollection("Book")
+		for _, q := range []struct {
+			query *Query
+			count int
+		}{
+			{query: nil, count: 3},
+			{query: Where("Meta.TotalReads").Gt(float64(10)), count: 2},
+		} {
+			count, err := c.Count(q.query)
+			checkErr(t, err)
+			if count != q.count {
+				t.Fatalf("wrong count, expected: %d, got: %d", q.count, count)
+			}
+		}
+	})
+}
+
 func TestInvalidSortField(t *testing.T) {
 	t.Parallel()
 
@@ -150,6 +224,1646 @@ func TestInvalidSortField(t *testing.T) {
 	}
 }
 
+func TestSortByMultipleFields(t *testing.T) {
+	t.Parallel()
+
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Task",
+		Schema: util.SchemaFromSchemaString(anyObjectSchema),
+	})
+	checkErr(t, err)
+	data := []string{
+		`{"Priority": 1, "Value": "b"}`,
+		`{"Priority": 2, "Value": 3}`,
+		`{"Priority": 2, "Value": true}`,
+		`{"Priority": 1}`,
+		`{"Priority": 2, "Value": null}`,
+		`{"Priority": 1, "Value": "a"}`,
+		`{"Value": ["x"]}`,
+		`{"Priority": 2, "Value": {"k": 1}}`,
+	}
+	ids := make([]core.InstanceID, len(data))
+	for i, d := range data {
+		ids[i], err = c.Create([]byte(d))
+		checkErr(t, err)
+	}
+	byID := func(idx ...int) []int {
+		sort.Slice(idx, func(i, j int) bool {
+			return ids[idx[i]] < ids[idx[j]]
+		})
+		return idx
+	}
+
+	tests := []struct {
+		name   string
+		query  *Query
+		resIdx []int
+	}{
+		{name: "MixedTypesAsc", query: OrderByDesc("Priority").ThenBy("Value", Asc), resIdx: []int{4, 2, 1, 7, 5, 0, 3, 6}},
+		{name: "MixedTypesDesc", query: OrderByDesc("Priority").ThenBy("Value", Desc), resIdx: []int{7, 1, 2, 4, 0, 5, 3, 6}},
+		{name: "MissingLastAsc", query: OrderBy("Priority").ThenBy("Value", Asc), resIdx: []int{5, 0, 3, 4, 2, 1, 7, 6}},
+		{name: "SkipLimit", query: OrderByDesc("Priority").ThenBy("Value", Asc).SkipNum(2).LimitTo(3), resIdx: []int{1, 7, 5}},
+		{name: "TiesByID", query: OrderBy("Priority"), resIdx: append(append(byID(0, 3, 5), byID(1, 2, 4, 7)...), 6)},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// queries reach the API encoded as JSON
+			queryJSON, err := json.Marshal(tc.query)
+			checkErr(t, err)
+			q := &Query{}
+			checkErr(t, json.Unmarshal(queryJSON, q))
+			for _, query := range []*Query{tc.query, q} {
+				res, err := c.Find(query)
+				checkErr(t, err)
+				if len(res) != len(tc.resIdx) {
+					t.Fatalf("query results length doesn't match, expected: %d, got: %d", len(tc.resIdx), len(res))
+				}
+				for i, idx := range tc.resIdx {
+					var instance struct {
+						ID core.InstanceID `json:"_id"`
+					}
+					util.InstanceFromJSON(res[i], &instance)
+					if instance.ID != ids[idx] {
+						t.Fatalf("wrong query result at %d, expected: %s, got: %s", i, data[idx], res[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSortByNestedFields(t *testing.T) {
+	t.Parallel()
+
+	t.Run("MissingLast", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		c, err := db.NewCollection(CollectionConfig{
+			Name:   "Player",
+			Schema: util.SchemaFromSchemaString(anyObjectSchema),
+		})
+		checkErr(t, err)
+		data := []string{
+			`{"Stats": {"Score": 3, "Rank": 1}}`,
+			`{"Stats": {"Score": 1, "Rank": 2}}`,
+			`{"Stats": "none"}`,
+			`{}`,
+			// arrays along the path aren't objects either, whatever
+			// their elements are
+			`{"Stats": [{"Score": 0}]}`,
+			`{"Stats": {"Score": 2, "Rank": 1}}`,
+		}
+		ids := make([]core.InstanceID, len(data))
+		for i, d := range data {
+			ids[i], err = c.Create([]byte(d))
+			checkErr(t, err)
+		}
+		missing := []int{2, 3, 4}
+		sort.Slice(missing, func(i, j int) bool {
+			return ids[missing[i]] < ids[missing[j]]
+		})
+
+		tests := []struct {
+			name   string
+			query  *Query
+			resIdx []int
+		}{
+			{name: "Asc", query: OrderBy("Stats.Score"), resIdx: append([]int{1, 5, 0}, missing...)},
+			{name: "Desc", query: OrderByDesc("Stats.Score"), resIdx: append([]int{0, 5, 1}, missing...)},
+			{name: "ThenBy", query: OrderBy("Stats.Rank").ThenBy("Stats.Score", Desc), resIdx: append([]int{0, 5, 1}, missing...)},
+		}
+		for _, tc := range tests {
+			// queries reach the API encoded as JSON, with the paths
+			// unchanged
+			queryJSON, err := json.Marshal(tc.query)
+			checkErr(t, err)
+			q := &Query{}
+			checkErr(t, json.Unmarshal(queryJSON, q))
+			if !reflect.DeepEqual(q.sortKeys(), tc.query.sortKeys()) {
+				t.Fatalf("wrong sort keys of %s decoded from JSON: %+v", tc.name, q.sortKeys())
+			}
+			for _, query := range []*Query{tc.query, q} {
+				res, err := c.Find(query)
+				checkErr(t, err)
+				if len(res) != len(tc.resIdx) {
+					t.Fatalf("query results length of %s doesn't match, expected: %d, got: %d", tc.name, len(tc.resIdx), len(res))
+				}
+				for i, idx := range tc.resIdx {
+					var instance struct {
+						ID core.InstanceID `json:"_id"`
+					}
+					util.InstanceFromJSON(res[i], &instance)
+					if instance.ID != ids[idx] {
+						t.Fatalf("wrong result of %s at %d, expected: %s, got: %s", tc.name, i, data[idx], res[i])
+					}
+				}
+			}
+		}
+
+		if _, err := c.Find(OrderBy("Stats..Score")); err == nil {
+			t.Fatal("sorting by a path with an empty field should fail")
+		}
+	})
+
+	t.Run("Indexed", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		c, err := db.NewCollection(CollectionConfig{
+			Name:    "Book",
+			Schema:  util.SchemaFromInstance(&book{}, false),
+			Indexes: []Index{{Path: "Meta.TotalReads"}},
+		})
+		checkErr(t, err)
+		for _, b := range sampleData {
+			_, err := c.Create(util.JSONFromInstance(b))
+			checkErr(t, err)
+		}
+		for _, desc := range []bool{false, true} {
+			q := Where("Meta.TotalReads").Gt(float64(10))
+			q.Sort = Sort{FieldPath: "Meta.TotalReads", Desc: desc}
+			res, err := c.FindPage(q.WithExplain())
+			checkErr(t, err)
+			if e := res.Explain; e.Index != "Meta.TotalReads" || e.SortedInMemory {
+				t.Fatalf("expected the nested sort to read the index in order: %+v", e)
+			}
+			expected := []int{20, 30, 114, 500}
+			if desc {
+				expected = []int{500, 114, 30, 20}
+			}
+			if len(res.Instances) != len(expected) {
+				t.Fatalf("wrong number of results, expected: %d, got: %d", len(expected), len(res.Instances))
+			}
+			for i, r := range res.Instances {
+				var b book
+				util.InstanceFromJSON(r, &b)
+				if b.Meta.TotalReads != expected[i] {
+					t.Fatalf("wrong result at %d, expected: %d, got: %d", i, expected[i], b.Meta.TotalReads)
+				}
+			}
+		}
+	})
+
+	t.Run("ArrayRejected", func(t *testing.T) {
+		t.Parallel()
+		type review struct {
+			Score int
+		}
+		type reviewed struct {
+			ID      core.InstanceID `json:"_id"`
+			Reviews []review
+		}
+		db, clean := createTestDB(t)
+		defer clean()
+		schema := util.SchemaFromInstance(&reviewed{}, false)
+		_, err := db.NewCollection(CollectionConfig{
+			Name:    "Reviewed",
+			Schema:  schema,
+			Indexes: []Index{{Path: "Reviews.Score"}},
+		})
+		if !errors.Is(err, ErrInvalidCollectionSchemaPath) {
+			t.Fatalf("expected indexing a path through an array to fail, got: %v", err)
+		}
+		c, err := db.NewCollection(CollectionConfig{Name: "Reviewed", Schema: schema})
+		checkErr(t, err)
+		_, err = c.Create(util.JSONFromInstance(reviewed{Reviews: []review{{Score: 1}}}))
+		checkErr(t, err)
+		for _, q := range []*Query{
+			OrderBy("Reviews.Score"),
+			OrderBy("Reviews.0.Score"),
+			OrderBy("_id").ThenBy("Reviews.Score", Desc),
+		} {
+			if _, err := c.Find(q); !errors.Is(err, ErrInvalidSortingField) {
+				t.Fatalf("expected sorting by a path through an array to fail, got: %v", err)
+			}
+		}
+	})
+}
+
+func TestInMissingField(t *testing.T) {
+	t.Parallel()
+
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name: "Task",
+		Schema: util.SchemaFromSchemaString(`{
+			"$schema": "http://json-schema.org/draft-04/schema#",
+			"properties": {
+				"_id": {"type": "string"},
+				"Status": {"type": "string"},
+				"Name": {"type": "string"}
+			},
+			"type": "object"
+		}`),
+		Indexes: []Index{{
+			Path: "Status",
+		}},
+	})
+	checkErr(t, err)
+	data := []string{
+		`{"Status": "open"}`,
+		`{"Name": "no status"}`,
+		`{"Status": "blocked"}`,
+		`{"Status": "done"}`,
+	}
+	ids := make([]core.InstanceID, len(data))
+	for i, d := range data {
+		ids[i], err = c.Create([]byte(d))
+		checkErr(t, err)
+	}
+
+	// instances missing the field match neither Ne nor NotIn, nor In
+	tests := []struct {
+		name   string
+		query  *Query
+		resIdx []int
+	}{
+		{name: "Ne", query: Where("Status").Ne("done"), resIdx: []int{0, 2}},
+		{name: "NotIn", query: Where("Status").NotIn("done"), resIdx: []int{0, 2}},
+		{name: "NotInMany", query: Where("Status").NotIn("done", "open"), resIdx: []int{2}},
+		{name: "In", query: Where("Status").In("open", "blocked", "open"), resIdx: []int{0, 2}},
+		{name: "InUseIndex", query: Where("Status").In("open", "blocked").UseIndex("Status"), resIdx: []int{0, 2}},
+		{name: "NotInOrName", query: Where("Status").NotIn("open", "blocked", "done").Or(Where("Name").Eq("no status")), resIdx: []int{1}},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// queries reach the API encoded as JSON
+			queryJSON, err := json.Marshal(tc.query)
+			checkErr(t, err)
+			q := &Query{}
+			checkErr(t, json.Unmarshal(queryJSON, q))
+			for _, query := range []*Query{tc.query, q} {
+				res, err := c.Find(query)
+				checkErr(t, err)
+				var got []string
+				for _, r := range res {
+					var instance struct {
+						ID core.InstanceID `json:"_id"`
+					}
+					util.InstanceFromJSON(r, &instance)
+					got = append(got, instance.ID.String())
+				}
+				var expected []string
+				for _, idx := range tc.resIdx {
+					expected = append(expected, ids[idx].String())
+				}
+				sort.Strings(got)
+				sort.Strings(expected)
+				if !reflect.DeepEqual(got, expected) {
+					t.Fatalf("wrong query results, expected: %v, got: %v", expected, got)
+				}
+				count, err := c.Count(query)
+				checkErr(t, err)
+				if count != len(tc.resIdx) {
+					t.Fatalf("wrong count, expected: %d, got: %d", len(tc.resIdx), count)
+				}
+			}
+		})
+	}
+
+	t.Run("IndexLookups", func(t *testing.T) {
+		q := c.indexFor(Where("Status").In("open", "blocked", "open"))
+		if q.Index != "Status" {
+			t.Fatalf("expected the index on Status to be used, got: %q", q.Index)
+		}
+		if lookups := q.indexLookups(indexPrefix.Child(c.baseKey()).ChildString(q.Index)); len(lookups) != 2 {
+			t.Fatalf("expected 2 index lookups, got: %v", lookups)
+		}
+		if q := c.indexFor(Where("Status").NotIn("open")); q.Index != "" {
+			t.Fatalf("expected no index for NotIn, got: %q", q.Index)
+		}
+	})
+}
+
+func TestExistsMissing(t *testing.T) {
+	t.Parallel()
+
+	db, clean := createTestDB(t)
+	defer clean()
+	schema := util.SchemaFromSchemaString(`{
+		"$schema": "http://json-schema.org/draft-04/schema#",
+		"properties": {
+			"_id": {"type": "string"},
+			"Owner": {"type": "string"},
+			"Due": {"type": "string"}
+		},
+		"type": "object"
+	}`)
+	plain, err := db.NewCollection(CollectionConfig{Name: "Plain", Schema: schema})
+	checkErr(t, err)
+	indexed, err := db.NewCollection(CollectionConfig{
+		Name:    "Indexed",
+		Schema:  schema,
+		Indexes: []Index{{Path: "Owner"}, {Path: "Due"}},
+	})
+	checkErr(t, err)
+	data := []string{
+		`{"Owner": "a", "Due": "2020-01-01", "Note": "x"}`,
+		`{"Owner": "a", "Note": null}`,
+		`{"Owner": "b", "Due": "2021-01-01", "Note": null}`,
+		`{"Owner": "b"}`,
+		`{"Owner": "a", "Due": "2022-01-01"}`,
+	}
+	ids := make(map[*Collection][]core.InstanceID)
+	for _, c := range []*Collection{plain, indexed} {
+		for _, d := range data {
+			id, err := c.Create([]byte(d))
+			checkErr(t, err)
+			ids[c] = append(ids[c], id)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		query  *Query
+		resIdx []int
+		// index is the index the indexed collection reads the results
+		// from, if any
+		index string
+	}{
+		{name: "Exists", query: Where("Due").Exists(), resIdx: []int{0, 2, 4}},
+		{name: "Missing", query: Where("Due").Missing(), resIdx: []int{1, 3}},
+		{name: "NullExists", query: Where("Note").Exists(), resIdx: []int{0}},
+		{name: "NullMissing", query: Where("Note").Missing(), resIdx: []int{3, 4}},
+		{name: "IsNull", query: Where("Note").IsNull(), resIdx: []int{1, 2}},
+		{name: "MissingOrNull", query: Where("Note").Missing().Or(Where("Note").IsNull()), resIdx: []int{1, 2, 3, 4}},
+		{name: "NullEq", query: Where("Note").Eq("x"), resIdx: []int{0}},
+		{name: "NullNe", query: Where("Note").Ne("x")},
+		{name: "NestedMissing", query: Where("Owner.Name").Missing(), resIdx: []int{0, 1, 2, 3, 4}},
+		{name: "IndexedAndMissing", query: Where("Owner").Eq("a").And("Due").Missing(), resIdx: []int{1}, index: "Owner"},
+		{name: "IndexedAndIsNull", query: Where("Due").Ge("2021-01-01").And("Note").IsNull(), resIdx: []int{2}, index: "Due"},
+		{name: "OrMissing", query: Where("Due").Eq("2020-01-01").Or(Where("Due").Missing()), resIdx: []int{0, 1, 3}},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// queries reach the API encoded as JSON
+			queryJSON, err := json.Marshal(tc.query)
+			checkErr(t, err)
+			q := &Query{}
+			checkErr(t, json.Unmarshal(queryJSON, q))
+			for _, c := range []*Collection{plain, indexed} {
+				for _, query := range []*Query{tc.query, q} {
+					explained := *query
+					res, err := c.FindPage(explained.WithExplain())
+					checkErr(t, err)
+					var got []string
+					for _, r := range res.Instances {
+						var instance struct {
+							ID core.InstanceID `json:"_id"`
+						}
+						util.InstanceFromJSON(r, &instance)
+						got = append(got, instance.ID.String())
+					}
+					var expected []string
+					for _, idx := range tc.resIdx {
+						expected = append(expected, ids[c][idx].String())
+					}
+					sort.Strings(got)
+					sort.Strings(expected)
+					if !reflect.DeepEqual(got, expected) {
+						t.Fatalf("wrong query results of %s, expected: %v, got: %v", c.name, expected, got)
+					}
+					if c == indexed && res.Explain.Index != tc.index {
+						t.Fatalf("expected the results to be read from index %q, got: %q", tc.index, res.Explain.Index)
+					}
+					count, err := c.Count(query)
+					checkErr(t, err)
+					if count != len(tc.resIdx) {
+						t.Fatalf("wrong count of %s, expected: %d, got: %d", c.name, len(tc.resIdx), count)
+					}
+				}
+			}
+		})
+	}
+
+	t.Run("UseIndex", func(t *testing.T) {
+		res, err := indexed.Find(Where("Due").Exists().UseIndex("Due"))
+		checkErr(t, err)
+		if len(res) != 3 {
+			t.Fatalf("expected 3 instances with the field of the index, got: %d", len(res))
+		}
+		for _, q := range []*Query{
+			Where("Due").Missing().UseIndex("Due"),
+			Where("Owner").Eq("a").Or(Where("Due").Missing()).UseIndex("Due"),
+		} {
+			if _, err := indexed.Find(q); !errors.Is(err, ErrMissingIndexQuery) {
+				t.Fatalf("expected finding instances missing the field of the index to fail, got: %v", err)
+			}
+			if _, err := indexed.Count(q); !errors.Is(err, ErrMissingIndexQuery) {
+				t.Fatalf("expected counting instances missing the field of the index to fail, got: %v", err)
+			}
+		}
+	})
+}
+
+func TestStringMatching(t *testing.T) {
+	t.Parallel()
+
+	c, _, clean := createCollectionWithData(t)
+	defer clean()
+
+	t.Run("JSON", func(t *testing.T) {
+		query := Where("Author").IgnoreCase().Matches("^AUTHOR[23]$").Or(Where("Title").IgnoreCase().Contains("TLE1"))
+		queryJSON, err := json.Marshal(query)
+		checkErr(t, err)
+		q := &Query{}
+		checkErr(t, json.Unmarshal(queryJSON, q))
+		if !q.Ands[0].CaseInsensitive || q.Ands[0].Operation != Matches {
+			t.Fatalf("string matching criterion wasn't decoded: %+v", q.Ands[0])
+		}
+		res, err := c.Find(q)
+		checkErr(t, err)
+		if len(res) != 3 {
+			t.Fatalf("expected 3 results, got: %d", len(res))
+		}
+		count, err := c.Count(q)
+		checkErr(t, err)
+		if count != 3 {
+			t.Fatalf("expected a count of 3, got: %d", count)
+		}
+	})
+
+	t.Run("InvalidRegexp", func(t *testing.T) {
+		invalid := []*Query{
+			Where("Title").Matches("Title(1"),
+			Where("Title").Matches(strings.Repeat("a", maxRegexpLength+1)),
+			Where("Title").Eq("Title1").Or(Where("Author").IgnoreCase().Matches("[z-a]")),
+		}
+		for i, query := range invalid {
+			queryJSON, err := json.Marshal(query)
+			checkErr(t, err)
+			q := &Query{}
+			checkErr(t, json.Unmarshal(queryJSON, q))
+			for _, query := range []*Query{query, q} {
+				err := query.Validate()
+				if err == nil || !strings.Contains(err.Error(), "regular expression") {
+					t.Fatalf("query %d: expected a regular expression error, got: %v", i, err)
+				}
+				if _, err := c.Find(query); err == nil {
+					t.Fatalf("query %d: expected Find to fail", i)
+				}
+			}
+		}
+	})
+
+	t.Run("NonStringValue", func(t *testing.T) {
+		rating := 3.3
+		q := &Query{Ands: []*Criterion{{FieldPath: "Title", Operation: HasPrefix, Value: Value{Float: &rating}}}}
+		if err := q.Validate(); err == nil {
+			t.Fatal("expected HasPrefix with a number to be invalid")
+		}
+	})
+}
+
+type note struct {
+	ID    core.InstanceID `json:"_id"`
+	Title string
+	Notes string
+}
+
+func TestFullTextIndex(t *testing.T) {
+	t.Parallel()
+
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:    "Note",
+		Schema:  util.SchemaFromInstance(&note{}, false),
+		Indexes: []Index{{Path: "Notes", FullText: true}},
+	})
+	checkErr(t, err)
+	notes := []note{
+		{Title: "a", Notes: "Sync conflict when syncing the log"},
+		{Title: "b", Notes: "Conflict, conflict, CONFLICT!"},
+		{Title: "c", Notes: "Nothing to see here"},
+		{Title: "d", Notes: "sync; sync"},
+	}
+	for i := range notes {
+		id, err := c.Create(util.JSONFromInstance(notes[i]))
+		checkErr(t, err)
+		notes[i].ID = id
+	}
+	search := func(t *testing.T, q *Query) []string {
+		res, err := c.Find(q)
+		checkErr(t, err)
+		titles := make([]string, len(res))
+		for i, r := range res {
+			n := note{}
+			util.InstanceFromJSON(r, &n)
+			titles[i] = n.Title
+		}
+		count, err := c.Count(q)
+		checkErr(t, err)
+		if count != len(res) {
+			t.Fatalf("count %d doesn't match the %d results", count, len(res))
+		}
+		return titles
+	}
+	// ties on the score are broken by ascending ID
+	tied := []string{"a", "d"}
+	if notes[3].ID < notes[0].ID {
+		tied = []string{"d", "a"}
+	}
+
+	t.Run("Ranked", func(t *testing.T) {
+		got := search(t, Where("Notes").Search("sync conflict"))
+		expected := append([]string{"b"}, tied...)
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("expected ranked results %v, got: %v", expected, got)
+		}
+	})
+
+	t.Run("WithCriteria", func(t *testing.T) {
+		got := search(t, Where("Notes").Search("SYNC").And("Title").Ne("a"))
+		if !reflect.DeepEqual(got, []string{"d"}) {
+			t.Fatalf("expected [d], got: %v", got)
+		}
+	})
+
+	t.Run("Sorted", func(t *testing.T) {
+		got := search(t, Where("Notes").Search("sync conflict").OrderByDesc("Title"))
+		if !reflect.DeepEqual(got, []string{"d", "b", "a"}) {
+			t.Fatalf("expected [d b a], got: %v", got)
+		}
+	})
+
+	t.Run("Pages", func(t *testing.T) {
+		q := Where("Notes").Search("sync conflict").LimitTo(2)
+		res, next, err := c.FindWithCursor(q)
+		checkErr(t, err)
+		if len(res) != 2 || next == "" {
+			t.Fatalf("expected a first page of 2 results and a cursor, got %d results", len(res))
+		}
+		res, next, err = c.FindWithCursor(Where("Notes").Search("sync conflict").LimitTo(2).After(next))
+		checkErr(t, err)
+		n := note{}
+		util.InstanceFromJSON(res[0], &n)
+		if len(res) != 1 || next != "" || n.Title != tied[1] {
+			t.Fatalf("expected a last page with %s, got %d results", tied[1], len(res))
+		}
+	})
+
+	t.Run("WithoutIndex", func(t *testing.T) {
+		got := search(t, Where("Title").Search("c b"))
+		if !reflect.DeepEqual(got, []string{"b", "c"}) && !reflect.DeepEqual(got, []string{"c", "b"}) {
+			t.Fatalf("expected b and c, got: %v", got)
+		}
+	})
+
+	t.Run("UseIndex", func(t *testing.T) {
+		if _, err := c.Find(Where("Notes").Eq("sync").UseIndex("Notes")); !errors.Is(err, ErrFullTextIndexQuery) {
+			t.Fatalf("expected ErrFullTextIndexQuery, got: %v", err)
+		}
+	})
+
+	t.Run("Updates", func(t *testing.T) {
+		notes[2].Notes = "a sync at last"
+		checkErr(t, c.Save(util.JSONFromInstance(notes[2])))
+		notes[3].Notes = "unrelated"
+		checkErr(t, c.Save(util.JSONFromInstance(notes[3])))
+		checkErr(t, c.Delete(notes[1].ID))
+		got := search(t, Where("Notes").Search("sync conflict"))
+		if len(got) != 2 || got[0] != "a" || got[1] != "c" {
+			t.Fatalf("expected [a c], got: %v", got)
+		}
+	})
+
+	t.Run("Transaction", func(t *testing.T) {
+		errAbort := errors.New("abort")
+		err := c.WriteTxn(func(txn *Txn) error {
+			if _, err := txn.Create(util.JSONFromInstance(note{Title: "e", Notes: "uncommitted"})); err != nil {
+				return err
+			}
+			return errAbort
+		})
+		if !errors.Is(err, errAbort) {
+			t.Fatalf("expected the transaction to abort, got: %v", err)
+		}
+		if got := search(t, Where("Notes").Search("uncommitted")); len(got) != 0 {
+			t.Fatalf("expected no results of an aborted transaction, got: %v", got)
+		}
+		checkErr(t, c.WriteTxn(func(txn *Txn) error {
+			_, err := txn.Create(util.JSONFromInstance(note{Title: "e", Notes: "committed"}))
+			return err
+		}))
+		if got := search(t, Where("Notes").Search("committed")); !reflect.DeepEqual(got, []string{"e"}) {
+			t.Fatalf("expected [e], got: %v", got)
+		}
+	})
+
+	t.Run("Rebuild", func(t *testing.T) {
+		legacy, err := db.NewCollection(CollectionConfig{
+			Name:   "LegacyNote",
+			Schema: util.SchemaFromInstance(&note{}, false),
+		})
+		checkErr(t, err)
+		_, err = legacy.Create(util.JSONFromInstance(note{Title: "a", Notes: "written before the index"}))
+		checkErr(t, err)
+		legacy, err = db.UpdateCollection(CollectionConfig{
+			Name:    "LegacyNote",
+			Schema:  util.SchemaFromInstance(&note{}, false),
+			Indexes: []Index{{Path: "Notes", FullText: true}},
+		})
+		checkErr(t, err)
+		res, err := legacy.Find(Where("Notes").Search("index"))
+		checkErr(t, err)
+		if len(res) != 0 {
+			t.Fatal("expected instances written before the index not to be indexed")
+		}
+		checkErr(t, legacy.RebuildIndex("Notes"))
+		res, err = legacy.Find(Where("Notes").Search("index"))
+		checkErr(t, err)
+		if len(res) != 1 {
+			t.Fatalf("expected 1 result after rebuilding the index, got: %d", len(res))
+		}
+		if err := legacy.RebuildIndex("Title"); !errors.Is(err, ErrIndexNotFound) {
+			t.Fatalf("expected ErrIndexNotFound, got: %v", err)
+		}
+	})
+
+	t.Run("DeleteCollection", func(t *testing.T) {
+		checkErr(t, db.DeleteCollection("Note"))
+		res, err := db.datastore.Query(query.Query{Prefix: textIndexPrefix.Child(c.baseKey()).String(), KeysOnly: true})
+		checkErr(t, err)
+		entries, err := res.Rest()
+		checkErr(t, err)
+		if len(entries) != 0 {
+			t.Fatalf("expected the full-text index to be deleted, found %d entries", len(entries))
+		}
+	})
+}
+
+const taskSchema = `{
+	"$schema": "http://json-schema.org/draft-04/schema#",
+	"properties": {
+		"_id": {"type": "string"},
+		"N": {"type": "integer"},
+		"Owner": {"type": "string"},
+		"Status": {"type": "string"},
+		"Priority": {"type": "number"},
+		"Done": {"type": "boolean"}
+	},
+	"type": "object"
+}`
+
+func TestCompositeIndex(t *testing.T) {
+	t.Parallel()
+
+	db, clean := createTestDB(t)
+	defer clean()
+	indexes := []Index{
+		{Fields: []string{"Owner", "Status"}},
+		{Path: "byPriority", Fields: []string{"Owner", "Priority"}},
+	}
+	indexed, err := db.NewCollection(CollectionConfig{
+		Name:    "Indexed",
+		Schema:  util.SchemaFromSchemaString(taskSchema),
+		Indexes: indexes,
+	})
+	checkErr(t, err)
+	plain, err := db.NewCollection(CollectionConfig{
+		Name:   "Plain",
+		Schema: util.SchemaFromSchemaString(taskSchema),
+	})
+	checkErr(t, err)
+	backfilled, err := db.NewCollection(CollectionConfig{
+		Name:   "Backfilled",
+		Schema: util.SchemaFromSchemaString(taskSchema),
+	})
+	checkErr(t, err)
+
+	owners := []string{"alice", "bob", "carol"}
+	statuses := []string{"open", "blocked", "done"}
+	for n := 0; n < 60; n++ {
+		task := fmt.Sprintf(`{"N": %d, "Owner": %q, "Priority": %v, "Done": %v`,
+			n, owners[n%len(owners)], float64(n%7)-2.5, n%4 == 0)
+		if n%11 != 0 {
+			// some tasks have no status
+			task += fmt.Sprintf(`, "Status": %q`, statuses[n%len(statuses)])
+		}
+		task += "}"
+		for _, c := range []*Collection{indexed, plain, backfilled} {
+			_, err := c.Create([]byte(task))
+			checkErr(t, err)
+		}
+	}
+	backfilled, err = db.UpdateCollection(CollectionConfig{
+		Name:    "Backfilled",
+		Schema:  util.SchemaFromSchemaString(taskSchema),
+		Indexes: indexes,
+	})
+	checkErr(t, err)
+	for _, index := range backfilled.GetIndexes() {
+		checkErr(t, backfilled.RebuildIndex(index.Path))
+	}
+
+	results := func(t *testing.T, c *Collection, q *Query) []int {
+		res, err := c.Find(q)
+		checkErr(t, err)
+		ns := make([]int, len(res))
+		for i, r := range res {
+			var task struct{ N int }
+			util.InstanceFromJSON(r, &task)
+			ns[i] = task.N
+		}
+		count, err := c.Count(q)
+		checkErr(t, err)
+		if count != len(res) {
+			t.Fatalf("count %d doesn't match the %d results", count, len(res))
+		}
+		if q.Sort.FieldPath == "" {
+			sort.Ints(ns)
+		}
+		return ns
+	}
+
+	tests := []struct {
+		name  string
+		query *Query
+		index string
+	}{
+		{name: "EqEq", query: Where("Owner").Eq("alice").And("Status").Eq("open"), index: "Owner,Status"},
+		{name: "EqPrefix", query: Where("Owner").Eq("bob"), index: "Owner,Status"},
+		{name: "EqGt", query: Where("Owner").Eq("carol").And("Priority").Gt(0.5), index: "byPriority"},
+		{name: "EqGeLt", query: Where("Owner").Eq("alice").And("Priority").Ge(-1.5).And("Priority").Lt(2.5), index: "byPriority"},
+		{name: "EqLe", query: Where("Owner").Eq("bob").And("Priority").Le(-1.5), index: "byPriority"},
+		{name: "EqEqOther", query: Where("Owner").Eq("alice").And("Status").Eq("done").And("Done").Eq(true), index: "Owner,Status"},
+		{name: "EqNe", query: Where("Owner").Eq("carol").And("Status").Ne("open"), index: "Owner,Status"},
+		{name: "EqEqPriority", query: Where("Owner").Eq("bob").And("Priority").Eq(1.5), index: "byPriority"},
+		{name: "EqNone", query: Where("Owner").Eq("dave").And("Status").Eq("open"), index: "Owner,Status"},
+		{name: "SortedPage", query: Where("Owner").Eq("alice").And("Priority").Gt(-2.0).OrderByDesc("N").LimitTo(5).SkipNum(2), index: "byPriority"},
+		{name: "NoPrefix", query: Where("Status").Eq("open")},
+		{name: "Or", query: Where("Owner").Eq("alice").Or(Where("Owner").Eq("bob"))},
+		{name: "UseIndexOr", query: Where("Owner").Eq("alice").Or(Where("Status").Eq("open")).UseIndex("Owner,Status"), index: "Owner,Status"},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			plan := indexed.planFor(tc.query)
+			if tc.index == "" && plan != nil {
+				t.Fatalf("expected no composite index, got: %s", plan.index.Path)
+			}
+			if tc.index != "" && (plan == nil || plan.index.Path != tc.index) {
+				t.Fatalf("expected the composite index %s, got: %+v", tc.index, plan)
+			}
+			scan := *tc.query
+			scan.Index = ""
+			expected := results(t, plain, &scan)
+			for _, c := range []*Collection{indexed, backfilled} {
+				if got := results(t, c, tc.query); !reflect.DeepEqual(got, expected) {
+					t.Fatalf("wrong results in %s, expected: %v, got: %v", c.GetName(), expected, got)
+				}
+			}
+		})
+	}
+
+	t.Run("KeyOrder", func(t *testing.T) {
+		values := []interface{}{nil, false, true, -10.0, -1.5, 0.0, 2.0, 100.0, "", "a", "a\x00", "ab", "b"}
+		var keys []string
+		for _, v := range values {
+			for _, w := range values {
+				keys = append(keys, hex.EncodeToString(appendTupleValue(appendTupleValue(nil, v, true), w, true)))
+			}
+		}
+		if !sort.StringsAreSorted(keys) {
+			t.Fatal("composite index keys don't sort like their values")
+		}
+	})
+
+	t.Run("Unique", func(t *testing.T) {
+		c, err := db.NewCollection(CollectionConfig{
+			Name:    "Unique",
+			Schema:  util.SchemaFromSchemaString(taskSchema),
+			Indexes: []Index{{Fields: []string{"Owner", "N"}, Unique: true}},
+		})
+		checkErr(t, err)
+		_, err = c.Create([]byte(`{"N": 1, "Owner": "alice"}`))
+		checkErr(t, err)
+		_, err = c.Create([]byte(`{"N": 2, "Owner": "alice"}`))
+		checkErr(t, err)
+		if _, err = c.Create([]byte(`{"N": 1, "Owner": "alice"}`)); !errors.Is(err, ErrUniqueExists) {
+			t.Fatalf("expected ErrUniqueExists, got: %v", err)
+		}
+	})
+}
+
+const eventSchema = `{
+	"$schema": "http://json-schema.org/draft-04/schema#",
+	"properties": {
+		"_id": {"type": "string"},
+		"N": {"type": "integer"},
+		"CreatedAt": {"type": "number"},
+		"Name": {"type": "string"},
+		"Tags": {"type": "array", "items": {"type": "string"}}
+	},
+	"type": "object"
+}`
+
+func TestRangeIndex(t *testing.T) {
+	t.Parallel()
+
+	db, clean := createTestDB(t)
+	defer clean()
+	indexed, err := db.NewCollection(CollectionConfig{
+		Name:    "Indexed",
+		Schema:  util.SchemaFromSchemaString(eventSchema),
+		Indexes: []Index{{Path: "CreatedAt"}, {Path: "Name"}, {Path: "Tags"}},
+	})
+	checkErr(t, err)
+	plain, err := db.NewCollection(CollectionConfig{
+		Name:   "Plain",
+		Schema: util.SchemaFromSchemaString(eventSchema),
+	})
+	checkErr(t, err)
+	for n := 0; n < 80; n++ {
+		// the same IDs in both collections break ties alike
+		event := fmt.Sprintf(`{"_id": "%03d", "N": %d, "CreatedAt": %v, "Name": "name/%02d", "Tags": ["t%d", "t%d"]}`,
+			(n*29)%80, n, float64((n*37)%50)/2-10, (n*13)%30, n%5, n%7)
+		for _, c := range []*Collection{indexed, plain} {
+			_, err := c.Create([]byte(event))
+			checkErr(t, err)
+		}
+	}
+
+	results := func(t *testing.T, c *Collection, q *Query) []int {
+		res, err := c.Find(q)
+		checkErr(t, err)
+		ns := make([]int, len(res))
+		for i, r := range res {
+			var event struct{ N int }
+			util.InstanceFromJSON(r, &event)
+			ns[i] = event.N
+		}
+		count, err := c.Count(q)
+		checkErr(t, err)
+		if count != len(res) {
+			t.Fatalf("count %d doesn't match the %d results", count, len(res))
+		}
+		if q.Sort.FieldPath == "" {
+			sort.Ints(ns)
+		}
+		return ns
+	}
+
+	tests := []struct {
+		name    string
+		query   *Query
+		index   string
+		ordered bool
+	}{
+		{name: "GtLt", query: Where("CreatedAt").Gt(0.0).And("CreatedAt").Lt(5.5), index: "CreatedAt"},
+		{name: "GeLe", query: Where("CreatedAt").Ge(-3.5).And("CreatedAt").Le(2.0), index: "CreatedAt"},
+		{name: "Gt", query: Where("CreatedAt").Gt(12.5), index: "CreatedAt"},
+		{name: "Le", query: Where("CreatedAt").Le(-8.0), index: "CreatedAt"},
+		{name: "Eq", query: Where("CreatedAt").Eq(4.5), index: "CreatedAt"},
+		{name: "Other", query: Where("CreatedAt").Ge(-5.0).And("Name").HasPrefix("name/1"), index: "CreatedAt"},
+		{name: "Strings", query: Where("Name").Ge("name/10").And("Name").Lt("name/2"), index: "Name"},
+		{name: "Array", query: Where("Tags").Gt("t3"), index: "Tags"},
+		{name: "UseIndex", query: Where("CreatedAt").Lt(0.0).UseIndex("CreatedAt"), index: "CreatedAt"},
+		{name: "Sorted", query: Where("CreatedAt").Gt(1.0).OrderBy("CreatedAt"), index: "CreatedAt", ordered: true},
+		{name: "SortedDesc", query: Where("CreatedAt").Ge(10.0).OrderByDesc("CreatedAt"), index: "CreatedAt", ordered: true},
+		{name: "SortedPage", query: Where("CreatedAt").Le(7.5).OrderByDesc("CreatedAt").LimitTo(7).SkipNum(3), index: "CreatedAt", ordered: true},
+		{name: "SortedStrings", query: Where("Name").Lt("name/15").OrderBy("Name").LimitTo(10), index: "Name", ordered: true},
+		{name: "SortedOther", query: Where("CreatedAt").Gt(1.0).OrderByDesc("N"), index: "CreatedAt"},
+		{name: "ThenBy", query: Where("CreatedAt").Gt(1.0).OrderBy("CreatedAt").ThenBy("N", Desc), index: "CreatedAt"},
+		{name: "SortedArray", query: Where("Tags").Ge("t2").OrderBy("Tags"), index: "Tags"},
+		{name: "In", query: Where("CreatedAt").In(4.5, -10.0, 100.0)},
+		{name: "NoIndex", query: Where("N").Gt(40)},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			plan := indexed.planFor(indexed.indexFor(tc.query))
+			if tc.index == "" && plan != nil {
+				t.Fatalf("expected no index, got: %s", plan.index.Path)
+			}
+			if tc.index != "" && (plan == nil || plan.index.Path != tc.index) {
+				t.Fatalf("expected the index %s, got: %+v", tc.index, plan)
+			}
+			if plan != nil && indexed.sortsByIndex(tc.query, plan) != tc.ordered {
+				t.Fatalf("expected the index to sort the results: %v", tc.ordered)
+			}
+			scan := *tc.query
+			scan.Index = ""
+			expected := results(t, plain, &scan)
+			if got := results(t, indexed, tc.query); !reflect.DeepEqual(got, expected) {
+				t.Fatalf("wrong results, expected: %v, got: %v", expected, got)
+			}
+		})
+	}
+
+	t.Run("Pages", func(t *testing.T) {
+		q := Where("CreatedAt").Gt(-6.0).OrderByDesc("CreatedAt")
+		expected := results(t, plain, q)
+		var got [][]byte
+		page := *q
+		page.Limit = 6
+		for {
+			res, next, err := indexed.FindWithCursor(&page)
+			checkErr(t, err)
+			got = append(got, res...)
+			if next == "" {
+				break
+			}
+			page.Cursor = next
+		}
+		ns := make([]int, len(got))
+		for i, r := range got {
+			var event struct{ N int }
+			util.InstanceFromJSON(r, &event)
+			ns[i] = event.N
+		}
+		if !reflect.DeepEqual(ns, expected) {
+			t.Fatalf("wrong pages, expected: %v, got: %v", expected, ns)
+		}
+	})
+
+	t.Run("Explain", func(t *testing.T) {
+		for _, tc := range tests {
+			explained := *tc.query
+			res, err := indexed.FindPage(explained.WithExplain())
+			checkErr(t, err)
+			expected, err := indexed.Find(tc.query)
+			checkErr(t, err)
+			if !reflect.DeepEqual(res.Instances, expected) {
+				t.Fatalf("expected explaining %s not to change the results", tc.name)
+			}
+			e := res.Explain
+			if e == nil || (tc.index != "" && e.Index != tc.index) || (tc.ordered && e.SortedInMemory) {
+				t.Fatalf("unexpected explain result of %s: %+v", tc.name, e)
+			}
+		}
+
+		res, err := plain.FindPage(Where("N").Gt(40).WithExplain())
+		checkErr(t, err)
+		if e := res.Explain; e.Index != "" || e.IndexKeys != 0 || e.InstancesRead != 80 || e.Matched != 39 {
+			t.Fatalf("unexpected explain result of a scan: %+v", e)
+		}
+		res, err = indexed.FindPage(Where("CreatedAt").Ge(10.0).OrderBy("CreatedAt").LimitTo(3).WithExplain())
+		checkErr(t, err)
+		if e := res.Explain; e.Index != "CreatedAt" || e.IndexKeys == 0 || e.InstancesRead != 4 || e.Matched != 4 || e.SortTime != 0 {
+			t.Fatalf("unexpected explain result of a sorted range: %+v", e)
+		}
+		res, err = indexed.FindPage(Where("CreatedAt").In(4.5, -10.0, 100.0).WithExplain())
+		checkErr(t, err)
+		if e := res.Explain; e.Index != "CreatedAt" || e.IndexKeys != 2 || e.InstancesRead != len(res.Instances) {
+			t.Fatalf("unexpected explain result of index lookups: %+v", e)
+		}
+		if res, err = indexed.FindPage(Where("CreatedAt").Eq(4.5)); err != nil || res.Explain != nil {
+			t.Fatalf("expected no explain result, got: %+v", res.Explain)
+		}
+	})
+
+	t.Run("DecodeKeys", func(t *testing.T) {
+		for _, v := range []interface{}{nil, false, true, -10.0, -1.5, 0.0, 2.0, 1e300, "", "a", "a\x00b", "ab"} {
+			got, ok := decodeTupleValue(appendTupleValue(nil, v, true))
+			if !ok || !reflect.DeepEqual(got, v) {
+				t.Fatalf("expected %v to be decoded, got: %v", v, got)
+			}
+		}
+	})
+
+	t.Run("Upgrade", func(t *testing.T) {
+		// indexes of an older format are rebuilt
+		txn, err := db.datastore.NewTransaction(false)
+		checkErr(t, err)
+		checkErr(t, deletePrefix(txn, indexPrefix.Child(indexed.baseKey()).ChildString("CreatedAt")))
+		checkErr(t, txn.Delete(dsIndexFormat))
+		checkErr(t, txn.Commit())
+		q := Where("CreatedAt").Gt(0.0).OrderBy("CreatedAt")
+		if res := results(t, indexed, q); len(res) != 0 {
+			t.Fatalf("expected the index to be deleted, got %d results", len(res))
+		}
+		checkErr(t, db.upgradeIndexes())
+		if got, expected := results(t, indexed, q), results(t, plain, q); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("wrong results after upgrading, expected: %v, got: %v", expected, got)
+		}
+		format, err := db.datastore.Get(dsIndexFormat)
+		checkErr(t, err)
+		if string(format) != fmt.Sprint(indexFormat) {
+			t.Fatalf("expected index format %d, got: %s", indexFormat, format)
+		}
+	})
+}
+
+func TestOrIndexes(t *testing.T) {
+	t.Parallel()
+
+	db, clean := createTestDB(t)
+	defer clean()
+	indexed, err := db.NewCollection(CollectionConfig{
+		Name:    "Indexed",
+		Schema:  util.SchemaFromSchemaString(eventSchema),
+		Indexes: []Index{{Path: "CreatedAt"}, {Path: "Name"}, {Path: "Tags"}},
+	})
+	checkErr(t, err)
+	plain, err := db.NewCollection(CollectionConfig{
+		Name:   "Plain",
+		Schema: util.SchemaFromSchemaString(eventSchema),
+	})
+	checkErr(t, err)
+	for n := 0; n < 80; n++ {
+		event := fmt.Sprintf(`{"_id": "%03d", "N": %d, "CreatedAt": %v, "Name": "name/%02d", "Tags": ["t%d", "t%d"]}`,
+			(n*29)%80, n, float64((n*37)%50)/2-10, (n*13)%30, n%5, n%7)
+		for _, c := range []*Collection{indexed, plain} {
+			_, err := c.Create([]byte(event))
+			checkErr(t, err)
+		}
+	}
+
+	numbers := func(res [][]byte) []int {
+		ns := make([]int, len(res))
+		for i, r := range res {
+			var event struct{ N int }
+			util.InstanceFromJSON(r, &event)
+			ns[i] = event.N
+		}
+		return ns
+	}
+	results := func(t *testing.T, c *Collection, q *Query) []int {
+		res, err := c.Find(q)
+		checkErr(t, err)
+		return numbers(res)
+	}
+
+	tests := []struct {
+		name    string
+		query   *Query
+		indexes []string
+	}{
+		{name: "EqOrEq", query: Where("Name").Eq("name/03").Or(Where("CreatedAt").Eq(4.5)), indexes: []string{"Name", "CreatedAt"}},
+		{name: "RangeOrIn", query: Where("CreatedAt").Gt(10.0).Or(Where("Name").In("name/01", "name/02")), indexes: []string{"CreatedAt", "Name"}},
+		{name: "AndOr", query: Where("CreatedAt").Lt(0.0).And("N").Gt(30).Or(Where("Tags").Eq("t3")), indexes: []string{"CreatedAt", "Tags"}},
+		{name: "Overlapping", query: Where("CreatedAt").Ge(0.0).Or(Where("CreatedAt").Le(5.0)), indexes: []string{"CreatedAt", "CreatedAt"}},
+		{name: "Nested", query: Where("Name").Eq("name/05").Or(Where("Tags").Eq("t1").Or(Where("CreatedAt").Le(-9.0))), indexes: []string{"Name", "Tags", "CreatedAt"}},
+		{name: "SortedPage", query: Where("CreatedAt").Gt(5.0).Or(Where("Tags").Eq("t0")).OrderByDesc("N").LimitTo(5).SkipNum(2), indexes: []string{"CreatedAt", "Tags"}},
+		{name: "ScanBranch", query: Where("Name").Eq("name/03").Or(Where("N").Gt(70))},
+		{name: "AllBranch", query: Where("Name").Eq("name/03").Or(&Query{})},
+		{name: "UseIndex", query: Where("Name").Eq("name/03").Or(Where("Name").Eq("name/04")).UseIndex("Name")},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var paths []string
+			for _, b := range indexed.planUnion(tc.query) {
+				paths = append(paths, b.path())
+			}
+			if !reflect.DeepEqual(paths, tc.indexes) {
+				t.Fatalf("expected the alternatives to be read from %v, got: %v", tc.indexes, paths)
+			}
+			scan := *tc.query
+			scan.Index = ""
+			expected := results(t, plain, &scan)
+			got := results(t, indexed, tc.query)
+			if tc.query.Index != "" {
+				// read in the order of the index
+				sort.Ints(expected)
+				sort.Ints(got)
+			}
+			if !reflect.DeepEqual(got, expected) {
+				t.Fatalf("wrong results, expected: %v, got: %v", expected, got)
+			}
+		})
+	}
+
+	t.Run("Pages", func(t *testing.T) {
+		q := Where("CreatedAt").Ge(5.0).Or(Where("Tags").Eq("t2"))
+		expected := results(t, plain, q)
+		var got [][]byte
+		page := *q
+		page.Limit = 4
+		for {
+			res, next, err := indexed.FindWithCursor(&page)
+			checkErr(t, err)
+			got = append(got, res...)
+			if next == "" {
+				break
+			}
+			page.Cursor = next
+		}
+		if ns := numbers(got); !reflect.DeepEqual(ns, expected) {
+			t.Fatalf("wrong pages, expected: %v, got: %v", expected, ns)
+		}
+	})
+
+	t.Run("Explain", func(t *testing.T) {
+		q := Where("Name").Eq("name/03").Or(Where("Tags").Eq("t4")).WithExplain()
+		res, err := indexed.FindPage(q)
+		checkErr(t, err)
+		e := res.Explain
+		if e.Index != "" || !reflect.DeepEqual(e.Indexes, []string{"Name", "Tags"}) || !e.SortedInMemory {
+			t.Fatalf("unexpected explain result of a union: %+v", e)
+		}
+		// keys found by both alternatives are read once
+		if e.IndexKeys != 2 || e.InstancesRead != len(res.Instances) || e.InstancesRead >= 80 {
+			t.Fatalf("expected only the instances of the union to be read, got: %+v", e)
+		}
+		res, err = plain.FindPage(q)
+		checkErr(t, err)
+		if e := res.Explain; len(e.Indexes) != 0 || e.InstancesRead != 80 {
+			t.Fatalf("unexpected explain result of a scan: %+v", e)
+		}
+	})
+}
+
+const meetingSchema = `{
+	"$schema": "http://json-schema.org/draft-04/schema#",
+	"definitions": {
+		"note": {
+			"properties": {"At": {"format": "date-time"}},
+			"type": "object"
+		}
+	},
+	"properties": {
+		"_id": {"type": "string"},
+		"N": {"type": "integer"},
+		"StartsAt": {"type": "string", "format": "date-time"},
+		"Notes": {"type": "array", "items": {"$ref": "#/definitions/note"}}
+	},
+	"type": "object"
+}`
+
+func TestDateTime(t *testing.T) {
+	t.Parallel()
+
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:    "Meeting",
+		Schema:  util.SchemaFromSchemaString(meetingSchema),
+		Indexes: []Index{{Path: "StartsAt"}},
+	})
+	checkErr(t, err)
+
+	base := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	zones := []*time.Location{time.UTC, time.FixedZone("east", 9*3600), time.FixedZone("west", -7*3600)}
+	var ids []core.InstanceID
+	for n := 0; n < 12; n++ {
+		// meetings are an hour and a half apart, written in alternating time
+		// zones which sort differently than chronologically as written
+		at := base.Add(time.Duration(n) * 90 * time.Minute).In(zones[n%len(zones)])
+		meeting := fmt.Sprintf(`{"N": %d, "StartsAt": "%s", "Notes": [{"At": "%s"}, {"At": null}]}`,
+			n, at.Format(time.RFC3339), at.Add(time.Second/2).Format(time.RFC3339Nano))
+		id, err := c.Create([]byte(meeting))
+		checkErr(t, err)
+		ids = append(ids, id)
+	}
+
+	t.Run("Normalized", func(t *testing.T) {
+		var meeting struct {
+			StartsAt string
+			Notes    []struct{ At *string }
+		}
+		res, err := c.FindByID(ids[1])
+		checkErr(t, err)
+		util.InstanceFromJSON(res, &meeting)
+		if meeting.StartsAt != "2020-06-01T13:30:00.000000000Z" {
+			t.Fatalf("expected a normalized date-time, got: %s", meeting.StartsAt)
+		}
+		if *meeting.Notes[0].At != "2020-06-01T13:30:00.500000000Z" || meeting.Notes[1].At != nil {
+			t.Fatalf("expected normalized nested date-times, got: %v, %v", *meeting.Notes[0].At, meeting.Notes[1].At)
+		}
+
+		// numbers are read as seconds since the epoch, and saves are
+		// normalized too
+		checkErr(t, c.Save([]byte(fmt.Sprintf(`{"_id": "%s", "N": 1, "StartsAt": %d.25}`, ids[1], base.Add(90*time.Minute).Unix()))))
+		res, err = c.FindByID(ids[1])
+		checkErr(t, err)
+		util.InstanceFromJSON(res, &meeting)
+		if meeting.StartsAt != "2020-06-01T13:30:00.250000000Z" {
+			t.Fatalf("expected a normalized date-time, got: %s", meeting.StartsAt)
+		}
+
+		if _, err := c.Create([]byte(`{"N": 99, "StartsAt": "tomorrow"}`)); !errors.Is(err, ErrInvalidSchemaInstance) {
+			t.Fatalf("expected an invalid date-time to fail validation, got: %v", err)
+		}
+	})
+
+	t.Run("Compare", func(t *testing.T) {
+		from, to := base.Add(3*time.Hour).In(zones[1]), base.Add(9*time.Hour).In(zones[2])
+		for _, q := range []*Query{
+			Where("StartsAt").After(from).And("StartsAt").Before(to).OrderBy("StartsAt"),
+			Where("StartsAt").After(from).And("StartsAt").Before(to).OrderByDesc("StartsAt").UseIndex("StartsAt"),
+			Where("N").Ge(0.0).And("StartsAt").After(from).And("StartsAt").Before(to).OrderBy("StartsAt"),
+		} {
+			res, err := c.Find(q)
+			checkErr(t, err)
+			var ns []int
+			for _, r := range res {
+				var meeting struct{ N int }
+				util.InstanceFromJSON(r, &meeting)
+				ns = append(ns, meeting.N)
+			}
+			expected := []int{3, 4, 5}
+			if q.Sort.Desc {
+				expected = []int{5, 4, 3}
+			}
+			if !reflect.DeepEqual(ns, expected) {
+				t.Fatalf("expected meetings %v, got: %v", expected, ns)
+			}
+		}
+		n, err := c.Count(Where("StartsAt").Eq(base.In(zones[2])))
+		checkErr(t, err)
+		if n != 1 {
+			t.Fatalf("expected an equal date-time in another zone to match, got %d", n)
+		}
+	})
+
+	t.Run("Legacy", func(t *testing.T) {
+		// instances written before the schema had date-times are reported
+		// until they're migrated
+		l, err := db.NewCollection(CollectionConfig{
+			Name:   "Legacy",
+			Schema: util.SchemaFromSchemaString(strings.Replace(meetingSchema, `"format": "date-time"`, `"title": "at"`, -1)),
+		})
+		checkErr(t, err)
+		id, err := l.Create([]byte(`{"N": 0, "StartsAt": "2020-06-01T14:00:00+02:00", "Notes": [{"At": "2020-06-01T12:00:00Z"}]}`))
+		checkErr(t, err)
+		l, err = db.UpdateCollection(CollectionConfig{
+			Name:   "Legacy",
+			Schema: util.SchemaFromSchemaString(meetingSchema),
+		})
+		checkErr(t, err)
+		errs, err := l.Validate(context.Background(), 0)
+		checkErr(t, err)
+		var fields []string
+		for _, e := range errs {
+			if e.InstanceID != id {
+				t.Fatalf("expected violations of instance %s, got: %v", id, e)
+			}
+			fields = append(fields, e.Field)
+		}
+		sort.Strings(fields)
+		if expected := []string{"/Notes/0/At", "/StartsAt"}; !reflect.DeepEqual(fields, expected) {
+			t.Fatalf("expected violations at %v, got: %v", expected, errs)
+		}
+		l, err = db.MigrateCollection("Legacy", util.SchemaFromSchemaString(meetingSchema), func(old []byte) ([]byte, error) {
+			return old, nil
+		})
+		checkErr(t, err)
+		errs, err = l.Validate(context.Background(), 0)
+		checkErr(t, err)
+		if len(errs) != 0 {
+			t.Fatalf("expected no violations once migrated, got: %v", errs)
+		}
+		n, err := l.Count(Where("StartsAt").Eq(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)))
+		checkErr(t, err)
+		if n != 1 {
+			t.Fatalf("expected the migrated date-time to match, got %d", n)
+		}
+	})
+}
+
+type assignee struct {
+	ID   string `json:"id"`
+	Role string `json:"role"`
+}
+
+type issue struct {
+	ID        core.InstanceID `json:"_id"`
+	Title     string
+	Tags      []string
+	Assignees []assignee
+}
+
+func TestArrayQuery(t *testing.T) {
+	t.Parallel()
+
+	db, clean := createTestDB(t)
+	defer clean()
+	indexed, err := db.NewCollection(CollectionConfig{
+		Name:    "Indexed",
+		Schema:  util.SchemaFromInstance(&issue{}, false),
+		Indexes: []Index{{Path: "Tags"}},
+	})
+	checkErr(t, err)
+	plain, err := db.NewCollection(CollectionConfig{
+		Name:   "Plain",
+		Schema: util.SchemaFromInstance(&issue{}, false),
+	})
+	checkErr(t, err)
+	issues := []issue{
+		{Title: "a", Tags: []string{"bug", "ui"}, Assignees: []assignee{{ID: "alice", Role: "owner"}}},
+		{Title: "b", Tags: []string{"docs"}, Assignees: []assignee{{ID: "alice", Role: "reviewer"}, {ID: "bob", Role: "owner"}}},
+		{Title: "c", Tags: []string{"bug", "bug"}, Assignees: []assignee{}},
+		{Title: "d", Tags: []string{}, Assignees: []assignee{}},
+	}
+	for _, c := range []*Collection{indexed, plain} {
+		for _, i := range issues {
+			_, err := c.Create(util.JSONFromInstance(i))
+			checkErr(t, err)
+		}
+	}
+	titles := func(t *testing.T, c *Collection, q *Query) []string {
+		res, err := c.Find(q.OrderBy("Title"))
+		checkErr(t, err)
+		titles := make([]string, len(res))
+		for i, r := range res {
+			var is issue
+			util.InstanceFromJSON(r, &is)
+			titles[i] = is.Title
+		}
+		count, err := c.Count(q)
+		checkErr(t, err)
+		if count != len(res) {
+			t.Fatalf("count %d doesn't match the %d results", count, len(res))
+		}
+		return titles
+	}
+
+	tests := []struct {
+		name     string
+		query    func() *Query
+		expected []string
+	}{
+		{"Eq", func() *Query { return Where("Tags").Eq("bug") }, []string{"a", "c"}},
+		{"Ne", func() *Query { return Where("Tags").Ne("bug") }, []string{"a", "b"}},
+		{"In", func() *Query { return Where("Tags").In("ui", "docs") }, []string{"a", "b"}},
+		{"Gt", func() *Query { return Where("Tags").Gt("c") }, []string{"a", "b"}},
+		{"Indexed", func() *Query { return Where("Tags").Ge("a").UseIndex("Tags") }, []string{"a", "b", "c"}},
+		{"Any", func() *Query {
+			return Where("Assignees").Any(Where("id").Eq("alice").And("role").Eq("owner"))
+		}, []string{"a"}},
+		{"AnyDifferentElements", func() *Query {
+			return Where("Assignees").Any(Where("id").Eq("bob").And("role").Eq("reviewer"))
+		}, []string{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, c := range []*Collection{indexed, plain} {
+				if tc.name == "Indexed" && c == plain {
+					continue
+				}
+				if got := titles(t, c, tc.query()); !reflect.DeepEqual(got, tc.expected) {
+					t.Fatalf("%s: expected %v, got %v", c.GetName(), tc.expected, got)
+				}
+			}
+		})
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		b, err := json.Marshal(Where("Assignees").Any(Where("role").Eq("owner")).And("Tags").Eq("docs"))
+		checkErr(t, err)
+		q := &Query{}
+		checkErr(t, json.Unmarshal(b, q))
+		if got := titles(t, plain, q); !reflect.DeepEqual(got, []string{"b"}) {
+			t.Fatalf("expected [b], got %v", got)
+		}
+	})
+
+	t.Run("Fail/UniqueArrayIndex", func(t *testing.T) {
+		_, err := db.NewCollection(CollectionConfig{
+			Name:    "Unique",
+			Schema:  util.SchemaFromInstance(&issue{}, false),
+			Indexes: []Index{{Path: "Tags", Unique: true}},
+		})
+		if !errors.Is(err, ErrNotIndexable) {
+			t.Fatalf("expected error %v, got %v", ErrNotIndexable, err)
+		}
+	})
+
+	t.Run("Fail/AnyWithoutQuery", func(t *testing.T) {
+		if _, err := plain.Find(&Query{Ands: []*Criterion{{FieldPath: "Assignees", Operation: Any}}}); err == nil {
+			t.Fatal("expected Any without an element query to be invalid")
+		}
+	})
+}
+
+func TestFindWithCursor(t *testing.T) {
+	t.Parallel()
+
+	c, data, clean := createCollectionWithData(t)
+	defer clean()
+	byID := make([]int, len(data))
+	for i := range byID {
+		byID[i] = i
+	}
+	sort.Slice(byID, func(i, j int) bool {
+		return data[byID[i]].ID < data[byID[j]].ID
+	})
+	byIDDesc := make([]int, len(byID))
+	for i := range byID {
+		byIDDesc[i] = byID[len(byID)-1-i]
+	}
+
+	pages := func(t *testing.T, q func() *Query) []book {
+		var res []book
+		var cursor string
+		for i := 0; i <= len(data); i++ {
+			found, next, err := c.FindWithCursor(q().After(cursor))
+			checkErr(t, err)
+			for _, f := range found {
+				b := book{}
+				util.InstanceFromJSON(f, &b)
+				res = append(res, b)
+			}
+			if next == "" {
+				return res
+			}
+			cursor = next
+		}
+		t.Fatal("pages don't end")
+		return nil
+	}
+	tests := []struct {
+		name   string
+		query  func() *Query
+		resIdx []int
+	}{
+		{name: "ByKey", query: func() *Query { return (&Query{}).LimitTo(2) }, resIdx: byID},
+		{name: "ByIDDesc", query: func() *Query { return OrderByIDDesc().LimitTo(2) }, resIdx: byIDDesc},
+		{name: "Filtered", query: func() *Query { return Where("Author").Eq("Author1").OrderByDesc("Meta.Rating").LimitTo(1) }, resIdx: []int{2, 1, 0}},
+		{name: "ByFields", query: func() *Query { return OrderBy("Author").ThenBy("Meta.TotalReads", Desc).LimitTo(2) }, resIdx: []int{2, 1, 0, 3, 4}},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			res := pages(t, tc.query)
+			if len(res) != len(tc.resIdx) {
+				t.Fatalf("paged results length doesn't match, expected: %d, got: %d", len(tc.resIdx), len(res))
+			}
+			for i, idx := range tc.resIdx {
+				if !reflect.DeepEqual(data[idx], res[i]) {
+					t.Fatalf("wrong paged result, expected: %v, got: %v", data[idx], res[i])
+				}
+			}
+		})
+	}
+
+	t.Run("UnrelatedWrites", func(t *testing.T) {
+		q := OrderBy("Meta.TotalReads").LimitTo(2)
+		_, cursor, err := c.FindWithCursor(q)
+		checkErr(t, err)
+		// neither instances before the cursor, nor new ones, shift the next page
+		checkErr(t, c.Delete(data[0].ID))
+		_, err = c.Create(util.JSONFromInstance(book{Title: "Title6", Author: "Author4", Meta: bookStats{TotalReads: 5}}))
+		checkErr(t, err)
+		found, _, err := c.FindWithCursor(q.After(cursor))
+		checkErr(t, err)
+		if len(found) != 2 {
+			t.Fatalf("expected 2 results, got: %d", len(found))
+		}
+		for i, idx := range []int{2, 3} {
+			b := book{}
+			util.InstanceFromJSON(found[i], &b)
+			if !reflect.DeepEqual(data[idx], b) {
+				t.Fatalf("wrong paged result, expected: %v, got: %v", data[idx], b)
+			}
+		}
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		_, cursor, err := c.FindWithCursor(OrderBy("Title").LimitTo(1))
+		checkErr(t, err)
+		if _, err := c.Find(OrderByDesc("Title").After(cursor)); !errors.Is(err, ErrCursorMismatch) {
+			t.Fatalf("expected cursor mismatch for another sort order, got: %v", err)
+		}
+		other, err := c.db.NewCollection(CollectionConfig{
+			Name:   "OtherBook",
+			Schema: util.SchemaFromInstance(&book{}, false),
+		})
+		checkErr(t, err)
+		if _, err := other.Find(OrderBy("Title").After(cursor)); !errors.Is(err, ErrCursorMismatch) {
+			t.Fatalf("expected cursor mismatch for another collection, got: %v", err)
+		}
+		if _, err := c.Find(OrderBy("Title").After("not-a-cursor")); !errors.Is(err, ErrInvalidCursor) {
+			t.Fatalf("expected invalid cursor, got: %v", err)
+		}
+	})
+}
+
+func TestFindPage(t *testing.T) {
+	t.Parallel()
+
+	c, _, clean := createCollectionWithData(t)
+	defer clean()
+
+	res, err := c.FindPage(Where("Author").Eq("Author1").OrderBy("Title").LimitTo(1).WithTotal())
+	checkErr(t, err)
+	if len(res.Instances) != 1 || res.Total != 3 || res.Cursor == "" {
+		t.Fatalf("expected a page of 1 of 3 results, got %d of %d", len(res.Instances), res.Total)
+	}
+	next, err := c.FindPage(Where("Author").Eq("Author1").OrderBy("Title").LimitTo(1).SkipNum(1).After(res.Cursor).WithTotal())
+	checkErr(t, err)
+	if len(next.Instances) != 1 || next.Total != 3 {
+		t.Fatalf("expected a page of 1 of 3 results, got %d of %d", len(next.Instances), next.Total)
+	}
+	indexed, err := c.FindPage((&Query{}).UseIndex(idFieldName).LimitTo(2).WithTotal())
+	checkErr(t, err)
+	if len(indexed.Instances) != 2 || indexed.Total != len(sampleData) {
+		t.Fatalf("expected a page of 2 of %d results, got %d of %d", len(sampleData), len(indexed.Instances), indexed.Total)
+	}
+	untotaled, err := c.FindPage(Where("Author").Eq("Author1").LimitTo(1))
+	checkErr(t, err)
+	if untotaled.Total != 0 {
+		t.Fatalf("expected no total without WithTotal, got %d", untotaled.Total)
+	}
+}
+
+func TestFindIter(t *testing.T) {
+	t.Parallel()
+
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:    "Event",
+		Schema:  util.SchemaFromSchemaString(eventSchema),
+		Indexes: []Index{{Path: "CreatedAt"}},
+	})
+	checkErr(t, err)
+	for n := 0; n < 50; n++ {
+		_, err := c.Create([]byte(fmt.Sprintf(`{"_id": "%03d", "N": %d, "CreatedAt": %d, "Name": "name/%02d"}`, (n*7)%50, n, n%10, n%4)))
+		checkErr(t, err)
+	}
+	collect := func(t *testing.T, it Iterator) [][]byte {
+		defer it.Close()
+		var res [][]byte
+		for it.Next() {
+			res = append(res, it.Value())
+		}
+		checkErr(t, it.Err())
+		return res
+	}
+
+	page, err := c.FindPage(Where("N").Ge(0.0).OrderBy("Name").LimitTo(5))
+	checkErr(t, err)
+	for _, tc := range []struct {
+		name  string
+		query *Query
+	}{
+		{name: "All", query: &Query{}},
+		{name: "Skip", query: Where("N").Lt(40.0).SkipNum(5).LimitTo(10)},
+		{name: "Index", query: Where("CreatedAt").Gt(3.0).LimitTo(12)},
+		{name: "IndexOrder", query: Where("CreatedAt").Ge(5.0).OrderByDesc("CreatedAt").LimitTo(7)},
+		{name: "InMemory", query: Where("N").Ge(0.0).OrderBy("Name").ThenBy("N", Desc).SkipNum(3).LimitTo(20)},
+		{name: "After", query: Where("N").Ge(0.0).OrderBy("Name").LimitTo(5).After(page.Cursor)},
+		{name: "AfterID", query: (&Query{}).LimitTo(5).After(mustFindCursor(t, c, (&Query{}).LimitTo(5)))},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			expected, err := c.FindPage(tc.query)
+			checkErr(t, err)
+			it, err := c.FindIter(context.Background(), tc.query)
+			checkErr(t, err)
+			if got := collect(t, it); !reflect.DeepEqual(got, expected.Instances) {
+				t.Fatalf("expected the %d results of FindPage, got %d", len(expected.Instances), len(got))
+			}
+		})
+	}
+
+	t.Run("Snapshot", func(t *testing.T) {
+		it, err := c.FindIter(context.Background(), &Query{})
+		checkErr(t, err)
+		defer it.Close()
+		if !it.Next() {
+			t.Fatal("expected a first result")
+		}
+		// writes after the iterator is created aren't read by it
+		_, err = c.Create([]byte(`{"_id": "100", "N": 100, "CreatedAt": 0, "Name": "new"}`))
+		checkErr(t, err)
+		checkErr(t, c.Delete("049"))
+		n := 1
+		for it.Next() {
+			n++
+		}
+		checkErr(t, it.Err())
+		if n != 50 {
+			t.Fatalf("expected the 50 results of the snapshot, got %d", n)
+		}
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		it, err := c.FindIter(ctx, &Query{})
+		checkErr(t, err)
+		defer it.Close()
+		if !it.Next() {
+			t.Fatal("expected a first result")
+		}
+		cancel()
+		if it.Next() || !errors.Is(it.Err(), context.Canceled) {
+			t.Fatalf("expected the iteration to stop once canceled, got: %v", it.Err())
+		}
+		it.Close()
+		if it.Next() || it.Value() != nil {
+			t.Fatal("expected no results once closed")
+		}
+	})
+}
+
+func mustFindCursor(t *testing.T, c *Collection, q *Query) string {
+	res, err := c.FindPage(q)
+	checkErr(t, err)
+	return res.Cursor
+}
+
 func createCollectionWithData(t *testing.T) (*Collection, []book, func()) {
 	db, clean := createTestDB(t)
 	c, err := db.NewCollection(CollectionConfig{
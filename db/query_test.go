@@ -243,6 +243,103 @@ var (
 			query:   Where("Meta.Rating").Gt(&ratingMid).OrderByDesc("Meta.TotalReads"),
 			ordered: true,
 		},
+		// In and NotIn
+		{
+			name:   "InByAuthor",
+			resIdx: []int{0, 1, 3},
+			query:  Where("Author").In("Author1", "Author3"),
+		},
+		{
+			name:   "NotInByAuthor",
+			resIdx: []int{2},
+			query:  Where("Author").NotIn("Author1", "Author3"),
+		},
+		{
+			name:   "InByBanned",
+			resIdx: []int{0, 3},
+			query:  Where("Banned").In(true),
+		},
+		{
+			name:   "InByRating",
+			resIdx: []int{0, 2},
+			query:  Where("Meta.Rating").In(3.2, 4.6),
+		},
+		{
+			name:   "InMixedTypes",
+			resIdx: []int{2},
+			query:  Where("Author").In(1, "Author2", true),
+		},
+		{
+			name:   "InNone",
+			resIdx: []int{},
+			query:  Where("Author").In(),
+		},
+		{
+			name:   "NotInNone",
+			resIdx: []int{0, 1, 2, 3},
+			query:  Where("Author").NotIn(),
+		},
+		{
+			name:   "InAndEq",
+			resIdx: []int{1},
+			query:  Where("Author").In("Author1", "Author2").And("Banned").Eq(false).And("Meta.TotalReads").Gt(120.0),
+		},
+		// String matching
+		{
+			name:   "ContainsAuthor",
+			resIdx: []int{0, 1, 2, 3},
+			query:  Where("Author").Contains("thor"),
+		},
+		{
+			name:   "ContainsCaseSensitive",
+			resIdx: []int{},
+			query:  Where("Author").Contains("AUTHOR"),
+		},
+		{
+			name:   "ContainsIgnoreCase",
+			resIdx: []int{0, 1},
+			query:  Where("Author").IgnoreCase().Contains("AUTHOR1"),
+		},
+		{
+			name:   "HasPrefixTitle",
+			resIdx: []int{0, 1, 2, 3},
+			query:  Where("Title").HasPrefix("Title"),
+		},
+		{
+			name:   "HasPrefixNone",
+			resIdx: []int{},
+			query:  Where("Title").HasPrefix("itle"),
+		},
+		{
+			name:   "HasPrefixIgnoreCase",
+			resIdx: []int{2},
+			query:  Where("Title").IgnoreCase().HasPrefix("title3"),
+		},
+		{
+			name:   "MatchesTitle",
+			resIdx: []int{1, 2},
+			query:  Where("Title").Matches("^Title[23]$"),
+		},
+		{
+			name:   "MatchesIgnoreCase",
+			resIdx: []int{3},
+			query:  Where("Author").IgnoreCase().Matches("author3$"),
+		},
+		{
+			name:   "MatchesAndEq",
+			resIdx: []int{0},
+			query:  Where("Author").Matches("1$").And("Banned").Eq(true),
+		},
+		{
+			name:   "ContainsNumberField",
+			resIdx: []int{},
+			query:  Where("Meta.TotalReads").Contains("100"),
+		},
+		{
+			name:   "MatchesUseIndex",
+			resIdx: []int{0, 3},
+			query:  Where("Title").Matches("[14]$").UseIndex("Title"),
+		},
 		// Indexing
 		{
 			name:   "EqTitle1OrTitle3UseIndex",
@@ -254,6 +351,21 @@ var (
 			resIdx: []int{0, 1, 2, 3},
 			query:  Where("Meta.TotalReads").Ge(&totreadMin).UseIndex("Meta.TotalReads"),
 		},
+		{
+			name:   "InByTotalReadsIndexed",
+			resIdx: []int{0, 3},
+			query:  Where("Meta.TotalReads").In(100, 1000, 100.0, 5),
+		},
+		{
+			name:   "InByTitleUseIndex",
+			resIdx: []int{1, 2},
+			query:  Where("Title").In("Title2", "Title3", "Title2", "Title5000").UseIndex("Title"),
+		},
+		{
+			name:   "NotInByTitleUseIndex",
+			resIdx: []int{0, 3},
+			query:  Where("Title").NotIn("Title2", "Title3").UseIndex("Title"),
+		},
 		{
 			name:   "InvalidIndex",
 			resIdx: []int{},
@@ -305,6 +417,22 @@ func TestQuery(t *testing.T) {
 	}
 }
 
+func TestQueryCount(t *testing.T) {
+	c, _, clean := createCollectionWithJSONData(t)
+	defer clean()
+
+	for _, q := range jsonQueries {
+		q := q
+		t.Run(q.name, func(t *testing.T) {
+			count, err := c.Count(q.query)
+			checkErr(t, err)
+			if count != len(q.resIdx) {
+				t.Fatalf("wrong count, expected: %d, got: %d", len(q.resIdx), count)
+			}
+		})
+	}
+}
+
 func createCollectionWithJSONData(t *testing.T) (*Collection, []Book, func()) {
 	s, clean := createTestDB(t)
 	c, err := s.NewCollection(CollectionConfig{
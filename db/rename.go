@@ -0,0 +1,303 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	core "github.com/textileio/go-threads/core/db"
+)
+
+// ErrRenameNotSupported indicates the event codec of the db doesn't support
+// renaming collections.
+var ErrRenameNotSupported = errors.New("event codec doesn't support renaming collections")
+
+// RenameCollection renames the collection named name to newName, keeping its
+// instances, indexes and config. The rename is an event of the thread, which
+// peers apply in turn, and the events of peers which didn't apply it yet are
+// applied to the collection under its new name. Renames of a collection by
+// different peers at the same time aren't reconciled.
+//
+// Listeners of the collection are sent an ActionRename, after which they're
+// sent no more actions of it under its old name. Collections got by the old
+// name don't read its instances anymore, so it must be got by the new one.
+// Renaming requires an event codec supporting it, like the default one.
+func (d *DB) RenameCollection(name, newName string, opts ...Option) error {
+	end, err := d.inflight.begin()
+	if err != nil {
+		return err
+	}
+	defer end()
+	log.Debugf("renaming collection %s to %s in %s", name, newName, d.name)
+	args := &Options{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if err := d.connector.Validate(args.Token, false); err != nil {
+		return err
+	}
+	if !nameRx.MatchString(newName) {
+		return ErrInvalidName
+	}
+	d.txnlock.Lock()
+	defer d.txnlock.Unlock()
+	d.lock.RLock()
+	_, ok := d.collections[name]
+	_, exists := d.collections[newName]
+	d.lock.RUnlock()
+	if !ok {
+		return ErrCollectionNotFound
+	}
+	if exists {
+		return ErrCollectionAlreadyRegistered
+	}
+	if err := d.resumePurge(newName); err != nil {
+		return err
+	}
+
+	events, node, err := d.eventcodec.Create([]core.Action{{
+		Type:           core.Rename,
+		CollectionName: name,
+		Current:        []byte(newName),
+	}})
+	if err != nil {
+		return err
+	}
+	if len(events) != 1 {
+		return ErrRenameNotSupported
+	}
+	if re, ok := events[0].(core.RenameEvent); !ok {
+		return ErrRenameNotSupported
+	} else if renamed, ok := re.RenamedCollection(); !ok || renamed != newName {
+		return ErrRenameNotSupported
+	}
+	endCommit, err := d.inflight.beginCommit()
+	if err != nil {
+		return err
+	}
+	defer endCommit()
+	ctx, cancel := context.WithTimeout(context.Background(), createNetRecordTimeout)
+	defer cancel()
+	if _, err = d.connector.CreateNetRecord(ctx, node, args.Token); err != nil {
+		return err
+	}
+	if err = d.dispatcher.Dispatch(events); err != nil {
+		return err
+	}
+	return d.notifyTxnEvents(node, args.Token)
+}
+
+// reduceRename applies the rename of the collection named name to newName,
+// logging it and notifying listeners. Renames a peer can't apply, of
+// collections it doesn't have or to the names of ones it has, are skipped.
+// The seq lock must be held.
+func (d *DB) reduceRename(name, newName string) error {
+	a, err := d.renameCollection(name, newName)
+	if err != nil || a == nil {
+		return err
+	}
+	d.notifyStateChanged([]Action{*a})
+	return nil
+}
+
+// renameCollection moves the keys of the collection named name to newName
+// with a transaction, and returns the action logged with it, if the rename
+// applies.
+func (d *DB) renameCollection(name, newName string) (*Action, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	c, ok := d.collections[name]
+	if !ok {
+		log.Warnf("skipping rename of missing collection %s to %s in %s", name, newName, d.name)
+		return nil, nil
+	}
+	if _, ok := d.collections[newName]; ok {
+		log.Warnf("skipping rename of collection %s to existing %s in %s", name, newName, d.name)
+		return nil, nil
+	}
+
+	txn, err := d.datastore.NewTransaction(false)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard()
+	for _, prefix := range dsCollectionConfigs {
+		if err := moveKey(txn, prefix.ChildString(name), prefix.ChildString(newName)); err != nil {
+			return nil, err
+		}
+	}
+	base, newBase := baseKey.ChildString(name), baseKey.ChildString(newName)
+	if _, err := movePrefix(txn, base, newBase, nil); err != nil {
+		return nil, err
+	}
+	// index entries hold the keys of the instances, which are moved too
+	relist := func(v []byte) ([]byte, error) {
+		keys := make(keyList, 0)
+		if err := DefaultDecode(v, &keys); err != nil {
+			return nil, err
+		}
+		for i, k := range keys {
+			keys[i] = newBase.ChildString(ds.RawKey(string(k)).Name()).Bytes()
+		}
+		return DefaultEncode(keys)
+	}
+	// the keys of the index entries, and their values, change sizes
+	var indexBytes int64
+	for _, prefix := range []ds.Key{indexPrefix, textIndexPrefix} {
+		size, err := movePrefix(txn, prefix.Child(base), prefix.Child(newBase), relist)
+		if err != nil {
+			return nil, err
+		}
+		indexBytes += size
+	}
+	if stats, err := getStats(txn, dsStats.ChildString(newName)); err == nil {
+		stats.IndexBytes += indexBytes
+		if err := putStats(txn, dsStats.ChildString(newName), stats); err != nil {
+			return nil, err
+		}
+	} else if !errors.Is(err, ds.ErrNotFound) {
+		return nil, err
+	}
+
+	// events of the collection under older names are applied to it under
+	// the new one, and events of its new name no longer are to another
+	renamed := map[string]string{name: newName}
+	for from, to := range d.renamed {
+		if to == name {
+			renamed[from] = newName
+		}
+	}
+	for from, to := range renamed {
+		if err := txn.Put(dsRenamed.ChildString(from), []byte(to)); err != nil {
+			return nil, err
+		}
+	}
+	if err := txn.Delete(dsRenamed.ChildString(newName)); err != nil {
+		return nil, err
+	}
+
+	a := &Action{Collection: name, Type: ActionRename, NewName: newName}
+	lastSeq := d.seq
+	if err := d.logAction(txn, a); err != nil {
+		d.seq = lastSeq
+		return nil, err
+	}
+	if err := txn.Commit(); err != nil {
+		d.seq = lastSeq
+		return nil, err
+	}
+
+	schema, err := d.datastore.Get(dsSchemas.ChildString(newName))
+	if err != nil {
+		return nil, err
+	}
+	rc, err := d.loadCollection(newName, schema)
+	if err != nil {
+		return nil, err
+	}
+	rc.hooks = c.getHooks()
+	delete(d.collections, name)
+	d.collections[newName] = rc
+	for from, to := range renamed {
+		d.renamed[from] = to
+	}
+	delete(d.renamed, newName)
+	return a, nil
+}
+
+// renamedEvent returns the event applied to the collection its collection
+// was renamed to, if it was.
+func (d *DB) renamedEvent(e core.Event) core.Event {
+	re, ok := e.(core.RenameEvent)
+	if !ok {
+		return e
+	}
+	d.lock.RLock()
+	name, ok := d.renamed[e.Collection()]
+	d.lock.RUnlock()
+	if !ok {
+		return e
+	}
+	return re.WithCollection(name)
+}
+
+// loadRenames loads the names of the renamed collections.
+func (d *DB) loadRenames() error {
+	res, err := d.datastore.Query(query.Query{Prefix: dsRenamed.String()})
+	if err != nil {
+		return err
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return err
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	for _, e := range entries {
+		d.renamed[ds.RawKey(e.Key).Name()] = string(e.Value)
+	}
+	return nil
+}
+
+// forgetRename forgets that the collection of name was renamed, once a
+// collection of the name is created, to which its events then apply. The
+// db lock must be held.
+func (d *DB) forgetRename(name string) error {
+	if _, ok := d.renamed[name]; !ok {
+		return nil
+	}
+	if err := d.datastore.Delete(dsRenamed.ChildString(name)); err != nil {
+		return err
+	}
+	delete(d.renamed, name)
+	return nil
+}
+
+// moveKey moves the value of key to newKey with txn, if it exists.
+func moveKey(txn ds.Txn, key, newKey ds.Key) error {
+	v, err := txn.Get(key)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := txn.Put(newKey, v); err != nil {
+		return err
+	}
+	return txn.Delete(key)
+}
+
+// movePrefix moves the keys under prefix to newPrefix with txn, with their
+// values mapped by f if it's set, returning the change of the size of the
+// keys and values.
+func movePrefix(txn ds.Txn, prefix, newPrefix ds.Key, f func([]byte) ([]byte, error)) (int64, error) {
+	res, err := txn.Query(query.Query{Prefix: prefix.String()})
+	if err != nil {
+		return 0, err
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	for _, e := range entries {
+		key := ds.RawKey(e.Key)
+		newKey := newPrefix.Child(ds.RawKey(key.String()[len(prefix.String()):]))
+		v := e.Value
+		if f != nil {
+			if v, err = f(v); err != nil {
+				return 0, err
+			}
+		}
+		if err := txn.Put(newKey, v); err != nil {
+			return 0, err
+		}
+		if err := txn.Delete(key); err != nil {
+			return 0, err
+		}
+		size += int64(len(newKey.String()) + len(v) - len(e.Key) - len(e.Value))
+	}
+	return size, nil
+}
@@ -0,0 +1,295 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/jsonschema"
+	ds "github.com/ipfs/go-datastore"
+)
+
+// Schemas can reference the schema of another collection of the db with
+// "$ref": "collection:<name>", and a definitions document registered with
+// RegisterSchemaDefinitions with "$ref": "definitions:<name>", both of which
+// may be followed by a JSON pointer into the schema, like
+// "definitions:common#/definitions/Address". References are resolved once,
+// when the collection is created or updated, by copying the schemas they
+// point to into the definitions of the schema, so later changes to the
+// schemas referenced don't change it.
+
+const (
+	collectionRefPrefix  = "collection:"
+	definitionsRefPrefix = "definitions:"
+)
+
+var dsDefinitions = dsPrefix.ChildString("definitions")
+
+// ErrUnresolvableSchemaRef indicates a reference of a schema doesn't point
+// to a schema.
+var ErrUnresolvableSchemaRef = errors.New("unresolvable schema reference")
+
+// SchemaRefError indicates a reference of a schema doesn't point to a
+// schema. It matches ErrUnresolvableSchemaRef.
+type SchemaRefError struct {
+	// Pointer is the JSON pointer to the reference in the schema.
+	Pointer string
+	// Ref is the reference.
+	Ref string
+}
+
+func (e *SchemaRefError) Error() string {
+	return fmt.Sprintf("%s: %s at %s", ErrUnresolvableSchemaRef, e.Ref, e.Pointer)
+}
+
+func (e *SchemaRefError) Unwrap() error {
+	return ErrUnresolvableSchemaRef
+}
+
+// RegisterSchemaDefinitions registers the definitions document named name,
+// which the schemas of collections can reference with
+// "definitions:<name>". Registering it again replaces it, without changing
+// the collections which reference it until they're updated. The references
+// of the document are resolved when it's registered. Documents aren't part
+// of dumps, which hold the resolved schemas of collections.
+func (d *DB) RegisterSchemaDefinitions(name string, schema *jsonschema.Schema, opts ...Option) error {
+	end, err := d.inflight.begin()
+	if err != nil {
+		return err
+	}
+	defer end()
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	log.Debugf("registering schema definitions %s in %s", name, d.name)
+	args := &Options{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if err := d.connector.Validate(args.Token, false); err != nil {
+		return err
+	}
+	if !nameRx.MatchString(name) {
+		return ErrInvalidName
+	}
+	schema, err = d.resolveSchemaRefs(schema)
+	if err != nil {
+		return err
+	}
+	sb, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+	return d.datastore.Put(dsDefinitions.ChildString(name), sb)
+}
+
+// resolveSchemaRefs returns the schema with the schemas its references to
+// collections and definitions documents point to copied into its
+// definitions, failing with a *SchemaRefError if any of its references
+// doesn't point to a schema. The db lock must be held.
+func (d *DB) resolveSchemaRefs(schema *jsonschema.Schema) (*jsonschema.Schema, error) {
+	if schema == nil {
+		return nil, nil
+	}
+	sb, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	var root map[string]interface{}
+	if err := decodeJSON(sb, &root); err != nil {
+		return nil, err
+	}
+
+	// the schemas referenced are copied in once they're all found, so the
+	// references among the copies aren't resolved again
+	imports := make(map[string]interface{})
+	var external bool
+	if err := walkSchemaRefs(root, "", func(node map[string]interface{}, pointer, ref string) error {
+		var prefix string
+		if strings.HasPrefix(ref, collectionRefPrefix) {
+			prefix = collectionRefPrefix
+		} else if strings.HasPrefix(ref, definitionsRefPrefix) {
+			prefix = definitionsRefPrefix
+		} else {
+			return nil
+		}
+		external = true
+		name, target := ref[len(prefix):], ""
+		if i := strings.Index(name, "#"); i >= 0 {
+			name, target = name[:i], name[i+1:]
+		}
+		key := strings.TrimSuffix(prefix, ":") + "." + name
+		source, err := d.referencedSchema(prefix, name)
+		if err != nil {
+			return err
+		} else if source == nil || !resolvesPointer(source, target) {
+			return &SchemaRefError{Pointer: pointer, Ref: ref}
+		}
+		if _, ok := imports[key]; !ok {
+			importSchema(imports, key, source)
+		}
+		node["$ref"] = "#" + importedPointer(key, target)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if len(imports) > 0 {
+		defs, _ := root["definitions"].(map[string]interface{})
+		if defs == nil {
+			defs = make(map[string]interface{})
+			root["definitions"] = defs
+		}
+		for k, v := range imports {
+			defs[k] = v
+		}
+	}
+
+	// local references must point into the schema too
+	if err := walkSchemaRefs(root, "", func(_ map[string]interface{}, pointer, ref string) error {
+		if strings.HasPrefix(ref, "#") && !resolvesPointer(root, ref[1:]) {
+			return &SchemaRefError{Pointer: pointer, Ref: ref}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if !external {
+		return schema, nil
+	}
+	rb, err := json.Marshal(root)
+	if err != nil {
+		return nil, err
+	}
+	resolved := &jsonschema.Schema{}
+	if err := json.Unmarshal(rb, resolved); err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
+
+// referencedSchema returns the decoded schema of the collection or the
+// definitions document named name, or nil if there's none. The db lock must
+// be held.
+func (d *DB) referencedSchema(prefix, name string) (map[string]interface{}, error) {
+	var sb []byte
+	if prefix == collectionRefPrefix {
+		c, ok := d.collections[name]
+		if !ok {
+			return nil, nil
+		}
+		var err error
+		if sb, err = json.Marshal(c.schema); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		sb, err = d.datastore.Get(dsDefinitions.ChildString(name))
+		if errors.Is(err, ds.ErrNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	var source map[string]interface{}
+	if err := decodeJSON(sb, &source); err != nil {
+		return nil, err
+	}
+	return source, nil
+}
+
+// importSchema adds the resolved schema source to the definitions imports,
+// at key and at the keys of its definitions prefixed with it, with its
+// references pointing to the copies.
+func importSchema(imports map[string]interface{}, key string, source map[string]interface{}) {
+	rewrite := func(node map[string]interface{}, _, ref string) error {
+		if strings.HasPrefix(ref, "#") {
+			node["$ref"] = "#" + importedPointer(key, ref[1:])
+		}
+		return nil
+	}
+	defs, _ := source["definitions"].(map[string]interface{})
+	for k, def := range defs {
+		def = copyJSONValue(def)
+		_ = walkSchemaRefs(def, "", rewrite)
+		imports[key+"."+k] = def
+	}
+	root := copyJSONValue(source).(map[string]interface{})
+	delete(root, "definitions")
+	delete(root, "$schema")
+	_ = walkSchemaRefs(root, "", rewrite)
+	imports[key] = root
+}
+
+// importedPointer returns the pointer to the copy imported at key of the
+// schema the pointer points into.
+func importedPointer(key, pointer string) string {
+	if strings.HasPrefix(pointer, "/definitions/") {
+		return "/definitions/" + key + "." + strings.TrimPrefix(pointer, "/definitions/")
+	}
+	return "/definitions/" + key + pointer
+}
+
+// walkSchemaRefs calls f with the nodes of the decoded schema value which
+// have a reference, along with the pointer to them and the reference, in
+// the order of their pointers.
+func walkSchemaRefs(v interface{}, pointer string, f func(node map[string]interface{}, pointer, ref string) error) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok {
+			if err := f(v, pointer, ref); err != nil {
+				return err
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			token := strings.Replace(strings.Replace(k, "~", "~0", -1), "/", "~1", -1)
+			if err := walkSchemaRefs(v[k], pointer+"/"+token, f); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, e := range v {
+			if err := walkSchemaRefs(e, pointer+"/"+strconv.Itoa(i), f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolvesPointer tells whether the JSON pointer points to a value of the
+// decoded document.
+func resolvesPointer(doc interface{}, pointer string) bool {
+	if pointer == "" {
+		return true
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return false
+	}
+	node := doc
+	for _, token := range strings.Split(pointer, "/")[1:] {
+		token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+		switch n := node.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if node, ok = n[token]; !ok {
+				return false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(n) {
+				return false
+			}
+			node = n[i]
+		default:
+			return false
+		}
+	}
+	return true
+}
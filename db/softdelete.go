@@ -0,0 +1,135 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// deletedFieldName is the field of the tombstone of an instance deleted from
+// a soft-delete collection, which holds the time it was deleted at.
+const deletedFieldName = "_deleted"
+
+// deletedAt returns the time an instance was deleted at, or false if it isn't
+// deleted.
+func deletedAt(instance []byte) (time.Time, bool) {
+	res := gjson.GetBytes(instance, deletedFieldName)
+	if !res.Exists() {
+		return time.Time{}, false
+	}
+	return time.Unix(0, res.Int()), true
+}
+
+// tombstoneAction returns the action deleting an instance to a tombstone, or
+// false if it doesn't exist or is already deleted.
+func (t *Txn) tombstoneAction(id core.InstanceID) (core.Action, bool, error) {
+	previous, err := t.collection.db.datastore.Get(t.collection.baseKey().ChildString(id.String()))
+	if errors.Is(err, ds.ErrNotFound) {
+		return core.Action{}, false, nil
+	}
+	if err != nil {
+		return core.Action{}, false, err
+	}
+	if _, ok := deletedAt(previous); ok {
+		return core.Action{}, false, nil
+	}
+	current, err := sjson.SetBytes(previous, deletedFieldName, time.Now().UnixNano())
+	if err != nil {
+		return core.Action{}, false, err
+	}
+	_, current = setModifiedTag(previous, current)
+	return core.Action{
+		Type:           core.Save,
+		InstanceID:     id,
+		CollectionName: t.collection.name,
+		Previous:       previous,
+		Current:        current,
+	}, true, nil
+}
+
+// Restore restores deleted instances of a soft-delete collection, which
+// weren't purged, when the current transaction commits. Instances which
+// aren't deleted are left as they are.
+// If an instance is purged by a peer while restored by another, it remains
+// purged once they sync.
+func (t *Txn) Restore(ids ...core.InstanceID) error {
+	if err := t.writable(); err != nil {
+		return err
+	}
+	var actions []core.Action
+	for _, id := range ids {
+		previous, err := t.collection.db.datastore.Get(t.collection.baseKey().ChildString(id.String()))
+		if errors.Is(err, ds.ErrNotFound) {
+			return ErrInstanceNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if _, ok := deletedAt(previous); !ok {
+			continue
+		}
+		current, err := sjson.DeleteBytes(previous, deletedFieldName)
+		if err != nil {
+			return err
+		}
+		_, current = setModifiedTag(previous, current)
+		actions = append(actions, core.Action{
+			Type:           core.Save,
+			InstanceID:     id,
+			CollectionName: t.collection.name,
+			Previous:       previous,
+			Current:        current,
+		})
+	}
+	t.actions = append(t.actions, actions...)
+	return nil
+}
+
+// Purge deletes the instances deleted from a soft-delete collection more than
+// olderThan ago, when the current transaction commits.
+func (t *Txn) Purge(olderThan time.Duration) error {
+	if err := t.writable(); err != nil {
+		return err
+	}
+	res, err := t.collection.db.datastore.Query(query.Query{Prefix: t.collection.baseKey().String()})
+	if err != nil {
+		return err
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return err
+	}
+	before := time.Now().Add(-olderThan)
+	for _, e := range entries {
+		at, ok := deletedAt(e.Value)
+		if !ok || !at.Before(before) {
+			continue
+		}
+		t.actions = append(t.actions, core.Action{
+			Type:           core.Delete,
+			InstanceID:     core.InstanceID(ds.RawKey(e.Key).Name()),
+			CollectionName: t.collection.name,
+		})
+	}
+	return nil
+}
+
+// Restore restores a deleted instance of a soft-delete collection.
+func (c *Collection) Restore(id core.InstanceID, opts ...TxnOption) error {
+	return c.WriteTxn(func(txn *Txn) error {
+		return txn.Restore(id)
+	}, opts...)
+}
+
+// Purge deletes the instances deleted from a soft-delete collection more
+// than olderThan ago.
+func (c *Collection) Purge(olderThan time.Duration, opts ...TxnOption) error {
+	return c.WriteTxn(func(txn *Txn) error {
+		return txn.Purge(olderThan)
+	}, opts...)
+}
@@ -0,0 +1,222 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+)
+
+// Direction is the order in which the values of a sort key are returned.
+type Direction int
+
+const (
+	// Asc returns the lowest values first.
+	Asc Direction = iota
+	// Desc returns the highest values first.
+	Desc
+)
+
+// validate checks the field path of the sort key has no empty fields.
+func (s Sort) validate() error {
+	for _, f := range strings.Split(s.FieldPath, ".") {
+		if f == "" {
+			return fmt.Errorf("sort field path %q has an empty field", s.FieldPath)
+		}
+	}
+	return nil
+}
+
+// checkSortPaths checks the sort keys of the query don't go through the
+// items of an array of the schema, which fail with ErrInvalidSortingField.
+// Paths the schema doesn't type are sorted by the values instances have, so
+// those whose values along the path aren't objects sort last.
+func (c *Collection) checkSortPaths(q *Query) error {
+	for _, key := range q.sortKeys() {
+		if key.FieldPath == idFieldName || key.FieldPath == scoreFieldName {
+			continue
+		}
+		_, err := getSchemaTypeAtPath(c.schema, key.FieldPath)
+		var arrayErr *schemaArrayPathError
+		if errors.As(err, &arrayErr) {
+			return fmt.Errorf("%w: %s goes through array %s", ErrInvalidSortingField, key.FieldPath, arrayErr.array)
+		}
+	}
+	return nil
+}
+
+// sortKeys returns the sort keys of the query, in order of precedence.
+// Search results which aren't sorted are ranked by descending score.
+func (q *Query) sortKeys() []Sort {
+	var keys []Sort
+	if q.Sort.FieldPath != "" {
+		keys = append(keys, q.Sort)
+	}
+	keys = append(keys, q.ThenSort...)
+	if len(keys) == 0 && q.searching() {
+		keys = []Sort{{FieldPath: scoreFieldName, Desc: true}}
+	}
+	return keys
+}
+
+// sortsInMemory tells whether the results have to be sorted after being
+// fetched, which is the case unless they are only sorted by ID, in which
+// case the datastore returns them in order.
+func (q *Query) sortsInMemory() bool {
+	keys := q.sortKeys()
+	return len(keys) > 0 && keys[0].FieldPath != idFieldName
+}
+
+// sortedValue is a result along with its values of the sort keys.
+type sortedValue struct {
+	result  MarshaledResult
+	id      string
+	fields  []interface{}
+	present []bool
+}
+
+// sortResults sorts the results by keys in order of precedence. Results
+// missing a field, or with values along its path which aren't objects, sort
+// last whatever the direction, and ties on all keys are
+// broken by ascending instance ID. Values of different types in the same
+// field are ordered null, booleans, numbers, strings, arrays then objects.
+// ErrInvalidSortingField is returned if no result has a field of the keys.
+// The score key sorts by the value of score for each result.
+func sortResults(values []MarshaledResult, keys []Sort, score func(map[string]interface{}) float64) ([]sortedValue, error) {
+	sorted := make([]sortedValue, len(values))
+	found := make([]bool, len(keys))
+	for i, v := range values {
+		if v.MarshaledValue == nil {
+			// results from an index aren't unmarshaled
+			v.MarshaledValue = make(map[string]interface{})
+			if err := json.Unmarshal(v.Value, &v.MarshaledValue); err != nil {
+				return nil, fmt.Errorf("error when unmarshaling query result: %v", err)
+			}
+		}
+		s := sortedValue{
+			result:  v,
+			id:      ds.RawKey(v.Key).Name(),
+			fields:  make([]interface{}, len(keys)),
+			present: make([]bool, len(keys)),
+		}
+		for k, key := range keys {
+			if key.FieldPath == idFieldName {
+				s.fields[k], s.present[k] = s.id, true
+				found[k] = true
+				continue
+			}
+			if key.FieldPath == scoreFieldName {
+				s.fields[k], s.present[k] = score(v.MarshaledValue), true
+				found[k] = true
+				continue
+			}
+			field, err := traverseFieldPathMap(v.MarshaledValue, key.FieldPath)
+			if err != nil {
+				continue
+			}
+			if field.IsValid() {
+				s.fields[k] = field.Interface()
+			}
+			s.present[k] = true
+			found[k] = true
+		}
+		sorted[i] = s
+	}
+	if len(values) > 0 {
+		for k := range keys {
+			if !found[k] {
+				return nil, ErrInvalidSortingField
+			}
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return compareSorted(&sorted[i], &sorted[j], keys) < 0
+	})
+	return sorted, nil
+}
+
+// compareSorted compares the positions of two results sorted by keys.
+func compareSorted(a, b *sortedValue, keys []Sort) int {
+	for k, key := range keys {
+		if a.present[k] != b.present[k] {
+			if a.present[k] {
+				return -1
+			}
+			return 1
+		}
+		if !a.present[k] {
+			continue
+		}
+		res := compareSortValues(a.fields[k], b.fields[k])
+		if key.Desc {
+			res *= -1
+		}
+		if res != 0 {
+			return res
+		}
+	}
+	if a.id == b.id {
+		return 0
+	}
+	if a.id < b.id {
+		return -1
+	}
+	return 1
+}
+
+// sortTypeRank returns the rank of the type of a JSON value when sorting
+// values of different types.
+func sortTypeRank(v interface{}) int {
+	switch v.(type) {
+	case nil:
+		return 0
+	case bool:
+		return 1
+	case float64:
+		return 2
+	case string:
+		return 3
+	case []interface{}:
+		return 4
+	default:
+		return 5
+	}
+}
+
+// compareSortValues compares two JSON values, which may be of different
+// types.
+func compareSortValues(a, b interface{}) int {
+	ra, rb := sortTypeRank(a), sortTypeRank(b)
+	if ra != rb {
+		if ra < rb {
+			return -1
+		}
+		return 1
+	}
+	switch ta := a.(type) {
+	case nil:
+		return 0
+	case bool:
+		tb := b.(bool)
+		if ta == tb {
+			return 0
+		}
+		if !ta {
+			return -1
+		}
+		return 1
+	case float64, string:
+		res, _ := compare(a, b)
+		return res
+	default:
+		// arrays and objects have no natural order, compare their JSON
+		ja, _ := json.Marshal(a)
+		jb, _ := json.Marshal(b)
+		res, _ := compare(string(ja), string(jb))
+		return res
+	}
+}
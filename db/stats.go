@@ -0,0 +1,442 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+)
+
+// Stats of the collections, and of the logs, are kept with the data they
+// count: transactions writing instances, index entries, dispatched events or
+// logged actions update them as they're committed, so they're read without
+// scanning the data. Sizes are approximate, as they count the bytes of keys
+// and values rather than the ones used on disk.
+
+var (
+	dsStats    = dsPrefix.ChildString("stats")
+	dsLogStats = dsPrefix.ChildString("logstats")
+
+	dsDispatcherStats = dsLogStats.ChildString("dispatcher")
+	dsActionStats     = dsLogStats.ChildString("action")
+)
+
+// CollectionStats are the stats of a collection.
+type CollectionStats struct {
+	// Name is the name of the collection.
+	Name string
+	// Instances is the number of instances, including soft-deleted ones.
+	Instances int64
+	// InstanceBytes is the approximate size of the instances.
+	InstanceBytes int64
+	// IndexEntries is the number of entries of the indexes.
+	IndexEntries int64
+	// IndexBytes is the approximate size of the index entries.
+	IndexBytes int64
+	// LastWrite is the time an instance was last written or deleted on this
+	// peer, which is zero if none was since the stats were kept.
+	LastWrite time.Time
+}
+
+// LogStats are the stats of a log of the db.
+type LogStats struct {
+	// Entries is the number of entries of the log.
+	Entries int64
+	// Bytes is the approximate size of the entries.
+	Bytes int64
+}
+
+// Stats are the stats of a db.
+type Stats struct {
+	// Collections are the stats of each collection, sorted by name.
+	Collections []CollectionStats
+	// Instances, InstanceBytes, IndexEntries and IndexBytes are the totals
+	// of the collections.
+	Instances     int64
+	InstanceBytes int64
+	IndexEntries  int64
+	IndexBytes    int64
+	// Dispatcher are the stats of the events dispatched to the db, which
+	// are kept until their collection is deleted.
+	Dispatcher LogStats
+	// ActionLog are the stats of the actions logged to be replayed to
+	// listeners.
+	ActionLog LogStats
+}
+
+// storedStats are the stats of a collection, or of a log, as they're stored.
+type storedStats struct {
+	Entries      int64 `json:"entries"`
+	Bytes        int64 `json:"bytes"`
+	IndexEntries int64 `json:"indexEntries,omitempty"`
+	IndexBytes   int64 `json:"indexBytes,omitempty"`
+	LastWrite    int64 `json:"lastWrite,omitempty"`
+}
+
+func (s *storedStats) add(o storedStats) {
+	s.Entries += o.Entries
+	s.Bytes += o.Bytes
+	s.IndexEntries += o.IndexEntries
+	s.IndexBytes += o.IndexBytes
+	if o.LastWrite > s.LastWrite {
+		s.LastWrite = o.LastWrite
+	}
+}
+
+func (s storedStats) collection(name string) CollectionStats {
+	cs := CollectionStats{
+		Name:          name,
+		Instances:     s.Entries,
+		InstanceBytes: s.Bytes,
+		IndexEntries:  s.IndexEntries,
+		IndexBytes:    s.IndexBytes,
+	}
+	if s.LastWrite != 0 {
+		cs.LastWrite = time.Unix(0, s.LastWrite)
+	}
+	return cs
+}
+
+func (s storedStats) log() LogStats {
+	return LogStats{Entries: s.Entries, Bytes: s.Bytes}
+}
+
+// getStats returns the stats stored at key, or ds.ErrNotFound.
+func getStats(r ds.Read, key ds.Key) (storedStats, error) {
+	var s storedStats
+	v, err := r.Get(key)
+	if err != nil {
+		return s, err
+	}
+	err = json.Unmarshal(v, &s)
+	return s, err
+}
+
+func putStats(w ds.Write, key ds.Key, s storedStats) error {
+	v, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return w.Put(key, v)
+}
+
+// CollectionStats returns the stats of the collection named name.
+func (d *DB) CollectionStats(name string, opts ...Option) (CollectionStats, error) {
+	args := &Options{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if err := d.connector.Validate(args.Token, true); err != nil {
+		return CollectionStats{}, err
+	}
+	d.lock.RLock()
+	_, ok := d.collections[name]
+	d.lock.RUnlock()
+	if !ok {
+		return CollectionStats{}, ErrCollectionNotFound
+	}
+	s, err := getStats(d.datastore, dsStats.ChildString(name))
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return CollectionStats{}, err
+	}
+	return s.collection(name), nil
+}
+
+// Stats returns the stats of the db, aggregating the ones of its collections.
+func (d *DB) Stats(opts ...Option) (Stats, error) {
+	args := &Options{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if err := d.connector.Validate(args.Token, true); err != nil {
+		return Stats{}, err
+	}
+	d.lock.RLock()
+	names := make([]string, 0, len(d.collections))
+	for name := range d.collections {
+		names = append(names, name)
+	}
+	d.lock.RUnlock()
+	sort.Strings(names)
+
+	txn, err := d.datastore.NewTransaction(true)
+	if err != nil {
+		return Stats{}, err
+	}
+	defer txn.Discard()
+	var stats Stats
+	for _, name := range names {
+		s, err := getStats(txn, dsStats.ChildString(name))
+		if err != nil && !errors.Is(err, ds.ErrNotFound) {
+			return Stats{}, err
+		}
+		cs := s.collection(name)
+		stats.Collections = append(stats.Collections, cs)
+		stats.Instances += cs.Instances
+		stats.InstanceBytes += cs.InstanceBytes
+		stats.IndexEntries += cs.IndexEntries
+		stats.IndexBytes += cs.IndexBytes
+	}
+	for key, ls := range map[ds.Key]*LogStats{dsDispatcherStats: &stats.Dispatcher, dsActionStats: &stats.ActionLog} {
+		s, err := getStats(txn, key)
+		if err != nil && !errors.Is(err, ds.ErrNotFound) {
+			return Stats{}, err
+		}
+		*ls = s.log()
+	}
+	return stats, nil
+}
+
+// RecalculateStats recalculates the stats of the collections and logs of the
+// db by scanning their data, to repair them. Writes wait until they're
+// recalculated.
+func (d *DB) RecalculateStats(opts ...Option) error {
+	args := &Options{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if err := d.connector.Validate(args.Token, false); err != nil {
+		return err
+	}
+	d.txnlock.Lock()
+	defer d.txnlock.Unlock()
+	d.seqLock.Lock()
+	defer d.seqLock.Unlock()
+	d.lock.RLock()
+	names := make([]string, 0, len(d.collections))
+	for name := range d.collections {
+		names = append(names, name)
+	}
+	d.lock.RUnlock()
+	for _, name := range names {
+		if err := d.recalculateCollectionStats(name); err != nil {
+			return err
+		}
+	}
+	return d.recalculateLogStats()
+}
+
+// initStats calculates the stats of the collections and logs of dbs created
+// before stats were kept.
+func (d *DB) initStats() error {
+	for name := range d.collections {
+		if ok, err := d.datastore.Has(dsStats.ChildString(name)); err != nil {
+			return err
+		} else if !ok {
+			if err := d.recalculateCollectionStats(name); err != nil {
+				return err
+			}
+		}
+	}
+	if ok, err := d.datastore.Has(dsActionStats); err != nil || ok {
+		return err
+	}
+	return d.recalculateLogStats()
+}
+
+// recalculateCollectionStats recalculates the stats of the collection named
+// name, keeping the time of its last write.
+func (d *DB) recalculateCollectionStats(name string) error {
+	txn, err := d.datastore.NewTransaction(false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard()
+	key := dsStats.ChildString(name)
+	old, err := getStats(txn, key)
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return err
+	}
+	base := baseKey.ChildString(name)
+	s := storedStats{LastWrite: old.LastWrite}
+	if s.Entries, s.Bytes, err = scanStats(txn, base, false); err != nil {
+		return err
+	}
+	for _, prefix := range []ds.Key{indexPrefix.Child(base), textIndexPrefix.Child(base)} {
+		entries, bytes, err := scanStats(txn, prefix, true)
+		if err != nil {
+			return err
+		}
+		s.IndexEntries += entries
+		s.IndexBytes += bytes
+	}
+	if err := putStats(txn, key, s); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
+
+// recalculateLogStats recalculates the stats of the logs.
+func (d *DB) recalculateLogStats() error {
+	txn, err := d.datastore.NewTransaction(false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard()
+	for prefix, key := range map[ds.Key]ds.Key{dsDispatcherPrefix: dsDispatcherStats, dsActions: dsActionStats} {
+		var s storedStats
+		if s.Entries, s.Bytes, err = scanStats(txn, prefix, true); err != nil {
+			return err
+		}
+		if err := putStats(txn, key, s); err != nil {
+			return err
+		}
+	}
+	return txn.Commit()
+}
+
+// scanStats returns the number of keys under prefix, and the size of their
+// keys and values, or only of the values unless withKeys is set.
+func scanStats(txn ds.Txn, prefix ds.Key, withKeys bool) (entries, bytes int64, err error) {
+	res, err := txn.Query(query.Query{Prefix: prefix.String()})
+	if err != nil {
+		return 0, 0, err
+	}
+	defer res.Close()
+	for r := range res.Next() {
+		if r.Error != nil {
+			return 0, 0, r.Error
+		}
+		entries++
+		bytes += int64(len(r.Value))
+		if withKeys {
+			bytes += int64(len(r.Key))
+		}
+	}
+	return entries, bytes, nil
+}
+
+// statsKind is the kind of data whose stats a write changes.
+type statsKind int
+
+const (
+	noStats statsKind = iota
+	instanceStats
+	indexStats
+	logStats
+)
+
+// statsKey returns the key of the stats which a write of key changes, with
+// the kind of data it's of.
+func statsKey(key ds.Key) (ds.Key, statsKind) {
+	ns := key.Namespaces()
+	switch {
+	case len(ns) == 4 && ns[0] == "db" && ns[1] == "collection":
+		return dsStats.ChildString(ns[2]), instanceStats
+	case len(ns) > 4 && (ns[0] == indexPrefix.Name() || ns[0] == textIndexPrefix.Name()) && ns[1] == "db" && ns[2] == "collection":
+		return dsStats.ChildString(ns[3]), indexStats
+	case len(ns) > 2 && ns[0] == "db" && ns[1] == dsDispatcherPrefix.Name():
+		return dsDispatcherStats, logStats
+	case len(ns) > 2 && ns[0] == "db" && ns[1] == dsActions.Name():
+		return dsActionStats, logStats
+	default:
+		return ds.Key{}, noStats
+	}
+}
+
+// statsTxn is a transaction keeping the stats of the data it writes.
+type statsTxn struct {
+	ds.Txn
+	deltas map[ds.Key]*storedStats
+}
+
+// trackStats returns txn, keeping the stats of the data written with it.
+func trackStats(txn ds.Txn) *statsTxn {
+	return &statsTxn{Txn: txn, deltas: make(map[ds.Key]*storedStats)}
+}
+
+func (t *statsTxn) Put(key ds.Key, value []byte) error {
+	return t.write(key, value, false)
+}
+
+func (t *statsTxn) Delete(key ds.Key) error {
+	return t.write(key, nil, true)
+}
+
+func (t *statsTxn) write(key ds.Key, value []byte, delete bool) error {
+	sk, kind := statsKey(key)
+	if kind == noStats {
+		if delete {
+			return t.Txn.Delete(key)
+		}
+		return t.Txn.Put(key, value)
+	}
+	old, err := t.Txn.Get(key)
+	exists := err == nil
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return err
+	}
+	if delete {
+		if err := t.Txn.Delete(key); err != nil {
+			return err
+		}
+	} else if err := t.Txn.Put(key, value); err != nil {
+		return err
+	}
+
+	// sizes of index entries and log entries count their keys, as an
+	// index entry holds the indexed value in its key
+	var entries int64
+	size := int64(len(value) - len(old))
+	if exists && delete {
+		entries = -1
+	} else if !exists && !delete {
+		entries = 1
+	}
+	if kind != instanceStats {
+		size += entries * int64(len(key.String()))
+	}
+	var change storedStats
+	switch kind {
+	case instanceStats:
+		change = storedStats{Entries: entries, Bytes: size, LastWrite: time.Now().UnixNano()}
+	case indexStats:
+		change = storedStats{IndexEntries: entries, IndexBytes: size}
+	default:
+		change = storedStats{Entries: entries, Bytes: size}
+	}
+	delta, ok := t.deltas[sk]
+	if !ok {
+		delta = &storedStats{}
+		t.deltas[sk] = delta
+	}
+	delta.add(change)
+	return nil
+}
+
+// Commit writes the stats changed by the transaction, and commits it. Stats
+// of collections which don't have any, because they're deleted, aren't.
+func (t *statsTxn) Commit() error {
+	for key, delta := range t.deltas {
+		s, err := getStats(t.Txn, key)
+		if errors.Is(err, ds.ErrNotFound) {
+			if key.Parent().Equal(dsStats) {
+				continue
+			}
+		} else if err != nil {
+			return err
+		}
+		s.add(*delta)
+		if err := putStats(t.Txn, key, s); err != nil {
+			return err
+		}
+	}
+	return t.Txn.Commit()
+}
+
+// statsStore is a datastore whose transactions keep the stats of the data
+// written with them.
+type statsStore struct {
+	ds.TxnDatastore
+}
+
+func (s statsStore) NewTransaction(readOnly bool) (ds.Txn, error) {
+	txn, err := s.TxnDatastore.NewTransaction(readOnly)
+	if err != nil {
+		return nil, err
+	}
+	return trackStats(txn), nil
+}
@@ -2,16 +2,58 @@ package db
 
 import (
 	"context"
+	"errors"
 	"io/ioutil"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
+	format "github.com/ipfs/go-ipld-format"
 	"github.com/textileio/go-threads/common"
+	"github.com/textileio/go-threads/core/app"
+	"github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/util"
 )
 
+var errCreateRecord = errors.New("creating record failed")
+
+// failingNet fails to create records once it created the number allowed,
+// which is unlimited if negative.
+type failingNet struct {
+	app.Net
+	lock    sync.Mutex
+	allowed int
+}
+
+func (n *failingNet) allow(records int) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.allowed = records
+}
+
+func (n *failingNet) ConnectApp(a app.App, id thread.ID) (*app.Connector, error) {
+	c, err := n.Net.ConnectApp(a, id)
+	if err != nil {
+		return nil, err
+	}
+	c.Net = n
+	return c, nil
+}
+
+func (n *failingNet) CreateRecord(ctx context.Context, id thread.ID, body format.Node, opts ...net.ThreadOption) (net.ThreadRecord, error) {
+	n.lock.Lock()
+	if n.allowed == 0 {
+		n.lock.Unlock()
+		return nil, errCreateRecord
+	} else if n.allowed > 0 {
+		n.allowed--
+	}
+	n.lock.Unlock()
+	return n.Net.CreateRecord(ctx, id, body, opts...)
+}
+
 func checkErr(t *testing.T, err error) {
 	t.Helper()
 	if err != nil {
@@ -20,6 +62,12 @@ func checkErr(t *testing.T, err error) {
 }
 
 func createTestDB(t *testing.T, opts ...NewOption) (*DB, func()) {
+	return createTestDBWithNet(t, nil, opts...)
+}
+
+// createTestDBWithNet creates a test db with its net wrapped by wrap, if it
+// isn't nil.
+func createTestDBWithNet(t *testing.T, wrap func(app.Net) app.Net, opts ...NewOption) (*DB, func()) {
 	dir, err := ioutil.TempDir("", "")
 	checkErr(t, err)
 	n, err := common.DefaultNetwork(
@@ -30,7 +78,11 @@ func createTestDB(t *testing.T, opts ...NewOption) (*DB, func()) {
 	checkErr(t, err)
 	store, err := util.NewBadgerDatastore(dir, "eventstore", false)
 	checkErr(t, err)
-	d, err := NewDB(context.Background(), store, n, thread.NewIDV1(thread.Raw, 32), opts...)
+	var dn app.Net = n
+	if wrap != nil {
+		dn = wrap(n)
+	}
+	d, err := NewDB(context.Background(), store, dn, thread.NewIDV1(thread.Raw, 32), opts...)
 	checkErr(t, err)
 	return d, func() {
 		time.Sleep(time.Second) // Give threads a chance to finish work
@@ -0,0 +1,248 @@
+package db
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"strings"
+	"unicode"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/tidwall/gjson"
+)
+
+var (
+	// ErrUniqueFullTextIndex indicates a full-text index was configured as unique.
+	ErrUniqueFullTextIndex = errors.New("full-text index can't be unique")
+	// ErrFullTextIndexQuery indicates a query uses a full-text index other than to search.
+	ErrFullTextIndexQuery = errors.New("full-text index can only be used to search")
+
+	textIndexPrefix = ds.NewKey("_textindex")
+)
+
+// scoreFieldName is the sort key of the rank of search results.
+const scoreFieldName = "_score"
+
+// tokenize splits text into lowercase terms of letters and digits.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// distinctTerms returns the distinct terms of text.
+func distinctTerms(text string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, t := range tokenize(text) {
+		if !seen[t] {
+			seen[t] = true
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
+
+// textIndexKey returns the key holding the instances of which the field
+// contains the term.
+func (c *Collection) textIndexKey(field, term string) ds.Key {
+	return textIndexPrefix.Child(c.baseKey()).ChildString(field).ChildString(term)
+}
+
+// textIndexUpdate adds or removes an item from the full-text index on
+// field, under each of the terms of its value.
+func (c *Collection) textIndexUpdate(field string, tx ds.Txn, key ds.Key, input []byte, delete bool) error {
+	result := gjson.GetBytes(input, field)
+	if result.Type != gjson.String {
+		return nil
+	}
+	for _, term := range distinctTerms(result.String()) {
+		indexKey := c.textIndexKey(field, term)
+		data, err := tx.Get(indexKey)
+		if err != nil && err != ds.ErrNotFound {
+			return err
+		}
+		indexValue := make(keyList, 0)
+		if data != nil {
+			if err := DefaultDecode(data, &indexValue); err != nil {
+				return err
+			}
+		}
+		if delete {
+			indexValue.remove(key)
+		} else {
+			indexValue.add(key)
+		}
+		if len(indexValue) == 0 {
+			if err := tx.Delete(indexKey); err != nil {
+				return err
+			}
+			continue
+		}
+		val, err := DefaultEncode(indexValue)
+		if err != nil {
+			return err
+		}
+		if err := tx.Put(indexKey, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deletePrefix deletes all the keys under prefix.
+func deletePrefix(tx ds.Txn, prefix ds.Key) error {
+	res, err := tx.Query(query.Query{Prefix: prefix.String(), KeysOnly: true})
+	if err != nil {
+		return err
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := tx.Delete(ds.RawKey(e.Key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkIndexUse returns ErrFullTextIndexQuery if the query uses a full-text
+// index, and ErrMissingIndexQuery if it uses the index of a single field to
+// find instances missing the field.
+func (c *Collection) checkIndexUse(q *Query) error {
+	index, ok := c.indexes[q.Index]
+	if !ok {
+		return nil
+	}
+	if index.FullText {
+		return ErrFullTextIndexQuery
+	}
+	if len(index.Fields) == 0 && q.matchesMissing(index.Path) {
+		return ErrMissingIndexQuery
+	}
+	return nil
+}
+
+// searchFor returns the Search criterion of the query which can find its
+// results from a full-text index, if any.
+func (c *Collection) searchFor(q *Query) *Criterion {
+	if q.Index != "" || len(q.Ors) != 0 {
+		return nil
+	}
+	for _, a := range q.Ands {
+		if a.Operation != Search {
+			continue
+		}
+		if index, ok := c.indexes[a.FieldPath]; ok && index.FullText {
+			return a
+		}
+	}
+	return nil
+}
+
+// newSearchIterator returns an iterator over the instances matching the
+// query, among those the full-text index finds for the Search criterion.
+func newSearchIterator(txn ds.Txn, c *Collection, q *Query, crit *Criterion) (*iterator, error) {
+	var keys [][]byte
+	var indexKeys int
+	seen := make(map[string]bool)
+	for _, term := range distinctTerms(*crit.Value.String) {
+		data, err := txn.Get(c.textIndexKey(crit.FieldPath, term))
+		if errors.Is(err, ds.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		indexKeys++
+		indexValue := make(keyList, 0)
+		if err := DefaultDecode(data, &indexValue); err != nil {
+			return nil, err
+		}
+		for _, k := range indexValue {
+			if !seen[string(k)] {
+				seen[string(k)] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+
+	i := &iterator{
+		txn:       txn,
+		query:     q,
+		iter:      query.ResultsWithEntries(query.Query{}, nil),
+		matchAll:  true,
+		indexKeys: indexKeys,
+	}
+	i.nextKeys = func() ([]ds.Key, error) {
+		nKeys := make([]ds.Key, len(keys))
+		for j, k := range keys {
+			nKeys[j] = ds.RawKey(string(k))
+		}
+		keys = nil
+		return nKeys, nil
+	}
+	return i, nil
+}
+
+// matchSearch tells whether the text contains any of the terms of the
+// search.
+func matchSearch(text, search string) bool {
+	terms := make(map[string]bool)
+	for _, t := range tokenize(search) {
+		terms[t] = true
+	}
+	for _, t := range tokenize(text) {
+		if terms[t] {
+			return true
+		}
+	}
+	return false
+}
+
+// searching tells whether the query has a Search criterion which ranks its
+// results.
+func (q *Query) searching() bool {
+	for _, a := range q.Ands {
+		if a.Operation == Search {
+			return true
+		}
+	}
+	return false
+}
+
+// searchScore returns the rank of an instance among the search results of
+// the query, which is the number of occurrences of the terms of its Search
+// criteria in their fields.
+func (q *Query) searchScore(v map[string]interface{}) float64 {
+	var score float64
+	for _, a := range q.Ands {
+		if a.Operation != Search {
+			continue
+		}
+		field, err := traverseFieldPathMap(v, a.FieldPath)
+		if err != nil || !field.IsValid() {
+			continue
+		}
+		text, ok := field.Interface().(string)
+		if !ok {
+			continue
+		}
+		terms := make(map[string]bool)
+		for _, t := range tokenize(*a.Value.String) {
+			terms[t] = true
+		}
+		for _, t := range tokenize(text) {
+			if terms[t] {
+				score++
+			}
+		}
+	}
+	return score
+}
@@ -0,0 +1,136 @@
+package db
+
+import (
+	"bytes"
+	"sort"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	dse "github.com/textileio/go-datastore-extensions"
+)
+
+// unionBranch is an alternative of a query whose keys are read from an
+// index, by the ones of the values of its In criterion, a full-text search,
+// or a plan.
+type unionBranch struct {
+	query  *Query
+	search *Criterion
+	plan   *indexPlan
+}
+
+// path returns the path of the index of the branch.
+func (b unionBranch) path() string {
+	switch {
+	case b.search != nil:
+		return b.search.FieldPath
+	case b.plan != nil:
+		return b.plan.index.Path
+	default:
+		return b.query.Index
+	}
+}
+
+// planUnion returns the alternatives of a query which doesn't use an index,
+// if all of them can be read from an index, so its results are found among
+// the union of their keys rather than by scanning the collection.
+// Alternatives are the criteria of the query and each of its Or queries,
+// whose own alternatives are flattened.
+func (c *Collection) planUnion(q *Query) []unionBranch {
+	if q.Index != "" || len(q.Ors) == 0 {
+		return nil
+	}
+	var branches []unionBranch
+	var add func(q *Query) bool
+	add = func(q *Query) bool {
+		if len(q.Ands) == 0 {
+			// matches every instance
+			return false
+		}
+		b := unionBranch{query: c.indexFor(&Query{Ands: q.Ands})}
+		if b.query.Index != "" {
+			if c.indexes[b.query.Index].FullText {
+				return false
+			}
+		} else {
+			b.search = c.searchFor(b.query)
+			if b.search == nil {
+				if b.plan = c.planFor(b.query); b.plan == nil {
+					return false
+				}
+			}
+		}
+		branches = append(branches, b)
+		for _, or := range q.Ors {
+			if !add(or) {
+				return false
+			}
+		}
+		return true
+	}
+	if !add(q) {
+		return nil
+	}
+	return branches
+}
+
+// newUnionIterator returns an iterator over the instances matching the
+// query, among the union of the keys of the branches. Keys are read from the
+// indexes, and deduplicated, before any instance is read.
+func newUnionIterator(txn dse.TxnExt, c *Collection, q *Query, branches []unionBranch) (*iterator, error) {
+	var keys [][]byte
+	var indexKeys int
+	seen := make(map[string]bool)
+	for _, b := range branches {
+		var it *iterator
+		var err error
+		switch {
+		case b.search != nil:
+			it, err = newSearchIterator(txn, c, b.query, b.search)
+		case b.plan != nil:
+			it, err = newPlanIterator(txn, c, b.query, b.plan)
+		default:
+			it, err = newIterator(txn, c.baseKey(), b.query)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for {
+			nKeys, err := it.nextKeys()
+			if err != nil {
+				it.Close()
+				return nil, err
+			}
+			if len(nKeys) == 0 {
+				break
+			}
+			for _, k := range nKeys {
+				if !seen[k.String()] {
+					seen[k.String()] = true
+					keys = append(keys, k.Bytes())
+				}
+			}
+		}
+		indexKeys += it.indexKeys
+		it.Close()
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+
+	i := &iterator{
+		txn:       txn,
+		query:     q,
+		iter:      query.ResultsWithEntries(query.Query{}, nil),
+		matchAll:  true,
+		indexKeys: indexKeys,
+	}
+	i.nextKeys = func() ([]ds.Key, error) {
+		nKeys := make([]ds.Key, len(keys))
+		for j, k := range keys {
+			nKeys[j] = ds.RawKey(string(k))
+		}
+		keys = nil
+		return nKeys, nil
+	}
+	return i, nil
+}
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/tidwall/gjson"
+)
+
+// ErrUniqueConstraintViolation indicates a write gives an instance the value
+// of a unique index of another instance. It matches ErrUniqueExists.
+type ErrUniqueConstraintViolation struct {
+	// Path is the path of the unique index.
+	Path string
+	// InstanceID is the instance which already has the value.
+	InstanceID core.InstanceID
+}
+
+func (e *ErrUniqueConstraintViolation) Error() string {
+	return fmt.Sprintf("%s: %s is already set by instance %s", ErrUniqueExists, e.Path, e.InstanceID)
+}
+
+func (e *ErrUniqueConstraintViolation) Unwrap() error {
+	return ErrUniqueExists
+}
+
+// uniqueIndexValue returns the key of the value of input in the index at
+// path, or false if input has no value to index.
+func uniqueIndexValue(path string, index Index, input []byte) (string, bool) {
+	if len(index.Fields) > 0 {
+		return compositeIndexValue(index.Fields, input), true
+	}
+	values := indexValues(path, index, input)
+	if len(values) == 0 {
+		return "", false
+	}
+	return values[0], true
+}
+
+// indexedIDs returns the instances with the value in the index at path.
+func (c *Collection) indexedIDs(txn ds.Read, path, value string) ([]core.InstanceID, error) {
+	data, err := txn.Get(indexPrefix.Child(c.baseKey()).ChildString(path).ChildString(value))
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	indexValue := make(keyList, 0)
+	if err := DefaultDecode(data, &indexValue); err != nil {
+		return nil, err
+	}
+	ids := make([]core.InstanceID, len(indexValue))
+	for i, k := range indexValue {
+		ids[i] = core.InstanceID(ds.RawKey(string(k)).Name())
+	}
+	return ids, nil
+}
+
+// checkUnique checks that the writes of the transaction don't give an
+// instance the value of a unique index of another instance. Writes are
+// checked in order, like they're indexed once committed, so instances can't
+// swap values in a single transaction.
+func (t *Txn) checkUnique() error {
+	c := t.collection
+	var paths []string
+	for path, index := range c.indexes {
+		if index.Unique && path != idFieldName {
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	sort.Strings(paths)
+
+	// instances and index values as the previous writes leave them, with
+	// an empty ID for values no longer set
+	instances := make(map[core.InstanceID][]byte)
+	owners := make(map[string]map[string]core.InstanceID)
+	for _, path := range paths {
+		owners[path] = make(map[string]core.InstanceID)
+	}
+	for _, a := range t.actions {
+		previous, ok := instances[a.InstanceID]
+		if !ok {
+			var err error
+			previous, err = c.db.datastore.Get(c.baseKey().ChildString(a.InstanceID.String()))
+			if err != nil && !errors.Is(err, ds.ErrNotFound) {
+				return err
+			}
+		}
+		for _, path := range paths {
+			index := c.indexes[path]
+			if previous != nil {
+				if value, ok := uniqueIndexValue(path, index, previous); ok {
+					owners[path][value] = ""
+				}
+			}
+			if a.Current == nil {
+				continue
+			}
+			value, ok := uniqueIndexValue(path, index, a.Current)
+			if !ok {
+				continue
+			}
+			owner, ok := owners[path][value]
+			if !ok {
+				ids, err := c.indexedIDs(c.db.datastore, path, value)
+				if err != nil {
+					return err
+				}
+				for _, id := range ids {
+					if id != a.InstanceID {
+						owner = id
+						break
+					}
+				}
+			}
+			if owner != "" && owner != a.InstanceID {
+				return &ErrUniqueConstraintViolation{Path: path, InstanceID: owner}
+			}
+			owners[path][value] = a.InstanceID
+		}
+		instances[a.InstanceID] = a.Current
+	}
+	return nil
+}
+
+// uniqueDuplicates returns an error listing the instances sharing a value of
+// the unique index, or nil if there are none.
+func uniqueDuplicates(index Index, instances [][]byte) error {
+	byValue := make(map[string][]string)
+	var values []string
+	for _, i := range instances {
+		value, ok := uniqueIndexValue(index.Path, index, i)
+		if !ok {
+			continue
+		}
+		if _, ok := byValue[value]; !ok {
+			values = append(values, value)
+		}
+		byValue[value] = append(byValue[value], gjson.GetBytes(i, idFieldName).String())
+	}
+	var duplicates []string
+	for _, value := range values {
+		if ids := byValue[value]; len(ids) > 1 {
+			duplicates = append(duplicates, strings.Join(ids, ", "))
+		}
+	}
+	if len(duplicates) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: instances share values at %s: %s", ErrCantCreateUniqueIndex, index.Path, strings.Join(duplicates, "; "))
+}
@@ -0,0 +1,410 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// UpdateReportSamples is the max number of incompatible instances sampled
+// by a CollectionUpdateReport.
+const UpdateReportSamples = 10
+
+// ErrIncompatibleInstances indicates instances of a collection aren't valid
+// against the schema it's updated to.
+var ErrIncompatibleInstances = errors.New("instances are incompatible with the new schema")
+
+// ValidationError is a violation of the schema or the indexes of a
+// collection by one of its instances.
+type ValidationError struct {
+	// InstanceID is the violating instance.
+	InstanceID core.InstanceID
+	// Field is the JSON pointer of the violating field, which is empty for
+	// the whole instance.
+	Field string
+	// Message describes the violation.
+	Message string
+	// Legacy is set if the instance was last modified before the schema
+	// was updated without validating the existing instances.
+	Legacy bool
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("instance %s: %s: %s", e.InstanceID, e.Field, e.Message)
+}
+
+// CollectionUpdateReport describes the instances of a collection which
+// aren't valid against the schema it's updated to.
+type CollectionUpdateReport struct {
+	// Incompatible is the number of invalid instances.
+	Incompatible int
+	// SampleIDs are the first invalid instances, up to UpdateReportSamples.
+	SampleIDs []core.InstanceID
+	// Pointers are the sorted JSON pointers of the fields failing
+	// validation, where the empty pointer is the whole instance.
+	Pointers []string
+}
+
+// IncompatibleInstancesError is returned by UpdateCollection if instances of
+// the collection aren't valid against the new schema.
+type IncompatibleInstancesError struct {
+	Report CollectionUpdateReport
+}
+
+func (e *IncompatibleInstancesError) Error() string {
+	return fmt.Sprintf("%s: %d instances, such as %s, fail at %s", ErrIncompatibleInstances, e.Report.Incompatible, e.Report.SampleIDs[0], strings.Join(e.Report.Pointers, ", "))
+}
+
+func (e *IncompatibleInstancesError) Unwrap() error {
+	return ErrIncompatibleInstances
+}
+
+// CheckCollectionUpdate reports the instances of a collection which aren't
+// valid against the schema of the new config, which UpdateCollection would
+// fail with, without changing the collection.
+func (d *DB) CheckCollectionUpdate(config CollectionConfig, opts ...Option) (CollectionUpdateReport, error) {
+	args := &Options{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	d.lock.Lock()
+	if err := d.connector.Validate(args.Token, true); err != nil {
+		d.lock.Unlock()
+		return CollectionUpdateReport{}, err
+	}
+	_, ok := d.collections[config.Name]
+	schema, err := d.resolveSchemaRefs(config.Schema)
+	d.lock.Unlock()
+	if !ok {
+		return CollectionUpdateReport{}, ErrCollectionNotFound
+	}
+	if err != nil {
+		return CollectionUpdateReport{}, err
+	}
+	config.Schema = schema
+	c, err := newCollection(d, config)
+	if err != nil {
+		return CollectionUpdateReport{}, err
+	}
+	for _, index := range config.Indexes {
+		if index.Path == idFieldName {
+			return CollectionUpdateReport{}, ErrCannotIndexIDField
+		}
+		if err := checkIndexable(config.Schema, index); err != nil {
+			return CollectionUpdateReport{}, fmt.Errorf("index %s: %w", index.Path, err)
+		}
+	}
+	return c.checkInstances()
+}
+
+// checkInstances reports the instances stored under the name of the
+// collection which aren't valid against its schema.
+func (c *Collection) checkInstances() (CollectionUpdateReport, error) {
+	var report CollectionUpdateReport
+	txn, err := c.db.datastore.NewTransaction(true)
+	if err != nil {
+		return report, err
+	}
+	defer txn.Discard()
+	res, err := txn.Query(query.Query{Prefix: c.baseKey().String()})
+	if err != nil {
+		return report, err
+	}
+	defer res.Close()
+	pointers := make(map[string]bool)
+	for r := range res.Next() {
+		if r.Error != nil {
+			return report, r.Error
+		}
+		id := core.InstanceID(ds.RawKey(r.Key).Name())
+		errs := c.schemaViolations(id, r.Value)
+		if len(errs) == 0 {
+			continue
+		}
+		if report.Incompatible++; len(report.SampleIDs) < UpdateReportSamples {
+			report.SampleIDs = append(report.SampleIDs, id)
+		}
+		for _, e := range errs {
+			if !pointers[e.Field] {
+				pointers[e.Field] = true
+				report.Pointers = append(report.Pointers, e.Field)
+			}
+		}
+	}
+	sort.Strings(report.Pointers)
+	return report, nil
+}
+
+// Validate checks every instance of the collection against its schema and
+// indexes, and returns the violations found, up to limit if it's positive.
+// Instances must not share the value of a unique index, and must be indexed,
+// which they aren't if they were written before the index was added and it
+// wasn't rebuilt. Full-text indexes aren't checked. Violations by instances
+// last modified before the schema was updated without validation are legacy.
+// Validation stops with the error of ctx once it's done.
+func (c *Collection) Validate(ctx context.Context, limit int, opts ...TxnOption) ([]ValidationError, error) {
+	args := &TxnOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if err := c.db.connector.Validate(args.Token, true); err != nil {
+		return nil, err
+	}
+	txn, err := c.db.datastore.NewTransaction(true)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard()
+	res, err := txn.Query(query.Query{Prefix: c.baseKey().String()})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	var paths []string
+	for path, index := range c.indexes {
+		if !index.FullText && path != idFieldName {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	// owners of the values of unique indexes
+	owners := make(map[string]map[string]core.InstanceID)
+	for _, path := range paths {
+		owners[path] = make(map[string]core.InstanceID)
+	}
+
+	var errs []ValidationError
+	for r := range res.Next() {
+		if err := ctx.Err(); err != nil {
+			return errs, err
+		}
+		if r.Error != nil {
+			return errs, r.Error
+		}
+		id := core.InstanceID(ds.RawKey(r.Key).Name())
+		found, err := c.validateInstance(txn, id, r.Value, paths, owners)
+		if err != nil {
+			return errs, err
+		}
+		for _, e := range found {
+			errs = append(errs, e)
+			if limit > 0 && len(errs) == limit {
+				return errs, nil
+			}
+		}
+	}
+	return errs, nil
+}
+
+// validateInstance returns the violations of the schema, and of the indexes
+// at paths, by an instance, along with its date-time values which aren't
+// canonical.
+func (c *Collection) validateInstance(txn ds.Read, id core.InstanceID, instance []byte, paths []string, owners map[string]map[string]core.InstanceID) ([]ValidationError, error) {
+	errs := c.schemaViolations(id, instance)
+	if c.dates != nil {
+		pointers, err := c.dates.dateViolations(instance)
+		if err != nil {
+			return nil, err
+		}
+		for _, pointer := range pointers {
+			errs = append(errs, ValidationError{
+				InstanceID: id,
+				Field:      pointer,
+				Message:    "date-time value isn't canonical, so it doesn't compare chronologically, and saving the instance normalizes it",
+			})
+		}
+	}
+	for _, path := range paths {
+		index := c.indexes[path]
+		var field string
+		if len(index.Fields) == 0 {
+			field = "/" + strings.Replace(path, ".", "/", -1)
+		}
+		for _, value := range indexValues(path, index, instance) {
+			if index.Unique {
+				if owner, ok := owners[path][value]; ok {
+					errs = append(errs, ValidationError{
+						InstanceID: id,
+						Field:      field,
+						Message:    fmt.Sprintf("value of unique index %s is also set by instance %s", path, owner),
+					})
+				} else {
+					owners[path][value] = id
+				}
+			}
+			ids, err := c.indexedIDs(txn, path, value)
+			if err != nil {
+				return nil, err
+			}
+			var indexed bool
+			for _, i := range ids {
+				indexed = indexed || i == id
+			}
+			if !indexed {
+				errs = append(errs, ValidationError{
+					InstanceID: id,
+					Field:      field,
+					Message:    fmt.Sprintf("instance isn't in index %s, which must be rebuilt", path),
+				})
+			}
+		}
+	}
+	if instanceVersion(instance) < c.legacyBefore {
+		for i := range errs {
+			errs[i].Legacy = true
+		}
+	}
+	return errs, nil
+}
+
+// schemaViolations returns the violations of the schema by an instance. The
+// fields the db adds to instances after validating writes are allowed.
+func (c *Collection) schemaViolations(id core.InstanceID, instance []byte) []ValidationError {
+	r, err := gojsonschema.Validate(c.schemaLoader, gojsonschema.NewBytesLoader(instance))
+	if err != nil {
+		return []ValidationError{{InstanceID: id, Message: err.Error()}}
+	}
+	var errs []ValidationError
+	for _, e := range r.Errors() {
+		if property, ok := e.Details()["property"].(string); ok && e.Type() == "additional_property_not_allowed" &&
+			(property == modFieldName || property == deletedFieldName) {
+			continue
+		}
+		field := strings.TrimPrefix(e.Context().String("/"), gojsonschema.STRING_CONTEXT_ROOT)
+		if property, ok := e.Details()["property"].(string); ok && e.Type() == "required" {
+			field += "/" + property
+		}
+		errs = append(errs, ValidationError{InstanceID: id, Field: field, Message: e.Description()})
+	}
+	return errs
+}
+
+// validateEvents validates the events written by identity with the write
+// validators of their collections. Events are reduced in order without being
+// applied, so each is validated with the instance it applies to and the one
+// it results in.
+func (d *DB) validateEvents(identity thread.PubKey, events []core.Event) error {
+	var validated bool
+	writes := make([]core.Event, 0, len(events))
+	for _, e := range events {
+		if re, ok := e.(core.RenameEvent); ok {
+			if _, ok := re.RenamedCollection(); ok {
+				continue
+			}
+		}
+		writes = append(writes, d.renamedEvent(e))
+	}
+	events = writes
+	for _, e := range events {
+		c, ok := d.collections[e.Collection()]
+		if !ok {
+			return ErrCollectionNotFound
+		}
+		validated = validated || c.writeValidator != nil
+	}
+	if !validated {
+		return nil
+	}
+
+	store := newPendingStore(d.datastore)
+	noIndex := func(string, ds.Key, []byte, []byte, ds.Txn) error { return nil }
+	for _, e := range events {
+		key := baseKey.ChildString(e.Collection()).ChildString(e.InstanceID().String())
+		previous, err := store.getOrNil(key)
+		if err != nil {
+			return err
+		}
+		if _, err := d.eventcodec.Reduce([]core.Event{e}, store, baseKey, noIndex); err != nil {
+			return fmt.Errorf("reducing event in validate write: %w", err)
+		}
+		next, err := store.getOrNil(key)
+		if err != nil {
+			return err
+		}
+		if err := d.collections[e.Collection()].validWrite(identity, e, previous, next); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pendingStore reads through to a datastore, but keeps writes in memory, so
+// events can be reduced without applying them. Queries don't read the writes.
+type pendingStore struct {
+	ds.TxnDatastore
+	values map[ds.Key][]byte
+}
+
+func newPendingStore(store ds.TxnDatastore) *pendingStore {
+	return &pendingStore{TxnDatastore: store, values: make(map[ds.Key][]byte)}
+}
+
+func (s *pendingStore) Get(key ds.Key) ([]byte, error) {
+	if v, ok := s.values[key]; ok {
+		if v == nil {
+			return nil, ds.ErrNotFound
+		}
+		return v, nil
+	}
+	return s.TxnDatastore.Get(key)
+}
+
+func (s *pendingStore) Has(key ds.Key) (bool, error) {
+	if v, ok := s.values[key]; ok {
+		return v != nil, nil
+	}
+	return s.TxnDatastore.Has(key)
+}
+
+func (s *pendingStore) GetSize(key ds.Key) (int, error) {
+	if v, ok := s.values[key]; ok {
+		if v == nil {
+			return -1, ds.ErrNotFound
+		}
+		return len(v), nil
+	}
+	return s.TxnDatastore.GetSize(key)
+}
+
+func (s *pendingStore) Put(key ds.Key, value []byte) error {
+	s.values[key] = value
+	return nil
+}
+
+func (s *pendingStore) Delete(key ds.Key) error {
+	s.values[key] = nil
+	return nil
+}
+
+func (s *pendingStore) NewTransaction(bool) (ds.Txn, error) {
+	return pendingTxn{s}, nil
+}
+
+// getOrNil returns the value of key, or nil if it doesn't exist.
+func (s *pendingStore) getOrNil(key ds.Key) ([]byte, error) {
+	v, err := s.Get(key)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, nil
+	}
+	return v, err
+}
+
+// pendingTxn writes to its pending store as soon as it's written to.
+type pendingTxn struct {
+	*pendingStore
+}
+
+func (pendingTxn) Commit() error {
+	return nil
+}
+
+func (pendingTxn) Discard() {}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/testground/sdk-go/sync"
+)
+
+// defaultAggregateTimeout is how long the first instance waits for the
+// summaries of all the others.
+const defaultAggregateTimeout = 30 * time.Second
+
+// summariesTopic is where every instance publishes its summaries.
+var summariesTopic = sync.NewTopic("measure-summaries", &instanceSummaries{})
+
+// instanceSummaries are the statistics of the metrics of a test instance, as
+// published to the sync service.
+type instanceSummaries struct {
+	Seq     int64
+	Metrics map[string]metricSummary
+}
+
+type metricSummary struct {
+	Mean float64
+	P95  float64
+	// Total is only meaningful if Rate is true.
+	Total float64
+	Rate  bool
+}
+
+// Aggregate stops measuring and prints if not done yet, then publishes the
+// summaries of the metrics to the sync service. The instance of global
+// sequence number 1 then collects the summaries of all instances, waiting for
+// them up to the "aggregate-timeout" param (30s if not set), and prints them
+// as a cluster-wide table along with aggregate statistics.
+func (p *measure) Aggregate(ctx context.Context, client sync.Client, instances int) error {
+	_ = p.Print()
+	p.lock.Lock()
+	own := &instanceSummaries{Seq: p.instanceSeq, Metrics: make(map[string]metricSummary)}
+	for name, s := range p.summaries {
+		if baseMetric(name) != name {
+			// leave the per-device series out, they don't add up across instances
+			continue
+		}
+		own.Metrics[name] = metricSummary{Mean: s.mean, P95: s.p95, Total: s.total, Rate: p.isRate(name)}
+	}
+	names := p.seriesNames()
+	p.lock.Unlock()
+
+	if _, err := client.Publish(ctx, summariesTopic, own); err != nil {
+		return fmt.Errorf("publishing summaries: %w", err)
+	}
+	if own.Seq != 1 {
+		return nil
+	}
+
+	timeout := defaultAggregateTimeout
+	if p.runenv.IsParamSet("aggregate-timeout") {
+		if d, err := time.ParseDuration(p.runenv.StringParam("aggregate-timeout")); err == nil {
+			timeout = d
+		} else {
+			p.runenv.RecordMessage("WARNING: Invalid aggregate-timeout, using %v: %v", timeout, err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	// buffered so that the summaries published so far can be replayed
+	ch := make(chan *instanceSummaries, instances)
+	sub, err := client.Subscribe(ctx, summariesTopic, ch)
+	if err != nil {
+		return fmt.Errorf("subscribing to summaries: %w", err)
+	}
+	all := make(map[int64]*instanceSummaries)
+wait:
+	for len(all) < instances {
+		select {
+		case s := <-ch:
+			all[s.Seq] = s
+		case err := <-sub.Done():
+			if ctx.Err() == nil {
+				return fmt.Errorf("receiving summaries: %v", err)
+			}
+			break wait
+		case <-ctx.Done():
+			break wait
+		}
+	}
+	if len(all) < instances {
+		var missing []string
+		for seq := int64(1); seq <= int64(instances); seq++ {
+			if _, ok := all[seq]; !ok {
+				missing = append(missing, fmt.Sprint(seq))
+			}
+		}
+		p.runenv.RecordMessage("WARNING: Timed out after %v waiting for the summaries of instances %s",
+			timeout, strings.Join(missing, ", "))
+	}
+	p.runenv.RecordMessage(p.aggregate(names, all))
+	return nil
+}
+
+// aggregate renders the summaries of all instances of the named metrics, and
+// records the aggregate statistics as test results prefixed by "cluster/".
+func (p *measure) aggregate(names []string, all map[int64]*instanceSummaries) string {
+	seqs := make([]int64, 0, len(all))
+	for seq := range all {
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster-wide measurements of %d instances:\n", len(all))
+	fmt.Fprintf(&b, "%-20s %8s %14s %14s %16s\n", "metric", "instance", "mean", "p95", "total")
+	var totals strings.Builder
+	fmt.Fprintf(&totals, "%-20s %14s %14s %9s %16s\n", "metric", "median mean", "worst p95", "worst at", "cluster total")
+	for _, name := range names {
+		var means []float64
+		var worst metricSummary
+		var worstSeq int64
+		var total float64
+		var isRate bool
+		for _, seq := range seqs {
+			s, ok := all[seq].Metrics[name]
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(&b, "%-20s %8d %14.2f %14.2f %16s\n", name, seq, s.Mean, s.P95, formatTotal(s))
+			means = append(means, s.Mean)
+			if worstSeq == 0 || s.P95 > worst.P95 {
+				worst, worstSeq = s, seq
+			}
+			total += s.Total
+			isRate = s.Rate
+		}
+		if len(means) == 0 {
+			continue
+		}
+		sort.Float64s(means)
+		medianMean := percentile(means, 50)
+		p.runenv.R().RecordPoint("cluster/"+name+"-median-mean", medianMean)
+		p.runenv.R().RecordPoint("cluster/"+name+"-worst-p95", worst.P95)
+		clusterTotal := "-"
+		if isRate {
+			p.runenv.R().RecordPoint("cluster/"+name+"-total", total)
+			clusterTotal = fmt.Sprintf("%.2f", total)
+		}
+		fmt.Fprintf(&totals, "%-20s %14.2f %14.2f %9d %16s\n", name, medianMean, worst.P95, worstSeq, clusterTotal)
+	}
+	return b.String() + "\n" + totals.String()
+}
+
+func formatTotal(s metricSummary) string {
+	if !s.Rate {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", s.Total)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// defaultBaselineTolerancePct is the change from the baseline, in percent,
+// beyond which a metric is highlighted.
+const defaultBaselineTolerancePct = 10
+
+// loadBaseline reads the samples exported by a previous run, either the CSV
+// or the JSON file, and returns them keyed by metric name.
+func loadBaseline(path string) (map[string][]float64, error) {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return loadBaselineCSV(path)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string][]struct {
+		Value float64 `json:"value"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	baseline := make(map[string][]float64, len(doc))
+	for name, samples := range doc {
+		for _, s := range samples {
+			baseline[name] = append(baseline[name], s.Value)
+		}
+	}
+	return baseline, nil
+}
+
+func loadBaselineCSV(path string) (map[string][]float64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	baseline := make(map[string][]float64)
+	for i, row := range rows {
+		// skip the header and the markers
+		if i == 0 || len(row) < 3 || row[1] == markerMetric {
+			continue
+		}
+		v, err := strconv.ParseFloat(row[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing row %d: %w", i+1, err)
+		}
+		baseline[row[1]] = append(baseline[row[1]], v)
+	}
+	return baseline, nil
+}
+
+// compareBaseline renders the change in mean and p95 of each metric versus
+// the baseline given by the "baseline-file" param, highlighting changes
+// beyond "baseline-tolerance-pct". It returns an empty string if no baseline
+// is given.
+func (p *measure) compareBaseline(names []string, summaries map[string]summary) string {
+	if !p.runenv.IsParamSet("baseline-file") || p.runenv.StringParam("baseline-file") == "" {
+		return ""
+	}
+	path := p.runenv.StringParam("baseline-file")
+	baseline, err := loadBaseline(path)
+	if err != nil {
+		p.runenv.RecordMessage("WARNING: Failed to load baseline %s: %v", path, err)
+		return ""
+	}
+	tolerance := float64(defaultBaselineTolerancePct)
+	if p.runenv.IsParamSet("baseline-tolerance-pct") {
+		tolerance = p.runenv.FloatParam("baseline-tolerance-pct")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\nCompared to baseline %s (tolerance %.1f%%):\n", path, tolerance)
+	fmt.Fprintf(&b, "%-20s %14s %14s\n", "metric", "mean", "p95")
+	for _, name := range names {
+		s, ok := summaries[name]
+		if !ok || len(baseline[name]) < 2 {
+			continue
+		}
+		base := summarize(baseline[name], nil)
+		fmt.Fprintf(&b, "%-20s %14s %14s\n", name,
+			formatChange(s.mean, base.mean, tolerance),
+			formatChange(s.p95, base.p95, tolerance))
+	}
+	return b.String()
+}
+
+// formatChange renders the change of value from base in percent, marked with
+// "!" if it is beyond tolerance.
+func formatChange(value, base, tolerance float64) string {
+	if base == 0 {
+		if value == 0 {
+			return "+0.0%"
+		}
+		return "n/a"
+	}
+	change := (value - base) / math.Abs(base) * 100
+	if math.Abs(change) > tolerance {
+		return fmt.Sprintf("!%+.1f%%", change)
+	}
+	return fmt.Sprintf("%+.1f%%", change)
+}
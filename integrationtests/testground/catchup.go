@@ -0,0 +1,282 @@
+package main
+
+import (
+	"context"
+	crand "crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+	sync "github.com/testground/sdk-go/sync"
+	corenet "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/net/api/client"
+)
+
+// The catch-up test case exercises the store-and-forward path:
+// |-- 1. The instances of group A (the "catch-up-group" test param) create a thread, and a few records each
+//         governed by the "records" test param, while the instances of group B are not started yet.
+// |-- 2. Group B starts, joins the thread, and must pull the full history. How it joins is governed by the
+//         "catch-up-join" test param:
+//     |-- "add-thread": AddThread with the multiaddr of the thread composed by group A.
+//     |-- "pubsub": create the thread locally with the shared key, so it's only subscribed to the thread topic,
+//         and learn the logs when group A adds it as a replicator.
+// |-- 3. All instances check they have exactly the records of group A, and the same heads.
+// Group B measures the catch-up in its own phase, and records how long it took.
+
+const (
+	catchUpGroupA = "a"
+	catchUpGroupB = "b"
+
+	catchUpJoinAddThread = "add-thread"
+	catchUpJoinPubsub    = "pubsub"
+)
+
+// catchUpMember tells the group of an instance, as published to the sync
+// service.
+type catchUpMember struct {
+	Seq    int64
+	Joiner bool
+}
+
+// catchUpHeads are the heads of the non-empty logs of the thread as seen by an
+// instance, keyed by log ID.
+type catchUpHeads struct {
+	Seq   int64
+	Heads map[string]string
+}
+
+func testCatchUp(env *runtime.RunEnv, ic *run.InitContext) (err error) {
+	return testMultipleRounds("testCatchUp", env, ic, testCatchUpRound)
+}
+
+func testCatchUpRound(ctx context.Context, env *runtime.RunEnv, ic *run.InitContext, round string, desiredAddr string) error {
+	var joiner bool
+	switch group := env.StringParam("catch-up-group"); group {
+	case catchUpGroupA:
+	case catchUpGroupB:
+		joiner = true
+	default:
+		return fmt.Errorf("invalid catch-up-group %q", group)
+	}
+	join := env.StringParam("catch-up-join")
+	if join != catchUpJoinAddThread && join != catchUpJoinPubsub {
+		return fmt.Errorf("invalid catch-up-join %q", join)
+	}
+
+	// find out the members of each group, the first of group A creates the thread
+	chMembers := make(chan *catchUpMember, env.TestInstanceCount)
+	ic.SyncClient.MustPublishSubscribe(ctx, sync.NewTopic("catch-up-members-"+round, &catchUpMember{}),
+		&catchUpMember{Seq: ic.GlobalSeq, Joiner: joiner}, chMembers)
+	var creator int64
+	var online, joiners int
+	for i := 0; i < env.TestInstanceCount; i++ {
+		m := <-chMembers
+		if m.Joiner {
+			joiners++
+			continue
+		}
+		online++
+		if creator == 0 || m.Seq < creator {
+			creator = m.Seq
+		}
+	}
+	if online == 0 {
+		return fmt.Errorf("no instance in catch-up-group %q", catchUpGroupA)
+	}
+	expected := online * env.IntParam("records")
+
+	chThreadToJoin := make(chan *SharedInfo, 1)
+	topic := sync.NewTopic("catch-up-thread-"+round, &SharedInfo{})
+	createdState := sync.State("catch-up-created-" + round)
+	replicators := sync.NewTopic("catch-up-replicators-"+round, "")
+	var thr *threadWithKeys
+	if !joiner {
+		cli, stop, err := startClient(desiredAddr, env, ic)
+		if err != nil {
+			return err
+		}
+		defer stop()
+		if ic.GlobalSeq == creator {
+			if thr, err = createThread(ctx, cli); err != nil {
+				return fmt.Errorf("failed to create thread: %w", err)
+			}
+			msg("Created thread")
+			ic.SyncClient.MustPublishSubscribe(ctx, topic, thr.Sharable(), chThreadToJoin)
+		} else {
+			ic.SyncClient.MustSubscribe(ctx, topic, chThreadToJoin)
+			if thr, err = joinThread(ctx, cli, <-chThreadToJoin); err != nil {
+				return fmt.Errorf("failed to join thread: %w", err)
+			}
+			msg("Joined thread")
+		}
+		ic.SyncClient.MustSignalAndWait(ctx, sync.State("catch-up-joined-"+round), online)
+		if err := thr.CreateRecords(ctx, env.IntParam("records")); err != nil {
+			return err
+		}
+		if got, err := thr.waitForRecordCount(ctx, expected); err != nil {
+			return fmt.Errorf("Peer #%d got %d records before group B joined, expect %d: %w", ic.GlobalSeq, got, expected, err)
+		}
+		msg("Peer #%d created %d records", ic.GlobalSeq, env.IntParam("records"))
+		ic.SyncClient.MustSignalEntry(ctx, createdState)
+		if join == catchUpJoinPubsub && ic.GlobalSeq == creator {
+			if err := thr.addReplicators(ctx, ic.SyncClient, replicators, joiners); err != nil {
+				return err
+			}
+		}
+	} else {
+		// leave the idle time before group A is done out of the measurement
+		meter.Pause()
+		ic.SyncClient.MustSubscribe(ctx, topic, chThreadToJoin)
+		shared := <-chThreadToJoin
+		<-ic.SyncClient.MustBarrier(ctx, createdState, online).C
+		meter.Resume()
+		meter.StartPhase(round + "-catch-up")
+
+		cli, stop, err := startClient(desiredAddr, env, ic)
+		if err != nil {
+			return err
+		}
+		defer stop()
+		start := time.Now()
+		meter.Mark(fmt.Sprintf("%s: peer #%d joining via %s", round, ic.GlobalSeq, join))
+		if join == catchUpJoinAddThread {
+			thr, err = joinThread(ctx, cli, shared)
+		} else {
+			thr, err = subscribeThread(ctx, cli, shared)
+			if err == nil {
+				ic.SyncClient.MustPublish(ctx, replicators, thr.hostAddr().String())
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to join thread via %s: %w", join, err)
+		}
+		got, err := thr.waitForRecordCount(ctx, expected)
+		if err != nil {
+			return fmt.Errorf("Peer #%d caught up on %d records, expect %d: %w", ic.GlobalSeq, got, expected, err)
+		}
+		catchUp := time.Since(start)
+		meter.Mark(fmt.Sprintf("%s: peer #%d caught up", round, ic.GlobalSeq))
+		meter.EndPhase()
+		env.R().RecordPoint("round-"+round+"-catch-up-seconds", catchUp.Seconds())
+		msg("Peer #%d caught up on %d records via %s in %v", ic.GlobalSeq, got, join, catchUp)
+	}
+	ic.SyncClient.MustSignalAndWait(ctx, sync.State("catch-up-done-"+round), env.TestInstanceCount)
+	return thr.checkCatchUp(ctx, env, ic, round, expected)
+}
+
+// subscribeThread creates a thread with the shared ID and key locally,
+// without connecting to the peer which shared it.
+func subscribeThread(ctx context.Context, cli *client.Client, shared *SharedInfo) (*threadWithKeys, error) {
+	key, err := thread.KeyFromString(shared.ThreadKey)
+	if err != nil {
+		return nil, err
+	}
+	logSk, logPk, err := crypto.GenerateEd25519Key(crand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	sk, _, err := crypto.GenerateEd25519Key(crand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	identity := thread.NewLibp2pIdentity(sk)
+	tok, err := cli.GetToken(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	info, err := cli.CreateThread(ctx, shared.ThreadId,
+		corenet.WithThreadKey(key),
+		corenet.WithLogKey(logPk),
+		corenet.WithNewThreadToken(tok))
+	if err != nil {
+		return nil, err
+	}
+	ch, err := cli.Subscribe(context.Background(), corenet.WithSubFilter(info.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &threadWithKeys{info, identity, logSk, logPk, cid.Undef, cli, ch, make(map[cid.Cid]bool)}, nil
+}
+
+// hostAddr returns the address of the host of the thread, without the
+// thread component.
+func (t *threadWithKeys) hostAddr() multiaddr.Multiaddr {
+	addr, _ := multiaddr.SplitLast(t.Addrs[0])
+	return addr
+}
+
+// addReplicators adds the n host addresses published to topic as replicators
+// of the thread.
+func (t *threadWithKeys) addReplicators(ctx context.Context, client sync.Client, topic *sync.Topic, n int) error {
+	ch := make(chan string, n)
+	sub, err := client.Subscribe(ctx, topic, ch)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		select {
+		case s := <-ch:
+			addr, err := multiaddr.NewMultiaddr(s)
+			if err != nil {
+				return err
+			}
+			pid, err := t.cli.AddReplicator(ctx, t.ID, addr)
+			if err != nil {
+				return fmt.Errorf("failed to add replicator %s: %w", addr, err)
+			}
+			msg("Added replicator %s", pid)
+		case err := <-sub.Done():
+			return fmt.Errorf("receiving replicators: %v", err)
+		case <-ctx.Done():
+			return fmt.Errorf("added %d out of %d replicators: %w", i, n, ctx.Err())
+		}
+	}
+	return nil
+}
+
+// checkCatchUp checks the thread has exactly expected records, and the same
+// heads as seen by all the other instances.
+func (t *threadWithKeys) checkCatchUp(ctx context.Context, env *runtime.RunEnv, ic *run.InitContext, round string, expected int) error {
+	info, err := t.cli.GetThread(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	own := &catchUpHeads{Seq: ic.GlobalSeq, Heads: make(map[string]string)}
+	total := 0
+	for _, l := range info.Logs {
+		if l.Head.Counter == 0 {
+			// the logs of group B are empty, and may not be known to everyone
+			continue
+		}
+		own.Heads[l.ID.String()] = l.Head.ID.String()
+		records, err := t.GetRecords(ctx, l.Head.ID)
+		if err != nil {
+			return fmt.Errorf("Error getting records: %w", err)
+		}
+		total += len(records)
+	}
+	if total != expected {
+		return fmt.Errorf("Peer #%d has %d records, expect %d", ic.GlobalSeq, total, expected)
+	}
+
+	ch := make(chan *catchUpHeads, env.TestInstanceCount)
+	ic.SyncClient.MustPublishSubscribe(ctx, sync.NewTopic("catch-up-heads-"+round, &catchUpHeads{}), own, ch)
+	for i := 0; i < env.TestInstanceCount; i++ {
+		other := <-ch
+		if len(other.Heads) != len(own.Heads) {
+			return fmt.Errorf("Peer #%d has %d non-empty logs, peer #%d has %d", ic.GlobalSeq, len(own.Heads), other.Seq, len(other.Heads))
+		}
+		for id, head := range own.Heads {
+			if other.Heads[id] != head {
+				return fmt.Errorf("Peer #%d has head %s of log %s, peer #%d has %q", ic.GlobalSeq, head, id, other.Seq, other.Heads[id])
+			}
+		}
+	}
+	msg("Peer #%d has all %d records and the same heads as the other peers", ic.GlobalSeq, total)
+	return nil
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+	sync "github.com/testground/sdk-go/sync"
+)
+
+// The churn test case simulates peers crashing in the middle of syncing:
+// |-- One test instance creates the thread and broadcasts to the rest, which join it.
+// |-- 1. Each instance creates a few records governed by the "records" test param.
+// |-- 2. A "churn-fraction" of the instances stop their network service, and stay down for "churn-downtime".
+// |-- 3. Meanwhile, the live instances keep creating records at "churn-record-rate" per second.
+// |-- 4. The stopped instances restart with an empty repo, join the thread again, and must catch up on all the records.
+
+func testChurn(env *runtime.RunEnv, ic *run.InitContext) (err error) {
+	return testMultipleRounds("testChurn", env, ic, testChurnRound)
+}
+
+// churnCount returns how many of the instances churn. The first instance
+// never does, since it's the one the others join the thread from.
+func churnCount(instances int, fraction float64) int {
+	n := int(math.Round(fraction * float64(instances)))
+	if n > instances-1 {
+		n = instances - 1
+	}
+	if n < 0 {
+		n = 0
+	}
+	return n
+}
+
+func testChurnRound(ctx context.Context, env *runtime.RunEnv, ic *run.InitContext, round string, desiredAddr string) error {
+	downtime, err := time.ParseDuration(env.StringParam("churn-downtime"))
+	if err != nil {
+		return fmt.Errorf("invalid churn-downtime: %w", err)
+	}
+	churners := churnCount(env.TestInstanceCount, env.FloatParam("churn-fraction"))
+	isChurner := ic.GlobalSeq > int64(env.TestInstanceCount-churners)
+	recordsToSend := env.IntParam("records")
+
+	cli, stop, err := startClient(desiredAddr, env, ic)
+	if err != nil {
+		return err
+	}
+	defer func() { stop() }()
+
+	chThreadToJoin := make(chan *SharedInfo, 1)
+	topic := sync.NewTopic("churn-thread-"+round, &SharedInfo{})
+	var thr *threadWithKeys
+	if ic.GlobalSeq == 1 {
+		if thr, err = createThread(ctx, cli); err != nil {
+			return fmt.Errorf("failed to create thread: %w", err)
+		}
+		msg("Created thread")
+		ic.SyncClient.MustPublishSubscribe(ctx, topic, thr.Sharable(), chThreadToJoin)
+	} else {
+		ic.SyncClient.MustSubscribe(ctx, topic, chThreadToJoin)
+	}
+	shared := <-chThreadToJoin
+	if thr == nil {
+		if thr, err = joinThread(ctx, cli, shared); err != nil {
+			return fmt.Errorf("failed to join thread: %w", err)
+		}
+		msg("Joined thread")
+	}
+	ic.SyncClient.MustSignalAndWait(ctx, sync.State("churn-ready-"+round), env.TestInstanceCount)
+
+	if err := thr.CreateRecords(ctx, recordsToSend); err != nil {
+		return err
+	}
+	ic.SyncClient.MustSignalAndWait(ctx, sync.State("churn-created-"+round), env.TestInstanceCount)
+
+	// the live instances report how many records they created during the downtime
+	createdTopic := sync.NewTopic("churn-created-during-downtime-"+round, 0)
+	downState := sync.State("churn-down-" + round)
+	if !isChurner {
+		if churners > 0 {
+			<-ic.SyncClient.MustBarrier(ctx, downState, churners).C
+		}
+		created, err := thr.createRecordsFor(ctx, env.FloatParam("churn-record-rate"), downtime)
+		if err != nil {
+			return err
+		}
+		msg("Peer #%d created %d records while %d peers were down", ic.GlobalSeq, created, churners)
+		ic.SyncClient.MustPublish(ctx, createdTopic, created)
+		ic.SyncClient.MustSignalAndWait(ctx, sync.State("churn-done-"+round), env.TestInstanceCount)
+		return nil
+	}
+
+	stop()
+	stop = func() {}
+	meter.Mark(fmt.Sprintf("%s: peer #%d down", round, ic.GlobalSeq))
+	ic.SyncClient.MustSignalEntry(ctx, downState)
+	time.Sleep(downtime)
+
+	cli, stop, err = startClient(desiredAddr, env, ic)
+	if err != nil {
+		return fmt.Errorf("failed to restart: %w", err)
+	}
+	start := time.Now()
+	meter.Mark(fmt.Sprintf("%s: peer #%d restarted", round, ic.GlobalSeq))
+	if thr, err = joinThread(ctx, cli, shared); err != nil {
+		return fmt.Errorf("failed to join thread again: %w", err)
+	}
+
+	expected := recordsToSend * env.TestInstanceCount
+	chCreated := make(chan int, env.TestInstanceCount)
+	ic.SyncClient.MustSubscribe(ctx, createdTopic, chCreated)
+	for i := 0; i < env.TestInstanceCount-churners; i++ {
+		select {
+		case n := <-chCreated:
+			expected += n
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for the records created during the downtime: %w", ctx.Err())
+		}
+	}
+
+	got, err := thr.waitForRecordCount(ctx, expected)
+	if err != nil {
+		return fmt.Errorf("Peer #%d caught up on %d records, expect %d: %w", ic.GlobalSeq, got, expected, err)
+	}
+	catchUp := time.Since(start)
+	meter.Mark(fmt.Sprintf("%s: peer #%d caught up", round, ic.GlobalSeq))
+	env.R().RecordPoint("round-"+round+"-catch-up-seconds", catchUp.Seconds())
+	msg("Peer #%d caught up on %d records in %v", ic.GlobalSeq, got, catchUp)
+	ic.SyncClient.MustSignalAndWait(ctx, sync.State("churn-done-"+round), env.TestInstanceCount)
+	return nil
+}
+
+// createRecordsFor creates records at rate per second for d, and returns how
+// many were created.
+func (t *threadWithKeys) createRecordsFor(ctx context.Context, rate float64, d time.Duration) (int, error) {
+	if rate <= 0 {
+		time.Sleep(d)
+		return 0, nil
+	}
+	tk := time.NewTicker(time.Duration(float64(time.Second) / rate))
+	defer tk.Stop()
+	deadline := time.After(d)
+	var created int
+	for {
+		select {
+		case <-tk.C:
+			if err := t.CreateRecords(ctx, 1); err != nil {
+				return created, err
+			}
+			created++
+		case <-deadline:
+			return created, nil
+		case <-ctx.Done():
+			return created, ctx.Err()
+		}
+	}
+}
+
+// waitForRecordCount polls the heads of all logs of the thread until they
+// add up to expected records, or ctx is done. It returns the last count.
+func (t *threadWithKeys) waitForRecordCount(ctx context.Context, expected int) (int, error) {
+	tk := time.NewTicker(100 * time.Millisecond)
+	defer tk.Stop()
+	var got int
+	for {
+		select {
+		case <-tk.C:
+			heads, err := t.GetHeads(ctx)
+			if err != nil {
+				return got, err
+			}
+			got = 0
+			for _, head := range heads {
+				got += int(head.Counter)
+			}
+			if got == expected {
+				return got, nil
+			}
+			debug("expect %d records, got %d, continuing", expected, got)
+		case <-ctx.Done():
+			return got, ctx.Err()
+		}
+	}
+}
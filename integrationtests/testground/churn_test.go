@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestChurnCount(t *testing.T) {
+	for _, c := range []struct {
+		instances int
+		fraction  float64
+		expected  int
+	}{
+		{10, 0.2, 2},
+		{5, 0.5, 3},
+		{1, 0.5, 0},
+		{3, 1, 2},
+		{4, 0, 0},
+		{4, -1, 0},
+	} {
+		if n := churnCount(c.instances, c.fraction); n != c.expected {
+			t.Fatalf("expected %d of %d instances to churn with fraction %v, got %d", c.expected, c.instances, c.fraction, n)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// export writes every recorded sample under the test outputs path, as a CSV
+// file with one row per sample followed by one per marker, as a JSON document keyed by metric name, and
+// in the InfluxDB line protocol. The samples of rates come with the raw delta
+// of the counter in the CSV and JSON files.
+func (p *measure) export() error {
+	base := filepath.Join(p.runenv.TestOutputsPath, fmt.Sprintf("measurements-%d", p.instanceSeq))
+	if err := p.exportSamples(base); err != nil {
+		return err
+	}
+	return p.exportInflux(base + ".influx")
+}
+
+func (p *measure) exportSamples(base string) error {
+	type sample struct {
+		Timestamp time.Time `json:"timestamp"`
+		Value     float64   `json:"value"`
+		Delta     *float64  `json:"delta,omitempty"`
+	}
+
+	f, err := os.Create(base + ".csv")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"timestamp", "metric", "value", "label", "delta"}); err != nil {
+		return err
+	}
+	doc := make(map[string][]sample, len(p.metrics))
+	for name, values := range p.metrics {
+		samples := make([]sample, len(values))
+		deltas := p.deltas[name]
+		for i, v := range values {
+			ts := p.timestamps[name][i]
+			samples[i] = sample{Timestamp: ts, Value: v}
+			var delta string
+			if i < len(deltas) {
+				samples[i].Delta = &deltas[i]
+				delta = strconv.FormatFloat(deltas[i], 'f', -1, 64)
+			}
+			if err := w.Write([]string{
+				ts.Format(time.RFC3339Nano),
+				name,
+				strconv.FormatFloat(v, 'f', -1, 64),
+				"",
+				delta,
+			}); err != nil {
+				return err
+			}
+		}
+		doc[name] = samples
+	}
+	for _, m := range p.markersSnapshot() {
+		if err := w.Write([]string{m.at.Format(time.RFC3339Nano), markerMetric, "", m.label, ""}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(base+".json", data, 0644)
+}
+
+// exportInflux writes every sample as an InfluxDB line protocol point, at
+// the time it was collected, unlike the gauges which are timestamped when
+// the testground collector scrapes them. The file can be loaded with e.g.
+// "influx write". Points are tagged like the metrics of the runenv.
+func (p *measure) exportInflux(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	tags := fmt.Sprintf("plan=%s,case=%s,run=%s,group_id=%s,instance=%d",
+		escapeInfluxTag(p.runenv.TestPlan),
+		escapeInfluxTag(p.runenv.TestCase),
+		escapeInfluxTag(p.runenv.TestRun),
+		escapeInfluxTag(p.runenv.TestGroupID),
+		p.instanceSeq)
+	for name, values := range p.metrics {
+		measurement := escapeInfluxMeasurement("measure." + name)
+		for i, v := range values {
+			if _, err := fmt.Fprintf(w, "%s,%s value=%s %d\n",
+				measurement, tags,
+				strconv.FormatFloat(v, 'f', -1, 64),
+				p.timestamps[name][i].UnixNano()); err != nil {
+				return err
+			}
+		}
+	}
+	return w.Flush()
+}
+
+var (
+	influxMeasurementEscaper = strings.NewReplacer(",", "\\,", " ", "\\ ")
+	influxTagEscaper         = strings.NewReplacer(",", "\\,", " ", "\\ ", "=", "\\=")
+)
+
+func escapeInfluxMeasurement(s string) string {
+	return influxMeasurementEscaper.Replace(s)
+}
+
+func escapeInfluxTag(s string) string {
+	return influxTagEscaper.Replace(s)
+}
@@ -14,6 +14,7 @@ require (
 	github.com/multiformats/go-multihash v0.0.15
 	github.com/prometheus/client_golang v1.11.0
 	github.com/prometheus/client_model v0.2.0
+	github.com/prometheus/common v0.26.0
 	github.com/prometheus/node_exporter v1.1.2
 	github.com/testground/sdk-go v0.2.7
 	github.com/textileio/go-threads v1.1.0-rc1.0.20210821175230-b925bde5b101
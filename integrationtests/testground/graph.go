@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/guptarohit/asciigraph"
+	"github.com/testground/sdk-go/runtime"
+)
+
+// graphSize holds the dimensions of the printed graphs, in characters.
+type graphSize struct {
+	width  int
+	height int
+	offset int
+}
+
+var defaultGraphSize = graphSize{width: 100, height: 10, offset: 10}
+
+// graphSizeFromParams returns the graph size given by the "graph-width",
+// "graph-height" and "graph-offset" params, or the default for those not set.
+func graphSizeFromParams(runenv *runtime.RunEnv) graphSize {
+	size := defaultGraphSize
+	for param, dim := range map[string]*int{
+		"graph-width":  &size.width,
+		"graph-height": &size.height,
+		"graph-offset": &size.offset,
+	} {
+		if runenv.IsParamSet(param) {
+			if v := runenv.IntParam(param); v > 0 {
+				*dim = v
+			}
+		}
+	}
+	return size
+}
+
+// plot renders the named series as a line graph, with the number of samples
+// and the time they span in the caption.
+func (p *measure) plot(name string) string {
+	values := p.metrics[name]
+	timestamps := p.timestamps[name]
+	duration := timestamps[len(timestamps)-1].Sub(timestamps[0]).Round(time.Second)
+	caption := fmt.Sprintf("%s (%d samples over %v, sampled every %v)", name, len(values), duration, p.interval)
+	if len(values) > p.graph.width {
+		caption += fmt.Sprintf(", averaged into %d buckets", p.graph.width)
+	}
+	return asciigraph.Plot(
+		downsample(values, p.graph.width),
+		asciigraph.Caption(caption),
+		asciigraph.Width(p.graph.width),
+		asciigraph.Height(p.graph.height),
+		asciigraph.Offset(p.graph.offset))
+}
+
+// downsample averages values into width buckets if there are more values
+// than that, so spikes aren't silently dropped by the graph.
+func downsample(values []float64, width int) []float64 {
+	if width <= 0 || len(values) <= width {
+		return values
+	}
+	buckets := make([]float64, width)
+	for i := range buckets {
+		start := i * len(values) / width
+		end := (i + 1) * len(values) / width
+		var sum float64
+		for _, v := range values[start:end] {
+			sum += v
+		}
+		buckets[i] = sum / float64(end-start)
+	}
+	return buckets
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/testground/sdk-go/sync"
+	"github.com/textileio/go-threads/cbor"
+	corenet "github.com/textileio/go-threads/core/net"
+)
+
+const (
+	metricRecordLatencyMs = "record-latency-ms"
+
+	// the keys of the record body fields telling which instance created
+	// the record, and when.
+	bodyCreator   = "created-by"
+	bodyCreatedAt = "created-at"
+
+	// clockSyncSamples is how many clock readings each instance publishes.
+	clockSyncSamples = 5
+)
+
+// clockSample is a reading of the wall clock of an instance, as published
+// to the sync service.
+type clockSample struct {
+	Seq      int64
+	UnixNano int64
+}
+
+var clockTopic = sync.NewTopic("clock-samples", &clockSample{})
+
+// clockOffsets tells how far ahead of the local clock the clock of each
+// instance is, keyed by global sequence number.
+type clockOffsets struct {
+	seq     int64
+	offsets map[int64]time.Duration
+}
+
+// syncClocks estimates the clock offsets of all instances by exchanging
+// readings of their wall clocks through the sync service. A reading gets
+// delayed by the sync service on its way, so the offset is taken as the
+// largest difference between the sent and the received times, which comes
+// from the fastest delivery. The offsets of the instances which didn't
+// publish before ctx is done are missing. The returned offsets are never
+// nil, even along with an error.
+func syncClocks(ctx context.Context, client sync.Client, seq int64, instances int) (*clockOffsets, error) {
+	c := &clockOffsets{seq: seq, offsets: make(map[int64]time.Duration)}
+	ch := make(chan *clockSample, instances*clockSyncSamples)
+	sub, err := client.Subscribe(ctx, clockTopic, ch)
+	if err != nil {
+		return c, err
+	}
+	go func() {
+		for i := 0; i < clockSyncSamples; i++ {
+			if _, err := client.Publish(ctx, clockTopic, &clockSample{Seq: seq, UnixNano: time.Now().UnixNano()}); err != nil {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+	received := make(map[int64]int)
+	for complete := 0; complete < instances; {
+		select {
+		case s := <-ch:
+			offset := time.Unix(0, s.UnixNano).Sub(time.Now())
+			if prev, ok := c.offsets[s.Seq]; !ok || offset > prev {
+				c.offsets[s.Seq] = offset
+			}
+			received[s.Seq]++
+			if received[s.Seq] == clockSyncSamples {
+				complete++
+			}
+		case err := <-sub.Done():
+			return c, fmt.Errorf("receiving clock samples: %v", err)
+		case <-ctx.Done():
+			return c, fmt.Errorf("got the clocks of %d out of %d instances: %w", len(received), instances, ctx.Err())
+		}
+	}
+	// our own samples only took the sync service latency
+	c.offsets[seq] = 0
+	return c, nil
+}
+
+// localTime converts a time read from the clock of the given instance to
+// the local clock, assuming no offset if it's unknown.
+func (c *clockOffsets) localTime(seq int64, t time.Time) time.Time {
+	return t.Add(-c.offsets[seq])
+}
+
+// stampBody adds the fields telling the record was created by the instance
+// seq at the current time to a record body.
+func stampBody(obj map[string][]byte, seq int64) {
+	obj[bodyCreator] = []byte(strconv.FormatInt(seq, 10))
+	obj[bodyCreatedAt] = []byte(strconv.FormatInt(time.Now().UnixNano(), 10))
+}
+
+// recordCreation returns the instance which created rec, and when according
+// to its own clock, out of the body of the record.
+func (t *threadWithKeys) recordCreation(ctx context.Context, rec corenet.Record) (seq int64, createdAt time.Time, err error) {
+	obj, err := t.recordBody(ctx, rec)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	seq, err = strconv.ParseInt(string(obj[bodyCreator]), 10, 64)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("parsing %s: %w", bodyCreator, err)
+	}
+	ns, err := strconv.ParseInt(string(obj[bodyCreatedAt]), 10, 64)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("parsing %s: %w", bodyCreatedAt, err)
+	}
+	return seq, time.Unix(0, ns), nil
+}
+
+// recordBody decrypts and decodes the body of rec.
+func (t *threadWithKeys) recordBody(ctx context.Context, rec corenet.Record) (map[string][]byte, error) {
+	event, err := cbor.EventFromRecord(ctx, nil, rec)
+	if err != nil {
+		return nil, err
+	}
+	body, err := event.GetBody(ctx, nil, t.Key.Read())
+	if err != nil {
+		return nil, err
+	}
+	obj := make(map[string][]byte)
+	if err := ipldcbor.DecodeInto(body.RawData(), &obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// observeLatency records the time it took rec to arrive from the instance
+// which created it, if it's another one.
+func (t *threadWithKeys) observeLatency(ctx context.Context, rec corenet.Record, arrived time.Time) {
+	seq, createdAt, err := t.recordCreation(ctx, rec)
+	if err != nil {
+		debug("Failed to read the creation of record %v: %v", rec.Cid(), err)
+		return
+	}
+	if seq == clock.seq {
+		return
+	}
+	latency := arrived.Sub(clock.localTime(seq, createdAt))
+	meter.Observe(metricRecordLatencyMs, float64(latency)/float64(time.Millisecond))
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/testground/sdk-go/sync"
+)
+
+func TestSyncClocks(t *testing.T) {
+	client := sync.NewInmemClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// another instance whose clock is an hour ahead
+	skew := time.Hour
+	for i := 0; i < clockSyncSamples; i++ {
+		client.MustPublish(ctx, clockTopic, &clockSample{Seq: 2, UnixNano: time.Now().Add(skew).UnixNano()})
+	}
+	c, err := syncClocks(ctx, client, 1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if offset := c.offsets[2]; offset > skew || offset < skew-time.Second {
+		t.Fatalf("expected an offset of about %v, got %v", skew, offset)
+	}
+	createdAt := time.Now().Add(skew)
+	if local := c.localTime(2, createdAt); time.Since(local) > time.Second || time.Until(local) > time.Second {
+		t.Fatalf("expected the creation time on the local clock, got %v", local)
+	}
+
+	// a third instance never shows up
+	client = sync.NewInmemClient()
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	client.MustPublish(ctx, clockTopic, &clockSample{Seq: 2, UnixNano: time.Now().UnixNano()})
+	c, err = syncClocks(ctx, client, 1, 3)
+	if err == nil {
+		t.Fatalf("expected an error with a missing instance")
+	}
+	if _, ok := c.offsets[2]; !ok {
+		t.Fatalf("expected the offsets of the instances which showed up")
+	}
+}
+
+func TestMeasureObserve(t *testing.T) {
+	p, cleanup := newTestMeasure(t)
+	defer cleanup()
+	for i := 1; i <= 100; i++ {
+		p.Observe(metricRecordLatencyMs, float64(i))
+	}
+	names := p.seriesNames()
+	if names[len(names)-1] != metricRecordLatencyMs {
+		t.Fatalf("expected the observed series to be printed last, got %v", names)
+	}
+	if err := p.stopAndPrint(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := p.summaries[metricRecordLatencyMs]
+	if s.median != 50 || s.p95 != 95 || s.p99 != 99 {
+		t.Fatalf("unexpected summary: %+v", s)
+	}
+	p.Observe(metricRecordLatencyMs, 1000)
+	if n := len(p.metrics[metricRecordLatencyMs]); n != 100 {
+		t.Fatalf("expected samples observed after stop to be ignored, got %d samples", n)
+	}
+}
@@ -26,14 +26,17 @@ import (
 	ipldcbor "github.com/ipfs/go-ipld-cbor"
 	logging "github.com/ipfs/go-log/v2"
 	crypto "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/metrics"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/multiformats/go-multihash"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/testground/sdk-go/network"
 	"github.com/testground/sdk-go/run"
 	"github.com/testground/sdk-go/runtime"
 	sync "github.com/testground/sdk-go/sync"
 	"google.golang.org/grpc"
 
+	"github.com/textileio/go-threads/common"
 	corenet "github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/net/api"
@@ -41,6 +44,12 @@ import (
 	"github.com/textileio/go-threads/util"
 )
 
+const (
+	metricRecordsCreated  = "threads_records_created_total"
+	metricRecordsReceived = "threads_records_received_total"
+	metricRecordsFetched  = "threads_records_fetched_total"
+)
+
 var (
 	netSlow = network.LinkShape{
 		Latency:   time.Second,
@@ -66,10 +75,7 @@ var (
 		Loss:      40,
 	}
 
-	simulatedNetworks = []struct {
-		simulation string
-		shape      network.LinkShape
-	}{
+	simulatedNetworks = []simulatedNetwork{
 		{"normal", network.LinkShape{}},
 		{"slow", netSlow},
 		{"long-fat", netLongFat},
@@ -84,17 +90,57 @@ var (
 	msg   func(msg string, args ...interface{})
 	debug func(msg string, args ...interface{})
 	fail  func(msg string, args ...interface{})
+	// meter collects resource usage of the whole test instance.
+	meter *measure
+	// clock tells the clock offsets of the other instances, which are
+	// synced at setup.
+	clock *clockOffsets
+	// bandwidth counts the libp2p traffic of all the hosts started by the test instance.
+	bandwidth = metrics.NewBandwidthCounter()
+	// recordsCreated, recordsReceived and recordsFetched count the records the
+	// test instance created, got through its subscription and fetched by CID.
+	recordsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: metricRecordsCreated,
+		Help: "Records created by the test instance.",
+	})
+	recordsReceived = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: metricRecordsReceived,
+		Help: "Unique records received through the thread subscription.",
+	})
+	recordsFetched = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: metricRecordsFetched,
+		Help: "Records fetched by CID while traversing the logs.",
+	})
 )
 
 func main() {
-	m, err := startMeasure(runtime.CurrentRunEnv())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var err error
+	meter, err = startMeasure(ctx, runtime.CurrentRunEnv(), WithBandwidth(bandwidth))
 	if err != nil {
 		panic(err)
 	}
-	defer m.stopAndPrint()
+	defer func() { _ = meter.stopAndPrint() }()
+	for _, c := range []struct {
+		counter prometheus.Counter
+		name    string
+	}{
+		{recordsCreated, metricRecordsCreated},
+		{recordsReceived, metricRecordsReceived},
+		{recordsFetched, metricRecordsFetched},
+	} {
+		if err := meter.Register(c.counter, c.name); err != nil {
+			panic(err)
+		}
+	}
 	run.InvokeMap(map[string]interface{}{
-		"sync-threads": run.InitializedTestCaseFn(testSyncThreads),
+		"sync-threads":      run.InitializedTestCaseFn(testSyncThreads),
 		"bitswap-sync-race": run.InitializedTestCaseFn(testBitswapSyncRace),
+		"churn":             run.InitializedTestCaseFn(testChurn),
+		"payload-sweep":     run.InitializedTestCaseFn(testPayloadSweep),
+		"shaping-matrix":    run.InitializedTestCaseFn(testShapingMatrix),
+		"catch-up":          run.InitializedTestCaseFn(testCatchUp),
 	})
 }
 
@@ -106,11 +152,47 @@ func testBitswapSyncRace(env *runtime.RunEnv, ic *run.InitContext) (err error) {
 	return testMultipleRounds("testBitswapSyncRace", env, ic, testBitswapSyncRaceRound)
 }
 
+// simulatedNetwork is the shape of the links a round of a test case runs over.
+type simulatedNetwork struct {
+	simulation string
+	shape      network.LinkShape
+}
+
 func testMultipleRounds(
 	stateName string,
 	env *runtime.RunEnv,
 	ic *run.InitContext,
-	testRound func (context.Context, *runtime.RunEnv, *run.InitContext, string, string) error) (err error) {
+	testRound func(context.Context, *runtime.RunEnv, *run.InitContext, string, string) error) (err error) {
+	return testRounds(stateName, env, ic, simulatedNetworks, configureFirstNetwork, testRound)
+}
+
+// configureFirstNetwork shapes the links of the first instance only.
+func configureFirstNetwork(ctx context.Context, env *runtime.RunEnv, ic *run.InitContext, n simulatedNetwork) {
+	if ic.GlobalSeq != 1 {
+		return
+	}
+	round := n.simulation
+	msg("################### Round %s network ###################", round)
+	ic.NetClient.MustConfigureNetwork(ctx, &network.Config{
+		Network:        "default",
+		Enable:         true,
+		Default:        n.shape,
+		CallbackState:  sync.State("network-configured-" + round),
+		CallbackTarget: 1,
+		RoutingPolicy:  network.AllowAll,
+	})
+	msg("Done configuring %s network", round)
+}
+
+// testRounds runs testRound over each of the networks in turn, configured by
+// configure, and stops measuring once all instances succeed.
+func testRounds(
+	stateName string,
+	env *runtime.RunEnv,
+	ic *run.InitContext,
+	networks []simulatedNetwork,
+	configure func(context.Context, *runtime.RunEnv, *run.InitContext, simulatedNetwork),
+	testRound func(context.Context, *runtime.RunEnv, *run.InitContext, string, string) error) (err error) {
 	msg = func(msg string, args ...interface{}) {
 		env.RecordMessage(msg, args...)
 	}
@@ -123,30 +205,20 @@ func testMultipleRounds(
 		env.RecordFailure(fmt.Errorf(msg, args...))
 	}
 	setup(env, ic)
-	successState := sync.State(stateName+"-success")
-	failState := sync.State(stateName+"-fail")
+	meter.setInstanceSeq(ic.GlobalSeq)
+	successState := sync.State(stateName + "-success")
+	failState := sync.State(stateName + "-fail")
 	barrierSuccess := ic.SyncClient.MustBarrier(context.Background(), successState, env.TestInstanceCount)
 	barrierFail := ic.SyncClient.MustBarrier(context.Background(), failState, 1)
 	go func() {
-		for i, pair := range simulatedNetworks {
-			round := pair.simulation
+		for i, n := range networks {
+			round := n.simulation
 			timeout, err := time.ParseDuration(env.StringParam("test-timeout"))
 			if err != nil {
 				timeout = time.Minute
 			}
 			ctx, cancel := context.WithTimeout(context.Background(), timeout)
-			if ic.GlobalSeq == 1 {
-				msg("################### Round %s network ###################", round)
-				ic.NetClient.MustConfigureNetwork(ctx, &network.Config{
-					Network:        "default",
-					Enable:         true,
-					Default:        pair.shape,
-					CallbackState:  sync.State("network-configured-" + round),
-					CallbackTarget: 1,
-					RoutingPolicy:  network.AllowAll,
-				})
-				msg("Done configuring %s network", round)
-			}
+			configure(ctx, env, ic, n)
 
 			desiredAddr := ""
 			if env.TestSidecar {
@@ -154,7 +226,10 @@ func testMultipleRounds(
 				ip := ic.NetClient.MustGetDataNetworkIP().String()
 				desiredAddr = fmt.Sprintf("/ip4/%s/tcp/%d", ip, 3000+i)
 			}
-			if err = testRound(ctx, env, ic, round, desiredAddr); err != nil {
+			meter.StartPhase(round)
+			err = testRound(ctx, env, ic, round, desiredAddr)
+			meter.EndPhase()
+			if err != nil {
 				msg("################### Peer #%d with %s network failed: %v ###################", ic.GlobalSeq, round, err)
 				ic.SyncClient.MustSignalEntry(ctx, failState)
 				cancel()
@@ -169,7 +244,13 @@ func testMultipleRounds(
 	// wait for either all instances to succeed, or any instance to fail
 	select {
 	case <-barrierSuccess.C:
-		return
+		// stop measuring before the test result is recorded, so that
+		// breached thresholds fail the test.
+		err = meter.stopAndPrint()
+		if aggErr := meter.Aggregate(context.Background(), ic.SyncClient, env.TestInstanceCount); aggErr != nil {
+			msg("WARNING: Failed to aggregate the measurements: %v", aggErr)
+		}
+		return err
 	case <-barrierFail.C:
 		return
 	}
@@ -331,11 +412,13 @@ func testSyncThreadsRound(ctx context.Context, env *runtime.RunEnv, ic *run.Init
 		msg("Joined thread")
 
 		ic.SyncClient.MustSignalAndWait(ctx, sync.State("ready-"+round+"-phase1"), livePeers)
+		meter.Mark(round + ": all peers joined thread")
 		start := time.Now()
 		donePhase1 := sync.State("done-" + round + "-phase1")
 		go func() {
-			_ = thr.WaitForRecords(ctx, recordsToReceive)
+			records := thr.WaitForRecords(ctx, recordsToReceive)
 			env.R().RecordPoint("round-"+round+"-phase-1-elapsed-seconds", time.Since(start).Seconds())
+			env.R().RecordPoint("round-"+round+"-phase-1-lost-records", float64(recordsToReceive-len(records)))
 			msg("Peer #%d done %s network phase 1, received %d records", ic.GlobalSeq, round, recordsToReceive)
 			ic.SyncClient.MustSignalAndWait(ctx, donePhase1, livePeers)
 		}()
@@ -346,6 +429,7 @@ func testSyncThreadsRound(ctx context.Context, env *runtime.RunEnv, ic *run.Init
 		msg("Peer #%d created %d records", ic.GlobalSeq, recordsToSend)
 		// wait until all live peers get correct results
 		<-ic.SyncClient.MustBarrier(ctx, donePhase1, livePeers).C
+		meter.Mark(round + ": all peers received phase 1 records")
 	}
 
 	meLive = 1
@@ -395,6 +479,7 @@ func testSyncThreadsRound(ctx context.Context, env *runtime.RunEnv, ic *run.Init
 	for i := 0; i < env.TestInstanceCount; i++ {
 		expectedHeads[<-chCurrentHead] = true
 	}
+	meter.Mark(round + ": started verification")
 	var heads []thread.Head
 	tk := time.NewTicker(10 * time.Millisecond)
 	defer tk.Stop()
@@ -463,11 +548,19 @@ func setup(env *runtime.RunEnv, ic *run.InitContext) {
 			_ = http.Serve(l, nil)
 		}()
 	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	var err error
+	clock, err = syncClocks(ctx, ic.SyncClient, ic.GlobalSeq, env.TestInstanceCount)
+	if err != nil {
+		msg("WARNING: Failed to sync clocks, record latencies may be skewed: %v", err)
+	}
 }
 
 func startClient(desiredAddr string, env *runtime.RunEnv, ic *run.InitContext) (*client.Client, func(), error) {
 	// starts the API server and client
-	hostAddr, gRPCAddr, shutdown, err := api.CreateTestService(desiredAddr, env.IntParam("verbose") > 1)
+	hostAddr, gRPCAddr, shutdown, err := api.CreateTestService(desiredAddr, env.IntParam("verbose") > 1,
+		common.WithNetBandwidthReporter(bandwidth))
 	if err != nil {
 		return nil, func() {}, err
 	}
@@ -576,30 +669,43 @@ func (t *threadWithKeys) Sharable() *SharedInfo {
 	return &SharedInfo{t.Addrs[0].String(), t.Key.String(), t.logHead, t.ID}
 }
 
-// WaitForRecords blocks until it receives nRecords, then return them
+// WaitForRecords blocks until it receives nRecords or ctx is done, then return them.
+// The time each record took to arrive from another instance is measured along the way.
 func (t *threadWithKeys) WaitForRecords(ctx context.Context, nRecords int) (records []corenet.Record) {
 	msg("Waiting for %d unique records", nRecords)
-	for record := range t.subscribeCh {
-		rec := record.Value()
-		if _, exists := t.seenRecords[rec.Cid()]; exists {
-			debug("duplicated record %v", rec)
-			continue
-		}
-		t.seenRecords[rec.Cid()] = true
-		records = append(records, record.Value())
-		debug("Got record #%d: %v", len(records), rec)
-		if len(records) == nRecords {
-			msg("Got all %d records", nRecords)
+	for {
+		select {
+		case record, ok := <-t.subscribeCh:
+			if !ok {
+				return
+			}
+			arrived := time.Now()
+			rec := record.Value()
+			if _, exists := t.seenRecords[rec.Cid()]; exists {
+				debug("duplicated record %v", rec)
+				continue
+			}
+			t.seenRecords[rec.Cid()] = true
+			recordsReceived.Inc()
+			t.observeLatency(ctx, rec, arrived)
+			records = append(records, record.Value())
+			debug("Got record #%d: %v", len(records), rec)
+			if len(records) == nRecords {
+				msg("Got all %d records", nRecords)
+				return
+			}
+		case <-ctx.Done():
+			msg("Lost %d out of %d records", nRecords-len(records), nRecords)
 			return
 		}
 	}
-	return
 }
 
 func (t *threadWithKeys) CreateRecords(ctx context.Context, num int) error {
 	for i := 0; i < num; i++ {
 		obj := make(map[string][]byte)
 		fuzzer.Fuzz(&obj)
+		stampBody(obj, clock.seq)
 		body, err := ipldcbor.WrapObject(obj, multihash.SHA2_256, -1)
 		if err != nil {
 			return err
@@ -608,6 +714,7 @@ func (t *threadWithKeys) CreateRecords(ctx context.Context, num int) error {
 		if err != nil {
 			return err
 		}
+		recordsCreated.Inc()
 		debug("Created record #%d: %v", i+1, rec)
 		t.logHead = rec.Value().Cid()
 	}
@@ -633,6 +740,7 @@ func (t *threadWithKeys) GetRecords(ctx context.Context, head cid.Cid) (recs []c
 		if err != nil {
 			return nil, err
 		}
+		recordsFetched.Inc()
 		recs = append(recs, rec)
 		debug("Got record #%d: %v", len(recs), rec)
 		rid = rec.PrevID()
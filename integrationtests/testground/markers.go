@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// markerMetric is the metric name of the marker rows in the CSV export.
+const markerMetric = "marker"
+
+// marker labels a point in time of the test run, e.g., all peers joined the
+// thread, to be matched with the samples around it.
+type marker struct {
+	label string
+	at    time.Time
+}
+
+// Mark records a marker with the given label at the current time. It's
+// printed in the legend of each graph along with the index of the sample
+// collected right after it. Marks after Stop are ignored.
+func (p *measure) Mark(label string) {
+	if p.stopped() {
+		p.runenv.RecordMessage("WARNING: Ignoring marker %q after the measure stopped", label)
+		return
+	}
+	p.stateLock.Lock()
+	defer p.stateLock.Unlock()
+	p.markers = append(p.markers, marker{label: label, at: time.Now()})
+}
+
+// markersSnapshot returns a copy of the markers recorded so far.
+func (p *measure) markersSnapshot() []marker {
+	p.stateLock.Lock()
+	defer p.stateLock.Unlock()
+	markers := make([]marker, len(p.markers))
+	copy(markers, p.markers)
+	return markers
+}
+
+// markerLegend renders the markers against the samples of the named series,
+// or an empty string if there's none.
+func (p *measure) markerLegend(name string, markers []marker) string {
+	if len(markers) == 0 {
+		return ""
+	}
+	timestamps := p.timestamps[name]
+	output := "\nMarkers:"
+	for _, m := range markers {
+		i := sort.Search(len(timestamps), func(i int) bool {
+			return !timestamps[i].Before(m.at)
+		})
+		var sampleNo string
+		switch {
+		case i == len(timestamps):
+			sampleNo = "after last sample"
+		case i == 0 && timestamps[0].After(m.at):
+			sampleNo = "before first sample"
+		default:
+			sampleNo = fmt.Sprintf("sample #%d", i+1)
+		}
+		output += fmt.Sprintf("\n  %-20s %10v  %s", sampleNo, m.at.Sub(timestamps[0]).Round(time.Millisecond), m.label)
+	}
+	return output
+}
+
+// recordMarkers sends the markers as runenv messages, with the time elapsed
+// since the first sample.
+func (p *measure) recordMarkers(markers []marker) {
+	var start time.Time
+	if timestamps := p.timestamps[metricGoroutines]; len(timestamps) > 0 {
+		start = timestamps[0]
+	}
+	for _, m := range markers {
+		if start.IsZero() {
+			p.runenv.RecordMessage("Marker %q, before any sample", m.label)
+			continue
+		}
+		p.runenv.RecordMessage("Marker %q at %v", m.label, m.at.Sub(start).Round(time.Millisecond))
+	}
+}
@@ -1,151 +1,732 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"regexp"
 	goruntime "runtime"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
-	"github.com/go-kit/kit/log"
-	"github.com/guptarohit/asciigraph"
+	"github.com/libp2p/go-libp2p-core/metrics"
 	"github.com/prometheus/client_golang/prometheus"
-	dto "github.com/prometheus/client_model/go"
-	"github.com/prometheus/node_exporter/collector"
 	"github.com/testground/sdk-go/runtime"
-	kingpin "gopkg.in/alecthomas/kingpin.v2"
 )
 
 type measure struct {
-	runenv       *runtime.RunEnv
-	registry     *prometheus.Registry
-	chStop       chan struct{}
-	metrics      map[string][]float64
-	lastRecv     float64
-	lastTransmit float64
+	runenv   *runtime.RunEnv
+	registry *prometheus.Registry
+	sampler  sampler
+	interval time.Duration
+	graph    graphSize
+	// netIfaces are the network devices counted toward the aggregate
+	// receive/transmit bytes. All but the excluded ones if empty.
+	netIfaces map[string]bool
+	// netExclude matches the network devices left out entirely, loopback
+	// and virtual ones by default.
+	netExclude *regexp.Regexp
+	// bandwidth reports the libp2p traffic per protocol, if not nil.
+	bandwidth metrics.Reporter
+	// profiles, cpuProfile and profileHeapMiBs configure the profiles
+	// captured, see measureConfig.
+	profiles        bool
+	cpuProfile      time.Duration
+	profileHeapMiBs float64
+	// registryDumpTicks is how many ticks apart the registries are dumped,
+	// 0 to only dump them at stop.
+	registryDumpTicks int
+	// cores is the number of CPUs of the node, which the CPU usage is
+	// normalized by.
+	cores int
+	// nodeMemory tells whether to record the node-wide active memory, which
+	// includes other processes on the node.
+	nodeMemory bool
+	// collectors holds the collectors registered by the test, kept apart
+	// from registry so they can be gathered without the node_exporter ones.
+	collectors *prometheus.Registry
+
+	chStop chan struct{}
+	// done is closed when Collect returns.
+	done     chan struct{}
+	stopOnce sync.Once
+	// started tells whether Collect was run, guarded by stateLock.
+	started   bool
+	printOnce sync.Once
+	printErr  error
+	// summaries are the statistics printed by Print.
+	summaries map[string]summary
+
+	// lock guards the series and the counter baselines, which are written
+	// by Collect.
+	lock                sync.Mutex
+	metrics             map[string][]float64
+	timestamps          map[string][]time.Time
+	instanceSeq         int64
+	lastRecv            float64
+	lastTransmit        float64
+	lastRecvByDev       map[string]float64
+	lastTransmitByDev   map[string]float64
+	lastDiskRead        float64
+	lastDiskWrite       float64
+	lastGCPauseNs       uint64
+	lastCPUSeconds      float64
+	lastMajorFaults     float64
+	peakProfiled        bool
+	swapWarned          bool
+	lastProtoRecv       float64
+	lastProtoTransmit   float64
+	lastRecvByProto     map[string]float64
+	lastTransmitByProto map[string]float64
+	// deltas are the raw counter deltas the samples of the rate series
+	// were computed from, aligned with them.
+	deltas map[string][]float64
+	// lastGather is when the OS counters were last sampled, and elapsed
+	// the time since then as of the current tick.
+	lastGather time.Time
+	elapsed    time.Duration
+	// tracked lists the metrics of the registered collectors to record, in
+	// the order they are printed, and trackedRates the counters among them.
+	tracked      []string
+	trackedRates map[string]bool
+	lastTracked  map[string]float64
+	// observed lists the series of the samples given to Observe, in the
+	// order they are printed.
+	observed []string
+
+	// stateLock guards the phases, the markers and the pause state, which
+	// are changed by the test while Collect is running.
+	stateLock sync.Mutex
+	phases    []phase
+	markers   []marker
+	paused    bool
+	reseed    bool
+
+	// errLock guards the gather error state, which is read by the test
+	// through Err while Collect is running.
+	errLock          sync.Mutex
+	gatherErrors     int
+	consecutiveFails int
+	lastGatherErr    error
 }
 
 const (
-	metricGoroutines    = "goroutines"
-	metricHeapAllocMiBs = "heap-alloc-mibs"
-	metricRecvBytes     = "receive-bytes"
-	metricTransmitBytes = "transmit-bytes"
+	metricCPUSeconds         = "cpu-seconds"
+	metricCPUPercent         = "cpu-percent"
+	metricGoroutines         = "goroutines"
+	metricHeapAllocMiBs      = "heap-alloc-mibs"
+	metricGCPauseMs          = "gc-pause-ms"
+	metricProcessRSSMiBs     = "process-rss-mibs"
+	metricOpenFDs            = "open-fds"
+	metricTCPConns           = "tcp-conns"
+	metricActiveMemoryMiBs   = "active-memory-mibs"
+	metricSwapUsedMiBs       = "swap-used-mibs"
+	metricMajorFaults        = "major-faults"
+	metricRecvBytes          = "receive-bytes"
+	metricTransmitBytes      = "transmit-bytes"
+	metricDiskReadBytes      = "disk-read-bytes"
+	metricDiskWriteBytes     = "disk-write-bytes"
+	metricProtoRecvBytes     = "libp2p-receive-bytes"
+	metricProtoTransmitBytes = "libp2p-transmit-bytes"
 )
 
-// startMeasure starts collecting number of goroutines, golang heap allocation and transmit/receive bytes every second, until stopAndPrint is called on the returned measure, at which point it sends all the recorded metrics as test result to InfluxDB, and prints them as line graphs for inspection.
-func startMeasure(runenv *runtime.RunEnv) (*measure, error) {
-	// have to do this because node_exporter requires it being called to properly initialize global variables.
-	kingpin.Parse()
-	logger := log.NewNopLogger()
-	registry := prometheus.NewRegistry()
-	collector.DisableDefaultCollectors()
-	nodeCollector, err := collector.NewNodeCollector(logger)
-	if err != nil {
-		return nil, err
-	}
-	netCollector, err := collector.NewNetDevCollector(logger)
+const defaultMeasureInterval = time.Second
+
+// defaultNetExclude matches the loopback, docker bridge and veth devices.
+const defaultNetExclude = "^(lo|docker|veth)"
+
+// gatherErrorLogEvery is how many gather errors occur between two log messages.
+const gatherErrorLogEvery = 10
+
+// measuredMetrics lists the metrics in the order they are printed.
+var measuredMetrics = []string{
+	metricCPUSeconds,
+	metricCPUPercent,
+	metricGoroutines,
+	metricHeapAllocMiBs,
+	metricGCPauseMs,
+	metricProcessRSSMiBs,
+	metricActiveMemoryMiBs,
+	metricSwapUsedMiBs,
+	metricMajorFaults,
+	metricOpenFDs,
+	metricTCPConns,
+	metricRecvBytes,
+	metricTransmitBytes,
+	metricDiskReadBytes,
+	metricDiskWriteBytes,
+	metricProtoRecvBytes,
+	metricProtoTransmitBytes,
+}
+
+// rateMetrics are the metrics recorded as per-second rates of a counter, for
+// which the total over the run is reported as well.
+var rateMetrics = map[string]bool{
+	metricCPUSeconds:         true,
+	metricGCPauseMs:          true,
+	metricMajorFaults:        true,
+	metricRecvBytes:          true,
+	metricTransmitBytes:      true,
+	metricDiskReadBytes:      true,
+	metricDiskWriteBytes:     true,
+	metricProtoRecvBytes:     true,
+	metricProtoTransmitBytes: true,
+}
+
+// startMeasure starts collecting node CPU usage, number of goroutines, golang heap allocation, GC pauses, process RSS, node swap usage and major page faults, open file descriptors, TCP connections, transmit/receive bytes, disk read/write bytes and, if a bandwidth reporter is given, libp2p traffic per protocol every "metrics-interval-ms" milliseconds (1s if not set), until ctx is done or Stop is called on the returned measure. Print then sends all the recorded metrics as test result to InfluxDB, and prints them as line graphs for inspection. The options take precedence over the test instance params.
+func startMeasure(ctx context.Context, runenv *runtime.RunEnv, opts ...MeasureOption) (*measure, error) {
+	c, err := newMeasureConfig(runenv, opts...)
 	if err != nil {
 		return nil, err
 	}
-	nodeCollector.Collectors["net"] = netCollector
-	registry.MustRegister(nodeCollector)
-	p := &measure{runenv: runenv, registry: registry,
-		chStop:  make(chan struct{}),
-		metrics: make(map[string][]float64),
+	smp := c.newSampler(runenv)
+	p := newMeasure(runenv, c, smp)
+	p.cores = cpuCount(smp)
+	p.start(ctx)
+	return p, nil
+}
+
+// newMeasure returns a measure sampling the OS counters with smp, configured
+// by c. Call start to begin collecting.
+func newMeasure(runenv *runtime.RunEnv, c measureConfig, smp sampler) *measure {
+	return &measure{runenv: runenv, registry: c.registry, sampler: smp,
+		chStop:              make(chan struct{}),
+		done:                make(chan struct{}),
+		interval:            c.interval,
+		graph:               c.graph,
+		metrics:             make(map[string][]float64),
+		timestamps:          make(map[string][]time.Time),
+		deltas:              make(map[string][]float64),
+		netIfaces:           c.netIfaces,
+		netExclude:          c.netExclude,
+		bandwidth:           c.bandwidth,
+		cores:               goruntime.NumCPU(),
+		nodeMemory:          c.nodeMemory,
+		profiles:            c.profiles,
+		cpuProfile:          c.cpuProfile,
+		profileHeapMiBs:     c.profileHeapMiBs,
+		registryDumpTicks:   c.registryDumpTicks,
+		lastRecvByDev:       make(map[string]float64),
+		lastTransmitByDev:   make(map[string]float64),
+		lastRecvByProto:     make(map[string]float64),
+		lastTransmitByProto: make(map[string]float64),
+		collectors:          prometheus.NewRegistry(),
+		trackedRates:        make(map[string]bool),
+		lastTracked:         make(map[string]float64),
 	}
+}
+
+// start runs Collect in the background.
+func (p *measure) start(ctx context.Context) {
+	p.stateLock.Lock()
+	p.started = true
+	p.stateLock.Unlock()
 	go func() {
-		p.Collect()
+		p.Collect(ctx)
 	}()
-
-	return p, nil
 }
 
-func (p *measure) Collect() {
-	tk := time.NewTicker(time.Second)
+// Collect samples all metrics every interval, until either ctx is done or
+// Stop is called.
+func (p *measure) Collect(ctx context.Context) {
+	defer close(p.done)
+	tk := time.NewTicker(p.interval)
+	defer tk.Stop()
+	var ticks int
 	for {
 		select {
 		case <-tk.C:
-			mf, err := p.registry.Gather()
-			if err != nil {
-				panic(err)
+			// the ticker may fire late under load, so take the actual
+			// time of the gather, which the rates are computed over
+			ts := time.Now()
+			skip, reseed := p.skipTick()
+			if skip {
+				continue
+			}
+			c, err := p.sampler.sample()
+			if terr := p.collect(ts, c, reseed); err == nil {
+				err = terr
 			}
-			for _, m := range mf {
-				switch *m.Name {
-				case "node_network_receive_bytes_total":
-					p.collectRecv(m.Metric)
-				case "node_network_transmit_bytes_total":
-					p.collectTransmit(m.Metric)
-				}
+			p.gathered(err)
+			ticks++
+			if p.registryDumpTicks > 0 && ticks%p.registryDumpTicks == 0 {
+				p.lock.Lock()
+				name := registryDumpName(p.instanceSeq, ticks)
+				p.lock.Unlock()
+				go p.dumpRegistryWithin(name, registryDumpTimeout)
 			}
-			p.collectGoroutines()
-			p.collectMemStats()
 		case <-p.chStop:
 			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// collect records the samples of a tick, out of the OS counters c, the
+// process metrics and the tracked metrics. It returns the error gathering the
+// latter, if any.
+func (p *measure) collect(ts time.Time, c counters, reseed bool) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if reseed {
+		p.resetBaselines()
+	}
+	p.elapsed = p.interval
+	if !p.lastGather.IsZero() {
+		p.elapsed = ts.Sub(p.lastGather)
+	}
+	p.lastGather = ts
+	for metric, byDev := range c {
+		switch metric {
+		case metricRecvBytes:
+			p.collectRecv(ts, byDev)
+		case metricTransmitBytes:
+			p.collectTransmit(ts, byDev)
+		case metricDiskReadBytes:
+			p.collectDiskRead(ts, byDev)
+		case metricDiskWriteBytes:
+			p.collectDiskWrite(ts, byDev)
+		case metricActiveMemoryMiBs:
+			if p.nodeMemory {
+				p.collectActiveMemory(ts, byDev)
+			}
+		case metricMajorFaults:
+			p.collectMajorFaults(ts, byDev)
+		case metricCPUSeconds:
+			p.collectCPU(ts, byDev)
 		}
 	}
+	if total, free := c[counterSwapTotal], c[counterSwapFree]; total != nil && free != nil {
+		p.collectSwap(ts, total, free)
+	}
+	p.collectGoroutines(ts)
+	p.collectMemStats(ts)
+	p.collectProcessRSS(ts)
+	p.collectOpenFDs(ts)
+	p.collectTCPConns(ts)
+	p.collectProtocols(ts)
+	return p.collectTracked(ts)
+}
+
+// gathered keeps track of the result of sampling the OS counters.
+func (p *measure) gathered(err error) {
+	p.errLock.Lock()
+	defer p.errLock.Unlock()
+	if err == nil {
+		p.consecutiveFails = 0
+		return
+	}
+	p.gatherErrors++
+	p.consecutiveFails++
+	p.lastGatherErr = err
+	if p.gatherErrors%gatherErrorLogEvery == 1 {
+		p.runenv.RecordMessage("WARNING: Failed to gather metrics (%d times so far): %v", p.gatherErrors, err)
+	}
+}
+
+// Err returns the number of consecutive failures gathering metrics up to the
+// last tick, along with the last error. The caller can use it to decide
+// whether measurement is reliable enough for the test to pass.
+func (p *measure) Err() (consecutive int, err error) {
+	p.errLock.Lock()
+	defer p.errLock.Unlock()
+	if p.consecutiveFails == 0 {
+		return 0, nil
+	}
+	return p.consecutiveFails, p.lastGatherErr
+}
+
+// record appends a sample to the named series, along with the time it was collected at.
+func (p *measure) record(name string, ts time.Time, value float64) {
+	p.metrics[name] = append(p.metrics[name], value)
+	p.timestamps[name] = append(p.timestamps[name], ts)
+}
+
+// histogramReservoirSize is the number of samples kept by each histogram.
+const histogramReservoirSize = 1028
+
+// recordRate records the per-second rate of a counter which grew by delta
+// since the last tick, along with the delta itself, and returns the rate.
+func (p *measure) recordRate(name string, ts time.Time, delta float64) float64 {
+	rate := p.perSecond(delta)
+	p.record(name, ts, rate)
+	p.report(name, rate)
+	p.deltas[name] = append(p.deltas[name], delta)
+	return rate
+}
+
+// report sends a sample to InfluxDB, both as a gauge under the metric name and
+// into a histogram under the name suffixed with "-hist", so that the
+// distribution over the run can be queried.
+func (p *measure) report(name string, value float64) {
+	p.runenv.D().Gauge(name).Update(value)
+	p.runenv.D().Histogram(name+"-hist", p.runenv.D().NewUniformSample(histogramReservoirSize)).Update(int64(math.Round(value)))
+}
+
+// cpuCount returns the number of CPUs in the cpu-seconds counters of smp, or
+// the number of CPUs usable by the process if there's none.
+func cpuCount(smp sampler) int {
+	if c, err := smp.sample(); err == nil && len(c[metricCPUSeconds]) > 0 {
+		return len(c[metricCPUSeconds])
+	}
+	return goruntime.NumCPU()
 }
 
-func (p *measure) collectGoroutines() {
-	p.metrics[metricGoroutines] = append(p.metrics[metricGoroutines], float64(goruntime.NumGoroutine()))
+// collectCPU records the CPU seconds used by the node per second since the
+// last tick, both as is and as a percentage of all its CPUs, so runs on nodes
+// with different core counts are comparable.
+func (p *measure) collectCPU(ts time.Time, byCPU map[string]float64) {
+	var total float64
+	for _, v := range byCPU {
+		total += v
+	}
+	if p.lastCPUSeconds > 0 {
+		usage := p.recordRate(metricCPUSeconds, ts, total-p.lastCPUSeconds)
+		percent := usage / float64(p.cores) * 100
+		p.record(metricCPUPercent, ts, percent)
+		p.report(metricCPUPercent, percent)
+	}
+	p.lastCPUSeconds = total
+}
+
+func (p *measure) collectGoroutines(ts time.Time) {
+	goroutines := float64(goruntime.NumGoroutine())
+	p.record(metricGoroutines, ts, goroutines)
+	p.report(metricGoroutines, goroutines)
 }
 
-func (p *measure) collectMemStats() {
+// collectMemStats records the heap allocation, and the time spent in GC
+// stop-the-world pauses per second since the last tick.
+func (p *measure) collectMemStats(ts time.Time) {
 	var m goruntime.MemStats
 	goruntime.ReadMemStats(&m)
-	p.metrics[metricHeapAllocMiBs] = append(p.metrics[metricHeapAllocMiBs], float64(m.HeapAlloc)/1048576.0)
+	heapAlloc := float64(m.HeapAlloc) / 1048576.0
+	p.record(metricHeapAllocMiBs, ts, heapAlloc)
+	p.report(metricHeapAllocMiBs, heapAlloc)
+	p.checkPeakHeap(heapAlloc)
+	if p.lastGCPauseNs > 0 {
+		p.recordRate(metricGCPauseMs, ts, float64(m.PauseTotalNs-p.lastGCPauseNs)/float64(time.Millisecond))
+	}
+	p.lastGCPauseNs = m.PauseTotalNs
+}
+
+// collectActiveMemory records the node-wide active memory.
+func (p *measure) collectActiveMemory(ts time.Time, byDev map[string]float64) {
+	var total float64
+	for _, v := range byDev {
+		total += v
+	}
+	active := total / 1048576.0
+	p.record(metricActiveMemoryMiBs, ts, active)
+	p.report(metricActiveMemoryMiBs, active)
+}
+
+// collectSwap records the swap space used on the node, out of the total and
+// free swap. Any swap in use gets a warning, once, since swapping skews the
+// latencies beyond comparison with other runs.
+func (p *measure) collectSwap(ts time.Time, totalByDev, freeByDev map[string]float64) {
+	var total, free float64
+	for _, v := range totalByDev {
+		total += v
+	}
+	for _, v := range freeByDev {
+		free += v
+	}
+	used := (total - free) / 1048576.0
+	p.record(metricSwapUsedMiBs, ts, used)
+	p.report(metricSwapUsedMiBs, used)
+	if used > 0 && !p.swapWarned {
+		p.swapWarned = true
+		p.runenv.RecordMessage("WARNING: The node is swapping, %.2f MiB in use, latencies can't be compared with other runs", used)
+	}
+}
+
+// collectMajorFaults records the node-wide major page faults per second since
+// the last tick, which grow when memory pages are read back from disk.
+func (p *measure) collectMajorFaults(ts time.Time, byDev map[string]float64) {
+	var total float64
+	for _, v := range byDev {
+		total += v
+	}
+	if p.lastMajorFaults > 0 {
+		p.recordRate(metricMajorFaults, ts, total-p.lastMajorFaults)
+	}
+	p.lastMajorFaults = total
 }
 
-func (p *measure) collectRecv(metrics []*dto.Metric) {
-	total := p.collectBytes(metrics)
-	usage := total - p.lastRecv
+func (p *measure) collectRecv(ts time.Time, byDev map[string]float64) {
+	total, byDev := p.collectBytes(byDev)
 	if p.lastRecv > 0 {
-		p.metrics[metricRecvBytes] = append(p.metrics[metricRecvBytes], usage)
-		p.runenv.D().Gauge(metricRecvBytes).Update(usage)
+		p.recordRate(metricRecvBytes, ts, total-p.lastRecv)
 	}
 	p.lastRecv = total
+	p.collectByDevice(ts, metricRecvBytes, byDev, p.lastRecvByDev)
 }
 
-func (p *measure) collectTransmit(metrics []*dto.Metric) {
-	total := p.collectBytes(metrics)
-	usage := total - p.lastTransmit
+func (p *measure) collectTransmit(ts time.Time, byDev map[string]float64) {
+	total, byDev := p.collectBytes(byDev)
 	if p.lastTransmit > 0 {
-		p.metrics[metricTransmitBytes] = append(p.metrics[metricTransmitBytes], usage)
-		p.runenv.D().Gauge(metricTransmitBytes).Update(usage)
+		p.recordRate(metricTransmitBytes, ts, total-p.lastTransmit)
 	}
 	p.lastTransmit = total
+	p.collectByDevice(ts, metricTransmitBytes, byDev, p.lastTransmitByDev)
 }
 
-func (p *measure) collectBytes(metrics []*dto.Metric) float64 {
-	var total, exclude float64
-	for _, m := range metrics {
-		total += *m.Counter.Value
-		for _, label := range m.Label {
-			if *label.Name == "device" && *label.Value == "lo0" {
-				exclude += *m.Counter.Value
-			}
+// collectProtocols records the libp2p traffic, both in total and per protocol
+// in series named like "libp2p-receive-bytes//thread/0.0.1".
+func (p *measure) collectProtocols(ts time.Time) {
+	if p.bandwidth == nil {
+		return
+	}
+	recvByProto := make(map[string]float64)
+	transmitByProto := make(map[string]float64)
+	var recv, transmit float64
+	for proto, stats := range p.bandwidth.GetBandwidthByProtocol() {
+		recvByProto[string(proto)] = float64(stats.TotalIn)
+		transmitByProto[string(proto)] = float64(stats.TotalOut)
+		recv += float64(stats.TotalIn)
+		transmit += float64(stats.TotalOut)
+	}
+	if p.lastProtoRecv > 0 {
+		p.recordRate(metricProtoRecvBytes, ts, recv-p.lastProtoRecv)
+	}
+	if p.lastProtoTransmit > 0 {
+		p.recordRate(metricProtoTransmitBytes, ts, transmit-p.lastProtoTransmit)
+	}
+	p.lastProtoRecv = recv
+	p.lastProtoTransmit = transmit
+	p.collectByDevice(ts, metricProtoRecvBytes, recvByProto, p.lastRecvByProto)
+	p.collectByDevice(ts, metricProtoTransmitBytes, transmitByProto, p.lastTransmitByProto)
+}
+
+// collectByDevice records the per-device series of a network metric, named
+// like "receive-bytes/eth0". It's used for breaking down by protocol as well.
+func (p *measure) collectByDevice(ts time.Time, metric string, byDev, last map[string]float64) {
+	for dev, total := range byDev {
+		p.collectRate(ts, deviceMetric(metric, dev), total, last)
+	}
+}
+
+// collectRate records the per-second rate of the named counter since the last
+// tick, if it was seen then, and remembers its total in last, which is keyed
+// by series name.
+func (p *measure) collectRate(ts time.Time, name string, total float64, last map[string]float64) {
+	if prev, ok := last[name]; ok {
+		p.recordRate(name, ts, total-prev)
+	}
+	last[name] = total
+}
+
+func (p *measure) collectDiskRead(ts time.Time, byDev map[string]float64) {
+	total := p.collectDiskBytes(byDev)
+	if p.lastDiskRead > 0 {
+		p.recordRate(metricDiskReadBytes, ts, total-p.lastDiskRead)
+	}
+	p.lastDiskRead = total
+}
+
+func (p *measure) collectDiskWrite(ts time.Time, byDev map[string]float64) {
+	total := p.collectDiskBytes(byDev)
+	if p.lastDiskWrite > 0 {
+		p.recordRate(metricDiskWriteBytes, ts, total-p.lastDiskWrite)
+	}
+	p.lastDiskWrite = total
+}
+
+// perSecond normalizes a delta observed since the last tick to a per-second
+// rate by the actual time elapsed, so neither the sampling interval nor the
+// ticks firing late distort the graphs.
+func (p *measure) perSecond(delta float64) float64 {
+	if p.elapsed <= 0 {
+		return delta / p.interval.Seconds()
+	}
+	return delta / p.elapsed.Seconds()
+}
+
+// collectDiskBytes sums the counters of all block devices, leaving out loop
+// and ram devices so the result reflects the actual data disk.
+func (p *measure) collectDiskBytes(byDev map[string]float64) float64 {
+	var total float64
+	for dev, v := range byDev {
+		if strings.HasPrefix(dev, "loop") || strings.HasPrefix(dev, "ram") {
+			continue
 		}
+		total += v
+	}
+	return total
+}
+
+// collectBytes returns the sum of the counters of the network devices which
+// count toward the aggregate, along with the counter of each device.
+func (p *measure) collectBytes(counters map[string]float64) (float64, map[string]float64) {
+	var total float64
+	byDev := make(map[string]float64)
+	for dev, v := range counters {
+		if p.netExclude.MatchString(dev) {
+			continue
+		}
+		byDev[dev] = v
+		if len(p.netIfaces) == 0 || p.netIfaces[dev] {
+			total += v
+		}
+	}
+	return total, byDev
+}
+
+// deviceMetric returns the name of the per-device series of a metric.
+func deviceMetric(metric, dev string) string {
+	return metric + "/" + dev
+}
+
+// baseMetric strips the device, if any, from the name of a series.
+func baseMetric(name string) string {
+	return strings.SplitN(name, "/", 2)[0]
+}
+
+// setInstanceSeq sets the global sequence number of the test instance, which
+// is used to name the exported sample files.
+func (p *measure) setInstanceSeq(seq int64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.instanceSeq = seq
+}
+
+// Stop stops collecting metrics, and waits for the in-flight tick, if any,
+// to be recorded. It's safe to call it any number of times, concurrently,
+// and after the context given to startMeasure is done.
+func (p *measure) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.chStop)
+	})
+	p.stateLock.Lock()
+	started := p.started
+	p.stateLock.Unlock()
+	if started {
+		<-p.done
+	}
+}
+
+// stopped tells whether collecting metrics stopped, or is about to.
+func (p *measure) stopped() bool {
+	select {
+	case <-p.chStop:
+		return true
+	case <-p.done:
+		return true
+	default:
+		return false
 	}
-	return total - exclude
 }
 
-func (p *measure) stopAndPrint() {
-	close(p.chStop)
+// Print stops collecting metrics if not done yet, captures the profiles if
+// configured to, then exports, summarizes and prints the metrics. It returns
+// an error if any of the configured thresholds is breached. Only the first
+// call has any effect, later ones return the same error.
+func (p *measure) Print() error {
+	p.Stop()
+	p.printOnce.Do(func() {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		if p.profiles {
+			if err := p.captureProfiles(fmt.Sprint(p.instanceSeq), p.cpuProfile); err != nil {
+				p.runenv.RecordMessage("WARNING: Failed to capture profiles: %v", err)
+			}
+		}
+		p.dumpRegistryWithin(registryDumpName(p.instanceSeq, 0), registryDumpTimeout)
+		p.summaries = p.print()
+		p.printErr = p.checkThresholds(p.summaries)
+		if p.printErr != nil {
+			p.runenv.RecordMessage("%v", p.printErr)
+		}
+	})
+	return p.printErr
+}
+
+// stopAndPrint is a shorthand for Stop then Print.
+func (p *measure) stopAndPrint() error {
+	p.Stop()
+	return p.Print()
+}
+
+func (p *measure) print() map[string]summary {
+	if err := p.export(); err != nil {
+		p.runenv.RecordMessage("WARNING: Failed to export metrics: %v", err)
+	}
 	output := fmt.Sprintf("Test params: %v", p.runenv.TestInstanceParams)
-	for _, name := range []string{
-		metricGoroutines,
-		metricHeapAllocMiBs,
-		metricRecvBytes,
-		metricTransmitBytes,
-	} {
-		if len(p.metrics[name]) == 0 {
+	summaries := make(map[string]summary)
+	names := p.seriesNames()
+	markers := p.markersSnapshot()
+	for _, name := range names {
+		if len(p.metrics[name]) < 2 {
 			p.runenv.RecordMessage("WARNING: No metrics for %s!", name)
 			continue
 		}
+		summaries[name] = p.summarize(name, name, p.metrics[name], p.deltas[name])
 		output += "\n"
-		output += asciigraph.Plot(
-			p.metrics[name],
-			asciigraph.Caption(name),
-			asciigraph.Width(100),
-			asciigraph.Height(10),
-			asciigraph.Offset(10))
+		output += p.plot(name)
+		output += p.markerLegend(name, markers)
 	}
+	p.recordMarkers(markers)
+	output += "\n" + formatSummaries(names, summaries, p.rateSeries(names))
+	output += p.printPhases(names)
+	output += p.compareBaseline(names, summaries)
 	p.runenv.RecordMessage(output)
+	return summaries
+}
+
+// seriesNames returns the names of all series to be printed, each metric
+// followed by its per-device series if any.
+func (p *measure) seriesNames() []string {
+	var names []string
+	for _, metric := range measuredMetrics {
+		names = append(names, metric)
+		var devices []string
+		for name := range p.metrics {
+			if name != metric && baseMetric(name) == metric {
+				devices = append(devices, name)
+			}
+		}
+		sort.Strings(devices)
+		names = append(names, devices...)
+	}
+	names = append(names, p.tracked...)
+	return append(names, p.observed...)
+}
+
+// rateSeries tells which of the named series are rates of a counter.
+func (p *measure) rateSeries(names []string) map[string]bool {
+	rates := make(map[string]bool)
+	for _, name := range names {
+		rates[name] = p.isRate(name)
+	}
+	return rates
+}
+
+// isRate tells whether the named series is the rate of a counter.
+func (p *measure) isRate(name string) bool {
+	return rateMetrics[baseMetric(name)] || p.trackedRates[name]
+}
+
+// summarize computes the statistics of values sampled from the named series,
+// and of deltas they were computed from if it's a rate, and records them as
+// test results named after result.
+func (p *measure) summarize(result, name string, values, deltas []float64) summary {
+	isRate := p.isRate(name)
+	if !isRate {
+		deltas = nil
+	}
+	s := summarize(values, deltas)
+	p.runenv.R().RecordPoint(result+"-min", s.min)
+	p.runenv.R().RecordPoint(result+"-max", s.max)
+	p.runenv.R().RecordPoint(result+"-mean", s.mean)
+	p.runenv.R().RecordPoint(result+"-median", s.median)
+	p.runenv.R().RecordPoint(result+"-p95", s.p95)
+	p.runenv.R().RecordPoint(result+"-p99", s.p99)
+	if isRate {
+		p.runenv.R().RecordPoint(result+"-total", s.total)
+	}
+	return s
 }
@@ -0,0 +1,553 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	goruntime "runtime"
+	"strings"
+	gosync "sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/testground/sdk-go/runtime"
+	"github.com/testground/sdk-go/sync"
+)
+
+// fakeSampler returns counters growing by a fixed amount on every sample.
+type fakeSampler struct {
+	lk    gosync.Mutex
+	total float64
+}
+
+func (s *fakeSampler) sample() (counters, error) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	s.total += 1000
+	return counters{
+		metricRecvBytes:      {"eth0": s.total},
+		metricTransmitBytes:  {"eth0": s.total},
+		metricDiskReadBytes:  {"sda": s.total},
+		metricDiskWriteBytes: {"sda": s.total},
+	}, nil
+}
+
+func newTestMeasure(t *testing.T) (*measure, func()) {
+	runenv, cleanup := runtime.RandomTestRunEnv(t)
+	runenv.TestInstanceParams["metrics-interval-ms"] = "1"
+	return newTestMeasureWith(t, runenv), cleanup
+}
+
+func newTestMeasureWith(t *testing.T, runenv *runtime.RunEnv, opts ...MeasureOption) *measure {
+	c, err := newMeasureConfig(runenv, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return newMeasure(runenv, c, &fakeSampler{})
+}
+
+func TestMeasureStopWhileCollecting(t *testing.T) {
+	p, cleanup := newTestMeasure(t)
+	defer cleanup()
+	p.start(context.Background())
+
+	// poke at the measure from the test while it's collecting
+	var wg gosync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			p.StartPhase("phase")
+			p.setInstanceSeq(int64(i))
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if err := p.stopAndPrint(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wg.Wait()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for _, name := range []string{metricGoroutines, metricRecvBytes, metricDiskWriteBytes} {
+		if len(p.metrics[name]) == 0 {
+			t.Fatalf("expected samples for %s", name)
+		}
+		if len(p.metrics[name]) != len(p.timestamps[name]) {
+			t.Fatalf("expected a timestamp for each sample of %s", name)
+		}
+	}
+}
+
+func TestMeasureNetDeviceFilter(t *testing.T) {
+	counters := map[string]float64{
+		"lo":      1,
+		"lo0":     10,
+		"eth0":    100,
+		"eth1":    1000,
+		"docker0": 10000,
+		"veth1a2": 100000,
+	}
+
+	t.Run("default exclusion", func(t *testing.T) {
+		p, cleanup := newTestMeasure(t)
+		defer cleanup()
+		total, byDev := p.collectBytes(counters)
+		if total != 1100 {
+			t.Fatalf("expected total 1100, got %v", total)
+		}
+		if len(byDev) != 2 || byDev["eth0"] != 100 || byDev["eth1"] != 1000 {
+			t.Fatalf("unexpected devices: %v", byDev)
+		}
+	})
+
+	t.Run("custom exclusion and aggregate", func(t *testing.T) {
+		runenv, cleanup := runtime.RandomTestRunEnv(t)
+		defer cleanup()
+		runenv.TestInstanceParams["net-exclude"] = "^lo$"
+		runenv.TestInstanceParams["net-ifaces"] = "eth0,lo0"
+		p := newTestMeasureWith(t, runenv)
+		total, byDev := p.collectBytes(counters)
+		if total != 110 {
+			t.Fatalf("expected total 110, got %v", total)
+		}
+		if _, ok := byDev["lo"]; ok || len(byDev) != 5 {
+			t.Fatalf("unexpected devices: %v", byDev)
+		}
+	})
+}
+
+func TestMeasureRegister(t *testing.T) {
+	p, cleanup := newTestMeasure(t)
+	defer cleanup()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "test_counter_total"})
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_gauge"})
+	if err := p.Register(counter, "test_counter_total"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := p.Register(gauge, "test_gauge"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := p.Register(prometheus.NewGauge(prometheus.GaugeOpts{Name: "other"}), "test_gauge"); err == nil {
+		t.Fatalf("expected an error tracking test_gauge twice")
+	}
+
+	ts := time.Now()
+	for i := 0; i < 3; i++ {
+		counter.Add(10)
+		gauge.Set(float64(i))
+		if err := p.collect(ts, counters{}, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ts = ts.Add(p.interval)
+	}
+	// the first tick only seeds the counter
+	rate := 10 / p.interval.Seconds()
+	if got := p.metrics["test_counter_total"]; len(got) != 2 || got[0] != rate || got[1] != rate {
+		t.Fatalf("unexpected counter samples: %v", got)
+	}
+	if got := p.metrics["test_gauge"]; len(got) != 3 || got[2] != 2 {
+		t.Fatalf("unexpected gauge samples: %v", got)
+	}
+	if !p.isRate("test_counter_total") || p.isRate("test_gauge") {
+		t.Fatalf("expected only the counter to be a rate")
+	}
+	names := p.seriesNames()
+	if names[len(names)-2] != "test_counter_total" || names[len(names)-1] != "test_gauge" {
+		t.Fatalf("expected tracked metrics to be printed last, got %v", names)
+	}
+}
+
+func TestMeasureOptions(t *testing.T) {
+	runenv, cleanup := runtime.RandomTestRunEnv(t)
+	defer cleanup()
+	runenv.TestInstanceParams["metrics-interval-ms"] = "500"
+	runenv.TestInstanceParams["net-exclude"] = "^lo$"
+	registry := prometheus.NewRegistry()
+	c, err := newMeasureConfig(runenv,
+		WithInterval(time.Millisecond),
+		WithGraph(50, 5),
+		WithExcludedDevices(regexp.MustCompile("^eth1$")),
+		WithCollectors(map[string]bool{"diskstats": false, "meminfo": true}),
+		WithRegistry(registry))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.interval != time.Millisecond {
+		t.Fatalf("expected the option to take precedence over the param, got %v", c.interval)
+	}
+	if c.graph.width != 50 || c.graph.height != 5 || c.graph.offset != defaultGraphSize.offset {
+		t.Fatalf("unexpected graph size: %+v", c.graph)
+	}
+	if !c.netExclude.MatchString("eth1") || c.netExclude.MatchString("lo") {
+		t.Fatalf("unexpected excluded devices: %v", c.netExclude)
+	}
+	if !c.collectors["netdev"] || c.collectors["diskstats"] || !c.collectors["meminfo"] {
+		t.Fatalf("unexpected collectors: %v", c.collectors)
+	}
+	if c.registry != registry {
+		t.Fatalf("expected the given registry")
+	}
+
+	if _, err := newMeasureConfig(runenv, WithCollectors(map[string]bool{"hwmon": true})); err == nil {
+		t.Fatalf("expected an error enabling an unknown collector")
+	}
+	if _, ok := c.newSampler(runenv).(nopSampler); ok {
+		t.Fatalf("expected a sampler of the OS counters")
+	}
+	c.collectors = map[string]bool{}
+	if _, ok := c.newSampler(runenv).(nopSampler); !ok {
+		t.Fatalf("expected no sampler with every collector disabled")
+	}
+}
+
+func TestMeasureMarkers(t *testing.T) {
+	p, cleanup := newTestMeasure(t)
+	defer cleanup()
+	p.Mark("before first sample")
+	ts := time.Now().Add(time.Second)
+	for i := 0; i < 4; i++ {
+		if i == 2 {
+			p.markers = append(p.markers, marker{label: "third sample", at: ts})
+		}
+		if err := p.collect(ts, counters{}, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ts = ts.Add(time.Second)
+	}
+	p.markers = append(p.markers, marker{label: "after last sample", at: ts})
+
+	legend := strings.Join(strings.Fields(p.markerLegend(metricGoroutines, p.markersSnapshot())), " ")
+	for _, expected := range []string{
+		"Markers: before first sample",
+		"sample #3 2s third sample",
+		"after last sample 4s after last sample",
+	} {
+		if !strings.Contains(legend, expected) {
+			t.Fatalf("expected %q in legend:\n%s", expected, legend)
+		}
+	}
+
+	p.start(context.Background())
+	if err := p.stopAndPrint(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Mark("after stop")
+	if n := len(p.markersSnapshot()); n != 3 {
+		t.Fatalf("expected marks after stop to be ignored, got %d markers", n)
+	}
+	rows, err := ioutil.ReadFile(filepath.Join(p.runenv.TestOutputsPath, "measurements-0.csv"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(rows), ",marker,,third sample,\n") {
+		t.Fatalf("expected the markers in the CSV export")
+	}
+}
+
+func TestMeasureCPUPercent(t *testing.T) {
+	p, cleanup := newTestMeasure(t)
+	defer cleanup()
+	p.cores = 4
+	ts := time.Now()
+	for i := 1; i <= 3; i++ {
+		// two of the four CPUs fully busy
+		cpu := float64(i) * p.interval.Seconds()
+		if err := p.collect(ts, counters{metricCPUSeconds: {"0": cpu, "1": cpu}}, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ts = ts.Add(p.interval)
+	}
+	for name, expected := range map[string]float64{metricCPUSeconds: 2, metricCPUPercent: 50} {
+		values := p.metrics[name]
+		if len(values) != 2 {
+			t.Fatalf("expected 2 samples of %s, got %v", name, values)
+		}
+		for _, v := range values {
+			if math.Abs(v-expected) > 1e-9 {
+				t.Fatalf("expected %s to be %v, got %v", name, expected, v)
+			}
+		}
+	}
+	if n := cpuCount(&fakeSampler{}); n != goruntime.NumCPU() {
+		t.Fatalf("expected to fall back to the number of CPUs of the process, got %d", n)
+	}
+}
+
+func TestMeasureIrregularTicks(t *testing.T) {
+	p, cleanup := newTestMeasure(t)
+	defer cleanup()
+	// the ticks fire late, then catch up, while the counter grows steadily
+	ts := time.Now()
+	var received float64
+	for _, gap := range []time.Duration{0, time.Second, 3 * time.Second, 500 * time.Millisecond, time.Second} {
+		ts = ts.Add(gap)
+		received += 100 * gap.Seconds()
+		if err := p.collect(ts, counters{metricRecvBytes: {"eth0": 1 + received}}, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	rates := p.metrics[metricRecvBytes]
+	deltas := p.deltas[metricRecvBytes]
+	expectedDeltas := []float64{100, 300, 50, 100}
+	if len(rates) != len(expectedDeltas) || len(deltas) != len(expectedDeltas) {
+		t.Fatalf("expected %d samples, got rates %v and deltas %v", len(expectedDeltas), rates, deltas)
+	}
+	for i, expected := range expectedDeltas {
+		if math.Abs(rates[i]-100) > 1e-9 {
+			t.Fatalf("expected a steady rate of 100 bytes per second, got %v", rates)
+		}
+		if math.Abs(deltas[i]-expected) > 1e-9 {
+			t.Fatalf("expected deltas %v, got %v", expectedDeltas, deltas)
+		}
+	}
+	// the total is what was actually received, not the rates over the interval
+	if s := p.summarize(metricRecvBytes, metricRecvBytes, rates, deltas); math.Abs(s.total-550) > 1e-9 {
+		t.Fatalf("expected a total of 550 bytes, got %v", s.total)
+	}
+	// so do the per-device series
+	if rates := p.metrics[deviceMetric(metricRecvBytes, "eth0")]; len(rates) != 4 || math.Abs(rates[1]-100) > 1e-9 {
+		t.Fatalf("expected a steady rate of eth0, got %v", rates)
+	}
+}
+
+func TestMeasureSwapAndFaults(t *testing.T) {
+	p, cleanup := newTestMeasure(t)
+	defer cleanup()
+	ts := time.Now()
+	for i := 1; i <= 3; i++ {
+		c := counters{
+			counterSwapTotal:  {"": 1 << 30},
+			counterSwapFree:   {"": 1<<30 - float64(i-1)*1048576},
+			metricMajorFaults: {"": float64(i) * 10 * p.interval.Seconds()},
+		}
+		if err := p.collect(ts, c, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if i == 1 && p.swapWarned {
+			t.Fatalf("expected no warning before swap is used")
+		}
+		ts = ts.Add(p.interval)
+	}
+	if swap := p.metrics[metricSwapUsedMiBs]; len(swap) != 3 || swap[0] != 0 || swap[2] != 2 {
+		t.Fatalf("expected 0 to 2 MiB of swap used, got %v", swap)
+	}
+	if !p.swapWarned {
+		t.Fatalf("expected a warning once swap is used")
+	}
+	if _, ok := p.metrics[counterSwapTotal]; ok {
+		t.Fatalf("expected the swap counters not to be recorded")
+	}
+	faults := p.metrics[metricMajorFaults]
+	if len(faults) != 2 {
+		t.Fatalf("expected 2 samples of %s, got %v", metricMajorFaults, faults)
+	}
+	for _, v := range faults {
+		if math.Abs(v-10) > 1e-9 {
+			t.Fatalf("expected 10 major faults per second, got %v", v)
+		}
+	}
+}
+
+func TestMeasureAggregate(t *testing.T) {
+	client := sync.NewInmemClient()
+	ctx := context.Background()
+	newInstance := func(seq int64, goroutines float64) *measure {
+		p, cleanup := newTestMeasure(t)
+		t.Cleanup(cleanup)
+		p.runenv.TestInstanceParams["aggregate-timeout"] = "100ms"
+		p.setInstanceSeq(seq)
+		p.start(ctx)
+		if err := p.stopAndPrint(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// replace the collected summaries with known ones
+		p.summaries = map[string]summary{
+			metricGoroutines:                      {mean: goroutines, p95: goroutines * 2},
+			metricRecvBytes:                       {mean: 10, p95: 20, total: 1000},
+			deviceMetric(metricRecvBytes, "eth0"): {mean: 10, p95: 20, total: 1000},
+		}
+		return p
+	}
+	second := newInstance(2, 30)
+	first := newInstance(1, 10)
+	if err := second.Aggregate(ctx, client, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the third instance never shows up
+	start := time.Now()
+	if err := first.Aggregate(ctx, client, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond || elapsed > 5*time.Second {
+		t.Fatalf("expected to give up waiting after the timeout, took %v", elapsed)
+	}
+
+	ch := make(chan *instanceSummaries, 2)
+	if _, err := client.Subscribe(ctx, summariesTopic, ch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	all := map[int64]*instanceSummaries{}
+	for i := 0; i < 2; i++ {
+		s := <-ch
+		all[s.Seq] = s
+	}
+	if _, ok := all[2].Metrics[deviceMetric(metricRecvBytes, "eth0")]; ok {
+		t.Fatalf("expected the per-device series not to be published")
+	}
+	output := strings.Join(strings.Fields(first.aggregate([]string{metricGoroutines, metricRecvBytes}, all)), " ")
+	for _, expected := range []string{
+		"goroutines 10.00 60.00 2 -",
+		"receive-bytes 10.00 20.00 1 2000.00",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output:\n%s", expected, output)
+		}
+	}
+}
+
+func TestMeasureProfiles(t *testing.T) {
+	runenv, cleanup := runtime.RandomTestRunEnv(t)
+	defer cleanup()
+	runenv.TestInstanceParams["metrics-interval-ms"] = "1"
+	p := newTestMeasureWith(t, runenv, WithProfiles(10*time.Millisecond), WithPeakHeapProfile(0.001))
+	p.setInstanceSeq(3)
+	p.start(context.Background())
+	time.Sleep(20 * time.Millisecond)
+	if err := p.stopAndPrint(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(runenv.TestOutputsPath, name))
+		return err == nil
+	}
+	for _, name := range []string{"heap-3.pprof", "goroutines-3.txt", "cpu-3.pprof"} {
+		if !exists(name) {
+			t.Fatalf("expected %s to be written", name)
+		}
+	}
+	// the peak profiles are captured in the background
+	deadline := time.Now().Add(5 * time.Second)
+	for !exists("goroutines-peak-3.txt") {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the peak profiles to be written")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestMeasureRegistryDump(t *testing.T) {
+	runenv, cleanup := runtime.RandomTestRunEnv(t)
+	defer cleanup()
+	runenv.TestInstanceParams["metrics-interval-ms"] = "1"
+	p := newTestMeasureWith(t, runenv, WithRegistryDumps(5))
+	p.setInstanceSeq(2)
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "test_dumped_total", Help: "Dumped."})
+	counter.Add(3)
+	if err := p.Register(counter); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.start(context.Background())
+	// the periodic dumps are written in the background
+	periodic := filepath.Join(runenv.TestOutputsPath, registryDumpName(2, 5))
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(periodic); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %s to be written", periodic)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := p.stopAndPrint(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dump, err := ioutil.ReadFile(filepath.Join(runenv.TestOutputsPath, "registry-2.prom"))
+	if err != nil {
+		t.Fatalf("expected the registry to be dumped at stop: %v", err)
+	}
+	if !strings.Contains(string(dump), "# TYPE test_dumped_total counter\ntest_dumped_total 3\n") {
+		t.Fatalf("expected the registered counter in the dump, got:\n%s", dump)
+	}
+	if _, err := newMeasureConfig(runenv, WithRegistryDumps(-1)); err == nil {
+		t.Fatalf("expected an error with negative registry dump ticks")
+	}
+}
+
+func TestMeasureStop(t *testing.T) {
+	t.Run("double stop", func(t *testing.T) {
+		p, cleanup := newTestMeasure(t)
+		defer cleanup()
+		p.start(context.Background())
+		time.Sleep(10 * time.Millisecond)
+		p.Stop()
+		p.Stop()
+		if err := p.stopAndPrint(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := p.stopAndPrint(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("stop before first sample", func(t *testing.T) {
+		p, cleanup := newTestMeasure(t)
+		defer cleanup()
+		p.interval = time.Hour
+		p.start(context.Background())
+		p.Stop()
+		if err := p.Print(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(p.summaries) != 0 {
+			t.Fatalf("expected no metrics, got %v", p.summaries)
+		}
+	})
+
+	t.Run("stop without start", func(t *testing.T) {
+		p, cleanup := newTestMeasure(t)
+		defer cleanup()
+		p.Stop()
+		if err := p.Print(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("stop after context done", func(t *testing.T) {
+		p, cleanup := newTestMeasure(t)
+		defer cleanup()
+		ctx, cancel := context.WithCancel(context.Background())
+		p.start(ctx)
+		cancel()
+		<-p.done
+		p.Stop()
+	})
+
+	t.Run("concurrent stops while collecting", func(t *testing.T) {
+		p, cleanup := newTestMeasure(t)
+		defer cleanup()
+		p.start(context.Background())
+		time.Sleep(10 * time.Millisecond)
+		var wg gosync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				p.Stop()
+				_ = p.Print()
+			}()
+		}
+		wg.Wait()
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		if len(p.metrics[metricGoroutines]) != len(p.timestamps[metricGoroutines]) {
+			t.Fatalf("expected a timestamp for each sample")
+		}
+	})
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/testground/sdk-go/runtime"
+)
+
+// measureConfig holds the settings of a measure.
+type measureConfig struct {
+	registry   *prometheus.Registry
+	interval   time.Duration
+	collectors map[string]bool
+	// nodeMemory tells whether to record the node-wide active memory, out
+	// of the meminfo collector.
+	nodeMemory bool
+	netIfaces  map[string]bool
+	netExclude *regexp.Regexp
+	graph      graphSize
+	bandwidth  metrics.Reporter
+	// profiles tells whether to capture profiles at stop, with the CPU
+	// profiled for cpuProfile if not zero.
+	profiles   bool
+	cpuProfile time.Duration
+	// profileHeapMiBs is the heap allocation beyond which profiles are
+	// captured in the middle of the run, 0 to disable.
+	profileHeapMiBs float64
+	// registryDumpTicks is how many ticks apart the registries are dumped,
+	// on top of the dump at stop, 0 to disable.
+	registryDumpTicks int
+}
+
+// MeasureOption configures a measure.
+type MeasureOption func(c *measureConfig) error
+
+// WithInterval sets how often the metrics are sampled.
+func WithInterval(interval time.Duration) MeasureOption {
+	return func(c *measureConfig) error {
+		if interval <= 0 {
+			return fmt.Errorf("invalid measure interval: %v", interval)
+		}
+		c.interval = interval
+		return nil
+	}
+}
+
+// WithCollectors enables or disables node_exporter collectors by name, among
+// "cpu", "netdev", "diskstats", "meminfo" and "vmstat". The node_exporter
+// isn't initialized at all if every collector is disabled.
+func WithCollectors(collectors map[string]bool) MeasureOption {
+	return func(c *measureConfig) error {
+		for name, enabled := range collectors {
+			if _, ok := nodeCollectors[name]; !ok {
+				return fmt.Errorf("unknown node_exporter collector: %s", name)
+			}
+			c.collectors[name] = enabled
+		}
+		return nil
+	}
+}
+
+// WithExcludedDevices sets the network devices left out of the network
+// metrics entirely.
+func WithExcludedDevices(exclude *regexp.Regexp) MeasureOption {
+	return func(c *measureConfig) error {
+		c.netExclude = exclude
+		return nil
+	}
+}
+
+// WithGraph sets the dimensions of the printed graphs, in characters.
+func WithGraph(width, height int) MeasureOption {
+	return func(c *measureConfig) error {
+		if width <= 0 || height <= 0 {
+			return fmt.Errorf("invalid graph size: %dx%d", width, height)
+		}
+		c.graph.width = width
+		c.graph.height = height
+		return nil
+	}
+}
+
+// WithRegistry sets the registry the node_exporter collectors are registered to.
+func WithRegistry(registry *prometheus.Registry) MeasureOption {
+	return func(c *measureConfig) error {
+		c.registry = registry
+		return nil
+	}
+}
+
+// WithBandwidth sets the reporter of the libp2p traffic per protocol.
+func WithBandwidth(bandwidth metrics.Reporter) MeasureOption {
+	return func(c *measureConfig) error {
+		c.bandwidth = bandwidth
+		return nil
+	}
+}
+
+// WithProfiles captures a heap profile, a goroutine dump and, if cpu is not
+// zero, a CPU profile over cpu when the measure stops.
+func WithProfiles(cpu time.Duration) MeasureOption {
+	return func(c *measureConfig) error {
+		c.profiles = true
+		c.cpuProfile = cpu
+		return nil
+	}
+}
+
+// WithPeakHeapProfile captures a heap profile and a goroutine dump the first
+// time the heap allocation exceeds mibs.
+func WithPeakHeapProfile(mibs float64) MeasureOption {
+	return func(c *measureConfig) error {
+		c.profileHeapMiBs = mibs
+		return nil
+	}
+}
+
+// WithRegistryDumps dumps the metrics registries every given number of ticks,
+// on top of the dump at stop. Zero disables the periodic dumps.
+func WithRegistryDumps(ticks int) MeasureOption {
+	return func(c *measureConfig) error {
+		if ticks < 0 {
+			return fmt.Errorf("invalid registry dump ticks: %d", ticks)
+		}
+		c.registryDumpTicks = ticks
+		return nil
+	}
+}
+
+// newMeasureConfig returns the settings given by the test instance params,
+// or the defaults for those not set, then applies opts.
+func newMeasureConfig(runenv *runtime.RunEnv, opts ...MeasureOption) (measureConfig, error) {
+	c := measureConfig{
+		interval: defaultMeasureInterval,
+		collectors: map[string]bool{
+			"cpu":       true,
+			"netdev":    true,
+			"diskstats": true,
+			"meminfo":   true,
+			"vmstat":    true,
+		},
+		nodeMemory: runenv.BooleanParam("node-memory"),
+		netIfaces:  make(map[string]bool),
+		netExclude: regexp.MustCompile(defaultNetExclude),
+		graph:      graphSizeFromParams(runenv),
+		profiles:   runenv.BooleanParam("capture-profiles"),
+		cpuProfile: defaultCPUProfileDuration,
+	}
+	if runenv.IsParamSet("cpu-profile-secs") {
+		c.cpuProfile = time.Duration(runenv.IntParam("cpu-profile-secs")) * time.Second
+	}
+	if runenv.IsParamSet("profile-heap-mibs") {
+		c.profileHeapMiBs = runenv.FloatParam("profile-heap-mibs")
+	}
+	if runenv.IsParamSet("registry-dump-ticks") {
+		if ticks := runenv.IntParam("registry-dump-ticks"); ticks > 0 {
+			c.registryDumpTicks = ticks
+		}
+	}
+	if runenv.IsParamSet("metrics-interval-ms") {
+		if ms := runenv.IntParam("metrics-interval-ms"); ms > 0 {
+			c.interval = time.Duration(ms) * time.Millisecond
+		}
+	}
+	if runenv.IsParamSet("net-ifaces") {
+		for _, iface := range strings.Split(runenv.StringParam("net-ifaces"), ",") {
+			if iface = strings.TrimSpace(iface); iface != "" {
+				c.netIfaces[iface] = true
+			}
+		}
+	}
+	if runenv.IsParamSet("net-exclude") {
+		if re, err := regexp.Compile(runenv.StringParam("net-exclude")); err == nil {
+			c.netExclude = re
+		} else {
+			runenv.RecordMessage("WARNING: Invalid net-exclude, using %s: %v", defaultNetExclude, err)
+		}
+	}
+	for _, opt := range opts {
+		if err := opt(&c); err != nil {
+			return c, err
+		}
+	}
+	if c.registry == nil {
+		c.registry = prometheus.NewRegistry()
+	}
+	return c, nil
+}
+
+// newSampler returns the sampler of the OS counters for c.
+func (c measureConfig) newSampler(runenv *runtime.RunEnv) sampler {
+	var enabled bool
+	for _, e := range c.collectors {
+		enabled = enabled || e
+	}
+	if !enabled {
+		return nopSampler{}
+	}
+	node, err := newNodeSampler(c.registry, c.collectors)
+	if err != nil {
+		runenv.RecordMessage("WARNING: node_exporter collectors unavailable, falling back to netstat: %v", err)
+		return netstatSampler{}
+	}
+	return node
+}
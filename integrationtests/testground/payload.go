@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multihash"
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+	sync "github.com/testground/sdk-go/sync"
+	corenet "github.com/textileio/go-threads/core/net"
+)
+
+// The payload-sweep test case measures how the size of the record bodies
+// affects resource usage. In each network scenario:
+// |-- One test instance creates the thread and broadcasts to the rest, which join it.
+// |-- For each of the "payload-sizes", all instances together:
+//     |-- 1. Create a few records governed by the "records" test param, with a random payload of that size.
+//     |-- 2. Wait for the records created by all instances, checking the payloads against their hash.
+
+const (
+	bodyPayload     = "payload"
+	bodyPayloadHash = "payload-sha256"
+)
+
+func testPayloadSweep(env *runtime.RunEnv, ic *run.InitContext) (err error) {
+	return testMultipleRounds("testPayloadSweep", env, ic, testPayloadSweepRound)
+}
+
+// payloadSizes parses the comma separated payload sizes, in bytes.
+func payloadSizes(param string) ([]int, error) {
+	var sizes []int
+	for _, s := range strings.Split(param, ",") {
+		size, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("invalid payload size %q", s)
+		}
+		sizes = append(sizes, size)
+	}
+	return sizes, nil
+}
+
+func testPayloadSweepRound(ctx context.Context, env *runtime.RunEnv, ic *run.InitContext, round string, desiredAddr string) error {
+	sizes, err := payloadSizes(env.StringParam("payload-sizes"))
+	if err != nil {
+		return err
+	}
+	cli, stop, err := startClient(desiredAddr, env, ic)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	chThreadToJoin := make(chan *SharedInfo, 1)
+	topic := sync.NewTopic("payload-thread-"+round, &SharedInfo{})
+	var thr *threadWithKeys
+	if ic.GlobalSeq == 1 {
+		if thr, err = createThread(ctx, cli); err != nil {
+			return fmt.Errorf("failed to create thread: %w", err)
+		}
+		msg("Created thread")
+		ic.SyncClient.MustPublishSubscribe(ctx, topic, thr.Sharable(), chThreadToJoin)
+	} else {
+		ic.SyncClient.MustSubscribe(ctx, topic, chThreadToJoin)
+		if thr, err = joinThread(ctx, cli, <-chThreadToJoin); err != nil {
+			return fmt.Errorf("failed to join thread: %w", err)
+		}
+		msg("Joined thread")
+	}
+
+	recordsToSend := env.IntParam("records")
+	recordsToReceive := recordsToSend * env.TestInstanceCount
+	var flagged []string
+	for _, size := range sizes {
+		name := fmt.Sprintf("%s-payload-%d", round, size)
+		// leave the coordination out of the measurement, and don't let the
+		// previous size leak into the first sample of this one
+		meter.Pause()
+		ic.SyncClient.MustSignalAndWait(ctx, sync.State("ready-"+name), env.TestInstanceCount)
+		meter.Resume()
+		meter.StartPhase(name)
+		start := time.Now()
+		if err := thr.CreatePayloadRecords(ctx, recordsToSend, size); err != nil {
+			return err
+		}
+		records := thr.WaitForRecords(ctx, recordsToReceive)
+		elapsed := time.Since(start)
+		corrupt := 0
+		for _, rec := range records {
+			if err := thr.checkPayload(ctx, rec, size); err != nil {
+				debug("Corrupt record %v: %v", rec.Cid(), err)
+				corrupt++
+			}
+		}
+		lost := recordsToReceive - len(records)
+		env.R().RecordPoint("round-"+name+"-elapsed-seconds", elapsed.Seconds())
+		env.R().RecordPoint("round-"+name+"-lost-records", float64(lost))
+		env.R().RecordPoint("round-"+name+"-corrupt-records", float64(corrupt))
+		if lost > 0 || corrupt > 0 {
+			msg("WARNING: Peer #%d lost %d and got %d corrupt records out of %d with %d bytes payloads",
+				ic.GlobalSeq, lost, corrupt, recordsToReceive, size)
+			flagged = append(flagged, strconv.Itoa(size))
+		} else {
+			msg("Peer #%d got all %d records with %d bytes payloads in %v", ic.GlobalSeq, recordsToReceive, size, elapsed)
+		}
+		ic.SyncClient.MustSignalAndWait(ctx, sync.State("done-"+name), env.TestInstanceCount)
+	}
+	if len(flagged) > 0 {
+		return fmt.Errorf("Peer #%d lost or got corrupt records with payloads of %s bytes", ic.GlobalSeq, strings.Join(flagged, ", "))
+	}
+	return nil
+}
+
+// CreatePayloadRecords creates num records, each with a random payload of
+// size bytes along with its hash.
+func (t *threadWithKeys) CreatePayloadRecords(ctx context.Context, num int, size int) error {
+	for i := 0; i < num; i++ {
+		payload := make([]byte, size)
+		if _, err := crand.Read(payload); err != nil {
+			return err
+		}
+		hash := sha256.Sum256(payload)
+		obj := map[string][]byte{bodyPayload: payload, bodyPayloadHash: hash[:]}
+		stampBody(obj, clock.seq)
+		body, err := ipldcbor.WrapObject(obj, multihash.SHA2_256, -1)
+		if err != nil {
+			return err
+		}
+		rec, err := t.cli.CreateRecord(ctx, t.ID, body)
+		if err != nil {
+			return err
+		}
+		recordsCreated.Inc()
+		debug("Created record #%d with %d bytes payload: %v", i+1, size, rec)
+		t.logHead = rec.Value().Cid()
+	}
+	return nil
+}
+
+// checkPayload returns an error if the payload of rec is not of size bytes,
+// or doesn't match its hash.
+func (t *threadWithKeys) checkPayload(ctx context.Context, rec corenet.Record, size int) error {
+	obj, err := t.recordBody(ctx, rec)
+	if err != nil {
+		return err
+	}
+	payload := obj[bodyPayload]
+	if len(payload) != size {
+		return fmt.Errorf("payload is %d bytes, expect %d", len(payload), size)
+	}
+	hash := sha256.Sum256(payload)
+	if !bytes.Equal(hash[:], obj[bodyPayloadHash]) {
+		return fmt.Errorf("payload doesn't match its hash")
+	}
+	return nil
+}
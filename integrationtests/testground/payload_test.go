@@ -0,0 +1,21 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPayloadSizes(t *testing.T) {
+	sizes, err := payloadSizes("256, 4096,1048576")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []int{256, 4096, 1048576}; !reflect.DeepEqual(sizes, expected) {
+		t.Fatalf("expected %v, got %v", expected, sizes)
+	}
+	for _, param := range []string{"", "256,", "4KB", "0", "-1"} {
+		if _, err := payloadSizes(param); err == nil {
+			t.Fatalf("expected an error parsing %q", param)
+		}
+	}
+}
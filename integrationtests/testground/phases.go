@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// phase is a named span of the test run, e.g., bootstrap or sync.
+type phase struct {
+	name  string
+	start time.Time
+	// end is zero while the phase is still running.
+	end time.Time
+}
+
+func (ph phase) contains(ts time.Time) bool {
+	return !ts.Before(ph.start) && (ph.end.IsZero() || ts.Before(ph.end))
+}
+
+// StartPhase tags the samples collected from now on with the named phase,
+// ending the current phase if any.
+func (p *measure) StartPhase(name string) {
+	p.stateLock.Lock()
+	defer p.stateLock.Unlock()
+	now := time.Now()
+	p.endPhase(now)
+	p.phases = append(p.phases, phase{name: name, start: now})
+}
+
+// EndPhase ends the current phase, if any.
+func (p *measure) EndPhase() {
+	p.stateLock.Lock()
+	defer p.stateLock.Unlock()
+	p.endPhase(time.Now())
+}
+
+func (p *measure) endPhase(now time.Time) {
+	if n := len(p.phases); n > 0 && p.phases[n-1].end.IsZero() {
+		p.phases[n-1].end = now
+	}
+}
+
+// Pause stops recording samples until Resume is called, to exclude e.g. setup
+// time from the measurement.
+func (p *measure) Pause() {
+	p.stateLock.Lock()
+	defer p.stateLock.Unlock()
+	p.paused = true
+}
+
+// Resume continues recording samples after Pause. The first tick after
+// resuming only re-seeds the counters, so the time spent paused doesn't show
+// up as a spike.
+func (p *measure) Resume() {
+	p.stateLock.Lock()
+	defer p.stateLock.Unlock()
+	if p.paused {
+		p.paused = false
+		p.reseed = true
+	}
+}
+
+// skipTick tells whether the current tick should be dropped, and whether the
+// counter baselines should be re-seeded before collecting it.
+func (p *measure) skipTick() (skip bool, reseed bool) {
+	p.stateLock.Lock()
+	defer p.stateLock.Unlock()
+	reseed = p.reseed
+	p.reseed = false
+	return p.paused, reseed
+}
+
+// resetBaselines forgets the last seen value of all counters, so the next
+// tick only seeds them without recording a sample.
+func (p *measure) resetBaselines() {
+	p.lastRecv = 0
+	p.lastTransmit = 0
+	p.lastRecvByDev = make(map[string]float64)
+	p.lastTransmitByDev = make(map[string]float64)
+	p.lastDiskRead = 0
+	p.lastDiskWrite = 0
+	p.lastGCPauseNs = 0
+	p.lastCPUSeconds = 0
+	p.lastMajorFaults = 0
+	p.lastProtoRecv = 0
+	p.lastProtoTransmit = 0
+	p.lastRecvByProto = make(map[string]float64)
+	p.lastTransmitByProto = make(map[string]float64)
+	p.lastTracked = make(map[string]float64)
+}
+
+// phaseSamples returns the samples of the named series collected during ph,
+// along with the raw deltas of those which are rates of a counter.
+func (p *measure) phaseSamples(name string, ph phase) (values, deltas []float64) {
+	for i, ts := range p.timestamps[name] {
+		if ph.contains(ts) {
+			values = append(values, p.metrics[name][i])
+			if i < len(p.deltas[name]) {
+				deltas = append(deltas, p.deltas[name][i])
+			}
+		}
+	}
+	return values, deltas
+}
+
+// printPhases summarizes the named series for each phase, and records the
+// statistics as test results prefixed by the phase name.
+func (p *measure) printPhases(names []string) string {
+	p.stateLock.Lock()
+	phases := make([]phase, len(p.phases))
+	copy(phases, p.phases)
+	p.stateLock.Unlock()
+
+	var output string
+	for _, ph := range phases {
+		summaries := make(map[string]summary)
+		for _, name := range names {
+			values, deltas := p.phaseSamples(name, ph)
+			if len(values) < 2 {
+				continue
+			}
+			summaries[name] = p.summarize(ph.name+"/"+name, name, values, deltas)
+		}
+		end := ph.end
+		if end.IsZero() {
+			end = time.Now()
+		}
+		output += fmt.Sprintf("\nPhase %s (%v):\n", ph.name, end.Sub(ph.start).Round(time.Millisecond))
+		output += formatSummaries(names, summaries, p.rateSeries(names))
+	}
+	return output
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The metrics below are read from /proc, so they are only collected on
+// Linux, and are no-ops elsewhere.
+
+// collectProcessRSS records the resident set size of the test process. Only
+// the process itself is measured, not its children if any.
+func (p *measure) collectProcessRSS(ts time.Time) {
+	status, err := ioutil.ReadFile("/proc/self/status")
+	if err != nil {
+		return
+	}
+	rss, err := parseVmRSS(status)
+	if err != nil {
+		return
+	}
+	rssMiBs := rss / 1048576.0
+	p.record(metricProcessRSSMiBs, ts, rssMiBs)
+	p.report(metricProcessRSSMiBs, rssMiBs)
+}
+
+// parseVmRSS returns the VmRSS in bytes from the content of /proc/<pid>/status.
+func parseVmRSS(status []byte) (float64, error) {
+	for _, line := range strings.Split(string(status), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 3 && fields[0] == "VmRSS:" && fields[2] == "kB" {
+			kb, err := strconv.ParseFloat(fields[1], 64)
+			if err != nil {
+				return 0, err
+			}
+			return kb * 1024, nil
+		}
+	}
+	return 0, fmt.Errorf("no VmRSS found")
+}
+
+// collectOpenFDs records the number of file descriptors opened by the test process.
+func (p *measure) collectOpenFDs(ts time.Time) {
+	fds, err := ioutil.ReadDir("/proc/self/fd")
+	if err != nil {
+		return
+	}
+	p.record(metricOpenFDs, ts, float64(len(fds)))
+	p.report(metricOpenFDs, float64(len(fds)))
+}
+
+// collectTCPConns records the number of established TCP connections, over
+// both IPv4 and IPv6. They are counted for the whole network namespace, which
+// in a testground container is the test process itself.
+func (p *measure) collectTCPConns(ts time.Time) {
+	var total int
+	var found bool
+	for _, path := range []string{"/proc/self/net/tcp", "/proc/self/net/tcp6"} {
+		table, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		found = true
+		total += countEstablished(table)
+	}
+	if !found {
+		return
+	}
+	p.record(metricTCPConns, ts, float64(total))
+	p.report(metricTCPConns, float64(total))
+}
+
+// tcpEstablished is the state of established connections in /proc/net/tcp.
+const tcpEstablished = "01"
+
+// countEstablished returns the number of established connections listed in
+// the content of /proc/net/tcp or /proc/net/tcp6.
+func countEstablished(table []byte) int {
+	var n int
+	lines := strings.Split(string(table), "\n")
+	for _, line := range lines[1:] {
+		// sl local_address rem_address st ...
+		fields := strings.Fields(line)
+		if len(fields) > 3 && fields[3] == tcpEstablished {
+			n++
+		}
+	}
+	return n
+}
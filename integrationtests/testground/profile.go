@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"runtime/pprof"
+	"time"
+)
+
+// defaultCPUProfileDuration is how long the CPU is profiled for when
+// profiles are captured at stop.
+const defaultCPUProfileDuration = 10 * time.Second
+
+// captureProfiles writes a heap profile, a goroutine dump and, if cpu is not
+// zero, a CPU profile over cpu under the test outputs path, the file names
+// ending with suffix.
+func (p *measure) captureProfiles(suffix string, cpu time.Duration) error {
+	dir := p.runenv.TestOutputsPath
+	// get up-to-date statistics of the live objects
+	goruntime.GC()
+	if err := writeProfile(filepath.Join(dir, "heap-"+suffix+".pprof"), "heap", 0); err != nil {
+		return err
+	}
+	if err := writeProfile(filepath.Join(dir, "goroutines-"+suffix+".txt"), "goroutine", 2); err != nil {
+		return err
+	}
+	if cpu <= 0 {
+		return nil
+	}
+	f, err := os.Create(filepath.Join(dir, "cpu-"+suffix+".pprof"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := pprof.StartCPUProfile(f); err != nil {
+		return err
+	}
+	time.Sleep(cpu)
+	pprof.StopCPUProfile()
+	return nil
+}
+
+func writeProfile(path, name string, debug int) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return pprof.Lookup(name).WriteTo(f, debug)
+}
+
+// checkPeakHeap captures the heap profile and goroutine dump in the
+// background the first time the heap allocation exceeds the configured
+// limit, so they reflect the peak rather than the end of the test.
+func (p *measure) checkPeakHeap(heapAllocMiBs float64) {
+	if p.profileHeapMiBs <= 0 || heapAllocMiBs <= p.profileHeapMiBs || p.peakProfiled {
+		return
+	}
+	p.peakProfiled = true
+	suffix := fmt.Sprintf("peak-%d", p.instanceSeq)
+	go func() {
+		if err := p.captureProfiles(suffix, 0); err != nil {
+			p.runenv.RecordMessage("WARNING: Failed to capture profiles at peak heap: %v", err)
+			return
+		}
+		p.runenv.RecordMessage("Captured profiles as heap allocation reached %.2f MiB", heapAllocMiBs)
+	}()
+}
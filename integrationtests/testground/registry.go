@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+)
+
+// registryDumpTimeout caps how long writing a dump of the registries may
+// hold up the caller.
+const registryDumpTimeout = 5 * time.Second
+
+// dumpRegistry writes all the metric families of the node_exporter and the
+// registered collectors, including those not recorded as series, in the
+// prometheus text format to a file of the test outputs path.
+func (p *measure) dumpRegistry(name string) error {
+	families, err := prometheus.Gatherers{p.registry, p.collectors}.Gather()
+	if len(families) == 0 && err != nil {
+		return err
+	}
+	// partial results are still worth keeping
+	f, err := os.Create(filepath.Join(p.runenv.TestOutputsPath, name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	for _, mf := range families {
+		if _, err := expfmt.MetricFamilyToText(f, mf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dumpRegistryWithin runs dumpRegistry, waiting for it up to timeout. A
+// warning is recorded if it fails or doesn't complete in time, in which case
+// it carries on in the background.
+func (p *measure) dumpRegistryWithin(name string, timeout time.Duration) {
+	done := make(chan error, 1)
+	go func() {
+		done <- p.dumpRegistry(name)
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			p.runenv.RecordMessage("WARNING: Failed to dump the metrics registry to %s: %v", name, err)
+		}
+	case <-time.After(timeout):
+		p.runenv.RecordMessage("WARNING: Gave up waiting for the metrics registry dump to %s after %v", name, timeout)
+	}
+}
+
+// registryDumpName returns the file name of the registry dump of the
+// instance, at the given tick or at stop if tick is 0.
+func registryDumpName(seq int64, tick int) string {
+	if tick == 0 {
+		return fmt.Sprintf("registry-%d.prom", seq)
+	}
+	return fmt.Sprintf("registry-%d-%06d.prom", seq, tick)
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/node_exporter/collector"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+// counters are cumulative OS counters, or gauges for some metrics, keyed by
+// metric name then by device.
+type counters map[string]map[string]float64
+
+// sampler takes snapshots of the OS counters tracked by measure.
+// It may return partial counters along with an error.
+type sampler interface {
+	sample() (counters, error)
+}
+
+// nodeMetrics maps the node_exporter metrics to the ones measure tracks.
+var nodeMetrics = map[string]string{
+	"node_network_receive_bytes_total":  metricRecvBytes,
+	"node_network_transmit_bytes_total": metricTransmitBytes,
+	"node_disk_read_bytes_total":        metricDiskReadBytes,
+	"node_disk_written_bytes_total":     metricDiskWriteBytes,
+	"node_memory_Active_bytes":          metricActiveMemoryMiBs,
+	"node_cpu_seconds_total":            metricCPUSeconds,
+	"node_vmstat_pgmajfault":            metricMajorFaults,
+	"node_memory_SwapTotal_bytes":       counterSwapTotal,
+	"node_memory_SwapFree_bytes":        counterSwapFree,
+}
+
+// counterSwapTotal and counterSwapFree are the counters the swap usage is
+// derived from, which aren't recorded themselves.
+const (
+	counterSwapTotal = "swap-total"
+	counterSwapFree  = "swap-free"
+)
+
+// idleCPUModes are the modes of node_cpu_seconds_total not counted as usage.
+var idleCPUModes = map[string]bool{"idle": true, "iowait": true}
+
+// nodeSampler reads the counters from node_exporter collectors, which are
+// only available on some platforms, Linux mainly.
+type nodeSampler struct {
+	registry *prometheus.Registry
+}
+
+// nodeCollectors are the node_exporter collectors measure can use, by name.
+var nodeCollectors = map[string]func(log.Logger) (collector.Collector, error){
+	"cpu":       collector.NewCPUCollector,
+	"netdev":    collector.NewNetDevCollector,
+	"diskstats": collector.NewDiskstatsCollector,
+	"meminfo":   collector.NewMeminfoCollector,
+	"vmstat":    collector.NewvmStatCollector,
+}
+
+var (
+	nodeExporterOnce sync.Once
+	nodeExporterErr  error
+)
+
+// initNodeExporter initializes node_exporter the first time it's called.
+func initNodeExporter() error {
+	nodeExporterOnce.Do(func() {
+		// have to do this because node_exporter requires it being called to
+		// properly initialize global variables. Parse no arguments, since
+		// the command line belongs to the binary.
+		_, nodeExporterErr = kingpin.CommandLine.Parse(nil)
+		collector.DisableDefaultCollectors()
+	})
+	return nodeExporterErr
+}
+
+// newNodeSampler registers the enabled node_exporter collectors to registry.
+func newNodeSampler(registry *prometheus.Registry, enabled map[string]bool) (*nodeSampler, error) {
+	if err := initNodeExporter(); err != nil {
+		return nil, err
+	}
+	logger := log.NewNopLogger()
+	nodeCollector, err := collector.NewNodeCollector(logger)
+	if err != nil {
+		return nil, err
+	}
+	for name, newCollector := range nodeCollectors {
+		if !enabled[name] {
+			continue
+		}
+		c, err := newCollector(logger)
+		if err != nil {
+			return nil, err
+		}
+		nodeCollector.Collectors[name] = c
+	}
+	if err := registry.Register(nodeCollector); err != nil {
+		return nil, err
+	}
+	return &nodeSampler{registry: registry}, nil
+}
+
+func (s *nodeSampler) sample() (counters, error) {
+	// Gather returns as many metrics as it could collect even when it
+	// fails, so carry on with whatever we got.
+	mf, err := s.registry.Gather()
+	c := make(counters)
+	for _, m := range mf {
+		name, ok := nodeMetrics[*m.Name]
+		if !ok {
+			continue
+		}
+		byDev := make(map[string]float64)
+		for _, metric := range m.Metric {
+			var dev string
+			var idle bool
+			for _, label := range metric.Label {
+				switch *label.Name {
+				case "device", "cpu":
+					dev = *label.Value
+				case "mode":
+					idle = idleCPUModes[*label.Value]
+				}
+			}
+			if idle {
+				continue
+			}
+			switch {
+			case metric.Counter != nil:
+				byDev[dev] += *metric.Counter.Value
+			case metric.Gauge != nil:
+				byDev[dev] += *metric.Gauge.Value
+			case metric.Untyped != nil:
+				byDev[dev] += *metric.Untyped.Value
+			}
+		}
+		c[name] = byDev
+	}
+	return c, err
+}
+
+// nopSampler is used when all node_exporter collectors are disabled.
+type nopSampler struct{}
+
+func (nopSampler) sample() (counters, error) {
+	return nil, nil
+}
+
+// netstatSampler is the fallback when node_exporter collectors are
+// unavailable, e.g., on macOS. It reads the network counters from the output
+// of "netstat -ibn", and has no disk counters.
+type netstatSampler struct{}
+
+func (netstatSampler) sample() (counters, error) {
+	out, err := exec.Command("netstat", "-ibn").Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseNetstat(out)
+}
+
+// parseNetstat parses the per-interface link level byte counters printed by
+// "netstat -ibn". The address column may be empty, so the counter columns are
+// located relative to the end of each line.
+func parseNetstat(out []byte) (counters, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("empty netstat output")
+	}
+	header := strings.Fields(scanner.Text())
+	recvOffset, transmitOffset := -1, -1
+	for i, col := range header {
+		switch col {
+		case "Ibytes":
+			recvOffset = len(header) - i
+		case "Obytes":
+			transmitOffset = len(header) - i
+		}
+	}
+	if recvOffset < 0 || transmitOffset < 0 {
+		return nil, fmt.Errorf("unexpected netstat header: %s", scanner.Text())
+	}
+	recv := make(map[string]float64)
+	transmit := make(map[string]float64)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || len(fields) < recvOffset || len(fields) < transmitOffset ||
+			!strings.HasPrefix(fields[2], "<Link#") {
+			continue
+		}
+		dev := strings.TrimSuffix(fields[0], "*")
+		r, err := strconv.ParseFloat(fields[len(fields)-recvOffset], 64)
+		if err != nil {
+			return nil, err
+		}
+		t, err := strconv.ParseFloat(fields[len(fields)-transmitOffset], 64)
+		if err != nil {
+			return nil, err
+		}
+		recv[dev] += r
+		transmit[dev] += t
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return counters{metricRecvBytes: recv, metricTransmitBytes: transmit}, nil
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/testground/sdk-go/network"
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+	sync "github.com/testground/sdk-go/sync"
+)
+
+// The shaping-matrix test case runs the sync-threads workload over every
+// combination of the "shaping-latencies", "shaping-bandwidths" and
+// "shaping-losses" test params, which shape the links of all instances, and
+// prints how long the workload took to converge in each to spot where sync
+// falls apart.
+
+// shapingCell is a combination of the shaping params, along with the
+// convergence time once run.
+type shapingCell struct {
+	simulatedNetwork
+	latency, bandwidth, loss string
+	converged                time.Duration
+}
+
+// shapingCells are the cells of the matrix, in the order they run.
+var shapingCells []*shapingCell
+
+func testShapingMatrix(env *runtime.RunEnv, ic *run.InitContext) (err error) {
+	shapingCells, err = shapingMatrix(
+		env.StringParam("shaping-latencies"),
+		env.StringParam("shaping-bandwidths"),
+		env.StringParam("shaping-losses"))
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	ic.NetClient.MustWaitNetworkInitialized(ctx)
+	cancel()
+	networks := make([]simulatedNetwork, len(shapingCells))
+	for i, c := range shapingCells {
+		networks[i] = c.simulatedNetwork
+	}
+	return testRounds("testShapingMatrix", env, ic, networks, configureAllNetworks, testShapingRound)
+}
+
+// shapingMatrix returns a cell for each combination of the comma separated
+// latencies, bandwidths and packet losses in percent.
+func shapingMatrix(latencies, bandwidths, losses string) ([]*shapingCell, error) {
+	var cells []*shapingCell
+	for _, latency := range splitParam(latencies) {
+		lat, err := time.ParseDuration(latency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid latency %q: %w", latency, err)
+		}
+		for _, bandwidth := range splitParam(bandwidths) {
+			bw, err := parseBandwidth(bandwidth)
+			if err != nil {
+				return nil, err
+			}
+			for _, loss := range splitParam(losses) {
+				l, err := strconv.ParseFloat(loss, 32)
+				if err != nil || l < 0 || l > 100 {
+					return nil, fmt.Errorf("invalid packet loss %q", loss)
+				}
+				cells = append(cells, &shapingCell{
+					simulatedNetwork: simulatedNetwork{
+						simulation: fmt.Sprintf("latency-%s-bandwidth-%s-loss-%s", latency, bandwidth, loss),
+						shape:      network.LinkShape{Latency: lat, Bandwidth: bw, Loss: float32(l)},
+					},
+					latency:   latency,
+					bandwidth: bandwidth,
+					loss:      loss,
+				})
+			}
+		}
+	}
+	return cells, nil
+}
+
+func splitParam(param string) []string {
+	var values []string
+	for _, v := range strings.Split(param, ",") {
+		values = append(values, strings.TrimSpace(v))
+	}
+	return values
+}
+
+// parseBandwidth parses a bandwidth in bits per second, optionally with a
+// Kbit, Mbit or Gbit suffix. Zero means unlimited.
+func parseBandwidth(s string) (uint64, error) {
+	multiplier := uint64(1)
+	n := s
+	for suffix, m := range map[string]uint64{"Kbit": 1e3, "Mbit": 1e6, "Gbit": 1e9} {
+		if strings.HasSuffix(s, suffix) {
+			n, multiplier = strings.TrimSuffix(s, suffix), m
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(n, 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid bandwidth %q", s)
+	}
+	return uint64(v * float64(multiplier)), nil
+}
+
+// configureAllNetworks shapes the links of all instances, and waits until
+// they are all configured.
+func configureAllNetworks(ctx context.Context, env *runtime.RunEnv, ic *run.InitContext, n simulatedNetwork) {
+	if ic.GlobalSeq == 1 {
+		msg("################### Round %s network ###################", n.simulation)
+	}
+	shapeNetwork(ctx, env, ic, n.shape, "network-configured-"+n.simulation)
+}
+
+func shapeNetwork(ctx context.Context, env *runtime.RunEnv, ic *run.InitContext, shape network.LinkShape, state string) {
+	ic.NetClient.MustConfigureNetwork(ctx, &network.Config{
+		Network:        "default",
+		Enable:         true,
+		Default:        shape,
+		CallbackState:  sync.State(state),
+		CallbackTarget: env.TestInstanceCount,
+		RoutingPolicy:  network.AllowAll,
+	})
+}
+
+func testShapingRound(ctx context.Context, env *runtime.RunEnv, ic *run.InitContext, round string, desiredAddr string) error {
+	var cell *shapingCell
+	done := 0
+	for i, c := range shapingCells {
+		if c.simulation == round {
+			cell, done = c, i+1
+			break
+		}
+	}
+	start := time.Now()
+	err := testSyncThreadsRound(ctx, env, ic, round, desiredAddr)
+	cell.converged = time.Since(start)
+	if err != nil {
+		msg("%s", formatShapingCells(shapingCells[:done-1]))
+		return err
+	}
+	env.R().RecordPoint("round-"+round+"-convergence-seconds", cell.converged.Seconds())
+	// don't let the shaping of this cell slow down the coordination of the next
+	shapeNetwork(ctx, env, ic, network.LinkShape{}, "network-reset-"+round)
+	if done == len(shapingCells) {
+		msg("%s", formatShapingCells(shapingCells))
+	}
+	return nil
+}
+
+// formatShapingCells renders the convergence time of each cell as a table.
+func formatShapingCells(cells []*shapingCell) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Convergence time by network shaping:\n")
+	fmt.Fprintf(&b, "%-12s %-12s %8s %16s\n", "latency", "bandwidth", "loss %", "convergence")
+	for _, c := range cells {
+		fmt.Fprintf(&b, "%-12s %-12s %8s %16v\n", c.latency, c.bandwidth, c.loss, c.converged.Round(time.Millisecond))
+	}
+	return b.String()
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShapingMatrix(t *testing.T) {
+	cells, err := shapingMatrix("0s, 200ms", "0,1Mbit,512Kbit", "0,1.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cells) != 12 {
+		t.Fatalf("expected 12 cells, got %d", len(cells))
+	}
+	last := cells[len(cells)-1]
+	if last.simulation != "latency-200ms-bandwidth-512Kbit-loss-1.5" {
+		t.Fatalf("unexpected name of the last cell %q", last.simulation)
+	}
+	if last.shape.Latency != 200*time.Millisecond || last.shape.Bandwidth != 512000 || last.shape.Loss != 1.5 {
+		t.Fatalf("unexpected shape of the last cell %+v", last.shape)
+	}
+	for _, c := range [][3]string{
+		{"fast", "0", "0"},
+		{"0s", "1Mb", "0"},
+		{"0s", "-1", "0"},
+		{"0s", "0", "101"},
+	} {
+		if _, err := shapingMatrix(c[0], c[1], c[2]); err == nil {
+			t.Fatalf("expected an error for %v", c)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// summary holds the statistics of a single metric series.
+type summary struct {
+	min    float64
+	max    float64
+	mean   float64
+	median float64
+	p95    float64
+	p99    float64
+	// total is the accumulated amount over the whole run, only meaningful
+	// for metrics which are rates of a counter, e.g., bytes transferred.
+	total float64
+}
+
+// summarize computes the statistics of values. deltas, if given, are the raw
+// counter deltas the values are the rates of, and add up to the total.
+func summarize(values, deltas []float64) summary {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	s := summary{min: sorted[0], max: sorted[len(sorted)-1]}
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	s.mean = sum / float64(len(sorted))
+	s.median = percentile(sorted, 50)
+	s.p95 = percentile(sorted, 95)
+	s.p99 = percentile(sorted, 99)
+	for _, d := range deltas {
+		s.total += d
+	}
+	return s
+}
+
+// percentile returns the nearest-rank percentile of already sorted values.
+func percentile(sorted []float64, pct float64) float64 {
+	rank := int(math.Ceil(pct / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// formatSummaries renders the summaries of the named metrics as a table.
+func formatSummaries(names []string, summaries map[string]summary, withTotal map[string]bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-20s %14s %14s %14s %14s %14s %14s %16s\n", "metric", "min", "max", "mean", "median", "p95", "p99", "total")
+	for _, name := range names {
+		s, ok := summaries[name]
+		if !ok {
+			continue
+		}
+		total := "-"
+		if withTotal[name] {
+			total = fmt.Sprintf("%.2f", s.total)
+		}
+		fmt.Fprintf(&b, "%-20s %14.2f %14.2f %14.2f %14.2f %14.2f %14.2f %16s\n",
+			name, s.min, s.max, s.mean, s.median, s.p95, s.p99, total)
+	}
+	return b.String()
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// threshold is a limit on a statistic of a metric, configured by a test
+// instance param. Thresholds whose param is not set or zero are ignored.
+type threshold struct {
+	param  string
+	metric string
+	stat   string
+	value  func(summary) float64
+}
+
+var thresholds = []threshold{
+	{"max-heap-alloc-mibs", metricHeapAllocMiBs, "max", func(s summary) float64 { return s.max }},
+	{"max-process-rss-mibs", metricProcessRSSMiBs, "max", func(s summary) float64 { return s.max }},
+	{"max-mean-cpu-percent", metricCPUPercent, "mean", func(s summary) float64 { return s.mean }},
+	{"max-p95-cpu-percent", metricCPUPercent, "p95", func(s summary) float64 { return s.p95 }},
+	{"max-mean-goroutines", metricGoroutines, "mean", func(s summary) float64 { return s.mean }},
+	{"max-total-receive-bytes", metricRecvBytes, "total", func(s summary) float64 { return s.total }},
+	{"max-total-transmit-bytes", metricTransmitBytes, "total", func(s summary) float64 { return s.total }},
+}
+
+// checkThresholds returns an error listing every threshold breached by the
+// summarized metrics.
+func (p *measure) checkThresholds(summaries map[string]summary) error {
+	var breaches []string
+	for _, t := range thresholds {
+		if !p.runenv.IsParamSet(t.param) {
+			continue
+		}
+		limit := p.runenv.FloatParam(t.param)
+		if limit <= 0 {
+			continue
+		}
+		s, ok := summaries[t.metric]
+		if !ok {
+			continue
+		}
+		if observed := t.value(s); observed > limit {
+			breaches = append(breaches, fmt.Sprintf("%s %s is %.2f, exceeding the limit %.2f", t.metric, t.stat, observed, limit))
+		}
+	}
+	if len(breaches) == 0 {
+		return nil
+	}
+	return fmt.Errorf("resource usage thresholds breached: %s", strings.Join(breaches, "; "))
+}
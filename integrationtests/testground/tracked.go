@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Register adds c to the collectors of the measure, and tracks the named
+// metrics it exposes, so they are recorded on every tick along with the
+// resource usage, and printed after it. Counters are recorded as per-second
+// rates like the OS counters, gauges as is, summed over all label values in
+// both cases. Other metric types are ignored.
+func (p *measure) Register(c prometheus.Collector, names ...string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for _, name := range names {
+		if p.isTracked(name) {
+			return fmt.Errorf("metric %s is already tracked", name)
+		}
+	}
+	if err := p.collectors.Register(c); err != nil {
+		return err
+	}
+	p.tracked = append(p.tracked, names...)
+	return nil
+}
+
+// Observe records a sample of the named series at the current time, for
+// metrics which are measured by the test as events happen rather than on
+// every tick, e.g., latencies. Samples observed after Stop are ignored.
+func (p *measure) Observe(name string, value float64) {
+	if p.stopped() {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if _, ok := p.metrics[name]; !ok {
+		p.observed = append(p.observed, name)
+	}
+	p.record(name, time.Now(), value)
+	p.report(name, value)
+}
+
+func (p *measure) isTracked(name string) bool {
+	for _, tracked := range p.tracked {
+		if tracked == name {
+			return true
+		}
+	}
+	return false
+}
+
+// collectTracked records the tracked metrics of the registered collectors.
+func (p *measure) collectTracked(ts time.Time) error {
+	if len(p.tracked) == 0 {
+		return nil
+	}
+	// same as for the node_exporter collectors, carry on with whatever
+	// Gather returns on failure.
+	mf, err := p.collectors.Gather()
+	families := make(map[string]*dto.MetricFamily)
+	for _, m := range mf {
+		families[m.GetName()] = m
+	}
+	for _, name := range p.tracked {
+		m, ok := families[name]
+		if !ok {
+			continue
+		}
+		var total float64
+		for _, metric := range m.Metric {
+			switch m.GetType() {
+			case dto.MetricType_COUNTER:
+				total += metric.GetCounter().GetValue()
+			case dto.MetricType_GAUGE:
+				total += metric.GetGauge().GetValue()
+			}
+		}
+		switch m.GetType() {
+		case dto.MetricType_COUNTER:
+			p.trackedRates[name] = true
+			p.collectRate(ts, name, total, p.lastTracked)
+		case dto.MetricType_GAUGE:
+			p.record(name, ts, total)
+			p.report(name, total)
+		}
+	}
+	return err
+}
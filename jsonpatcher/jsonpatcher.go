@@ -16,6 +16,7 @@ import (
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/multiformats/go-multihash"
 	core "github.com/textileio/go-threads/core/db"
+	"github.com/tidwall/gjson"
 )
 
 type operationType int
@@ -24,6 +25,7 @@ const (
 	create operationType = iota
 	save
 	del
+	rename
 )
 
 func (ot operationType) String() (s string) {
@@ -34,10 +36,15 @@ func (ot operationType) String() (s string) {
 		s = "save"
 	case del:
 		s = "delete"
+	case rename:
+		s = "rename"
 	}
 	return s
 }
 
+// idFieldName is the field holding the ID of instances.
+const idFieldName = "_id"
+
 var (
 	log                           = logging.Logger("jsonpatcher")
 	errCantCreateExistingInstance = errors.New("cant't create already existent instance")
@@ -52,7 +59,10 @@ type operation struct {
 
 type jsonPatcher struct{}
 
-var _ core.EventCodec = (*jsonPatcher)(nil)
+var (
+	_ core.EventCodec  = (*jsonPatcher)(nil)
+	_ core.RenameEvent = patchEvent{}
+)
 
 func init() {
 	cbornode.RegisterCborType(patchEvent{})
@@ -81,6 +91,8 @@ func (jp *jsonPatcher) Create(actions []core.Action) ([]core.Event, format.Node,
 			op, err = saveEvent(actions[i].InstanceID, actions[i].Previous, actions[i].Current)
 		case core.Delete:
 			op, err = deleteEvent(actions[i].InstanceID)
+		case core.Rename:
+			op, err = renameEvent(string(actions[i].Current))
 		default:
 			panic("unkown action type")
 		}
@@ -115,7 +127,9 @@ func (jp *jsonPatcher) Reduce(
 	}
 	defer txn.Discard()
 
-	sort.Slice(events, func(i, j int) bool {
+	// events of a record have the order of its actions, which is kept for
+	// events with the same time
+	sort.SliceStable(events, func(i, j int) bool {
 		ei, oki := events[i].(patchEvent)
 		ej, okj := events[j].(patchEvent)
 
@@ -126,8 +140,8 @@ func (jp *jsonPatcher) Reduce(
 		return ei.time().Before(ej.time())
 	})
 
-	actions := make([]core.ReduceAction, len(events))
-	for i, e := range events {
+	actions := make([]core.ReduceAction, 0, len(events))
+	for _, e := range events {
 		je, ok := e.(patchEvent)
 		if !ok {
 			return nil, fmt.Errorf("event unrecognized for jsonpatcher eventcodec")
@@ -148,11 +162,18 @@ func (jp *jsonPatcher) Reduce(
 			if err := indexFunc(e.Collection(), key, nil, je.Patch.JSONPatch, txn); err != nil {
 				return nil, fmt.Errorf("error when indexing created data: %w", err)
 			}
-			actions[i] = core.ReduceAction{Type: core.Create, Collection: e.Collection(), InstanceID: e.InstanceID()}
+			actions = append(actions, core.ReduceAction{Type: core.Create, Collection: e.Collection(), InstanceID: e.InstanceID()})
 			log.Debug("\tcreate operation applied")
 		case save:
 			value, err := txn.Get(key)
 			if errors.Is(err, ds.ErrNotFound) {
+				if !gjson.GetBytes(je.Patch.JSONPatch, idFieldName).Exists() {
+					// the patch of an existing instance doesn't carry its
+					// ID, so it doesn't recreate it if it was deleted by
+					// an event applied before
+					log.Debug("\tsave operation of deleted instance skipped")
+					continue
+				}
 				value = []byte("{}")
 			} else if err != nil {
 				return nil, err
@@ -167,11 +188,16 @@ func (jp *jsonPatcher) Reduce(
 			if err := indexFunc(e.Collection(), key, value, patchedValue, txn); err != nil {
 				return nil, fmt.Errorf("error when indexing created data: %w", err)
 			}
-			actions[i] = core.ReduceAction{Type: core.Save, Collection: e.Collection(), InstanceID: e.InstanceID()}
+			actions = append(actions, core.ReduceAction{Type: core.Save, Collection: e.Collection(), InstanceID: e.InstanceID()})
 			log.Debug("\tsave operation applied")
 		case del:
 			value, err := txn.Get(key)
-			if err != nil {
+			if errors.Is(err, ds.ErrNotFound) {
+				// deleting is idempotent, so peers deleting an instance
+				// at the same time converge
+				log.Debug("\tdelete operation of deleted instance skipped")
+				continue
+			} else if err != nil {
 				return nil, err
 			}
 			if err := txn.Delete(key); err != nil {
@@ -180,8 +206,11 @@ func (jp *jsonPatcher) Reduce(
 			if err := indexFunc(e.Collection(), key, value, nil, txn); err != nil {
 				return nil, fmt.Errorf("error when removing index: %w", err)
 			}
-			actions[i] = core.ReduceAction{Type: core.Delete, Collection: e.Collection(), InstanceID: e.InstanceID()}
+			actions = append(actions, core.ReduceAction{Type: core.Delete, Collection: e.Collection(), InstanceID: e.InstanceID()})
 			log.Debug("\tdelete operation applied")
+		case rename:
+			// renames are applied by the db
+			continue
 		default:
 			return nil, errUnknownOperation
 		}
@@ -240,6 +269,17 @@ func deleteEvent(id core.InstanceID) (*operation, error) {
 	}, nil
 }
 
+func renameEvent(name string) (*operation, error) {
+	patch, err := json.Marshal(name)
+	if err != nil {
+		return nil, err
+	}
+	return &operation{
+		Type:      rename,
+		JSONPatch: patch,
+	}, nil
+}
+
 type patchEvent struct {
 	Timestamp      interface{}
 	ID             core.InstanceID
@@ -285,6 +325,22 @@ func (je patchEvent) Collection() string {
 	return je.CollectionName
 }
 
+func (je patchEvent) RenamedCollection() (string, bool) {
+	if je.Patch.Type != rename {
+		return "", false
+	}
+	var name string
+	if err := json.Unmarshal(je.Patch.JSONPatch, &name); err != nil {
+		return "", false
+	}
+	return name, true
+}
+
+func (je patchEvent) WithCollection(name string) core.Event {
+	je.CollectionName = name
+	return je
+}
+
 type patchEventJson struct {
 	Timestamp      interface{}   `json:"timestamp"`
 	ID             string        `json:"_id"`
@@ -3,12 +3,16 @@ package jsonpatcher
 import (
 	"bytes"
 	"encoding/gob"
+	"io/ioutil"
+	"os"
 	"testing"
 	"time"
 
+	ds "github.com/ipfs/go-datastore"
 	cbornode "github.com/ipfs/go-ipld-cbor"
 	mh "github.com/multiformats/go-multihash"
 	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/util"
 )
 
 type patchEventOld struct {
@@ -79,3 +83,185 @@ func TestJsonPatcher_Migration(t *testing.T) {
 		t.Error("encodable time should be equal to input")
 	}
 }
+
+func TestJsonPatcher_Batch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store, err := util.NewBadgerDatastore(dir, "eventstore", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	jp := New()
+	_, node, err := jp.Create([]core.Action{
+		{Type: core.Create, InstanceID: "a", CollectionName: "abc", Current: []byte(`{"_id":"a","n":1}`)},
+		{Type: core.Save, InstanceID: "a", CollectionName: "abc", Previous: []byte(`{"_id":"a","n":1}`), Current: []byte(`{"_id":"a","n":2}`)},
+		{Type: core.Create, InstanceID: "b", CollectionName: "abc", Current: []byte(`{"_id":"b"}`)},
+		{Type: core.Delete, InstanceID: "b", CollectionName: "abc"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err := jp.EventsFromBytes(node.RawData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 4 {
+		t.Fatalf("expected %d events, got %d", 4, len(events))
+	}
+	// the events of a batch may have the same time
+	for i, e := range events {
+		pe := e.(patchEvent)
+		pe.Timestamp = 1
+		events[i] = pe
+	}
+
+	noIndex := func(string, ds.Key, []byte, []byte, ds.Txn) error { return nil }
+	actions, err := jp.Reduce(events, store, ds.NewKey("/db"), noIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []core.ActionType{core.Create, core.Save, core.Create, core.Delete}
+	for i, a := range actions {
+		if a.Type != expected[i] {
+			t.Fatalf("expected action %d to be %v, got %v", i, expected[i], a.Type)
+		}
+	}
+	a, err := store.Get(ds.NewKey("/db/abc/a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(a) != `{"_id":"a","n":2}` {
+		t.Fatalf("expected saved instance, got %s", a)
+	}
+	if exists, err := store.Has(ds.NewKey("/db/abc/b")); err != nil || exists {
+		t.Fatal("deleted instance should not exist")
+	}
+
+	// a batch is reduced atomically
+	_, node, err = jp.Create([]core.Action{
+		{Type: core.Create, InstanceID: "c", CollectionName: "abc", Current: []byte(`{"_id":"c"}`)},
+		{Type: core.Create, InstanceID: "a", CollectionName: "abc", Current: []byte(`{"_id":"a"}`)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err = jp.EventsFromBytes(node.RawData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := jp.Reduce(events, store, ds.NewKey("/db"), noIndex); err == nil {
+		t.Fatal("creating an existing instance should fail")
+	}
+	if exists, err := store.Has(ds.NewKey("/db/abc/c")); err != nil || exists {
+		t.Fatal("failed batch should not be applied")
+	}
+}
+
+func TestJsonPatcher_RestorePurge(t *testing.T) {
+	jp := New()
+	record := func(actions ...core.Action) []core.Event {
+		_, node, err := jp.Create(actions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		events, err := jp.EventsFromBytes(node.RawData())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return events
+	}
+	created := []byte(`{"_id":"a","n":1}`)
+	deleted := []byte(`{"_id":"a","n":1,"_deleted":1}`)
+	create := record(core.Action{Type: core.Create, InstanceID: "a", CollectionName: "abc", Current: created})
+	tombstone := record(core.Action{Type: core.Save, InstanceID: "a", CollectionName: "abc", Previous: created, Current: deleted})
+	restore := record(core.Action{Type: core.Save, InstanceID: "a", CollectionName: "abc", Previous: deleted, Current: created})
+	purge := record(core.Action{Type: core.Delete, InstanceID: "a", CollectionName: "abc"})
+	// tombstones and restores are plain saves, which peers of any version reduce
+	for _, events := range [][]core.Event{tombstone, restore} {
+		if op := events[0].(patchEvent).Patch.Type; op != save {
+			t.Fatalf("expected a save operation, got %s", op)
+		}
+	}
+
+	noIndex := func(string, ds.Key, []byte, []byte, ds.Txn) error { return nil }
+	// peers apply the restore and purge of each other in different orders,
+	// and a peer may also receive the purge before the tombstone
+	for _, records := range [][][]core.Event{
+		{create, tombstone, restore, purge},
+		{create, tombstone, purge, restore},
+		{create, purge, tombstone, restore, purge},
+	} {
+		dir, err := ioutil.TempDir("", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		store, err := util.NewBadgerDatastore(dir, "eventstore", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, events := range records {
+			if _, err := jp.Reduce(events, store, ds.NewKey("/db"), noIndex); err != nil {
+				t.Fatal(err)
+			}
+		}
+		exists, err := store.Has(ds.NewKey("/db/abc/a"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exists {
+			t.Fatal("purged instance should not exist")
+		}
+		_ = store.Close()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func TestJsonPatcher_Rename(t *testing.T) {
+	jp := New()
+	_, node, err := jp.Create([]core.Action{{Type: core.Rename, CollectionName: "abc", Current: []byte("def")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err := jp.EventsFromBytes(node.RawData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	re, ok := events[0].(core.RenameEvent)
+	if !ok {
+		t.Fatal("event should be a rename event")
+	}
+	if name, ok := re.RenamedCollection(); !ok || name != "def" || re.Collection() != "abc" {
+		t.Fatalf("expected the rename of abc to def, got %s to %s", re.Collection(), name)
+	}
+	if e := re.WithCollection("ghi"); e.Collection() != "ghi" {
+		t.Fatalf("expected the event of ghi, got %s", e.Collection())
+	}
+
+	// renames are applied by the db, not reduced
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store, err := util.NewBadgerDatastore(dir, "eventstore", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	noIndex := func(string, ds.Key, []byte, []byte, ds.Txn) error { return nil }
+	actions, err := jp.Reduce(events, store, ds.NewKey("db"), noIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 0 {
+		t.Fatalf("expected no actions, got %d", len(actions))
+	}
+}
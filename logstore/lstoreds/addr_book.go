@@ -485,16 +485,17 @@ func (ab *DsAddrBook) deleteAddrs(t thread.ID, p peer.ID, addrs []ma.Multiaddr)
 
 	// deletes addresses in place, and avoiding copies until we encounter the first deletion.
 	survived := 0
+Outer:
 	for i, addr := range pr.Addrs {
 		for _, del := range addrs {
 			if addr.Addr.Equal(del) {
-				continue
-			}
-			if i != survived {
-				pr.Addrs[survived] = pr.Addrs[i]
+				continue Outer
 			}
-			survived++
 		}
+		if i != survived {
+			pr.Addrs[survived] = pr.Addrs[i]
+		}
+		survived++
 	}
 	pr.Addrs = pr.Addrs[:survived]
 
@@ -11,17 +11,24 @@ import (
 	badger "github.com/textileio/go-ds-badger"
 	core "github.com/textileio/go-threads/core/logstore"
 	pt "github.com/textileio/go-threads/test"
+	"github.com/textileio/go-threads/util/badger3ds"
+	"github.com/textileio/go-threads/util/badger4ds"
+	"github.com/textileio/go-threads/util/memds"
 )
 
 type datastoreFactory func(tb testing.TB) (ds.Batching, func())
 
 var dstores = map[string]datastoreFactory{
-	"Badger": badgerStore,
+	"Badger":   badgerStore,
+	"BadgerV3": badger3Store,
+	"BadgerV4": badger4Store,
+	"Memory":   memoryStore,
 	// "Leveldb": leveldbStore,
 }
 
 func TestDatastoreLogstore(t *testing.T) {
 	for name, dsFactory := range dstores {
+		dsFactory := dsFactory
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 			pt.LogstoreTest(t, logstoreFactory(t, dsFactory, DefaultOpts()))
@@ -31,6 +38,7 @@ func TestDatastoreLogstore(t *testing.T) {
 
 func TestDatastoreAddrBook(t *testing.T) {
 	for name, dsFactory := range dstores {
+		dsFactory := dsFactory
 		t.Run(name+" Cacheful", func(t *testing.T) {
 			t.Parallel()
 			opts := DefaultOpts()
@@ -53,6 +61,7 @@ func TestDatastoreAddrBook(t *testing.T) {
 
 func TestDatastoreKeyBook(t *testing.T) {
 	for name, dsFactory := range dstores {
+		dsFactory := dsFactory
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 			pt.KeyBookTest(t, keyBookFactory(t, dsFactory))
@@ -62,6 +71,7 @@ func TestDatastoreKeyBook(t *testing.T) {
 
 func TestDatastoreHeadBook(t *testing.T) {
 	for name, dsFactory := range dstores {
+		dsFactory := dsFactory
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 			pt.HeadBookTest(t, headBookFactory(t, dsFactory))
@@ -71,6 +81,7 @@ func TestDatastoreHeadBook(t *testing.T) {
 
 func TestDatastoreMetadataBook(t *testing.T) {
 	for name, dsFactory := range dstores {
+		dsFactory := dsFactory
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 			pt.MetadataBookTest(t, metadataBookFactory(t, dsFactory))
@@ -160,6 +171,42 @@ func badgerStore(tb testing.TB) (ds.Batching, func()) {
 	return store, closer
 }
 
+func badger3Store(tb testing.TB) (ds.Batching, func()) {
+	dataPath, err := ioutil.TempDir(os.TempDir(), "badger3")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	store, err := badger3ds.NewDatastore(dataPath, nil)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	closer := func() {
+		_ = store.Close()
+		_ = os.RemoveAll(dataPath)
+	}
+	return store, closer
+}
+
+func badger4Store(tb testing.TB) (ds.Batching, func()) {
+	dataPath, err := ioutil.TempDir(os.TempDir(), "badger4")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	store, err := badger4ds.NewDatastore(dataPath, nil)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	closer := func() {
+		_ = store.Close()
+		_ = os.RemoveAll(dataPath)
+	}
+	return store, closer
+}
+
+func memoryStore(testing.TB) (ds.Batching, func()) {
+	return memds.New(), func() {}
+}
+
 // func leveldbStore(tb testing.TB) (ds.Datastore, func()) {
 // 	dataPath, err := ioutil.TempDir(os.TempDir(), "leveldb")
 // 	if err != nil {
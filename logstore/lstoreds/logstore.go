@@ -2,6 +2,7 @@ package lstoreds
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	ds "github.com/ipfs/go-datastore"
@@ -18,6 +19,10 @@ var AllowEmptyRestore = false
 
 const EmptyEdgeValue uint64 = 0
 
+// ErrTxnDatastoreRequired indicates a datastore without transactions, which
+// the head book needs.
+var ErrTxnDatastoreRequired = errors.New("logstore requires a transactional datastore")
+
 // Configuration object for datastores
 type Options struct {
 	// The size of the in-memory cache. A value of 0 or lower disables the cache.
@@ -45,8 +50,14 @@ func DefaultOpts() Options {
 	}
 }
 
-// NewLogstore creates a logstore backed by the provided persistent datastore.
+// NewLogstore creates a logstore backed by the provided persistent datastore,
+// which must be transactional.
 func NewLogstore(ctx context.Context, store ds.Batching, opts Options) (core.Logstore, error) {
+	tstore, ok := store.(ds.TxnDatastore)
+	if !ok {
+		return nil, ErrTxnDatastoreRequired
+	}
+
 	addrBook, err := NewAddrBook(ctx, store, opts)
 	if err != nil {
 		return nil, err
@@ -59,7 +70,7 @@ func NewLogstore(ctx context.Context, store ds.Batching, opts Options) (core.Log
 
 	threadMetadata := NewThreadMetadata(store)
 
-	headBook := NewHeadBook(store.(ds.TxnDatastore))
+	headBook := NewHeadBook(tstore)
 
 	ps := lstore.NewLogstore(keyBook, addrBook, headBook, threadMetadata)
 	return ps, nil
@@ -0,0 +1,298 @@
+package net
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pstore "github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// The addresses peers are connected at, inbound or outbound, are written to
+// the logs which have the peers' addresses with the ttl ConfirmedAddrTTL,
+// which each connection refreshes. The peers are dialed at their confirmed
+// addresses first, the most recently confirmed first, and then at the other
+// dialable addresses of their logs. The ttl of the dialable addresses halves
+// each time a peer fails to be dialed, and they're removed after
+// maxAddrFailures failures, while the addresses of the peers without a
+// transport, as the logs are created with, are kept as a last resort.
+
+// DefaultConfirmedAddrTTL is the ttl of the addresses of peers confirmed by
+// connections.
+const DefaultConfirmedAddrTTL = 24 * time.Hour
+
+// maxAddrFailures is the number of failed dials of a peer which its dialable
+// log addresses are removed after.
+const maxAddrFailures = 4
+
+// confirmedDialTimeout bounds the dial of a peer at each of its confirmed
+// addresses.
+var confirmedDialTimeout = DialTimeout / 4
+
+// logAddr is the state of a dialable address of a peer.
+type logAddr struct {
+	addr      ma.Multiaddr
+	confirmed time.Time
+	failures  int
+}
+
+// logAddrs keeps the state of the dialable log addresses of peers, by peer
+// and the string of their transport part.
+type logAddrs struct {
+	lock  sync.Mutex
+	peers map[peer.ID]map[string]*logAddr
+}
+
+func newLogAddrs() *logAddrs {
+	return &logAddrs{peers: make(map[peer.ID]map[string]*logAddr)}
+}
+
+func (a *logAddrs) entry(p peer.ID, addr ma.Multiaddr) *logAddr {
+	addrs, ok := a.peers[p]
+	if !ok {
+		addrs = make(map[string]*logAddr)
+		a.peers[p] = addrs
+	}
+	e, ok := addrs[addr.String()]
+	if !ok {
+		e = &logAddr{addr: addr}
+		addrs[addr.String()] = e
+	}
+	return e
+}
+
+// confirm records the peer was connected at the address at t, which resets
+// its failures.
+func (a *logAddrs) confirm(p peer.ID, addr ma.Multiaddr, t time.Time) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	e := a.entry(p, addr)
+	e.confirmed = t
+	e.failures = 0
+}
+
+// fail records the peer failed to be dialed at the address, returning the
+// number of failures since it was confirmed.
+func (a *logAddrs) fail(p peer.ID, addr ma.Multiaddr) int {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	e := a.entry(p, addr)
+	e.failures++
+	return e.failures
+}
+
+// forget drops the state of the address of the peer.
+func (a *logAddrs) forget(p peer.ID, addr ma.Multiaddr) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	delete(a.peers[p], addr.String())
+	if len(a.peers[p]) == 0 {
+		delete(a.peers, p)
+	}
+}
+
+// confirmed returns the confirmed addresses of the peer, the most recently
+// confirmed first.
+func (a *logAddrs) confirmed(p peer.ID) []ma.Multiaddr {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	var es []*logAddr
+	for _, e := range a.peers[p] {
+		if !e.confirmed.IsZero() {
+			es = append(es, e)
+		}
+	}
+	sort.Slice(es, func(i, j int) bool {
+		return es[i].confirmed.After(es[j].confirmed)
+	})
+	addrs := make([]ma.Multiaddr, len(es))
+	for i, e := range es {
+		addrs[i] = e.addr
+	}
+	return addrs
+}
+
+// confirmedAddrTTL returns the ttl of the confirmed addresses, which is
+// negative if they're not learned.
+func (n *net) confirmedAddrTTL() time.Duration {
+	if n.conf.ConfirmedAddrTTL == 0 {
+		return DefaultConfirmedAddrTTL
+	}
+	return n.conf.ConfirmedAddrTTL
+}
+
+// notifyConfirmedAddrs returns the notifiee confirming the addresses of the
+// peers once they connect.
+func (n *net) notifyConfirmedAddrs() *network.NotifyBundle {
+	return &network.NotifyBundle{
+		ConnectedF: func(_ network.Network, c network.Conn) {
+			go n.confirmAddr(c.RemotePeer(), c.RemoteMultiaddr())
+		},
+	}
+}
+
+// peerLog is a log which has the addresses of a peer.
+type peerLog struct {
+	tid   thread.ID
+	lid   peer.ID
+	addrs []ma.Multiaddr
+}
+
+// peerLogs returns the logs which have the addresses of the peer.
+func (n *net) peerLogs(pid peer.ID) ([]peerLog, error) {
+	ts, err := n.store.Threads()
+	if err != nil {
+		return nil, err
+	}
+	var logs []peerLog
+	for _, id := range ts {
+		info, err := n.store.GetThread(id)
+		if err != nil {
+			return nil, err
+		}
+		for _, lg := range info.Logs {
+			var addrs []ma.Multiaddr
+			for _, addr := range lg.Addrs {
+				if _, p, err := splitPeerAddr(addr); err == nil && p == pid {
+					addrs = append(addrs, addr)
+				}
+			}
+			if len(addrs) > 0 {
+				logs = append(logs, peerLog{tid: id, lid: lg.ID, addrs: addrs})
+			}
+		}
+	}
+	return logs, nil
+}
+
+// confirmAddr writes the address the peer is connected at to the logs which
+// have its addresses, refreshing its ttl.
+func (n *net) confirmAddr(pid peer.ID, transport ma.Multiaddr) {
+	ttl := n.confirmedAddrTTL()
+	if ttl < 0 || pid == n.host.ID() || transport == nil || len(transport.Bytes()) == 0 {
+		return
+	}
+	logs, err := n.peerLogs(pid)
+	if err != nil {
+		log.Errorf("listing logs of %s: %v", pid, err)
+		return
+	} else if len(logs) == 0 {
+		return
+	}
+	paddr, err := ma.NewComponent(ma.ProtocolWithCode(ma.P_P2P).Name, pid.String())
+	if err != nil {
+		return
+	}
+	addr := transport.Encapsulate(paddr)
+	for _, lg := range logs {
+		if err := n.store.AddAddr(lg.tid, lg.lid, addr, ttl); err != nil {
+			log.Errorf("adding confirmed address %s to log %s: %v", addr, lg.lid, err)
+			return
+		}
+	}
+	n.addrs.confirm(pid, transport, time.Now())
+	log.Debugf("confirmed address %s of %s", transport, pid)
+}
+
+// decayAddrs halves the ttl of the dialable log addresses of the peer, which
+// failed to be dialed, removing those which failed maxAddrFailures times.
+func (n *net) decayAddrs(pid peer.ID) {
+	ttl := n.confirmedAddrTTL()
+	if ttl < 0 {
+		return
+	}
+	logs, err := n.peerLogs(pid)
+	if err != nil {
+		log.Errorf("listing logs of %s: %v", pid, err)
+		return
+	}
+	failures := make(map[string]int)
+	for _, lg := range logs {
+		for _, addr := range lg.addrs {
+			transport, err := getDialable(addr)
+			if err != nil {
+				continue
+			}
+			f, ok := failures[transport.String()]
+			if !ok {
+				f = n.addrs.fail(pid, transport)
+				failures[transport.String()] = f
+			}
+			if f >= maxAddrFailures {
+				// a zero ttl removes the address
+				if err := n.store.SetAddr(lg.tid, lg.lid, addr, 0); err != nil {
+					log.Errorf("removing address %s from log %s: %v", addr, lg.lid, err)
+				}
+				n.addrs.forget(pid, transport)
+			} else if err := n.store.SetAddr(lg.tid, lg.lid, addr, ttl>>f); err != nil {
+				log.Errorf("decaying address %s of log %s: %v", addr, lg.lid, err)
+			}
+		}
+	}
+}
+
+// connectLogAddrs connects to the peer, unless it's connected, at its
+// confirmed log addresses one at a time, the most recently confirmed first.
+// The other dialable addresses of its logs are then added to those the host
+// dials it at.
+func (n *net) connectLogAddrs(ctx context.Context, pid peer.ID) {
+	if n.confirmedAddrTTL() < 0 || n.host.Network().Connectedness(pid) == network.Connected {
+		return
+	}
+	logs, err := n.peerLogs(pid)
+	if err != nil {
+		log.Errorf("listing logs of %s: %v", pid, err)
+		return
+	}
+	dialable := make(map[string]ma.Multiaddr)
+	for _, lg := range logs {
+		for _, addr := range lg.addrs {
+			if transport, err := getDialable(addr); err == nil {
+				dialable[transport.String()] = transport
+			}
+		}
+	}
+	for _, addr := range n.addrs.confirmed(pid) {
+		if _, ok := dialable[addr.String()]; !ok {
+			continue
+		}
+		delete(dialable, addr.String())
+		cctx, cancel := context.WithTimeout(ctx, confirmedDialTimeout)
+		err := n.host.Connect(cctx, peer.AddrInfo{ID: pid, Addrs: []ma.Multiaddr{addr}})
+		cancel()
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+		log.Debugf("dialing %s at confirmed address %s failed: %v", pid, addr, err)
+	}
+	for _, addr := range dialable {
+		n.host.Peerstore().AddAddr(pid, addr, pstore.TempAddrTTL)
+	}
+}
+
+// addLogAddrs adds the addresses of a log received from a peer, the dialable
+// ones with the ttl of the confirmed addresses so that they decay unless
+// they're confirmed, and the others permanently.
+func (n *net) addLogAddrs(tid thread.ID, lid peer.ID, addrs []ma.Multiaddr) error {
+	ttl := n.confirmedAddrTTL()
+	if ttl < 0 {
+		return n.store.AddAddrs(tid, lid, addrs, pstore.PermanentAddrTTL)
+	}
+	var dialable, original []ma.Multiaddr
+	for _, addr := range addrs {
+		if _, err := getDialable(addr); err == nil {
+			dialable = append(dialable, addr)
+		} else {
+			original = append(original, addr)
+		}
+	}
+	if err := n.store.AddAddrs(tid, lid, original, pstore.PermanentAddrTTL); err != nil {
+		return err
+	}
+	return n.store.AddAddrs(tid, lid, dialable, ttl)
+}
@@ -0,0 +1,129 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestLogAddrs(t *testing.T) {
+	a := newLogAddrs()
+	p := peer.ID("p")
+	a1, a2 := ma.StringCast("/ip4/127.0.0.1/tcp/1"), ma.StringCast("/ip4/127.0.0.1/tcp/2")
+	now := time.Now()
+
+	a.confirm(p, a1, now)
+	a.confirm(p, a2, now.Add(time.Second))
+	if c := a.confirmed(p); len(c) != 2 || !c[0].Equal(a2) || !c[1].Equal(a1) {
+		t.Fatalf("expected most recently confirmed address first, got %v", c)
+	}
+	if f := a.fail(p, a2); f != 1 {
+		t.Fatalf("expected 1 failure, got %d", f)
+	}
+	a.confirm(p, a1, now.Add(2*time.Second))
+	if c := a.confirmed(p); !c[0].Equal(a1) {
+		t.Fatalf("expected reconfirmed address first, got %v", c)
+	}
+	if f := a.fail(p, a2); f != 2 {
+		t.Fatalf("expected 2 failures, got %d", f)
+	}
+	a.confirm(p, a2, now.Add(3*time.Second))
+	if f := a.fail(p, a2); f != 1 {
+		t.Fatalf("expected confirmation to reset failures, got %d", f)
+	}
+
+	a.forget(p, a1)
+	a.forget(p, a2)
+	if len(a.peers) != 0 {
+		t.Fatalf("expected forgotten addresses to be dropped, got %v", a.peers)
+	}
+}
+
+func TestNet_ConfirmedAddrs(t *testing.T) {
+	noPubSub := func(c *Config) { c.PubSub = false }
+	n1 := makeNetwork(t, noPubSub)
+	defer n1.Close()
+	n2 := makeNetwork(t, noPubSub)
+	defer n2.Close()
+
+	ctx := context.Background()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	info := createThread(t, ctx, n1)
+	raddr, err := ma.NewMultiaddr("/p2p/" + n2.Host().ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n1.AddReplicator(ctx, info.ID, raddr); err != nil {
+		t.Fatal(err)
+	}
+	lid := info.GetFirstPrivKeyLog().ID
+
+	// the address n2 is connected at is written to the log
+	var confirmed ma.Multiaddr
+	for i := 0; i < 50 && confirmed == nil; i++ {
+		time.Sleep(100 * time.Millisecond)
+		confirmed = dialableAddr(t, n1, info.ID, lid, n2.Host().ID())
+	}
+	if confirmed == nil {
+		t.Fatal("expected confirmed address to be written to the log")
+	}
+	if c := n1.(*net).addrs.confirmed(n2.Host().ID()); len(c) != 1 {
+		t.Fatalf("expected 1 confirmed address, got %v", c)
+	}
+
+	// failed dials decay the address until it's removed
+	dialErr := errors.New("unreachable")
+	for i := 1; i < maxAddrFailures; i++ {
+		n1.(*net).dialed(ctx, n2.Host().ID(), dialErr)
+		if dialableAddr(t, n1, info.ID, lid, n2.Host().ID()) == nil {
+			t.Fatalf("expected address to be kept after %d failures", i)
+		}
+	}
+	n1.(*net).dialed(ctx, n2.Host().ID(), dialErr)
+	if addr := dialableAddr(t, n1, info.ID, lid, n2.Host().ID()); addr != nil {
+		t.Fatalf("expected address %s to be removed", addr)
+	}
+	if c := n1.(*net).addrs.confirmed(n2.Host().ID()); len(c) != 0 {
+		t.Fatalf("expected removed address to be forgotten, got %v", c)
+	}
+	addrs, err := n1.(*net).store.Addrs(info.ID, lid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var original bool
+	for _, addr := range addrs {
+		if addr.Equal(raddr) {
+			original = true
+		}
+	}
+	if !original {
+		t.Fatalf("expected original address to be kept, got %v", addrs)
+	}
+}
+
+// noConfirmedAddrs doesn't write the addresses the peers connect at to their
+// logs, so that the peers are unreachable once their addresses are cleared
+// from the peerstore, and their logs keep the addresses they're created with.
+func noConfirmedAddrs(c *Config) { c.ConfirmedAddrTTL = -1 }
+
+// dialableAddr returns the address of the peer with a transport in the log,
+// if any.
+func dialableAddr(t *testing.T, n core.Net, id thread.ID, lid, pid peer.ID) ma.Multiaddr {
+	addrs, err := n.(*net).store.Addrs(id, lid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, addr := range addrs {
+		if transport, p, err := splitPeerAddr(addr); err == nil && p == pid && transport != nil {
+			return addr
+		}
+	}
+	return nil
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-ipld-format"
@@ -174,6 +176,34 @@ func (c *Client) GetThread(ctx context.Context, id thread.ID, opts ...core.Threa
 	return threadInfoFromProto(resp)
 }
 
+func (c *Client) GetThreadSummary(ctx context.Context, id thread.ID, opts ...core.ThreadOption) (summary core.ThreadSummary, err error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	resp, err := c.c.GetThreadSummary(ctx, &pb.GetThreadSummaryRequest{
+		ThreadID: id.Bytes(),
+	})
+	if err != nil {
+		return
+	}
+	summary.ID, err = thread.Cast(resp.ThreadID)
+	if err != nil {
+		return
+	}
+	summary.HasServiceKey = resp.HasServiceKey
+	summary.HasReadKey = resp.HasReadKey
+	summary.LogCount = int(resp.LogCount)
+	summary.RecordCount = resp.RecordCount
+	summary.PullInterval = time.Duration(resp.PullInterval)
+	summary.PullPaused = resp.PullPaused
+	if resp.LastUpdated != 0 {
+		summary.LastUpdated = time.Unix(0, resp.LastUpdated)
+	}
+	return summary, nil
+}
+
 func (c *Client) PullThread(ctx context.Context, id thread.ID, opts ...core.ThreadOption) error {
 	args := &core.ThreadOptions{}
 	for _, opt := range opts {
@@ -186,6 +216,32 @@ func (c *Client) PullThread(ctx context.Context, id thread.ID, opts ...core.Thre
 	return err
 }
 
+func (c *Client) SetPullInterval(ctx context.Context, id thread.ID, interval time.Duration, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	_, err := c.c.SetPullInterval(ctx, &pb.SetPullIntervalRequest{
+		ThreadID: id.Bytes(),
+		Interval: int64(interval),
+	})
+	return err
+}
+
+func (c *Client) SetPullPaused(ctx context.Context, id thread.ID, paused bool, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	_, err := c.c.SetPullPaused(ctx, &pb.SetPullPausedRequest{
+		ThreadID: id.Bytes(),
+		Paused:   paused,
+	})
+	return err
+}
+
 func (c *Client) DeleteThread(ctx context.Context, id thread.ID, opts ...core.ThreadOption) error {
 	args := &core.ThreadOptions{}
 	for _, opt := range opts {
@@ -194,6 +250,7 @@ func (c *Client) DeleteThread(ctx context.Context, id thread.ID, opts ...core.Th
 	ctx = thread.NewTokenContext(ctx, args.Token)
 	_, err := c.c.DeleteThread(ctx, &pb.DeleteThreadRequest{
 		ThreadID: id.Bytes(),
+		Announce: args.AnnounceDeletion,
 	})
 	return err
 }
@@ -204,16 +261,33 @@ func (c *Client) AddReplicator(ctx context.Context, id thread.ID, paddr ma.Multi
 		opt(args)
 	}
 	ctx = thread.NewTokenContext(ctx, args.Token)
-	resp, err := c.c.AddReplicator(ctx, &pb.AddReplicatorRequest{
+	req := &pb.AddReplicatorRequest{
 		ThreadID: id.Bytes(),
 		Addr:     paddr.Bytes(),
-	})
+	}
+	if !args.ReplicatorExpiry.IsZero() {
+		req.Expiry = args.ReplicatorExpiry.UnixNano()
+	}
+	resp, err := c.c.AddReplicator(ctx, req)
 	if err != nil {
 		return
 	}
 	return peer.IDFromBytes(resp.PeerID)
 }
 
+func (c *Client) RemoveReplicator(ctx context.Context, id thread.ID, paddr ma.Multiaddr, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	_, err := c.c.RemoveReplicator(ctx, &pb.RemoveReplicatorRequest{
+		ThreadID: id.Bytes(),
+		Addr:     paddr.Bytes(),
+	})
+	return err
+}
+
 func (c *Client) CreateRecord(ctx context.Context, id thread.ID, body format.Node, opts ...core.ThreadOption) (core.ThreadRecord, error) {
 	args := &core.ThreadOptions{}
 	for _, opt := range opts {
@@ -229,7 +303,7 @@ func (c *Client) CreateRecord(ctx context.Context, id thread.ID, body format.Nod
 		Body:     body.RawData(),
 	})
 	if err != nil {
-		return nil, err
+		return nil, recordError(err)
 	}
 	return threadRecordFromProto(resp, info.Key.Service())
 }
@@ -250,7 +324,15 @@ func (c *Client) AddRecord(ctx context.Context, id thread.ID, lid peer.ID, rec c
 		LogID:    lidb,
 		Record:   util.RecFromServiceRec(prec),
 	})
-	return err
+	return recordError(err)
+}
+
+func (c *Client) GetMaxRecordSize(ctx context.Context) (int, error) {
+	resp, err := c.c.GetMaxRecordSize(ctx, &pb.GetMaxRecordSizeRequest{})
+	if err != nil {
+		return 0, err
+	}
+	return int(resp.Size), nil
 }
 
 func (c *Client) GetRecord(ctx context.Context, id thread.ID, rid cid.Cid, opts ...core.ThreadOption) (core.Record, error) {
@@ -283,9 +365,14 @@ func (c *Client) Subscribe(ctx context.Context, opts ...core.SubOption) (<-chan
 	for i, id := range args.ThreadIDs {
 		ids[i] = id.Bytes()
 	}
+	logIDs := make([][]byte, len(args.LogIDs))
+	for i, id := range args.LogIDs {
+		logIDs[i], _ = id.Marshal()
+	}
 	ctx = thread.NewTokenContext(ctx, args.Token)
 	stream, err := c.c.Subscribe(ctx, &pb.SubscribeRequest{
 		ThreadIDs: ids,
+		LogIDs:    logIDs,
 	})
 	if err != nil {
 		return nil, err
@@ -327,10 +414,35 @@ func (c *Client) Subscribe(ctx context.Context, opts ...core.SubOption) (<-chan
 			if err != nil {
 				log.Fatalf("error unpacking record: %v", err)
 			}
-			channel <- rec
+			select {
+			case channel <- rec:
+			case <-ctx.Done():
+				return
+			}
 		}
 	}()
-	return channel, nil
+	if args.BufferSize <= 0 {
+		return channel, nil
+	}
+	// records are buffered as they're received, so the buffer of the
+	// subscription of the service is always read
+	return util.BufferRecords(ctx, channel, args.BufferSize, args.Dropped), nil
+}
+
+// ListPeerStats returns the sync statistics of the peers kept by the network,
+// ordered by peer.
+func (c *Client) ListPeerStats(ctx context.Context) ([]core.PeerSyncStats, error) {
+	reply, err := c.c.ListPeerStats(ctx, &pb.ListPeerStatsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	list := make([]core.PeerSyncStats, len(reply.Stats))
+	for i, ps := range reply.Stats {
+		if list[i], err = peerStatsFromProto(ps); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
 }
 
 func getThreadKeys(args *core.NewThreadOptions) (*pb.Keys, error) {
@@ -439,3 +551,43 @@ func threadRecordFromProto(reply *pb.NewRecordReply, key crypto.DecryptionKey) (
 	}
 	return net.NewRecord(rec, threadID, logID), nil
 }
+
+func peerStatsFromProto(ps *pb.PeerStats) (stats core.PeerSyncStats, err error) {
+	stats.Peer, err = peer.IDFromBytes(ps.PeerID)
+	if err != nil {
+		return
+	}
+	stats.LastPushed = fromUnixNano(ps.LastPushed)
+	stats.LastReceived = fromUnixNano(ps.LastReceived)
+	stats.FailedPushes = make(map[string]int64, len(ps.FailedPushes))
+	for _, f := range ps.FailedPushes {
+		stats.FailedPushes[f.Class] = f.Count
+	}
+	stats.Addrs = make([]ma.Multiaddr, len(ps.Addrs))
+	for i, addr := range ps.Addrs {
+		if stats.Addrs[i], err = ma.NewMultiaddrBytes(addr); err != nil {
+			return
+		}
+	}
+	stats.Down = ps.Down
+	stats.BackoffUntil = fromUnixNano(ps.BackoffUntil)
+	return stats, nil
+}
+
+// fromUnixNano returns the time of the unix time in nanoseconds, or the zero
+// time if it's zero.
+func fromUnixNano(nsec int64) time.Time {
+	if nsec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nsec)
+}
+
+// recordError returns ErrRecordTooLarge if the record was denied for its size.
+func recordError(err error) error {
+	if st, ok := status.FromError(err); ok && st.Code() == codes.OutOfRange {
+		msg := strings.TrimPrefix(st.Message(), core.ErrRecordTooLarge.Error()+": ")
+		return fmt.Errorf("%w: %s", core.ErrRecordTooLarge, msg)
+	}
+	return err
+}
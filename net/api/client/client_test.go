@@ -3,6 +3,7 @@ package client_test
 import (
 	"context"
 	crand "crypto/rand"
+	"errors"
 	"log"
 	"sync"
 	"testing"
@@ -18,6 +19,7 @@ import (
 	core "github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
 	sym "github.com/textileio/crypto/symmetric"
+	"github.com/textileio/go-threads/net"
 	"github.com/textileio/go-threads/net/api"
 	. "github.com/textileio/go-threads/net/api/client"
 	"github.com/textileio/go-threads/util"
@@ -120,6 +122,27 @@ func TestClient_GetThread(t *testing.T) {
 	})
 }
 
+func TestClient_GetThreadSummary(t *testing.T) {
+	t.Parallel()
+	_, client, done := setup(t)
+	defer done()
+
+	info := createThread(t, client)
+
+	t.Run("test get thread summary", func(t *testing.T) {
+		summary, err := client.GetThreadSummary(context.Background(), info.ID)
+		if err != nil {
+			t.Fatalf("failed to get thread summary: %v", err)
+		}
+		if !summary.ID.Equals(info.ID) {
+			t.Fatal("got bad ID from get thread summary")
+		}
+		if !summary.HasServiceKey || !summary.HasReadKey || summary.LogCount != len(info.Logs) {
+			t.Fatalf("got bad summary %+v", summary)
+		}
+	})
+}
+
 func TestClient_PullThread(t *testing.T) {
 	t.Parallel()
 	_, client, done := setup(t)
@@ -134,6 +157,31 @@ func TestClient_PullThread(t *testing.T) {
 	})
 }
 
+func TestClient_SetPullSettings(t *testing.T) {
+	t.Parallel()
+	_, client, done := setup(t)
+	defer done()
+
+	info := createThread(t, client)
+
+	t.Run("test set pull settings", func(t *testing.T) {
+		ctx := context.Background()
+		if err := client.SetPullInterval(ctx, info.ID, time.Hour); err != nil {
+			t.Fatalf("failed to set pull interval: %v", err)
+		}
+		if err := client.SetPullPaused(ctx, info.ID, true); err != nil {
+			t.Fatalf("failed to set pull paused: %v", err)
+		}
+		summary, err := client.GetThreadSummary(ctx, info.ID)
+		if err != nil {
+			t.Fatalf("failed to get thread summary: %v", err)
+		}
+		if summary.PullInterval != time.Hour || !summary.PullPaused {
+			t.Fatalf("got bad pull settings in summary %+v", summary)
+		}
+	})
+}
+
 func TestClient_DeleteThread(t *testing.T) {
 	t.Parallel()
 	_, client, done := setup(t)
@@ -176,6 +224,40 @@ func TestClient_AddReplicator(t *testing.T) {
 	})
 }
 
+func TestClient_RemoveReplicator(t *testing.T) {
+	t.Parallel()
+	_, client1, done1 := setup(t)
+	defer done1()
+	hostAddr2, client2, done2 := setup(t)
+	defer done2()
+
+	info := createThread(t, client1)
+	hostID2, err := client2.GetHostID(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := peerAddr(t, hostAddr2, hostID2)
+	expiry := time.Now().Add(time.Hour)
+	if _, err = client1.AddReplicator(context.Background(), info.ID, addr, core.WithReplicatorExpiry(expiry)); err != nil {
+		t.Fatalf("failed to add replicator: %v", err)
+	}
+
+	t.Run("test remove replicator", func(t *testing.T) {
+		if err := client1.RemoveReplicator(context.Background(), info.ID, addr); err != nil {
+			t.Fatalf("failed to remove replicator: %v", err)
+		}
+		info2, err := client1.GetThread(context.Background(), info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, lg := range info2.Logs {
+			if len(lg.Addrs) != 1 {
+				t.Fatalf("expected replicator address to be withdrawn, got %v", lg.Addrs)
+			}
+		}
+	})
+}
+
 func TestClient_CreateRecord(t *testing.T) {
 	t.Parallel()
 	_, client, done := setup(t)
@@ -276,6 +358,35 @@ func TestClient_AddRecord(t *testing.T) {
 	})
 }
 
+func TestClient_GetMaxRecordSize(t *testing.T) {
+	t.Parallel()
+	_, client, done := setup(t)
+	defer done()
+
+	t.Run("test get max record size", func(t *testing.T) {
+		size, err := client.GetMaxRecordSize(context.Background())
+		if err != nil {
+			t.Fatalf("failed to get max record size: %v", err)
+		}
+		if size != net.DefaultMaxRecordSize {
+			t.Fatalf("expected max record size %d, got %d", net.DefaultMaxRecordSize, size)
+		}
+	})
+
+	t.Run("test create too large record", func(t *testing.T) {
+		info := createThread(t, client)
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": make([]byte, net.DefaultMaxRecordSize),
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = client.CreateRecord(context.Background(), info.ID, body); !errors.Is(err, core.ErrRecordTooLarge) {
+			t.Fatalf("expected error %v, got %v", core.ErrRecordTooLarge, err)
+		}
+	})
+}
+
 func TestClient_GetRecord(t *testing.T) {
 	t.Parallel()
 	_, client, done := setup(t)
@@ -363,6 +474,83 @@ func TestClient_Subscribe(t *testing.T) {
 		}
 		lock.Unlock()
 	})
+
+	t.Run("test subscribe filtered by logs", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sk, _, err := crypto.GenerateEd25519Key(crand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		otherLog, err := peer.IDFromPrivateKey(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sub, err := client2.Subscribe(ctx, core.WithSubFilter(info.ID), core.WithSubFilterLogs(otherLog))
+		if err != nil {
+			t.Fatalf("failed to subscribe to thread: %v", err)
+		}
+		body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar3"}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec, err := client1.CreateRecord(context.Background(), info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case r := <-sub:
+			t.Fatalf("expected no record of another log, got %s", r.Value().Cid())
+		case <-time.After(time.Second * 5):
+		}
+
+		// the record is still added to the log
+		if _, err := client2.GetRecord(context.Background(), info.ID, rec.Value().Cid()); err != nil {
+			t.Fatalf("failed to get record: %v", err)
+		}
+	})
+}
+
+func TestClient_ListPeerStats(t *testing.T) {
+	t.Parallel()
+	_, client1, done1 := setup(t)
+	defer done1()
+	hostAddr2, client2, done2 := setup(t)
+	defer done2()
+
+	info := createThread(t, client1)
+	hostID2, err := client2.GetHostID(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client1.AddReplicator(context.Background(), info.ID, peerAddr(t, hostAddr2, hostID2)); err != nil {
+		t.Fatalf("failed to add replicator: %v", err)
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"foo": "bar",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client1.CreateRecord(context.Background(), info.ID, body); err != nil {
+		t.Fatalf("failed to create record: %v", err)
+	}
+
+	t.Run("test list peer stats", func(t *testing.T) {
+		for i := 0; ; i++ {
+			list, err := client1.ListPeerStats(context.Background())
+			if err != nil {
+				t.Fatalf("failed to list peer stats: %v", err)
+			}
+			if len(list) == 1 && list[0].Peer == hostID2 && !list[0].LastPushed.IsZero() {
+				break
+			}
+			if i == 50 {
+				t.Fatalf("expected record to be pushed to replicator, got %+v", list)
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
 }
 
 func TestClient_Close(t *testing.T) {
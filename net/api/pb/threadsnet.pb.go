@@ -635,7 +635,7 @@ func (x *GetThreadRequest) GetThreadID() []byte {
 	return nil
 }
 
-type PullThreadRequest struct {
+type GetThreadSummaryRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -643,8 +643,8 @@ type PullThreadRequest struct {
 	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
 }
 
-func (x *PullThreadRequest) Reset() {
-	*x = PullThreadRequest{}
+func (x *GetThreadSummaryRequest) Reset() {
+	*x = GetThreadSummaryRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_threadsnet_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -652,13 +652,13 @@ func (x *PullThreadRequest) Reset() {
 	}
 }
 
-func (x *PullThreadRequest) String() string {
+func (x *GetThreadSummaryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PullThreadRequest) ProtoMessage() {}
+func (*GetThreadSummaryRequest) ProtoMessage() {}
 
-func (x *PullThreadRequest) ProtoReflect() protoreflect.Message {
+func (x *GetThreadSummaryRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_threadsnet_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -670,26 +670,35 @@ func (x *PullThreadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PullThreadRequest.ProtoReflect.Descriptor instead.
-func (*PullThreadRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetThreadSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetThreadSummaryRequest) Descriptor() ([]byte, []int) {
 	return file_threadsnet_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *PullThreadRequest) GetThreadID() []byte {
+func (x *GetThreadSummaryRequest) GetThreadID() []byte {
 	if x != nil {
 		return x.ThreadID
 	}
 	return nil
 }
 
-type PullThreadReply struct {
+type ThreadSummaryReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	ThreadID      []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	HasServiceKey bool   `protobuf:"varint,2,opt,name=hasServiceKey,proto3" json:"hasServiceKey,omitempty"`
+	HasReadKey    bool   `protobuf:"varint,3,opt,name=hasReadKey,proto3" json:"hasReadKey,omitempty"`
+	LogCount      int64  `protobuf:"varint,4,opt,name=logCount,proto3" json:"logCount,omitempty"`
+	RecordCount   int64  `protobuf:"varint,5,opt,name=recordCount,proto3" json:"recordCount,omitempty"`
+	LastUpdated   int64  `protobuf:"varint,6,opt,name=lastUpdated,proto3" json:"lastUpdated,omitempty"`
+	PullInterval  int64  `protobuf:"varint,7,opt,name=pullInterval,proto3" json:"pullInterval,omitempty"`
+	PullPaused    bool   `protobuf:"varint,8,opt,name=pullPaused,proto3" json:"pullPaused,omitempty"`
 }
 
-func (x *PullThreadReply) Reset() {
-	*x = PullThreadReply{}
+func (x *ThreadSummaryReply) Reset() {
+	*x = ThreadSummaryReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_threadsnet_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -697,13 +706,13 @@ func (x *PullThreadReply) Reset() {
 	}
 }
 
-func (x *PullThreadReply) String() string {
+func (x *ThreadSummaryReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PullThreadReply) ProtoMessage() {}
+func (*ThreadSummaryReply) ProtoMessage() {}
 
-func (x *PullThreadReply) ProtoReflect() protoreflect.Message {
+func (x *ThreadSummaryReply) ProtoReflect() protoreflect.Message {
 	mi := &file_threadsnet_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -715,12 +724,68 @@ func (x *PullThreadReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PullThreadReply.ProtoReflect.Descriptor instead.
-func (*PullThreadReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use ThreadSummaryReply.ProtoReflect.Descriptor instead.
+func (*ThreadSummaryReply) Descriptor() ([]byte, []int) {
 	return file_threadsnet_proto_rawDescGZIP(), []int{11}
 }
 
-type DeleteThreadRequest struct {
+func (x *ThreadSummaryReply) GetThreadID() []byte {
+	if x != nil {
+		return x.ThreadID
+	}
+	return nil
+}
+
+func (x *ThreadSummaryReply) GetHasServiceKey() bool {
+	if x != nil {
+		return x.HasServiceKey
+	}
+	return false
+}
+
+func (x *ThreadSummaryReply) GetHasReadKey() bool {
+	if x != nil {
+		return x.HasReadKey
+	}
+	return false
+}
+
+func (x *ThreadSummaryReply) GetLogCount() int64 {
+	if x != nil {
+		return x.LogCount
+	}
+	return 0
+}
+
+func (x *ThreadSummaryReply) GetRecordCount() int64 {
+	if x != nil {
+		return x.RecordCount
+	}
+	return 0
+}
+
+func (x *ThreadSummaryReply) GetLastUpdated() int64 {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return 0
+}
+
+func (x *ThreadSummaryReply) GetPullInterval() int64 {
+	if x != nil {
+		return x.PullInterval
+	}
+	return 0
+}
+
+func (x *ThreadSummaryReply) GetPullPaused() bool {
+	if x != nil {
+		return x.PullPaused
+	}
+	return false
+}
+
+type PullThreadRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -728,8 +793,8 @@ type DeleteThreadRequest struct {
 	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
 }
 
-func (x *DeleteThreadRequest) Reset() {
-	*x = DeleteThreadRequest{}
+func (x *PullThreadRequest) Reset() {
+	*x = PullThreadRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_threadsnet_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -737,13 +802,13 @@ func (x *DeleteThreadRequest) Reset() {
 	}
 }
 
-func (x *DeleteThreadRequest) String() string {
+func (x *PullThreadRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteThreadRequest) ProtoMessage() {}
+func (*PullThreadRequest) ProtoMessage() {}
 
-func (x *DeleteThreadRequest) ProtoReflect() protoreflect.Message {
+func (x *PullThreadRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_threadsnet_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -755,26 +820,26 @@ func (x *DeleteThreadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteThreadRequest.ProtoReflect.Descriptor instead.
-func (*DeleteThreadRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PullThreadRequest.ProtoReflect.Descriptor instead.
+func (*PullThreadRequest) Descriptor() ([]byte, []int) {
 	return file_threadsnet_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *DeleteThreadRequest) GetThreadID() []byte {
+func (x *PullThreadRequest) GetThreadID() []byte {
 	if x != nil {
 		return x.ThreadID
 	}
 	return nil
 }
 
-type DeleteThreadReply struct {
+type PullThreadReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *DeleteThreadReply) Reset() {
-	*x = DeleteThreadReply{}
+func (x *PullThreadReply) Reset() {
+	*x = PullThreadReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_threadsnet_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -782,13 +847,13 @@ func (x *DeleteThreadReply) Reset() {
 	}
 }
 
-func (x *DeleteThreadReply) String() string {
+func (x *PullThreadReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteThreadReply) ProtoMessage() {}
+func (*PullThreadReply) ProtoMessage() {}
 
-func (x *DeleteThreadReply) ProtoReflect() protoreflect.Message {
+func (x *PullThreadReply) ProtoReflect() protoreflect.Message {
 	mi := &file_threadsnet_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -800,22 +865,22 @@ func (x *DeleteThreadReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteThreadReply.ProtoReflect.Descriptor instead.
-func (*DeleteThreadReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use PullThreadReply.ProtoReflect.Descriptor instead.
+func (*PullThreadReply) Descriptor() ([]byte, []int) {
 	return file_threadsnet_proto_rawDescGZIP(), []int{13}
 }
 
-type AddReplicatorRequest struct {
+type SetPullIntervalRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
-	Addr     []byte `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Interval int64  `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
 }
 
-func (x *AddReplicatorRequest) Reset() {
-	*x = AddReplicatorRequest{}
+func (x *SetPullIntervalRequest) Reset() {
+	*x = SetPullIntervalRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_threadsnet_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -823,13 +888,13 @@ func (x *AddReplicatorRequest) Reset() {
 	}
 }
 
-func (x *AddReplicatorRequest) String() string {
+func (x *SetPullIntervalRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddReplicatorRequest) ProtoMessage() {}
+func (*SetPullIntervalRequest) ProtoMessage() {}
 
-func (x *AddReplicatorRequest) ProtoReflect() protoreflect.Message {
+func (x *SetPullIntervalRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_threadsnet_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -841,35 +906,33 @@ func (x *AddReplicatorRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddReplicatorRequest.ProtoReflect.Descriptor instead.
-func (*AddReplicatorRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetPullIntervalRequest.ProtoReflect.Descriptor instead.
+func (*SetPullIntervalRequest) Descriptor() ([]byte, []int) {
 	return file_threadsnet_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *AddReplicatorRequest) GetThreadID() []byte {
+func (x *SetPullIntervalRequest) GetThreadID() []byte {
 	if x != nil {
 		return x.ThreadID
 	}
 	return nil
 }
 
-func (x *AddReplicatorRequest) GetAddr() []byte {
+func (x *SetPullIntervalRequest) GetInterval() int64 {
 	if x != nil {
-		return x.Addr
+		return x.Interval
 	}
-	return nil
+	return 0
 }
 
-type AddReplicatorReply struct {
+type SetPullIntervalReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	PeerID []byte `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
 }
 
-func (x *AddReplicatorReply) Reset() {
-	*x = AddReplicatorReply{}
+func (x *SetPullIntervalReply) Reset() {
+	*x = SetPullIntervalReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_threadsnet_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -877,13 +940,13 @@ func (x *AddReplicatorReply) Reset() {
 	}
 }
 
-func (x *AddReplicatorReply) String() string {
+func (x *SetPullIntervalReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddReplicatorReply) ProtoMessage() {}
+func (*SetPullIntervalReply) ProtoMessage() {}
 
-func (x *AddReplicatorReply) ProtoReflect() protoreflect.Message {
+func (x *SetPullIntervalReply) ProtoReflect() protoreflect.Message {
 	mi := &file_threadsnet_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -895,29 +958,22 @@ func (x *AddReplicatorReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddReplicatorReply.ProtoReflect.Descriptor instead.
-func (*AddReplicatorReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetPullIntervalReply.ProtoReflect.Descriptor instead.
+func (*SetPullIntervalReply) Descriptor() ([]byte, []int) {
 	return file_threadsnet_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *AddReplicatorReply) GetPeerID() []byte {
-	if x != nil {
-		return x.PeerID
-	}
-	return nil
-}
-
-type CreateRecordRequest struct {
+type SetPullPausedRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
-	Body     []byte `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	Paused   bool   `protobuf:"varint,2,opt,name=paused,proto3" json:"paused,omitempty"`
 }
 
-func (x *CreateRecordRequest) Reset() {
-	*x = CreateRecordRequest{}
+func (x *SetPullPausedRequest) Reset() {
+	*x = SetPullPausedRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_threadsnet_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -925,13 +981,13 @@ func (x *CreateRecordRequest) Reset() {
 	}
 }
 
-func (x *CreateRecordRequest) String() string {
+func (x *SetPullPausedRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateRecordRequest) ProtoMessage() {}
+func (*SetPullPausedRequest) ProtoMessage() {}
 
-func (x *CreateRecordRequest) ProtoReflect() protoreflect.Message {
+func (x *SetPullPausedRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_threadsnet_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -943,37 +999,33 @@ func (x *CreateRecordRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateRecordRequest.ProtoReflect.Descriptor instead.
-func (*CreateRecordRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetPullPausedRequest.ProtoReflect.Descriptor instead.
+func (*SetPullPausedRequest) Descriptor() ([]byte, []int) {
 	return file_threadsnet_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *CreateRecordRequest) GetThreadID() []byte {
+func (x *SetPullPausedRequest) GetThreadID() []byte {
 	if x != nil {
 		return x.ThreadID
 	}
 	return nil
 }
 
-func (x *CreateRecordRequest) GetBody() []byte {
+func (x *SetPullPausedRequest) GetPaused() bool {
 	if x != nil {
-		return x.Body
+		return x.Paused
 	}
-	return nil
+	return false
 }
 
-type NewRecordReply struct {
+type SetPullPausedReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	ThreadID []byte  `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
-	LogID    []byte  `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
-	Record   *Record `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
 }
 
-func (x *NewRecordReply) Reset() {
-	*x = NewRecordReply{}
+func (x *SetPullPausedReply) Reset() {
+	*x = SetPullPausedReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_threadsnet_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -981,13 +1033,13 @@ func (x *NewRecordReply) Reset() {
 	}
 }
 
-func (x *NewRecordReply) String() string {
+func (x *SetPullPausedReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NewRecordReply) ProtoMessage() {}
+func (*SetPullPausedReply) ProtoMessage() {}
 
-func (x *NewRecordReply) ProtoReflect() protoreflect.Message {
+func (x *SetPullPausedReply) ProtoReflect() protoreflect.Message {
 	mi := &file_threadsnet_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -999,44 +1051,22 @@ func (x *NewRecordReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NewRecordReply.ProtoReflect.Descriptor instead.
-func (*NewRecordReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetPullPausedReply.ProtoReflect.Descriptor instead.
+func (*SetPullPausedReply) Descriptor() ([]byte, []int) {
 	return file_threadsnet_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *NewRecordReply) GetThreadID() []byte {
-	if x != nil {
-		return x.ThreadID
-	}
-	return nil
-}
-
-func (x *NewRecordReply) GetLogID() []byte {
-	if x != nil {
-		return x.LogID
-	}
-	return nil
-}
-
-func (x *NewRecordReply) GetRecord() *Record {
-	if x != nil {
-		return x.Record
-	}
-	return nil
-}
-
-type AddRecordRequest struct {
+type DeleteThreadRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ThreadID []byte  `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
-	LogID    []byte  `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
-	Record   *Record `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
+	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Announce bool   `protobuf:"varint,2,opt,name=announce,proto3" json:"announce,omitempty"`
 }
 
-func (x *AddRecordRequest) Reset() {
-	*x = AddRecordRequest{}
+func (x *DeleteThreadRequest) Reset() {
+	*x = DeleteThreadRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_threadsnet_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1044,13 +1074,13 @@ func (x *AddRecordRequest) Reset() {
 	}
 }
 
-func (x *AddRecordRequest) String() string {
+func (x *DeleteThreadRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddRecordRequest) ProtoMessage() {}
+func (*DeleteThreadRequest) ProtoMessage() {}
 
-func (x *AddRecordRequest) ProtoReflect() protoreflect.Message {
+func (x *DeleteThreadRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_threadsnet_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1062,45 +1092,33 @@ func (x *AddRecordRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddRecordRequest.ProtoReflect.Descriptor instead.
-func (*AddRecordRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteThreadRequest.ProtoReflect.Descriptor instead.
+func (*DeleteThreadRequest) Descriptor() ([]byte, []int) {
 	return file_threadsnet_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *AddRecordRequest) GetThreadID() []byte {
+func (x *DeleteThreadRequest) GetThreadID() []byte {
 	if x != nil {
 		return x.ThreadID
 	}
 	return nil
 }
 
-func (x *AddRecordRequest) GetLogID() []byte {
-	if x != nil {
-		return x.LogID
-	}
-	return nil
-}
-
-func (x *AddRecordRequest) GetRecord() *Record {
+func (x *DeleteThreadRequest) GetAnnounce() bool {
 	if x != nil {
-		return x.Record
+		return x.Announce
 	}
-	return nil
+	return false
 }
 
-type Record struct {
+type DeleteThreadReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	RecordNode []byte `protobuf:"bytes,1,opt,name=recordNode,proto3" json:"recordNode,omitempty"`
-	EventNode  []byte `protobuf:"bytes,2,opt,name=eventNode,proto3" json:"eventNode,omitempty"`
-	HeaderNode []byte `protobuf:"bytes,3,opt,name=headerNode,proto3" json:"headerNode,omitempty"`
-	BodyNode   []byte `protobuf:"bytes,4,opt,name=bodyNode,proto3" json:"bodyNode,omitempty"`
 }
 
-func (x *Record) Reset() {
-	*x = Record{}
+func (x *DeleteThreadReply) Reset() {
+	*x = DeleteThreadReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_threadsnet_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1108,13 +1126,13 @@ func (x *Record) Reset() {
 	}
 }
 
-func (x *Record) String() string {
+func (x *DeleteThreadReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Record) ProtoMessage() {}
+func (*DeleteThreadReply) ProtoMessage() {}
 
-func (x *Record) ProtoReflect() protoreflect.Message {
+func (x *DeleteThreadReply) ProtoReflect() protoreflect.Message {
 	mi := &file_threadsnet_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1126,47 +1144,23 @@ func (x *Record) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Record.ProtoReflect.Descriptor instead.
-func (*Record) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteThreadReply.ProtoReflect.Descriptor instead.
+func (*DeleteThreadReply) Descriptor() ([]byte, []int) {
 	return file_threadsnet_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *Record) GetRecordNode() []byte {
-	if x != nil {
-		return x.RecordNode
-	}
-	return nil
-}
-
-func (x *Record) GetEventNode() []byte {
-	if x != nil {
-		return x.EventNode
-	}
-	return nil
-}
-
-func (x *Record) GetHeaderNode() []byte {
-	if x != nil {
-		return x.HeaderNode
-	}
-	return nil
-}
-
-func (x *Record) GetBodyNode() []byte {
-	if x != nil {
-		return x.BodyNode
-	}
-	return nil
-}
-
-type AddRecordReply struct {
+type AddReplicatorRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Addr     []byte `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Expiry   int64  `protobuf:"varint,3,opt,name=expiry,proto3" json:"expiry,omitempty"`
 }
 
-func (x *AddRecordReply) Reset() {
-	*x = AddRecordReply{}
+func (x *AddReplicatorRequest) Reset() {
+	*x = AddReplicatorRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_threadsnet_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1174,55 +1168,843 @@ func (x *AddRecordReply) Reset() {
 	}
 }
 
-func (x *AddRecordReply) String() string {
+func (x *AddReplicatorRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddRecordReply) ProtoMessage() {}
+func (*AddReplicatorRequest) ProtoMessage() {}
+
+func (x *AddReplicatorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReplicatorRequest.ProtoReflect.Descriptor instead.
+func (*AddReplicatorRequest) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AddReplicatorRequest) GetThreadID() []byte {
+	if x != nil {
+		return x.ThreadID
+	}
+	return nil
+}
+
+func (x *AddReplicatorRequest) GetAddr() []byte {
+	if x != nil {
+		return x.Addr
+	}
+	return nil
+}
+
+func (x *AddReplicatorRequest) GetExpiry() int64 {
+	if x != nil {
+		return x.Expiry
+	}
+	return 0
+}
+
+type AddReplicatorReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PeerID []byte `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+}
+
+func (x *AddReplicatorReply) Reset() {
+	*x = AddReplicatorReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddReplicatorReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReplicatorReply) ProtoMessage() {}
+
+func (x *AddReplicatorReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReplicatorReply.ProtoReflect.Descriptor instead.
+func (*AddReplicatorReply) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AddReplicatorReply) GetPeerID() []byte {
+	if x != nil {
+		return x.PeerID
+	}
+	return nil
+}
+
+type RemoveReplicatorRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Addr     []byte `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+}
+
+func (x *RemoveReplicatorRequest) Reset() {
+	*x = RemoveReplicatorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveReplicatorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveReplicatorRequest) ProtoMessage() {}
+
+func (x *RemoveReplicatorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveReplicatorRequest.ProtoReflect.Descriptor instead.
+func (*RemoveReplicatorRequest) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RemoveReplicatorRequest) GetThreadID() []byte {
+	if x != nil {
+		return x.ThreadID
+	}
+	return nil
+}
+
+func (x *RemoveReplicatorRequest) GetAddr() []byte {
+	if x != nil {
+		return x.Addr
+	}
+	return nil
+}
+
+type RemoveReplicatorReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RemoveReplicatorReply) Reset() {
+	*x = RemoveReplicatorReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveReplicatorReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveReplicatorReply) ProtoMessage() {}
+
+func (x *RemoveReplicatorReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveReplicatorReply.ProtoReflect.Descriptor instead.
+func (*RemoveReplicatorReply) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{23}
+}
+
+type CreateRecordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Body     []byte `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+}
+
+func (x *CreateRecordRequest) Reset() {
+	*x = CreateRecordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRecordRequest) ProtoMessage() {}
+
+func (x *CreateRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRecordRequest.ProtoReflect.Descriptor instead.
+func (*CreateRecordRequest) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreateRecordRequest) GetThreadID() []byte {
+	if x != nil {
+		return x.ThreadID
+	}
+	return nil
+}
+
+func (x *CreateRecordRequest) GetBody() []byte {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type NewRecordReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ThreadID []byte  `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	LogID    []byte  `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
+	Record   *Record `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
+}
+
+func (x *NewRecordReply) Reset() {
+	*x = NewRecordReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewRecordReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewRecordReply) ProtoMessage() {}
+
+func (x *NewRecordReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewRecordReply.ProtoReflect.Descriptor instead.
+func (*NewRecordReply) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *NewRecordReply) GetThreadID() []byte {
+	if x != nil {
+		return x.ThreadID
+	}
+	return nil
+}
+
+func (x *NewRecordReply) GetLogID() []byte {
+	if x != nil {
+		return x.LogID
+	}
+	return nil
+}
+
+func (x *NewRecordReply) GetRecord() *Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+type AddRecordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ThreadID []byte  `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	LogID    []byte  `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
+	Record   *Record `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
+}
+
+func (x *AddRecordRequest) Reset() {
+	*x = AddRecordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRecordRequest) ProtoMessage() {}
+
+func (x *AddRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRecordRequest.ProtoReflect.Descriptor instead.
+func (*AddRecordRequest) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AddRecordRequest) GetThreadID() []byte {
+	if x != nil {
+		return x.ThreadID
+	}
+	return nil
+}
+
+func (x *AddRecordRequest) GetLogID() []byte {
+	if x != nil {
+		return x.LogID
+	}
+	return nil
+}
+
+func (x *AddRecordRequest) GetRecord() *Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+type Record struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RecordNode []byte `protobuf:"bytes,1,opt,name=recordNode,proto3" json:"recordNode,omitempty"`
+	EventNode  []byte `protobuf:"bytes,2,opt,name=eventNode,proto3" json:"eventNode,omitempty"`
+	HeaderNode []byte `protobuf:"bytes,3,opt,name=headerNode,proto3" json:"headerNode,omitempty"`
+	BodyNode   []byte `protobuf:"bytes,4,opt,name=bodyNode,proto3" json:"bodyNode,omitempty"`
+}
+
+func (x *Record) Reset() {
+	*x = Record{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Record) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Record) ProtoMessage() {}
+
+func (x *Record) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Record.ProtoReflect.Descriptor instead.
+func (*Record) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Record) GetRecordNode() []byte {
+	if x != nil {
+		return x.RecordNode
+	}
+	return nil
+}
+
+func (x *Record) GetEventNode() []byte {
+	if x != nil {
+		return x.EventNode
+	}
+	return nil
+}
+
+func (x *Record) GetHeaderNode() []byte {
+	if x != nil {
+		return x.HeaderNode
+	}
+	return nil
+}
+
+func (x *Record) GetBodyNode() []byte {
+	if x != nil {
+		return x.BodyNode
+	}
+	return nil
+}
+
+type AddRecordReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AddRecordReply) Reset() {
+	*x = AddRecordReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddRecordReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRecordReply) ProtoMessage() {}
+
+func (x *AddRecordReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRecordReply.ProtoReflect.Descriptor instead.
+func (*AddRecordReply) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{28}
+}
+
+type GetRecordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	RecordID []byte `protobuf:"bytes,2,opt,name=recordID,proto3" json:"recordID,omitempty"`
+}
+
+func (x *GetRecordRequest) Reset() {
+	*x = GetRecordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecordRequest) ProtoMessage() {}
+
+func (x *GetRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecordRequest.ProtoReflect.Descriptor instead.
+func (*GetRecordRequest) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetRecordRequest) GetThreadID() []byte {
+	if x != nil {
+		return x.ThreadID
+	}
+	return nil
+}
+
+func (x *GetRecordRequest) GetRecordID() []byte {
+	if x != nil {
+		return x.RecordID
+	}
+	return nil
+}
+
+type GetRecordReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Record *Record `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+}
+
+func (x *GetRecordReply) Reset() {
+	*x = GetRecordReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRecordReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecordReply) ProtoMessage() {}
+
+func (x *GetRecordReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecordReply.ProtoReflect.Descriptor instead.
+func (*GetRecordReply) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetRecordReply) GetRecord() *Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+type SubscribeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ThreadIDs [][]byte `protobuf:"bytes,1,rep,name=threadIDs,proto3" json:"threadIDs,omitempty"`
+	LogIDs    [][]byte `protobuf:"bytes,2,rep,name=logIDs,proto3" json:"logIDs,omitempty"`
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SubscribeRequest) GetThreadIDs() [][]byte {
+	if x != nil {
+		return x.ThreadIDs
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetLogIDs() [][]byte {
+	if x != nil {
+		return x.LogIDs
+	}
+	return nil
+}
+
+type ListPeerStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListPeerStatsRequest) Reset() {
+	*x = ListPeerStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPeerStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPeerStatsRequest) ProtoMessage() {}
+
+func (x *ListPeerStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPeerStatsRequest.ProtoReflect.Descriptor instead.
+func (*ListPeerStatsRequest) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{32}
+}
+
+type ListPeerStatsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stats []*PeerStats `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *ListPeerStatsReply) Reset() {
+	*x = ListPeerStatsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPeerStatsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPeerStatsReply) ProtoMessage() {}
+
+func (x *ListPeerStatsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPeerStatsReply.ProtoReflect.Descriptor instead.
+func (*ListPeerStatsReply) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListPeerStatsReply) GetStats() []*PeerStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type PeerStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PeerID       []byte          `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	LastPushed   int64           `protobuf:"varint,2,opt,name=lastPushed,proto3" json:"lastPushed,omitempty"`
+	LastReceived int64           `protobuf:"varint,3,opt,name=lastReceived,proto3" json:"lastReceived,omitempty"`
+	FailedPushes []*PushFailures `protobuf:"bytes,4,rep,name=failedPushes,proto3" json:"failedPushes,omitempty"`
+	Addrs        [][]byte        `protobuf:"bytes,5,rep,name=addrs,proto3" json:"addrs,omitempty"`
+	Down         bool            `protobuf:"varint,6,opt,name=down,proto3" json:"down,omitempty"`
+	BackoffUntil int64           `protobuf:"varint,7,opt,name=backoffUntil,proto3" json:"backoffUntil,omitempty"`
+}
+
+func (x *PeerStats) Reset() {
+	*x = PeerStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerStats) ProtoMessage() {}
+
+func (x *PeerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerStats.ProtoReflect.Descriptor instead.
+func (*PeerStats) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PeerStats) GetPeerID() []byte {
+	if x != nil {
+		return x.PeerID
+	}
+	return nil
+}
+
+func (x *PeerStats) GetLastPushed() int64 {
+	if x != nil {
+		return x.LastPushed
+	}
+	return 0
+}
+
+func (x *PeerStats) GetLastReceived() int64 {
+	if x != nil {
+		return x.LastReceived
+	}
+	return 0
+}
+
+func (x *PeerStats) GetFailedPushes() []*PushFailures {
+	if x != nil {
+		return x.FailedPushes
+	}
+	return nil
+}
+
+func (x *PeerStats) GetAddrs() [][]byte {
+	if x != nil {
+		return x.Addrs
+	}
+	return nil
+}
 
-func (x *AddRecordReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threadsnet_proto_msgTypes[20]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *PeerStats) GetDown() bool {
+	if x != nil {
+		return x.Down
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use AddRecordReply.ProtoReflect.Descriptor instead.
-func (*AddRecordReply) Descriptor() ([]byte, []int) {
-	return file_threadsnet_proto_rawDescGZIP(), []int{20}
+func (x *PeerStats) GetBackoffUntil() int64 {
+	if x != nil {
+		return x.BackoffUntil
+	}
+	return 0
 }
 
-type GetRecordRequest struct {
+type PushFailures struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
-	RecordID []byte `protobuf:"bytes,2,opt,name=recordID,proto3" json:"recordID,omitempty"`
+	Class string `protobuf:"bytes,1,opt,name=class,proto3" json:"class,omitempty"`
+	Count int64  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
 }
 
-func (x *GetRecordRequest) Reset() {
-	*x = GetRecordRequest{}
+func (x *PushFailures) Reset() {
+	*x = PushFailures{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threadsnet_proto_msgTypes[21]
+		mi := &file_threadsnet_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetRecordRequest) String() string {
+func (x *PushFailures) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRecordRequest) ProtoMessage() {}
+func (*PushFailures) ProtoMessage() {}
 
-func (x *GetRecordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threadsnet_proto_msgTypes[21]
+func (x *PushFailures) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1233,50 +2015,48 @@ func (x *GetRecordRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRecordRequest.ProtoReflect.Descriptor instead.
-func (*GetRecordRequest) Descriptor() ([]byte, []int) {
-	return file_threadsnet_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use PushFailures.ProtoReflect.Descriptor instead.
+func (*PushFailures) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *GetRecordRequest) GetThreadID() []byte {
+func (x *PushFailures) GetClass() string {
 	if x != nil {
-		return x.ThreadID
+		return x.Class
 	}
-	return nil
+	return ""
 }
 
-func (x *GetRecordRequest) GetRecordID() []byte {
+func (x *PushFailures) GetCount() int64 {
 	if x != nil {
-		return x.RecordID
+		return x.Count
 	}
-	return nil
+	return 0
 }
 
-type GetRecordReply struct {
+type GetMaxRecordSizeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Record *Record `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
 }
 
-func (x *GetRecordReply) Reset() {
-	*x = GetRecordReply{}
+func (x *GetMaxRecordSizeRequest) Reset() {
+	*x = GetMaxRecordSizeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threadsnet_proto_msgTypes[22]
+		mi := &file_threadsnet_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetRecordReply) String() string {
+func (x *GetMaxRecordSizeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRecordReply) ProtoMessage() {}
+func (*GetMaxRecordSizeRequest) ProtoMessage() {}
 
-func (x *GetRecordReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threadsnet_proto_msgTypes[22]
+func (x *GetMaxRecordSizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1287,43 +2067,36 @@ func (x *GetRecordReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRecordReply.ProtoReflect.Descriptor instead.
-func (*GetRecordReply) Descriptor() ([]byte, []int) {
-	return file_threadsnet_proto_rawDescGZIP(), []int{22}
-}
-
-func (x *GetRecordReply) GetRecord() *Record {
-	if x != nil {
-		return x.Record
-	}
-	return nil
+// Deprecated: Use GetMaxRecordSizeRequest.ProtoReflect.Descriptor instead.
+func (*GetMaxRecordSizeRequest) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{36}
 }
 
-type SubscribeRequest struct {
+type GetMaxRecordSizeReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ThreadIDs [][]byte `protobuf:"bytes,1,rep,name=threadIDs,proto3" json:"threadIDs,omitempty"`
+	Size int64 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
 }
 
-func (x *SubscribeRequest) Reset() {
-	*x = SubscribeRequest{}
+func (x *GetMaxRecordSizeReply) Reset() {
+	*x = GetMaxRecordSizeReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threadsnet_proto_msgTypes[23]
+		mi := &file_threadsnet_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SubscribeRequest) String() string {
+func (x *GetMaxRecordSizeReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubscribeRequest) ProtoMessage() {}
+func (*GetMaxRecordSizeReply) ProtoMessage() {}
 
-func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threadsnet_proto_msgTypes[23]
+func (x *GetMaxRecordSizeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1334,16 +2107,16 @@ func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
-func (*SubscribeRequest) Descriptor() ([]byte, []int) {
-	return file_threadsnet_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use GetMaxRecordSizeReply.ProtoReflect.Descriptor instead.
+func (*GetMaxRecordSizeReply) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *SubscribeRequest) GetThreadIDs() [][]byte {
+func (x *GetMaxRecordSizeReply) GetSize() int64 {
 	if x != nil {
-		return x.ThreadIDs
+		return x.Size
 	}
-	return nil
+	return 0
 }
 
 var File_threadsnet_proto protoreflect.FileDescriptor
@@ -1401,135 +2174,246 @@ var file_threadsnet_proto_rawDesc = []byte{
 	0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x22, 0x2e, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72,
 	0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68,
 	0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x22, 0x2f, 0x0a, 0x11, 0x50, 0x75, 0x6c, 0x6c, 0x54, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74,
-	0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74,
-	0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x22, 0x11, 0x0a, 0x0f, 0x50, 0x75, 0x6c, 0x6c, 0x54,
-	0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x31, 0x0a, 0x13, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x22, 0x35, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x22, 0x13, 0x0a,
-	0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x22, 0x46, 0x0a, 0x14, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
-	0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x22, 0x2c, 0x0a, 0x12, 0x41, 0x64,
-	0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x44, 0x22, 0x45, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x22, 0x9a, 0x02,
+	0x0a, 0x12, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44,
+	0x12, 0x24, 0x0a, 0x0d, 0x68, 0x61, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4b, 0x65,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x68, 0x61, 0x73, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x68, 0x61, 0x73, 0x52, 0x65, 0x61,
+	0x64, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x68, 0x61, 0x73, 0x52,
+	0x65, 0x61, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x67, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c, 0x6f, 0x67, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x75, 0x6c, 0x6c, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70, 0x75,
+	0x6c, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x75,
+	0x6c, 0x6c, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x70, 0x75, 0x6c, 0x6c, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x22, 0x2f, 0x0a, 0x11, 0x50, 0x75,
+	0x6c, 0x6c, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
 	0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x62,
-	0x6f, 0x64, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22,
-	0x72, 0x0a, 0x0e, 0x4e, 0x65, 0x77, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x12, 0x14, 0x0a,
-	0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6c, 0x6f,
-	0x67, 0x49, 0x44, 0x12, 0x2e, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65,
-	0x74, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x63,
-	0x6f, 0x72, 0x64, 0x22, 0x74, 0x0a, 0x10, 0x41, 0x64, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x22, 0x11, 0x0a, 0x0f, 0x50,
+	0x75, 0x6c, 0x6c, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x50,
+	0x0a, 0x16, 0x53, 0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x22, 0x16, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x4a, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x50,
+	0x75, 0x6c, 0x6c, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61,
+	0x75, 0x73, 0x65, 0x64, 0x22, 0x14, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x50,
+	0x61, 0x75, 0x73, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x4d, 0x0a, 0x13, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x12, 0x1a, 0x0a,
+	0x08, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x5e,
+	0x0a, 0x14, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
+	0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x22, 0x2c,
+	0x0a, 0x12, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x44, 0x22, 0x49, 0x0a, 0x17,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61,
 	0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61,
-	0x64, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x12, 0x2e, 0x0a, 0x06, 0x72, 0x65, 0x63,
-	0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x68, 0x72, 0x65,
-	0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0x82, 0x01, 0x0a, 0x06, 0x52, 0x65,
-	0x63, 0x6f, 0x72, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4e, 0x6f,
-	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
-	0x4e, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x4e, 0x6f, 0x64,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x4e, 0x6f,
-	0x64, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4e, 0x6f,
-	0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x6f, 0x64, 0x79, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x62, 0x6f, 0x64, 0x79, 0x4e, 0x6f, 0x64, 0x65, 0x22, 0x10,
-	0x0a, 0x0e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x22, 0x4a, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44,
-	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x08, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44, 0x22, 0x40, 0x0a, 0x0e,
-	0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2e,
-	0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
-	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e,
-	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0x30,
-	0x0a, 0x10, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x73,
-	0x32, 0xfb, 0x07, 0x0a, 0x03, 0x41, 0x50, 0x49, 0x12, 0x4f, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x48,
-	0x6f, 0x73, 0x74, 0x49, 0x44, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e,
-	0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x49, 0x44,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
-	0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74,
-	0x49, 0x44, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x08, 0x47, 0x65, 0x74,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1f, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e,
-	0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
-	0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x56, 0x0a, 0x0c, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x12, 0x23, 0x2e, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1f, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70,
-	0x62, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64,
-	0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70,
-	0x62, 0x2e, 0x41, 0x64, 0x64, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74,
-	0x2e, 0x70, 0x62, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72, 0x65,
-	0x61, 0x64, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74,
-	0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e,
-	0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f,
+	0x64, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x22, 0x17, 0x0a, 0x15, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x45, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0x72, 0x0a, 0x0e, 0x4e, 0x65, 0x77, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x12, 0x2e, 0x0a, 0x06, 0x72,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x68,
+	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0x74, 0x0a, 0x10, 0x41,
+	0x64, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x6f, 0x67, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6c, 0x6f, 0x67, 0x49,
+	0x44, 0x12, 0x2e, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e,
+	0x70, 0x62, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x22, 0x82, 0x01, 0x0a, 0x06, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x1e, 0x0a, 0x0a,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x68, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x6f,
+	0x64, 0x79, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x62, 0x6f,
+	0x64, 0x79, 0x4e, 0x6f, 0x64, 0x65, 0x22, 0x10, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x4a, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x49, 0x44, 0x22, 0x40, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2e, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
+	0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0x48, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68,
+	0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x74,
+	0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x67, 0x49,
+	0x44, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x73,
+	0x22, 0x16, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x45, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2f,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x50,
+	0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x22,
+	0xf7, 0x01, 0x0a, 0x09, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70,
+	0x65, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x50, 0x75, 0x73,
+	0x68, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x50,
+	0x75, 0x73, 0x68, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6c, 0x61, 0x73,
+	0x74, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x40, 0x0a, 0x0c, 0x66, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x50, 0x75, 0x73, 0x68, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62,
+	0x2e, 0x50, 0x75, 0x73, 0x68, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x52, 0x0c, 0x66,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x50, 0x75, 0x73, 0x68, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x61,
+	0x64, 0x64, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x61, 0x64, 0x64, 0x72,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66,
+	0x55, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x62, 0x61, 0x63,
+	0x6b, 0x6f, 0x66, 0x66, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x22, 0x3a, 0x0a, 0x0c, 0x50, 0x75, 0x73,
+	0x68, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x61,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x19, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x78, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x2b, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x78, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x53, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x32, 0xc1, 0x0c,
+	0x0a, 0x03, 0x41, 0x50, 0x49, 0x12, 0x4f, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74,
+	0x49, 0x44, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74,
+	0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x49, 0x44, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e,
+	0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x49, 0x44, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x1f, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74,
+	0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65,
+	0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x56, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x12, 0x23, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x54,
+	0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x50, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x12, 0x20, 0x2e,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x41,
+	0x64, 0x64, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1f, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62,
+	0x2e, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x50, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x12,
+	0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62,
+	0x2e, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1f, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e,
+	0x70, 0x62, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x12, 0x61, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x27, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x22, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e,
+	0x70, 0x62, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
 	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x0a, 0x50, 0x75, 0x6c, 0x6c, 0x54,
 	0x68, 0x72, 0x65, 0x61, 0x64, 0x12, 0x21, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e,
 	0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x6c, 0x6c, 0x54, 0x68, 0x72, 0x65, 0x61,
 	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
 	0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x6c, 0x6c, 0x54, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x0c, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x12, 0x23, 0x2e, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x21, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70,
-	0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5b, 0x0a, 0x0d, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c,
-	0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x24, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
-	0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69,
-	0x63, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x74,
-	0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x64,
-	0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x22, 0x00, 0x12, 0x55, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x12, 0x23, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74,
-	0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
-	0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x52, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x09, 0x41, 0x64, 0x64,
-	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
-	0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
-	0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x63,
-	0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x09, 0x47, 0x65,
-	0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
-	0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5f, 0x0a, 0x0f, 0x53,
+	0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x26,
+	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e,
+	0x53, 0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
+	0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x59, 0x0a, 0x0d,
+	0x53, 0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x12, 0x24, 0x2e,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x53,
+	0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65,
+	0x74, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x74, 0x50, 0x75, 0x6c, 0x6c, 0x50, 0x61, 0x75, 0x73,
+	0x65, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x58, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x12, 0x23, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
+	0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54,
+	0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x74,
+	0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x5b, 0x0a, 0x0d, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x24, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74,
+	0x2e, 0x70, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x64,
+	0x0a, 0x10, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x27, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74,
+	0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x74, 0x68,
+	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x12, 0x55, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x12, 0x23, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e,
+	0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f,
 	0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x09, 0x41,
+	0x64, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x09,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65,
 	0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65,
-	0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x09, 0x53,
-	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
-	0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
-	0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x68, 0x72,
-	0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x52,
-	0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x42, 0x69,
-	0x0a, 0x1b, 0x69, 0x6f, 0x2e, 0x74, 0x65, 0x78, 0x74, 0x69, 0x6c, 0x65, 0x2e, 0x74, 0x68, 0x72,
-	0x65, 0x61, 0x64, 0x73, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x67, 0x72, 0x70, 0x63, 0x42, 0x0a, 0x54,
-	0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x4e, 0x65, 0x74, 0x50, 0x01, 0x5a, 0x2f, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x74, 0x68, 0x72, 0x65, 0x61,
-	0x64, 0x73, 0x2f, 0x6e, 0x65, 0x74, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x62, 0x2f, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x73, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x70, 0x62, 0xa2, 0x02, 0x0a, 0x54,
-	0x48, 0x52, 0x45, 0x41, 0x44, 0x53, 0x4e, 0x45, 0x54, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x68,
+	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x51, 0x0a,
+	0x09, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74,
+	0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65,
+	0x77, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01,
+	0x12, 0x59, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x24, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e,
+	0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
+	0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x64, 0x0a, 0x10, 0x47,
+	0x65, 0x74, 0x4d, 0x61, 0x78, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x27, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62,
+	0x2e, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x78, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x69, 0x7a,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x78,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x42, 0x69, 0x0a, 0x1b, 0x69, 0x6f, 0x2e, 0x74, 0x65, 0x78, 0x74, 0x69, 0x6c, 0x65, 0x2e,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x67, 0x72, 0x70, 0x63,
+	0x42, 0x0a, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x4e, 0x65, 0x74, 0x50, 0x01, 0x5a, 0x2f,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x74, 0x68,
+	0x72, 0x65, 0x61, 0x64, 0x73, 0x2f, 0x6e, 0x65, 0x74, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x62,
+	0x2f, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x70, 0x62, 0xa2,
+	0x02, 0x0a, 0x54, 0x48, 0x52, 0x45, 0x41, 0x44, 0x53, 0x4e, 0x45, 0x54, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1544,69 +2428,97 @@ func file_threadsnet_proto_rawDescGZIP() []byte {
 	return file_threadsnet_proto_rawDescData
 }
 
-var file_threadsnet_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_threadsnet_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_threadsnet_proto_goTypes = []interface{}{
-	(*GetHostIDRequest)(nil),     // 0: threads.net.pb.GetHostIDRequest
-	(*GetHostIDReply)(nil),       // 1: threads.net.pb.GetHostIDReply
-	(*GetTokenRequest)(nil),      // 2: threads.net.pb.GetTokenRequest
-	(*GetTokenReply)(nil),        // 3: threads.net.pb.GetTokenReply
-	(*CreateThreadRequest)(nil),  // 4: threads.net.pb.CreateThreadRequest
-	(*Keys)(nil),                 // 5: threads.net.pb.Keys
-	(*ThreadInfoReply)(nil),      // 6: threads.net.pb.ThreadInfoReply
-	(*LogInfo)(nil),              // 7: threads.net.pb.LogInfo
-	(*AddThreadRequest)(nil),     // 8: threads.net.pb.AddThreadRequest
-	(*GetThreadRequest)(nil),     // 9: threads.net.pb.GetThreadRequest
-	(*PullThreadRequest)(nil),    // 10: threads.net.pb.PullThreadRequest
-	(*PullThreadReply)(nil),      // 11: threads.net.pb.PullThreadReply
-	(*DeleteThreadRequest)(nil),  // 12: threads.net.pb.DeleteThreadRequest
-	(*DeleteThreadReply)(nil),    // 13: threads.net.pb.DeleteThreadReply
-	(*AddReplicatorRequest)(nil), // 14: threads.net.pb.AddReplicatorRequest
-	(*AddReplicatorReply)(nil),   // 15: threads.net.pb.AddReplicatorReply
-	(*CreateRecordRequest)(nil),  // 16: threads.net.pb.CreateRecordRequest
-	(*NewRecordReply)(nil),       // 17: threads.net.pb.NewRecordReply
-	(*AddRecordRequest)(nil),     // 18: threads.net.pb.AddRecordRequest
-	(*Record)(nil),               // 19: threads.net.pb.Record
-	(*AddRecordReply)(nil),       // 20: threads.net.pb.AddRecordReply
-	(*GetRecordRequest)(nil),     // 21: threads.net.pb.GetRecordRequest
-	(*GetRecordReply)(nil),       // 22: threads.net.pb.GetRecordReply
-	(*SubscribeRequest)(nil),     // 23: threads.net.pb.SubscribeRequest
+	(*GetHostIDRequest)(nil),        // 0: threads.net.pb.GetHostIDRequest
+	(*GetHostIDReply)(nil),          // 1: threads.net.pb.GetHostIDReply
+	(*GetTokenRequest)(nil),         // 2: threads.net.pb.GetTokenRequest
+	(*GetTokenReply)(nil),           // 3: threads.net.pb.GetTokenReply
+	(*CreateThreadRequest)(nil),     // 4: threads.net.pb.CreateThreadRequest
+	(*Keys)(nil),                    // 5: threads.net.pb.Keys
+	(*ThreadInfoReply)(nil),         // 6: threads.net.pb.ThreadInfoReply
+	(*LogInfo)(nil),                 // 7: threads.net.pb.LogInfo
+	(*AddThreadRequest)(nil),        // 8: threads.net.pb.AddThreadRequest
+	(*GetThreadRequest)(nil),        // 9: threads.net.pb.GetThreadRequest
+	(*GetThreadSummaryRequest)(nil), // 10: threads.net.pb.GetThreadSummaryRequest
+	(*ThreadSummaryReply)(nil),      // 11: threads.net.pb.ThreadSummaryReply
+	(*PullThreadRequest)(nil),       // 12: threads.net.pb.PullThreadRequest
+	(*PullThreadReply)(nil),         // 13: threads.net.pb.PullThreadReply
+	(*SetPullIntervalRequest)(nil),  // 14: threads.net.pb.SetPullIntervalRequest
+	(*SetPullIntervalReply)(nil),    // 15: threads.net.pb.SetPullIntervalReply
+	(*SetPullPausedRequest)(nil),    // 16: threads.net.pb.SetPullPausedRequest
+	(*SetPullPausedReply)(nil),      // 17: threads.net.pb.SetPullPausedReply
+	(*DeleteThreadRequest)(nil),     // 18: threads.net.pb.DeleteThreadRequest
+	(*DeleteThreadReply)(nil),       // 19: threads.net.pb.DeleteThreadReply
+	(*AddReplicatorRequest)(nil),    // 20: threads.net.pb.AddReplicatorRequest
+	(*AddReplicatorReply)(nil),      // 21: threads.net.pb.AddReplicatorReply
+	(*RemoveReplicatorRequest)(nil), // 22: threads.net.pb.RemoveReplicatorRequest
+	(*RemoveReplicatorReply)(nil),   // 23: threads.net.pb.RemoveReplicatorReply
+	(*CreateRecordRequest)(nil),     // 24: threads.net.pb.CreateRecordRequest
+	(*NewRecordReply)(nil),          // 25: threads.net.pb.NewRecordReply
+	(*AddRecordRequest)(nil),        // 26: threads.net.pb.AddRecordRequest
+	(*Record)(nil),                  // 27: threads.net.pb.Record
+	(*AddRecordReply)(nil),          // 28: threads.net.pb.AddRecordReply
+	(*GetRecordRequest)(nil),        // 29: threads.net.pb.GetRecordRequest
+	(*GetRecordReply)(nil),          // 30: threads.net.pb.GetRecordReply
+	(*SubscribeRequest)(nil),        // 31: threads.net.pb.SubscribeRequest
+	(*ListPeerStatsRequest)(nil),    // 32: threads.net.pb.ListPeerStatsRequest
+	(*ListPeerStatsReply)(nil),      // 33: threads.net.pb.ListPeerStatsReply
+	(*PeerStats)(nil),               // 34: threads.net.pb.PeerStats
+	(*PushFailures)(nil),            // 35: threads.net.pb.PushFailures
+	(*GetMaxRecordSizeRequest)(nil), // 36: threads.net.pb.GetMaxRecordSizeRequest
+	(*GetMaxRecordSizeReply)(nil),   // 37: threads.net.pb.GetMaxRecordSizeReply
 }
 var file_threadsnet_proto_depIdxs = []int32{
 	5,  // 0: threads.net.pb.CreateThreadRequest.keys:type_name -> threads.net.pb.Keys
 	7,  // 1: threads.net.pb.ThreadInfoReply.logs:type_name -> threads.net.pb.LogInfo
 	5,  // 2: threads.net.pb.AddThreadRequest.keys:type_name -> threads.net.pb.Keys
-	19, // 3: threads.net.pb.NewRecordReply.record:type_name -> threads.net.pb.Record
-	19, // 4: threads.net.pb.AddRecordRequest.record:type_name -> threads.net.pb.Record
-	19, // 5: threads.net.pb.GetRecordReply.record:type_name -> threads.net.pb.Record
-	0,  // 6: threads.net.pb.API.GetHostID:input_type -> threads.net.pb.GetHostIDRequest
-	2,  // 7: threads.net.pb.API.GetToken:input_type -> threads.net.pb.GetTokenRequest
-	4,  // 8: threads.net.pb.API.CreateThread:input_type -> threads.net.pb.CreateThreadRequest
-	8,  // 9: threads.net.pb.API.AddThread:input_type -> threads.net.pb.AddThreadRequest
-	9,  // 10: threads.net.pb.API.GetThread:input_type -> threads.net.pb.GetThreadRequest
-	10, // 11: threads.net.pb.API.PullThread:input_type -> threads.net.pb.PullThreadRequest
-	12, // 12: threads.net.pb.API.DeleteThread:input_type -> threads.net.pb.DeleteThreadRequest
-	14, // 13: threads.net.pb.API.AddReplicator:input_type -> threads.net.pb.AddReplicatorRequest
-	16, // 14: threads.net.pb.API.CreateRecord:input_type -> threads.net.pb.CreateRecordRequest
-	18, // 15: threads.net.pb.API.AddRecord:input_type -> threads.net.pb.AddRecordRequest
-	21, // 16: threads.net.pb.API.GetRecord:input_type -> threads.net.pb.GetRecordRequest
-	23, // 17: threads.net.pb.API.Subscribe:input_type -> threads.net.pb.SubscribeRequest
-	1,  // 18: threads.net.pb.API.GetHostID:output_type -> threads.net.pb.GetHostIDReply
-	3,  // 19: threads.net.pb.API.GetToken:output_type -> threads.net.pb.GetTokenReply
-	6,  // 20: threads.net.pb.API.CreateThread:output_type -> threads.net.pb.ThreadInfoReply
-	6,  // 21: threads.net.pb.API.AddThread:output_type -> threads.net.pb.ThreadInfoReply
-	6,  // 22: threads.net.pb.API.GetThread:output_type -> threads.net.pb.ThreadInfoReply
-	11, // 23: threads.net.pb.API.PullThread:output_type -> threads.net.pb.PullThreadReply
-	13, // 24: threads.net.pb.API.DeleteThread:output_type -> threads.net.pb.DeleteThreadReply
-	15, // 25: threads.net.pb.API.AddReplicator:output_type -> threads.net.pb.AddReplicatorReply
-	17, // 26: threads.net.pb.API.CreateRecord:output_type -> threads.net.pb.NewRecordReply
-	20, // 27: threads.net.pb.API.AddRecord:output_type -> threads.net.pb.AddRecordReply
-	22, // 28: threads.net.pb.API.GetRecord:output_type -> threads.net.pb.GetRecordReply
-	17, // 29: threads.net.pb.API.Subscribe:output_type -> threads.net.pb.NewRecordReply
-	18, // [18:30] is the sub-list for method output_type
-	6,  // [6:18] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	27, // 3: threads.net.pb.NewRecordReply.record:type_name -> threads.net.pb.Record
+	27, // 4: threads.net.pb.AddRecordRequest.record:type_name -> threads.net.pb.Record
+	27, // 5: threads.net.pb.GetRecordReply.record:type_name -> threads.net.pb.Record
+	34, // 6: threads.net.pb.ListPeerStatsReply.stats:type_name -> threads.net.pb.PeerStats
+	35, // 7: threads.net.pb.PeerStats.failedPushes:type_name -> threads.net.pb.PushFailures
+	0,  // 8: threads.net.pb.API.GetHostID:input_type -> threads.net.pb.GetHostIDRequest
+	2,  // 9: threads.net.pb.API.GetToken:input_type -> threads.net.pb.GetTokenRequest
+	4,  // 10: threads.net.pb.API.CreateThread:input_type -> threads.net.pb.CreateThreadRequest
+	8,  // 11: threads.net.pb.API.AddThread:input_type -> threads.net.pb.AddThreadRequest
+	9,  // 12: threads.net.pb.API.GetThread:input_type -> threads.net.pb.GetThreadRequest
+	10, // 13: threads.net.pb.API.GetThreadSummary:input_type -> threads.net.pb.GetThreadSummaryRequest
+	12, // 14: threads.net.pb.API.PullThread:input_type -> threads.net.pb.PullThreadRequest
+	14, // 15: threads.net.pb.API.SetPullInterval:input_type -> threads.net.pb.SetPullIntervalRequest
+	16, // 16: threads.net.pb.API.SetPullPaused:input_type -> threads.net.pb.SetPullPausedRequest
+	18, // 17: threads.net.pb.API.DeleteThread:input_type -> threads.net.pb.DeleteThreadRequest
+	20, // 18: threads.net.pb.API.AddReplicator:input_type -> threads.net.pb.AddReplicatorRequest
+	22, // 19: threads.net.pb.API.RemoveReplicator:input_type -> threads.net.pb.RemoveReplicatorRequest
+	24, // 20: threads.net.pb.API.CreateRecord:input_type -> threads.net.pb.CreateRecordRequest
+	26, // 21: threads.net.pb.API.AddRecord:input_type -> threads.net.pb.AddRecordRequest
+	29, // 22: threads.net.pb.API.GetRecord:input_type -> threads.net.pb.GetRecordRequest
+	31, // 23: threads.net.pb.API.Subscribe:input_type -> threads.net.pb.SubscribeRequest
+	32, // 24: threads.net.pb.API.ListPeerStats:input_type -> threads.net.pb.ListPeerStatsRequest
+	36, // 25: threads.net.pb.API.GetMaxRecordSize:input_type -> threads.net.pb.GetMaxRecordSizeRequest
+	1,  // 26: threads.net.pb.API.GetHostID:output_type -> threads.net.pb.GetHostIDReply
+	3,  // 27: threads.net.pb.API.GetToken:output_type -> threads.net.pb.GetTokenReply
+	6,  // 28: threads.net.pb.API.CreateThread:output_type -> threads.net.pb.ThreadInfoReply
+	6,  // 29: threads.net.pb.API.AddThread:output_type -> threads.net.pb.ThreadInfoReply
+	6,  // 30: threads.net.pb.API.GetThread:output_type -> threads.net.pb.ThreadInfoReply
+	11, // 31: threads.net.pb.API.GetThreadSummary:output_type -> threads.net.pb.ThreadSummaryReply
+	13, // 32: threads.net.pb.API.PullThread:output_type -> threads.net.pb.PullThreadReply
+	15, // 33: threads.net.pb.API.SetPullInterval:output_type -> threads.net.pb.SetPullIntervalReply
+	17, // 34: threads.net.pb.API.SetPullPaused:output_type -> threads.net.pb.SetPullPausedReply
+	19, // 35: threads.net.pb.API.DeleteThread:output_type -> threads.net.pb.DeleteThreadReply
+	21, // 36: threads.net.pb.API.AddReplicator:output_type -> threads.net.pb.AddReplicatorReply
+	23, // 37: threads.net.pb.API.RemoveReplicator:output_type -> threads.net.pb.RemoveReplicatorReply
+	25, // 38: threads.net.pb.API.CreateRecord:output_type -> threads.net.pb.NewRecordReply
+	28, // 39: threads.net.pb.API.AddRecord:output_type -> threads.net.pb.AddRecordReply
+	30, // 40: threads.net.pb.API.GetRecord:output_type -> threads.net.pb.GetRecordReply
+	25, // 41: threads.net.pb.API.Subscribe:output_type -> threads.net.pb.NewRecordReply
+	33, // 42: threads.net.pb.API.ListPeerStats:output_type -> threads.net.pb.ListPeerStatsReply
+	37, // 43: threads.net.pb.API.GetMaxRecordSize:output_type -> threads.net.pb.GetMaxRecordSizeReply
+	26, // [26:44] is the sub-list for method output_type
+	8,  // [8:26] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_threadsnet_proto_init() }
@@ -1736,7 +2648,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PullThreadRequest); i {
+			switch v := v.(*GetThreadSummaryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1748,7 +2660,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PullThreadReply); i {
+			switch v := v.(*ThreadSummaryReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1760,7 +2672,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteThreadRequest); i {
+			switch v := v.(*PullThreadRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1772,7 +2684,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteThreadReply); i {
+			switch v := v.(*PullThreadReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1784,7 +2696,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddReplicatorRequest); i {
+			switch v := v.(*SetPullIntervalRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1796,7 +2708,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddReplicatorReply); i {
+			switch v := v.(*SetPullIntervalReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1808,7 +2720,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateRecordRequest); i {
+			switch v := v.(*SetPullPausedRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1820,7 +2732,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewRecordReply); i {
+			switch v := v.(*SetPullPausedReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1832,7 +2744,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddRecordRequest); i {
+			switch v := v.(*DeleteThreadRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1844,7 +2756,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Record); i {
+			switch v := v.(*DeleteThreadReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1856,7 +2768,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddRecordReply); i {
+			switch v := v.(*AddReplicatorRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1868,7 +2780,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetRecordRequest); i {
+			switch v := v.(*AddReplicatorReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1880,7 +2792,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetRecordReply); i {
+			switch v := v.(*RemoveReplicatorRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1892,6 +2804,102 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveReplicatorReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateRecordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewRecordReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddRecordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Record); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddRecordReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRecordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRecordReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SubscribeRequest); i {
 			case 0:
 				return &v.state
@@ -1903,6 +2911,78 @@ func file_threadsnet_proto_init() {
 				return nil
 			}
 		}
+		file_threadsnet_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPeerStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPeerStatsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushFailures); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMaxRecordSizeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMaxRecordSizeReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_threadsnet_proto_msgTypes[2].OneofWrappers = []interface{}{
 		(*GetTokenRequest_Key)(nil),
@@ -1918,7 +2998,7 @@ func file_threadsnet_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_threadsnet_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   24,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,13 +23,19 @@ type APIClient interface {
 	CreateThread(ctx context.Context, in *CreateThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error)
 	AddThread(ctx context.Context, in *AddThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error)
 	GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error)
+	GetThreadSummary(ctx context.Context, in *GetThreadSummaryRequest, opts ...grpc.CallOption) (*ThreadSummaryReply, error)
 	PullThread(ctx context.Context, in *PullThreadRequest, opts ...grpc.CallOption) (*PullThreadReply, error)
+	SetPullInterval(ctx context.Context, in *SetPullIntervalRequest, opts ...grpc.CallOption) (*SetPullIntervalReply, error)
+	SetPullPaused(ctx context.Context, in *SetPullPausedRequest, opts ...grpc.CallOption) (*SetPullPausedReply, error)
 	DeleteThread(ctx context.Context, in *DeleteThreadRequest, opts ...grpc.CallOption) (*DeleteThreadReply, error)
 	AddReplicator(ctx context.Context, in *AddReplicatorRequest, opts ...grpc.CallOption) (*AddReplicatorReply, error)
+	RemoveReplicator(ctx context.Context, in *RemoveReplicatorRequest, opts ...grpc.CallOption) (*RemoveReplicatorReply, error)
 	CreateRecord(ctx context.Context, in *CreateRecordRequest, opts ...grpc.CallOption) (*NewRecordReply, error)
 	AddRecord(ctx context.Context, in *AddRecordRequest, opts ...grpc.CallOption) (*AddRecordReply, error)
 	GetRecord(ctx context.Context, in *GetRecordRequest, opts ...grpc.CallOption) (*GetRecordReply, error)
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (API_SubscribeClient, error)
+	ListPeerStats(ctx context.Context, in *ListPeerStatsRequest, opts ...grpc.CallOption) (*ListPeerStatsReply, error)
+	GetMaxRecordSize(ctx context.Context, in *GetMaxRecordSizeRequest, opts ...grpc.CallOption) (*GetMaxRecordSizeReply, error)
 }
 
 type aPIClient struct {
@@ -107,6 +113,15 @@ func (c *aPIClient) GetThread(ctx context.Context, in *GetThreadRequest, opts ..
 	return out, nil
 }
 
+func (c *aPIClient) GetThreadSummary(ctx context.Context, in *GetThreadSummaryRequest, opts ...grpc.CallOption) (*ThreadSummaryReply, error) {
+	out := new(ThreadSummaryReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/GetThreadSummary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) PullThread(ctx context.Context, in *PullThreadRequest, opts ...grpc.CallOption) (*PullThreadReply, error) {
 	out := new(PullThreadReply)
 	err := c.cc.Invoke(ctx, "/threads.net.pb.API/PullThread", in, out, opts...)
@@ -116,6 +131,24 @@ func (c *aPIClient) PullThread(ctx context.Context, in *PullThreadRequest, opts
 	return out, nil
 }
 
+func (c *aPIClient) SetPullInterval(ctx context.Context, in *SetPullIntervalRequest, opts ...grpc.CallOption) (*SetPullIntervalReply, error) {
+	out := new(SetPullIntervalReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/SetPullInterval", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) SetPullPaused(ctx context.Context, in *SetPullPausedRequest, opts ...grpc.CallOption) (*SetPullPausedReply, error) {
+	out := new(SetPullPausedReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/SetPullPaused", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) DeleteThread(ctx context.Context, in *DeleteThreadRequest, opts ...grpc.CallOption) (*DeleteThreadReply, error) {
 	out := new(DeleteThreadReply)
 	err := c.cc.Invoke(ctx, "/threads.net.pb.API/DeleteThread", in, out, opts...)
@@ -134,6 +167,15 @@ func (c *aPIClient) AddReplicator(ctx context.Context, in *AddReplicatorRequest,
 	return out, nil
 }
 
+func (c *aPIClient) RemoveReplicator(ctx context.Context, in *RemoveReplicatorRequest, opts ...grpc.CallOption) (*RemoveReplicatorReply, error) {
+	out := new(RemoveReplicatorReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/RemoveReplicator", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) CreateRecord(ctx context.Context, in *CreateRecordRequest, opts ...grpc.CallOption) (*NewRecordReply, error) {
 	out := new(NewRecordReply)
 	err := c.cc.Invoke(ctx, "/threads.net.pb.API/CreateRecord", in, out, opts...)
@@ -193,6 +235,24 @@ func (x *aPISubscribeClient) Recv() (*NewRecordReply, error) {
 	return m, nil
 }
 
+func (c *aPIClient) ListPeerStats(ctx context.Context, in *ListPeerStatsRequest, opts ...grpc.CallOption) (*ListPeerStatsReply, error) {
+	out := new(ListPeerStatsReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/ListPeerStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetMaxRecordSize(ctx context.Context, in *GetMaxRecordSizeRequest, opts ...grpc.CallOption) (*GetMaxRecordSizeReply, error) {
+	out := new(GetMaxRecordSizeReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/GetMaxRecordSize", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // APIServer is the server API for API service.
 // All implementations must embed UnimplementedAPIServer
 // for forward compatibility
@@ -202,13 +262,19 @@ type APIServer interface {
 	CreateThread(context.Context, *CreateThreadRequest) (*ThreadInfoReply, error)
 	AddThread(context.Context, *AddThreadRequest) (*ThreadInfoReply, error)
 	GetThread(context.Context, *GetThreadRequest) (*ThreadInfoReply, error)
+	GetThreadSummary(context.Context, *GetThreadSummaryRequest) (*ThreadSummaryReply, error)
 	PullThread(context.Context, *PullThreadRequest) (*PullThreadReply, error)
+	SetPullInterval(context.Context, *SetPullIntervalRequest) (*SetPullIntervalReply, error)
+	SetPullPaused(context.Context, *SetPullPausedRequest) (*SetPullPausedReply, error)
 	DeleteThread(context.Context, *DeleteThreadRequest) (*DeleteThreadReply, error)
 	AddReplicator(context.Context, *AddReplicatorRequest) (*AddReplicatorReply, error)
+	RemoveReplicator(context.Context, *RemoveReplicatorRequest) (*RemoveReplicatorReply, error)
 	CreateRecord(context.Context, *CreateRecordRequest) (*NewRecordReply, error)
 	AddRecord(context.Context, *AddRecordRequest) (*AddRecordReply, error)
 	GetRecord(context.Context, *GetRecordRequest) (*GetRecordReply, error)
 	Subscribe(*SubscribeRequest, API_SubscribeServer) error
+	ListPeerStats(context.Context, *ListPeerStatsRequest) (*ListPeerStatsReply, error)
+	GetMaxRecordSize(context.Context, *GetMaxRecordSizeRequest) (*GetMaxRecordSizeReply, error)
 	mustEmbedUnimplementedAPIServer()
 }
 
@@ -231,15 +297,27 @@ func (UnimplementedAPIServer) AddThread(context.Context, *AddThreadRequest) (*Th
 func (UnimplementedAPIServer) GetThread(context.Context, *GetThreadRequest) (*ThreadInfoReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetThread not implemented")
 }
+func (UnimplementedAPIServer) GetThreadSummary(context.Context, *GetThreadSummaryRequest) (*ThreadSummaryReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetThreadSummary not implemented")
+}
 func (UnimplementedAPIServer) PullThread(context.Context, *PullThreadRequest) (*PullThreadReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PullThread not implemented")
 }
+func (UnimplementedAPIServer) SetPullInterval(context.Context, *SetPullIntervalRequest) (*SetPullIntervalReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPullInterval not implemented")
+}
+func (UnimplementedAPIServer) SetPullPaused(context.Context, *SetPullPausedRequest) (*SetPullPausedReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPullPaused not implemented")
+}
 func (UnimplementedAPIServer) DeleteThread(context.Context, *DeleteThreadRequest) (*DeleteThreadReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteThread not implemented")
 }
 func (UnimplementedAPIServer) AddReplicator(context.Context, *AddReplicatorRequest) (*AddReplicatorReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddReplicator not implemented")
 }
+func (UnimplementedAPIServer) RemoveReplicator(context.Context, *RemoveReplicatorRequest) (*RemoveReplicatorReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveReplicator not implemented")
+}
 func (UnimplementedAPIServer) CreateRecord(context.Context, *CreateRecordRequest) (*NewRecordReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateRecord not implemented")
 }
@@ -252,6 +330,12 @@ func (UnimplementedAPIServer) GetRecord(context.Context, *GetRecordRequest) (*Ge
 func (UnimplementedAPIServer) Subscribe(*SubscribeRequest, API_SubscribeServer) error {
 	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
 }
+func (UnimplementedAPIServer) ListPeerStats(context.Context, *ListPeerStatsRequest) (*ListPeerStatsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPeerStats not implemented")
+}
+func (UnimplementedAPIServer) GetMaxRecordSize(context.Context, *GetMaxRecordSizeRequest) (*GetMaxRecordSizeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMaxRecordSize not implemented")
+}
 func (UnimplementedAPIServer) mustEmbedUnimplementedAPIServer() {}
 
 // UnsafeAPIServer may be embedded to opt out of forward compatibility for this service.
@@ -363,6 +447,24 @@ func _API_GetThread_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_GetThreadSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThreadSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetThreadSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/GetThreadSummary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetThreadSummary(ctx, req.(*GetThreadSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_PullThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PullThreadRequest)
 	if err := dec(in); err != nil {
@@ -381,6 +483,42 @@ func _API_PullThread_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_SetPullInterval_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPullIntervalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).SetPullInterval(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/SetPullInterval",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SetPullInterval(ctx, req.(*SetPullIntervalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SetPullPaused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPullPausedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).SetPullPaused(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/SetPullPaused",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SetPullPaused(ctx, req.(*SetPullPausedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_DeleteThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteThreadRequest)
 	if err := dec(in); err != nil {
@@ -417,6 +555,24 @@ func _API_AddReplicator_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_RemoveReplicator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveReplicatorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).RemoveReplicator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/RemoveReplicator",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).RemoveReplicator(ctx, req.(*RemoveReplicatorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_CreateRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateRecordRequest)
 	if err := dec(in); err != nil {
@@ -492,6 +648,42 @@ func (x *aPISubscribeServer) Send(m *NewRecordReply) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _API_ListPeerStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPeerStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListPeerStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/ListPeerStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListPeerStats(ctx, req.(*ListPeerStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetMaxRecordSize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMaxRecordSizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetMaxRecordSize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/GetMaxRecordSize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetMaxRecordSize(ctx, req.(*GetMaxRecordSizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // API_ServiceDesc is the grpc.ServiceDesc for API service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -515,10 +707,22 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetThread",
 			Handler:    _API_GetThread_Handler,
 		},
+		{
+			MethodName: "GetThreadSummary",
+			Handler:    _API_GetThreadSummary_Handler,
+		},
 		{
 			MethodName: "PullThread",
 			Handler:    _API_PullThread_Handler,
 		},
+		{
+			MethodName: "SetPullInterval",
+			Handler:    _API_SetPullInterval_Handler,
+		},
+		{
+			MethodName: "SetPullPaused",
+			Handler:    _API_SetPullPaused_Handler,
+		},
 		{
 			MethodName: "DeleteThread",
 			Handler:    _API_DeleteThread_Handler,
@@ -527,6 +731,10 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddReplicator",
 			Handler:    _API_AddReplicator_Handler,
 		},
+		{
+			MethodName: "RemoveReplicator",
+			Handler:    _API_RemoveReplicator_Handler,
+		},
 		{
 			MethodName: "CreateRecord",
 			Handler:    _API_CreateRecord_Handler,
@@ -539,6 +747,14 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRecord",
 			Handler:    _API_GetRecord_Handler,
 		},
+		{
+			MethodName: "ListPeerStats",
+			Handler:    _API_ListPeerStats_Handler,
+		},
+		{
+			MethodName: "GetMaxRecordSize",
+			Handler:    _API_GetMaxRecordSize_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -4,7 +4,10 @@ package api
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	cbornode "github.com/ipfs/go-ipld-cbor"
@@ -16,6 +19,7 @@ import (
 	"github.com/textileio/go-threads/cbor"
 	"github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
+	tnet "github.com/textileio/go-threads/net"
 	pb "github.com/textileio/go-threads/net/api/pb"
 	"github.com/textileio/go-threads/net/util"
 	tutil "github.com/textileio/go-threads/util"
@@ -222,6 +226,36 @@ func (s *Service) GetThread(ctx context.Context, req *pb.GetThreadRequest) (*pb.
 	return threadInfoToProto(info)
 }
 
+func (s *Service) GetThreadSummary(ctx context.Context, req *pb.GetThreadSummaryRequest) (*pb.ThreadSummaryReply, error) {
+	log.Debugf("received get thread summary request")
+
+	id, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := s.net.GetThreadSummary(ctx, id, net.WithThreadToken(token))
+	if err != nil {
+		return nil, err
+	}
+	reply := &pb.ThreadSummaryReply{
+		ThreadID:      summary.ID.Bytes(),
+		HasServiceKey: summary.HasServiceKey,
+		HasReadKey:    summary.HasReadKey,
+		LogCount:      int64(summary.LogCount),
+		RecordCount:   summary.RecordCount,
+		PullInterval:  int64(summary.PullInterval),
+		PullPaused:    summary.PullPaused,
+	}
+	if !summary.LastUpdated.IsZero() {
+		reply.LastUpdated = summary.LastUpdated.UnixNano()
+	}
+	return reply, nil
+}
+
 func (s *Service) PullThread(ctx context.Context, req *pb.PullThreadRequest) (*pb.PullThreadReply, error) {
 	log.Debugf("received pull thread request")
 
@@ -239,6 +273,40 @@ func (s *Service) PullThread(ctx context.Context, req *pb.PullThreadRequest) (*p
 	return &pb.PullThreadReply{}, nil
 }
 
+func (s *Service) SetPullInterval(ctx context.Context, req *pb.SetPullIntervalRequest) (*pb.SetPullIntervalReply, error) {
+	log.Debugf("received set pull interval request")
+
+	id, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.net.SetPullInterval(ctx, id, time.Duration(req.Interval), net.WithThreadToken(token)); err != nil {
+		return nil, err
+	}
+	return &pb.SetPullIntervalReply{}, nil
+}
+
+func (s *Service) SetPullPaused(ctx context.Context, req *pb.SetPullPausedRequest) (*pb.SetPullPausedReply, error) {
+	log.Debugf("received set pull paused request")
+
+	id, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.net.SetPullPaused(ctx, id, req.Paused, net.WithThreadToken(token)); err != nil {
+		return nil, err
+	}
+	return &pb.SetPullPausedReply{}, nil
+}
+
 func (s *Service) DeleteThread(ctx context.Context, req *pb.DeleteThreadRequest) (*pb.DeleteThreadReply, error) {
 	log.Debugf("received delete thread request")
 
@@ -250,7 +318,12 @@ func (s *Service) DeleteThread(ctx context.Context, req *pb.DeleteThreadRequest)
 	if err != nil {
 		return nil, err
 	}
-	if err := s.net.DeleteThread(ctx, id, net.WithThreadToken(token)); err != nil {
+	if err := s.net.DeleteThread(
+		ctx,
+		id,
+		net.WithThreadToken(token),
+		net.WithDeletionAnnouncement(req.Announce),
+	); err != nil {
 		return nil, err
 	}
 	return &pb.DeleteThreadReply{}, nil
@@ -271,7 +344,11 @@ func (s *Service) AddReplicator(ctx context.Context, req *pb.AddReplicatorReques
 	if err != nil {
 		return nil, err
 	}
-	pid, err := s.net.AddReplicator(ctx, id, addr, net.WithThreadToken(token))
+	opts := []net.ThreadOption{net.WithThreadToken(token)}
+	if req.Expiry != 0 {
+		opts = append(opts, net.WithReplicatorExpiry(time.Unix(0, req.Expiry)))
+	}
+	pid, err := s.net.AddReplicator(ctx, id, addr, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -280,6 +357,27 @@ func (s *Service) AddReplicator(ctx context.Context, req *pb.AddReplicatorReques
 	}, nil
 }
 
+func (s *Service) RemoveReplicator(ctx context.Context, req *pb.RemoveReplicatorRequest) (*pb.RemoveReplicatorReply, error) {
+	log.Debugf("received remove replicator request")
+
+	id, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	addr, err := ma.NewMultiaddrBytes(req.Addr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.net.RemoveReplicator(ctx, id, addr, net.WithThreadToken(token)); err != nil {
+		return nil, err
+	}
+	return &pb.RemoveReplicatorReply{}, nil
+}
+
 func (s *Service) CreateRecord(ctx context.Context, req *pb.CreateRecordRequest) (*pb.NewRecordReply, error) {
 	log.Debugf("received create record request")
 
@@ -296,7 +394,9 @@ func (s *Service) CreateRecord(ctx context.Context, req *pb.CreateRecordRequest)
 		return nil, err
 	}
 	rec, err := s.net.CreateRecord(ctx, id, body, net.WithThreadToken(token))
-	if err != nil {
+	if errors.Is(err, net.ErrRecordTooLarge) {
+		return nil, status.Error(codes.OutOfRange, err.Error())
+	} else if err != nil {
 		return nil, err
 	}
 	prec, err := cbor.RecordToProto(ctx, s.net, rec.Value())
@@ -333,12 +433,24 @@ func (s *Service) AddRecord(ctx context.Context, req *pb.AddRecordRequest) (*pb.
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	if err = s.net.AddRecord(ctx, id, logID, rec, net.WithThreadToken(token)); err != nil {
+	if err = s.net.AddRecord(ctx, id, logID, rec, net.WithThreadToken(token)); errors.Is(err, net.ErrRecordTooLarge) {
+		return nil, status.Error(codes.OutOfRange, err.Error())
+	} else if err != nil {
 		return nil, err
 	}
 	return &pb.AddRecordReply{}, nil
 }
 
+func (s *Service) GetMaxRecordSize(ctx context.Context, _ *pb.GetMaxRecordSizeRequest) (*pb.GetMaxRecordSizeReply, error) {
+	log.Debugf("received get max record size request")
+
+	size, err := s.net.GetMaxRecordSize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetMaxRecordSizeReply{Size: int64(size)}, nil
+}
+
 func (s *Service) GetRecord(ctx context.Context, req *pb.GetRecordRequest) (*pb.GetRecordReply, error) {
 	log.Debugf("received get record request")
 
@@ -378,6 +490,13 @@ func (s *Service) Subscribe(req *pb.SubscribeRequest, server pb.API_SubscribeSer
 		}
 		opts[i] = net.WithSubFilter(id)
 	}
+	for _, id := range req.LogIDs {
+		lid, err := peer.IDFromBytes(id)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		opts = append(opts, net.WithSubFilterLogs(lid))
+	}
 
 	token, err := thread.NewTokenFromMD(server.Context())
 	if err != nil {
@@ -405,6 +524,21 @@ func (s *Service) Subscribe(req *pb.SubscribeRequest, server pb.API_SubscribeSer
 	return nil
 }
 
+func (s *Service) ListPeerStats(_ context.Context, _ *pb.ListPeerStatsRequest) (*pb.ListPeerStatsReply, error) {
+	log.Debugf("received list peer stats request")
+
+	reporter, ok := s.net.(tnet.PeerSyncReporter)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "Peer sync stats are not kept by the network")
+	}
+	list := reporter.ListPeerSyncStats()
+	stats := make([]*pb.PeerStats, len(list))
+	for i, ps := range list {
+		stats[i] = peerStatsToProto(ps)
+	}
+	return &pb.ListPeerStatsReply{Stats: stats}, nil
+}
+
 func marshalPeerID(id peer.ID) []byte {
 	b, _ := id.Marshal() // This will never return an error
 	return b
@@ -475,3 +609,32 @@ func threadInfoToProto(info thread.Info) (*pb.ThreadInfoReply, error) {
 		Addrs:     addrs,
 	}, nil
 }
+
+func peerStatsToProto(stats net.PeerSyncStats) *pb.PeerStats {
+	ps := &pb.PeerStats{
+		PeerID:       marshalPeerID(stats.Peer),
+		LastPushed:   unixNano(stats.LastPushed),
+		LastReceived: unixNano(stats.LastReceived),
+		Addrs:        make([][]byte, len(stats.Addrs)),
+		Down:         stats.Down,
+		BackoffUntil: unixNano(stats.BackoffUntil),
+	}
+	for class, count := range stats.FailedPushes {
+		ps.FailedPushes = append(ps.FailedPushes, &pb.PushFailures{Class: class, Count: count})
+	}
+	sort.Slice(ps.FailedPushes, func(i, j int) bool {
+		return ps.FailedPushes[i].Class < ps.FailedPushes[j].Class
+	})
+	for i, addr := range stats.Addrs {
+		ps.Addrs[i] = addr.Bytes()
+	}
+	return ps
+}
+
+// unixNano returns the unix time of t in nanoseconds, or zero if t is.
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
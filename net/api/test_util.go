@@ -16,8 +16,9 @@ import (
 )
 
 // CreateTestService creates a test network API gRPC service for test purpose.
-// It uses either the addr passed in as host addr, or pick an available local addr if it is empty
-func CreateTestService(addr string, debug bool) (hostAddr ma.Multiaddr, gRPCAddr ma.Multiaddr, stop func(), err error) {
+// It uses either the addr passed in as host addr, or pick an available local addr if it is empty.
+// Extra options are applied to the network after the defaults.
+func CreateTestService(addr string, debug bool, opts ...common.NetOption) (hostAddr ma.Multiaddr, gRPCAddr ma.Multiaddr, stop func(), err error) {
 	time.Sleep(time.Second * time.Duration(rand.Intn(5)))
 	dir, err := ioutil.TempDir("", "")
 	if err != nil {
@@ -28,12 +29,12 @@ func CreateTestService(addr string, debug bool) (hostAddr ma.Multiaddr, gRPCAddr
 	} else {
 		hostAddr, _ = ma.NewMultiaddr(addr)
 	}
-	n, err := common.DefaultNetwork(
+	n, err := common.DefaultNetwork(append([]common.NetOption{
 		common.WithNetBadgerPersistence(dir),
 		common.WithNetHostAddr(hostAddr),
 		common.WithNetPubSub(true),
 		common.WithNetDebug(debug),
-	)
+	}, opts...)...)
 	if err != nil {
 		return
 	}
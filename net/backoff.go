@@ -0,0 +1,283 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// DialBackoff is the backoff of the addresses of peers which failed to be
+// dialed. The delay before an address is dialed again starts at Initial and
+// doubles with each failure up to Max, and is randomly shortened or
+// lengthened by up to the fraction Jitter of it. A zero Initial doesn't back
+// off.
+type DialBackoff struct {
+	Initial time.Duration
+	Max     time.Duration
+	Jitter  float64
+}
+
+// Validate checks the backoff.
+func (b DialBackoff) Validate() error {
+	if b.Initial < 0 {
+		return errors.New("initial delay must not be negative")
+	}
+	if b.Initial > 0 && b.Max < b.Initial {
+		return errors.New("max delay must not be less than the initial delay")
+	}
+	if b.Jitter < 0 || b.Jitter > 1 {
+		return errors.New("jitter must be between 0 and 1")
+	}
+	return nil
+}
+
+// DefaultDialBackoff is a backoff which stops dialing peers which are down
+// at every pull, while retrying them within minutes.
+var DefaultDialBackoff = DialBackoff{
+	Initial: 5 * time.Second,
+	Max:     10 * time.Minute,
+	Jitter:  0.2,
+}
+
+// AddrBackoff is the backoff of an address of a peer, which isn't dialed
+// again until Until. Addr is nil for peers dialed without known addresses.
+type AddrBackoff struct {
+	Addr     ma.Multiaddr
+	Failures int
+	Until    time.Time
+}
+
+// PeerBackoff is the backoff of the addresses of a peer which failed to be
+// dialed. The peer is considered Down while all its addresses back off.
+type PeerBackoff struct {
+	Peer  peer.ID
+	Addrs []AddrBackoff
+	Down  bool
+}
+
+// DialBackoffReporter is implemented by the networks of NewNetwork, which
+// report the peers they back off dialing.
+type DialBackoffReporter interface {
+	// DialBackoffs returns the backoff of the peers which failed to be
+	// dialed since they were last reached, ordered by peer.
+	DialBackoffs() []PeerBackoff
+}
+
+var _ DialBackoffReporter = (*net)(nil)
+
+// errDialBackoff is returned by dials of peers whose addresses all back off.
+var errDialBackoff = errors.New("dial backoff")
+
+// addrFailures is the backoff of an address.
+type addrFailures struct {
+	failures int
+	until    time.Time
+}
+
+// dialBackoff keeps the backoff of the addresses of peers, by peer and the
+// string of the address. Peers without known addresses are kept under the
+// empty address.
+type dialBackoff struct {
+	lock   sync.Mutex
+	conf   DialBackoff
+	peers  map[peer.ID]map[string]*addrFailures
+	now    func() time.Time
+	random func() float64
+}
+
+func newDialBackoff(conf DialBackoff) *dialBackoff {
+	return &dialBackoff{
+		conf:   conf,
+		peers:  make(map[peer.ID]map[string]*addrFailures),
+		now:    time.Now,
+		random: rand.Float64,
+	}
+}
+
+func addrKeys(addrs []ma.Multiaddr) []string {
+	if len(addrs) == 0 {
+		return []string{""}
+	}
+	keys := make([]string, len(addrs))
+	for i, a := range addrs {
+		keys[i] = a.String()
+	}
+	return keys
+}
+
+// delay returns the delay before an address which failed the number of
+// times is dialed again.
+func (b *dialBackoff) delay(failures int) time.Duration {
+	d := b.conf.Initial
+	for i := 1; i < failures && d < b.conf.Max; i++ {
+		d *= 2
+	}
+	if d > b.conf.Max {
+		d = b.conf.Max
+	}
+	d = time.Duration(float64(d) * (1 + b.conf.Jitter*(2*b.random()-1)))
+	if d > b.conf.Max {
+		d = b.conf.Max
+	}
+	return d
+}
+
+// allow tells whether the peer may be dialed, which it may if any of its
+// addresses doesn't back off, as those it wasn't dialed at.
+func (b *dialBackoff) allow(p peer.ID, addrs []ma.Multiaddr) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	failed, ok := b.peers[p]
+	if b.conf.Initial == 0 || !ok {
+		return true
+	}
+	now := b.now()
+	for _, k := range addrKeys(addrs) {
+		if f, ok := failed[k]; !ok || !now.Before(f.until) {
+			return true
+		}
+	}
+	return false
+}
+
+// failure backs off the addresses of the peer, which failed to be dialed,
+// and forgets those it no longer has.
+func (b *dialBackoff) failure(p peer.ID, addrs []ma.Multiaddr) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.conf.Initial == 0 {
+		return
+	}
+	now := b.now()
+	failed := make(map[string]*addrFailures, len(addrs))
+	for _, k := range addrKeys(addrs) {
+		f, ok := b.peers[p][k]
+		if !ok {
+			f = &addrFailures{}
+		}
+		f.failures++
+		f.until = now.Add(b.delay(f.failures))
+		failed[k] = f
+	}
+	b.peers[p] = failed
+}
+
+// success forgets the backoff of the peer, which was reached.
+func (b *dialBackoff) success(p peer.ID) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	delete(b.peers, p)
+}
+
+// learn forgets the backoff of the peer if the address is one it wasn't
+// dialed at, so that it's dialed at once.
+func (b *dialBackoff) learn(p peer.ID, addr ma.Multiaddr) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	failed, ok := b.peers[p]
+	if !ok {
+		return
+	}
+	if _, ok := failed[addr.String()]; !ok {
+		log.Debugf("learned address %s of %s, resetting dial backoff", addr, p)
+		delete(b.peers, p)
+	}
+}
+
+func (b *dialBackoff) list() []PeerBackoff {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	now := b.now()
+	list := make([]PeerBackoff, 0, len(b.peers))
+	for p, failed := range b.peers {
+		list = append(list, peerBackoff(p, failed, now))
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Peer < list[j].Peer
+	})
+	return list
+}
+
+// get returns the backoff of the peer, if it failed to be dialed since it
+// was last reached.
+func (b *dialBackoff) get(p peer.ID) (PeerBackoff, bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	failed, ok := b.peers[p]
+	if !ok {
+		return PeerBackoff{}, false
+	}
+	return peerBackoff(p, failed, b.now()), true
+}
+
+func peerBackoff(p peer.ID, failed map[string]*addrFailures, now time.Time) PeerBackoff {
+	pb := PeerBackoff{Peer: p, Down: true}
+	for k, f := range failed {
+		ab := AddrBackoff{Failures: f.failures, Until: f.until}
+		if k != "" {
+			ab.Addr, _ = ma.NewMultiaddr(k)
+		}
+		if !now.Before(f.until) {
+			pb.Down = false
+		}
+		pb.Addrs = append(pb.Addrs, ab)
+	}
+	sort.Slice(pb.Addrs, func(i, j int) bool {
+		return pb.Addrs[i].Until.Before(pb.Addrs[j].Until)
+	})
+	return pb
+}
+
+// checkDialBackoff returns an error if the peer isn't connected and all its
+// addresses back off.
+func (n *net) checkDialBackoff(p peer.ID) error {
+	if n.host.Network().Connectedness(p) == network.Connected {
+		return nil
+	}
+	if !n.backoff.allow(p, n.host.Peerstore().Addrs(p)) {
+		return fmt.Errorf("%w of %s", errDialBackoff, p)
+	}
+	return nil
+}
+
+// dialed updates the backoff of the peer with the result of dialing it,
+// decaying its log addresses if it failed. Dials canceled by the caller
+// aren't failures.
+func (n *net) dialed(ctx context.Context, p peer.ID, err error) {
+	n.peerStats.dialed(p, n.host.Peerstore().Addrs(p))
+	if err == nil {
+		n.backoff.success(p)
+	} else if ctx.Err() != context.Canceled && n.ctx.Err() == nil {
+		n.backoff.failure(p, n.host.Peerstore().Addrs(p))
+		n.decayAddrs(p)
+		log.Debugf("dialing %s failed, backing off: %v", p, err)
+	}
+}
+
+// learnAddrs resets the backoff of the peers of the log addresses which
+// they weren't dialed at.
+func (n *net) learnAddrs(addrs []ma.Multiaddr) {
+	for _, addr := range addrs {
+		pid, ok, err := n.callablePeer(addr)
+		if err != nil || !ok {
+			continue
+		}
+		dialable, err := getDialable(addr)
+		if err != nil || len(dialable.Bytes()) == 0 {
+			continue
+		}
+		n.backoff.learn(pid, dialable)
+	}
+}
+
+func (n *net) DialBackoffs() []PeerBackoff {
+	return n.backoff.list()
+}
@@ -0,0 +1,118 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+func TestDialBackoff(t *testing.T) {
+	now := time.Now()
+	b := newDialBackoff(DialBackoff{Initial: time.Second, Max: 4 * time.Second, Jitter: 0.5})
+	b.now = func() time.Time { return now }
+	b.random = func() float64 { return 0.5 }
+	p := peer.ID("p")
+	a1, a2 := ma.StringCast("/ip4/127.0.0.1/tcp/1"), ma.StringCast("/ip4/127.0.0.1/tcp/2")
+	addrs := []ma.Multiaddr{a1}
+
+	if !b.allow(p, addrs) {
+		t.Fatal("expected peer without failures to be allowed")
+	}
+	for i, d := range []time.Duration{1, 2, 4, 4} {
+		b.failure(p, addrs)
+		if b.allow(p, addrs) {
+			t.Fatalf("expected failure %d to back off", i+1)
+		}
+		if until := b.list()[0].Addrs[0].Until; !until.Equal(now.Add(d * time.Second)) {
+			t.Fatalf("expected failure %d to back off for %ds, got %s", i+1, d, until.Sub(now))
+		}
+	}
+	if !b.allow(p, []ma.Multiaddr{a1, a2}) {
+		t.Fatal("expected peer with untried address to be allowed")
+	}
+	now = now.Add(4 * time.Second)
+	if !b.allow(p, addrs) {
+		t.Fatal("expected peer to be allowed once the backoff elapsed")
+	}
+	if l := b.list(); len(l) != 1 || l[0].Down || l[0].Addrs[0].Failures != 4 {
+		t.Fatalf("unexpected backoffs %+v", l)
+	}
+
+	b.random = func() float64 { return 0 }
+	b.failure(p, []ma.Multiaddr{a2})
+	l := b.list()
+	if len(l[0].Addrs) != 1 || !l[0].Addrs[0].Addr.Equal(a2) || !l[0].Down {
+		t.Fatalf("expected only the address dialed to back off, got %+v", l)
+	}
+	if until := l[0].Addrs[0].Until; !until.Equal(now.Add(500 * time.Millisecond)) {
+		t.Fatalf("expected jittered backoff of 500ms, got %s", until.Sub(now))
+	}
+
+	b.learn(p, a2)
+	if len(b.list()) != 1 {
+		t.Fatal("expected known address not to reset the backoff")
+	}
+	b.learn(p, a1)
+	if len(b.list()) != 0 {
+		t.Fatal("expected new address to reset the backoff")
+	}
+	b.failure(p, nil)
+	if b.allow(p, nil) || b.list()[0].Addrs[0].Addr != nil {
+		t.Fatal("expected peer without addresses to back off")
+	}
+	b.success(p)
+	if !b.allow(p, nil) || len(b.list()) != 0 {
+		t.Fatal("expected success to reset the backoff")
+	}
+}
+
+func TestNet_DialBackoff(t *testing.T) {
+	n := makeNetwork(t, func(c *Config) {
+		c.DialBackoff = DialBackoff{Initial: time.Minute, Max: time.Hour}
+	})
+	defer n.Close()
+	s := n.(*net).server
+
+	other := makeNetwork(t)
+	oid, oaddrs := other.Host().ID(), other.Host().Addrs()
+	n.Host().Peerstore().AddAddrs(oid, oaddrs, peerstore.PermanentAddrTTL)
+	if err := other.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Host().Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := s.dial(oid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), PullTimeout)
+	defer cancel()
+	if _, err := client.GetLogs(ctx, &pb.GetLogsRequest{}); err == nil {
+		t.Fatal("expected request to unreachable peer to fail")
+	}
+	l := n.(DialBackoffReporter).DialBackoffs()
+	if len(l) != 1 || l[0].Peer != oid || !l[0].Down || len(l[0].Addrs) != len(oaddrs) {
+		t.Fatalf("expected unreachable peer to back off, got %+v", l)
+	}
+	if _, err := s.dial(oid); !errors.Is(err, errDialBackoff) {
+		t.Fatalf("expected dial to back off, got %v", err)
+	}
+
+	p2p := ma.StringCast("/p2p/" + oid.String())
+	n.(*net).learnAddrs([]ma.Multiaddr{oaddrs[0].Encapsulate(p2p)})
+	if _, err := s.dial(oid); !errors.Is(err, errDialBackoff) {
+		t.Fatalf("expected dial to back off after learning a known address, got %v", err)
+	}
+	n.(*net).learnAddrs([]ma.Multiaddr{ma.StringCast("/ip4/127.0.0.1/tcp/1").Encapsulate(p2p)})
+	if l := n.(DialBackoffReporter).DialBackoffs(); len(l) != 0 {
+		t.Fatalf("expected new address to reset the backoff, got %+v", l)
+	}
+}
@@ -9,8 +9,8 @@ import (
 	"time"
 
 	"github.com/gogo/status"
+	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p-core/peer"
-	pstore "github.com/libp2p/go-libp2p-core/peerstore"
 	gostream "github.com/libp2p/go-libp2p-gostream"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/textileio/go-threads/cbor"
@@ -31,6 +31,10 @@ var (
 	PullTimeout = time.Second * 10
 )
 
+// getRecordsVersionPaged is the version of get records requests which peers
+// reply to with pages of the records following the offsets.
+const getRecordsVersionPaged = 1
+
 // getLogs in a thread.
 func (s *server) getLogs(ctx context.Context, id thread.ID, pid peer.ID) ([]thread.LogInfo, error) {
 	sk, err := s.net.store.ServiceKey(id)
@@ -135,6 +139,7 @@ func (s *server) getRecords(
 				}
 				for lid, rs := range recs {
 					rc.UpdateHeadCounter(lid, rs.counter)
+					rc.UpdateMore(lid, rs.more)
 					for _, rec := range rs.records {
 						rc.Store(lid, rec)
 					}
@@ -176,6 +181,7 @@ func (s *server) buildGetRecordsRequest(
 		ThreadID:   &pb.ProtoThreadID{ID: tid},
 		ServiceKey: &pb.ProtoKey{Key: serviceKey},
 		Logs:       pblgs,
+		Version:    getRecordsVersionPaged,
 	}
 
 	req = &pb.GetRecordsRequest{
@@ -187,6 +193,8 @@ func (s *server) buildGetRecordsRequest(
 type peerRecords struct {
 	records []core.Record
 	counter int64
+	// more tells whether the peer has records following these.
+	more bool
 }
 
 // Send GetRecords request to a certain peer.
@@ -200,26 +208,42 @@ func (s *server) getRecordsFromPeer(
 	log.Debugf("getting records from %s...", pid)
 	client, err := s.dial(pid)
 	if err != nil {
+		if errors.Is(err, errDialBackoff) {
+			s.net.metrics.pulls.WithLabelValues(outcomeSkipped).Inc()
+		}
 		return nil, fmt.Errorf("dial %s failed: %w", pid, err)
 	}
 
 	recs := make(map[peer.ID]peerRecords)
 	cctx, cancel := context.WithTimeout(ctx, PullTimeout)
 	defer cancel()
+	start := time.Now()
 	reply, err := client.GetRecords(cctx, req)
 	if err != nil {
+		s.net.metrics.pulled(start, 0, err)
 		log.Warnf("get records from %s failed: %s", pid, err)
 		return recs, nil
 	}
+	var received int
+	for _, l := range reply.Logs {
+		received += len(l.Records)
+	}
+	s.net.metrics.pulled(start, received, nil)
+	if received > 0 {
+		s.net.peerStats.received(pid)
+	}
 
 	for _, l := range reply.Logs {
 		var logID = l.LogID.ID
 		log.Debugf("received %d records in log %s from %s", len(l.Records), logID, pid)
 
 		if l.Log != nil && len(l.Log.Addrs) > 0 {
-			if err = s.net.store.AddAddrs(tid, logID, addrsFromProto(l.Log.Addrs), pstore.PermanentAddrTTL); err != nil {
+			addrs := addrsFromProto(l.Log.Addrs)
+			s.net.learnAddrs(addrs)
+			if err = s.net.addLogAddrs(tid, logID, addrs); err != nil {
 				return nil, err
 			}
+			s.net.protectThreadPeers(tid)
 		}
 
 		pk, err := s.net.store.PubKey(tid, logID)
@@ -239,6 +263,11 @@ func (s *server) getRecordsFromPeer(
 		}
 		var records []core.Record
 		for _, r := range l.Records {
+			if err := s.net.checkRecordSize(r); err != nil {
+				// the records following it can't be put without it
+				log.Warnf("rejecting records of log %s from %s: %v", logID, pid, err)
+				break
+			}
 			rec, err := cbor.RecordFromProto(r, serviceKey)
 			if err != nil {
 				return nil, err
@@ -258,6 +287,7 @@ func (s *server) getRecordsFromPeer(
 		recs[logID] = peerRecords{
 			records: records,
 			counter: counter,
+			more:    l.More,
 		}
 	}
 
@@ -296,8 +326,14 @@ func (s *server) pushRecord(ctx context.Context, tid thread.ID, lid peer.ID, rec
 
 	// Push to each address
 	for _, p := range peers {
+		if s.net.replicatorExpired(tid, p) {
+			log.Debugf("grant of replicator %s expired, skip pushing the record", p)
+			continue
+		}
+		s.net.metrics.pushesInFlight.Inc()
 		go func(pid peer.ID) {
-			if err := s.pushRecordToPeer(req, pid, tid, lid); err != nil {
+			defer s.net.metrics.pushesInFlight.Dec()
+			if err := s.pushRecordToPeer(req, pid, tid, lid, rec.Cid()); err != nil {
 				log.Errorf("pushing record to %s (thread: %s, log: %s) failed: %v", pid, tid, lid, err)
 			}
 		}(p)
@@ -311,28 +347,56 @@ func (s *server) pushRecord(ctx context.Context, tid thread.ID, lid peer.ID, rec
 	return nil
 }
 
+// pushRecordToPeer pushes the record with the request, queueing it in the
+// outbox if the peer is unreachable.
 func (s *server) pushRecordToPeer(
 	req *pb.PushRecordRequest,
 	pid peer.ID,
 	tid thread.ID,
 	lid peer.ID,
+	rid cid.Cid,
 ) error {
 	client, err := s.dial(pid)
-	if err != nil {
+	if errors.Is(err, errDialBackoff) {
+		s.net.metrics.recordsPushed.WithLabelValues(outcomeSkipped).Inc()
+		s.net.peerStats.pushFailed(pid, pushFailureBackoff)
+		log.Debugf("%s backs off, queue the record", pid)
+		s.net.queueRecord(tid, lid, rid, req.Counter, pid)
+		return nil
+	} else if err != nil {
+		s.net.metrics.recordsPushed.WithLabelValues(outcomeFailure).Inc()
+		s.net.peerStats.pushFailed(pid, pushFailureDial)
+		s.net.queueRecord(tid, lid, rid, req.Counter, pid)
 		return fmt.Errorf("dial failed: %w", err)
 	}
 	rctx, cancel := context.WithTimeout(context.Background(), PushTimeout)
 	defer cancel()
+	start := time.Now()
 	_, err = client.PushRecord(rctx, req)
+	s.net.metrics.pushed(start, err)
 	if err == nil {
+		s.net.peerStats.pushed(pid)
+		s.net.deliveredRecord(tid, lid, rid, req.Counter, pid)
 		return nil
 	}
 
-	switch status.Convert(err).Code() {
-	case codes.Unavailable:
-		log.Debugf("%s unavailable, skip pushing the record", pid)
+	code := status.Convert(err).Code()
+	s.net.peerStats.pushFailed(pid, code.String())
+	switch code {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		log.Debugf("%s unavailable, queue the record", pid)
+		s.net.queueRecord(tid, lid, rid, req.Counter, pid)
 		return nil
 
+	case codes.FailedPrecondition:
+		// the peer doesn't host the thread, such as after deleting it
+		log.Debugf("%s doesn't host thread %s, stop pushing records to it", pid, tid)
+		info, err := s.net.store.GetThread(tid)
+		if err != nil {
+			return fmt.Errorf("getting thread information: %w", err)
+		}
+		return s.net.withdrawPeerAddrs(tid, pid, info.Logs)
+
 	case codes.NotFound:
 		// send the missing log
 		lctx, cancel := context.WithTimeout(s.net.ctx, PushTimeout)
@@ -446,10 +510,26 @@ func (s *server) exchangeEdges(ctx context.Context, pid peer.ID, tids []thread.I
 }
 
 // dial attempts to open a gRPC connection over libp2p to a peer.
+// resetConnectBackoff makes the connection to the peer, if any, reconnect
+// without waiting for its backoff.
+func (s *server) resetConnectBackoff(peerID peer.ID) {
+	s.Lock()
+	defer s.Unlock()
+	if conn, ok := s.conns[peerID]; ok {
+		conn.ResetConnectBackoff()
+	}
+}
+
 func (s *server) dial(peerID peer.ID) (pb.ServiceClient, error) {
 	s.Lock()
 	defer s.Unlock()
 	conn, ok := s.conns[peerID]
+	if ok && conn.GetState() == connectivity.Ready {
+		return pb.NewServiceClient(conn), nil
+	}
+	if err := s.net.checkDialBackoff(peerID); err != nil {
+		return nil, err
+	}
 	if ok {
 		if conn.GetState() == connectivity.Shutdown {
 			if err := conn.Close(); err != nil && status.Code(err) != codes.Canceled {
@@ -477,7 +557,12 @@ func (s *server) getLibp2pDialer() grpc.DialOption {
 			return nil, fmt.Errorf("grpc tried to dial non peerID: %w", err)
 		}
 
+		if err := s.net.checkDialBackoff(id); err != nil {
+			return nil, err
+		}
+		s.net.connectLogAddrs(ctx, id)
 		conn, err := gostream.Dial(ctx, s.net.host, id, thread.Protocol)
+		s.net.dialed(ctx, id, err)
 		if err != nil {
 			return nil, fmt.Errorf("gostream dial failed: %w", err)
 		}
@@ -487,7 +572,9 @@ func (s *server) getLibp2pDialer() grpc.DialOption {
 }
 
 func withErrLog(pid peer.ID, f func(pid peer.ID) error) {
-	if err := f(pid); err != nil {
+	if err := f(pid); errors.Is(err, errDialBackoff) {
+		log.Debug(err.Error())
+	} else if err != nil {
 		log.Error(err.Error())
 	}
 }
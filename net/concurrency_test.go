@@ -0,0 +1,132 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestNet_CreateRecordConcurrently(t *testing.T) {
+	n := makeNetwork(t, func(c *Config) { c.Debug = false })
+	defer n.Close()
+	ctx := context.Background()
+
+	const threads, writers, records = 10, 8, 10
+	infos := make([]thread.Info, threads)
+	for i := range infos {
+		infos[i] = createThread(t, ctx, n)
+	}
+
+	// heads must never regress while records are created
+	done := make(chan struct{})
+	var watch sync.WaitGroup
+	watch.Add(1)
+	go func() {
+		defer watch.Done()
+		last := make(map[thread.ID]int64)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			for _, info := range infos {
+				head, err := n.(*net).currentHead(info.ID, info.Logs[0].ID)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if head.Counter < last[info.ID] {
+					t.Errorf("head of thread %s regressed from %d to %d", info.ID, last[info.ID], head.Counter)
+					return
+				}
+				last[info.ID] = head.Counter
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for _, info := range infos {
+		for w := 0; w < writers; w++ {
+			wg.Add(1)
+			go func(id thread.ID, w int) {
+				defer wg.Done()
+				for i := 0; i < records; i++ {
+					body, err := cbornode.WrapObject(map[string]interface{}{"w": w, "i": i}, mh.SHA2_256, -1)
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					if _, err := n.CreateRecord(ctx, id, body); err != nil {
+						t.Error(err)
+						return
+					}
+				}
+			}(info.ID, w)
+		}
+	}
+	wg.Wait()
+	close(done)
+	watch.Wait()
+
+	// the records of each log must form a single chain
+	for _, info := range infos {
+		head, err := n.(*net).currentHead(info.ID, info.Logs[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if head.Counter != writers*records {
+			t.Fatalf("expected head counter %d, got %d", writers*records, head.Counter)
+		}
+		var length int64
+		for rid := head.ID; rid.Defined(); length++ {
+			rec, err := n.GetRecord(ctx, info.ID, rid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rid = rec.PrevID()
+		}
+		if length != head.Counter {
+			t.Fatalf("expected chain of %d records, got %d", head.Counter, length)
+		}
+	}
+}
+
+func BenchmarkNet_CreateRecordConcurrently(b *testing.B) {
+	for _, threads := range []int{1, 100} {
+		b.Run(fmt.Sprintf("threads=%d", threads), func(b *testing.B) {
+			n := makeNetwork(b, func(c *Config) {
+				c.Debug = false
+				c.PubSub = false
+			})
+			defer n.Close()
+			ctx := context.Background()
+			ids := make([]thread.ID, threads)
+			for i := range ids {
+				ids[i] = createThread(b, ctx, n).ID
+			}
+			body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			var next int64
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					id := ids[int(atomic.AddInt64(&next, 1))%threads]
+					if _, err := n.CreateRecord(ctx, id, body); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
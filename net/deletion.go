@@ -0,0 +1,205 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gogo/status"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+	"google.golang.org/grpc/codes"
+)
+
+// deletionSigPrefix prefixes the bodies of deletion announcements signed by
+// the keys of logs, so that the signatures can't be taken for signatures of
+// records.
+const deletionSigPrefix = "/threads/deletion/"
+
+// errThreadUnknown is returned to the push record requests of threads which
+// aren't hosted, such as those deleted, for the peers to stop pushing them.
+var errThreadUnknown = status.Error(codes.FailedPrecondition, "unknown thread, stop sending")
+
+// ThreadDeletion is the deletion of a thread announced by the owner of one
+// of its logs. Deleted tells whether the thread was deleted along, as it is
+// with Config.DeleteAnnouncedThreads.
+type ThreadDeletion struct {
+	ThreadID thread.ID
+	LogID    peer.ID
+	Deleted  bool
+}
+
+// DeletionNotifier is implemented by the networks of NewNetwork, which
+// report the deletions of threads announced by the owners of their logs.
+type DeletionNotifier interface {
+	// ThreadDeletions returns a channel of the deletions announced until
+	// ctx is done.
+	ThreadDeletions(ctx context.Context) (<-chan ThreadDeletion, error)
+}
+
+var _ DeletionNotifier = (*net)(nil)
+
+func deletionSigData(body *pb.DeleteThreadRequest_Body) ([]byte, error) {
+	b, err := body.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(deletionSigPrefix), b...), nil
+}
+
+// announceDeletion sends the deletion of the thread, signed by each log of
+// the host, to the peers of the thread. Peers which fail to get it are
+// logged only, since the deletion doesn't depend on them.
+func (n *net) announceDeletion(id thread.ID) error {
+	info, err := n.store.GetThread(id)
+	if err != nil {
+		return err
+	}
+	var (
+		addrs []ma.Multiaddr
+		reqs  []*pb.DeleteThreadRequest
+	)
+	for _, lg := range info.Logs {
+		addrs = append(addrs, lg.Addrs...)
+		if lg.PrivKey == nil {
+			continue
+		}
+		body := &pb.DeleteThreadRequest_Body{
+			ThreadID: &pb.ProtoThreadID{ID: id},
+			LogID:    &pb.ProtoPeerID{ID: lg.ID},
+		}
+		data, err := deletionSigData(body)
+		if err != nil {
+			return err
+		}
+		sig, err := lg.PrivKey.Sign(data)
+		if err != nil {
+			return err
+		}
+		reqs = append(reqs, &pb.DeleteThreadRequest{Body: body, Sig: sig})
+	}
+	if len(reqs) == 0 {
+		log.Debugf("no log of thread %s owned, skip announcing the deletion", id)
+		return nil
+	}
+	peers, err := n.uniquePeers(addrs)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, p := range peers {
+		wg.Add(1)
+		go func(pid peer.ID) {
+			defer wg.Done()
+			client, err := n.server.dial(pid)
+			if err != nil {
+				log.Debugf("dialing %s to announce deletion of thread %s failed: %v", pid, id, err)
+				return
+			}
+			for _, req := range reqs {
+				ctx, cancel := context.WithTimeout(n.ctx, PushTimeout)
+				_, err := client.DeleteThread(ctx, req)
+				cancel()
+				if err != nil {
+					log.Debugf("announcing deletion of thread %s to %s failed: %v", id, pid, err)
+					return
+				}
+			}
+		}(p)
+	}
+	wg.Wait()
+	log.Debugf("announced deletion of thread %s to %d peers", id, len(peers))
+	return nil
+}
+
+// DeleteThread receives a deletion announcement.
+func (s *server) DeleteThread(ctx context.Context, req *pb.DeleteThreadRequest) (*pb.DeleteThreadReply, error) {
+	pid, err := peerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("received delete thread request from %s", pid)
+	if req.Body == nil || req.Body.ThreadID == nil || req.Body.LogID == nil {
+		return nil, status.Error(codes.InvalidArgument, "thread and log are required")
+	}
+	tid, lid := req.Body.ThreadID.ID, req.Body.LogID.ID
+
+	// Only the owner of a log of the thread may announce its deletion
+	logpk, err := s.net.store.PubKey(tid, lid)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if logpk == nil {
+		return nil, status.Error(codes.NotFound, "log not found")
+	}
+	data, err := deletionSigData(req.Body)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if ok, err := logpk.Verify(data, req.Sig); err != nil || !ok {
+		return nil, status.Error(codes.PermissionDenied, "invalid signature")
+	}
+
+	deleted, err := s.net.handleDeletion(tid, lid, pid)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	ev := &ThreadDeletion{ThreadID: tid, LogID: lid, Deleted: deleted}
+	if err := s.net.bus.SendWithTimeout(ev, notifyTimeout); err != nil {
+		log.Warnf("notifying deletion of thread %s: %v", tid, err)
+	}
+	return &pb.DeleteThreadReply{}, nil
+}
+
+// handleDeletion deletes the thread announced as deleted with
+// Config.DeleteAnnouncedThreads, unless an app is connected to it, and
+// otherwise stops replicating it to the peer which announced it.
+func (n *net) handleDeletion(tid thread.ID, lid, pid peer.ID) (deleted bool, err error) {
+	ts := n.semaphores.Get(semaThreadUpdate(tid))
+	ts.Acquire()
+	defer ts.Release()
+
+	if _, connected := n.getConnector(tid); n.conf.DeleteAnnouncedThreads && !connected {
+		if err := n.deleteThread(n.ctx, tid); err != nil {
+			return false, fmt.Errorf("deleting thread: %w", err)
+		}
+		log.Infof("deleted thread %s announced as deleted by owner of log %s", tid, lid)
+		return true, nil
+	}
+	info, err := n.store.GetThread(tid)
+	if err != nil {
+		return false, err
+	}
+	log.Infof("thread %s announced as deleted by owner of log %s", tid, lid)
+	return false, n.withdrawPeerAddrs(tid, pid, info.Logs)
+}
+
+func (n *net) ThreadDeletions(ctx context.Context) (<-chan ThreadDeletion, error) {
+	channel := make(chan ThreadDeletion)
+	listener := n.bus.Listen()
+	go func() {
+		defer close(channel)
+		defer listener.Discard()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case i, ok := <-listener.Channel():
+				if !ok {
+					return
+				}
+				if ev, ok := i.(*ThreadDeletion); ok {
+					select {
+					case channel <- *ev:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+	return channel, nil
+}
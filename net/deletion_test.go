@@ -0,0 +1,118 @@
+package net
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogo/status"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+	"google.golang.org/grpc/codes"
+	grpcpeer "google.golang.org/grpc/peer"
+)
+
+func TestNet_DeletionAnnouncement(t *testing.T) {
+	noPubSub := func(c *Config) { c.PubSub = false }
+	n1 := makeNetwork(t, noPubSub)
+	defer n1.Close()
+	n2 := makeNetwork(t, noPubSub)
+	defer n2.Close()
+	n3 := makeNetwork(t, noPubSub, func(c *Config) { c.DeleteAnnouncedThreads = true })
+	defer n3.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	info := createThread(t, ctx, n1)
+	for _, n := range []core.Net{n2, n3} {
+		n1.Host().Peerstore().AddAddrs(n.Host().ID(), n.Host().Addrs(), peerstore.PermanentAddrTTL)
+		n.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+		raddr, err := ma.NewMultiaddr("/p2p/" + n.Host().ID().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := n1.AddReplicator(ctx, info.ID, raddr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d2, err := n2.(DeletionNotifier).ThreadDeletions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d3, err := n3.(DeletionNotifier).ThreadDeletions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("invalid signature", func(t *testing.T) {
+		s := n2.(*net).server
+		pctx := grpcpeer.NewContext(ctx, &grpcpeer.Peer{Addr: &addr{id: n1.Host().ID()}})
+		_, err := s.DeleteThread(pctx, &pb.DeleteThreadRequest{
+			Body: &pb.DeleteThreadRequest_Body{
+				ThreadID: &pb.ProtoThreadID{ID: info.ID},
+				LogID:    &pb.ProtoPeerID{ID: info.Logs[0].ID},
+			},
+			Sig: []byte("sig"),
+		})
+		if code := status.Code(err); code != codes.PermissionDenied {
+			t.Fatalf("expected announcement with invalid signature to be denied, got %s", code)
+		}
+	})
+
+	if err := n1.DeleteThread(ctx, info.ID, core.WithDeletionAnnouncement(true)); err != nil {
+		t.Fatal(err)
+	}
+	for i, d := range []<-chan ThreadDeletion{d2, d3} {
+		select {
+		case ev := <-d:
+			if ev.ThreadID != info.ID || ev.LogID != info.Logs[0].ID || ev.Deleted != (i == 1) {
+				t.Fatalf("unexpected deletion %+v", ev)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected deletion to be announced")
+		}
+	}
+
+	// n2 keeps the thread without the addresses of n1, and n3 deleted it
+	rinfo, err := n2.GetThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, lg := range rinfo.Logs {
+		for _, a := range lg.Addrs {
+			if pid, err := a.ValueForProtocol(ma.P_P2P); err == nil && pid == n1.Host().ID().String() {
+				t.Fatalf("expected addresses of owner to be withdrawn, got %v", lg.Addrs)
+			}
+		}
+	}
+	if _, err := n3.GetThread(ctx, info.ID); err == nil {
+		t.Fatal("expected announced thread to be deleted")
+	}
+
+	t.Run("unknown thread", func(t *testing.T) {
+		s := n1.(*net).server
+		pctx := grpcpeer.NewContext(ctx, &grpcpeer.Peer{Addr: &addr{id: n2.Host().ID()}})
+		_, err := s.PushRecord(pctx, &pb.PushRecordRequest{
+			Body: &pb.PushRecordRequest_Body{
+				ThreadID: &pb.ProtoThreadID{ID: info.ID},
+				LogID:    &pb.ProtoPeerID{ID: info.Logs[0].ID},
+			},
+		})
+		if code := status.Code(err); code != codes.FailedPrecondition {
+			t.Fatalf("expected push of deleted thread to fail precondition, got %s", code)
+		}
+		_, err = s.PushRecord(pctx, &pb.PushRecordRequest{
+			Body: &pb.PushRecordRequest_Body{
+				ThreadID: &pb.ProtoThreadID{ID: thread.NewIDV1(thread.Raw, 32)},
+				LogID:    &pb.ProtoPeerID{ID: info.Logs[0].ID},
+			},
+		})
+		if code := status.Code(err); code != codes.FailedPrecondition {
+			t.Fatalf("expected push of unknown thread to fail precondition, got %s", code)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package net
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// Peers gossip the edges of the heads of the threads they share every
+// HeadGossipInterval and once connected, which are hashed by the headbook
+// without loading the records. The threads which edges differ are pulled from
+// the peer, so a missed record doesn't wait for the thread to be pulled in
+// the background. The edges are exchanged in pages of MaxThreadsExchanged
+// threads, as the edges of the pulled threads are.
+
+// startHeadGossip gossips the heads of the shared threads with the connected
+// peers every HeadGossipInterval, if it's set.
+func (n *net) startHeadGossip() {
+	if n.conf.HeadGossipInterval <= 0 {
+		return
+	}
+	tick := time.NewTicker(n.conf.HeadGossipInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-tick.C:
+			shared, err := n.sharedThreads()
+			if err != nil {
+				log.Errorf("listing shared threads: %v", err)
+				continue
+			}
+			for pid, ts := range shared {
+				if n.host.Network().Connectedness(pid) == network.Connected {
+					go n.gossipHeads(pid, ts)
+				}
+			}
+
+		case <-n.ctx.Done():
+			return
+		}
+	}
+}
+
+// notifyHeadGossip returns the notifiee gossiping the heads of the shared
+// threads with the peers once they connect.
+func (n *net) notifyHeadGossip() *network.NotifyBundle {
+	return &network.NotifyBundle{
+		ConnectedF: func(_ network.Network, c network.Conn) {
+			go func(pid peer.ID) {
+				shared, err := n.sharedThreads()
+				if err != nil {
+					log.Errorf("listing shared threads: %v", err)
+					return
+				}
+				n.gossipHeads(pid, shared[pid])
+			}(c.RemotePeer())
+		},
+	}
+}
+
+// sharedThreads returns the threads shared with each of the peers, that is
+// those which logs have the peer addresses, skipping the threads which
+// pulls are paused.
+func (n *net) sharedThreads() (map[peer.ID][]thread.ID, error) {
+	ts, err := n.store.Threads()
+	if err != nil {
+		return nil, err
+	}
+	shared := make(map[peer.ID][]thread.ID)
+	for _, id := range ts {
+		if n.pullPaused(id) {
+			continue
+		}
+		info, err := n.store.GetThread(id)
+		if err != nil {
+			return nil, err
+		}
+		var addrs []ma.Multiaddr
+		for _, lg := range info.Logs {
+			addrs = append(addrs, lg.Addrs...)
+		}
+		peers, err := n.uniquePeers(addrs)
+		if err != nil {
+			return nil, err
+		}
+		for _, pid := range peers {
+			shared[pid] = append(shared[pid], id)
+		}
+	}
+	return shared, nil
+}
+
+// gossipHeads exchanges the edges of the threads with the peer, page by page,
+// unless they're being exchanged already.
+func (n *net) gossipHeads(pid peer.ID, ts []thread.ID) {
+	if len(ts) == 0 {
+		return
+	}
+	n.gossipLock.Lock()
+	if _, ok := n.gossiping[pid]; ok {
+		n.gossipLock.Unlock()
+		return
+	}
+	n.gossiping[pid] = struct{}{}
+	n.gossipLock.Unlock()
+	defer func() {
+		n.gossipLock.Lock()
+		delete(n.gossiping, pid)
+		n.gossipLock.Unlock()
+	}()
+
+	for len(ts) > 0 {
+		page := ts
+		if len(page) > MaxThreadsExchanged {
+			page = page[:MaxThreadsExchanged]
+		}
+		ts = ts[len(page):]
+		if err := n.server.exchangeEdges(n.ctx, pid, page); err != nil {
+			log.Debugf("gossiping heads with %s failed: %v", pid, err)
+			return
+		}
+	}
+}
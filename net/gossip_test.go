@@ -0,0 +1,94 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peer"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestNet_HeadGossip(t *testing.T) {
+	t.Run("periodically", func(t *testing.T) {
+		testHeadGossip(t, 200*time.Millisecond, false)
+	})
+	t.Run("once connected", func(t *testing.T) {
+		testHeadGossip(t, time.Hour, true)
+	})
+}
+
+func testHeadGossip(t *testing.T, interval time.Duration, reconnect bool) {
+	configure := func(c *Config) {
+		c.PubSub = false
+		c.NoNetPulling = true
+		c.HeadGossipInterval = interval
+	}
+	n1 := makeNetwork(t, configure)
+	defer n1.Close()
+	n2 := makeNetwork(t, configure)
+	defer n2.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	info, err := n1.GetThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n2.AddThread(ctx, info.Addrs[0], core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+
+	// the record pushed to n2 is missed
+	var missed int32 = 1
+	n2.(RecordInterceptorRegistry).RegisterRecordInterceptor(func(context.Context, thread.ID, peer.ID, core.Record) error {
+		if atomic.LoadInt32(&missed) == 1 {
+			return errors.New("missed")
+		}
+		return nil
+	})
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n1.CreateRecord(ctx, info.ID, body); err != nil {
+		t.Fatal(err)
+	}
+	head := func() int64 {
+		t.Helper()
+		h, err := n2.(*net).currentHead(info.ID, info.Logs[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h.Counter
+	}
+	time.Sleep(time.Second)
+	if h := head(); h != 0 {
+		t.Fatalf("expected record to be missed, got head %d", h)
+	}
+	atomic.StoreInt32(&missed, 0)
+
+	if reconnect {
+		h1, h2 := n1.Host(), n2.Host()
+		addrs := h1.Peerstore().Addrs(h2.ID())
+		if err := h1.Network().ClosePeer(h2.ID()); err != nil {
+			t.Fatal(err)
+		}
+		if err := h1.Connect(ctx, peer.AddrInfo{ID: h2.ID(), Addrs: addrs}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the edges of the heads differ, so the thread is pulled
+	for i := 0; head() != 1; i++ {
+		if i == 100 {
+			t.Fatal("expected missed record to be pulled after gossiping heads")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
@@ -0,0 +1,130 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// RecordInterceptor is called with each record received from peers after
+// its signature is verified, and before it's stored and its events are
+// emitted. An error wrapping ErrRecordRejected rejects the record, and those
+// following it in its log. Other errors are failures to handle the record,
+// which leave it to be received again, like after a timeout. Interceptors are
+// called while the thread is locked for updates, so they must not add records
+// to it.
+type RecordInterceptor func(ctx context.Context, id thread.ID, lid peer.ID, rec core.Record) error
+
+// RecordInterceptorRegistry is implemented by the networks of NewNetwork,
+// which let the records received from peers be intercepted.
+type RecordInterceptorRegistry interface {
+	// RegisterRecordInterceptor adds the interceptor of the records
+	// received from peers. Interceptors are called in the order they were
+	// registered, until one returns an error.
+	RegisterRecordInterceptor(RecordInterceptor)
+}
+
+var _ RecordInterceptorRegistry = (*net)(nil)
+
+// ErrRecordRejected is wrapped by the errors of the interceptors rejecting
+// records, which are denied to the peers pushing them.
+var ErrRecordRejected = errors.New("record rejected")
+
+func (n *net) RegisterRecordInterceptor(i RecordInterceptor) {
+	n.interceptLock.Lock()
+	defer n.interceptLock.Unlock()
+	n.interceptors = append(n.interceptors, i)
+}
+
+// interceptRecord calls the interceptors with the record, removing its
+// blocks if it's rejected. The blocks are kept if an interceptor fails
+// otherwise, so that the record can be handled again.
+func (n *net) interceptRecord(ctx context.Context, id thread.ID, lid peer.ID, rec core.Record) error {
+	n.interceptLock.RLock()
+	interceptors := n.interceptors
+	n.interceptLock.RUnlock()
+
+	for _, intercept := range interceptors {
+		err := intercept(ctx, id, lid, rec)
+		if errors.Is(err, ErrRecordRejected) {
+			log.Warnf("record %s of log %s in thread %s rejected: %v", rec.Cid(), lid, id, err)
+			if err := n.removeEventBlocks(ctx, rec); err != nil {
+				return fmt.Errorf("removing invalid blocks: %w", err)
+			}
+			return err
+		} else if err != nil {
+			return fmt.Errorf("intercepting record %s: %w", rec.Cid(), err)
+		}
+	}
+	return nil
+}
+
+// removeEventBlocks removes the stored blocks of the event of the record.
+func (n *net) removeEventBlocks(ctx context.Context, rec core.Record) error {
+	event, err := n.recordEvent(ctx, rec)
+	if err != nil {
+		return err
+	}
+	header, err := event.GetHeader(ctx, n, nil)
+	if err != nil {
+		return err
+	}
+	body, err := event.GetBody(ctx, n, nil)
+	if err != nil {
+		return err
+	}
+	return n.RemoveMany(ctx, []cid.Cid{event.Cid(), header.Cid(), body.Cid()})
+}
+
+// recordEvent returns the event of the record.
+func (n *net) recordEvent(ctx context.Context, rec core.Record) (*cbor.Event, error) {
+	block, err := rec.GetBlock(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	event, ok := block.(*cbor.Event)
+	if !ok {
+		event, err = cbor.EventFromNode(block)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event: %w", err)
+		}
+	}
+	return event, nil
+}
+
+// validateConnectedRecord is the interceptor of the threads with a connected
+// app, which validates the decrypted bodies of their records.
+func (n *net) validateConnectedRecord(ctx context.Context, id thread.ID, _ peer.ID, rec core.Record) error {
+	connector, ok := n.getConnector(id)
+	if !ok {
+		return nil
+	}
+	readKey, err := n.store.ReadKey(id)
+	if err != nil {
+		return err
+	} else if readKey == nil {
+		return nil
+	}
+	event, err := n.recordEvent(ctx, rec)
+	if err != nil {
+		return err
+	}
+	body, err := event.GetBody(ctx, n, readKey)
+	if err != nil {
+		return err
+	}
+	identity := &thread.Libp2pPubKey{}
+	if err = identity.UnmarshalBinary(rec.PubKey()); err != nil {
+		return err
+	}
+	if err = connector.ValidateNetRecordBody(ctx, body, identity); err != nil {
+		return fmt.Errorf("%w: %v", ErrRecordRejected, err)
+	}
+	return nil
+}
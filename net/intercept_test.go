@@ -0,0 +1,154 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/gogo/status"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+	"google.golang.org/grpc/codes"
+	grpcpeer "google.golang.org/grpc/peer"
+)
+
+func TestNet_RecordInterceptors(t *testing.T) {
+	noPubSub := func(c *Config) { c.PubSub = false }
+	n1 := makeNetwork(t, noPubSub, noConfirmedAddrs)
+	defer n1.Close()
+	n2 := makeNetwork(t, noPubSub, noConfirmedAddrs, func(c *Config) { c.NoNetPulling = true })
+	defer n2.Close()
+
+	var (
+		lock  sync.Mutex
+		calls []string
+		// result is the error of the second interceptor
+		result = fmt.Errorf("%w: denied", ErrRecordRejected)
+	)
+	intercept := func(name string) RecordInterceptor {
+		return func(_ context.Context, _ thread.ID, _ peer.ID, _ core.Record) error {
+			lock.Lock()
+			defer lock.Unlock()
+			calls = append(calls, name)
+			if name == "second" {
+				return result
+			}
+			return nil
+		}
+	}
+	for _, name := range []string{"first", "second", "third"} {
+		n2.(RecordInterceptorRegistry).RegisterRecordInterceptor(intercept(name))
+	}
+
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	raddr, err := ma.NewMultiaddr("/p2p/" + n2.Host().ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n1.AddReplicator(ctx, info.ID, raddr); err != nil {
+		t.Fatal(err)
+	}
+	// disconnect the peers to push the records to the replicator directly
+	n1.Host().Peerstore().ClearAddrs(n2.Host().ID())
+	n2.Host().Peerstore().ClearAddrs(n1.Host().ID())
+	if err := n1.Host().Network().ClosePeer(n2.Host().ID()); err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pbrec, err := cbor.RecordToProto(ctx, n1, rec.Value())
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &pb.PushRecordRequest{
+		Body: &pb.PushRecordRequest_Body{
+			ThreadID: &pb.ProtoThreadID{ID: info.ID},
+			LogID:    &pb.ProtoPeerID{ID: rec.LogID()},
+			Record:   pbrec,
+		},
+		Counter: 1,
+	}
+	pctx := grpcpeer.NewContext(ctx, &grpcpeer.Peer{Addr: &addr{id: n1.Host().ID()}})
+	head := func() int64 {
+		t.Helper()
+		h, err := n2.(*net).currentHead(info.ID, rec.LogID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h.Counter
+	}
+
+	t.Run("rejected", func(t *testing.T) {
+		_, err := n2.(*net).server.PushRecord(pctx, req)
+		if code := status.Code(err); code != codes.PermissionDenied {
+			t.Fatalf("expected rejected record to be denied, got %s", code)
+		}
+		if h := head(); h != 0 {
+			t.Fatalf("expected rejected record not to be stored, got head %d", h)
+		}
+		if ok, err := n2.(*net).bstore.Has(rec.Value().BlockID()); err != nil {
+			t.Fatal(err)
+		} else if ok {
+			t.Fatal("expected blocks of rejected record to be removed")
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		if len(calls) != 2 || calls[0] != "first" || calls[1] != "second" {
+			t.Fatalf("expected interceptors to be called in order until rejecting, got %v", calls)
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		lock.Lock()
+		calls, result = nil, errors.New("timed out")
+		lock.Unlock()
+		_, err := n2.(*net).server.PushRecord(pctx, req)
+		if code := status.Code(err); code != codes.Internal {
+			t.Fatalf("expected record failing to be handled to be retried, got %s", code)
+		}
+		if h := head(); h != 0 {
+			t.Fatalf("expected record failing to be handled not to be stored, got head %d", h)
+		}
+		if ok, err := n2.(*net).bstore.Has(rec.Value().BlockID()); err != nil {
+			t.Fatal(err)
+		} else if !ok {
+			t.Fatal("expected blocks of record failing to be handled to be kept")
+		}
+	})
+
+	t.Run("accepted", func(t *testing.T) {
+		lock.Lock()
+		calls, result = nil, nil
+		lock.Unlock()
+		if _, err := n2.(*net).server.PushRecord(pctx, req); err != nil {
+			t.Fatal(err)
+		}
+		if h := head(); h != 1 {
+			t.Fatalf("expected accepted record to be stored, got head %d", h)
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		if len(calls) != 3 {
+			t.Fatalf("expected all interceptors to be called, got %v", calls)
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package net
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/gogo/status"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+const (
+	metricsNamespace = "threads"
+	metricsSubsystem = "net"
+
+	outcomeSuccess = "success"
+	outcomeFailure = "failure"
+	outcomeSkipped = "skipped"
+
+	sourcePush = "push"
+	sourcePull = "pull"
+)
+
+// metrics instruments the threads protocol. Metrics are labeled by outcome,
+// never by thread or peer, to keep their cardinality bounded.
+type metrics struct {
+	requests        *prometheus.CounterVec
+	recordsCreated  prometheus.Counter
+	recordsPushed   *prometheus.CounterVec
+	recordsReceived *prometheus.CounterVec
+	recordsRead     prometheus.Counter
+	pushDuration    *prometheus.HistogramVec
+	pushesInFlight  prometheus.Gauge
+	pulls           *prometheus.CounterVec
+	pullDuration    *prometheus.HistogramVec
+	subscriptions   prometheus.Gauge
+	queueCalls      []prometheus.Collector
+	outboxRecords   prometheus.GaugeFunc
+}
+
+func newMetrics(queues map[string]func() int, outbox func() int) *metrics {
+	opts := func(name, help string) prometheus.Opts {
+		return prometheus.Opts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      name,
+			Help:      help,
+		}
+	}
+	histogramOpts := func(name, help string) prometheus.HistogramOpts {
+		o := opts(name, help)
+		return prometheus.HistogramOpts{
+			Namespace: o.Namespace,
+			Subsystem: o.Subsystem,
+			Name:      o.Name,
+			Help:      o.Help,
+			Buckets:   prometheus.DefBuckets,
+		}
+	}
+	m := &metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts(
+			opts("requests_total", "Requests of peers handled, by method and status code.")),
+			[]string{"method", "code"}),
+		recordsCreated: prometheus.NewCounter(prometheus.CounterOpts(
+			opts("records_created_total", "Records created by the host."))),
+		recordsPushed: prometheus.NewCounterVec(prometheus.CounterOpts(
+			opts("records_pushed_total", "Records pushed to peers, by outcome.")),
+			[]string{"outcome"}),
+		recordsReceived: prometheus.NewCounterVec(prometheus.CounterOpts(
+			opts("records_received_total", "Records received from peers, by source.")),
+			[]string{"source"}),
+		recordsRead: prometheus.NewCounter(prometheus.CounterOpts(
+			opts("records_read_total", "Records read to reply to get records requests of peers."))),
+		pushDuration: prometheus.NewHistogramVec(
+			histogramOpts("push_duration_seconds", "Duration of pushes of records to peers, by outcome."),
+			[]string{"outcome"}),
+		pushesInFlight: prometheus.NewGauge(prometheus.GaugeOpts(
+			opts("pushes_in_flight", "Pushes of records to peers in flight."))),
+		pulls: prometheus.NewCounterVec(prometheus.CounterOpts(
+			opts("pulls_total", "Get records requests to peers, by outcome.")),
+			[]string{"outcome"}),
+		pullDuration: prometheus.NewHistogramVec(
+			histogramOpts("pull_duration_seconds", "Duration of get records requests to peers, by outcome."),
+			[]string{"outcome"}),
+		subscriptions: prometheus.NewGauge(prometheus.GaugeOpts(
+			opts("subscriptions", "Active subscriptions to records."))),
+		outboxRecords: prometheus.NewGaugeFunc(prometheus.GaugeOpts(
+			opts("outbox_records", "Records queued for unreachable peers.")),
+			func() float64 { return float64(outbox()) }),
+	}
+	for name, size := range queues {
+		o := opts("queue_calls", "Calls to peers scheduled, by queue.")
+		o.ConstLabels = prometheus.Labels{"queue": name}
+		m.queueCalls = append(m.queueCalls, prometheus.NewGaugeFunc(prometheus.GaugeOpts(o), func(size func() int) func() float64 {
+			return func() float64 { return float64(size()) }
+		}(size)))
+	}
+	return m
+}
+
+// register registers the metrics with the registerer.
+func (m *metrics) register(r prometheus.Registerer) error {
+	collectors := []prometheus.Collector{
+		m.requests,
+		m.recordsCreated,
+		m.recordsPushed,
+		m.recordsReceived,
+		m.recordsRead,
+		m.pushDuration,
+		m.pushesInFlight,
+		m.pulls,
+		m.pullDuration,
+		m.subscriptions,
+		m.outboxRecords,
+	}
+	for _, c := range append(collectors, m.queueCalls...) {
+		if err := r.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func outcome(err error) string {
+	if err != nil {
+		return outcomeFailure
+	}
+	return outcomeSuccess
+}
+
+// pushed records the push of a record started at start.
+func (m *metrics) pushed(start time.Time, err error) {
+	o := outcome(err)
+	m.recordsPushed.WithLabelValues(o).Inc()
+	m.pushDuration.WithLabelValues(o).Observe(time.Since(start).Seconds())
+}
+
+// pulled records the get records request started at start, which got the
+// number of records.
+func (m *metrics) pulled(start time.Time, records int, err error) {
+	o := outcome(err)
+	m.pulls.WithLabelValues(o).Inc()
+	m.pullDuration.WithLabelValues(o).Observe(time.Since(start).Seconds())
+	m.recordsReceived.WithLabelValues(sourcePull).Add(float64(records))
+}
+
+// unaryServerInterceptor counts the requests of peers by method and code.
+func (m *metrics) unaryServerInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	m.requests.WithLabelValues(path.Base(info.FullMethod), status.Code(err).String()).Inc()
+	return resp, err
+}
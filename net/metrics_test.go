@@ -0,0 +1,75 @@
+package net
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestNet_Metrics(t *testing.T) {
+	r1, r2 := prometheus.NewRegistry(), prometheus.NewRegistry()
+	n1 := makeNetwork(t, func(c *Config) { c.Metrics = r1 })
+	defer n1.Close()
+	n2 := makeNetwork(t, func(c *Config) { c.Metrics = r2 })
+	defer n2.Close()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if _, err := n1.Subscribe(ctx); err != nil {
+		t.Fatal(err)
+	}
+	info := createThread(t, ctx, n1)
+	raddr, err := ma.NewMultiaddr("/p2p/" + n2.Host().ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n1.AddReplicator(ctx, info.ID, raddr); err != nil {
+		t.Fatal(err)
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n1.CreateRecord(ctx, info.ID, body); err != nil {
+		t.Fatal(err)
+	}
+
+	m1, m2 := n1.(*net).metrics, n2.(*net).metrics
+	for i := 0; testutil.ToFloat64(m1.recordsPushed.WithLabelValues(outcomeSuccess)) == 0; i++ {
+		if i == 50 {
+			t.Fatal("expected pushed record to be counted")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if v := testutil.ToFloat64(m1.recordsCreated); v != 1 {
+		t.Fatalf("expected 1 created record, got %v", v)
+	}
+	if v := testutil.ToFloat64(m1.subscriptions); v != 1 {
+		t.Fatalf("expected 1 subscription, got %v", v)
+	}
+	if v := testutil.ToFloat64(m2.recordsReceived.WithLabelValues(sourcePush)); v != 1 {
+		t.Fatalf("expected 1 record received, got %v", v)
+	}
+	if v := testutil.ToFloat64(m2.requests.WithLabelValues("PushRecord", "OK")); v != 1 {
+		t.Fatalf("expected 1 push record request handled, got %v", v)
+	}
+	if n, err := testutil.GatherAndCount(r1, "threads_net_queue_calls"); err != nil || n != 2 {
+		t.Fatalf("expected depths of 2 queues, got %d (%v)", n, err)
+	}
+
+	cancel()
+	for i := 0; testutil.ToFloat64(m1.subscriptions) != 0; i++ {
+		if i == 50 {
+			t.Fatal("expected canceled subscription to be uncounted")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
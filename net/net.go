@@ -8,7 +8,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"strings"
 	"sync"
 	"time"
 
@@ -18,10 +17,12 @@ import (
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	pstore "github.com/libp2p/go-libp2p-core/peerstore"
 	gostream "github.com/libp2p/go-libp2p-gostream"
 	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prometheus/client_golang/prometheus"
 	sym "github.com/textileio/crypto/symmetric"
 	"github.com/textileio/go-threads/broadcast"
 	"github.com/textileio/go-threads/cbor"
@@ -51,6 +52,17 @@ var (
 	// EventBusCapacity is the buffer size of local event bus listeners.
 	EventBusCapacity = 1
 
+	// MaxRecordsPageSize is the size of the records of a reply to a paged get
+	// records request, split among the logs of the thread, past which records
+	// are left for the next pages.
+	MaxRecordsPageSize = 2 << 20
+
+	// MaxLegacyRecordsReplySize is the size of the records of a reply to a
+	// get records request of a peer which doesn't page, which gets the last
+	// records of the logs which fit. It's below the default message size
+	// limit of gRPC clients.
+	MaxLegacyRecordsReplySize = 3 << 20
+
 	// notifyTimeout is the duration to wait for a subscriber to read a new record.
 	notifyTimeout = time.Second * 5
 
@@ -59,6 +71,14 @@ var (
 
 	// tokenChallengeTimeout is the duration of time given to an identity to complete a token challenge.
 	tokenChallengeTimeout = time.Minute
+
+	// rateLimitPruneInterval is the interval at which the rate limits of
+	// the peers which stopped making requests are dropped.
+	rateLimitPruneInterval = time.Minute
+
+	// lastUpdatedKey is the key of the metadata of a thread holding the
+	// unix time in nanoseconds a record was last added to it.
+	lastUpdatedKey = "updated"
 )
 
 const (
@@ -68,6 +88,8 @@ const (
 
 var (
 	_ util.SemaphoreKey = (*semaThreadUpdate)(nil)
+	_ util.SemaphoreKey = (*semaLogUpdate)(nil)
+	_ util.SemaphoreKey = (*semaLogCreate)(nil)
 )
 
 // semaphore protecting thread info updates
@@ -77,6 +99,28 @@ func (t semaThreadUpdate) Key() string {
 	return "tu:" + string(t)
 }
 
+// semaphore protecting the head of a log, which is held briefly to move the
+// head and never while acquiring other locks, so that records of different
+// logs and threads are created in parallel
+type semaLogUpdate struct {
+	id  thread.ID
+	lid peer.ID
+}
+
+func (t semaLogUpdate) Key() string {
+	return "lu:" + string(t.id) + "/" + string(t.lid)
+}
+
+// semaphore protecting the creation of the log of an identity
+type semaLogCreate struct {
+	id       thread.ID
+	identity string
+}
+
+func (t semaLogCreate) Key() string {
+	return "lc:" + string(t.id) + "/" + t.identity
+}
+
 // net is an implementation of app.Net.
 type net struct {
 	conf Config
@@ -94,9 +138,27 @@ type net struct {
 	connectors map[thread.ID]*app.Connector
 	connLock   sync.RWMutex
 
+	interceptors  []RecordInterceptor
+	interceptLock sync.RWMutex
+
+	gossiping  map[peer.ID]struct{}
+	gossipLock sync.Mutex
+
 	semaphores      *util.SemaphorePool
 	queueGetLogs    queue.CallQueue
 	queueGetRecords queue.CallQueue
+	limiter         *rateLimiter
+	backoff         *dialBackoff
+	protector       *peerProtector
+	outbox          *outbox
+	pulls           *pullSchedule
+	notifiee        network.Notifiee
+	gossipNotifiee  network.Notifiee
+	addrsNotifiee   network.Notifiee
+	addrs           *logAddrs
+	chains          *recordChains
+	peerStats       *peerStats
+	metrics         *metrics
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -111,7 +173,66 @@ type Config struct {
 	NoNetPulling              bool
 	NoExchangeEdgesMigration  bool
 	PubSub                    bool
-	Debug                     bool
+	// NetPullingMaxInterval is the ceiling of the intervals of the threads
+	// which pulls repeatedly get no new records, which back off from their
+	// interval up to it. A value below their interval doesn't back off.
+	NetPullingMaxInterval time.Duration
+	// PubSubStrategy is the pubsub strategy of threads without one of
+	// their own, which defaults to core.PubSubPerThread.
+	PubSubStrategy core.PubSubStrategy
+	// PubSubShards is the number of topics of the sharded strategy, which
+	// defaults to DefaultPubSubShards. Peers must agree on it to receive
+	// each other's records.
+	PubSubShards int
+	// RateLimits are the limits of the inbound requests of each peer,
+	// which can be adjusted with SetRateLimits. The zero value doesn't
+	// limit requests.
+	RateLimits RateLimits
+	// DialBackoff is the backoff of the addresses of peers which failed to
+	// be dialed. The zero value doesn't back off.
+	DialBackoff DialBackoff
+	// DeleteAnnouncedThreads deletes the threads whose deletion is
+	// announced by the owner of one of their logs, unless an app is
+	// connected to them. Otherwise the deletions are only reported by
+	// ThreadDeletions.
+	DeleteAnnouncedThreads bool
+	// MaxProtectedPeers is the number of the peers of the hosted threads
+	// protected from the pruning of the connection manager, those sharing
+	// the most threads first, which defaults to DefaultMaxProtectedPeers.
+	// A negative value protects none, which are still tagged.
+	MaxProtectedPeers int
+	// MaxOutboxSize is the number of the records queued for a peer which
+	// is unreachable, which defaults to DefaultMaxOutboxSize. A negative
+	// value doesn't queue records.
+	MaxOutboxSize int
+	// OutboxOverflow is the policy of the records which fail to be pushed
+	// to a peer for which MaxOutboxSize records are queued.
+	OutboxOverflow OutboxOverflow
+	// HeadGossipInterval is the interval the edges of the heads of the
+	// threads shared with the connected peers are exchanged at, as they're
+	// once the peers connect, which is disabled if it's zero.
+	HeadGossipInterval time.Duration
+	// MaxPeerStats is the number of the peers which sync statistics are
+	// kept, those last updated, which defaults to DefaultMaxPeerStats. A
+	// negative value keeps none.
+	MaxPeerStats int
+	// PersistPeerStats persists the sync statistics of the peers in their
+	// metadata in the peerstore of the host, once they're no longer kept
+	// and when the network is closed.
+	PersistPeerStats bool
+	// MaxRecordSize is the size in bytes of the largest record body which
+	// is created, which defaults to DefaultMaxRecordSize. The records of
+	// larger bodies received from peers are rejected.
+	MaxRecordSize int
+	// ConfirmedAddrTTL is the ttl of the addresses peers are connected at,
+	// which are written to the logs which have their addresses, which
+	// defaults to DefaultConfirmedAddrTTL. A negative value doesn't write
+	// them, and keeps the log addresses received from peers permanently.
+	ConfirmedAddrTTL time.Duration
+	// Metrics registers the metrics of the threads protocol, which are
+	// collected but not registered if it's nil.
+	Metrics prometheus.Registerer
+	Debug   bool
 }
 
 func (c Config) Validate() error {
@@ -127,6 +248,27 @@ func (c Config) Validate() error {
 	if c.NetPullingInterval <= 0 {
 		return errors.New("NetPullingInterval must be greater than zero")
 	}
+	if c.HeadGossipInterval < 0 {
+		return errors.New("HeadGossipInterval must not be negative")
+	}
+	if !c.PubSubStrategy.Valid() {
+		return fmt.Errorf("unknown PubSubStrategy %q", c.PubSubStrategy)
+	}
+	if c.PubSubShards < 0 {
+		return errors.New("PubSubShards must not be negative")
+	}
+	if c.MaxRecordSize < 0 {
+		return errors.New("MaxRecordSize must not be negative")
+	}
+	if !c.OutboxOverflow.Valid() {
+		return fmt.Errorf("unknown OutboxOverflow %d", c.OutboxOverflow)
+	}
+	if err := c.RateLimits.Validate(); err != nil {
+		return fmt.Errorf("RateLimits: %v", err)
+	}
+	if err := c.DialBackoff.Validate(); err != nil {
+		return fmt.Errorf("DialBackoff: %v", err)
+	}
 	return nil
 }
 
@@ -159,7 +301,6 @@ func NewNetwork(
 		host:            h,
 		bstore:          bstore,
 		store:           ls,
-		rpc:             grpc.NewServer(serverOptions...),
 		bus:             broadcast.NewBroadcaster(EventBusCapacity),
 		connectors:      make(map[thread.ID]*app.Connector),
 		ctx:             ctx,
@@ -167,12 +308,47 @@ func NewNetwork(
 		semaphores:      util.NewSemaphorePool(1),
 		queueGetLogs:    queue.NewFFQueue(ctx, QueuePollInterval, conf.NetPullingInterval),
 		queueGetRecords: queue.NewFFQueue(ctx, QueuePollInterval, conf.NetPullingInterval),
+		limiter:         newRateLimiter(conf.RateLimits),
+		backoff:         newDialBackoff(conf.DialBackoff),
+		protector:       newPeerProtector(h.ConnManager(), conf.MaxProtectedPeers),
+		outbox:          newOutbox(ls, conf.MaxOutboxSize, conf.OutboxOverflow),
+		pulls:           newPullSchedule(),
+		gossiping:       make(map[peer.ID]struct{}),
+		addrs:           newLogAddrs(),
+		chains:          newRecordChains(),
+	}
+	var peerMeta pstore.PeerMetadata
+	if conf.PersistPeerStats {
+		peerMeta = h.Peerstore()
+	}
+	var err error
+	if n.peerStats, err = newPeerStats(conf.MaxPeerStats, peerMeta); err != nil {
+		return nil, fmt.Errorf("creating peer stats: %v", err)
+	}
+	n.peerStats.load(h.Peerstore().Peers())
+	// apps connected to threads validate the records received first
+	n.RegisterRecordInterceptor(n.validateConnectedRecord)
+	n.metrics = newMetrics(map[string]func() int{
+		"get_logs":    n.queueGetLogs.Size,
+		"get_records": n.queueGetRecords.Size,
+	}, n.outbox.size)
+	if conf.Metrics != nil {
+		if err := n.metrics.register(conf.Metrics); err != nil {
+			return nil, fmt.Errorf("registering metrics: %v", err)
+		}
 	}
+	serverOptions = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(n.metrics.unaryServerInterceptor),
+	}, serverOptions...)
+	n.rpc = grpc.NewServer(serverOptions...)
 
-	err := n.migrateHeadsIfNeeded(ctx, ls)
+	err = n.migrateHeadsIfNeeded(ctx, ls)
 	if err != nil {
 		return nil, err
 	}
+	if err = n.outbox.load(); err != nil {
+		return nil, fmt.Errorf("loading outbox: %v", err)
+	}
 
 	n.server, err = newServer(n, dialOptions...)
 	if err != nil {
@@ -190,7 +366,31 @@ func NewNetwork(
 		}
 	}()
 
+	// Push the records queued for peers once they connect
+	n.notifiee = n.notifyOutbox()
+	h.Network().Notify(n.notifiee)
+	for _, p := range n.outbox.peers() {
+		if h.Network().Connectedness(p) == network.Connected {
+			go n.flushOutbox(p)
+		}
+	}
+
+	// Gossip the heads of the shared threads once the peers connect
+	if conf.HeadGossipInterval > 0 {
+		n.gossipNotifiee = n.notifyHeadGossip()
+		h.Network().Notify(n.gossipNotifiee)
+	}
+
+	// Write the addresses the peers connect at to their logs
+	if n.confirmedAddrTTL() > 0 {
+		n.addrsNotifiee = n.notifyConfirmedAddrs()
+		h.Network().Notify(n.addrsNotifiee)
+	}
+
+	go n.protectPeers()
 	go n.startPulling()
+	go n.startHeadGossip()
+	go n.pruneLoop(rateLimitPruneInterval)
 	return n, nil
 }
 
@@ -273,6 +473,15 @@ func (n *net) migrateHeadsIfNeeded(ctx context.Context, ls lstore.Logstore) (err
 }
 
 func (n *net) Close() (err error) {
+	n.host.Network().StopNotify(n.notifiee)
+	if n.gossipNotifiee != nil {
+		n.host.Network().StopNotify(n.gossipNotifiee)
+	}
+	if n.addrsNotifiee != nil {
+		n.host.Network().StopNotify(n.addrsNotifiee)
+	}
+	n.peerStats.close()
+
 	// Wait for all thread pulls to finish
 	n.semaphores.Stop()
 
@@ -350,6 +559,10 @@ func (n *net) CreateThread(
 	for _, opt := range opts {
 		opt(args)
 	}
+	if !args.PubSub.Valid() {
+		err = fmt.Errorf("unknown pubsub strategy %q", args.PubSub)
+		return
+	}
 	// @todo: Check identity key against ACL.
 	identity, err := n.Validate(id, args.Token, false)
 	if err != nil {
@@ -378,9 +591,13 @@ func (n *net) CreateThread(
 	if _, err = n.createLog(id, args.LogKey, identity); err != nil {
 		return
 	}
+	if err = n.setPubSubStrategy(id, args.PubSub); err != nil {
+		return
+	}
 	if err = n.server.addPubsubTopic(id); err != nil {
 		return
 	}
+	n.protectThreadPeers(id)
 
 	return n.getThreadWithAddrs(id)
 }
@@ -395,6 +612,10 @@ func (n *net) AddThread(
 		opt(args)
 	}
 
+	if !args.PubSub.Valid() {
+		err = fmt.Errorf("unknown pubsub strategy %q", args.PubSub)
+		return
+	}
 	id, err := thread.FromAddr(addr)
 	if err != nil {
 		return
@@ -445,6 +666,9 @@ func (n *net) AddThread(
 			return
 		}
 	}
+	if err = n.setPubSubStrategy(id, args.PubSub); err != nil {
+		return
+	}
 
 	// Skip if trying to dial ourselves (already have the logs)
 	if !addFromSelf {
@@ -475,6 +699,81 @@ func (n *net) GetThread(_ context.Context, id thread.ID, opts ...core.ThreadOpti
 	return n.getThreadWithAddrs(id)
 }
 
+func (n *net) GetThreadSummary(_ context.Context, id thread.ID, opts ...core.ThreadOption) (summary core.ThreadSummary, err error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err = n.Validate(id, args.Token, true); err != nil {
+		return
+	}
+	return n.getThreadSummary(id)
+}
+
+// getThreadSummary summarizes the thread from its keys, the ids of its logs
+// and their heads, without the keys and addresses of the logs.
+func (n *net) getThreadSummary(id thread.ID) (summary core.ThreadSummary, err error) {
+	sk, err := n.store.ServiceKey(id)
+	if err != nil {
+		return
+	}
+	if sk == nil {
+		return summary, lstore.ErrThreadNotFound
+	}
+	rk, err := n.store.ReadKey(id)
+	if err != nil {
+		return
+	}
+	withKeys, err := n.store.LogsWithKeys(id)
+	if err != nil {
+		return
+	}
+	withAddrs, err := n.store.LogsWithAddrs(id)
+	if err != nil {
+		return
+	}
+	lids := make(map[peer.ID]struct{}, len(withKeys))
+	for _, lid := range append(withKeys, withAddrs...) {
+		lids[lid] = struct{}{}
+	}
+	for lid := range lids {
+		heads, err := n.store.Heads(id, lid)
+		if err != nil {
+			return summary, err
+		}
+		var counter int64
+		for _, h := range heads {
+			if h.Counter > counter {
+				counter = h.Counter
+			}
+		}
+		summary.RecordCount += counter
+	}
+	updated, err := n.store.GetInt64(id, lastUpdatedKey)
+	if err != nil {
+		return
+	}
+	if updated != nil {
+		summary.LastUpdated = time.Unix(0, *updated)
+	}
+	if summary.PullInterval, summary.PullPaused, err = n.pullSettings(id); err != nil {
+		return
+	}
+	summary.ID = id
+	summary.HasServiceKey = true
+	summary.HasReadKey = rk != nil
+	summary.LogCount = len(lids)
+	return summary, nil
+}
+
+// setLastUpdated keeps the time in the metadata of the thread as the one a
+// record was last added to it.
+func (n *net) setLastUpdated(id thread.ID) {
+	if err := n.store.PutInt64(id, lastUpdatedKey, time.Now().UnixNano()); err != nil {
+		log.Errorf("setting last updated time of thread %s: %v", id, err)
+	}
+}
+
 func (n *net) getThreadWithAddrs(id thread.ID) (info thread.Info, err error) {
 	var tinfo thread.Info
 	var peerID *ma.Component
@@ -508,31 +807,66 @@ func (n *net) PullThread(ctx context.Context, id thread.ID, opts ...core.ThreadO
 	if _, err := n.Validate(id, args.Token, true); err != nil {
 		return err
 	}
-	return n.pullThread(ctx, id)
+	return n.pullThread(ctx, id, args.Fetched)
 }
 
-// pullThread for the new records. This method is thread-safe.
-func (n *net) pullThread(ctx context.Context, tid thread.ID) error {
-	offsets, peers, err := n.threadOffsets(tid)
-	if err != nil {
-		return err
-	}
-
-	// Pull from peers
-	recs, err := n.server.getRecords(peers, tid, offsets, n.conf.NetPullingLimit)
-	if err != nil {
-		return err
-	}
+// pullThread for the new records, calling fetched with their number if it's
+// set. Pages of records are pulled until the peers have no more, calling
+// fetched for each. This method is thread-safe.
+func (n *net) pullThread(ctx context.Context, tid thread.ID, fetched func(records int)) error {
+	var prev map[peer.ID]thread.Head
+	for {
+		offsets, peers, err := n.threadOffsets(tid)
+		if err != nil {
+			return err
+		}
+		if prev != nil && sameOffsets(prev, offsets) {
+			// the last page didn't move the heads
+			break
+		}
+		prev = offsets
 
-	for lid, rs := range recs {
-		if err = n.putRecords(ctx, tid, lid, rs.records, rs.counter); err != nil {
+		// Pull from peers
+		recs, err := n.server.getRecords(peers, tid, offsets, n.conf.NetPullingLimit)
+		if err != nil {
 			return err
 		}
-	}
 
+		if fetched != nil {
+			var total int
+			for _, rs := range recs {
+				total += len(rs.records)
+			}
+			fetched(total)
+		}
+
+		var more bool
+		for lid, rs := range recs {
+			if err = n.putRecords(ctx, tid, lid, rs.records, rs.counter); err != nil {
+				return err
+			}
+			more = more || rs.more
+		}
+		if !more {
+			break
+		}
+	}
 	return nil
 }
 
+// sameOffsets tells whether the offsets of the logs are equal.
+func sameOffsets(a, b map[peer.ID]thread.Head) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for lid, h := range a {
+		if o, ok := b[lid]; !ok || o.Counter != h.Counter || !o.ID.Equals(h.ID) {
+			return false
+		}
+	}
+	return true
+}
+
 func (n *net) DeleteThread(ctx context.Context, id thread.ID, opts ...core.ThreadOption) error {
 	args := &core.ThreadOptions{}
 	for _, opt := range opts {
@@ -545,6 +879,12 @@ func (n *net) DeleteThread(ctx context.Context, id thread.ID, opts ...core.Threa
 		return fmt.Errorf("cannot delete thread: %w", app.ErrThreadInUse)
 	}
 
+	if args.AnnounceDeletion {
+		if err := n.announceDeletion(id); err != nil {
+			return fmt.Errorf("announcing deletion: %w", err)
+		}
+	}
+
 	log.Debugf("deleting thread %s...", id)
 	ts := n.semaphores.Get(semaThreadUpdate(id))
 
@@ -581,7 +921,13 @@ func (n *net) deleteThread(ctx context.Context, id thread.ID) error {
 		}
 	}
 
-	return n.store.DeleteThread(id) // Delete logstore keys, addresses, heads, and metadata
+	// Delete logstore keys, addresses, heads, and metadata
+	if err := n.store.DeleteThread(id); err != nil {
+		return err
+	}
+	n.outbox.dropThread(id)
+	n.protectThreadPeers(id)
+	return nil
 }
 
 func (n *net) AddReplicator(
@@ -604,17 +950,19 @@ func (n *net) AddReplicator(
 	}
 
 	// Extract peer portion
-	p2p, err := paddr.ValueForProtocol(ma.P_P2P)
+	transport, pid, err := splitPeerAddr(paddr)
 	if err != nil {
 		return
 	}
-	pid, err = peer.Decode(p2p)
-	if err != nil {
+	if !args.ReplicatorExpiry.IsZero() && !args.ReplicatorExpiry.After(time.Now()) {
+		return pid, errors.New("replicator expiry must be in the future")
+	}
+	if err = n.setReplicatorExpiry(info.ID, pid, args.ReplicatorExpiry); err != nil {
 		return
 	}
 
 	// Update local addresses
-	addr, err := ma.NewMultiaddr("/" + ma.ProtocolWithCode(ma.P_P2P).Name + "/" + p2p)
+	addr, err := ma.NewMultiaddr("/" + ma.ProtocolWithCode(ma.P_P2P).Name + "/" + pid.String())
 	if err != nil {
 		return
 	}
@@ -631,14 +979,13 @@ func (n *net) AddReplicator(
 	if err != nil {
 		return
 	}
+	n.protectThreadPeers(info.ID)
 
 	// Check if we're dialing ourselves (regardless of addr)
 	if pid != n.host.ID() {
-		// If not, update peerstore address
-		var dialable ma.Multiaddr
-		dialable, err = getDialable(paddr)
-		if err == nil {
-			n.host.Peerstore().AddAddr(pid, dialable, pstore.PermanentAddrTTL)
+		// If not, update peerstore address, which may be relayed
+		if transport != nil {
+			n.host.Peerstore().AddAddr(pid, transport, pstore.PermanentAddrTTL)
 		}
 
 		// Send all logs to the new replicator
@@ -706,12 +1053,7 @@ func (n *net) uniquePeers(addrs []ma.Multiaddr) ([]peer.ID, error) {
 
 // callablePeer attempts to obtain external peer ID from the multiaddress.
 func (n *net) callablePeer(addr ma.Multiaddr) (peer.ID, bool, error) {
-	p, err := addr.ValueForProtocol(ma.P_P2P)
-	if err != nil {
-		return "", false, err
-	}
-
-	pid, err := peer.Decode(p)
+	_, pid, err := splitPeerAddr(addr)
 	if err != nil {
 		return "", false, err
 	}
@@ -724,8 +1066,29 @@ func (n *net) callablePeer(addr ma.Multiaddr) (peer.ID, bool, error) {
 }
 
 func getDialable(addr ma.Multiaddr) (ma.Multiaddr, error) {
-	parts := strings.Split(addr.String(), "/"+ma.ProtocolWithCode(ma.P_P2P).Name)
-	return ma.NewMultiaddr(parts[0])
+	transport, _, err := splitPeerAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+	if transport == nil {
+		return nil, fmt.Errorf("address %s is not dialable", addr)
+	}
+	return transport, nil
+}
+
+// splitPeerAddr splits the address of a peer, which may be followed by a
+// thread, into its transport part and the peer. The peer is the last one of
+// the address, which is relayed by the others it has, if any. The transport
+// part is nil if the address has none.
+func splitPeerAddr(addr ma.Multiaddr) (ma.Multiaddr, peer.ID, error) {
+	addr, _ = ma.SplitFunc(addr, func(c ma.Component) bool {
+		return c.Protocol().Code == thread.Code
+	})
+	transport, pid := peer.SplitAddr(addr)
+	if pid == "" {
+		return nil, "", ma.ErrProtocolNotFound
+	}
+	return transport, pid, nil
 }
 
 func (n *net) CreateRecord(
@@ -742,6 +1105,9 @@ func (n *net) CreateRecord(
 	if err != nil {
 		return
 	}
+	if err = n.checkBodySize(body); err != nil {
+		return
+	}
 	if identity == nil {
 		identity = thread.NewLibp2pPubKey(n.getPrivKey().GetPublic())
 	}
@@ -758,23 +1124,18 @@ func (n *net) CreateRecord(
 	if err != nil {
 		return
 	}
-	r, err := n.newRecord(ctx, id, lg, body, identity)
+	r, head, err := n.createRecord(ctx, id, lg, body, identity)
 	if err != nil {
 		return
 	}
 	tr = NewRecord(r, id, lg.ID)
-	head := thread.Head{
-		ID:      tr.Value().Cid(),
-		Counter: lg.Head.Counter + 1,
-	}
-	if err = n.store.SetHead(id, lg.ID, head); err != nil {
-		return
-	}
+	n.setLastUpdated(id)
+	n.metrics.recordsCreated.Inc()
 	log.Debugf("created record %s (thread=%s, log=%s)", tr.Value().Cid(), id, lg.ID)
 	if err = n.bus.SendWithTimeout(tr, notifyTimeout); err != nil {
 		return
 	}
-	if err = n.server.pushRecord(ctx, id, lg.ID, tr.Value(), lg.Head.Counter+1); err != nil {
+	if err = n.server.pushRecord(ctx, id, lg.ID, tr.Value(), head.Counter); err != nil {
 		return
 	}
 	return tr, nil
@@ -812,6 +1173,13 @@ func (n *net) AddRecord(
 	if err = rec.Verify(logpk); err != nil {
 		return err
 	}
+	prec, err := cbor.RecordToProto(ctx, n, rec)
+	if err != nil {
+		return err
+	}
+	if err = n.checkRecordSize(prec); err != nil {
+		return err
+	}
 	if err = n.putRecords(ctx, id, lid, []core.Record{rec}, thread.CounterUndef); err != nil {
 		return err
 	}
@@ -887,14 +1255,28 @@ func (n *net) Subscribe(ctx context.Context, opts ...core.SubOption) (<-chan cor
 			filter[id] = struct{}{}
 		}
 	}
-	return n.subscribe(ctx, filter)
+	logs := make(map[peer.ID]struct{})
+	for _, id := range args.LogIDs {
+		if err := id.Validate(); err != nil {
+			return nil, err
+		}
+		logs[id] = struct{}{}
+	}
+	return n.subscribe(ctx, filter, logs, args.BufferSize, args.Dropped)
 }
 
-func (n *net) subscribe(ctx context.Context, filter map[thread.ID]struct{}) (<-chan core.ThreadRecord, error) {
+// subscribe returns a channel of the records of the threads and logs of the
+// filters, or of all of them if a filter is empty. If bufferSize is positive,
+// it buffers up to bufferSize records and then drops the oldest, otherwise
+// the records wait for it to be read.
+func (n *net) subscribe(ctx context.Context, filter map[thread.ID]struct{}, logs map[peer.ID]struct{}, bufferSize int, dropped func(int)) (<-chan core.ThreadRecord, error) {
 	channel := make(chan core.ThreadRecord)
+	// records are received once it returns
+	listener := n.bus.Listen()
+	n.metrics.subscriptions.Inc()
 	go func() {
+		defer n.metrics.subscriptions.Dec()
 		defer close(channel)
-		listener := n.bus.Listen()
 		defer listener.Discard()
 		for {
 			select {
@@ -905,20 +1287,27 @@ func (n *net) subscribe(ctx context.Context, filter map[thread.ID]struct{}) (<-c
 					return
 				}
 				if rec, ok := i.(*Record); ok {
-					if len(filter) > 0 {
-						if _, ok := filter[rec.threadID]; ok {
-							channel <- rec
-						}
-					} else {
-						channel <- rec
+					if _, ok := filter[rec.threadID]; !ok && len(filter) > 0 {
+						continue
 					}
-				} else {
+					if _, ok := logs[rec.logID]; !ok && len(logs) > 0 {
+						continue
+					}
+					select {
+					case channel <- rec:
+					case <-ctx.Done():
+						return
+					}
+				} else if _, ok := i.(*ThreadDeletion); !ok {
 					log.Warn("listener received a non-record value")
 				}
 			}
 		}
 	}()
-	return channel, nil
+	if bufferSize <= 0 {
+		return channel, nil
+	}
+	return util.BufferRecords(ctx, channel, bufferSize, dropped), nil
 }
 
 func (n *net) ConnectApp(a app.App, id thread.ID) (*app.Connector, error) {
@@ -1015,78 +1404,40 @@ func (n *net) putRecords(ctx context.Context, tid thread.ID, lid peer.ID, recs [
 
 	var (
 		connector, appConnected = n.getConnector(tid)
-		identity                = &thread.Libp2pPubKey{}
-		readKey                 *sym.Key
-		validate                bool
 		// setting new counters for heads
 		updatedCounter = head.Counter
+		prev           = head.ID
 	)
-
-	if appConnected {
-		var err error
-		if readKey, err = n.store.ReadKey(tid); err != nil {
-			return err
-		} else if readKey != nil {
-			validate = true
+	defer func() {
+		if updatedCounter != head.Counter {
+			n.setLastUpdated(tid)
 		}
-	}
+	}()
 
 	for _, record := range chain {
-		if validate {
-			block, err := record.Value().GetBlock(ctx, n)
-			if err != nil {
-				return err
-			}
-
-			event, ok := block.(*cbor.Event)
-			if !ok {
-				event, err = cbor.EventFromNode(block)
-				if err != nil {
-					return fmt.Errorf("invalid event: %w", err)
-				}
-			}
-
-			dbody, err := event.GetBody(ctx, n, readKey)
-			if err != nil {
-				return err
-			}
-
-			if err = identity.UnmarshalBinary(record.Value().PubKey()); err != nil {
-				return err
-			}
-
-			if err = connector.ValidateNetRecordBody(ctx, dbody, identity); err != nil {
-				userErr := err
-
-				// remove stored internal blocks
-				header, err := event.GetHeader(ctx, n, nil)
-				if err != nil {
-					return err
-				}
-
-				body, err := event.GetBody(ctx, n, nil)
-				if err != nil {
-					return err
-				}
-
-				if err := n.RemoveMany(ctx, []cid.Cid{event.Cid(), header.Cid(), body.Cid()}); err != nil {
-					return fmt.Errorf("removing invalid blocks: %w", err)
-				}
-
-				return userErr
-			}
+		// records are handled until ctx is canceled, leaving the head at
+		// the last one handled, so pulling later resumes after it
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := n.interceptRecord(ctx, tid, lid, record.Value()); err != nil {
+			return err
 		}
 
-		updatedCounter++
-		if err := n.store.SetHead(
-			tid,
-			lid,
-			thread.Head{
-				ID:      record.Value().Cid(),
-				Counter: updatedCounter,
-			}); err != nil {
+		next := thread.Head{
+			ID:      record.Value().Cid(),
+			Counter: updatedCounter + 1,
+		}
+		if advanced, err := n.advanceHead(tid, lid, prev, next); err != nil {
 			return fmt.Errorf("setting log head failed: %w", err)
+		} else if !advanced {
+			// the head moved meanwhile, after which the records
+			// are pulled again
+			log.Debugf("head of log %s (thread %s) moved, skip putting records", lid, tid)
+			return nil
 		}
+		prev = next.ID
+		updatedCounter++
 
 		if appConnected {
 			if err := connector.HandleNetRecord(ctx, record); err != nil {
@@ -1240,6 +1591,57 @@ func (n *net) currentHead(tid thread.ID, lid peer.ID) (thread.Head, error) {
 	return head, nil
 }
 
+// createRecord creates a new record of the log following its head, and moves
+// the head to it, which it returns. It holds the semaphore of the log, so
+// that records created concurrently in the log follow each other.
+func (n *net) createRecord(
+	ctx context.Context,
+	id thread.ID,
+	lg thread.LogInfo,
+	body format.Node,
+	pk thread.PubKey,
+) (core.Record, thread.Head, error) {
+	ls := n.semaphores.Get(semaLogUpdate{id: id, lid: lg.ID})
+	ls.Acquire()
+	defer ls.Release()
+
+	// the head may have moved since the log was read
+	var err error
+	if lg.Head, err = n.currentHead(id, lg.ID); err != nil {
+		return nil, thread.HeadUndef, err
+	}
+	r, err := n.newRecord(ctx, id, lg, body, pk)
+	if err != nil {
+		return nil, thread.HeadUndef, err
+	}
+	head := thread.Head{
+		ID:      r.Cid(),
+		Counter: lg.Head.Counter + 1,
+	}
+	if err = n.store.SetHead(id, lg.ID, head); err != nil {
+		return nil, thread.HeadUndef, err
+	}
+	return r, head, nil
+}
+
+// advanceHead moves the head of the log from prev to head, unless it moved
+// from prev since it was read, which it reports. It holds the semaphore of
+// the log, like createRecord, so that the head doesn't fork or regress.
+func (n *net) advanceHead(id thread.ID, lid peer.ID, prev cid.Cid, head thread.Head) (bool, error) {
+	ls := n.semaphores.Get(semaLogUpdate{id: id, lid: lid})
+	ls.Acquire()
+	defer ls.Release()
+
+	current, err := n.currentHead(id, lid)
+	if err != nil {
+		return false, err
+	}
+	if !current.ID.Equals(prev) {
+		return false, nil
+	}
+	return true, n.store.SetHead(id, lid, head)
+}
+
 // newRecord creates a new record with the given body as a new event body.
 func (n *net) newRecord(
 	ctx context.Context,
@@ -1283,10 +1685,12 @@ func (n *net) getPrivKey() crypto.PrivKey {
 	return n.host.Peerstore().PrivKey(n.host.ID())
 }
 
-// getLocalRecords returns local records from the given thread that are ahead of
-// offset but not farther than limit.
-// It is possible to reach limit before offset, meaning that the caller
-// will be responsible for the remaining traversal.
+// getLocalRecords returns up to limit records of the log following offset.
+// Unless paged, they're the last records of the log, as peers which don't
+// page expect. Otherwise they're the first records following offset, and more
+// tells whether others follow them. A page ends early once fit, if not nil,
+// returns false for a record, which is left out, so the records past it
+// aren't read.
 func (n *net) getLocalRecords(
 	ctx context.Context,
 	id thread.ID,
@@ -1294,52 +1698,83 @@ func (n *net) getLocalRecords(
 	offset cid.Cid,
 	limit int,
 	counter int64,
-) ([]core.Record, error) {
+	paged bool,
+	fit func(core.Record) bool,
+) (recs []core.Record, more bool, err error) {
 	lg, err := n.store.GetLog(id, lid)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	// reverting to old logic if the new one is not supported
 	if counter == thread.CounterUndef && offset != cid.Undef {
 		if offset.Defined() {
 			// ensure that we know about requested offset
 			if knownRecord, err := n.isKnown(offset); err != nil {
-				return nil, err
+				return nil, false, err
 			} else if !knownRecord {
-				return nil, nil
+				return nil, false, nil
 			}
 		}
 		// if we have less or equal records
 	} else if lg.Head.Counter <= counter {
-		return []core.Record{}, nil
+		return []core.Record{}, false, nil
 	}
 	sk, err := n.store.ServiceKey(id)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	if sk == nil {
-		return nil, fmt.Errorf("a service-key is required to get records")
+		return nil, false, fmt.Errorf("a service-key is required to get records")
 	}
 
-	var (
-		cursor = lg.Head.ID
-		recs   []core.Record
-	)
-
-	for len(recs) < limit {
-		if !cursor.Defined() || cursor.String() == offset.String() {
-			break
+	var cursor = lg.Head.ID
+	if !paged {
+		for len(recs) < limit {
+			if !cursor.Defined() || cursor.String() == offset.String() {
+				break
+			}
+			n.metrics.recordsRead.Inc()
+			r, err := cbor.GetRecord(ctx, n, cursor, sk) // Important invariant: heads are always in blockstore
+			if err != nil {
+				// return records fetched so far
+				return recs, false, err
+			}
+			recs = append([]core.Record{r}, recs...)
+			cursor = r.PrevID()
 		}
-		r, err := cbor.GetRecord(ctx, n, cursor, sk) // Important invariant: heads are always in blockstore
-		if err != nil {
-			// return records fetched so far
-			return recs, err
+		return recs, false, nil
+	} else if limit <= 0 {
+		return nil, false, nil
+	}
+
+	// Records only link to the previous ones, so the log is walked back from
+	// the head to the offset once, and the chain walked is kept for the next
+	// pages.
+	get := func(rid cid.Cid) (core.Record, error) {
+		n.metrics.recordsRead.Inc()
+		return cbor.GetRecord(ctx, n, rid, sk)
+	}
+	chain := n.chains.get(id, lid)
+	chain.lock.Lock()
+	ids, walked, more, err := chain.page(lg.Head.ID, offset, limit, get)
+	chain.lock.Unlock()
+	if err != nil {
+		return nil, false, err
+	}
+	recs = make([]core.Record, 0, len(ids))
+	for _, rid := range ids {
+		r, ok := walked[rid]
+		if !ok {
+			if r, err = get(rid); err != nil {
+				return nil, false, err
+			}
 		}
-		recs = append([]core.Record{r}, recs...)
-		cursor = r.PrevID()
+		if fit != nil && !fit(r) {
+			return recs, true, nil
+		}
+		recs = append(recs, r)
 	}
-
-	return recs, nil
+	return recs, more, nil
 }
 
 // deleteRecord remove a record from the dag service.
@@ -1372,64 +1807,40 @@ func (n *net) startPulling() {
 		return
 	}
 
-	// set pull cycle interval into initial value,
-	// it will be redefined on the next iteration
-	var interval = n.conf.NetPullingInitialInterval
-
 	// group threads by peers and exchange edges efficiently
 	var compressor = queue.NewThreadPacker(n.ctx, MaxThreadsExchanged, ExchangeCompressionTimeout)
 	go n.startExchange(compressor)
 
-PullCycle:
-	for {
-		ts, err := n.store.Threads()
-		if err != nil {
-			log.Errorf("error listing threads: %s", err)
-			return
-		}
-		log.Infof("pulling %d threads", len(ts))
+	// threads are listed every interval, spreading the pulls of those
+	// added over it, and over the initial interval for the first ones
+	var (
+		spread  = n.conf.NetPullingInitialInterval
+		refresh = time.NewTimer(0)
+		tick    = time.NewTicker(pullScheduleTick)
+	)
+	defer refresh.Stop()
+	defer tick.Stop()
 
-		if len(ts) == 0 {
-			// if there are no threads served, just wait and retry
-			select {
-			case <-time.After(interval):
-				interval = n.conf.NetPullingInterval
-				continue PullCycle
-			case <-n.ctx.Done():
+	for {
+		select {
+		case <-refresh.C:
+			ts, err := n.store.Threads()
+			if err != nil {
+				log.Errorf("error listing threads: %s", err)
 				return
 			}
-		}
-
-		var (
-			period = interval / time.Duration(len(ts))
-			ticker = time.NewTicker(period)
-			idx    = 0
-		)
-
-		for {
-			select {
-			case <-ticker.C:
-				var tid = ts[idx]
-				if _, peers, err := n.threadOffsets(tid); err != nil {
-					log.Errorf("error getting thread info %s: %s", tid, err)
-					return
-				} else {
-					for _, pid := range peers {
-						compressor.Add(pid, tid)
-					}
-				}
-
-				idx++
-				if idx >= len(ts) {
-					ticker.Stop()
-					interval = n.conf.NetPullingInterval
-					continue PullCycle
-				}
-
-			case <-n.ctx.Done():
-				ticker.Stop()
-				return
+			log.Infof("pulling %d threads", len(ts))
+			n.pulls.sync(ts, time.Now(), spread)
+			spread = n.conf.NetPullingInterval
+			refresh.Reset(n.conf.NetPullingInterval)
+
+		case now := <-tick.C:
+			for _, tid := range n.pulls.popDue(now) {
+				n.pullDue(compressor, tid, now)
 			}
+
+		case <-n.ctx.Done():
+			return
 		}
 	}
 }
@@ -1489,6 +1900,11 @@ func (n *net) getOrCreateLog(id thread.ID, identity thread.PubKey) (info thread.
 	if identity == nil {
 		identity = thread.NewLibp2pPubKey(n.getPrivKey().GetPublic())
 	}
+	// concurrent calls must not create two logs for the identity
+	lc := n.semaphores.Get(semaLogCreate{id: id, identity: identity.String()})
+	lc.Acquire()
+	defer lc.Release()
+
 	lidb, err := n.store.GetBytes(id, identity.String())
 	if err != nil {
 		return info, err
@@ -1533,11 +1949,13 @@ func (n *net) createExternalLogsIfNotExist(
 			}
 		} else {
 			// update log addresses
-			if err = n.Store().AddAddrs(tid, li.ID, li.Addrs, pstore.PermanentAddrTTL); err != nil {
+			n.learnAddrs(li.Addrs)
+			if err = n.addLogAddrs(tid, li.ID, li.Addrs); err != nil {
 				return err
 			}
 		}
 	}
+	n.protectThreadPeers(tid)
 	return nil
 }
 
@@ -1598,31 +2016,54 @@ func (n *net) ensureUniqueLog(id thread.ID, key crypto.Key, identity thread.PubK
 }
 
 // updateRecordsFromPeer fetches new logs & records from the peer and adds them in the local peer store.
+// Pages of records are pulled until the peer has no more.
 func (n *net) updateRecordsFromPeer(ctx context.Context, pid peer.ID, tid thread.ID) error {
-	offsets, _, err := n.threadOffsets(tid)
-	if err != nil {
-		return fmt.Errorf("getting offsets for thread %s failed: %w", tid, err)
-	}
-	req, sk, err := n.server.buildGetRecordsRequest(tid, offsets, n.conf.NetPullingLimit)
-	if err != nil {
-		return fmt.Errorf("building GetRecords request for thread %s failed: %w", tid, err)
-	}
-	recs, err := n.server.getRecordsFromPeer(ctx, tid, pid, req, sk)
-	if err != nil {
-		return fmt.Errorf("getting records for thread %s from %s failed: %w", tid, pid, err)
+	if n.pullPaused(tid) {
+		log.Debugf("pulls of thread %s are paused, skip getting records from %s", tid, pid)
+		return nil
 	}
-	for lid, rs := range recs {
-		if err = n.putRecords(ctx, tid, lid, rs.records, rs.counter); err != nil {
-			return fmt.Errorf("putting records from log %s (thread %s) failed: %w", lid, tid, err)
+	var prev map[peer.ID]thread.Head
+	for {
+		offsets, _, err := n.threadOffsets(tid)
+		if err != nil {
+			return fmt.Errorf("getting offsets for thread %s failed: %w", tid, err)
+		}
+		if prev != nil && sameOffsets(prev, offsets) {
+			// the last page didn't move the heads
+			return nil
+		}
+		prev = offsets
+		req, sk, err := n.server.buildGetRecordsRequest(tid, offsets, n.conf.NetPullingLimit)
+		if err != nil {
+			return fmt.Errorf("building GetRecords request for thread %s failed: %w", tid, err)
+		}
+		recs, err := n.server.getRecordsFromPeer(ctx, tid, pid, req, sk)
+		if errors.Is(err, errDialBackoff) {
+			log.Debugf("%s backs off, skip getting records for thread %s", pid, tid)
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("getting records for thread %s from %s failed: %w", tid, pid, err)
+		}
+		var more bool
+		for lid, rs := range recs {
+			if err = n.putRecords(ctx, tid, lid, rs.records, rs.counter); err != nil {
+				return fmt.Errorf("putting records from log %s (thread %s) failed: %w", lid, tid, err)
+			}
+			more = more || rs.more
+		}
+		if !more {
+			return nil
 		}
 	}
-	return nil
 }
 
 // updateLogsFromPeer gets new logs information from the peer and adds it in the local peer store.
 func (n *net) updateLogsFromPeer(ctx context.Context, pid peer.ID, tid thread.ID) error {
 	lgs, err := n.server.getLogs(ctx, tid, pid)
-	if err != nil {
+	if errors.Is(err, errDialBackoff) {
+		log.Debugf("%s backs off, skip getting logs for thread %s", pid, tid)
+		return nil
+	} else if err != nil {
 		return err
 	}
 	return n.createExternalLogsIfNotExist(tid, lgs)
@@ -3,10 +3,13 @@ package net
 import (
 	"context"
 	rand "crypto/rand"
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
 	bserv "github.com/ipfs/go-blockservice"
+	"github.com/ipfs/go-cid"
 	ds "github.com/ipfs/go-datastore"
 	syncds "github.com/ipfs/go-datastore/sync"
 	bstore "github.com/ipfs/go-ipfs-blockstore"
@@ -15,15 +18,20 @@ import (
 	dag "github.com/ipfs/go-merkledag"
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/peerstore"
 	ma "github.com/multiformats/go-multiaddr"
 	mh "github.com/multiformats/go-multihash"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/textileio/go-threads/cbor"
 	"github.com/textileio/go-threads/core/logstore"
 	core "github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
 	tstore "github.com/textileio/go-threads/logstore/lstoremem"
+	pb "github.com/textileio/go-threads/net/pb"
 	"github.com/textileio/go-threads/util"
+	grpcpeer "google.golang.org/grpc/peer"
 )
 
 func TestNet_GetToken(t *testing.T) {
@@ -107,6 +115,188 @@ func TestNet_CreateRecord(t *testing.T) {
 	})
 }
 
+func TestNet_SubscribeFilterLogs(t *testing.T) {
+	n := makeNetwork(t)
+	defer n.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	info := createThread(t, ctx, n)
+
+	sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherLog, err := peer.IDFromPrivateKey(sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := n.Subscribe(ctx, core.WithSubFilter(info.ID), core.WithSubFilterLogs(otherLog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r1, err := n.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	own, err := n.Subscribe(ctx, core.WithSubFilter(info.ID), core.WithSubFilterLogs(otherLog, r1.LogID()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r2, err := n.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case rec := <-own:
+		if !rec.Value().Cid().Equals(r2.Value().Cid()) {
+			t.Fatalf("expected record %s, got %s", r2.Value().Cid(), rec.Value().Cid())
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("expected the record of the log")
+	}
+	select {
+	case rec := <-other:
+		t.Fatalf("expected no record of another log, got %s", rec.Value().Cid())
+	case <-time.After(time.Second):
+	}
+
+	// records of the logs filtered out are still added to them
+	info, err = n.GetThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, lg := range info.Logs {
+		if lg.ID == r2.LogID() && (!lg.Head.ID.Equals(r2.Value().Cid()) || lg.Head.Counter != 2) {
+			t.Fatalf("expected the head of the log to be %s, got %+v", r2.Value().Cid(), lg.Head)
+		}
+	}
+}
+
+func TestNet_SubscribeSlowConsumer(t *testing.T) {
+	n := makeNetwork(t)
+	defer n.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	info := createThread(t, ctx, n)
+
+	var lock sync.Mutex
+	var dropped int
+	sub, err := n.Subscribe(ctx, core.WithSubFilter(info.ID), core.WithSubBuffer(2), core.WithSubDropped(func(d int) {
+		lock.Lock()
+		dropped = d
+		lock.Unlock()
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the subscription isn't read while the records are created, which
+	// doesn't hold up creating them
+	var recs []core.ThreadRecord
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{"n": i}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec, err := n.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, rec)
+	}
+	if elapsed := time.Since(start); elapsed > notifyTimeout {
+		t.Fatalf("expected creating records not to wait for the subscriber, took %s", elapsed)
+	}
+	deadline := time.Now().Add(time.Second * 5)
+	for {
+		lock.Lock()
+		d := dropped
+		lock.Unlock()
+		if d == 3 {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("expected 3 records to be dropped, got %d", d)
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	// the oldest records are dropped
+	for _, expected := range recs[3:] {
+		select {
+		case rec := <-sub:
+			if !rec.Value().Cid().Equals(expected.Value().Cid()) {
+				t.Fatalf("expected record %s, got %s", expected.Value().Cid(), rec.Value().Cid())
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatal("expected a buffered record")
+		}
+	}
+	select {
+	case rec := <-sub:
+		t.Fatalf("expected no more records, got %s", rec.Value().Cid())
+	case <-time.After(time.Millisecond * 100):
+	}
+}
+
+func TestNet_SubscribeUnbuffered(t *testing.T) {
+	n := makeNetwork(t)
+	defer n.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	info := createThread(t, ctx, n)
+
+	sub, err := n.Subscribe(ctx, core.WithSubFilter(info.ID), core.WithSubDropped(func(d int) {
+		t.Errorf("expected no records to be dropped, got %d", d)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the records wait for the slow subscriber instead of being dropped
+	recs := make(chan core.ThreadRecord, 5)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(recs)
+		for i := 0; i < 5; i++ {
+			body, err := cbornode.WrapObject(map[string]interface{}{"n": i}, mh.SHA2_256, -1)
+			if err != nil {
+				errs <- err
+				return
+			}
+			rec, err := n.CreateRecord(ctx, info.ID, body)
+			if err != nil {
+				errs <- err
+				return
+			}
+			recs <- rec
+		}
+	}()
+	for i := 0; i < 5; i++ {
+		time.Sleep(time.Millisecond * 100)
+		select {
+		case rec := <-sub:
+			select {
+			case expected := <-recs:
+				if !rec.Value().Cid().Equals(expected.Value().Cid()) {
+					t.Fatalf("expected record %s, got %s", expected.Value().Cid(), rec.Value().Cid())
+				}
+			case err := <-errs:
+				t.Fatal(err)
+			}
+		case err := <-errs:
+			t.Fatal(err)
+		case <-time.After(time.Second * 5):
+			t.Fatalf("expected record %d", i)
+		}
+	}
+}
+
 func TestNet_AddThread(t *testing.T) {
 	n1 := makeNetwork(t)
 	defer n1.Close()
@@ -171,6 +361,71 @@ func TestNet_AddThread(t *testing.T) {
 	}
 }
 
+func TestNet_GetThreadSummary(t *testing.T) {
+	n1 := makeNetwork(t)
+	defer n1.Close()
+	n2 := makeNetwork(t)
+	defer n2.Close()
+
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	summary, err := n1.GetThreadSummary(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !summary.ID.Equals(info.ID) || !summary.HasServiceKey || !summary.HasReadKey {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	if summary.LogCount != 1 || summary.RecordCount != 0 || !summary.LastUpdated.IsZero() {
+		t.Fatalf("expected summary of empty thread, got %+v", summary)
+	}
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"msg": i,
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = n1.CreateRecord(ctx, info.ID, body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	summary, err = n1.GetThreadSummary(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.RecordCount != 2 || summary.LastUpdated.Before(start) {
+		t.Fatalf("expected summary of 2 records, got %+v", summary)
+	}
+
+	addr, err := ma.NewMultiaddr("/p2p/" + n1.Host().ID().String() + "/thread/" + info.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n2.AddThread(ctx, addr, core.WithThreadKey(thread.NewServiceKey(info.Key.Service()))); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	summary, err = n2.GetThreadSummary(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.HasReadKey || summary.LogCount != 1 || summary.RecordCount != 2 || summary.LastUpdated.Before(start) {
+		t.Fatalf("unexpected summary of pulled thread %+v", summary)
+	}
+
+	if _, err := n1.GetThreadSummary(ctx, thread.NewIDV1(thread.Raw, 32)); !errors.Is(err, logstore.ErrThreadNotFound) {
+		t.Fatalf("expected thread not found, got %v", err)
+	}
+}
+
 func TestNet_CreateThreadManaged(t *testing.T) {
 	n := makeNetwork(t)
 	defer n.Close()
@@ -263,9 +518,9 @@ func TestNet_AddThreadManaged(t *testing.T) {
 }
 
 func TestNet_AddReplicator(t *testing.T) {
-	n1 := makeNetwork(t)
+	n1 := makeNetwork(t, noConfirmedAddrs)
 	defer n1.Close()
-	n2 := makeNetwork(t)
+	n2 := makeNetwork(t, noConfirmedAddrs)
 	defer n2.Close()
 
 	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
@@ -316,9 +571,9 @@ func TestNet_AddReplicator(t *testing.T) {
 }
 
 func TestNet_AddReplicatorManaged(t *testing.T) {
-	n1 := makeNetwork(t)
+	n1 := makeNetwork(t, noConfirmedAddrs)
 	defer n1.Close()
-	n2 := makeNetwork(t)
+	n2 := makeNetwork(t, noConfirmedAddrs)
 	defer n2.Close()
 
 	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
@@ -401,6 +656,242 @@ func TestNet_DeleteThread(t *testing.T) {
 	}
 }
 
+func TestNet_PubSubStrategy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("per thread", func(t *testing.T) {
+		n := makeNetwork(t)
+		defer n.Close()
+		info := createThread(t, ctx, n)
+
+		s := n.(*net).server
+		if name := s.threadTopics[info.ID]; name != info.ID.String() {
+			t.Fatalf("expected topic %s, got %s", info.ID, name)
+		}
+		if _, ok := s.topics[info.ID.String()]; !ok {
+			t.Fatal("expected topic of thread")
+		}
+	})
+
+	t.Run("sharded", func(t *testing.T) {
+		n := makeNetwork(t, func(c *Config) {
+			c.PubSubStrategy = core.PubSubSharded
+			c.PubSubShards = 1
+		})
+		defer n.Close()
+		info1 := createThread(t, ctx, n)
+		info2 := createThread(t, ctx, n)
+
+		s := n.(*net).server
+		if len(s.topics) != 1 {
+			t.Fatalf("expected 1 shared topic, got %d", len(s.topics))
+		}
+		if s.threadTopics[info1.ID] != "/threads/shard/1/0" || s.threadTopics[info2.ID] != "/threads/shard/1/0" {
+			t.Fatalf("expected threads to share shard topic, got %v", s.threadTopics)
+		}
+		if err := n.DeleteThread(ctx, info1.ID); err != nil {
+			t.Fatal(err)
+		}
+		if len(s.topics) != 1 {
+			t.Fatal("expected shared topic to be kept")
+		}
+		if err := n.DeleteThread(ctx, info2.ID); err != nil {
+			t.Fatal(err)
+		}
+		if len(s.topics) != 0 {
+			t.Fatal("expected shared topic to be closed")
+		}
+	})
+
+	t.Run("disabled by thread", func(t *testing.T) {
+		n := makeNetwork(t)
+		defer n.Close()
+		info, err := n.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32), core.WithNewThreadPubSub(core.PubSubDisabled))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := n.(*net).server
+		if name, ok := s.threadTopics[info.ID]; !ok || name != "" {
+			t.Fatalf("expected thread without topic, got %q", name)
+		}
+		if len(s.topics) != 0 {
+			t.Fatalf("expected no topics, got %d", len(s.topics))
+		}
+		v, err := n.(*net).store.GetString(info.ID, pubSubStrategyKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v == nil || *v != string(core.PubSubDisabled) {
+			t.Fatal("expected strategy in thread metadata")
+		}
+
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": "bar",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := n.CreateRecord(ctx, info.ID, body); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("unknown strategy", func(t *testing.T) {
+		n := makeNetwork(t)
+		defer n.Close()
+		if _, err := n.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32), core.WithNewThreadPubSub("foo")); err == nil {
+			t.Fatal("expected unknown strategy to be rejected")
+		}
+	})
+
+	t.Run("ignore unhosted threads", func(t *testing.T) {
+		n := makeNetwork(t, func(c *Config) {
+			c.PubSubStrategy = core.PubSubSharded
+			c.PubSubShards = 1
+		})
+		defer n.Close()
+		createThread(t, ctx, n)
+
+		req := &pb.PushRecordRequest{
+			Body: &pb.PushRecordRequest_Body{
+				ThreadID: &pb.ProtoThreadID{ID: thread.NewIDV1(thread.Raw, 32)},
+			},
+		}
+		msg, err := req.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := n.(*net).server
+		if _, err := s.pubSubRecordHandler(n.Host().ID(), "/threads/shard/1/0", msg); err != nil {
+			t.Fatalf("expected record of unhosted thread to be ignored, got %v", err)
+		}
+	})
+}
+
+func TestNet_PullPagedRecords(t *testing.T) {
+	n1 := makeNetwork(t)
+	defer n1.Close()
+	n2 := makeNetwork(t, func(c *Config) {
+		c.NoNetPulling = true
+	})
+	defer n2.Close()
+
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	const total = 50000
+	for i := 0; i < total; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": i,
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = n1.CreateRecord(ctx, info.ID, body); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("legacy", func(t *testing.T) {
+		info1, err := n1.GetThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lg := info1.GetFirstPrivKeyLog()
+		req := &pb.GetRecordsRequest{
+			Body: &pb.GetRecordsRequest_Body{
+				ThreadID:   &pb.ProtoThreadID{ID: info.ID},
+				ServiceKey: &pb.ProtoKey{Key: info.Key.Service()},
+				Logs: []*pb.GetRecordsRequest_Body_LogEntry{{
+					LogID:   &pb.ProtoPeerID{ID: lg.ID},
+					Offset:  &pb.ProtoCid{Cid: cid.Undef},
+					Limit:   total,
+					Counter: thread.CounterUndef,
+				}},
+			},
+		}
+		pctx := grpcpeer.NewContext(ctx, &grpcpeer.Peer{Addr: &addr{id: n2.Host().ID()}})
+		reply, err := n1.(*net).server.GetRecords(pctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(reply.Logs) != 1 {
+			t.Fatalf("expected 1 log, got %d", len(reply.Logs))
+		}
+		entry := reply.Logs[0]
+		if entry.More {
+			t.Fatal("expected legacy reply not to page")
+		}
+		if len(entry.Records) == 0 || len(entry.Records) == total {
+			t.Fatalf("expected legacy reply to be capped, got %d records", len(entry.Records))
+		}
+		var size int
+		for _, r := range entry.Records {
+			size += r.Size()
+		}
+		if size > MaxLegacyRecordsReplySize {
+			t.Fatalf("expected legacy records within %d bytes, got %d", MaxLegacyRecordsReplySize, size)
+		}
+		last, err := cbor.RecordFromProto(entry.Records[len(entry.Records)-1], info.Key.Service())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !last.Cid().Equals(lg.Head.ID) {
+			t.Fatal("expected legacy reply to end at the head")
+		}
+	})
+
+	t.Run("paged", func(t *testing.T) {
+		addr, err := ma.NewMultiaddr("/p2p/" + n1.Host().ID().String() + "/thread/" + info.ID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+			t.Fatal(err)
+		}
+		var fetched, pages int
+		readBefore := testutil.ToFloat64(n1.(*net).metrics.recordsRead)
+		if err := n2.PullThread(ctx, info.ID, core.WithRecordsFetched(func(records int) {
+			fetched += records
+			pages++
+		})); err != nil {
+			t.Fatal(err)
+		}
+		if fetched != total {
+			t.Fatalf("expected %d records fetched, got %d", total, fetched)
+		}
+		if pages < 2 {
+			t.Fatalf("expected records to be paged, got %d pages", pages)
+		}
+		// the log is walked once, and then only the records of the pages
+		// are read
+		if read := testutil.ToFloat64(n1.(*net).metrics.recordsRead) - readBefore; read > 2*total {
+			t.Fatalf("expected at most %d records read for %d pages, got %.0f", 2*total, pages, read)
+		}
+		info1, err := n1.GetThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		info2, err := n2.GetThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lg1 := info1.GetFirstPrivKeyLog()
+		var lg2 thread.LogInfo
+		for _, lg := range info2.Logs {
+			if lg.ID == lg1.ID {
+				lg2 = lg
+			}
+		}
+		if !lg2.Head.ID.Equals(lg1.Head.ID) || lg2.Head.Counter != total {
+			t.Fatalf("expected head %s at %d, got %s at %d", lg1.Head.ID, total, lg2.Head.ID, lg2.Head.Counter)
+		}
+	})
+}
+
 func TestClose(t *testing.T) {
 	n := makeNetwork(t)
 	defer n.Close()
@@ -412,7 +903,7 @@ func TestClose(t *testing.T) {
 	})
 }
 
-func makeNetwork(t *testing.T) core.Net {
+func makeNetwork(t testing.TB, configure ...func(*Config)) core.Net {
 	sk, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
 	if err != nil {
 		t.Fatal(err)
@@ -427,29 +918,38 @@ func makeNetwork(t *testing.T) core.Net {
 	if err != nil {
 		t.Fatal(err)
 	}
+	return makeHostNetwork(t, host, configure...)
+}
+
+// makeHostNetwork returns a network running on the host.
+func makeHostNetwork(t testing.TB, host host.Host, configure ...func(*Config)) core.Net {
 	bs := bstore.NewBlockstore(syncds.MutexWrap(ds.NewMapDatastore()))
 	bsrv := bserv.New(bs, offline.Exchange(bs))
+	conf := Config{
+		NetPullingLimit:           10000,
+		NetPullingStartAfter:      time.Second,
+		NetPullingInitialInterval: time.Second,
+		NetPullingInterval:        time.Second * 10,
+		PubSub:                    true,
+		Debug:                     true,
+	}
+	for _, c := range configure {
+		c(&conf)
+	}
 	n, err := NewNetwork(
 		context.Background(),
 		host,
 		bsrv.Blockstore(),
 		dag.NewDAGService(bsrv),
 		tstore.NewLogstore(),
-		Config{
-			NetPullingLimit:           10000,
-			NetPullingStartAfter:      time.Second,
-			NetPullingInitialInterval: time.Second,
-			NetPullingInterval:        time.Second * 10,
-			PubSub:                    true,
-			Debug:                     true,
-		}, nil, nil)
+		conf, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
 	return n
 }
 
-func createThread(t *testing.T, ctx context.Context, api core.API) thread.Info {
+func createThread(t testing.TB, ctx context.Context, api core.API) thread.Info {
 	info, err := api.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32))
 	if err != nil {
 		t.Fatal(err)
@@ -0,0 +1,473 @@
+package net
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/cbor"
+	lstore "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+// Records which can't be pushed to unreachable peers are queued in the
+// outbox of their thread, which is persisted in the metadata of the thread,
+// and pushed again once the peers connect, backing off while they fail.
+// Queued records are dropped once the head of their log acknowledged by the
+// peer passes them, either by a push or by the offsets of its get records
+// requests, and once the peer doesn't host the thread.
+
+// outboxKey is the key of the metadata of a thread holding its outbox.
+const outboxKey = "outbox"
+
+var (
+	// OutboxRetryInitial is the delay before records queued for a connected
+	// peer are pushed again after failing, which doubles with each failure
+	// up to OutboxRetryMax.
+	OutboxRetryInitial = time.Second
+	OutboxRetryMax     = time.Minute
+)
+
+// DefaultMaxOutboxSize is the number of records queued for a peer.
+const DefaultMaxOutboxSize = 1000
+
+// OutboxOverflow is the policy of the records which fail to be pushed to a
+// peer for which the max of records are queued already.
+type OutboxOverflow int
+
+const (
+	// OutboxDropOldest drops the record queued for the peer the longest.
+	// A record dropped is still delivered by a later record of its log,
+	// as the peer gets the records preceding those pushed to it.
+	OutboxDropOldest OutboxOverflow = iota
+	// OutboxDropNewest doesn't queue the record.
+	OutboxDropNewest
+)
+
+// Valid tells whether the policy is known.
+func (o OutboxOverflow) Valid() bool {
+	return o == OutboxDropOldest || o == OutboxDropNewest
+}
+
+// OutboxReporter is implemented by the networks of NewNetwork, which report
+// the records queued for unreachable peers.
+type OutboxReporter interface {
+	// OutboxDepths returns the number of records queued for each peer.
+	OutboxDepths() map[peer.ID]int
+}
+
+var _ OutboxReporter = (*net)(nil)
+
+// outboxEntry is a record queued for a peer.
+type outboxEntry struct {
+	Log     peer.ID `json:"log"`
+	Record  cid.Cid `json:"record"`
+	Counter int64   `json:"counter"`
+	Peer    peer.ID `json:"peer"`
+	Queued  int64   `json:"queued"`
+}
+
+// outboxTarget is a record queued for a peer, with its thread.
+type outboxTarget struct {
+	thread thread.ID
+	outboxEntry
+}
+
+// outbox keeps the records queued for peers by thread, persisting the
+// entries of a thread with each change.
+type outbox struct {
+	lock     sync.Mutex
+	store    lstore.Logstore
+	max      int
+	overflow OutboxOverflow
+	threads  map[thread.ID][]outboxEntry
+	depths   map[peer.ID]int
+	flushing map[peer.ID]struct{}
+	now      func() time.Time
+}
+
+func newOutbox(store lstore.Logstore, max int, overflow OutboxOverflow) *outbox {
+	if max == 0 {
+		max = DefaultMaxOutboxSize
+	} else if max < 0 {
+		max = 0
+	}
+	return &outbox{
+		store:    store,
+		max:      max,
+		overflow: overflow,
+		threads:  make(map[thread.ID][]outboxEntry),
+		depths:   make(map[peer.ID]int),
+		flushing: make(map[peer.ID]struct{}),
+		now:      time.Now,
+	}
+}
+
+// load restores the entries persisted for the threads.
+func (o *outbox) load() error {
+	tids, err := o.store.Threads()
+	if err != nil {
+		return err
+	}
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	for _, id := range tids {
+		v, err := o.store.GetBytes(id, outboxKey)
+		if err != nil {
+			return err
+		}
+		if v == nil || len(*v) == 0 {
+			continue
+		}
+		var entries []outboxEntry
+		if err := json.Unmarshal(*v, &entries); err != nil {
+			return fmt.Errorf("decoding outbox of thread %s: %v", id, err)
+		}
+		o.threads[id] = entries
+		for _, e := range entries {
+			o.depths[e.Peer]++
+		}
+	}
+	return nil
+}
+
+// persist stores the entries of the thread. It's not thread-safe.
+func (o *outbox) persist(id thread.ID) error {
+	var v []byte
+	if entries := o.threads[id]; len(entries) > 0 {
+		var err error
+		if v, err = json.Marshal(entries); err != nil {
+			return err
+		}
+	}
+	return o.store.PutBytes(id, outboxKey, v)
+}
+
+// remove removes the entries of the thread matching the filter, returning
+// whether any was. It's not thread-safe.
+func (o *outbox) remove(id thread.ID, match func(e outboxEntry) bool) bool {
+	entries := o.threads[id]
+	kept := entries[:0]
+	for _, e := range entries {
+		if match(e) {
+			o.depths[e.Peer]--
+			if o.depths[e.Peer] == 0 {
+				delete(o.depths, e.Peer)
+			}
+		} else {
+			kept = append(kept, e)
+		}
+	}
+	if len(kept) == len(entries) {
+		return false
+	}
+	if len(kept) == 0 {
+		delete(o.threads, id)
+	} else {
+		o.threads[id] = kept
+	}
+	return true
+}
+
+// add queues the record of the log for the peer, applying the overflow
+// policy if the max of records are queued for it.
+func (o *outbox) add(id thread.ID, lid peer.ID, rid cid.Cid, counter int64, pid peer.ID) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	for _, e := range o.threads[id] {
+		if e.Peer == pid && e.Log == lid && e.Record.Equals(rid) {
+			return nil
+		}
+	}
+	if o.depths[pid] >= o.max {
+		if o.max == 0 || o.overflow == OutboxDropNewest {
+			log.Debugf("outbox of %s is full, dropping record %s (thread %s)", pid, rid, id)
+			return nil
+		}
+		var (
+			oldest outboxTarget
+			found  bool
+		)
+		for tid, entries := range o.threads {
+			for _, e := range entries {
+				if e.Peer == pid && (!found || e.Queued < oldest.Queued) {
+					oldest, found = outboxTarget{thread: tid, outboxEntry: e}, true
+				}
+			}
+		}
+		if found {
+			log.Debugf("outbox of %s is full, dropping oldest record %s (thread %s)", pid, oldest.Record, oldest.thread)
+			o.remove(oldest.thread, func(e outboxEntry) bool {
+				return e.Peer == pid && e.Log == oldest.Log && e.Record.Equals(oldest.Record)
+			})
+			if oldest.thread != id {
+				if err := o.persist(oldest.thread); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	o.threads[id] = append(o.threads[id], outboxEntry{
+		Log:     lid,
+		Record:  rid,
+		Counter: counter,
+		Peer:    pid,
+		Queued:  o.now().UnixNano(),
+	})
+	o.depths[pid]++
+	return o.persist(id)
+}
+
+// ack drops the records of the log queued for the peer up to the head it
+// acknowledged. Records without a counter are only dropped once delivered.
+func (o *outbox) ack(id thread.ID, lid peer.ID, pid peer.ID, counter int64) error {
+	if counter == thread.CounterUndef {
+		return nil
+	}
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if !o.remove(id, func(e outboxEntry) bool {
+		return e.Peer == pid && e.Log == lid && e.Counter != thread.CounterUndef && e.Counter <= counter
+	}) {
+		return nil
+	}
+	return o.persist(id)
+}
+
+// dropRecord drops the record of the log queued for the peer.
+func (o *outbox) dropRecord(id thread.ID, lid peer.ID, rid cid.Cid, pid peer.ID) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if !o.remove(id, func(e outboxEntry) bool {
+		return e.Peer == pid && e.Log == lid && e.Record.Equals(rid)
+	}) {
+		return nil
+	}
+	return o.persist(id)
+}
+
+// queued tells whether the record of the log is queued for the peer.
+func (o *outbox) queued(id thread.ID, lid peer.ID, rid cid.Cid, pid peer.ID) bool {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	for _, e := range o.threads[id] {
+		if e.Peer == pid && e.Log == lid && e.Record.Equals(rid) {
+			return true
+		}
+	}
+	return false
+}
+
+// dropPeer drops the records of the thread queued for the peer.
+func (o *outbox) dropPeer(id thread.ID, pid peer.ID) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if !o.remove(id, func(e outboxEntry) bool { return e.Peer == pid }) {
+		return nil
+	}
+	return o.persist(id)
+}
+
+// dropThread drops the records of the deleted thread, whose metadata is
+// deleted with it.
+func (o *outbox) dropThread(id thread.ID) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.remove(id, func(outboxEntry) bool { return true })
+}
+
+// pending returns the records queued for the peer, ordered by thread, log
+// and counter.
+func (o *outbox) pending(pid peer.ID) []outboxTarget {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	var list []outboxTarget
+	for id, entries := range o.threads {
+		for _, e := range entries {
+			if e.Peer == pid {
+				list = append(list, outboxTarget{thread: id, outboxEntry: e})
+			}
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].thread != list[j].thread {
+			return list[i].thread < list[j].thread
+		}
+		if list[i].Log != list[j].Log {
+			return list[i].Log < list[j].Log
+		}
+		return list[i].Counter < list[j].Counter
+	})
+	return list
+}
+
+// peers returns the peers for which records are queued.
+func (o *outbox) peers() []peer.ID {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	list := make([]peer.ID, 0, len(o.depths))
+	for p := range o.depths {
+		list = append(list, p)
+	}
+	return list
+}
+
+// size returns the number of queued records.
+func (o *outbox) size() int {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	var n int
+	for _, d := range o.depths {
+		n += d
+	}
+	return n
+}
+
+// startFlush marks the peer as being flushed, returning false if it was
+// already.
+func (o *outbox) startFlush(pid peer.ID) bool {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if _, ok := o.flushing[pid]; ok {
+		return false
+	}
+	o.flushing[pid] = struct{}{}
+	return true
+}
+
+func (o *outbox) stopFlush(pid peer.ID) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	delete(o.flushing, pid)
+}
+
+func (n *net) OutboxDepths() map[peer.ID]int {
+	n.outbox.lock.Lock()
+	defer n.outbox.lock.Unlock()
+	depths := make(map[peer.ID]int, len(n.outbox.depths))
+	for p, d := range n.outbox.depths {
+		depths[p] = d
+	}
+	return depths
+}
+
+// queueRecord queues the record of the log which failed to be pushed to the
+// peer.
+func (n *net) queueRecord(tid thread.ID, lid peer.ID, rid cid.Cid, counter int64, pid peer.ID) {
+	if err := n.outbox.add(tid, lid, rid, counter, pid); err != nil {
+		log.Errorf("queueing record %s for %s (thread %s): %v", rid, pid, tid, err)
+	}
+}
+
+// ackRecords drops the records of the log queued for the peer up to the head
+// it acknowledged.
+func (n *net) ackRecords(tid thread.ID, lid peer.ID, pid peer.ID, counter int64) {
+	if err := n.outbox.ack(tid, lid, pid, counter); err != nil {
+		log.Errorf("acknowledging records of log %s for %s (thread %s): %v", lid, pid, tid, err)
+	}
+}
+
+// deliveredRecord drops the record of the log pushed to the peer, and those
+// preceding it.
+func (n *net) deliveredRecord(tid thread.ID, lid peer.ID, rid cid.Cid, counter int64, pid peer.ID) {
+	if err := n.outbox.dropRecord(tid, lid, rid, pid); err != nil {
+		log.Errorf("dropping delivered record %s for %s (thread %s): %v", rid, pid, tid, err)
+	}
+	n.ackRecords(tid, lid, pid, counter)
+}
+
+// notifyOutbox pushes the records queued for the peers once they connect.
+func (n *net) notifyOutbox() *network.NotifyBundle {
+	return &network.NotifyBundle{
+		ConnectedF: func(_ network.Network, c network.Conn) {
+			go n.flushOutbox(c.RemotePeer())
+		},
+	}
+}
+
+// flushOutbox pushes the records queued for the peer, backing off while they
+// fail and the peer is connected.
+func (n *net) flushOutbox(pid peer.ID) {
+	if !n.outbox.startFlush(pid) {
+		return
+	}
+	defer n.outbox.stopFlush(pid)
+	n.server.resetConnectBackoff(pid)
+
+	delay := OutboxRetryInitial
+	for {
+		pending := n.outbox.pending(pid)
+		if len(pending) == 0 {
+			return
+		}
+		log.Debugf("pushing %d records queued for %s", len(pending), pid)
+		delivered := true
+		for _, t := range pending {
+			if !n.pushQueuedRecord(t) {
+				delivered = false
+				break
+			}
+		}
+		if delivered {
+			continue
+		}
+		if n.host.Network().Connectedness(pid) != network.Connected {
+			return
+		}
+		select {
+		case <-n.ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > OutboxRetryMax {
+			delay = OutboxRetryMax
+		}
+	}
+}
+
+// pushQueuedRecord pushes the queued record, returning whether it was
+// delivered or dropped rather than queued again.
+func (n *net) pushQueuedRecord(t outboxTarget) bool {
+	drop := func(reason string, args ...interface{}) bool {
+		log.Debugf("dropping record %s queued for %s (thread %s): %s", t.Record, t.Peer, t.thread, fmt.Sprintf(reason, args...))
+		if err := n.outbox.dropRecord(t.thread, t.Log, t.Record, t.Peer); err != nil {
+			log.Errorf("dropping queued record %s: %v", t.Record, err)
+		}
+		return true
+	}
+	if n.replicatorExpired(t.thread, t.Peer) {
+		if err := n.outbox.dropPeer(t.thread, t.Peer); err != nil {
+			log.Errorf("dropping records queued for %s: %v", t.Peer, err)
+		}
+		return true
+	}
+	ctx, cancel := context.WithTimeout(n.ctx, PushTimeout)
+	defer cancel()
+	rec, err := n.getRecord(ctx, t.thread, t.Record)
+	if err != nil {
+		return drop("getting record: %v", err)
+	}
+	pbrec, err := cbor.RecordToProto(ctx, n, rec)
+	if err != nil {
+		return drop("encoding record: %v", err)
+	}
+	req := &pb.PushRecordRequest{
+		Body: &pb.PushRecordRequest_Body{
+			ThreadID: &pb.ProtoThreadID{ID: t.thread},
+			LogID:    &pb.ProtoPeerID{ID: t.Log},
+			Record:   pbrec,
+		},
+		Counter: t.Counter,
+	}
+	if err := n.server.pushRecordToPeer(req, t.Peer, t.thread, t.Log, t.Record); err != nil {
+		return drop("pushing record: %v", err)
+	}
+	return !n.outbox.queued(t.thread, t.Log, t.Record, t.Peer)
+}
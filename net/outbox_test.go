@@ -0,0 +1,173 @@
+package net
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestOutbox(t *testing.T) {
+	n := makeNetwork(t)
+	defer n.Close()
+	ctx := context.Background()
+	t1, t2 := createThread(t, ctx, n).ID, createThread(t, ctx, n).ID
+	store := n.(*net).store
+	newPeer := func() peer.ID {
+		sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pid, err := peer.IDFromPrivateKey(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pid
+	}
+	a, b, lid := newPeer(), newPeer(), newPeer()
+	rid := func(i int) cid.Cid {
+		h, _ := mh.Sum([]byte{byte(i)}, mh.SHA2_256, -1)
+		return cid.NewCidV1(cid.DagCBOR, h)
+	}
+	depths := func(o *outbox, want map[peer.ID]int) {
+		t.Helper()
+		for _, p := range []peer.ID{a, b} {
+			if got := o.depths[p]; got != want[p] {
+				t.Fatalf("expected %d records queued for %s, got %d", want[p], p, got)
+			}
+		}
+	}
+
+	t.Run("drop oldest", func(t *testing.T) {
+		o := newOutbox(store, 2, OutboxDropOldest)
+		var now int64
+		o.now = func() time.Time { now++; return time.Unix(0, now) }
+		for i, id := range []thread.ID{t1, t2, t1} {
+			if err := o.add(id, lid, rid(i), int64(i+1), a); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := o.add(t2, lid, rid(1), 2, a); err != nil {
+			t.Fatal(err)
+		}
+		if err := o.add(t1, lid, rid(0), 1, b); err != nil {
+			t.Fatal(err)
+		}
+		depths(o, map[peer.ID]int{a: 2, b: 1})
+		if o.queued(t1, lid, rid(0), a) {
+			t.Fatal("expected oldest record to be dropped")
+		}
+
+		// the entries are restored from the metadata of the threads
+		l := newOutbox(store, 2, OutboxDropOldest)
+		if err := l.load(); err != nil {
+			t.Fatal(err)
+		}
+		depths(l, map[peer.ID]int{a: 2, b: 1})
+
+		if err := o.ack(t1, lid, a, 3); err != nil {
+			t.Fatal(err)
+		}
+		if err := o.dropPeer(t1, b); err != nil {
+			t.Fatal(err)
+		}
+		depths(o, map[peer.ID]int{a: 1})
+		if pending := o.pending(a); len(pending) != 1 || pending[0].thread != t2 || pending[0].Counter != 2 {
+			t.Fatalf("expected record of thread %s pending, got %v", t2, pending)
+		}
+		o.dropThread(t2)
+		depths(o, nil)
+	})
+
+	t.Run("drop newest", func(t *testing.T) {
+		o := newOutbox(store, 1, OutboxDropNewest)
+		for i := 0; i < 2; i++ {
+			if err := o.add(t1, lid, rid(i), int64(i+1), a); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if !o.queued(t1, lid, rid(0), a) || o.queued(t1, lid, rid(1), a) {
+			t.Fatal("expected newest record to be dropped")
+		}
+	})
+}
+
+func TestNet_Outbox(t *testing.T) {
+	n1 := makeNetwork(t, noConfirmedAddrs, func(c *Config) { c.PubSub = false })
+	defer n1.Close()
+	n2 := makeNetwork(t, noConfirmedAddrs, func(c *Config) {
+		c.PubSub = false
+		c.NoNetPulling = true
+	})
+	defer n2.Close()
+	h1, h2 := n1.Host(), n2.Host()
+	h1.Peerstore().AddAddrs(h2.ID(), h2.Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	raddr, err := ma.NewMultiaddr("/p2p/" + h2.ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n1.AddReplicator(ctx, info.ID, raddr); err != nil {
+		t.Fatal(err)
+	}
+
+	// make the replicator unreachable
+	h1.Peerstore().ClearAddrs(h2.ID())
+	h2.Peerstore().ClearAddrs(h1.ID())
+	if err := h1.Network().ClosePeer(h2.ID()); err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n1.CreateRecord(ctx, info.ID, body); err != nil {
+		t.Fatal(err)
+	}
+	reporter := n1.(OutboxReporter)
+	for i := 0; reporter.OutboxDepths()[h2.ID()] != 1; i++ {
+		if i == 100 {
+			t.Fatal("expected record to be queued for unreachable replicator")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if v, err := n1.(*net).store.GetBytes(info.ID, outboxKey); err != nil || v == nil || len(*v) == 0 {
+		t.Fatalf("expected queued record to be persisted (%v)", err)
+	}
+
+	// the record is delivered once the replicator connects
+	if err := h1.Connect(ctx, peer.AddrInfo{ID: h2.ID(), Addrs: h2.Addrs()}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; ; i++ {
+		head, err := n2.(*net).currentHead(info.ID, info.Logs[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if head.Counter == 1 {
+			break
+		}
+		if i == 100 {
+			t.Fatal("expected queued record to be delivered")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	for i := 0; len(reporter.OutboxDepths()) != 0; i++ {
+		if i == 50 {
+			t.Fatal("expected delivered record to be dropped from the outbox")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
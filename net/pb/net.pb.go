@@ -27,18 +27,12 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
-// Log represents a thread log.
 type Log struct {
-	// ID of the log.
-	ID *ProtoPeerID `protobuf:"bytes,1,opt,name=ID,proto3,customtype=ProtoPeerID" json:"ID,omitempty"`
-	// pubKey of the log.
-	PubKey *ProtoPubKey `protobuf:"bytes,2,opt,name=pubKey,proto3,customtype=ProtoPubKey" json:"pubKey,omitempty"`
-	// addrs of the log.
-	Addrs []ProtoAddr `protobuf:"bytes,3,rep,name=addrs,proto3,customtype=ProtoAddr" json:"addrs,omitempty"`
-	// head of the log.
-	Head *ProtoCid `protobuf:"bytes,4,opt,name=head,proto3,customtype=ProtoCid" json:"head,omitempty"`
-	// position of the log
-	Counter int64 `protobuf:"varint,5,opt,name=counter,proto3" json:"counter,omitempty"`
+	ID      *ProtoPeerID `protobuf:"bytes,1,opt,name=ID,proto3,customtype=ProtoPeerID" json:"ID,omitempty"`
+	PubKey  *ProtoPubKey `protobuf:"bytes,2,opt,name=pubKey,proto3,customtype=ProtoPubKey" json:"pubKey,omitempty"`
+	Addrs   []ProtoAddr  `protobuf:"bytes,3,rep,name=addrs,proto3,customtype=ProtoAddr" json:"addrs,omitempty"`
+	Head    *ProtoCid    `protobuf:"bytes,4,opt,name=head,proto3,customtype=ProtoCid" json:"head,omitempty"`
+	Counter int64        `protobuf:"varint,5,opt,name=counter,proto3" json:"counter,omitempty"`
 }
 
 func (m *Log) Reset()         { *m = Log{} }
@@ -81,16 +75,11 @@ func (m *Log) GetCounter() int64 {
 	return 0
 }
 
-// Record is a thread record containing link data.
 type Log_Record struct {
-	// recordNode is the top-level node's raw data.
 	RecordNode []byte `protobuf:"bytes,1,opt,name=recordNode,proto3" json:"recordNode,omitempty"`
-	// eventNode is the event node's raw data.
-	EventNode []byte `protobuf:"bytes,2,opt,name=eventNode,proto3" json:"eventNode,omitempty"`
-	// headerNode is the header node's raw data.
+	EventNode  []byte `protobuf:"bytes,2,opt,name=eventNode,proto3" json:"eventNode,omitempty"`
 	HeaderNode []byte `protobuf:"bytes,3,opt,name=headerNode,proto3" json:"headerNode,omitempty"`
-	// bodyNode is the body node's raw data.
-	BodyNode []byte `protobuf:"bytes,4,opt,name=bodyNode,proto3" json:"bodyNode,omitempty"`
+	BodyNode   []byte `protobuf:"bytes,4,opt,name=bodyNode,proto3" json:"bodyNode,omitempty"`
 }
 
 func (m *Log_Record) Reset()         { *m = Log_Record{} }
@@ -154,9 +143,7 @@ func (m *Log_Record) GetBodyNode() []byte {
 	return nil
 }
 
-// GetLogsRequest is used to request thread logs.
 type GetLogsRequest struct {
-	// body is the message body.
 	Body *GetLogsRequest_Body `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
 }
 
@@ -201,10 +188,8 @@ func (m *GetLogsRequest) GetBody() *GetLogsRequest_Body {
 }
 
 type GetLogsRequest_Body struct {
-	// threadID is the target thread's ID.
-	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
-	// serviceKey for the thread.
-	ServiceKey *ProtoKey `protobuf:"bytes,2,opt,name=serviceKey,proto3,customtype=ProtoKey" json:"serviceKey,omitempty"`
+	ThreadID   *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	ServiceKey *ProtoKey      `protobuf:"bytes,2,opt,name=serviceKey,proto3,customtype=ProtoKey" json:"serviceKey,omitempty"`
 }
 
 func (m *GetLogsRequest_Body) Reset()         { *m = GetLogsRequest_Body{} }
@@ -240,9 +225,7 @@ func (m *GetLogsRequest_Body) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetLogsRequest_Body proto.InternalMessageInfo
 
-// GetLogsReply is the response from a GetLogsRequest.
 type GetLogsReply struct {
-	// logs are the result of the request.
 	Logs []*Log `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
 }
 
@@ -286,9 +269,7 @@ func (m *GetLogsReply) GetLogs() []*Log {
 	return nil
 }
 
-// PushLogRequest is used to push a thread log to a peer.
 type PushLogRequest struct {
-	// body is the message body.
 	Body *PushLogRequest_Body `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
 }
 
@@ -333,14 +314,10 @@ func (m *PushLogRequest) GetBody() *PushLogRequest_Body {
 }
 
 type PushLogRequest_Body struct {
-	// threadID is the target thread's ID.
-	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
-	// serviceKey for the thread.
-	ServiceKey *ProtoKey `protobuf:"bytes,2,opt,name=serviceKey,proto3,customtype=ProtoKey" json:"serviceKey,omitempty"`
-	// readKey for the thread.
-	ReadKey *ProtoKey `protobuf:"bytes,3,opt,name=readKey,proto3,customtype=ProtoKey" json:"readKey,omitempty"`
-	// log is the actual log payload.
-	Log *Log `protobuf:"bytes,4,opt,name=log,proto3" json:"log,omitempty"`
+	ThreadID   *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	ServiceKey *ProtoKey      `protobuf:"bytes,2,opt,name=serviceKey,proto3,customtype=ProtoKey" json:"serviceKey,omitempty"`
+	ReadKey    *ProtoKey      `protobuf:"bytes,3,opt,name=readKey,proto3,customtype=ProtoKey" json:"readKey,omitempty"`
+	Log        *Log           `protobuf:"bytes,4,opt,name=log,proto3" json:"log,omitempty"`
 }
 
 func (m *PushLogRequest_Body) Reset()         { *m = PushLogRequest_Body{} }
@@ -383,7 +360,6 @@ func (m *PushLogRequest_Body) GetLog() *Log {
 	return nil
 }
 
-// PushLogReply is the response from a PushLogRequest.
 type PushLogReply struct {
 }
 
@@ -420,9 +396,7 @@ func (m *PushLogReply) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PushLogReply proto.InternalMessageInfo
 
-// GetRecordsRequest is used to request records from a log address.
 type GetRecordsRequest struct {
-	// body is the message body.
 	Body *GetRecordsRequest_Body `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
 }
 
@@ -467,12 +441,10 @@ func (m *GetRecordsRequest) GetBody() *GetRecordsRequest_Body {
 }
 
 type GetRecordsRequest_Body struct {
-	// threadID is the target thread's ID.
-	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
-	// serviceKey for the thread.
-	ServiceKey *ProtoKey `protobuf:"bytes,2,opt,name=serviceKey,proto3,customtype=ProtoKey" json:"serviceKey,omitempty"`
-	// List of requested logs.
-	Logs []*GetRecordsRequest_Body_LogEntry `protobuf:"bytes,3,rep,name=logs,proto3" json:"logs,omitempty"`
+	ThreadID   *ProtoThreadID                     `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	ServiceKey *ProtoKey                          `protobuf:"bytes,2,opt,name=serviceKey,proto3,customtype=ProtoKey" json:"serviceKey,omitempty"`
+	Logs       []*GetRecordsRequest_Body_LogEntry `protobuf:"bytes,3,rep,name=logs,proto3" json:"logs,omitempty"`
+	Version    uint32                             `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
 }
 
 func (m *GetRecordsRequest_Body) Reset()         { *m = GetRecordsRequest_Body{} }
@@ -515,16 +487,18 @@ func (m *GetRecordsRequest_Body) GetLogs() []*GetRecordsRequest_Body_LogEntry {
 	return nil
 }
 
-// LogEntry represents a single log.
+func (m *GetRecordsRequest_Body) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type GetRecordsRequest_Body_LogEntry struct {
-	// logID of this entry.
-	LogID *ProtoPeerID `protobuf:"bytes,1,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
-	// offset tells the recipient at which point to consider records new for the reply.
-	Offset *ProtoCid `protobuf:"bytes,2,opt,name=offset,proto3,customtype=ProtoCid" json:"offset,omitempty"`
-	// limit indicates the max number of records to return.
-	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	// counter indicates the position of record
-	Counter int64 `protobuf:"varint,4,opt,name=counter,proto3" json:"counter,omitempty"`
+	LogID   *ProtoPeerID `protobuf:"bytes,1,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
+	Offset  *ProtoCid    `protobuf:"bytes,2,opt,name=offset,proto3,customtype=ProtoCid" json:"offset,omitempty"`
+	Limit   int32        `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Counter int64        `protobuf:"varint,4,opt,name=counter,proto3" json:"counter,omitempty"`
 }
 
 func (m *GetRecordsRequest_Body_LogEntry) Reset()         { *m = GetRecordsRequest_Body_LogEntry{} }
@@ -574,9 +548,7 @@ func (m *GetRecordsRequest_Body_LogEntry) GetCounter() int64 {
 	return 0
 }
 
-// GetRecordsReply contains records requested with a GetRecordsRequest.
 type GetRecordsReply struct {
-	// records are the result of the request.
 	Logs []*GetRecordsReply_LogEntry `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
 }
 
@@ -620,14 +592,11 @@ func (m *GetRecordsReply) GetLogs() []*GetRecordsReply_LogEntry {
 	return nil
 }
 
-// LogEntry represents a single log.
 type GetRecordsReply_LogEntry struct {
-	// logID of this entry.
-	LogID *ProtoPeerID `protobuf:"bytes,1,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
-	// records returned for this entry.
+	LogID   *ProtoPeerID  `protobuf:"bytes,1,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
 	Records []*Log_Record `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
-	// log contains new log info that was missing from the request.
-	Log *Log `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
+	Log     *Log          `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
+	More    bool          `protobuf:"varint,4,opt,name=more,proto3" json:"more,omitempty"`
 }
 
 func (m *GetRecordsReply_LogEntry) Reset()         { *m = GetRecordsReply_LogEntry{} }
@@ -677,12 +646,16 @@ func (m *GetRecordsReply_LogEntry) GetLog() *Log {
 	return nil
 }
 
-// PushRecordRequest is used to push a log record to a peer.
+func (m *GetRecordsReply_LogEntry) GetMore() bool {
+	if m != nil {
+		return m.More
+	}
+	return false
+}
+
 type PushRecordRequest struct {
-	// body is the message body.
-	Body *PushRecordRequest_Body `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
-	// position of the record
-	Counter int64 `protobuf:"varint,3,opt,name=counter,proto3" json:"counter,omitempty"`
+	Body    *PushRecordRequest_Body `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	Counter int64                   `protobuf:"varint,3,opt,name=counter,proto3" json:"counter,omitempty"`
 }
 
 func (m *PushRecordRequest) Reset()         { *m = PushRecordRequest{} }
@@ -733,12 +706,9 @@ func (m *PushRecordRequest) GetCounter() int64 {
 }
 
 type PushRecordRequest_Body struct {
-	// threadID is the target thread's ID.
 	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
-	// logID is the target log's ID.
-	LogID *ProtoPeerID `protobuf:"bytes,2,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
-	// record is the actual record payload.
-	Record *Log_Record `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
+	LogID    *ProtoPeerID   `protobuf:"bytes,2,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
+	Record   *Log_Record    `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
 }
 
 func (m *PushRecordRequest_Body) Reset()         { *m = PushRecordRequest_Body{} }
@@ -781,7 +751,6 @@ func (m *PushRecordRequest_Body) GetRecord() *Log_Record {
 	return nil
 }
 
-// PushRecordReply is the response from a PushRecordRequest.
 type PushRecordReply struct {
 }
 
@@ -818,9 +787,7 @@ func (m *PushRecordReply) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PushRecordReply proto.InternalMessageInfo
 
-// ExchangeEdgesRequest is used to exchange address/heads edges with a peer.
 type ExchangeEdgesRequest struct {
-	// body is the message body.
 	Body *ExchangeEdgesRequest_Body `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
 }
 
@@ -865,7 +832,6 @@ func (m *ExchangeEdgesRequest) GetBody() *ExchangeEdgesRequest_Body {
 }
 
 type ExchangeEdgesRequest_Body struct {
-	// threads is a list of requested thread IDs with its local edges.
 	Threads []*ExchangeEdgesRequest_Body_ThreadEntry `protobuf:"bytes,1,rep,name=threads,proto3" json:"threads,omitempty"`
 }
 
@@ -910,12 +876,9 @@ func (m *ExchangeEdgesRequest_Body) GetThreads() []*ExchangeEdgesRequest_Body_Th
 }
 
 type ExchangeEdgesRequest_Body_ThreadEntry struct {
-	// threadID is the target thread's ID.
-	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
-	// addressEdge is the current hash of peers addresses stored on a requester.
-	AddressEdge uint64 `protobuf:"varint,2,opt,name=addressEdge,proto3" json:"addressEdge,omitempty"`
-	// headsEdge is the current hash of the log's heads stored on a requester.
-	HeadsEdge uint64 `protobuf:"varint,3,opt,name=headsEdge,proto3" json:"headsEdge,omitempty"`
+	ThreadID    *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	AddressEdge uint64         `protobuf:"varint,2,opt,name=addressEdge,proto3" json:"addressEdge,omitempty"`
+	HeadsEdge   uint64         `protobuf:"varint,3,opt,name=headsEdge,proto3" json:"headsEdge,omitempty"`
 }
 
 func (m *ExchangeEdgesRequest_Body_ThreadEntry) Reset()         { *m = ExchangeEdgesRequest_Body_ThreadEntry{} }
@@ -965,9 +928,7 @@ func (m *ExchangeEdgesRequest_Body_ThreadEntry) GetHeadsEdge() uint64 {
 	return 0
 }
 
-// ExchangeEdgesReply contains edges requested with an ExchangeEdgesRequest.
 type ExchangeEdgesReply struct {
-	// edges contains edge information about requested threads.
 	Edges []*ExchangeEdgesReply_ThreadEdges `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
 }
 
@@ -1012,14 +973,10 @@ func (m *ExchangeEdgesReply) GetEdges() []*ExchangeEdgesReply_ThreadEdges {
 }
 
 type ExchangeEdgesReply_ThreadEdges struct {
-	// threadID is the requested thread's ID.
-	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
-	// deprecated, use default values for addressEdge and headsEdge
-	Exists bool `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"`
-	// addressEdge is the current hash of peers addresses stored on a respondent.
-	AddressEdge uint64 `protobuf:"varint,3,opt,name=addressEdge,proto3" json:"addressEdge,omitempty"`
-	// headsEdge is the current hash of the log's heads stored on a respondent.
-	HeadsEdge uint64 `protobuf:"varint,4,opt,name=headsEdge,proto3" json:"headsEdge,omitempty"`
+	ThreadID    *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	Exists      bool           `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"`
+	AddressEdge uint64         `protobuf:"varint,3,opt,name=addressEdge,proto3" json:"addressEdge,omitempty"`
+	HeadsEdge   uint64         `protobuf:"varint,4,opt,name=headsEdge,proto3" json:"headsEdge,omitempty"`
 }
 
 func (m *ExchangeEdgesReply_ThreadEdges) Reset()         { *m = ExchangeEdgesReply_ThreadEdges{} }
@@ -1076,6 +1033,132 @@ func (m *ExchangeEdgesReply_ThreadEdges) GetHeadsEdge() uint64 {
 	return 0
 }
 
+type DeleteThreadRequest struct {
+	Body *DeleteThreadRequest_Body `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	Sig  []byte                    `protobuf:"bytes,2,opt,name=sig,proto3" json:"sig,omitempty"`
+}
+
+func (m *DeleteThreadRequest) Reset()         { *m = DeleteThreadRequest{} }
+func (m *DeleteThreadRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteThreadRequest) ProtoMessage()    {}
+func (*DeleteThreadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{11}
+}
+func (m *DeleteThreadRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeleteThreadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteThreadRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DeleteThreadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteThreadRequest.Merge(m, src)
+}
+func (m *DeleteThreadRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteThreadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteThreadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteThreadRequest proto.InternalMessageInfo
+
+func (m *DeleteThreadRequest) GetBody() *DeleteThreadRequest_Body {
+	if m != nil {
+		return m.Body
+	}
+	return nil
+}
+
+func (m *DeleteThreadRequest) GetSig() []byte {
+	if m != nil {
+		return m.Sig
+	}
+	return nil
+}
+
+type DeleteThreadRequest_Body struct {
+	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	LogID    *ProtoPeerID   `protobuf:"bytes,2,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
+}
+
+func (m *DeleteThreadRequest_Body) Reset()         { *m = DeleteThreadRequest_Body{} }
+func (m *DeleteThreadRequest_Body) String() string { return proto.CompactTextString(m) }
+func (*DeleteThreadRequest_Body) ProtoMessage()    {}
+func (*DeleteThreadRequest_Body) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{11, 0}
+}
+func (m *DeleteThreadRequest_Body) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeleteThreadRequest_Body) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteThreadRequest_Body.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DeleteThreadRequest_Body) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteThreadRequest_Body.Merge(m, src)
+}
+func (m *DeleteThreadRequest_Body) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteThreadRequest_Body) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteThreadRequest_Body.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteThreadRequest_Body proto.InternalMessageInfo
+
+type DeleteThreadReply struct {
+}
+
+func (m *DeleteThreadReply) Reset()         { *m = DeleteThreadReply{} }
+func (m *DeleteThreadReply) String() string { return proto.CompactTextString(m) }
+func (*DeleteThreadReply) ProtoMessage()    {}
+func (*DeleteThreadReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{12}
+}
+func (m *DeleteThreadReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeleteThreadReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteThreadReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DeleteThreadReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteThreadReply.Merge(m, src)
+}
+func (m *DeleteThreadReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteThreadReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteThreadReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteThreadReply proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*Log)(nil), "net.pb.Log")
 	proto.RegisterType((*Log_Record)(nil), "net.pb.Log.Record")
@@ -1098,69 +1181,78 @@ func init() {
 	proto.RegisterType((*ExchangeEdgesRequest_Body_ThreadEntry)(nil), "net.pb.ExchangeEdgesRequest.Body.ThreadEntry")
 	proto.RegisterType((*ExchangeEdgesReply)(nil), "net.pb.ExchangeEdgesReply")
 	proto.RegisterType((*ExchangeEdgesReply_ThreadEdges)(nil), "net.pb.ExchangeEdgesReply.ThreadEdges")
+	proto.RegisterType((*DeleteThreadRequest)(nil), "net.pb.DeleteThreadRequest")
+	proto.RegisterType((*DeleteThreadRequest_Body)(nil), "net.pb.DeleteThreadRequest.Body")
+	proto.RegisterType((*DeleteThreadReply)(nil), "net.pb.DeleteThreadReply")
 }
 
 func init() { proto.RegisterFile("net.proto", fileDescriptor_a5b10ce944527a32) }
 
 var fileDescriptor_a5b10ce944527a32 = []byte{
-	// 912 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0x3b, 0x8f, 0x23, 0x45,
-	0x10, 0x76, 0xcf, 0x8c, 0x1f, 0x5b, 0xf6, 0xee, 0xe2, 0xd6, 0xea, 0x18, 0x86, 0x63, 0x3c, 0x18,
-	0xb8, 0xb3, 0xd0, 0xad, 0x2d, 0x19, 0x08, 0x10, 0x24, 0x98, 0x5d, 0xad, 0x96, 0x5b, 0xa1, 0x55,
-	0xc3, 0x1f, 0xb0, 0x3d, 0xbd, 0x63, 0x4b, 0x3e, 0xb7, 0x99, 0x19, 0x9f, 0xce, 0x12, 0x22, 0x20,
-	0x81, 0x90, 0x80, 0x7f, 0x40, 0x86, 0x48, 0xc9, 0xc9, 0x20, 0x41, 0xba, 0x80, 0x00, 0x39, 0xb0,
-	0xc0, 0x1b, 0x91, 0x22, 0x02, 0x44, 0x84, 0xfa, 0x31, 0x2f, 0xbf, 0x4e, 0x7b, 0xc1, 0x66, 0xae,
-	0xfa, 0xaa, 0x7a, 0xea, 0xab, 0xfa, 0xaa, 0xdb, 0xb0, 0x37, 0xa6, 0x61, 0x73, 0xe2, 0xb3, 0x90,
-	0xe1, 0x82, 0xf8, 0xd9, 0xb3, 0x8e, 0xbd, 0x61, 0x38, 0x98, 0xf6, 0x9a, 0x7d, 0xf6, 0xa8, 0xe5,
-	0x31, 0x8f, 0xb5, 0x04, 0xdc, 0x9b, 0x5e, 0x09, 0x4b, 0x18, 0xe2, 0x97, 0x4c, 0xab, 0xff, 0xa8,
-	0x81, 0x7e, 0xc1, 0x3c, 0x5c, 0x03, 0xed, 0xfc, 0xc4, 0x44, 0x0e, 0x6a, 0x54, 0x3a, 0x87, 0xf3,
-	0x45, 0xad, 0x7c, 0xc9, 0xe1, 0x4b, 0x4a, 0xfd, 0xf3, 0x13, 0xa2, 0x9d, 0x9f, 0xe0, 0xfb, 0x50,
-	0x98, 0x4c, 0x7b, 0x0f, 0xe9, 0xcc, 0xd4, 0x56, 0x83, 0x84, 0x9b, 0x28, 0x18, 0xbf, 0x06, 0xf9,
-	0xae, 0xeb, 0xfa, 0x81, 0xa9, 0x3b, 0x7a, 0xa3, 0xd2, 0xd9, 0x9f, 0x2f, 0x6a, 0x7b, 0x22, 0xee,
-	0x03, 0xd7, 0xf5, 0x89, 0xc4, 0xb0, 0x03, 0xc6, 0x80, 0x76, 0x5d, 0xd3, 0x10, 0x67, 0x55, 0xe6,
-	0x8b, 0x5a, 0x49, 0xc4, 0x7c, 0x38, 0x74, 0x89, 0x40, 0xb0, 0x09, 0xc5, 0x3e, 0x9b, 0x8e, 0x43,
-	0xea, 0x9b, 0x79, 0x07, 0x35, 0x74, 0x12, 0x99, 0xd6, 0x97, 0x08, 0x0a, 0x84, 0xf6, 0x99, 0xef,
-	0x62, 0x1b, 0xc0, 0x17, 0xbf, 0x3e, 0x66, 0x2e, 0x95, 0xd5, 0x93, 0x94, 0x07, 0xdf, 0x85, 0x3d,
-	0xfa, 0x98, 0x8e, 0x43, 0x01, 0x8b, 0xba, 0x49, 0xe2, 0xe0, 0xd9, 0xfc, 0x53, 0xd4, 0x17, 0xb0,
-	0x2e, 0xb3, 0x13, 0x0f, 0xb6, 0xa0, 0xd4, 0x63, 0xee, 0x4c, 0xa0, 0xa2, 0x50, 0x12, 0xdb, 0xf5,
-	0x1f, 0x10, 0x1c, 0x9c, 0xd1, 0xf0, 0x82, 0x79, 0x01, 0xa1, 0x9f, 0x4d, 0x69, 0x10, 0xe2, 0x16,
-	0x18, 0x1c, 0x16, 0xdf, 0x29, 0xb7, 0x5f, 0x6e, 0xca, 0x81, 0x34, 0xb3, 0x51, 0xcd, 0x0e, 0x73,
-	0x67, 0x44, 0x04, 0x5a, 0x7d, 0x30, 0xb8, 0x85, 0x8f, 0xa1, 0x14, 0x0e, 0x7c, 0xda, 0x75, 0xe3,
-	0x09, 0x54, 0xe7, 0x8b, 0xda, 0xbe, 0x68, 0xc8, 0xa7, 0x0a, 0x20, 0x71, 0x08, 0x7e, 0x00, 0x10,
-	0x50, 0xff, 0xf1, 0xb0, 0x4f, 0x93, 0x69, 0x24, 0x1d, 0xe4, 0xa3, 0x48, 0xe1, 0x1f, 0x19, 0x25,
-	0xf4, 0x82, 0x56, 0x6f, 0x41, 0x25, 0xae, 0x63, 0x32, 0x9a, 0xe1, 0x1a, 0x18, 0x23, 0xe6, 0x05,
-	0x26, 0x72, 0xf4, 0x46, 0xb9, 0x5d, 0x8e, 0x6a, 0xbd, 0x60, 0x1e, 0x11, 0x40, 0xfd, 0x1f, 0x04,
-	0x07, 0x97, 0xd3, 0x60, 0xc0, 0x3d, 0xbb, 0xf9, 0x65, 0xa3, 0xd2, 0xfc, 0xbe, 0x47, 0xb7, 0x40,
-	0x10, 0xdf, 0x83, 0x22, 0xcf, 0xe3, 0xa1, 0xfa, 0x86, 0xd0, 0x08, 0xc4, 0xaf, 0x80, 0x3e, 0x62,
-	0x9e, 0x18, 0xe4, 0x0a, 0x63, 0xee, 0x57, 0x7d, 0x3a, 0x80, 0x4a, 0xcc, 0x67, 0x32, 0x9a, 0xd5,
-	0xff, 0xd3, 0xa0, 0x7a, 0x46, 0x43, 0x29, 0xb7, 0x78, 0xd2, 0xed, 0x4c, 0x27, 0xec, 0xd4, 0xa4,
-	0xb3, 0x81, 0x99, 0x66, 0x68, 0xb7, 0xd1, 0x8c, 0xf7, 0xd4, 0x5c, 0x75, 0x31, 0xd7, 0xfb, 0xbb,
-	0x2b, 0xe3, 0xe4, 0x4f, 0xc7, 0xa1, 0x3f, 0x93, 0x33, 0xb7, 0xbe, 0x42, 0x50, 0x8a, 0x5c, 0xf8,
-	0x0d, 0xc8, 0x8f, 0x98, 0xb7, 0xfd, 0x4e, 0x90, 0x28, 0x7e, 0x1d, 0x0a, 0xec, 0xea, 0x2a, 0xa0,
-	0xe1, 0x5a, 0x69, 0x7c, 0x95, 0x15, 0x86, 0x8f, 0x20, 0x3f, 0x1a, 0x3e, 0x1a, 0x86, 0x62, 0x42,
-	0x79, 0x22, 0x8d, 0xf4, 0x8a, 0x1b, 0x99, 0x15, 0x57, 0xc3, 0xf8, 0x19, 0xc1, 0x61, 0xba, 0x72,
-	0x2e, 0xdc, 0xb7, 0x33, 0xc2, 0x75, 0x36, 0x11, 0x9c, 0x8c, 0xd6, 0x98, 0x7d, 0x71, 0x73, 0x62,
-	0x0f, 0xb8, 0xac, 0xc4, 0x89, 0xa6, 0x26, 0xbe, 0x85, 0x53, 0x92, 0x69, 0xca, 0x8f, 0x91, 0x28,
-	0x24, 0x12, 0x97, 0xbe, 0x59, 0x5c, 0xf5, 0xbf, 0x11, 0x54, 0xb9, 0xae, 0x54, 0xda, 0x6e, 0x19,
-	0xad, 0x05, 0xa6, 0x64, 0x94, 0xee, 0x99, 0x9e, 0xbd, 0x16, 0xbf, 0x7e, 0xce, 0x6d, 0x8b, 0xfb,
-	0xa1, 0xed, 0xec, 0xc7, 0x9b, 0x50, 0x90, 0x64, 0x15, 0xc9, 0x4d, 0xed, 0x50, 0x11, 0x6a, 0x7c,
-	0x55, 0x38, 0x4c, 0x53, 0xe1, 0xeb, 0xf4, 0x9d, 0x06, 0x47, 0xa7, 0x4f, 0xfa, 0x83, 0xee, 0xd8,
-	0xa3, 0xa7, 0xae, 0x47, 0xe3, 0x8d, 0x7a, 0x27, 0xd3, 0x8a, 0x57, 0xa3, 0xb3, 0x37, 0xc5, 0xa6,
-	0x97, 0xea, 0xd7, 0x88, 0xf3, 0x19, 0x14, 0x25, 0xa1, 0x48, 0x19, 0xc7, 0xcf, 0x3c, 0xa2, 0x29,
-	0x7b, 0x21, 0x65, 0x12, 0x65, 0x5b, 0x9f, 0x43, 0x39, 0xe5, 0xbf, 0x69, 0x2f, 0x1d, 0x28, 0xf3,
-	0xf7, 0x8d, 0x06, 0x01, 0xff, 0x9c, 0x60, 0x63, 0x90, 0xb4, 0x8b, 0xbf, 0x48, 0xfc, 0x85, 0x91,
-	0xb8, 0x2e, 0xf0, 0xc4, 0xa1, 0x1a, 0xf7, 0x17, 0x02, 0xbc, 0x52, 0x36, 0x97, 0xfe, 0xfb, 0x90,
-	0xa7, 0xdc, 0x52, 0x0c, 0xef, 0x6d, 0x61, 0xc8, 0xe5, 0xaf, 0x28, 0x08, 0x87, 0x4c, 0xb2, 0xbe,
-	0x45, 0x31, 0x33, 0x6e, 0xdf, 0x94, 0xd9, 0x1d, 0x28, 0xd0, 0x27, 0xc3, 0x20, 0x0c, 0x04, 0xa9,
-	0x12, 0x51, 0xd6, 0x2a, 0x63, 0xfd, 0x19, 0x8c, 0x8d, 0x15, 0xc6, 0xed, 0xdf, 0x34, 0x28, 0x7e,
-	0x22, 0xef, 0x2f, 0xfc, 0x2e, 0x14, 0xd5, 0x23, 0x85, 0xef, 0x6c, 0x7e, 0x3d, 0xad, 0xa3, 0x35,
-	0x3f, 0x97, 0x55, 0x8e, 0xa7, 0xaa, 0x7b, 0x3b, 0x49, 0xcd, 0x3e, 0x4c, 0x49, 0x6a, 0xe6, 0x82,
-	0xcf, 0xe1, 0x0e, 0x40, 0x72, 0x7b, 0xe0, 0x97, 0xb6, 0x5e, 0x99, 0xd6, 0x8b, 0x5b, 0x2e, 0x1b,
-	0x79, 0x46, 0x22, 0xf5, 0xe4, 0x8c, 0xb5, 0x4d, 0x4e, 0xce, 0x58, 0xdd, 0x8c, 0x1c, 0x7e, 0x08,
-	0xfb, 0x99, 0x49, 0xe2, 0xbb, 0xbb, 0x24, 0x6c, 0x59, 0xdb, 0xc7, 0x5f, 0xcf, 0x75, 0x9c, 0x7f,
-	0xff, 0xb4, 0xd1, 0x4f, 0x4b, 0x1b, 0xfd, 0xb2, 0xb4, 0xd1, 0xd3, 0xa5, 0x8d, 0xfe, 0x58, 0xda,
-	0xe8, 0x9b, 0x6b, 0x3b, 0xf7, 0xf4, 0xda, 0xce, 0xfd, 0x7e, 0x6d, 0xe7, 0x7a, 0x05, 0xf1, 0xff,
-	0xef, 0xad, 0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0x8d, 0xfc, 0x77, 0x2b, 0x43, 0x0a, 0x00, 0x00,
+	// 1000 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0x3d, 0x6c, 0x1c, 0x45,
+	0x14, 0xf6, 0xec, 0xee, 0xfd, 0xf8, 0xdd, 0xd9, 0xce, 0x4d, 0xac, 0x64, 0xb3, 0x09, 0x7b, 0xcb,
+	0x02, 0x89, 0x85, 0xe2, 0xb3, 0x64, 0x42, 0x81, 0xa0, 0xe1, 0xb0, 0x65, 0x4c, 0x2c, 0x64, 0x0d,
+	0x94, 0x34, 0xbe, 0xdb, 0xf1, 0xde, 0x49, 0xeb, 0x9b, 0x63, 0x77, 0xcf, 0xca, 0x49, 0x54, 0x34,
+	0x50, 0x22, 0x94, 0x9a, 0x86, 0x0e, 0xd1, 0x52, 0xd1, 0x50, 0x50, 0xd0, 0x20, 0xa5, 0x44, 0x16,
+	0xb2, 0xc0, 0xae, 0x68, 0x11, 0x05, 0x25, 0x9a, 0x9f, 0xfd, 0x3b, 0xef, 0x5d, 0x94, 0x48, 0xa4,
+	0xdb, 0xf7, 0xbe, 0x37, 0x6f, 0xe6, 0x7b, 0xef, 0x7b, 0x33, 0x0b, 0xcb, 0x23, 0x1a, 0x77, 0xc6,
+	0x21, 0x8b, 0x19, 0xae, 0x8a, 0xcf, 0x9e, 0xb5, 0xe9, 0x0f, 0xe3, 0xc1, 0xa4, 0xd7, 0xe9, 0xb3,
+	0x93, 0x2d, 0x9f, 0xf9, 0x6c, 0x4b, 0xc0, 0xbd, 0xc9, 0xb1, 0xb0, 0x84, 0x21, 0xbe, 0xe4, 0x32,
+	0xf7, 0x07, 0x0d, 0xf4, 0x03, 0xe6, 0xe3, 0x36, 0x68, 0xfb, 0x3b, 0x26, 0x72, 0xd0, 0x46, 0xb3,
+	0xbb, 0x76, 0x76, 0xde, 0x6e, 0x1c, 0x72, 0xf8, 0x90, 0xd2, 0x70, 0x7f, 0x87, 0x68, 0xfb, 0x3b,
+	0xf8, 0x1e, 0x54, 0xc7, 0x93, 0xde, 0x43, 0x3a, 0x35, 0xb5, 0xd9, 0x20, 0xe1, 0x26, 0x0a, 0xc6,
+	0xaf, 0x40, 0xe5, 0xc8, 0xf3, 0xc2, 0xc8, 0xd4, 0x1d, 0x7d, 0xa3, 0xd9, 0x5d, 0x39, 0x3b, 0x6f,
+	0x2f, 0x8b, 0xb8, 0x77, 0x3d, 0x2f, 0x24, 0x12, 0xc3, 0x0e, 0x18, 0x03, 0x7a, 0xe4, 0x99, 0x86,
+	0xc8, 0xd5, 0x3c, 0x3b, 0x6f, 0xd7, 0x45, 0xcc, 0x7b, 0x43, 0x8f, 0x08, 0x04, 0x9b, 0x50, 0xeb,
+	0xb3, 0xc9, 0x28, 0xa6, 0xa1, 0x59, 0x71, 0xd0, 0x86, 0x4e, 0x12, 0xd3, 0xfa, 0x1c, 0x41, 0x95,
+	0xd0, 0x3e, 0x0b, 0x3d, 0x6c, 0x03, 0x84, 0xe2, 0xeb, 0x43, 0xe6, 0x51, 0x79, 0x7a, 0x92, 0xf3,
+	0xe0, 0x3b, 0xb0, 0x4c, 0x4f, 0xe9, 0x28, 0x16, 0xb0, 0x38, 0x37, 0xc9, 0x1c, 0x7c, 0x35, 0xdf,
+	0x8a, 0x86, 0x02, 0xd6, 0xe5, 0xea, 0xcc, 0x83, 0x2d, 0xa8, 0xf7, 0x98, 0x37, 0x15, 0xa8, 0x38,
+	0x28, 0x49, 0x6d, 0xf7, 0x7b, 0x04, 0xab, 0x7b, 0x34, 0x3e, 0x60, 0x7e, 0x44, 0xe8, 0xa7, 0x13,
+	0x1a, 0xc5, 0x78, 0x0b, 0x0c, 0x0e, 0x8b, 0x7d, 0x1a, 0xdb, 0xb7, 0x3b, 0xb2, 0x21, 0x9d, 0x62,
+	0x54, 0xa7, 0xcb, 0xbc, 0x29, 0x11, 0x81, 0x56, 0x1f, 0x0c, 0x6e, 0xe1, 0x4d, 0xa8, 0xc7, 0x83,
+	0x90, 0x1e, 0x79, 0x69, 0x07, 0x5a, 0x67, 0xe7, 0xed, 0x15, 0x51, 0x90, 0x8f, 0x15, 0x40, 0xd2,
+	0x10, 0x7c, 0x1f, 0x20, 0xa2, 0xe1, 0xe9, 0xb0, 0x4f, 0xb3, 0x6e, 0x64, 0x15, 0xe4, 0xad, 0xc8,
+	0xe1, 0x1f, 0x18, 0x75, 0x74, 0x4d, 0x73, 0xb7, 0xa0, 0x99, 0x9e, 0x63, 0x1c, 0x4c, 0x71, 0x1b,
+	0x8c, 0x80, 0xf9, 0x91, 0x89, 0x1c, 0x7d, 0xa3, 0xb1, 0xdd, 0x48, 0xce, 0x7a, 0xc0, 0x7c, 0x22,
+	0x00, 0xf7, 0x1f, 0x04, 0xab, 0x87, 0x93, 0x68, 0xc0, 0x3d, 0x8b, 0xf9, 0x15, 0xa3, 0xf2, 0xfc,
+	0xbe, 0x43, 0x2f, 0x80, 0x20, 0xbe, 0x0b, 0x35, 0xbe, 0x8e, 0x87, 0xea, 0x25, 0xa1, 0x09, 0x88,
+	0x5f, 0x02, 0x3d, 0x60, 0xbe, 0x68, 0xe4, 0x0c, 0x63, 0xee, 0x57, 0x75, 0x5a, 0x85, 0x66, 0xca,
+	0x67, 0x1c, 0x4c, 0xdd, 0xc7, 0x3a, 0xb4, 0xf6, 0x68, 0x2c, 0xe5, 0x96, 0x76, 0x7a, 0xbb, 0x50,
+	0x09, 0x3b, 0xd7, 0xe9, 0x62, 0x60, 0xbe, 0x18, 0x3f, 0x6b, 0x2f, 0xa2, 0x18, 0x6f, 0xab, 0xbe,
+	0xea, 0xa2, 0xaf, 0xf7, 0x16, 0x9f, 0x8c, 0x93, 0xdf, 0x1d, 0xc5, 0xe1, 0x54, 0xf6, 0x9c, 0x8f,
+	0xdc, 0x29, 0x0d, 0xa3, 0x21, 0x1b, 0x89, 0x2a, 0xad, 0x90, 0xc4, 0xb4, 0xbe, 0x40, 0x50, 0x4f,
+	0x82, 0xf1, 0x6b, 0x50, 0x09, 0x98, 0x3f, 0xff, 0xb6, 0x90, 0x28, 0x7e, 0x15, 0xaa, 0xec, 0xf8,
+	0x38, 0xa2, 0xb1, 0xa9, 0x95, 0x0c, 0xb9, 0xc2, 0xf0, 0x3a, 0x54, 0x82, 0xe1, 0xc9, 0x30, 0x16,
+	0xbd, 0xab, 0x10, 0x69, 0xe4, 0x87, 0xdf, 0x28, 0x0c, 0xbf, 0x6a, 0xd3, 0xef, 0x08, 0xd6, 0xf2,
+	0x9c, 0xb8, 0xa4, 0x1f, 0x14, 0x24, 0xed, 0x94, 0x51, 0x1f, 0x07, 0xb3, 0x9c, 0xad, 0xaf, 0x9f,
+	0x83, 0xd9, 0x7d, 0xae, 0x38, 0x91, 0xd2, 0xd4, 0xc4, 0x66, 0x38, 0xa7, 0xa6, 0x8e, 0xdc, 0x8d,
+	0x24, 0x21, 0x89, 0xee, 0xf4, 0x72, 0xdd, 0x61, 0x0c, 0xc6, 0x09, 0x0b, 0xe5, 0x05, 0x53, 0x27,
+	0xe2, 0xdb, 0xfd, 0x1b, 0x41, 0x8b, 0xcb, 0x50, 0xa5, 0x5a, 0xac, 0xba, 0x2b, 0x81, 0x39, 0xd5,
+	0xe5, 0x0b, 0xa9, 0x17, 0x6f, 0xd1, 0x2f, 0x9f, 0x73, 0x38, 0xd3, 0x1a, 0x69, 0x0b, 0x6b, 0xf4,
+	0x3a, 0x54, 0x65, 0x01, 0x14, 0xf1, 0xb2, 0x12, 0xa9, 0x08, 0xd5, 0xd3, 0x16, 0xac, 0xe5, 0xa9,
+	0xf0, 0xe9, 0xfb, 0x56, 0x83, 0xf5, 0xdd, 0x47, 0xfd, 0xc1, 0xd1, 0xc8, 0xa7, 0xbb, 0x9e, 0x4f,
+	0xd3, 0x01, 0x7c, 0xb3, 0x50, 0x8a, 0x97, 0x93, 0xdc, 0x65, 0xb1, 0xf9, 0x19, 0xfc, 0x35, 0xe1,
+	0xbc, 0x07, 0x35, 0x49, 0x28, 0x91, 0xcb, 0xe6, 0x53, 0x53, 0x74, 0x64, 0x2d, 0xa4, 0x76, 0x92,
+	0xd5, 0xd6, 0x67, 0xd0, 0xc8, 0xf9, 0x9f, 0xb5, 0x96, 0x0e, 0x34, 0xf8, 0x73, 0x48, 0xa3, 0x88,
+	0x6f, 0x27, 0xd8, 0x18, 0x24, 0xef, 0xe2, 0x0f, 0x18, 0x7f, 0x90, 0x24, 0xae, 0x0b, 0x3c, 0x73,
+	0xa8, 0xc2, 0xfd, 0x85, 0x00, 0xcf, 0x1c, 0x9b, 0xcf, 0xc3, 0x3b, 0x50, 0xa1, 0xdc, 0x52, 0x0c,
+	0xef, 0xce, 0x61, 0xc8, 0x67, 0x42, 0x51, 0x10, 0x0e, 0xb9, 0xc8, 0x7a, 0x8c, 0x52, 0x66, 0xdc,
+	0x7e, 0x56, 0x66, 0x37, 0xa0, 0x4a, 0x1f, 0x0d, 0xa3, 0x38, 0x12, 0xa4, 0xea, 0x44, 0x59, 0xb3,
+	0x8c, 0xf5, 0xa7, 0x30, 0x36, 0x66, 0x18, 0xbb, 0x3f, 0x22, 0xb8, 0xbe, 0x43, 0x03, 0x1a, 0x53,
+	0xb9, 0x69, 0x22, 0x88, 0x07, 0x4a, 0x10, 0xc8, 0x41, 0xf9, 0xe1, 0x2f, 0x09, 0xcd, 0x4f, 0xc7,
+	0x35, 0xd0, 0xa3, 0xa1, 0xaf, 0x7e, 0x0c, 0xf8, 0xa7, 0xf5, 0xc9, 0xff, 0x39, 0x14, 0xee, 0x75,
+	0x68, 0x15, 0x4f, 0x34, 0x0e, 0xa6, 0xdb, 0xdf, 0xe8, 0x50, 0xfb, 0x48, 0xde, 0xe0, 0xf8, 0x2d,
+	0xa8, 0xa9, 0x67, 0x1a, 0xdf, 0x28, 0xff, 0x7f, 0xb0, 0xd6, 0xaf, 0xf8, 0xf9, 0xa4, 0x2c, 0xf1,
+	0xa5, 0xea, 0xe5, 0xca, 0x96, 0x16, 0x9f, 0x66, 0x6b, 0xfd, 0x8a, 0x5f, 0x2e, 0xed, 0x02, 0x64,
+	0xb7, 0x24, 0xbe, 0x35, 0xf7, 0xd1, 0xb0, 0x6e, 0xce, 0xb9, 0x54, 0x65, 0x8e, 0x6c, 0x7a, 0xb3,
+	0x1c, 0x57, 0x2e, 0x27, 0xeb, 0x66, 0x19, 0x24, 0x73, 0x3c, 0x84, 0x95, 0x82, 0x38, 0xf1, 0x9d,
+	0x45, 0x53, 0x69, 0x59, 0xf3, 0x15, 0xed, 0x2e, 0xe1, 0xf7, 0xa1, 0x99, 0xaf, 0x35, 0xbe, 0xbd,
+	0x40, 0x13, 0xd6, 0xad, 0x72, 0x50, 0x64, 0xea, 0x3a, 0xff, 0xfe, 0x69, 0xa3, 0x9f, 0x2e, 0x6c,
+	0xf4, 0xcb, 0x85, 0x8d, 0x9e, 0x5c, 0xd8, 0xe8, 0x8f, 0x0b, 0x1b, 0x7d, 0x75, 0x69, 0x2f, 0x3d,
+	0xb9, 0xb4, 0x97, 0x7e, 0xbb, 0xb4, 0x97, 0x7a, 0x55, 0xf1, 0x2f, 0xfd, 0xc6, 0x7f, 0x03, 0x00,
+	0xd9, 0x9c, 0x39, 0x2b, 0x8f, 0x0b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1175,16 +1267,12 @@ const _ = grpc.SupportPackageIsVersion4
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type ServiceClient interface {
-	// GetLogs from a peer.
 	GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (*GetLogsReply, error)
-	// PushLog to a peer.
 	PushLog(ctx context.Context, in *PushLogRequest, opts ...grpc.CallOption) (*PushLogReply, error)
-	// GetRecords from a peer.
 	GetRecords(ctx context.Context, in *GetRecordsRequest, opts ...grpc.CallOption) (*GetRecordsReply, error)
-	// PushRecord to a peer.
 	PushRecord(ctx context.Context, in *PushRecordRequest, opts ...grpc.CallOption) (*PushRecordReply, error)
-	// ExchangeEdges with a peer.
 	ExchangeEdges(ctx context.Context, in *ExchangeEdgesRequest, opts ...grpc.CallOption) (*ExchangeEdgesReply, error)
+	DeleteThread(ctx context.Context, in *DeleteThreadRequest, opts ...grpc.CallOption) (*DeleteThreadReply, error)
 }
 
 type serviceClient struct {
@@ -1240,18 +1328,23 @@ func (c *serviceClient) ExchangeEdges(ctx context.Context, in *ExchangeEdgesRequ
 	return out, nil
 }
 
+func (c *serviceClient) DeleteThread(ctx context.Context, in *DeleteThreadRequest, opts ...grpc.CallOption) (*DeleteThreadReply, error) {
+	out := new(DeleteThreadReply)
+	err := c.cc.Invoke(ctx, "/net.pb.Service/DeleteThread", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServiceServer is the server API for Service service.
 type ServiceServer interface {
-	// GetLogs from a peer.
 	GetLogs(context.Context, *GetLogsRequest) (*GetLogsReply, error)
-	// PushLog to a peer.
 	PushLog(context.Context, *PushLogRequest) (*PushLogReply, error)
-	// GetRecords from a peer.
 	GetRecords(context.Context, *GetRecordsRequest) (*GetRecordsReply, error)
-	// PushRecord to a peer.
 	PushRecord(context.Context, *PushRecordRequest) (*PushRecordReply, error)
-	// ExchangeEdges with a peer.
 	ExchangeEdges(context.Context, *ExchangeEdgesRequest) (*ExchangeEdgesReply, error)
+	DeleteThread(context.Context, *DeleteThreadRequest) (*DeleteThreadReply, error)
 }
 
 // UnimplementedServiceServer can be embedded to have forward compatible implementations.
@@ -1273,6 +1366,9 @@ func (*UnimplementedServiceServer) PushRecord(ctx context.Context, req *PushReco
 func (*UnimplementedServiceServer) ExchangeEdges(ctx context.Context, req *ExchangeEdgesRequest) (*ExchangeEdgesReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExchangeEdges not implemented")
 }
+func (*UnimplementedServiceServer) DeleteThread(ctx context.Context, req *DeleteThreadRequest) (*DeleteThreadReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteThread not implemented")
+}
 
 func RegisterServiceServer(s *grpc.Server, srv ServiceServer) {
 	s.RegisterService(&_Service_serviceDesc, srv)
@@ -1368,6 +1464,24 @@ func _Service_ExchangeEdges_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_DeleteThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).DeleteThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/net.pb.Service/DeleteThread",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).DeleteThread(ctx, req.(*DeleteThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Service_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "net.pb.Service",
 	HandlerType: (*ServiceServer)(nil),
@@ -1392,6 +1506,10 @@ var _Service_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ExchangeEdges",
 			Handler:    _Service_ExchangeEdges_Handler,
 		},
+		{
+			MethodName: "DeleteThread",
+			Handler:    _Service_DeleteThread_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "net.proto",
@@ -1829,6 +1947,11 @@ func (m *GetRecordsRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	_ = i
 	var l int
 	_ = l
+	if m.Version != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.Logs) > 0 {
 		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1984,6 +2107,16 @@ func (m *GetRecordsReply_LogEntry) MarshalToSizedBuffer(dAtA []byte) (int, error
 	_ = i
 	var l int
 	_ = l
+	if m.More {
+		i--
+		if m.More {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.Log != nil {
 		{
 			size, err := m.Log.MarshalToSizedBuffer(dAtA[:i])
@@ -2356,6 +2489,118 @@ func (m *ExchangeEdgesReply_ThreadEdges) MarshalToSizedBuffer(dAtA []byte) (int,
 	return len(dAtA) - i, nil
 }
 
+func (m *DeleteThreadRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteThreadRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteThreadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Sig) > 0 {
+		i -= len(m.Sig)
+		copy(dAtA[i:], m.Sig)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.Sig)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeleteThreadRequest_Body) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteThreadRequest_Body) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteThreadRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LogID != nil {
+		{
+			size := m.LogID.Size()
+			i -= size
+			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeleteThreadReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteThreadReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteThreadReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintNet(dAtA []byte, offset int, v uint64) int {
 	offset -= sovNet(v)
 	base := offset
@@ -2498,6 +2743,7 @@ func NewPopulatedGetRecordsRequest_Body(r randyNet, easy bool) *GetRecordsReques
 			this.Logs[i] = NewPopulatedGetRecordsRequest_Body_LogEntry(r, easy)
 		}
 	}
+	this.Version = uint32(r.Uint32())
 	if !easy && r.Intn(10) != 0 {
 	}
 	return this
@@ -2547,6 +2793,7 @@ func NewPopulatedGetRecordsReply_LogEntry(r randyNet, easy bool) *GetRecordsRepl
 	if r.Intn(5) != 0 {
 		this.Log = NewPopulatedLog(r, easy)
 	}
+	this.More = bool(bool(r.Intn(2) == 0))
 	if !easy && r.Intn(10) != 0 {
 	}
 	return this
@@ -2644,6 +2891,37 @@ func NewPopulatedExchangeEdgesReply_ThreadEdges(r randyNet, easy bool) *Exchange
 	return this
 }
 
+func NewPopulatedDeleteThreadRequest(r randyNet, easy bool) *DeleteThreadRequest {
+	this := &DeleteThreadRequest{}
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedDeleteThreadRequest_Body(r, easy)
+	}
+	v13 := r.Intn(100)
+	this.Sig = make([]byte, v13)
+	for i := 0; i < v13; i++ {
+		this.Sig[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedDeleteThreadRequest_Body(r randyNet, easy bool) *DeleteThreadRequest_Body {
+	this := &DeleteThreadRequest_Body{}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.LogID = NewPopulatedProtoPeerID(r)
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedDeleteThreadReply(r randyNet, easy bool) *DeleteThreadReply {
+	this := &DeleteThreadReply{}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
 type randyNet interface {
 	Float32() float32
 	Float64() float64
@@ -2663,9 +2941,9 @@ func randUTF8RuneNet(r randyNet) rune {
 	return rune(ru + 61)
 }
 func randStringNet(r randyNet) string {
-	v13 := r.Intn(100)
-	tmps := make([]rune, v13)
-	for i := 0; i < v13; i++ {
+	v14 := r.Intn(100)
+	tmps := make([]rune, v14)
+	for i := 0; i < v14; i++ {
 		tmps[i] = randUTF8RuneNet(r)
 	}
 	return string(tmps)
@@ -2687,11 +2965,11 @@ func randFieldNet(dAtA []byte, r randyNet, fieldNumber int, wire int) []byte {
 	switch wire {
 	case 0:
 		dAtA = encodeVarintPopulateNet(dAtA, uint64(key))
-		v14 := r.Int63()
+		v15 := r.Int63()
 		if r.Intn(2) == 0 {
-			v14 *= -1
+			v15 *= -1
 		}
-		dAtA = encodeVarintPopulateNet(dAtA, uint64(v14))
+		dAtA = encodeVarintPopulateNet(dAtA, uint64(v15))
 	case 1:
 		dAtA = encodeVarintPopulateNet(dAtA, uint64(key))
 		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
@@ -2896,6 +3174,9 @@ func (m *GetRecordsRequest_Body) Size() (n int) {
 			n += 1 + l + sovNet(uint64(l))
 		}
 	}
+	if m.Version != 0 {
+		n += 1 + sovNet(uint64(m.Version))
+	}
 	return n
 }
 
@@ -2957,6 +3238,9 @@ func (m *GetRecordsReply_LogEntry) Size() (n int) {
 		l = m.Log.Size()
 		n += 1 + l + sovNet(uint64(l))
 	}
+	if m.More {
+		n += 2
+	}
 	return n
 }
 
@@ -3090,13 +3374,56 @@ func (m *ExchangeEdgesReply_ThreadEdges) Size() (n int) {
 	return n
 }
 
-func sovNet(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozNet(x uint64) (n int) {
-	return sovNet(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (m *Log) Unmarshal(dAtA []byte) error {
+func (m *DeleteThreadRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	l = len(m.Sig)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *DeleteThreadRequest_Body) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ThreadID != nil {
+		l = m.ThreadID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.LogID != nil {
+		l = m.LogID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *DeleteThreadReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func sovNet(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozNet(x uint64) (n int) {
+	return sovNet(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Log) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4327,6 +4654,25 @@ func (m *GetRecordsRequest_Body) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
@@ -4724,6 +5070,26 @@ func (m *GetRecordsReply_LogEntry) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field More", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.More = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
@@ -5576,6 +5942,296 @@ func (m *ExchangeEdgesReply_ThreadEdges) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *DeleteThreadRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteThreadRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteThreadRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Body == nil {
+				m.Body = &DeleteThreadRequest_Body{}
+			}
+			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sig", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sig = append(m.Sig[:0], dAtA[iNdEx:postIndex]...)
+			if m.Sig == nil {
+				m.Sig = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteThreadRequest_Body) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Body: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Body: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoThreadID
+			m.ThreadID = &v
+			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.LogID = &v
+			if err := m.LogID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteThreadReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteThreadReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteThreadReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipNet(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
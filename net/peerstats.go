@@ -0,0 +1,278 @@
+package net
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pstore "github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	core "github.com/textileio/go-threads/core/net"
+)
+
+// DefaultMaxPeerStats is the number of the peers which sync statistics are
+// kept by default.
+const DefaultMaxPeerStats = 1000
+
+// peerStatsKey is the key of the metadata of a peer in the peerstore holding
+// its sync statistics, if they're persisted.
+const peerStatsKey = "threads-sync-stats"
+
+// Classes of the failed pushes of peers which weren't reached.
+const (
+	pushFailureDial    = "dial"
+	pushFailureBackoff = "backoff"
+)
+
+// PeerSyncReporter is implemented by the networks of NewNetwork, which keep
+// the sync statistics of the last peers records were exchanged with.
+type PeerSyncReporter interface {
+	// PeerSyncStats returns the sync statistics of the peer, if they're
+	// kept.
+	PeerSyncStats(p peer.ID) (core.PeerSyncStats, bool)
+	// ListPeerSyncStats returns the sync statistics of the peers they're
+	// kept for, ordered by peer.
+	ListPeerSyncStats() []core.PeerSyncStats
+}
+
+var _ PeerSyncReporter = (*net)(nil)
+
+// peerSync is the entry of the sync statistics of a peer.
+type peerSync struct {
+	LastPushed   time.Time        `json:"pushed"`
+	LastReceived time.Time        `json:"received"`
+	FailedPushes map[string]int64 `json:"failed,omitempty"`
+	Addrs        []string         `json:"addrs,omitempty"`
+}
+
+// peerStats keeps the sync statistics of the peers they were last updated
+// for, which are persisted in the metadata of the peers once evicted and
+// when closed, if the peerstore is set.
+type peerStats struct {
+	lock  sync.Mutex
+	cache *lru.Cache
+	max   int
+	store pstore.PeerMetadata
+	now   func() time.Time
+}
+
+// newPeerStats returns the statistics of up to max peers, or nil if max is
+// negative, which keeps none.
+func newPeerStats(max int, store pstore.PeerMetadata) (*peerStats, error) {
+	if max < 0 {
+		return nil, nil
+	}
+	if max == 0 {
+		max = DefaultMaxPeerStats
+	}
+	s := &peerStats{max: max, store: store, now: time.Now}
+	cache, err := lru.NewWithEvict(max, func(k, v interface{}) {
+		s.persist(k.(peer.ID), v.(*peerSync))
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.cache = cache
+	return s, nil
+}
+
+// load restores the statistics of the peers persisted in their metadata.
+func (s *peerStats) load(peers peer.IDSlice) {
+	if s == nil || s.store == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, p := range peers {
+		if s.cache.Len() == s.max {
+			break
+		}
+		if e := s.restore(p); e != nil {
+			s.cache.ContainsOrAdd(p, e)
+		}
+	}
+}
+
+// restore returns the statistics of the peer persisted in its metadata, if
+// any.
+func (s *peerStats) restore(p peer.ID) *peerSync {
+	if s.store == nil {
+		return nil
+	}
+	v, err := s.store.Get(p, peerStatsKey)
+	if err != nil {
+		return nil
+	}
+	b, ok := v.([]byte)
+	if !ok {
+		return nil
+	}
+	e := &peerSync{}
+	if err := json.Unmarshal(b, e); err != nil {
+		log.Errorf("decoding sync statistics of %s: %v", p, err)
+		return nil
+	}
+	return e
+}
+
+func (s *peerStats) persist(p peer.ID, e *peerSync) {
+	if s.store == nil {
+		return
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		log.Errorf("encoding sync statistics of %s: %v", p, err)
+		return
+	}
+	if err := s.store.Put(p, peerStatsKey, b); err != nil {
+		log.Errorf("persisting sync statistics of %s: %v", p, err)
+	}
+}
+
+// close persists the statistics of the peers.
+func (s *peerStats) close() {
+	if s == nil || s.store == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, k := range s.cache.Keys() {
+		if v, ok := s.cache.Peek(k); ok {
+			s.persist(k.(peer.ID), v.(*peerSync))
+		}
+	}
+}
+
+// update updates the statistics of the peer with f.
+func (s *peerStats) update(p peer.ID, f func(e *peerSync)) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var e *peerSync
+	if v, ok := s.cache.Get(p); ok {
+		e = v.(*peerSync)
+	} else if e = s.restore(p); e == nil {
+		e = &peerSync{}
+	}
+	f(e)
+	s.cache.Add(p, e)
+}
+
+func (s *peerStats) pushed(p peer.ID) {
+	s.update(p, func(e *peerSync) { e.LastPushed = s.now() })
+}
+
+func (s *peerStats) pushFailed(p peer.ID, class string) {
+	s.update(p, func(e *peerSync) {
+		if e.FailedPushes == nil {
+			e.FailedPushes = make(map[string]int64)
+		}
+		e.FailedPushes[class]++
+	})
+}
+
+func (s *peerStats) received(p peer.ID) {
+	s.update(p, func(e *peerSync) { e.LastReceived = s.now() })
+}
+
+func (s *peerStats) dialed(p peer.ID, addrs []ma.Multiaddr) {
+	s.update(p, func(e *peerSync) {
+		e.Addrs = make([]string, len(addrs))
+		for i, a := range addrs {
+			e.Addrs[i] = a.String()
+		}
+	})
+}
+
+// get returns the statistics of the peer, restoring them if they're
+// persisted.
+func (s *peerStats) get(p peer.ID) (core.PeerSyncStats, bool) {
+	if s == nil {
+		return core.PeerSyncStats{}, false
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	v, ok := s.cache.Get(p)
+	if !ok {
+		e := s.restore(p)
+		if e == nil {
+			return core.PeerSyncStats{}, false
+		}
+		s.cache.Add(p, e)
+		v = e
+	}
+	return v.(*peerSync).stats(p), true
+}
+
+func (s *peerStats) list() []core.PeerSyncStats {
+	if s == nil {
+		return nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	keys := s.cache.Keys()
+	list := make([]core.PeerSyncStats, 0, len(keys))
+	for _, k := range keys {
+		if v, ok := s.cache.Peek(k); ok {
+			list = append(list, v.(*peerSync).stats(k.(peer.ID)))
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Peer < list[j].Peer
+	})
+	return list
+}
+
+func (e *peerSync) stats(p peer.ID) core.PeerSyncStats {
+	stats := core.PeerSyncStats{
+		Peer:         p,
+		LastPushed:   e.LastPushed,
+		LastReceived: e.LastReceived,
+		FailedPushes: make(map[string]int64, len(e.FailedPushes)),
+	}
+	for class, n := range e.FailedPushes {
+		stats.FailedPushes[class] = n
+	}
+	for _, a := range e.Addrs {
+		if addr, err := ma.NewMultiaddr(a); err == nil {
+			stats.Addrs = append(stats.Addrs, addr)
+		}
+	}
+	return stats
+}
+
+func (n *net) PeerSyncStats(p peer.ID) (core.PeerSyncStats, bool) {
+	stats, ok := n.peerStats.get(p)
+	if ok {
+		n.addBackoffStats(&stats)
+	}
+	return stats, ok
+}
+
+func (n *net) ListPeerSyncStats() []core.PeerSyncStats {
+	list := n.peerStats.list()
+	for i := range list {
+		n.addBackoffStats(&list[i])
+	}
+	return list
+}
+
+// addBackoffStats adds the dial backoff of the peer to its statistics.
+func (n *net) addBackoffStats(stats *core.PeerSyncStats) {
+	pb, ok := n.backoff.get(stats.Peer)
+	if !ok || !pb.Down {
+		return
+	}
+	stats.Down = true
+	for _, ab := range pb.Addrs {
+		// the peer is dialed again once any address no longer backs off
+		if stats.BackoffUntil.IsZero() || ab.Until.Before(stats.BackoffUntil) {
+			stats.BackoffUntil = ab.Until
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package net
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-peerstore/pstoremem"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+)
+
+func TestPeerStats(t *testing.T) {
+	ps := pstoremem.NewPeerstore()
+	defer ps.Close()
+	now := time.Unix(100, 0)
+	newStats := func() *peerStats {
+		s, err := newPeerStats(2, ps)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.now = func() time.Time { return now }
+		return s
+	}
+	s := newStats()
+	p1, p2, p3 := peer.ID("p1"), peer.ID("p2"), peer.ID("p3")
+	addr := ma.StringCast("/ip4/127.0.0.1/tcp/1")
+
+	s.pushed(p1)
+	s.pushFailed(p1, pushFailureDial)
+	s.pushFailed(p1, pushFailureDial)
+	s.dialed(p1, []ma.Multiaddr{addr})
+	s.received(p2)
+	s.received(p3)
+	if l := s.list(); len(l) != 2 || l[0].Peer != p2 || l[1].Peer != p3 {
+		t.Fatalf("expected the stats of the last updated peers to be kept, got %+v", l)
+	}
+
+	// the evicted stats are persisted, and restored once requested
+	stats, ok := s.get(p1)
+	if !ok {
+		t.Fatal("expected evicted stats to be restored")
+	}
+	if !stats.LastPushed.Equal(now) || !stats.LastReceived.IsZero() {
+		t.Fatalf("unexpected restored stats %+v", stats)
+	}
+	if stats.FailedPushes[pushFailureDial] != 2 || len(stats.Addrs) != 1 || !stats.Addrs[0].Equal(addr) {
+		t.Fatalf("unexpected restored failures and addresses %+v", stats)
+	}
+
+	// the kept stats are persisted once closed
+	s.close()
+	s = newStats()
+	s.load(peer.IDSlice{p2, p3})
+	if l := s.list(); len(l) != 2 || !l[0].LastReceived.Equal(now) || !l[1].LastReceived.Equal(now) {
+		t.Fatalf("expected closed stats to be loaded, got %+v", l)
+	}
+
+	if s, err := newPeerStats(-1, ps); err != nil || s != nil {
+		t.Fatalf("expected negative max to keep no stats, got %v", err)
+	}
+	var none *peerStats
+	none.pushed(p1)
+	if _, ok := none.get(p1); ok {
+		t.Fatal("expected no stats to be kept")
+	}
+}
+
+func TestNet_PeerSyncStats(t *testing.T) {
+	configure := func(c *Config) {
+		c.PubSub = false
+		c.NoNetPulling = true
+		c.DialBackoff = DialBackoff{Initial: time.Minute, Max: time.Hour}
+	}
+	n1 := makeNetwork(t, configure)
+	defer n1.Close()
+	n2 := makeNetwork(t, configure)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	addr2 := n2.Host().Addrs()[0].Encapsulate(ma.StringCast("/p2p/" + n2.Host().ID().String()))
+	if _, err := n1.AddReplicator(ctx, info.ID, addr2); err != nil {
+		t.Fatal(err)
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n1.CreateRecord(ctx, info.ID, body); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; ; i++ {
+		pushed, _ := n1.(PeerSyncReporter).PeerSyncStats(n2.Host().ID())
+		received, _ := n2.(PeerSyncReporter).PeerSyncStats(n1.Host().ID())
+		if !pushed.LastPushed.IsZero() && !received.LastReceived.IsZero() {
+			if len(pushed.FailedPushes) != 0 || len(pushed.Addrs) == 0 {
+				t.Fatalf("unexpected stats of replicator %+v", pushed)
+			}
+			break
+		}
+		if i == 100 {
+			t.Fatal("expected record to be pushed to replicator")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// pushes to the unreachable replicator fail, then back off
+	if err := n2.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.Host().Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n1.CreateRecord(ctx, info.ID, body); err != nil {
+		t.Fatal(err)
+	}
+	var stats []core.PeerSyncStats
+	for i := 0; ; i++ {
+		stats = n1.(PeerSyncReporter).ListPeerSyncStats()
+		if len(stats) != 1 || stats[0].Peer != n2.Host().ID() {
+			t.Fatalf("expected stats of replicator only, got %+v", stats)
+		}
+		if len(stats[0].FailedPushes) != 0 && stats[0].Down {
+			break
+		}
+		if i == 100 {
+			t.Fatalf("expected replicator to back off, got %+v", stats[0])
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if !stats[0].BackoffUntil.After(time.Now()) {
+		t.Fatalf("expected replicator to back off until later, got %+v", stats[0])
+	}
+	if _, err := n1.CreateRecord(ctx, info.ID, body); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; ; i++ {
+		stats, _ := n1.(PeerSyncReporter).PeerSyncStats(n2.Host().ID())
+		if stats.FailedPushes[pushFailureBackoff] == 1 {
+			break
+		}
+		if i == 100 {
+			t.Fatalf("expected push to back off, got %+v", stats)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
@@ -0,0 +1,145 @@
+package net
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/libp2p/go-libp2p-core/connmgr"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	lstore "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+const (
+	// threadPeerTag tags and protects the peers of the hosted threads in
+	// the connection manager.
+	threadPeerTag = "threads"
+
+	// threadPeerTagWeight is the weight of the tag of a peer for each thread
+	// shared with it, up to maxThreadPeerTagWeight.
+	threadPeerTagWeight    = 5
+	maxThreadPeerTagWeight = 100
+)
+
+// DefaultMaxProtectedPeers is the number of peers of the hosted threads
+// protected from the connection manager.
+const DefaultMaxProtectedPeers = 50
+
+// peerProtector tags the peers of the hosted threads in the connection
+// manager by the number of threads shared with them, and protects the max
+// of them sharing the most threads, so that their connections aren't pruned.
+type peerProtector struct {
+	lock      sync.Mutex
+	cm        connmgr.ConnManager
+	max       int
+	threads   map[thread.ID][]peer.ID
+	shared    map[peer.ID]int
+	protected map[peer.ID]struct{}
+}
+
+func newPeerProtector(cm connmgr.ConnManager, max int) *peerProtector {
+	if max == 0 {
+		max = DefaultMaxProtectedPeers
+	} else if max < 0 {
+		max = 0
+	}
+	return &peerProtector{
+		cm:        cm,
+		max:       max,
+		threads:   make(map[thread.ID][]peer.ID),
+		shared:    make(map[peer.ID]int),
+		protected: make(map[peer.ID]struct{}),
+	}
+}
+
+// update replaces the peers of the thread, which has none once deleted.
+// It's not thread-safe.
+func (pp *peerProtector) update(id thread.ID, peers []peer.ID) {
+	for _, p := range pp.threads[id] {
+		pp.shared[p]--
+	}
+	for _, p := range peers {
+		pp.shared[p]++
+	}
+	if len(peers) > 0 {
+		pp.threads[id] = peers
+	} else {
+		delete(pp.threads, id)
+	}
+
+	ranked := make([]peer.ID, 0, len(pp.shared))
+	for p, count := range pp.shared {
+		if count == 0 {
+			delete(pp.shared, p)
+			pp.cm.UntagPeer(p, threadPeerTag)
+			continue
+		}
+		weight := count * threadPeerTagWeight
+		if weight > maxThreadPeerTagWeight {
+			weight = maxThreadPeerTagWeight
+		}
+		pp.cm.TagPeer(p, threadPeerTag, weight)
+		ranked = append(ranked, p)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ci, cj := pp.shared[ranked[i]], pp.shared[ranked[j]]; ci != cj {
+			return ci > cj
+		}
+		return ranked[i] < ranked[j]
+	})
+	if len(ranked) > pp.max {
+		ranked = ranked[:pp.max]
+	}
+
+	protected := make(map[peer.ID]struct{}, len(ranked))
+	for _, p := range ranked {
+		protected[p] = struct{}{}
+		if _, ok := pp.protected[p]; !ok {
+			pp.cm.Protect(p, threadPeerTag)
+		}
+	}
+	for p := range pp.protected {
+		if _, ok := protected[p]; !ok {
+			pp.cm.Unprotect(p, threadPeerTag)
+		}
+	}
+	pp.protected = protected
+}
+
+// protectThreadPeers updates the peers of the thread in the connection
+// manager with the peers of the addresses of its logs, or with none if it
+// isn't hosted.
+func (n *net) protectThreadPeers(id thread.ID) {
+	n.protector.lock.Lock()
+	defer n.protector.lock.Unlock()
+
+	var addrs []ma.Multiaddr
+	info, err := n.store.GetThread(id)
+	if err != nil && !errors.Is(err, lstore.ErrThreadNotFound) {
+		log.Errorf("getting thread %s to protect its peers: %v", id, err)
+		return
+	}
+	for _, lg := range info.Logs {
+		addrs = append(addrs, lg.Addrs...)
+	}
+	peers, err := n.uniquePeers(addrs)
+	if err != nil {
+		log.Errorf("getting peers of thread %s to protect: %v", id, err)
+		return
+	}
+	n.protector.update(id, peers)
+}
+
+// protectPeers protects the peers of all the hosted threads.
+func (n *net) protectPeers() {
+	ids, err := n.store.Threads()
+	if err != nil {
+		log.Errorf("listing threads to protect their peers: %v", err)
+		return
+	}
+	for _, id := range ids {
+		n.protectThreadPeers(id)
+	}
+}
@@ -0,0 +1,98 @@
+package net
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	connmgr "github.com/libp2p/go-libp2p-connmgr"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestPeerProtector(t *testing.T) {
+	cm := connmgr.NewConnManager(0, 0, time.Minute)
+	pp := newPeerProtector(cm, 2)
+	a, b, c := peer.ID("a"), peer.ID("b"), peer.ID("c")
+	t1, t2, t3 := thread.NewIDV1(thread.Raw, 32), thread.NewIDV1(thread.Raw, 32), thread.NewIDV1(thread.Raw, 32)
+
+	weight := func(p peer.ID) int {
+		if info := cm.GetTagInfo(p); info != nil {
+			return info.Tags[threadPeerTag]
+		}
+		return 0
+	}
+	protected := func(ps ...peer.ID) {
+		t.Helper()
+		want := make(map[peer.ID]bool)
+		for _, p := range ps {
+			want[p] = true
+		}
+		for _, p := range []peer.ID{a, b, c} {
+			if got := cm.IsProtected(p, threadPeerTag); got != want[p] {
+				t.Fatalf("expected %s protected to be %v, got %v", p, want[p], got)
+			}
+		}
+	}
+
+	pp.update(t1, []peer.ID{a, b, c})
+	pp.update(t2, []peer.ID{a, b})
+	pp.update(t3, []peer.ID{a})
+	protected(a, b)
+	if wa, wb, wc := weight(a), weight(b), weight(c); wa != 3*threadPeerTagWeight || wb != 2*threadPeerTagWeight || wc != threadPeerTagWeight {
+		t.Fatalf("expected tags to scale with shared threads, got %d, %d, %d", wa, wb, wc)
+	}
+
+	pp.update(t2, []peer.ID{a, c})
+	pp.update(t3, []peer.ID{c})
+	protected(a, c)
+
+	pp.update(t1, nil)
+	pp.update(t2, nil)
+	protected(c)
+	if weight(a) != 0 || weight(b) != 0 {
+		t.Fatal("expected peers without shared threads to be untagged")
+	}
+
+	for i := 0; i < 2*maxThreadPeerTagWeight/threadPeerTagWeight; i++ {
+		pp.update(thread.NewIDV1(thread.Raw, 32), []peer.ID{c})
+	}
+	if weight(c) != maxThreadPeerTagWeight {
+		t.Fatalf("expected tag weight to be capped, got %d", weight(c))
+	}
+}
+
+func TestNet_ProtectThreadPeers(t *testing.T) {
+	n1 := makeNetwork(t)
+	defer n1.Close()
+	n2 := makeNetwork(t)
+	defer n2.Close()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	raddr, err := ma.NewMultiaddr("/p2p/" + n2.Host().ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n1.AddReplicator(ctx, info.ID, raddr); err != nil {
+		t.Fatal(err)
+	}
+	pp := n1.(*net).protector
+	shared := func() int {
+		pp.lock.Lock()
+		defer pp.lock.Unlock()
+		return pp.shared[n2.Host().ID()]
+	}
+	if shared() != 1 {
+		t.Fatal("expected replicator to be protected")
+	}
+	if err := n1.DeleteThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	if shared() != 0 {
+		t.Fatal("expected replicator of deleted thread to be unprotected")
+	}
+}
@@ -0,0 +1,69 @@
+package net
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+const (
+	// DefaultPubSubShards is the default number of topics of the sharded
+	// pubsub strategy.
+	DefaultPubSubShards = 64
+
+	// pubSubStrategyKey is the key of the metadata of a thread holding its
+	// pubsub strategy, if it isn't the one of the network.
+	pubSubStrategyKey = "pubsub"
+)
+
+// setPubSubStrategy keeps the pubsub strategy of the thread in its metadata,
+// unless it's the one of the network.
+func (n *net) setPubSubStrategy(id thread.ID, strategy core.PubSubStrategy) error {
+	if !strategy.Valid() {
+		return fmt.Errorf("unknown pubsub strategy %q", strategy)
+	}
+	if strategy == core.PubSubDefault {
+		return nil
+	}
+	return n.store.PutString(id, pubSubStrategyKey, string(strategy))
+}
+
+// pubSubStrategy returns the pubsub strategy of the thread, which is the one
+// of its metadata, or of the network.
+func (n *net) pubSubStrategy(id thread.ID) core.PubSubStrategy {
+	v, err := n.store.GetString(id, pubSubStrategyKey)
+	if err != nil {
+		log.Errorf("getting pubsub strategy of thread %s: %v", id, err)
+	} else if v != nil {
+		if s := core.PubSubStrategy(*v); s.Valid() && s != core.PubSubDefault {
+			return s
+		}
+		log.Warnf("unknown pubsub strategy %q of thread %s", *v, id)
+	}
+	if n.conf.PubSubStrategy == core.PubSubDefault {
+		return core.PubSubPerThread
+	}
+	return n.conf.PubSubStrategy
+}
+
+// pubSubTopic returns the name of the topic the records of the thread are
+// announced on, which is empty if they aren't.
+func (n *net) pubSubTopic(id thread.ID) string {
+	switch n.pubSubStrategy(id) {
+	case core.PubSubSharded:
+		shards := n.conf.PubSubShards
+		if shards <= 0 {
+			shards = DefaultPubSubShards
+		}
+		h := fnv.New32a()
+		_, _ = h.Write(id.Bytes())
+		// topics of networks with other numbers of shards are distinct
+		return fmt.Sprintf("/threads/shard/%d/%d", shards, h.Sum32()%uint32(shards))
+	case core.PubSubDisabled:
+		return ""
+	default:
+		return id.String()
+	}
+}
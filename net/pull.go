@@ -0,0 +1,309 @@
+package net
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/net/queue"
+)
+
+// Threads are pulled in the background at NetPullingInterval, unless an
+// interval of their own is set with SetPullInterval. Threads which pulls
+// repeatedly get no new records back off, doubling their interval with each
+// of them up to NetPullingMaxInterval, until a record is added to them.
+// Threads which background pulls are paused with SetPullPaused are still
+// pulled by PullThread.
+
+const (
+	// pullIntervalKey is the key of the metadata of a thread holding the
+	// interval in nanoseconds it's pulled at in the background, if it's
+	// set for the thread.
+	pullIntervalKey = "pull-interval"
+
+	// pullPausedKey is the key of the metadata of a thread telling whether
+	// its background pulls are paused.
+	pullPausedKey = "pull-paused"
+
+	// pullScheduleTick is the interval threads due to be pulled are
+	// checked at.
+	pullScheduleTick = 250 * time.Millisecond
+)
+
+// pullState is the schedule of the background pulls of a thread.
+type pullState struct {
+	id   thread.ID
+	next time.Time
+	// idle is the number of the last pulls which got no new records.
+	idle int
+	// pulled tells whether the thread was pulled, and updated is the time
+	// it was last updated at when it was last pulled, if ever.
+	pulled  bool
+	updated *int64
+	// index is the index of the state in the queue, or -1 if it isn't due.
+	index int
+}
+
+// pullQueue implements heap.Interface, ordering the threads by the time
+// they're due to be pulled.
+type pullQueue []*pullState
+
+func (q pullQueue) Len() int { return len(q) }
+
+func (q pullQueue) Less(i, j int) bool { return q[i].next.Before(q[j].next) }
+
+func (q pullQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *pullQueue) Push(x interface{}) {
+	s := x.(*pullState)
+	s.index = len(*q)
+	*q = append(*q, s)
+}
+
+func (q *pullQueue) Pop() interface{} {
+	old := *q
+	s := old[len(old)-1]
+	old[len(old)-1] = nil
+	s.index = -1
+	*q = old[:len(old)-1]
+	return s
+}
+
+// pullSchedule keeps the time the threads are due to be pulled at in the
+// background.
+type pullSchedule struct {
+	lock    sync.Mutex
+	threads map[thread.ID]*pullState
+	due     pullQueue
+}
+
+func newPullSchedule() *pullSchedule {
+	return &pullSchedule{threads: make(map[thread.ID]*pullState)}
+}
+
+// sync schedules the threads which aren't, spreading their pulls over the
+// duration from now, and drops those which aren't listed anymore.
+func (s *pullSchedule) sync(ts []thread.ID, now time.Time, spread time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	listed := make(map[thread.ID]struct{}, len(ts))
+	var unscheduled []thread.ID
+	for _, id := range ts {
+		listed[id] = struct{}{}
+		if st, ok := s.threads[id]; !ok || st.index < 0 {
+			unscheduled = append(unscheduled, id)
+		}
+	}
+	for id, st := range s.threads {
+		if _, ok := listed[id]; !ok {
+			if st.index >= 0 {
+				heap.Remove(&s.due, st.index)
+			}
+			delete(s.threads, id)
+		}
+	}
+	for i, id := range unscheduled {
+		st, ok := s.threads[id]
+		if !ok {
+			st = &pullState{id: id, index: -1}
+			s.threads[id] = st
+		}
+		st.next = now.Add(spread * time.Duration(i) / time.Duration(len(unscheduled)))
+		heap.Push(&s.due, st)
+	}
+}
+
+// popDue returns the threads due to be pulled at now, which aren't
+// scheduled again until they're pulled or skipped.
+func (s *pullSchedule) popDue(now time.Time) []thread.ID {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var ids []thread.ID
+	for len(s.due) > 0 && !s.due[0].next.After(now) {
+		ids = append(ids, heap.Pop(&s.due).(*pullState).id)
+	}
+	return ids
+}
+
+// skip schedules the pull of the thread at next.
+func (s *pullSchedule) skip(id thread.ID, next time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if st, ok := s.threads[id]; ok {
+		s.schedule(st, next)
+	}
+}
+
+// pulled schedules the next pull of the thread pulled at now, which was
+// last updated at updated, if ever. The interval is doubled for each of the
+// last pulls the thread wasn't updated since, up to max.
+func (s *pullSchedule) pulled(id thread.ID, now time.Time, updated *int64, interval, max time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st, ok := s.threads[id]
+	if !ok {
+		return
+	}
+	if st.pulled && sameTime(st.updated, updated) {
+		st.idle++
+	} else {
+		st.idle = 0
+	}
+	st.pulled, st.updated = true, nil
+	if updated != nil {
+		v := *updated
+		st.updated = &v
+	}
+	wait := interval
+	if max > interval {
+		for i := 0; i < st.idle && wait < max; i++ {
+			wait *= 2
+		}
+		if wait > max {
+			wait = max
+		}
+	}
+	s.schedule(st, now.Add(wait))
+}
+
+// reset clears the backoff of the thread, pulling it by next at the latest.
+func (s *pullSchedule) reset(id thread.ID, next time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st, ok := s.threads[id]
+	if !ok {
+		return
+	}
+	st.idle = 0
+	if st.index < 0 || next.Before(st.next) {
+		s.schedule(st, next)
+	}
+}
+
+// schedule (re)schedules the pull of the thread at next. It's not
+// thread-safe.
+func (s *pullSchedule) schedule(st *pullState, next time.Time) {
+	st.next = next
+	if st.index >= 0 {
+		heap.Fix(&s.due, st.index)
+	} else {
+		heap.Push(&s.due, st)
+	}
+}
+
+func sameTime(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (n *net) SetPullInterval(_ context.Context, id thread.ID, interval time.Duration, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return err
+	}
+	if _, err := n.store.GetThread(id); err != nil {
+		return err
+	}
+	if interval < 0 {
+		interval = 0
+	}
+	if err := n.store.PutInt64(id, pullIntervalKey, int64(interval)); err != nil {
+		return err
+	}
+	if interval == 0 {
+		interval = n.conf.NetPullingInterval
+	}
+	n.pulls.reset(id, time.Now().Add(interval))
+	return nil
+}
+
+func (n *net) SetPullPaused(_ context.Context, id thread.ID, paused bool, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return err
+	}
+	if _, err := n.store.GetThread(id); err != nil {
+		return err
+	}
+	if err := n.store.PutBool(id, pullPausedKey, paused); err != nil {
+		return err
+	}
+	if !paused {
+		n.pulls.reset(id, time.Now())
+	}
+	return nil
+}
+
+// pullSettings returns the interval the thread is pulled at in the
+// background, which is zero unless it's set for the thread, and whether
+// its background pulls are paused.
+func (n *net) pullSettings(id thread.ID) (interval time.Duration, paused bool, err error) {
+	v, err := n.store.GetInt64(id, pullIntervalKey)
+	if err != nil {
+		return
+	}
+	if v != nil {
+		interval = time.Duration(*v)
+	}
+	p, err := n.store.GetBool(id, pullPausedKey)
+	if err != nil {
+		return
+	}
+	return interval, p != nil && *p, nil
+}
+
+// pullPaused tells whether the background pulls of the thread are paused.
+func (n *net) pullPaused(id thread.ID) bool {
+	_, paused, err := n.pullSettings(id)
+	if err != nil {
+		log.Errorf("getting pull settings of thread %s: %v", id, err)
+		return false
+	}
+	return paused
+}
+
+// pullDue adds the peers of the thread due to be pulled at now to the
+// exchange of edges, and schedules its next pull.
+func (n *net) pullDue(compressor queue.ThreadPacker, id thread.ID, now time.Time) {
+	interval, paused, err := n.pullSettings(id)
+	if err != nil {
+		log.Errorf("getting pull settings of thread %s: %v", id, err)
+		return
+	}
+	if interval == 0 {
+		interval = n.conf.NetPullingInterval
+	}
+	if paused {
+		n.pulls.skip(id, now.Add(interval))
+		return
+	}
+	updated, err := n.store.GetInt64(id, lastUpdatedKey)
+	if err != nil {
+		log.Errorf("getting last update of thread %s: %v", id, err)
+		return
+	}
+	_, peers, err := n.threadOffsets(id)
+	if err != nil {
+		log.Errorf("error getting thread info %s: %s", id, err)
+		return
+	}
+	for _, pid := range peers {
+		compressor.Add(pid, id)
+	}
+	n.pulls.pulled(id, now, updated, interval, n.conf.NetPullingMaxInterval)
+}
@@ -0,0 +1,125 @@
+package net
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/net/queue"
+)
+
+func TestPullSchedule(t *testing.T) {
+	t1, t2, t3 := thread.NewIDV1(thread.Raw, 32), thread.NewIDV1(thread.Raw, 32), thread.NewIDV1(thread.Raw, 32)
+	now := time.Unix(0, 0)
+	s := newPullSchedule()
+
+	// the pulls are spread over the duration
+	s.sync([]thread.ID{t1, t2, t3}, now, 3*time.Second)
+	if due := s.popDue(now); len(due) != 1 || due[0] != t1 {
+		t.Fatalf("expected first thread due at once, got %v", due)
+	}
+	if due := s.popDue(now.Add(2 * time.Second)); len(due) != 2 {
+		t.Fatalf("expected all threads due after spread, got %v", due)
+	}
+	if due := s.popDue(now.Add(time.Hour)); len(due) != 0 {
+		t.Fatalf("expected popped threads not to be due until pulled, got %v", due)
+	}
+
+	// the threads not listed anymore are dropped
+	s.sync([]thread.ID{t1}, now, 0)
+	if len(s.threads) != 1 || s.threads[t1] == nil {
+		t.Fatalf("expected unlisted threads to be dropped, got %v", s.threads)
+	}
+
+	// idle pulls back off up to the ceiling
+	updated := int64(1)
+	interval, max := 10*time.Second, 45*time.Second
+	for i, wait := range []time.Duration{10, 20, 40, 45, 45} {
+		s.popDue(now.Add(time.Hour))
+		s.pulled(t1, now, &updated, interval, max)
+		if got := s.threads[t1].next.Sub(now); got != wait*time.Second {
+			t.Fatalf("pull %d: expected next pull in %s, got %s", i, wait*time.Second, got)
+		}
+	}
+
+	// an update clears the backoff
+	updated = 2
+	s.popDue(now.Add(time.Hour))
+	s.pulled(t1, now, &updated, interval, max)
+	if got := s.threads[t1].next.Sub(now); got != interval {
+		t.Fatalf("expected updated thread to be pulled at its interval, got %s", got)
+	}
+
+	// a ceiling below the interval doesn't back off
+	for i := 0; i < 3; i++ {
+		s.popDue(now.Add(time.Hour))
+		s.pulled(t1, now, &updated, interval, 0)
+	}
+	if got := s.threads[t1].next.Sub(now); got != interval {
+		t.Fatalf("expected no backoff without a ceiling, got %s", got)
+	}
+
+	// reset pulls the thread by next at the latest
+	s.reset(t1, now.Add(time.Second))
+	if due := s.popDue(now.Add(time.Second)); len(due) != 1 || s.threads[t1].idle != 0 {
+		t.Fatalf("expected reset thread to be due, got %v", due)
+	}
+	s.skip(t1, now.Add(time.Minute))
+	if due := s.popDue(now.Add(time.Minute)); len(due) != 1 {
+		t.Fatalf("expected skipped thread to be due again, got %v", due)
+	}
+}
+
+type nopPacker struct{}
+
+func (nopPacker) Add(peer.ID, thread.ID) {}
+
+func (nopPacker) Run() <-chan queue.ThreadPack { return nil }
+
+func TestNet_PullSettings(t *testing.T) {
+	n := makeNetwork(t, func(c *Config) { c.NoNetPulling = true })
+	defer n.Close()
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+
+	if err := n.SetPullInterval(ctx, info.ID, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.SetPullPaused(ctx, info.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	summary, err := n.GetThreadSummary(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.PullInterval != time.Hour || !summary.PullPaused {
+		t.Fatalf("expected pull settings in summary, got %+v", summary)
+	}
+
+	// paused threads are skipped by the background pulls
+	nn := n.(*net)
+	now := time.Now()
+	nn.pulls.sync([]thread.ID{info.ID}, now, 0)
+	for _, id := range nn.pulls.popDue(now) {
+		nn.pullDue(nopPacker{}, id, now)
+	}
+	st := nn.pulls.threads[info.ID]
+	if st.pulled || st.next.Sub(now) != time.Hour {
+		t.Fatalf("expected paused thread to be skipped for its interval, got %+v", st)
+	}
+
+	// unpausing pulls the thread at once
+	if err := n.SetPullPaused(ctx, info.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	due := nn.pulls.popDue(time.Now())
+	if len(due) != 1 {
+		t.Fatalf("expected unpaused thread to be due, got %v", due)
+	}
+	nn.pullDue(nopPacker{}, due[0], now)
+	if st := nn.pulls.threads[info.ID]; !st.pulled || st.next.Sub(now) != time.Hour {
+		t.Fatalf("expected thread to be pulled at its interval, got %+v", st)
+	}
+}
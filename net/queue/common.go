@@ -19,6 +19,9 @@ type (
 
 		// Schedule call to be invoked later.
 		Schedule(p peer.ID, t thread.ID, priority int, c PeerCall) bool
+
+		// Size returns the number of calls scheduled.
+		Size() int
 	}
 )
 
@@ -26,56 +26,83 @@ type peerQueue struct {
 	sync.Mutex
 }
 
-// Simple FIFO-queue with O(1)-operations.
+// Simple FIFO-queue with O(1)-operations for calls of the same priority.
+// Calls of a higher priority are queued ahead of those of lower ones.
 func newPeerQueue() *peerQueue {
 	return &peerQueue{index: make(map[thread.ID]*linkedOperation)}
 }
 
-// Add new call to the queue or replace existing one with lower priority.
+// Add new call to the queue or replace existing one with lower priority,
+// moving it ahead of the calls of lower priority.
 func (q *peerQueue) Add(tid thread.ID, call PeerCall, priority int) bool {
 	op, exist := q.index[tid]
 	if !exist {
-		// append new entry at the end
 		op = &linkedOperation{
 			tid:      tid,
 			call:     call,
 			priority: priority,
 			created:  time.Now().Unix(),
 		}
-		if q.last == nil {
-			// empty queue
-			q.first = op
-			q.last = op
-		} else {
-			q.last.next = op
-			op.prev = q.last
-			q.last = op
-		}
+		q.insert(op)
 		q.index[tid] = op
 		return true
 	}
 
 	if op.priority < priority {
-		// just replace the call
+		// replace the call and requeue it by its new priority
 		op.call = call
+		op.priority = priority
+		q.unlink(op)
+		q.insert(op)
 	}
 	return false
 }
 
+// insert links the operation after the last one of the same or higher
+// priority, which is the end of the queue unless higher-priority calls
+// were added.
+func (q *peerQueue) insert(op *linkedOperation) {
+	prev := q.last
+	for prev != nil && prev.priority < op.priority {
+		prev = prev.prev
+	}
+	op.prev = prev
+	if prev == nil {
+		op.next = q.first
+		q.first = op
+	} else {
+		op.next = prev.next
+		prev.next = op
+	}
+	if op.next == nil {
+		q.last = op
+	} else {
+		op.next.prev = op
+	}
+}
+
+// unlink removes the operation from the queue, keeping its index.
+func (q *peerQueue) unlink(op *linkedOperation) {
+	if op.prev == nil {
+		q.first = op.next
+	} else {
+		op.prev.next = op.next
+	}
+	if op.next == nil {
+		q.last = op.prev
+	} else {
+		op.next.prev = op.prev
+	}
+	op.prev, op.next = nil, nil
+}
+
 // Return previously added calls in FIFO order.
 func (q *peerQueue) Pop() (PeerCall, thread.ID, int64, bool) {
 	if q.first == nil {
 		return nil, thread.Undef, 0, false
 	}
 	op := q.first
-
-	q.first = op.next
-	if q.first != nil {
-		q.first.prev = nil
-	} else {
-		q.last = nil
-	}
-
+	q.unlink(op)
 	delete(q.index, op.tid)
 	return op.call, op.tid, op.created, true
 }
@@ -86,27 +113,7 @@ func (q *peerQueue) Remove(tid thread.ID) bool {
 	if !exist {
 		return false
 	}
-
-	switch {
-	case q.last == op && q.first == op:
-		// single operation - empty the queue
-		q.first = nil
-		q.last = nil
-	case q.first == op:
-		// first operation
-		next := op.next
-		next.prev = nil
-		q.first = next
-	case q.last == op:
-		// last operation
-		prev := op.prev
-		prev.next = nil
-		q.last = prev
-	default:
-		prev, next := op.prev, op.next
-		prev.next = next
-		next.prev = prev
-	}
+	q.unlink(op)
 	delete(q.index, tid)
 	return true
 }
@@ -200,6 +207,23 @@ func (q *ffQueue) Call(
 	return err
 }
 
+func (q *ffQueue) Size() int {
+	q.mx.Lock()
+	pqs := make([]*peerQueue, 0, len(q.peers))
+	for _, pq := range q.peers {
+		pqs = append(pqs, pq)
+	}
+	q.mx.Unlock()
+
+	var size int
+	for _, pq := range pqs {
+		pq.Lock()
+		size += pq.Size()
+		pq.Unlock()
+	}
+	return size
+}
+
 func (q *ffQueue) pollQueue(pid peer.ID, pq *peerQueue) {
 	var tick = time.NewTicker(q.poll)
 
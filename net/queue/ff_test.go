@@ -167,3 +167,38 @@ func TestOperationQueue_Pop(t *testing.T) {
 	checkedPop(false, thread.Undef)
 	checkedPop(false, thread.Undef)
 }
+
+func TestOperationQueue_Priority(t *testing.T) {
+	var (
+		q  = newPeerQueue()
+		t1 = thread.NewIDV1(thread.Raw, 32)
+		t2 = thread.NewIDV1(thread.Raw, 32)
+		t3 = thread.NewIDV1(thread.Raw, 32)
+		t4 = thread.NewIDV1(thread.Raw, 32)
+
+		checkedPop = func(tid thread.ID) {
+			if _, stid, _, ok := q.Pop(); !ok {
+				t.Errorf("expected call for %s, but queue is empty", tid)
+			} else if stid != tid {
+				t.Errorf("expected call for %s, but get call for %s", tid, stid)
+			}
+		}
+	)
+
+	// higher-priority calls are queued ahead, in FIFO order among them
+	q.Add(t1, nil, 1)
+	q.Add(t2, nil, 3)
+	q.Add(t3, nil, 1)
+	q.Add(t4, nil, 3)
+
+	// raising the priority of a call requeues it
+	q.Add(t3, nil, 5)
+
+	checkedPop(t3)
+	checkedPop(t2)
+	checkedPop(t4)
+	checkedPop(t1)
+	if _, _, _, ok := q.Pop(); ok || q.Size() != 0 {
+		t.Error("unexpected operations in the queue")
+	}
+}
@@ -0,0 +1,244 @@
+package net
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gogo/status"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/core/thread"
+	"google.golang.org/grpc/codes"
+)
+
+// RateLimit limits the requests of a peer with a token bucket, which holds up
+// to Burst requests and refills at Rate requests per second. A zero Rate
+// doesn't limit requests.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+func (l RateLimit) validate(name string) error {
+	if l.Rate < 0 {
+		return fmt.Errorf("%s rate must not be negative", name)
+	}
+	if l.Rate > 0 && l.Burst <= 0 {
+		return fmt.Errorf("%s burst must be greater than zero", name)
+	}
+	return nil
+}
+
+// RateLimits are the limits of the inbound requests of each peer, by RPC.
+type RateLimits struct {
+	PushRecord RateLimit
+	GetRecords RateLimit
+	// GetRecordsReplicator limits the get records requests of the peers
+	// which replicate the thread requested, so that they can catch up on a
+	// long history.
+	GetRecordsReplicator RateLimit
+}
+
+// Validate checks the limits.
+func (l RateLimits) Validate() error {
+	if err := l.PushRecord.validate("PushRecord"); err != nil {
+		return err
+	}
+	if err := l.GetRecords.validate("GetRecords"); err != nil {
+		return err
+	}
+	return l.GetRecordsReplicator.validate("GetRecordsReplicator")
+}
+
+// DefaultRateLimits are limits which let well-behaved peers push and pull
+// records of many threads.
+var DefaultRateLimits = RateLimits{
+	PushRecord:           RateLimit{Rate: 100, Burst: 1000},
+	GetRecords:           RateLimit{Rate: 20, Burst: 200},
+	GetRecordsReplicator: RateLimit{Rate: 200, Burst: 2000},
+}
+
+// RateLimitStats are the numbers of inbound requests rejected for exceeding
+// the rate limits, by RPC.
+type RateLimitStats struct {
+	PushRecord           uint64
+	GetRecords           uint64
+	GetRecordsReplicator uint64
+}
+
+// RateLimiter is implemented by the networks of NewNetwork, whose limits can
+// be adjusted while they run.
+type RateLimiter interface {
+	// RateLimits returns the limits of inbound requests.
+	RateLimits() RateLimits
+	// SetRateLimits replaces the limits of inbound requests. The requests
+	// peers made count against the new limits.
+	SetRateLimits(limits RateLimits) error
+	// RateLimitStats returns the numbers of requests rejected so far.
+	RateLimitStats() RateLimitStats
+}
+
+var _ RateLimiter = (*net)(nil)
+
+// errRateLimited is returned to requests exceeding the rate limits.
+var errRateLimited = status.Error(codes.ResourceExhausted, "rate limit exceeded")
+
+type rpcKind int
+
+const (
+	rpcPushRecord rpcKind = iota
+	rpcGetRecords
+	rpcGetRecordsReplicator
+)
+
+// bucket is the token bucket of a peer, refilled when it's taken from.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter keeps the token buckets of peers, by RPC.
+type rateLimiter struct {
+	lock     sync.Mutex
+	limits   RateLimits
+	buckets  [3]map[peer.ID]*bucket
+	rejected [3]uint64
+	now      func() time.Time
+}
+
+func newRateLimiter(limits RateLimits) *rateLimiter {
+	l := &rateLimiter{limits: limits, now: time.Now}
+	for i := range l.buckets {
+		l.buckets[i] = make(map[peer.ID]*bucket)
+	}
+	return l
+}
+
+func (l *rateLimiter) limit(kind rpcKind) RateLimit {
+	switch kind {
+	case rpcPushRecord:
+		return l.limits.PushRecord
+	case rpcGetRecords:
+		return l.limits.GetRecords
+	default:
+		return l.limits.GetRecordsReplicator
+	}
+}
+
+// allow takes a token from the bucket of the peer for the RPC, returning
+// false if there's none left.
+func (l *rateLimiter) allow(kind rpcKind, p peer.ID) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	lim := l.limit(kind)
+	if lim.Rate == 0 {
+		return true
+	}
+	now := l.now()
+	b, ok := l.buckets[kind][p]
+	if !ok {
+		b = &bucket{tokens: float64(lim.Burst), last: now}
+		l.buckets[kind][p] = b
+	}
+	b.tokens += now.Sub(b.last).Seconds() * lim.Rate
+	if b.tokens > float64(lim.Burst) {
+		b.tokens = float64(lim.Burst)
+	}
+	b.last = now
+	if b.tokens < 1 {
+		l.rejected[kind]++
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// prune drops the buckets which are full, as those of peers which stopped
+// making requests, so that they don't pile up.
+func (l *rateLimiter) prune() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := l.now()
+	for i, bs := range l.buckets {
+		lim := l.limit(rpcKind(i))
+		for p, b := range bs {
+			if lim.Rate == 0 || b.tokens+now.Sub(b.last).Seconds()*lim.Rate >= float64(lim.Burst) {
+				delete(bs, p)
+			}
+		}
+	}
+}
+
+func (l *rateLimiter) setLimits(limits RateLimits) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.limits = limits
+}
+
+func (l *rateLimiter) getLimits() RateLimits {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.limits
+}
+
+func (l *rateLimiter) stats() RateLimitStats {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return RateLimitStats{
+		PushRecord:           l.rejected[rpcPushRecord],
+		GetRecords:           l.rejected[rpcGetRecords],
+		GetRecordsReplicator: l.rejected[rpcGetRecordsReplicator],
+	}
+}
+
+// pruneLoop prunes the buckets of the limiter at interval until the network
+// is closed.
+func (n *net) pruneLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-n.ctx.Done():
+			return
+		case <-ticker.C:
+			n.limiter.prune()
+		}
+	}
+}
+
+// isReplicator tells whether the peer has addresses of logs of the thread.
+func (n *net) isReplicator(id thread.ID, p peer.ID) bool {
+	info, err := n.store.GetThread(id)
+	if err != nil {
+		return false
+	}
+	for _, lg := range info.Logs {
+		pids, err := n.uniquePeers(lg.Addrs)
+		if err != nil {
+			continue
+		}
+		for _, pid := range pids {
+			if pid == p {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (n *net) RateLimits() RateLimits {
+	return n.limiter.getLimits()
+}
+
+func (n *net) SetRateLimits(limits RateLimits) error {
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+	log.Infof("setting rate limits: %+v", limits)
+	n.limiter.setLimits(limits)
+	return nil
+}
+
+func (n *net) RateLimitStats() RateLimitStats {
+	return n.limiter.stats()
+}
@@ -0,0 +1,144 @@
+package net
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogo/status"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+	"google.golang.org/grpc/codes"
+	grpcpeer "google.golang.org/grpc/peer"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Now()
+	l := newRateLimiter(RateLimits{
+		PushRecord: RateLimit{Rate: 1, Burst: 2},
+	})
+	l.now = func() time.Time { return now }
+	p1, p2 := peer.ID("p1"), peer.ID("p2")
+
+	if !l.allow(rpcPushRecord, p1) || !l.allow(rpcPushRecord, p1) {
+		t.Fatal("expected burst to be allowed")
+	}
+	if l.allow(rpcPushRecord, p1) {
+		t.Fatal("expected request past burst to be rejected")
+	}
+	if !l.allow(rpcPushRecord, p2) {
+		t.Fatal("expected other peer to be allowed")
+	}
+	now = now.Add(time.Second)
+	if !l.allow(rpcPushRecord, p1) {
+		t.Fatal("expected refilled request to be allowed")
+	}
+	if l.allow(rpcPushRecord, p1) {
+		t.Fatal("expected request past refill to be rejected")
+	}
+	for i := 0; i < 10; i++ {
+		if !l.allow(rpcGetRecords, p1) {
+			t.Fatal("expected unlimited RPC to be allowed")
+		}
+	}
+	if s := l.stats(); s.PushRecord != 2 || s.GetRecords != 0 {
+		t.Fatalf("unexpected stats %+v", s)
+	}
+
+	now = now.Add(time.Minute)
+	l.prune()
+	if len(l.buckets[rpcPushRecord]) != 0 {
+		t.Fatal("expected full buckets to be pruned")
+	}
+}
+
+func TestNet_RateLimits(t *testing.T) {
+	n := makeNetwork(t, func(c *Config) {
+		c.RateLimits = RateLimits{
+			PushRecord:           RateLimit{Rate: 0.001, Burst: 2},
+			GetRecords:           RateLimit{Rate: 0.001, Burst: 1},
+			GetRecordsReplicator: RateLimit{Rate: 0.001, Burst: 3},
+		}
+	})
+	defer n.Close()
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+	s := n.(*net).server
+
+	other := makeNetwork(t)
+	defer other.Close()
+	replicator := makeNetwork(t)
+	defer replicator.Close()
+	raddr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/4006/p2p/" + replicator.Host().ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.(*net).store.AddAddr(info.ID, info.Logs[0].ID, raddr, peerstore.PermanentAddrTTL); err != nil {
+		t.Fatal(err)
+	}
+	peerContext := func(p peer.ID) context.Context {
+		return grpcpeer.NewContext(ctx, &grpcpeer.Peer{Addr: &addr{id: p}})
+	}
+
+	t.Run("push record", func(t *testing.T) {
+		req := &pb.PushRecordRequest{
+			Body: &pb.PushRecordRequest_Body{
+				ThreadID: &pb.ProtoThreadID{ID: thread.NewIDV1(thread.Raw, 32)},
+				LogID:    &pb.ProtoPeerID{ID: other.Host().ID()},
+			},
+		}
+		pctx := peerContext(other.Host().ID())
+		for i := 0; i < 2; i++ {
+			if _, err := s.PushRecord(pctx, req); status.Code(err) != codes.FailedPrecondition {
+				t.Fatalf("expected request to be allowed, got %v", err)
+			}
+		}
+		if _, err := s.PushRecord(pctx, req); status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("expected request to be rate limited, got %v", err)
+		}
+
+		limiter := n.(RateLimiter)
+		limits := limiter.RateLimits()
+		if err := limiter.SetRateLimits(RateLimits{}); err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			if err := limiter.SetRateLimits(limits); err != nil {
+				t.Fatal(err)
+			}
+		}()
+		if _, err := s.PushRecord(pctx, req); status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("expected request to be allowed once limits are lifted, got %v", err)
+		}
+		if st := limiter.RateLimitStats(); st.PushRecord != 1 {
+			t.Fatalf("expected 1 rate limited request, got %d", st.PushRecord)
+		}
+		if err := limiter.SetRateLimits(RateLimits{PushRecord: RateLimit{Rate: 1}}); err == nil {
+			t.Fatal("expected limit without burst to be rejected")
+		}
+	})
+
+	t.Run("get records", func(t *testing.T) {
+		req := &pb.GetRecordsRequest{
+			Body: &pb.GetRecordsRequest_Body{
+				ThreadID: &pb.ProtoThreadID{ID: info.ID},
+			},
+		}
+		try := func(p peer.ID, n int) {
+			pctx := peerContext(p)
+			for i := 0; i < n; i++ {
+				if _, err := s.GetRecords(pctx, req); status.Code(err) == codes.ResourceExhausted {
+					t.Fatalf("expected request %d of %s to be allowed", i, p)
+				}
+			}
+			if _, err := s.GetRecords(pctx, req); status.Code(err) != codes.ResourceExhausted {
+				t.Fatalf("expected request of %s to be rate limited, got %v", p, err)
+			}
+		}
+		try(other.Host().ID(), 1)
+		try(replicator.Host().ID(), 3)
+	})
+}
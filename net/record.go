@@ -19,6 +19,7 @@ type linkedRecord interface {
 type recordCollector struct {
 	rs       map[peer.ID]*recordSequence
 	counters map[peer.ID]int64
+	more     map[peer.ID]bool
 	lock     sync.Mutex
 }
 
@@ -26,6 +27,7 @@ func newRecordCollector() *recordCollector {
 	return &recordCollector{
 		rs:       make(map[peer.ID]*recordSequence),
 		counters: make(map[peer.ID]int64),
+		more:     make(map[peer.ID]bool),
 	}
 }
 
@@ -60,6 +62,14 @@ func (r *recordCollector) UpdateHeadCounter(lid peer.ID, counter int64) {
 	}
 }
 
+// UpdateMore records whether a peer has more records of the log.
+func (r *recordCollector) UpdateMore(lid peer.ID, more bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.more[lid] = r.more[lid] || more
+}
+
 // List all previously stored records in a proper order if the latter exists.
 func (r *recordCollector) List() (map[peer.ID]peerRecords, error) {
 	r.lock.Lock()
@@ -85,6 +95,7 @@ func (r *recordCollector) List() (map[peer.ID]peerRecords, error) {
 		logSeqs[id] = peerRecords{
 			records: casted,
 			counter: counter,
+			more:    r.more[id],
 		}
 	}
 
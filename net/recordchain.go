@@ -0,0 +1,142 @@
+package net
+
+import (
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// maxRecordChains is the number of the logs which chains of records are kept
+// for the pages of pulls.
+const maxRecordChains = 100
+
+// recordChain is the chain of records of a log walked back from its head,
+// kept between the pages of a pull so that each page doesn't walk the log
+// from the head again. Records only link to the previous ones, so that's the
+// only way to find the records following an offset.
+type recordChain struct {
+	lock sync.Mutex
+	head cid.Cid
+	// base is the record preceding the first one of ids, or cid.Undef if
+	// they start the log.
+	base cid.Cid
+	// ids are the records walked, oldest first, and index their positions.
+	ids   []cid.Cid
+	index map[cid.Cid]int
+}
+
+// recordChains keeps the chains of records of the logs last paged.
+type recordChains struct {
+	cache *lru.Cache
+}
+
+func newRecordChains() *recordChains {
+	cache, err := lru.New(maxRecordChains)
+	if err != nil {
+		panic(err) // only returned for a non-positive size
+	}
+	return &recordChains{cache: cache}
+}
+
+// get returns the chain of records of the log, which is empty if it wasn't
+// walked yet.
+func (c *recordChains) get(id thread.ID, lid peer.ID) *recordChain {
+	key := id.String() + "/" + lid.String()
+	if v, ok := c.cache.Get(key); ok {
+		return v.(*recordChain)
+	}
+	chain := &recordChain{index: make(map[cid.Cid]int)}
+	if v, ok, _ := c.cache.PeekOrAdd(key, chain); ok {
+		return v.(*recordChain)
+	}
+	return chain
+}
+
+// page returns the IDs of up to limit records following offset, walking the
+// records which the chain is missing back from head with get, and whether
+// others follow them. The records walked last, up to limit, are returned
+// along, since they're usually the ones of the page. The lock must be held.
+func (c *recordChain) page(
+	head, offset cid.Cid,
+	limit int,
+	get func(cid.Cid) (core.Record, error),
+) (ids []cid.Cid, walked map[cid.Cid]core.Record, more bool, err error) {
+	walked = make(map[cid.Cid]core.Record, limit)
+	var ring []cid.Cid
+	// walk walks back from cursor until stop, returning the records walked,
+	// newest first, and the record it stopped at.
+	walk := func(cursor cid.Cid, stop func(cid.Cid) bool) ([]cid.Cid, cid.Cid, error) {
+		var ids []cid.Cid
+		for cursor.Defined() && !stop(cursor) {
+			r, err := get(cursor)
+			if err != nil {
+				return nil, cid.Undef, err
+			}
+			if len(ring) == limit {
+				delete(walked, ring[0])
+				ring = ring[1:]
+			}
+			ring = append(ring, cursor)
+			walked[cursor] = r
+			ids = append(ids, cursor)
+			cursor = r.PrevID()
+		}
+		return ids, cursor, nil
+	}
+
+	if !c.head.Equals(head) {
+		// the records added since the chain was last walked follow it,
+		// unless it doesn't reach the offset
+		newIDs, stopped, err := walk(head, func(id cid.Cid) bool {
+			return id.Equals(offset) || (c.head.Defined() && id.Equals(c.head))
+		})
+		if err != nil {
+			return nil, nil, false, err
+		}
+		if !c.head.Defined() || !stopped.Equals(c.head) {
+			c.base = stopped
+			c.ids = c.ids[:0]
+			c.index = make(map[cid.Cid]int, len(newIDs))
+		}
+		for i := len(newIDs) - 1; i >= 0; i-- {
+			c.index[newIDs[i]] = len(c.ids)
+			c.ids = append(c.ids, newIDs[i])
+		}
+		c.head = head
+	}
+	if _, ok := c.index[offset]; !ok && !offset.Equals(c.base) && c.base.Defined() {
+		// the offset precedes the records walked
+		oldIDs, stopped, err := walk(c.base, func(id cid.Cid) bool {
+			return id.Equals(offset)
+		})
+		if err != nil {
+			return nil, nil, false, err
+		}
+		ids := make([]cid.Cid, 0, len(oldIDs)+len(c.ids))
+		for i := len(oldIDs) - 1; i >= 0; i-- {
+			ids = append(ids, oldIDs[i])
+		}
+		c.ids = append(ids, c.ids...)
+		c.base = stopped
+		c.index = make(map[cid.Cid]int, len(c.ids))
+		for i, id := range c.ids {
+			c.index[id] = i
+		}
+	}
+
+	var start int
+	if i, ok := c.index[offset]; ok {
+		start = i + 1
+	}
+	end := start + limit
+	if end > len(c.ids) {
+		end = len(c.ids)
+	}
+	ids = make([]cid.Cid, end-start)
+	copy(ids, c.ids[start:end])
+	return ids, walked, end < len(c.ids), nil
+}
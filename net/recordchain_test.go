@@ -0,0 +1,91 @@
+package net
+
+import (
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+)
+
+// chainRecord is a record which only links to the previous one.
+type chainRecord struct {
+	core.Record
+	prev cid.Cid
+}
+
+func (r *chainRecord) PrevID() cid.Cid {
+	return r.prev
+}
+
+func TestRecordChain(t *testing.T) {
+	var ids []cid.Cid
+	recs := make(map[cid.Cid]core.Record)
+	add := func() {
+		h, _ := mh.Sum([]byte{byte(len(ids))}, mh.SHA2_256, -1)
+		id := cid.NewCidV1(cid.DagCBOR, h)
+		prev := cid.Undef
+		if len(ids) > 0 {
+			prev = ids[len(ids)-1]
+		}
+		recs[id] = &chainRecord{prev: prev}
+		ids = append(ids, id)
+	}
+	for i := 0; i < 10; i++ {
+		add()
+	}
+	var reads int
+	get := func(id cid.Cid) (core.Record, error) {
+		reads++
+		return recs[id], nil
+	}
+	page := func(c *recordChain, offset cid.Cid, limit, from, to int, more bool, read int) {
+		t.Helper()
+		reads = 0
+		page, _, m, err := c.page(ids[len(ids)-1], offset, limit, get)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) != to-from {
+			t.Fatalf("expected %d records, got %d", to-from, len(page))
+		}
+		for i, id := range page {
+			if !id.Equals(ids[from+i]) {
+				t.Fatalf("expected record %d at %d", from+i, i)
+			}
+		}
+		if m != more {
+			t.Fatalf("expected more to be %v", more)
+		}
+		if reads != read {
+			t.Fatalf("expected %d records read, got %d", read, reads)
+		}
+	}
+
+	t.Run("Pages", func(t *testing.T) {
+		c := &recordChain{index: make(map[cid.Cid]int)}
+		// the log is walked once for the first page
+		page(c, cid.Undef, 4, 0, 4, true, 10)
+		page(c, ids[3], 4, 4, 8, true, 0)
+		page(c, ids[7], 4, 8, 10, false, 0)
+		page(c, ids[9], 4, 10, 10, false, 0)
+	})
+
+	t.Run("HeadMoved", func(t *testing.T) {
+		c := &recordChain{index: make(map[cid.Cid]int)}
+		page(c, ids[5], 2, 6, 8, true, 4)
+		add()
+		add()
+		// only the records added are walked
+		page(c, ids[7], 2, 8, 10, true, 2)
+		page(c, ids[9], 4, 10, 12, false, 0)
+	})
+
+	t.Run("OffsetBeforeChain", func(t *testing.T) {
+		c := &recordChain{index: make(map[cid.Cid]int)}
+		page(c, ids[5], 2, 6, 8, true, len(ids)-6)
+		// the chain is walked further back from where it stopped
+		page(c, ids[1], 2, 2, 4, true, 4)
+		page(c, cid.Undef, 2, 0, 2, true, 2)
+	})
+}
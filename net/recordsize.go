@@ -0,0 +1,59 @@
+package net
+
+import (
+	"context"
+	"fmt"
+
+	format "github.com/ipfs/go-ipld-format"
+	core "github.com/textileio/go-threads/core/net"
+	pb "github.com/textileio/go-threads/net/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultMaxRecordSize is the size in bytes of the largest record body
+// created by default. Records well below the default message size limit of
+// gRPC fit in the replies to get records requests along with others.
+const DefaultMaxRecordSize = 1 << 20
+
+// maxRecordOverhead bounds the size the blocks of a record add to its body,
+// by encrypting and signing it, which the records received from peers may
+// exceed the max record size by.
+const maxRecordOverhead = 4 << 10
+
+func (n *net) GetMaxRecordSize(context.Context) (int, error) {
+	return n.maxRecordSize(), nil
+}
+
+func (n *net) maxRecordSize() int {
+	if n.conf.MaxRecordSize == 0 {
+		return DefaultMaxRecordSize
+	}
+	return n.conf.MaxRecordSize
+}
+
+// checkBodySize returns ErrRecordTooLarge if the record body exceeds the max
+// record size.
+func (n *net) checkBodySize(body format.Node) error {
+	if size, max := len(body.RawData()), n.maxRecordSize(); size > max {
+		return fmt.Errorf("%w: body of %d bytes is more than %d", core.ErrRecordTooLarge, size, max)
+	}
+	return nil
+}
+
+// checkRecordSize returns ErrRecordTooLarge if the blocks of the record, as
+// they're exchanged with peers, exceed the max record size and the overhead
+// of the blocks.
+func (n *net) checkRecordSize(rec *pb.Log_Record) error {
+	size := len(rec.RecordNode) + len(rec.EventNode) + len(rec.HeaderNode) + len(rec.BodyNode)
+	if max := n.maxRecordSize() + maxRecordOverhead; size > max {
+		return fmt.Errorf("%w: %d bytes is more than %d", core.ErrRecordTooLarge, size, max)
+	}
+	return nil
+}
+
+// errRecordTooLarge is the status of the records denied to peers for their
+// size.
+func errRecordTooLarge(err error) error {
+	return status.Error(codes.OutOfRange, err.Error())
+}
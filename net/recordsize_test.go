@@ -0,0 +1,92 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gogo/status"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	pb "github.com/textileio/go-threads/net/pb"
+	"google.golang.org/grpc/codes"
+	grpcpeer "google.golang.org/grpc/peer"
+)
+
+func TestNet_MaxRecordSize(t *testing.T) {
+	noPubSub := func(c *Config) { c.PubSub = false }
+	n1 := makeNetwork(t, noPubSub, noConfirmedAddrs)
+	defer n1.Close()
+	n2 := makeNetwork(t, noPubSub, noConfirmedAddrs, func(c *Config) {
+		c.NoNetPulling = true
+		c.MaxRecordSize = 1024
+	})
+	defer n2.Close()
+
+	ctx := context.Background()
+	if max, err := n1.GetMaxRecordSize(ctx); err != nil || max != DefaultMaxRecordSize {
+		t.Fatalf("expected default max record size, got %d (%v)", max, err)
+	}
+	if max, err := n2.GetMaxRecordSize(ctx); err != nil || max != 1024 {
+		t.Fatalf("expected configured max record size, got %d (%v)", max, err)
+	}
+
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	info := createThread(t, ctx, n1)
+	raddr, err := ma.NewMultiaddr("/p2p/" + n2.Host().ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n1.AddReplicator(ctx, info.ID, raddr); err != nil {
+		t.Fatal(err)
+	}
+	// disconnect the peers to push the records to the replicator directly
+	n1.Host().Peerstore().ClearAddrs(n2.Host().ID())
+	n2.Host().Peerstore().ClearAddrs(n1.Host().ID())
+	if err := n1.Host().Network().ClosePeer(n2.Host().ID()); err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"foo": strings.Repeat("bar", maxRecordOverhead),
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n2.CreateRecord(ctx, info.ID, body); !errors.Is(err, core.ErrRecordTooLarge) {
+		t.Fatalf("expected error %v, got %v", core.ErrRecordTooLarge, err)
+	}
+
+	rec, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pbrec, err := cbor.RecordToProto(ctx, n1, rec.Value())
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &pb.PushRecordRequest{
+		Body: &pb.PushRecordRequest_Body{
+			ThreadID: &pb.ProtoThreadID{ID: info.ID},
+			LogID:    &pb.ProtoPeerID{ID: rec.LogID()},
+			Record:   pbrec,
+		},
+		Counter: 1,
+	}
+	pctx := grpcpeer.NewContext(ctx, &grpcpeer.Peer{Addr: &addr{id: n1.Host().ID()}})
+	if _, err := n2.(*net).server.PushRecord(pctx, req); status.Code(err) != codes.OutOfRange {
+		t.Fatalf("expected large record to be denied, got %v", err)
+	}
+	h, err := n2.(*net).currentHead(info.ID, rec.LogID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Counter != 0 {
+		t.Fatalf("expected large record not to be stored, got head %d", h.Counter)
+	}
+}
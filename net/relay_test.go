@@ -0,0 +1,87 @@
+package net
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p"
+	circuit "github.com/libp2p/go-libp2p-circuit"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/util"
+)
+
+func TestNet_Relay(t *testing.T) {
+	ctx := context.Background()
+	relay, err := libp2p.New(ctx,
+		libp2p.ListenAddrs(util.MustParseAddr("/ip4/127.0.0.1/tcp/0")),
+		libp2p.EnableRelay(circuit.OptHop))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer relay.Close()
+	relayInfo := peer.AddrInfo{ID: relay.ID(), Addrs: relay.Addrs()}
+
+	// the peers don't listen, so they're only reachable through the relay
+	relayed := func() (core.Net, ma.Multiaddr) {
+		sk, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		h, err := libp2p.New(ctx, libp2p.Identity(sk), libp2p.NoListenAddrs, libp2p.EnableRelay())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := h.Connect(ctx, relayInfo); err != nil {
+			t.Fatal(err)
+		}
+		n := makeHostNetwork(t, h, func(c *Config) {
+			c.PubSub = false
+			c.NoNetPulling = true
+		})
+		addr, err := ma.NewMultiaddr(relay.Addrs()[0].String() + "/p2p/" + relay.ID().String() + "/p2p-circuit/p2p/" + h.ID().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n, addr
+	}
+	n1, _ := relayed()
+	defer n1.Close()
+	n2, addr2 := relayed()
+	defer n2.Close()
+	if len(n1.Host().Addrs()) != 0 || len(n2.Host().Addrs()) != 0 {
+		t.Fatal("expected peers not to listen")
+	}
+
+	info := createThread(t, ctx, n1)
+	if pid, err := n1.AddReplicator(ctx, info.ID, addr2); err != nil {
+		t.Fatal(err)
+	} else if pid != n2.Host().ID() {
+		t.Fatalf("expected relayed peer %s to be added as replicator, got %s", n2.Host().ID(), pid)
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n1.CreateRecord(ctx, info.ID, body); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; ; i++ {
+		head, err := n2.(*net).currentHead(info.ID, info.Logs[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if head.Counter == 1 {
+			break
+		}
+		if i == 100 {
+			t.Fatal("expected record to be pushed through the relay")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
@@ -0,0 +1,121 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gogo/status"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	"google.golang.org/grpc/codes"
+)
+
+// Replicators are granted a thread by AddReplicator until the expiry of
+// WithReplicatorExpiry, if any, or until RemoveReplicator. The grants are
+// enforced by policy only: once they expire, records aren't pushed to the
+// replicators, which are denied getting records of the thread, yet the
+// replicators keep the service key, the records they got, and the records
+// announced over pubsub.
+
+// replicatorExpiryKeyPrefix prefixes the keys of the metadata of a thread
+// holding the unix time in nanoseconds the grants of its replicators expire
+// at, which is zero for grants which don't.
+const replicatorExpiryKeyPrefix = "replicator-expiry/"
+
+// errReplicatorExpired is returned to the get records requests of the
+// replicators whose grant of the thread expired.
+var errReplicatorExpired = status.Error(codes.PermissionDenied, "replicator grant expired")
+
+func replicatorExpiryKey(pid peer.ID) string {
+	return replicatorExpiryKeyPrefix + pid.String()
+}
+
+// setReplicatorExpiry grants the thread to the replicator until expiry, or
+// with no expiry if it's zero.
+func (n *net) setReplicatorExpiry(id thread.ID, pid peer.ID, expiry time.Time) error {
+	var v int64
+	if !expiry.IsZero() {
+		v = expiry.UnixNano()
+	}
+	return n.store.PutInt64(id, replicatorExpiryKey(pid), v)
+}
+
+// replicatorExpired tells whether the grant of the thread to the peer
+// expired, which it didn't for peers which weren't granted it.
+func (n *net) replicatorExpired(id thread.ID, pid peer.ID) bool {
+	v, err := n.store.GetInt64(id, replicatorExpiryKey(pid))
+	if err != nil {
+		log.Errorf("getting replicator expiry of %s (thread %s): %v", pid, id, err)
+		return false
+	}
+	return v != nil && *v != 0 && time.Now().UnixNano() >= *v
+}
+
+func (n *net) RemoveReplicator(
+	ctx context.Context,
+	id thread.ID,
+	paddr ma.Multiaddr,
+	opts ...core.ThreadOption,
+) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return err
+	}
+	if _, err := n.store.GetThread(id); err != nil {
+		return err
+	}
+	_, pid, err := splitPeerAddr(paddr)
+	if err != nil {
+		return err
+	}
+	if pid == n.host.ID() {
+		return errors.New("cannot remove the host as replicator")
+	}
+
+	// expire the grant at once, then withdraw the addresses of the peer
+	if err := n.setReplicatorExpiry(id, pid, time.Now()); err != nil {
+		return err
+	}
+	managedLogs, err := n.store.GetManagedLogs(id)
+	if err != nil {
+		return err
+	}
+	if err := n.withdrawPeerAddrs(id, pid, managedLogs); err != nil {
+		return err
+	}
+	log.Infof("removed replicator %s of thread %s", pid, id)
+	return nil
+}
+
+// withdrawPeerAddrs removes the addresses of the peer from the logs of the
+// thread, so that records of the logs are no longer exchanged with it.
+func (n *net) withdrawPeerAddrs(id thread.ID, pid peer.ID, logs []thread.LogInfo) error {
+	for _, lg := range logs {
+		var withdrawn []ma.Multiaddr
+		for _, addr := range lg.Addrs {
+			if p, err := addr.ValueForProtocol(ma.P_P2P); err != nil {
+				continue
+			} else if lp, err := peer.Decode(p); err == nil && lp == pid {
+				withdrawn = append(withdrawn, addr)
+			}
+		}
+		if len(withdrawn) == 0 {
+			continue
+		}
+		// a zero ttl removes the addresses
+		if err := n.store.SetAddrs(id, lg.ID, withdrawn, 0); err != nil {
+			return err
+		}
+	}
+	if err := n.outbox.dropPeer(id, pid); err != nil {
+		return err
+	}
+	n.protectThreadPeers(id)
+	return nil
+}
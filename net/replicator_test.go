@@ -0,0 +1,134 @@
+package net
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogo/status"
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+	"google.golang.org/grpc/codes"
+	grpcpeer "google.golang.org/grpc/peer"
+)
+
+func TestNet_ReplicatorGrants(t *testing.T) {
+	noPubSub := func(c *Config) { c.PubSub = false }
+	n1 := makeNetwork(t, noPubSub)
+	defer n1.Close()
+	n2 := makeNetwork(t, noPubSub)
+	defer n2.Close()
+
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	raddr, err := ma.NewMultiaddr("/p2p/" + n2.Host().ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := n1.(*net).server
+	req := &pb.GetRecordsRequest{
+		Body: &pb.GetRecordsRequest_Body{
+			ThreadID:   &pb.ProtoThreadID{ID: info.ID},
+			ServiceKey: &pb.ProtoKey{Key: info.Key.Service()},
+		},
+	}
+	pctx := grpcpeer.NewContext(ctx, &grpcpeer.Peer{Addr: &addr{id: n2.Host().ID()}})
+	getRecords := func() codes.Code {
+		_, err := s.GetRecords(pctx, req)
+		return status.Code(err)
+	}
+
+	createRecord := func() cid.Cid {
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"time": time.Now().UnixNano(),
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r.Value().Cid()
+	}
+	replicatedHead := func() cid.Cid {
+		lg, err := n2.(*net).store.GetLog(info.ID, info.Logs[0].ID)
+		if err != nil {
+			return cid.Undef
+		}
+		return lg.Head.ID
+	}
+
+	t.Run("expiry", func(t *testing.T) {
+		if _, err := n1.AddReplicator(ctx, info.ID, raddr, core.WithReplicatorExpiry(time.Now().Add(-time.Second))); err == nil {
+			t.Fatal("expected expiry in the past to be rejected")
+		}
+		expiry := time.Now().Add(time.Second)
+		if _, err := n1.AddReplicator(ctx, info.ID, raddr, core.WithReplicatorExpiry(expiry)); err != nil {
+			t.Fatal(err)
+		}
+		if code := getRecords(); code != codes.OK {
+			t.Fatalf("expected replicator to get records, got %s", code)
+		}
+		r1 := createRecord()
+		for i := 0; !replicatedHead().Equals(r1); i++ {
+			if i == 50 {
+				t.Fatal("expected record to be pushed to replicator")
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+
+		time.Sleep(time.Until(expiry))
+		if code := getRecords(); code != codes.PermissionDenied {
+			t.Fatalf("expected expired replicator to be denied, got %s", code)
+		}
+		createRecord()
+		time.Sleep(500 * time.Millisecond)
+		if !replicatedHead().Equals(r1) {
+			t.Fatal("expected record not to be pushed to expired replicator")
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		if _, err := n1.AddReplicator(ctx, info.ID, raddr); err != nil {
+			t.Fatal(err)
+		}
+		if code := getRecords(); code != codes.OK {
+			t.Fatalf("expected replicator added again to get records, got %s", code)
+		}
+		if err := n1.RemoveReplicator(ctx, info.ID, raddr); err != nil {
+			t.Fatal(err)
+		}
+		if code := getRecords(); code != codes.PermissionDenied {
+			t.Fatalf("expected removed replicator to be denied, got %s", code)
+		}
+		lg, err := n1.(*net).store.GetLog(info.ID, info.Logs[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(lg.Addrs) != 1 {
+			t.Fatalf("expected addresses of removed replicator to be withdrawn, got %v", lg.Addrs)
+		}
+
+		self, err := ma.NewMultiaddr("/p2p/" + n1.Host().ID().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := n1.RemoveReplicator(ctx, info.ID, self); err == nil {
+			t.Fatal("expected removing the host to fail")
+		}
+		if err := n1.RemoveReplicator(ctx, thread.NewIDV1(thread.Raw, 32), raddr); err == nil {
+			t.Fatal("expected removing replicator of unknown thread to fail")
+		}
+	})
+}
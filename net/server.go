@@ -17,6 +17,7 @@ import (
 	rpc "github.com/textileio/go-libp2p-pubsub-rpc"
 	"github.com/textileio/go-threads/cbor"
 	lstore "github.com/textileio/go-threads/core/logstore"
+	core "github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/logstore/lstoreds"
 	pb "github.com/textileio/go-threads/net/pb"
@@ -37,8 +38,12 @@ type server struct {
 	opts  []grpc.DialOption
 	conns map[peer.ID]*grpc.ClientConn
 
-	ps     *pubsub.PubSub
-	topics map[thread.ID]*rpc.Topic
+	ps *pubsub.PubSub
+	// topics are the pubsub topics by name, which threads may share, and
+	// threadTopics are the names of the topics of the threads, which are
+	// empty for threads not announced over pubsub.
+	topics       map[string]*rpc.Topic
+	threadTopics map[thread.ID]string
 
 	sync.Mutex
 }
@@ -47,9 +52,10 @@ type server struct {
 func newServer(n *net, opts ...grpc.DialOption) (*server, error) {
 	var (
 		s = &server{
-			net:    n,
-			conns:  make(map[peer.ID]*grpc.ClientConn),
-			topics: make(map[thread.ID]*rpc.Topic),
+			net:          n,
+			conns:        make(map[peer.ID]*grpc.ClientConn),
+			topics:       make(map[string]*rpc.Topic),
+			threadTopics: make(map[thread.ID]string),
 		}
 
 		defaultOpts = []grpc.DialOption{
@@ -161,12 +167,29 @@ func (s *server) GetRecords(ctx context.Context, req *pb.GetRecordsRequest) (*pb
 		return nil, err
 	}
 	log.Debugf("received get records request from %s", pid)
+	kind := rpcGetRecords
+	if s.net.isReplicator(req.Body.ThreadID.ID, pid) {
+		kind = rpcGetRecordsReplicator
+	}
+	if !s.net.limiter.allow(kind, pid) {
+		log.Debugf("rate limited get records request from %s", pid)
+		return nil, errRateLimited
+	}
+	if s.net.replicatorExpired(req.Body.ThreadID.ID, pid) {
+		log.Debugf("denied get records request of expired replicator %s", pid)
+		return nil, errReplicatorExpired
+	}
 
 	var pbrecs = &pb.GetRecordsReply{}
 	if err := s.checkServiceKey(req.Body.ThreadID.ID, req.Body.ServiceKey); err != nil {
 		return pbrecs, err
 	}
 
+	// the offsets are the heads the peer acknowledges
+	for _, l := range req.Body.Logs {
+		s.net.ackRecords(req.Body.ThreadID.ID, l.LogID.ID, pid, l.Counter)
+	}
+
 	// fast check if requested offsets are equal with thread heads
 	if changed, err := s.headsChanged(req); err != nil {
 		return nil, err
@@ -188,9 +211,14 @@ func (s *server) GetRecords(ctx context.Context, req *pb.GetRecordsRequest) (*pb
 
 	var (
 		logRecordLimit = int(s.net.conf.NetPullingLimit) / len(info.Logs)
+		paged          = req.Body.Version >= getRecordsVersionPaged
+		logSizeLimit   = MaxLegacyRecordsReplySize / len(info.Logs)
 		mx             sync.Mutex
 		wg             sync.WaitGroup
 	)
+	if paged {
+		logSizeLimit = MaxRecordsPageSize / len(info.Logs)
+	}
 
 	for _, lg := range info.Logs {
 		var (
@@ -217,19 +245,49 @@ func (s *server) GetRecords(ctx context.Context, req *pb.GetRecordsRequest) (*pb
 				return
 			}
 
-			recs, err := s.net.getLocalRecords(ctx, tid, lid, off, lim, counter)
-			if err != nil {
-				log.Errorf("getting local records (thread %s, log %s): %v", tid, lid, err)
-			}
-
-			var prs = make([]*pb.Log_Record, 0, len(recs))
-			for _, r := range recs {
+			// Past the size limit, pages end early, and legacy replies keep
+			// the last records which fit.
+			var (
+				prs    []*pb.Log_Record
+				size   int
+				failed bool
+			)
+			add := func(r core.Record) bool {
 				pr, err := cbor.RecordToProto(ctx, s.net, r)
 				if err != nil {
 					log.Errorf("constructing proto-record %s (thread %s, log %s): %v", r.Cid(), tid, lid, err)
-					break
+					failed = true
+					return false
+				}
+				if size += pr.Size(); size > logSizeLimit && len(prs) > 0 {
+					return false
 				}
 				prs = append(prs, pr)
+				return true
+			}
+			var fit func(core.Record) bool
+			if paged {
+				fit = add
+			}
+
+			recs, more, err := s.net.getLocalRecords(ctx, tid, lid, off, lim, counter, paged, fit)
+			if err != nil {
+				log.Errorf("getting local records (thread %s, log %s): %v", tid, lid, err)
+			}
+			if failed {
+				more = false
+			}
+			if !paged {
+				for i := len(recs) - 1; i >= 0; i-- {
+					if !add(recs[i]) {
+						break
+					}
+				}
+			}
+			if !paged {
+				for i, j := 0, len(prs)-1; i < j; i, j = i+1, j-1 {
+					prs[i], prs[j] = prs[j], prs[i]
+				}
 			}
 
 			if len(prs) == 0 {
@@ -242,10 +300,11 @@ func (s *server) GetRecords(ctx context.Context, req *pb.GetRecordsRequest) (*pb
 				LogID:   &pb.ProtoPeerID{ID: lid},
 				Records: prs,
 				Log:     pblg,
+				More:    more,
 			})
 			mx.Unlock()
 
-			log.Debugf("sending %d records in log %s to %s", len(recs), lid, pid)
+			log.Debugf("sending %d records in log %s to %s", len(prs), lid, pid)
 		}(req.Body.ThreadID.ID, lg.ID, offset, limit)
 	}
 
@@ -260,6 +319,19 @@ func (s *server) PushRecord(ctx context.Context, req *pb.PushRecordRequest) (*pb
 		return nil, err
 	}
 	log.Debugf("received push record request from %s", pid)
+	if !s.net.limiter.allow(rpcPushRecord, pid) {
+		log.Debugf("rate limited push record request from %s", pid)
+		return nil, errRateLimited
+	}
+
+	// Peers pushing records of threads which aren't hosted are told to stop
+	key, err := s.net.store.ServiceKey(req.Body.ThreadID.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if key == nil {
+		return nil, errThreadUnknown
+	}
 
 	// A log is required to accept new records
 	logpk, err := s.net.store.PubKey(req.Body.ThreadID.ID, req.Body.LogID.ID)
@@ -269,10 +341,8 @@ func (s *server) PushRecord(ctx context.Context, req *pb.PushRecordRequest) (*pb
 	if logpk == nil {
 		return nil, status.Error(codes.NotFound, "log not found")
 	}
-
-	key, err := s.net.store.ServiceKey(req.Body.ThreadID.ID)
-	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+	if err = s.net.checkRecordSize(req.Body.Record); err != nil {
+		return nil, errRecordTooLarge(err)
 	}
 	rec, err := cbor.RecordFromProto(req.Body.Record, key)
 	if err != nil {
@@ -282,9 +352,13 @@ func (s *server) PushRecord(ctx context.Context, req *pb.PushRecordRequest) (*pb
 	if err = rec.Verify(logpk); err != nil {
 		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
-	if err = s.net.PutRecord(ctx, req.Body.ThreadID.ID, req.Body.LogID.ID, rec, req.Counter); err != nil {
+	if err = s.net.PutRecord(ctx, req.Body.ThreadID.ID, req.Body.LogID.ID, rec, req.Counter); errors.Is(err, ErrRecordRejected) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	} else if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	s.net.metrics.recordsReceived.WithLabelValues(sourcePush).Inc()
+	s.net.peerStats.received(pid)
 	return &pb.PushRecordReply{}, nil
 }
 
@@ -419,50 +493,67 @@ func (s *server) localEdges(tid thread.ID) (addrsEdge, headsEdge uint64, err err
 	return
 }
 
-// addPubSubTopic subscribes to a thread topic.
+// addPubSubTopic subscribes to the topic of a thread, depending on its pubsub
+// strategy.
 func (s *server) addPubsubTopic(id thread.ID) error {
 	if s.ps == nil {
 		return nil
 	}
 	s.Lock()
 	defer s.Unlock()
-	if _, ok := s.topics[id]; ok {
+	if _, ok := s.threadTopics[id]; ok {
 		return nil
 	}
 
-	t, err := rpc.NewTopic(s.net.ctx, s.ps, s.net.host.ID(), id.String(), true)
-	if err != nil {
-		return err
+	name := s.net.pubSubTopic(id)
+	if _, ok := s.topics[name]; !ok && name != "" {
+		t, err := rpc.NewTopic(s.net.ctx, s.ps, s.net.host.ID(), name, true)
+		if err != nil {
+			return err
+		}
+		t.SetEventHandler(s.pubSubEventHandler)
+		t.SetMessageHandler(s.pubSubRecordHandler)
+		s.topics[name] = t
 	}
-	t.SetEventHandler(s.pubSubEventHandler)
-	t.SetMessageHandler(s.pubSubRecordHandler)
-	s.topics[id] = t
+	s.threadTopics[id] = name
 	return nil
 }
 
-// addPubSubTopic subscribes to a thread topic.
+// removePubsubTopic unsubscribes from the topic of a thread, unless other
+// threads share it.
 func (s *server) removePubsubTopic(id thread.ID) error {
 	if s.ps == nil {
 		return nil
 	}
 	s.Lock()
 	defer s.Unlock()
-	if t, ok := s.topics[id]; ok {
-		delete(s.topics, id)
+	name, ok := s.threadTopics[id]
+	if !ok {
+		return nil
+	}
+	delete(s.threadTopics, id)
+	for _, n := range s.threadTopics {
+		if n == name {
+			return nil
+		}
+	}
+	if t, ok := s.topics[name]; ok {
+		delete(s.topics, name)
 		return t.Close()
 	}
 	return nil
 }
 
-// addPubSubTopic subscribes to a thread topic.
+// removeAllPubsubTopics unsubscribes from all topics.
 func (s *server) removeAllPubsubTopics() error {
 	if s.ps == nil {
 		return nil
 	}
 	s.Lock()
 	defer s.Unlock()
-	for id, t := range s.topics {
-		delete(s.topics, id)
+	s.threadTopics = make(map[thread.ID]string)
+	for name, t := range s.topics {
+		delete(s.topics, name)
 		if err := t.Close(); err != nil {
 			return err
 		}
@@ -476,10 +567,13 @@ func (s *server) publishRecord(ctx context.Context, topic thread.ID, req *pb.Pus
 		return nil
 	}
 	s.Lock()
-	t, ok := s.topics[topic]
+	name, ok := s.threadTopics[topic]
+	t := s.topics[name]
 	s.Unlock()
 	if !ok {
 		return fmt.Errorf("publish to unknown thread %s", topic)
+	} else if name == "" {
+		return nil
 	}
 
 	data, err := req.Marshal()
@@ -508,6 +602,17 @@ func (s *server) pubSubRecordHandler(from peer.ID, topic string, msg []byte) ([]
 	if err := proto.Unmarshal(msg, req); err != nil {
 		return nil, err
 	}
+	if req.Body == nil || req.Body.ThreadID == nil {
+		return nil, nil
+	}
+	// Topics may be shared by threads, of which records of the threads not
+	// hosted, or announced on other topics, are ignored.
+	s.Lock()
+	name, ok := s.threadTopics[req.Body.ThreadID.ID]
+	s.Unlock()
+	if !ok || name != topic {
+		return nil, nil
+	}
 
 	ctx := grpcpeer.NewContext(s.net.ctx, &grpcpeer.Peer{
 		Addr: &addr{id: from},
@@ -1,8 +1,11 @@
 package util
 
 import (
+	"context"
+	"hash/fnv"
 	"sync"
 
+	core "github.com/textileio/go-threads/core/net"
 	apipb "github.com/textileio/go-threads/net/api/pb"
 	netpb "github.com/textileio/go-threads/net/pb"
 )
@@ -25,6 +28,51 @@ func RecToServiceRec(r *apipb.Record) *netpb.Log_Record {
 	}
 }
 
+// BufferRecords returns a channel of the records received from in, which
+// buffers up to size of them while it isn't read, so that in is always
+// received from. Once size records are buffered, the oldest is dropped for
+// each new one, and dropped is called with the number of records dropped so
+// far, if it isn't nil. The channel is closed once in is closed and the
+// records buffered are read, or ctx is done.
+func BufferRecords(ctx context.Context, in <-chan core.ThreadRecord, size int, dropped func(int)) <-chan core.ThreadRecord {
+	if size < 1 {
+		size = 1
+	}
+	out := make(chan core.ThreadRecord)
+	go func() {
+		defer close(out)
+		var queue []core.ThreadRecord
+		var drops int
+		for in != nil || len(queue) > 0 {
+			var send chan<- core.ThreadRecord
+			var next core.ThreadRecord
+			if len(queue) > 0 {
+				send, next = out, queue[0]
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case rec, ok := <-in:
+				if !ok {
+					in = nil
+					continue
+				}
+				if len(queue) == size {
+					queue = queue[1:]
+					drops++
+					if dropped != nil {
+						dropped(drops)
+					}
+				}
+				queue = append(queue, rec)
+			case send <- next:
+				queue = queue[1:]
+			}
+		}
+	}()
+	return out
+}
+
 func NewSemaphore(capacity int) *Semaphore {
 	return &Semaphore{inner: make(chan struct{}, capacity)}
 }
@@ -60,39 +108,60 @@ type SemaphoreKey interface {
 	Key() string
 }
 
+// semaPoolShards is the number of shards of a semaphore pool, whose
+// semaphores are looked up under the lock of their shard only.
+const semaPoolShards = 64
+
 func NewSemaphorePool(semaCap int) *SemaphorePool {
-	return &SemaphorePool{ss: make(map[string]*Semaphore), semaCap: semaCap}
+	p := &SemaphorePool{semaCap: semaCap}
+	for i := range p.shards {
+		p.shards[i].ss = make(map[string]*Semaphore)
+	}
+	return p
 }
 
+// SemaphorePool holds the semaphores of keys, sharded by key so that looking
+// up the semaphores of different keys rarely contends.
 type SemaphorePool struct {
-	ss      map[string]*Semaphore
+	shards  [semaPoolShards]semaShard
 	semaCap int
-	mu      sync.Mutex
+}
+
+type semaShard struct {
+	ss map[string]*Semaphore
+	mu sync.Mutex
+}
+
+func (p *SemaphorePool) shard(key string) *semaShard {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return &p.shards[h.Sum32()%semaPoolShards]
 }
 
 func (p *SemaphorePool) Get(k SemaphoreKey) *Semaphore {
 	var (
-		s     *Semaphore
-		exist bool
 		key   = k.Key()
+		shard = p.shard(key)
 	)
 
-	p.mu.Lock()
-	if s, exist = p.ss[key]; !exist {
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	s, exist := shard.ss[key]
+	if !exist {
 		s = NewSemaphore(p.semaCap)
-		p.ss[key] = s
+		shard.ss[key] = s
 	}
-	p.mu.Unlock()
-
 	return s
 }
 
 func (p *SemaphorePool) Stop() {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	// grab all semaphores and hold
-	for _, s := range p.ss {
-		s.Acquire()
+	for i := range p.shards {
+		shard := &p.shards[i]
+		shard.mu.Lock()
+		// grab all semaphores and hold
+		for _, s := range shard.ss {
+			s.Acquire()
+		}
+		shard.mu.Unlock()
 	}
 }
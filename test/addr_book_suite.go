@@ -182,6 +182,13 @@ func testSetNegativeTTLClears(ab core.AddrBook) func(t *testing.T) {
 		survivors = append(survivors[0:74], survivors[75:]...)
 
 		AssertAddressesEqual(t, survivors, checkedAddrs(t, ab, tid, id))
+
+		// remove several addresses at once.
+		check(t, ab.SetAddrs(tid, id, []ma.Multiaddr{survivors[10], survivors[20]}, 0))
+		survivors = append(survivors[0:10], survivors[11:]...)
+		survivors = append(survivors[0:19], survivors[20:]...)
+
+		AssertAddressesEqual(t, survivors, checkedAddrs(t, ab, tid, id))
 	}
 }
 
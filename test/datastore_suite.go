@@ -0,0 +1,352 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/keytransform"
+	"github.com/ipfs/go-datastore/query"
+	dse "github.com/textileio/go-datastore-extensions"
+	kt "github.com/textileio/go-threads/db/keytransform"
+)
+
+// datastoreSuite covers the key prefixes and transaction semantics dbs rely
+// on from their datastores.
+var datastoreSuite = map[string]func(kt.TxnDatastoreExtended) func(*testing.T){
+	"PrefixBoundaries":    testPrefixBoundaries,
+	"OrdersOffsetLimit":   testOrdersOffsetLimit,
+	"SeekPrefix":          testSeekPrefix,
+	"SeekPrefixDesc":      testSeekPrefixDesc,
+	"ReadYourWrites":      testReadYourWrites,
+	"Discard":             testDiscard,
+	"AtomicCommit":        testAtomicCommit,
+	"SnapshotIsolation":   testSnapshotIsolation,
+	"ReadOnlyTransaction": testReadOnlyTransaction,
+}
+
+type DatastoreFactory func() (kt.TxnDatastoreExtended, func())
+
+// DatastoreTest runs the datastore suite against the stores of factory, both
+// as they are and wrapped with a prefix, as dbs of a manager are.
+func DatastoreTest(t *testing.T, factory DatastoreFactory) {
+	for name, test := range datastoreSuite {
+		store, closeFunc := factory()
+		t.Run(name, test(store))
+		if closeFunc != nil {
+			closeFunc()
+		}
+
+		store, closeFunc = factory()
+		prefixed := kt.WrapTxnDatastore(store, keytransform.PrefixTransform{
+			Prefix: ds.NewKey("/prefixed"),
+		})
+		t.Run(name+"Prefixed", test(prefixed))
+		if closeFunc != nil {
+			closeFunc()
+		}
+	}
+	t.Run("PrefixedKeys", testPrefixedKeys(factory))
+}
+
+func testPrefixBoundaries(store kt.TxnDatastoreExtended) func(t *testing.T) {
+	return func(t *testing.T) {
+		putKeys(t, store, "/a", "/a/b", "/a/b/c", "/a/b/d/e", "/a/bc", "/a/bc/d", "/ab/c")
+
+		assertKeys(t, store, query.Query{Prefix: "/a/b"}, "/a/b/c", "/a/b/d/e")
+		assertKeys(t, store, query.Query{Prefix: "/a/b/"}, "/a/b/c", "/a/b/d/e")
+		assertKeys(t, store, query.Query{Prefix: "/a"},
+			"/a/b", "/a/b/c", "/a/b/d/e", "/a/bc", "/a/bc/d")
+		assertKeys(t, store, query.Query{Prefix: "/a/b/c"})
+	}
+}
+
+func testOrdersOffsetLimit(store kt.TxnDatastoreExtended) func(t *testing.T) {
+	return func(t *testing.T) {
+		putKeys(t, store, "/p/1", "/p/2", "/p/3", "/p/4", "/p/5", "/q/1")
+
+		// descending queries seek the end of their prefix, which is
+		// covered by SeekPrefixDesc
+		asc := []query.Order{query.OrderByKey{}}
+		assertKeys(t, store, query.Query{Prefix: "/p", Orders: asc},
+			"/p/1", "/p/2", "/p/3", "/p/4", "/p/5")
+		assertKeys(t, store, query.Query{Prefix: "/p", Orders: asc, Offset: 1, Limit: 2},
+			"/p/2", "/p/3")
+		assertKeys(t, store, query.Query{
+			Prefix:  "/p",
+			Orders:  asc,
+			Filters: []query.Filter{query.FilterKeyCompare{Op: query.NotEqual, Key: "/p/2"}},
+			Offset:  1,
+			Limit:   2,
+		}, "/p/3", "/p/4")
+	}
+}
+
+func testSeekPrefix(store kt.TxnDatastoreExtended) func(t *testing.T) {
+	return func(t *testing.T) {
+		putKeys(t, store, "/i/a/1", "/i/b/1", "/i/b/2", "/i/c/1", "/i/d/1")
+
+		asc := []query.Order{query.OrderByKey{}}
+		assertKeysExtended(t, store, dse.QueryExt{
+			Query:      query.Query{Prefix: "/i", Orders: asc},
+			SeekPrefix: "/i/b",
+		}, "/i/b/1", "/i/b/2", "/i/c/1", "/i/d/1")
+		assertKeysExtended(t, store, dse.QueryExt{
+			Query:      query.Query{Prefix: "/i", Orders: asc, Offset: 1, Limit: 2},
+			SeekPrefix: "/i/b/2",
+		}, "/i/c/1", "/i/d/1")
+		assertKeysExtended(t, store, dse.QueryExt{
+			Query:      query.Query{Prefix: "/i", Orders: asc},
+			SeekPrefix: "/i/e",
+		})
+	}
+}
+
+func testSeekPrefixDesc(store kt.TxnDatastoreExtended) func(t *testing.T) {
+	return func(t *testing.T) {
+		putKeys(t, store, "/i/a/1", "/i/b/1", "/i/b/2", "/i/c/1", "/i/d/1")
+
+		desc := []query.Order{query.OrderByKeyDescending{}}
+		// the keys under a prefix sort before the prefix and a byte
+		// higher than any in keys
+		assertKeysExtended(t, store, dse.QueryExt{
+			Query:      query.Query{Prefix: "/i", Orders: desc},
+			SeekPrefix: "/i/b\xff",
+		}, "/i/b/2", "/i/b/1", "/i/a/1")
+		assertKeysExtended(t, store, dse.QueryExt{
+			Query:      query.Query{Prefix: "/i", Orders: desc},
+			SeekPrefix: "/i/\xff",
+		}, "/i/d/1", "/i/c/1", "/i/b/2", "/i/b/1", "/i/a/1")
+		assertKeysExtended(t, store, dse.QueryExt{
+			Query:      query.Query{Prefix: "/i", Orders: desc, Offset: 1, Limit: 2},
+			SeekPrefix: "/i/c/1",
+		}, "/i/b/2", "/i/b/1")
+	}
+}
+
+func testReadYourWrites(store kt.TxnDatastoreExtended) func(t *testing.T) {
+	return func(t *testing.T) {
+		putKeys(t, store, "/k/1", "/k/2")
+
+		txn, err := store.NewTransactionExtended(false)
+		check(t, err)
+		defer txn.Discard()
+		check(t, txn.Put(ds.NewKey("/k/3"), []byte("3")))
+		check(t, txn.Put(ds.NewKey("/k/1"), []byte("one")))
+		check(t, txn.Delete(ds.NewKey("/k/2")))
+
+		v, err := txn.Get(ds.NewKey("/k/1"))
+		check(t, err)
+		if string(v) != "one" {
+			t.Fatalf("expected the value written with the transaction, got %s", v)
+		}
+		if _, err := txn.Get(ds.NewKey("/k/2")); !errors.Is(err, ds.ErrNotFound) {
+			t.Fatalf("expected the key deleted with the transaction to be not found, got %v", err)
+		}
+		if ok, err := txn.Has(ds.NewKey("/k/3")); err != nil || !ok {
+			t.Fatalf("expected the key written with the transaction, got %v", err)
+		}
+		assertEntries(t, txn, query.Query{Prefix: "/k", Orders: []query.Order{query.OrderByKey{}}},
+			"/k/1", "one", "/k/3", "3")
+		res, err := txn.QueryExtended(dse.QueryExt{
+			Query:      query.Query{Prefix: "/k", Orders: []query.Order{query.OrderByKey{}}},
+			SeekPrefix: "/k/2",
+		})
+		check(t, err)
+		assertResults(t, res, "/k/3")
+	}
+}
+
+func testDiscard(store kt.TxnDatastoreExtended) func(t *testing.T) {
+	return func(t *testing.T) {
+		putKeys(t, store, "/k/1")
+
+		txn, err := store.NewTransaction(false)
+		check(t, err)
+		check(t, txn.Put(ds.NewKey("/k/2"), []byte("2")))
+		check(t, txn.Delete(ds.NewKey("/k/1")))
+		txn.Discard()
+
+		assertKeys(t, store, query.Query{Prefix: "/k"}, "/k/1")
+	}
+}
+
+func testAtomicCommit(store kt.TxnDatastoreExtended) func(t *testing.T) {
+	return func(t *testing.T) {
+		putKeys(t, store, "/k/1", "/k/2")
+
+		txn, err := store.NewTransaction(false)
+		check(t, err)
+		defer txn.Discard()
+		for i := 3; i <= 10; i++ {
+			check(t, txn.Put(ds.NewKey(fmt.Sprintf("/k/%02d", i)), []byte("v")))
+		}
+		check(t, txn.Delete(ds.NewKey("/k/1")))
+
+		// nothing is written before the commit
+		assertKeys(t, store, query.Query{Prefix: "/k"}, "/k/1", "/k/2")
+		if _, err := store.Get(ds.NewKey("/k/03")); !errors.Is(err, ds.ErrNotFound) {
+			t.Fatalf("expected the key written with an open transaction to be not found, got %v", err)
+		}
+
+		check(t, txn.Commit())
+		assertKeys(t, store, query.Query{Prefix: "/k", Orders: []query.Order{query.OrderByKey{}}},
+			"/k/03", "/k/04", "/k/05", "/k/06", "/k/07", "/k/08", "/k/09", "/k/10", "/k/2")
+	}
+}
+
+func testSnapshotIsolation(store kt.TxnDatastoreExtended) func(t *testing.T) {
+	return func(t *testing.T) {
+		putKeys(t, store, "/k/1")
+
+		rtxn, err := store.NewTransactionExtended(true)
+		check(t, err)
+		defer rtxn.Discard()
+
+		txn, err := store.NewTransaction(false)
+		check(t, err)
+		check(t, txn.Put(ds.NewKey("/k/1"), []byte("one")))
+		check(t, txn.Put(ds.NewKey("/k/2"), []byte("2")))
+		check(t, txn.Commit())
+
+		v, err := rtxn.Get(ds.NewKey("/k/1"))
+		check(t, err)
+		if string(v) != "/k/1" {
+			t.Fatalf("expected the value as of when the transaction began, got %s", v)
+		}
+		assertEntries(t, rtxn, query.Query{Prefix: "/k"}, "/k/1", "/k/1")
+
+		v, err = store.Get(ds.NewKey("/k/1"))
+		check(t, err)
+		if string(v) != "one" {
+			t.Fatalf("expected the committed value, got %s", v)
+		}
+	}
+}
+
+func testReadOnlyTransaction(store kt.TxnDatastoreExtended) func(t *testing.T) {
+	return func(t *testing.T) {
+		txn, err := store.NewTransaction(true)
+		check(t, err)
+		defer txn.Discard()
+		err = txn.Put(ds.NewKey("/k/1"), []byte("1"))
+		if err == nil {
+			err = txn.Commit()
+		}
+		if err == nil {
+			t.Fatal("expected writing with a read-only transaction to fail")
+		}
+		assertKeys(t, store, query.Query{Prefix: "/k"})
+	}
+}
+
+func testPrefixedKeys(factory DatastoreFactory) func(t *testing.T) {
+	return func(t *testing.T) {
+		store, closeFunc := factory()
+		if closeFunc != nil {
+			defer closeFunc()
+		}
+		putKeys(t, store, "/a/1")
+		prefixed := kt.WrapTxnDatastore(store, keytransform.PrefixTransform{
+			Prefix: ds.NewKey("/prefixed"),
+		})
+		putKeys(t, prefixed, "/a/2")
+		txn, err := prefixed.NewTransaction(false)
+		check(t, err)
+		check(t, txn.Put(ds.NewKey("/a/3"), []byte("/a/3")))
+		check(t, txn.Commit())
+
+		assertKeys(t, store, query.Query{Prefix: "/a"}, "/a/1")
+		assertKeys(t, store, query.Query{Prefix: "/prefixed", Orders: []query.Order{query.OrderByKey{}}},
+			"/prefixed/a/2", "/prefixed/a/3")
+		assertKeys(t, prefixed, query.Query{Prefix: "/a", Orders: []query.Order{query.OrderByKey{}}},
+			"/a/2", "/a/3")
+	}
+}
+
+// putKeys commits the keys with a transaction, with the keys as values.
+func putKeys(t *testing.T, store kt.TxnDatastoreExtended, keys ...string) {
+	txn, err := store.NewTransaction(false)
+	check(t, err)
+	defer txn.Discard()
+	for _, k := range keys {
+		check(t, txn.Put(ds.NewKey(k), []byte(k)))
+	}
+	check(t, txn.Commit())
+}
+
+// assertKeys checks the keys of the results of q with store, in order if q
+// is ordered.
+func assertKeys(t *testing.T, store kt.TxnDatastoreExtended, q query.Query, keys ...string) {
+	t.Helper()
+	res, err := store.Query(q)
+	check(t, err)
+	assertResults(t, res, keys...)
+	assertKeysExtended(t, store, dse.QueryExt{Query: q}, keys...)
+}
+
+// assertKeysExtended checks the keys of the results of q, with store and a
+// read-only transaction of it.
+func assertKeysExtended(t *testing.T, store kt.TxnDatastoreExtended, q dse.QueryExt, keys ...string) {
+	t.Helper()
+	res, err := store.QueryExtended(q)
+	check(t, err)
+	assertResults(t, res, keys...)
+
+	txn, err := store.NewTransactionExtended(true)
+	check(t, err)
+	defer txn.Discard()
+	res, err = txn.QueryExtended(q)
+	check(t, err)
+	assertResults(t, res, keys...)
+}
+
+// assertEntries checks the keys and values of the results of q with txn.
+func assertEntries(t *testing.T, txn ds.Txn, q query.Query, keysAndValues ...string) {
+	t.Helper()
+	res, err := txn.Query(q)
+	check(t, err)
+	entries, err := res.Rest()
+	check(t, err)
+	if len(entries)*2 != len(keysAndValues) {
+		t.Fatalf("expected %d entries, got %d", len(keysAndValues)/2, len(entries))
+	}
+	for i, e := range entries {
+		if e.Key != keysAndValues[2*i] || string(e.Value) != keysAndValues[2*i+1] {
+			t.Fatalf("expected entry %s=%s, got %s=%s",
+				keysAndValues[2*i], keysAndValues[2*i+1], e.Key, e.Value)
+		}
+	}
+}
+
+// assertResults checks the keys of res, in order if the query is ordered.
+func assertResults(t *testing.T, res query.Results, keys ...string) {
+	t.Helper()
+	entries, err := res.Rest()
+	check(t, err)
+	got := make([]string, len(entries))
+	for i, e := range entries {
+		got[i] = e.Key
+	}
+	if len(got) != len(keys) {
+		t.Fatalf("expected keys %v, got %v", keys, got)
+	}
+	if len(res.Query().Orders) == 0 {
+		seen := make(map[string]struct{}, len(got))
+		for _, k := range got {
+			seen[k] = struct{}{}
+		}
+		for _, k := range keys {
+			if _, ok := seen[k]; !ok {
+				t.Fatalf("expected keys %v, got %v", keys, got)
+			}
+		}
+		return
+	}
+	for i := range keys {
+		if got[i] != keys[i] {
+			t.Fatalf("expected keys %v, got %v", keys, got)
+		}
+	}
+}
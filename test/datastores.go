@@ -0,0 +1,77 @@
+package test
+
+import (
+	"io/ioutil"
+	"os"
+
+	badger "github.com/textileio/go-ds-badger"
+	kt "github.com/textileio/go-threads/db/keytransform"
+	"github.com/textileio/go-threads/util/badger3ds"
+	"github.com/textileio/go-threads/util/badger4ds"
+	"github.com/textileio/go-threads/util/memds"
+)
+
+// DatastoreFactories are the factories of the stores dbs can be backed by,
+// by name.
+var DatastoreFactories = map[string]DatastoreFactory{
+	"Badger":   BadgerDatastore,
+	"BadgerV3": Badger3Datastore,
+	"BadgerV4": Badger4Datastore,
+	"Memory":   MemoryDatastore,
+}
+
+// BadgerDatastore returns a badger store in a temporary directory, which is
+// removed by the closer.
+func BadgerDatastore() (kt.TxnDatastoreExtended, func()) {
+	return tempStore("badger", func(path string) (kt.TxnDatastoreExtended, error) {
+		return badger.NewDatastore(path, nil)
+	})
+}
+
+// Badger3Datastore returns a badger v3 store in a temporary directory, which
+// is removed by the closer. It doesn't implement the query extensions, so
+// they're emulated.
+func Badger3Datastore() (kt.TxnDatastoreExtended, func()) {
+	return tempStore("badger3", func(path string) (kt.TxnDatastoreExtended, error) {
+		store, err := badger3ds.NewDatastore(path, nil)
+		if err != nil {
+			return nil, err
+		}
+		return kt.ExtendTxnDatastore(store), nil
+	})
+}
+
+// Badger4Datastore returns a badger v4 store in a temporary directory, which
+// is removed by the closer. It doesn't implement the query extensions, so
+// they're emulated.
+func Badger4Datastore() (kt.TxnDatastoreExtended, func()) {
+	return tempStore("badger4", func(path string) (kt.TxnDatastoreExtended, error) {
+		store, err := badger4ds.NewDatastore(path, nil)
+		if err != nil {
+			return nil, err
+		}
+		return kt.ExtendTxnDatastore(store), nil
+	})
+}
+
+// MemoryDatastore returns an in-memory store. It doesn't implement the query
+// extensions, so they're emulated.
+func MemoryDatastore() (kt.TxnDatastoreExtended, func()) {
+	return kt.ExtendTxnDatastore(memds.New()), func() {}
+}
+
+func tempStore(name string, open func(path string) (kt.TxnDatastoreExtended, error)) (kt.TxnDatastoreExtended, func()) {
+	dataPath, err := ioutil.TempDir(os.TempDir(), name)
+	if err != nil {
+		panic(err)
+	}
+	store, err := open(dataPath)
+	if err != nil {
+		panic(err)
+	}
+	closer := func() {
+		_ = store.Close()
+		_ = os.RemoveAll(dataPath)
+	}
+	return store, closer
+}
@@ -16,11 +16,16 @@ import (
 	connmgr "github.com/libp2p/go-libp2p-connmgr"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/namsral/flag"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	sym "github.com/textileio/crypto/symmetric"
 	mongods "github.com/textileio/go-ds-mongo"
 	"github.com/textileio/go-threads/api"
 	pb "github.com/textileio/go-threads/api/pb"
 	"github.com/textileio/go-threads/common"
+	nc "github.com/textileio/go-threads/core/net"
 	kt "github.com/textileio/go-threads/db/keytransform"
+	tnet "github.com/textileio/go-threads/net"
 	netapi "github.com/textileio/go-threads/net/api"
 	netpb "github.com/textileio/go-threads/net/api/pb"
 	"github.com/textileio/go-threads/util"
@@ -37,6 +42,7 @@ func main() {
 	announceAddrStr := fs.String("announceAddr", "", "Libp2p announce address") // Should be supplied as multiaddr, /ip4/<your_public_ip>/tcp/4006
 	apiAddrStr := fs.String("apiAddr", "/ip4/127.0.0.1/tcp/6006", "gRPC API bind address")
 	apiProxyAddrStr := fs.String("apiProxyAddr", "/ip4/127.0.0.1/tcp/6007", "gRPC API web proxy bind address")
+	metricsAddrStr := fs.String("metricsAddr", "", "Prometheus metrics bind address, served at /metrics (disabled if not provided)")
 	connLowWater := fs.Uint("connLowWater", 100, "Low watermark of libp2p connections that'll be maintained")
 	connHighWater := fs.Uint("connHighWater", 400, "High watermark of libp2p connections that'll be maintained")
 	connGracePeriod := fs.Duration("connGracePeriod", time.Second*20, "Duration a new opened connection is not subject to pruning")
@@ -48,9 +54,19 @@ func main() {
 	netPullingInterval := fs.Duration("netPullingInterval", time.Second*10, "Interval at which threads are pulled from network peers (must be > 0)")
 	disableExchangeEdgesMigration := fs.Bool("disableExchangeEdgesMigration", false, "Disables automatic thread migration to the exchangeEdges protocol")
 	enableNetPubsub := fs.Bool("enableNetPubsub", false, "Enables thread networking over libp2p pubsub")
+	netPubsubStrategy := fs.String("netPubsubStrategy", "thread", "Pubsub strategy of threads without one in their metadata (thread, sharded or disabled)")
+	netPubsubShards := fs.Int("netPubsubShards", 0, "Number of pubsub topics of the sharded strategy (0 for the default)")
+	netPushRecordRate := fs.Float64("netPushRecordRate", tnet.DefaultRateLimits.PushRecord.Rate, "Push record requests per second allowed from each peer (0 for no limit)")
+	netPushRecordBurst := fs.Int("netPushRecordBurst", tnet.DefaultRateLimits.PushRecord.Burst, "Burst of push record requests allowed from each peer")
+	netGetRecordsRate := fs.Float64("netGetRecordsRate", tnet.DefaultRateLimits.GetRecords.Rate, "Get records requests per second allowed from each peer (0 for no limit)")
+	netGetRecordsBurst := fs.Int("netGetRecordsBurst", tnet.DefaultRateLimits.GetRecords.Burst, "Burst of get records requests allowed from each peer")
+	netGetRecordsReplicatorRate := fs.Float64("netGetRecordsReplicatorRate", tnet.DefaultRateLimits.GetRecordsReplicator.Rate, "Get records requests per second allowed from each replicator of the thread (0 for no limit)")
+	netGetRecordsReplicatorBurst := fs.Int("netGetRecordsReplicatorBurst", tnet.DefaultRateLimits.GetRecordsReplicator.Burst, "Burst of get records requests allowed from each replicator of the thread")
 	mongoUri := fs.String("mongoUri", "", "MongoDB URI (if not provided, an embedded Badger datastore will be used)")
 	mongoDatabase := fs.String("mongoDatabase", "", "MongoDB database name (required with mongoUri")
 	badgerLowMem := fs.Bool("badgerLowMem", false, "Use Badger's low memory settings")
+	gcInterval := fs.Duration("gcInterval", 0, "Interval at which the garbage of the datastores is collected (disabled if 0)")
+	dbEncryptionKey := fs.String("dbEncryptionKey", "", "Base32 encoded key the values of the DB datastore are encrypted with at rest (plaintext if not provided)")
 	debug := fs.Bool("debug", false, "Enables debug logging")
 	logFile := fs.String("logFile", "", "File to write logs to")
 	if err := fs.Parse(os.Args[1:]); err != nil {
@@ -76,6 +92,21 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	var metricsAddr ma.Multiaddr
+	if *metricsAddrStr != "" {
+		metricsAddr, err = ma.NewMultiaddr(*metricsAddrStr)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var encryptionKey *sym.Key
+	if len(*dbEncryptionKey) != 0 {
+		encryptionKey, err = sym.FromString(*dbEncryptionKey)
+		if err != nil {
+			log.Fatalf("parsing dbEncryptionKey: %v", err)
+		}
+	}
 
 	var parsedMongoUri *url.URL
 	if len(*mongoUri) != 0 {
@@ -106,17 +137,30 @@ func main() {
 	}
 	log.Debugf("apiAddr: %v", *apiAddrStr)
 	log.Debugf("apiProxyAddr: %v", *apiProxyAddrStr)
+	if metricsAddr != nil {
+		log.Debugf("metricsAddr: %v", *metricsAddrStr)
+	}
 	log.Debugf("connLowWater: %v", *connLowWater)
 	log.Debugf("connHighWater: %v", *connHighWater)
 	log.Debugf("connGracePeriod: %v", *connGracePeriod)
 	log.Debugf("keepAliveInterval: %v", *keepAliveInterval)
 	log.Debugf("enableNetPubsub: %v", *enableNetPubsub)
+	log.Debugf("netPubsubStrategy: %v", *netPubsubStrategy)
+	log.Debugf("netPubsubShards: %v", *netPubsubShards)
+	log.Debugf("netPushRecordRate: %v", *netPushRecordRate)
+	log.Debugf("netPushRecordBurst: %v", *netPushRecordBurst)
+	log.Debugf("netGetRecordsRate: %v", *netGetRecordsRate)
+	log.Debugf("netGetRecordsBurst: %v", *netGetRecordsBurst)
+	log.Debugf("netGetRecordsReplicatorRate: %v", *netGetRecordsReplicatorRate)
+	log.Debugf("netGetRecordsReplicatorBurst: %v", *netGetRecordsReplicatorBurst)
 	if parsedMongoUri != nil {
 		log.Debugf("mongoUri: %v", parsedMongoUri.Redacted())
 		log.Debugf("mongoDatabase: %v", *mongoDatabase)
 	} else {
 		log.Debugf("badgerLowMem: %v", *badgerLowMem)
 	}
+	log.Debugf("gcInterval: %v", *gcInterval)
+	log.Debugf("dbEncryptionKey: %v", encryptionKey != nil)
 	log.Debugf("debug: %v", *debug)
 
 	opts := []common.NetOption{
@@ -132,8 +176,17 @@ func main() {
 		common.WithNoExchangeEdgesMigration(*disableExchangeEdgesMigration),
 		common.WithNetPubSub(*enableNetPubsub),
 		common.WithNetLogstore(common.LogstoreHybrid),
+		common.WithNetGCInterval(*gcInterval),
+		common.WithNetRateLimits(tnet.RateLimits{
+			PushRecord:           tnet.RateLimit{Rate: *netPushRecordRate, Burst: *netPushRecordBurst},
+			GetRecords:           tnet.RateLimit{Rate: *netGetRecordsRate, Burst: *netGetRecordsBurst},
+			GetRecordsReplicator: tnet.RateLimit{Rate: *netGetRecordsReplicatorRate, Burst: *netGetRecordsReplicatorBurst},
+		}),
 		common.WithNetDebug(*debug),
 	}
+	if *enableNetPubsub {
+		opts = append(opts, common.WithNetPubSubStrategy(nc.PubSubStrategy(*netPubsubStrategy), *netPubsubShards))
+	}
 	if parsedMongoUri != nil {
 		opts = append(opts, common.WithNetMongoPersistence(*mongoUri, *mongoDatabase))
 	} else {
@@ -142,6 +195,15 @@ func main() {
 	if announceAddr != nil {
 		opts = append(opts, common.WithAnnounceAddr(announceAddr))
 	}
+	var registry *prometheus.Registry
+	if metricsAddr != nil {
+		registry = prometheus.NewRegistry()
+		registry.MustRegister(
+			prometheus.NewGoCollector(),
+			prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		)
+		opts = append(opts, common.WithNetMetrics(registry))
+	}
 	n, err := common.DefaultNetwork(opts...)
 	if err != nil {
 		log.Fatal(err)
@@ -161,7 +223,9 @@ func main() {
 		log.Fatal(err)
 	}
 	service, err := api.NewService(store, n, api.Config{
-		Debug: *debug,
+		GCInterval:    *gcInterval,
+		EncryptionKey: encryptionKey,
+		Debug:         *debug,
 	})
 	if err != nil {
 		log.Fatal(err)
@@ -220,6 +284,25 @@ func main() {
 		}
 	}()
 
+	var metrics *http.Server
+	if metricsAddr != nil {
+		mtarget, err := util.TCPAddrFromMultiAddr(metricsAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+		metrics = &http.Server{
+			Addr:    mtarget,
+			Handler: mux,
+		}
+		go func() {
+			if err := metrics.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("metrics error: %v", err)
+			}
+		}()
+	}
+
 	fmt.Println("Welcome to Threads!")
 	fmt.Println("Your peer ID is " + n.Host().ID().String())
 
@@ -229,6 +312,11 @@ func main() {
 		if err := proxy.Shutdown(ctx); err != nil {
 			log.Fatal(err)
 		}
+		if metrics != nil {
+			if err := metrics.Shutdown(ctx); err != nil {
+				log.Fatal(err)
+			}
+		}
 		util.StopGRPCServer(server)
 		if err := n.Close(); err != nil {
 			log.Fatal(err)
@@ -0,0 +1,99 @@
+// Package badger3ds provides a transactional datastore over a badger v3 db,
+// which can back dbs and logstores in place of the badger v1 ones.
+package badger3ds
+
+import (
+	"errors"
+
+	badger "github.com/dgraph-io/badger/v3"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/textileio/go-threads/util/badgerds"
+)
+
+// NewDatastore opens the badger v3 db at path with opts, or with the default
+// options if nil, and returns a datastore over it.
+func NewDatastore(path string, opts *badger.Options) (*badgerds.Datastore, error) {
+	o := badger.DefaultOptions(path)
+	if opts != nil {
+		o = *opts
+		o.Dir = path
+		o.ValueDir = path
+	}
+	db, err := badger.Open(o)
+	if err != nil {
+		return nil, err
+	}
+	return Wrap(db), nil
+}
+
+// Wrap returns a datastore over db, which is closed with it.
+func Wrap(db *badger.DB) *badgerds.Datastore {
+	return badgerds.New(&dbAdapter{db: db})
+}
+
+type dbAdapter struct {
+	db *badger.DB
+}
+
+func (a *dbAdapter) NewTransaction(update bool) badgerds.Txn {
+	return &txnAdapter{txn: a.db.NewTransaction(update)}
+}
+
+func (a *dbAdapter) Sync() error {
+	return a.db.Sync()
+}
+
+func (a *dbAdapter) Close() error {
+	return a.db.Close()
+}
+
+type txnAdapter struct {
+	txn *badger.Txn
+}
+
+func (a *txnAdapter) Get(key []byte) ([]byte, error) {
+	item, err := a.txn.Get(key)
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return nil, ds.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return item.ValueCopy(nil)
+}
+
+func (a *txnAdapter) Set(key, value []byte) error {
+	return a.txn.Set(key, value)
+}
+
+func (a *txnAdapter) Delete(key []byte) error {
+	return a.txn.Delete(key)
+}
+
+func (a *txnAdapter) Iterate(prefix []byte, keysOnly bool, fn func(key, value []byte, size int)) error {
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = !keysOnly
+	opts.Prefix = prefix
+	it := a.txn.NewIterator(opts)
+	defer it.Close()
+	for it.Rewind(); it.Valid(); it.Next() {
+		item := it.Item()
+		if keysOnly {
+			fn(item.KeyCopy(nil), nil, int(item.ValueSize()))
+			continue
+		}
+		value, err := item.ValueCopy(nil)
+		if err != nil {
+			return err
+		}
+		fn(item.KeyCopy(nil), value, len(value))
+	}
+	return nil
+}
+
+func (a *txnAdapter) Commit() error {
+	return a.txn.Commit()
+}
+
+func (a *txnAdapter) Discard() {
+	a.txn.Discard()
+}
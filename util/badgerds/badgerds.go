@@ -0,0 +1,185 @@
+// Package badgerds provides a transactional datastore over a badger db of a
+// newer major version than the v1 of go-ds-badger. The versions are adapted to
+// it by the badger3ds and badger4ds packages.
+package badgerds
+
+import (
+	"path"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+)
+
+// DB is a badger db, of whichever major version.
+type DB interface {
+	// NewTransaction returns a transaction reading from the db as of now,
+	// which may write if update is true.
+	NewTransaction(update bool) Txn
+	Sync() error
+	Close() error
+}
+
+// Txn is a transaction of a badger db.
+type Txn interface {
+	// Get returns a copy of the value of key, or ds.ErrNotFound if it's
+	// missing.
+	Get(key []byte) ([]byte, error)
+	Set(key, value []byte) error
+	Delete(key []byte) error
+	// Iterate calls fn with copies of the keys with prefix, of their values
+	// and with the sizes of the values, in key order. Values are nil if
+	// keysOnly is true.
+	Iterate(prefix []byte, keysOnly bool, fn func(key, value []byte, size int)) error
+	Commit() error
+	Discard()
+}
+
+var (
+	_ ds.Batching     = (*Datastore)(nil)
+	_ ds.TxnDatastore = (*Datastore)(nil)
+)
+
+// Datastore is a transactional datastore over a badger db. Its writes outside
+// of transactions, and its batches, are committed as transactions of their
+// own.
+type Datastore struct {
+	db DB
+}
+
+// New returns a datastore over db, which is closed with it.
+func New(db DB) *Datastore {
+	return &Datastore{db: db}
+}
+
+func (d *Datastore) Get(key ds.Key) ([]byte, error) {
+	t := d.db.NewTransaction(false)
+	defer t.Discard()
+	return t.Get(key.Bytes())
+}
+
+func (d *Datastore) Has(key ds.Key) (bool, error) {
+	return ds.GetBackedHas(d, key)
+}
+
+func (d *Datastore) GetSize(key ds.Key) (int, error) {
+	return ds.GetBackedSize(d, key)
+}
+
+func (d *Datastore) Query(q dsq.Query) (dsq.Results, error) {
+	t := d.db.NewTransaction(false)
+	defer t.Discard()
+	return query(t, q)
+}
+
+func (d *Datastore) Put(key ds.Key, value []byte) error {
+	return d.update(func(t Txn) error {
+		return t.Set(key.Bytes(), value)
+	})
+}
+
+func (d *Datastore) Delete(key ds.Key) error {
+	return d.update(func(t Txn) error {
+		return t.Delete(key.Bytes())
+	})
+}
+
+func (d *Datastore) Sync(ds.Key) error {
+	return d.db.Sync()
+}
+
+func (d *Datastore) Close() error {
+	return d.db.Close()
+}
+
+// Batch returns a batch of writes, applied at once when committed.
+func (d *Datastore) Batch() (ds.Batch, error) {
+	return &txn{t: d.db.NewTransaction(true)}, nil
+}
+
+// NewTransaction returns a transaction reading from the datastore as of now.
+func (d *Datastore) NewTransaction(readOnly bool) (ds.Txn, error) {
+	return &txn{t: d.db.NewTransaction(!readOnly)}, nil
+}
+
+// update commits the writes of fn as a transaction.
+func (d *Datastore) update(fn func(Txn) error) error {
+	t := d.db.NewTransaction(true)
+	defer t.Discard()
+	if err := fn(t); err != nil {
+		return err
+	}
+	return t.Commit()
+}
+
+type txn struct {
+	t Txn
+}
+
+var _ ds.Txn = (*txn)(nil)
+
+func (t *txn) Get(key ds.Key) ([]byte, error) {
+	return t.t.Get(key.Bytes())
+}
+
+func (t *txn) Has(key ds.Key) (bool, error) {
+	return ds.GetBackedHas(t, key)
+}
+
+func (t *txn) GetSize(key ds.Key) (int, error) {
+	return ds.GetBackedSize(t, key)
+}
+
+func (t *txn) Query(q dsq.Query) (dsq.Results, error) {
+	return query(t.t, q)
+}
+
+func (t *txn) Put(key ds.Key, value []byte) error {
+	return t.t.Set(key.Bytes(), value)
+}
+
+func (t *txn) Delete(key ds.Key) error {
+	return t.t.Delete(key.Bytes())
+}
+
+func (t *txn) Commit() error {
+	defer t.t.Discard()
+	return t.t.Commit()
+}
+
+func (t *txn) Discard() {
+	t.t.Discard()
+}
+
+// query collects the entries under the prefix of q as of t and applies the
+// rest of q to them.
+func query(t Txn, q dsq.Query) (dsq.Results, error) {
+	var entries []dsq.Entry
+	err := t.Iterate(queryPrefix(q), q.KeysOnly, func(key, value []byte, size int) {
+		entries = append(entries, dsq.Entry{
+			Key:   string(key),
+			Value: value,
+			Size:  size,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dsq.NaiveQueryApply(q, dsq.ResultsWithEntries(q, entries)), nil
+}
+
+// queryPrefix returns the key prefix of the entries matching the prefix of q,
+// which is cleaned as a key so /bar only matches /bar/baz, not /barbaz.
+func queryPrefix(q dsq.Query) []byte {
+	prefix := q.Prefix
+	if prefix == "" {
+		return nil
+	}
+	if prefix[0] != '/' {
+		prefix = "/" + prefix
+	}
+	prefix = path.Clean(prefix)
+	if prefix == "/" {
+		return nil
+	}
+	return []byte(prefix + "/")
+}
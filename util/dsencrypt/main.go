@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	logging "github.com/ipfs/go-log/v2"
+	"github.com/namsral/flag"
+	sym "github.com/textileio/crypto/symmetric"
+	badger "github.com/textileio/go-ds-badger"
+	mongods "github.com/textileio/go-ds-mongo"
+	"github.com/textileio/go-threads/db/encryption"
+	kt "github.com/textileio/go-threads/db/keytransform"
+)
+
+var log = logging.Logger("dsencrypt")
+
+// storeName is the name of the DB datastore of threadsd.
+const storeName = "eventstore"
+
+func main() {
+	fs := flag.NewFlagSet(os.Args[0], 0)
+
+	badgerRepo := fs.String("badgerRepo", "", "Badger repo path")
+	mongoUri := fs.String("mongoUri", "", "MongoDB URI")
+	mongoDatabase := fs.String("mongoDatabase", "", "MongoDB database")
+
+	keyStr := fs.String("key", "", "Base32 encoded encryption key (a new one is generated and printed if not provided)")
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		log.Fatal(err)
+	}
+
+	logging.SetupLogging(logging.Config{
+		Format: logging.ColorizedOutput,
+		Stderr: true,
+		Level:  logging.LevelError,
+	})
+	if err := logging.SetLogLevel("dsencrypt", "info"); err != nil {
+		log.Fatal(err)
+	}
+
+	var key *sym.Key
+	var err error
+	if len(*keyStr) != 0 {
+		key, err = sym.FromString(*keyStr)
+		if err != nil {
+			log.Fatalf("parsing key: %v", err)
+		}
+	} else {
+		key = sym.New()
+		fmt.Println(key.String())
+	}
+
+	start := time.Now()
+	if err := encryptDatastore(*badgerRepo, *mongoUri, *mongoDatabase, key); err != nil {
+		log.Fatal(err)
+	}
+	log.Infof("done in %s", time.Since(start))
+}
+
+// encryptDatastore encrypts the DB datastore of the badger repo or mongo
+// database in place.
+func encryptDatastore(badgerRepo, mongoUri, mongoDatabase string, key *sym.Key) error {
+	if len(badgerRepo) != 0 && len(mongoUri) != 0 {
+		return fmt.Errorf("multiple datastores specified")
+	}
+	if len(badgerRepo) == 0 && len(mongoUri) == 0 {
+		return fmt.Errorf("datastore not specified")
+	}
+
+	var store kt.TxnDatastoreExtended
+	var err error
+	if len(badgerRepo) != 0 {
+		path := filepath.Join(badgerRepo, storeName)
+		store, err = badger.NewDatastore(path, &badger.DefaultOptions)
+		if err != nil {
+			return fmt.Errorf("connecting to badger: %v", err)
+		}
+		log.Infof("connected to badger: %s", path)
+	} else {
+		uri, err := url.Parse(mongoUri)
+		if err != nil {
+			return fmt.Errorf("parsing mongo URI: %v", err)
+		}
+		if len(mongoDatabase) == 0 {
+			return fmt.Errorf("mongo database not specified")
+		}
+		store, err = mongods.New(context.Background(), mongoUri, mongoDatabase, mongods.WithCollName(storeName))
+		if err != nil {
+			return fmt.Errorf("connecting to mongo: %v", err)
+		}
+		log.Infof("connected to mongo: %s", uri.Redacted())
+	}
+	defer store.Close()
+
+	if err := encryption.Encrypt(store, key); err != nil {
+		return fmt.Errorf("encrypting: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	bdg "github.com/dgraph-io/badger"
+	ds "github.com/ipfs/go-datastore"
+	badger "github.com/textileio/go-ds-badger"
+)
+
+// badgerGCDiscardRatio is the ratio of the garbage of the value log files of
+// badger which are rewritten.
+const badgerGCDiscardRatio = 0.5
+
+// GarbageCollector is a datastore collecting its garbage on demand.
+type GarbageCollector interface {
+	// GC collects the garbage of the datastore, returning the bytes
+	// reclaimed. It stops early if ctx is canceled.
+	GC(ctx context.Context) (uint64, error)
+}
+
+// CollectGarbage collects the garbage of store, or of the one it wraps,
+// returning the bytes reclaimed. Datastores without garbage, like in-memory
+// ones, reclaim nothing.
+func CollectGarbage(ctx context.Context, store ds.Datastore) (uint64, error) {
+	switch s := store.(type) {
+	case GarbageCollector:
+		return s.GC(ctx)
+	case ds.GCDatastore:
+		before, err := ds.DiskUsage(s)
+		if err != nil {
+			return 0, err
+		}
+		if err := s.CollectGarbage(); err != nil {
+			return 0, err
+		}
+		after, err := ds.DiskUsage(s)
+		if err != nil {
+			return 0, err
+		}
+		return reclaimed(before, after), nil
+	case ds.Shim:
+		var total uint64
+		for _, c := range s.Children() {
+			n, err := CollectGarbage(ctx, c)
+			if err != nil {
+				return total, err
+			}
+			total += n
+		}
+		return total, nil
+	default:
+		return 0, nil
+	}
+}
+
+// BadgerDatastore is a badger based datastore collecting the garbage of its
+// value log with GC.
+type BadgerDatastore struct {
+	*badger.Datastore
+	path string
+	lock sync.Mutex
+}
+
+var _ GarbageCollector = (*BadgerDatastore)(nil)
+
+// GC rewrites the value log files of badger with enough garbage until none
+// are left, while the datastore remains usable. The bytes reclaimed are those
+// the files of the datastore shrunk by, which lag behind for files still read
+// by iterators, as they're deleted once the iterators are closed.
+func (d *BadgerDatastore) GC(ctx context.Context) (uint64, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	before, err := dirSize(d.path)
+	if err != nil {
+		return 0, err
+	}
+	for ctx.Err() == nil {
+		err = d.DB.RunValueLogGC(badgerGCDiscardRatio)
+		// rejected collections are running already, as the periodic
+		// one of the datastore
+		if errors.Is(err, bdg.ErrNoRewrite) || errors.Is(err, bdg.ErrRejected) {
+			break
+		} else if err != nil {
+			return 0, err
+		}
+	}
+	after, err := dirSize(d.path)
+	if err != nil {
+		return 0, err
+	}
+	return reclaimed(before, after), ctx.Err()
+}
+
+func reclaimed(before, after uint64) uint64 {
+	if after > before {
+		return 0
+	}
+	return before - after
+}
+
+// dirSize returns the size of the files in the directory at path.
+func dirSize(path string) (uint64, error) {
+	var size uint64
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) {
+			// files deleted while walking are skipped
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += uint64(info.Size())
+		}
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,329 @@
+// Package memds provides a transactional in-memory datastore, which can back
+// dbs and logstores where nothing needs to be persisted, like in tests.
+package memds
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+)
+
+var (
+	// ErrConflict indicates a transaction read a key written by another
+	// transaction committed since it began.
+	ErrConflict = errors.New("transaction conflict, please retry")
+	// ErrReadOnly indicates a write with a read-only transaction.
+	ErrReadOnly = errors.New("transaction is read-only")
+	// ErrDiscarded indicates the use of a committed or discarded
+	// transaction.
+	ErrDiscarded = errors.New("transaction was committed or discarded")
+)
+
+var (
+	_ ds.Batching     = (*Datastore)(nil)
+	_ ds.TxnDatastore = (*Datastore)(nil)
+)
+
+// version is the value of a key as of a commit.
+type version struct {
+	ts      uint64
+	value   []byte
+	deleted bool
+}
+
+// Datastore is a transactional in-memory datastore. Transactions read from a
+// snapshot of it as of when they began, along with their own writes, and
+// apply their writes at once when committed. Committing fails with
+// ErrConflict if a key read by the transaction was written since it began.
+type Datastore struct {
+	lock sync.RWMutex
+	// ts is the timestamp of the latest commit.
+	ts   uint64
+	keys map[ds.Key][]version
+	// readers counts the open transactions by the timestamps they read at,
+	// which decides the versions of keys kept.
+	readers map[uint64]int
+	// stale are the keys with versions which may no longer be read.
+	stale map[ds.Key]struct{}
+}
+
+// New returns an empty in-memory datastore.
+func New() *Datastore {
+	return &Datastore{
+		keys:    make(map[ds.Key][]version),
+		readers: make(map[uint64]int),
+		stale:   make(map[ds.Key]struct{}),
+	}
+}
+
+func (d *Datastore) Get(key ds.Key) ([]byte, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return d.get(key, d.ts)
+}
+
+func (d *Datastore) Has(key ds.Key) (bool, error) {
+	return ds.GetBackedHas(d, key)
+}
+
+func (d *Datastore) GetSize(key ds.Key) (int, error) {
+	return ds.GetBackedSize(d, key)
+}
+
+func (d *Datastore) Query(q dsq.Query) (dsq.Results, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return dsq.NaiveQueryApply(q, dsq.ResultsWithEntries(q, d.entries(q, d.ts, nil))), nil
+}
+
+func (d *Datastore) Put(key ds.Key, value []byte) error {
+	return d.commit(nil, map[ds.Key]*version{key: {value: copyValue(value)}})
+}
+
+func (d *Datastore) Delete(key ds.Key) error {
+	return d.commit(nil, map[ds.Key]*version{key: {deleted: true}})
+}
+
+func (d *Datastore) Sync(ds.Key) error {
+	return nil
+}
+
+func (d *Datastore) Close() error {
+	return nil
+}
+
+// Batch returns a batch of writes, applied at once when committed.
+func (d *Datastore) Batch() (ds.Batch, error) {
+	return d.newTxn(false), nil
+}
+
+// NewTransaction returns a transaction reading from the datastore as of now.
+func (d *Datastore) NewTransaction(readOnly bool) (ds.Txn, error) {
+	return d.newTxn(readOnly), nil
+}
+
+func (d *Datastore) newTxn(readOnly bool) *txn {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.readers[d.ts]++
+	return &txn{
+		d:        d,
+		readTs:   d.ts,
+		readOnly: readOnly,
+		reads:    make(map[ds.Key]struct{}),
+		writes:   make(map[ds.Key]*version),
+	}
+}
+
+// get returns the value of key as of ts. The lock must be held.
+func (d *Datastore) get(key ds.Key, ts uint64) ([]byte, error) {
+	v := d.version(key, ts)
+	if v == nil || v.deleted {
+		return nil, ds.ErrNotFound
+	}
+	return copyValue(v.value), nil
+}
+
+// version returns the version of key as of ts. The lock must be held.
+func (d *Datastore) version(key ds.Key, ts uint64) *version {
+	vs := d.keys[key]
+	for i := len(vs) - 1; i >= 0; i-- {
+		if vs[i].ts <= ts {
+			return &vs[i]
+		}
+	}
+	return nil
+}
+
+// entries returns the entries of the keys as of ts, sorted by key, with the
+// pending writes applied. The lock must be held.
+func (d *Datastore) entries(q dsq.Query, ts uint64, writes map[ds.Key]*version) []dsq.Entry {
+	values := make(map[ds.Key][]byte)
+	for k := range d.keys {
+		if v := d.version(k, ts); v != nil && !v.deleted {
+			values[k] = v.value
+		}
+	}
+	for k, v := range writes {
+		if v.deleted {
+			delete(values, k)
+		} else {
+			values[k] = v.value
+		}
+	}
+	entries := make([]dsq.Entry, 0, len(values))
+	for k, v := range values {
+		e := dsq.Entry{Key: k.String(), Size: len(v)}
+		if !q.KeysOnly {
+			e.Value = copyValue(v)
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+	return entries
+}
+
+// commit applies writes as a new commit, if none of the keys read as of the
+// timestamp of reads were written since.
+func (d *Datastore) commit(reads *txn, writes map[ds.Key]*version) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if reads != nil {
+		for k := range reads.reads {
+			if vs := d.keys[k]; len(vs) > 0 && vs[len(vs)-1].ts > reads.readTs {
+				return ErrConflict
+			}
+		}
+	}
+	if len(writes) == 0 {
+		return nil
+	}
+	d.ts++
+	for k, v := range writes {
+		v.ts = d.ts
+		d.keys[k] = append(d.keys[k], *v)
+		d.stale[k] = struct{}{}
+	}
+	d.prune()
+	return nil
+}
+
+// release forgets a transaction reading as of ts. The lock must be held.
+func (d *Datastore) release(ts uint64) {
+	d.readers[ts]--
+	if d.readers[ts] == 0 {
+		delete(d.readers, ts)
+	}
+	d.prune()
+}
+
+// prune drops the versions of the stale keys which no transaction may read
+// anymore. The lock must be held.
+func (d *Datastore) prune() {
+	oldest := d.ts
+	for ts := range d.readers {
+		if ts < oldest {
+			oldest = ts
+		}
+	}
+	for k := range d.stale {
+		vs := d.keys[k]
+		i := len(vs) - 1
+		for i > 0 && vs[i].ts > oldest {
+			i--
+		}
+		vs = vs[i:]
+		switch {
+		case len(vs) == 1 && vs[0].deleted:
+			delete(d.keys, k)
+			delete(d.stale, k)
+		case len(vs) == 1:
+			d.keys[k] = vs
+			delete(d.stale, k)
+		default:
+			d.keys[k] = vs
+		}
+	}
+}
+
+type txn struct {
+	d        *Datastore
+	readTs   uint64
+	readOnly bool
+	done     bool
+	reads    map[ds.Key]struct{}
+	writes   map[ds.Key]*version
+}
+
+var _ ds.Txn = (*txn)(nil)
+
+func (t *txn) Get(key ds.Key) ([]byte, error) {
+	if t.done {
+		return nil, ErrDiscarded
+	}
+	if v, ok := t.writes[key]; ok {
+		if v.deleted {
+			return nil, ds.ErrNotFound
+		}
+		return copyValue(v.value), nil
+	}
+	t.reads[key] = struct{}{}
+	t.d.lock.RLock()
+	defer t.d.lock.RUnlock()
+	return t.d.get(key, t.readTs)
+}
+
+func (t *txn) Has(key ds.Key) (bool, error) {
+	return ds.GetBackedHas(t, key)
+}
+
+func (t *txn) GetSize(key ds.Key) (int, error) {
+	return ds.GetBackedSize(t, key)
+}
+
+func (t *txn) Query(q dsq.Query) (dsq.Results, error) {
+	if t.done {
+		return nil, ErrDiscarded
+	}
+	t.d.lock.RLock()
+	entries := t.d.entries(q, t.readTs, t.writes)
+	t.d.lock.RUnlock()
+	for _, e := range entries {
+		t.reads[ds.RawKey(e.Key)] = struct{}{}
+	}
+	return dsq.NaiveQueryApply(q, dsq.ResultsWithEntries(q, entries)), nil
+}
+
+func (t *txn) Put(key ds.Key, value []byte) error {
+	if t.done {
+		return ErrDiscarded
+	}
+	if t.readOnly {
+		return ErrReadOnly
+	}
+	t.writes[key] = &version{value: copyValue(value)}
+	return nil
+}
+
+func (t *txn) Delete(key ds.Key) error {
+	if t.done {
+		return ErrDiscarded
+	}
+	if t.readOnly {
+		return ErrReadOnly
+	}
+	t.writes[key] = &version{deleted: true}
+	return nil
+}
+
+func (t *txn) Commit() error {
+	if t.done {
+		return ErrDiscarded
+	}
+	defer t.Discard()
+	return t.d.commit(t, t.writes)
+}
+
+func (t *txn) Discard() {
+	if t.done {
+		return
+	}
+	t.done = true
+	t.d.lock.Lock()
+	defer t.d.lock.Unlock()
+	t.d.release(t.readTs)
+}
+
+func copyValue(v []byte) []byte {
+	if v == nil {
+		return nil
+	}
+	c := make([]byte, len(v))
+	copy(c, v)
+	return c
+}
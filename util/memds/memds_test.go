@@ -0,0 +1,70 @@
+package memds
+
+import (
+	"errors"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+)
+
+func TestConflict(t *testing.T) {
+	d := New()
+	key := ds.NewKey("/k")
+	checkErr(t, d.Put(key, []byte("0")))
+
+	t1, err := d.NewTransaction(false)
+	checkErr(t, err)
+	t2, err := d.NewTransaction(false)
+	checkErr(t, err)
+	_, err = t1.Get(key)
+	checkErr(t, err)
+	checkErr(t, t1.Put(key, []byte("1")))
+	_, err = t2.Get(key)
+	checkErr(t, err)
+	checkErr(t, t2.Put(key, []byte("2")))
+
+	checkErr(t, t1.Commit())
+	if err := t2.Commit(); !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected a conflict committing a transaction reading a key written since, got %v", err)
+	}
+	v, err := d.Get(key)
+	checkErr(t, err)
+	if string(v) != "1" {
+		t.Fatalf("expected the value of the committed transaction, got %s", v)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	d := New()
+	key := ds.NewKey("/k")
+	checkErr(t, d.Put(key, []byte("0")))
+
+	txn, err := d.NewTransaction(true)
+	checkErr(t, err)
+	checkErr(t, d.Put(key, []byte("1")))
+	checkErr(t, d.Put(key, []byte("2")))
+	if len(d.keys[key]) != 3 {
+		t.Fatalf("expected the versions read by the open transaction to be kept, got %d", len(d.keys[key]))
+	}
+	v, err := txn.Get(key)
+	checkErr(t, err)
+	if string(v) != "0" {
+		t.Fatalf("expected the value as of when the transaction began, got %s", v)
+	}
+	txn.Discard()
+	if len(d.keys[key]) != 1 {
+		t.Fatalf("expected the versions no longer read to be pruned, got %d", len(d.keys[key]))
+	}
+
+	checkErr(t, d.Delete(key))
+	if _, ok := d.keys[key]; ok {
+		t.Fatal("expected the deleted key to be pruned")
+	}
+}
+
+func checkErr(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
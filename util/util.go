@@ -46,15 +46,24 @@ var (
 
 // NewBadgerDatastore returns a badger based datastore.
 func NewBadgerDatastore(dirPath, name string, lowMem bool) (kt.TxnDatastoreExtended, error) {
-	path := filepath.Join(dirPath, name)
-	if err := os.MkdirAll(path, os.ModePerm); err != nil {
-		return nil, err
-	}
 	opts := badger.DefaultOptions
 	if lowMem {
 		opts.TableLoadingMode = options.FileIO
 	}
-	return badger.NewDatastore(path, &opts)
+	return OpenBadgerDatastore(filepath.Join(dirPath, name), &opts)
+}
+
+// OpenBadgerDatastore returns a badger based datastore at path, which
+// collects its garbage with GC.
+func OpenBadgerDatastore(path string, opts *badger.Options) (*BadgerDatastore, error) {
+	if err := os.MkdirAll(path, os.ModePerm); err != nil {
+		return nil, err
+	}
+	store, err := badger.NewDatastore(path, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &BadgerDatastore{Datastore: store, path: path}, nil
 }
 
 // SetupDefaultLoggingConfig sets up a standard logging configuration.